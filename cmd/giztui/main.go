@@ -8,9 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ajramos/giztui/internal/calendar"
 	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/demo"
 	"github.com/ajramos/giztui/internal/gmail"
 	"github.com/ajramos/giztui/internal/llm"
 	"github.com/ajramos/giztui/internal/services"
@@ -26,6 +28,8 @@ func main() {
 	setupFlag := flag.Bool("setup", false, "Run interactive setup wizard")
 	versionFlag := flag.Bool("version", false, "Show version information and exit")
 	migrateConfigFlag := flag.Bool("migrate-config", false, "Add missing default options to the config file and exit")
+	demoFlag := flag.Bool("demo", false, "Run against a built-in fake mailbox (no credentials or network needed)")
+	demoMailboxFlag := flag.String("demo-mailbox", "", "Demo mailbox: built-in name or fixture JSON path (implies --demo)")
 
 	// Override flag usage text to show clean, simple usage
 	flag.Usage = func() {
@@ -36,13 +40,16 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s                        # Run with default configuration\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --setup                # Run interactive setup wizard\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --version              # Show version information\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s --config custom.json   # Use custom configuration\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --config custom.json   # Use custom configuration\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --demo                 # Try it with a fake mailbox, no Google account needed\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		fmt.Fprintf(os.Stderr, "  --config string\n        %s\n", "Path to JSON configuration file (default: ~/.config/giztui/config.json)")
 		fmt.Fprintf(os.Stderr, "  --credentials string\n        %s\n", "Path to OAuth client credentials JSON (default: ~/.config/giztui/credentials.json)")
 		fmt.Fprintf(os.Stderr, "  --setup\n        %s\n", "Run interactive setup wizard")
 		fmt.Fprintf(os.Stderr, "  --version\n        %s\n", "Show version information and exit")
-		fmt.Fprintf(os.Stderr, "  --migrate-config\n        %s\n\n", "Add missing default options to the config file and exit")
+		fmt.Fprintf(os.Stderr, "  --migrate-config\n        %s\n", "Add missing default options to the config file and exit")
+		fmt.Fprintf(os.Stderr, "  --demo\n        %s\n", "Run against a built-in fake mailbox (no credentials or network needed)")
+		fmt.Fprintf(os.Stderr, "  --demo-mailbox string\n        %s\n\n", "Demo mailbox: "+strings.Join(demo.MailboxNames(), ", ")+", or a fixture JSON path (implies --demo)")
 		fmt.Fprintf(os.Stderr, "Environment Variables:\n")
 		fmt.Fprintf(os.Stderr, "  GMAIL_TUI_CONFIG      Override default config file path\n")
 		fmt.Fprintf(os.Stderr, "  GMAIL_TUI_CREDENTIALS Override default credentials file path\n")
@@ -94,6 +101,12 @@ func main() {
 		cfg = config.DefaultConfig()
 	}
 
+	// Demo mode: in-memory Gmail, skipping accounts and OAuth entirely
+	if *demoFlag || *demoMailboxFlag != "" {
+		runDemo(cfg, *demoMailboxFlag)
+		return
+	}
+
 	// Initialize Gmail service using multi-account logic
 	ctx := context.Background()
 
@@ -338,13 +351,12 @@ func main() {
 		}
 	}
 
-	service, err := auth.NewGmailService(ctx, credPath, tokenPath,
-		"https://www.googleapis.com/auth/gmail.readonly",
-		"https://www.googleapis.com/auth/gmail.send",
-		"https://www.googleapis.com/auth/gmail.modify",
-		"https://www.googleapis.com/auth/gmail.compose",
-		"https://www.googleapis.com/auth/calendar.events",
-	)
+	// The LLM provider (Bedrock loads the AWS configuration, others build HTTP clients) starts
+	// alongside Gmail auth instead of after it
+	llmReady := make(chan llm.Provider, 1)
+	go func() { llmReady <- newLLMProvider(cfg, bootLogf(logger)) }()
+
+	service, err := auth.NewGmailService(ctx, credPath, tokenPath, auth.ScopesFor(cfg.Access.SignInFeatures()...)...)
 	if err != nil {
 		if logger != nil {
 			logger.Printf("❌ Could not initialize Gmail service: %v", err)
@@ -372,52 +384,123 @@ func main() {
 		}
 	}
 
-	// Initialize Calendar service (Calendar-only RSVP)
+	// Initialize Calendar service (Calendar-only RSVP). After a successful Gmail auth the token is
+	// fresh, so Calendar attaches in the background; in limited mode it may still need the
+	// browser consent flow and runs before the UI takes over the terminal.
 	var calClient *calendar.Client
-	if calSvc, err := auth.NewCalendarService(ctx, credPath, tokenPath,
-		"https://www.googleapis.com/auth/calendar.events",
-	); err == nil && calSvc != nil {
-		calClient = calendar.NewClient(calSvc)
-	} else if err != nil {
-		log.Printf("Warning: could not initialize Calendar service: %v", err)
+	if service == nil {
+		calClient = newCalendarClient(ctx, cfg, credPath, tokenPath, log.Printf)
 	}
 
-	// All LLM configuration is now handled via config file only
-
-	// Initialize LLM provider
+	// Pass the provider when it is already initialized; otherwise it attaches when ready
 	var llmProvider llm.Provider
-	if cfg.LLM.Enabled {
-		model := cfg.LLM.Model
-		timeout := cfg.GetLLMTimeout()
-
-		if model != "" {
-			providerName := cfg.LLM.Provider
-			if providerName == "" {
-				providerName = "ollama"
-			}
+	llmPending := true
+	select {
+	case llmProvider = <-llmReady:
+		llmPending = false
+	default:
+	}
+
+	// Create and run TUI (database management is now handled internally)
+	// Pass the logger and accountService to avoid duplicate initialization
+	app := tui.NewApp(gmailClient, calClient, llmProvider, cfg, logger, accountService)
+	if llmPending {
+		go func() { app.AttachLLM(<-llmReady) }()
+	}
+	if service != nil {
+		go func() { app.AttachCalendar(newCalendarClient(ctx, cfg, credPath, tokenPath, bootLogf(logger))) }()
+	}
+	if err := app.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-			arg := cfg.LLM.Endpoint
+// bootLogf returns a printf for initialization that may finish after the UI owns the terminal:
+// it writes to the file logger and drops the message without one
+func bootLogf(logger *log.Logger) func(format string, args ...interface{}) {
+	if logger == nil {
+		return func(string, ...interface{}) {}
+	}
+	return logger.Printf
+}
 
-			if providerName == "bedrock" {
-				region := cfg.LLM.Region
-				if region == "" {
-					if env := os.Getenv("AWS_REGION"); env != "" {
-						region = env
-					}
-				}
-				arg = region
-			}
-			var err error
-			llmProvider, err = llm.NewProviderFromConfig(providerName, arg, model, timeout, cfg.LLM.APIKey)
-			if err != nil {
-				log.Printf("Warning: could not initialize LLM provider (%s): %v", providerName, err)
+// newCalendarClient initializes the Calendar client; nil when Calendar is unavailable. It signs
+// in with the access mode's scopes: when those leave out Calendar, its permission is asked for
+// on the first RSVP.
+func newCalendarClient(ctx context.Context, cfg *config.Config, credPath, tokenPath string, logf func(format string, args ...interface{})) *calendar.Client {
+	calSvc, err := auth.NewCalendarService(ctx, credPath, tokenPath, auth.ScopesFor(cfg.Access.SignInFeatures()...)...)
+	if err != nil {
+		logf("Warning: could not initialize Calendar service: %v", err)
+		return nil
+	}
+	if calSvc == nil {
+		return nil
+	}
+	return calendar.NewClient(calSvc)
+}
+
+// newLLMProvider initializes the configured LLM provider; nil when disabled or misconfigured
+func newLLMProvider(cfg *config.Config, logf func(format string, args ...interface{})) llm.Provider {
+	if !cfg.LLM.Enabled || cfg.LLM.Model == "" {
+		return nil
+	}
+	providerName := cfg.LLM.Provider
+	if providerName == "" {
+		providerName = "ollama"
+	}
+	if cfg.LLM.LocalOnly && !llm.IsLocalProvider(providerName, cfg.LLM.Endpoint) {
+		logf("llm.local_only: provider %s (%s) is not local — AI disabled", providerName, cfg.LLM.Endpoint)
+		return nil
+	}
+
+	arg := cfg.LLM.Endpoint
+	if providerName == "bedrock" {
+		region := cfg.LLM.Region
+		if region == "" {
+			if env := os.Getenv("AWS_REGION"); env != "" {
+				region = env
 			}
 		}
+		arg = region
 	}
+	llmProvider, err := llm.NewProviderFromConfig(providerName, arg, cfg.LLM.Model, cfg.GetLLMTimeout(), cfg.LLM.APIKey)
+	if err != nil {
+		logf("Warning: could not initialize LLM provider (%s): %v", providerName, err)
+		return nil
+	}
+	return llmProvider
+}
 
-	// Create and run TUI (database management is now handled internally)
-	// Pass the logger and accountService to avoid duplicate initialization
-	app := tui.NewApp(gmailClient, calClient, llmProvider, cfg, logger, accountService)
+// runDemo starts the TUI against an in-memory fake Gmail seeded with a fixture mailbox. Configured
+// accounts are ignored so nothing touches a real mailbox; the calendar is unavailable.
+func runDemo(cfg *config.Config, mailbox string) {
+	mb, err := demo.LoadMailbox(mailbox)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Demo mode: %v\n", err)
+		os.Exit(1)
+	}
+	client, _, err := demo.NewClient(context.Background(), mb, time.Now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Demo mode: %v\n", err)
+		os.Exit(1)
+	}
+
+	// A single demo account replaces the configured ones, so the account picker and the local
+	// database never point at a real mailbox (the database is keyed by the demo address)
+	demoCfg := *cfg
+	demoCfg.Accounts = []config.AccountConfig{{ID: "demo", DisplayName: "Demo · " + mb.Email, Active: true}}
+	demoCfg.DemoMode = true
+
+	logger := createFileLogger()
+	serviceLogger := logger
+	if serviceLogger == nil {
+		serviceLogger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	serviceLogger.Printf("🎭 Demo mode: mailbox %s (%d fixture messages)", mb.Email, len(mb.Messages))
+	accountService := services.NewAccountService(&demoCfg, serviceLogger)
+
+	app := tui.NewApp(client, nil, newLLMProvider(&demoCfg, log.Printf), &demoCfg, logger, accountService)
 	if err := app.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
@@ -36,6 +36,9 @@ type LLMConfig struct {
 	ReplyTemplate     string `json:"reply_template"`
 	LabelTemplate     string `json:"label_template"`
 	TouchUpTemplate   string `json:"touch_up_template"`
+	// Summary preset templates by preset name (oneline, bullets, actions, eli5, explain); a preset
+	// without an entry uses templates/ai/summarize_<preset>.md
+	SummaryPresetTemplates map[string]string `json:"summary_preset_templates,omitempty"`
 
 	// Inline prompt overrides (optional - takes precedence over files)
 	SummarizePrompt string `json:"summarize_prompt,omitempty"`
@@ -43,6 +46,30 @@ type LLMConfig struct {
 	LabelPrompt     string `json:"label_prompt,omitempty"`
 	// Touch-up prompt for LLM whitespace/line-break adjustments (no semantic changes)
 	TouchUpPrompt string `json:"touch_up_prompt,omitempty"`
+
+	// Privacy masks personal data in prompts sent to untrusted providers
+	Privacy LLMPrivacyConfig `json:"privacy"`
+	// LocalOnly refuses any provider that does not run on this machine (Ollama on a loopback
+	// endpoint); AI features are disabled, visibly, when the configured provider is remote
+	LocalOnly bool `json:"local_only"`
+	// Language the explain style writes in, e.g. "Spanish" (default English)
+	Language string `json:"language,omitempty"`
+}
+
+// LLMPrivacyConfig controls the redaction applied to every prompt before it reaches a provider
+// that is not trusted. :privacy previews what the current message looks like once redacted.
+type LLMPrivacyConfig struct {
+	// Enabled turns the guard on (default false)
+	Enabled bool `json:"enabled"`
+	// RedactEmails, RedactPhones and RedactCards mask addresses, phone numbers and card numbers
+	// (default true)
+	RedactEmails bool `json:"redact_emails"`
+	RedactPhones bool `json:"redact_phones"`
+	RedactCards  bool `json:"redact_cards"`
+	// Patterns are extra regular expressions masked as [REDACTED] (e.g. customer or ticket IDs)
+	Patterns []string `json:"patterns,omitempty"`
+	// TrustedProviders receive prompts unredacted; unset means only the local "ollama"
+	TrustedProviders []string `json:"trusted_providers,omitempty"`
 }
 
 // ThemeConfig holds theme-related configuration
@@ -58,6 +85,9 @@ type AccountConfig struct {
 	Credentials string `json:"credentials"`  // path to credentials.json for this account
 	Token       string `json:"token"`        // path to token.json for this account
 	Active      bool   `json:"active"`       // whether this is the currently active account
+	// InternalDomains are the organization's domains (subdomains included); sending to anyone
+	// else from this account asks for confirmation first
+	InternalDomains []string `json:"internal_domains,omitempty"`
 }
 
 // Config holds all configuration for the GizTUI application
@@ -65,10 +95,17 @@ type Config struct {
 	// Multi-account support
 	Accounts []AccountConfig `json:"accounts,omitempty"`
 
+	// DemoMode is set by --demo (fake in-memory mailbox); never persisted, and it keeps the
+	// session's demo account out of the config file
+	DemoMode bool `json:"-"`
+
 	// Legacy single-account support (for backward compatibility)
 	Credentials string `json:"credentials,omitempty"`
 	Token       string `json:"token,omitempty"`
 
+	// Google permissions requested at sign-in; the others are asked for when first needed
+	Access AccessConfig `json:"access"`
+
 	// LLM configuration (unified)
 	LLM LLMConfig `json:"llm"`
 
@@ -113,6 +150,55 @@ type Config struct {
 
 	// Display configuration
 	Display DisplayConfig `json:"display"`
+
+	// Link picker configuration
+	Links LinksConfig `json:"links"`
+
+	// Outgoing mail options
+	Compose ComposeConfig `json:"compose"`
+
+	// Local archive (messages moved out of Gmail but kept on disk)
+	LocalArchive LocalArchiveConfig `json:"local_archive"`
+
+	// Quiet hours: new-mail notifications are held while auto-refresh keeps syncing
+	DoNotDisturb DoNotDisturbConfig `json:"do_not_disturb"`
+
+	// HTML preview opens a message's HTML part in a browser
+	HTMLPreview HTMLPreviewConfig `json:"html_preview"`
+
+	// Time machine: daily inbox snapshots for browsing the mailbox as of a past date
+	TimeMachine TimeMachineConfig `json:"time_machine"`
+
+	// Alert groups: repeated notification emails collapsed into one list row per alert
+	AlertGroups AlertGroupsConfig `json:"alert_groups"`
+
+	// Google Chat, Meet and Drive comment notification emails: grouped, archived or left alone
+	WorkspaceNotifications WorkspaceNotificationsConfig `json:"workspace_notifications"`
+
+	// Which labels pickers and the message list show
+	LabelVisibility LabelVisibilityConfig `json:"label_visibility"`
+
+	// Unread/total counters shown in the message list title
+	UnreadCounters UnreadCountersConfig `json:"unread_counters"`
+
+	// Pace of :merge (templated messages sent to each row of a CSV)
+	MailMerge MailMergeConfig `json:"mail_merge"`
+
+	// Language and default time of quick dates such as "tomorrow 9am" or "next mon"
+	QuickDates QuickDatesConfig `json:"quick_dates"`
+
+	// Suggestions of what is usually done to a sender's messages, learned from a local action log
+	UsualActions UsualActionsConfig `json:"usual_actions"`
+
+	// Commands run in order after the first inbox load, e.g. ["query today", "threads", "expand-all"]
+	StartupActions []string `json:"startup_actions,omitempty"`
+
+	// Commands of your own: a name mapped to command lines separated by ';', with {{1}}, {{2}}…
+	// for its arguments and {{args}} for all of them, e.g. "work": "search label:work is:unread; threads"
+	CustomCommands map[string]string `json:"custom_commands,omitempty"`
+
+	// Team prompts and saved queries merged read-only from a git repository or directory
+	SharedPack SharedPackConfig `json:"shared_pack"`
 }
 
 // SlackConfig contains all Slack integration settings
@@ -133,6 +219,30 @@ type SlackConfig struct {
 	// Available variables: {{body}}, {{subject}}, {{from}}, {{to}}, {{cc}}, {{bcc}},
 	// {{date}}, {{reply-to}}, {{message-id}}, {{in-reply-to}}, {{references}}, {{max_words}}
 	SummaryPrompt string `json:"summary_prompt,omitempty"`
+
+	// Routes post new mail matching a label or query to a channel as auto-refresh finds it
+	Routes []SlackRoute `json:"routes,omitempty"`
+}
+
+// SlackRoute sends new messages that match it to a Slack channel. With both Label and Query set,
+// a message must match both.
+type SlackRoute struct {
+	// Name identifies the route in notifications and the log
+	Name string `json:"name"`
+
+	// Label is the name of a label the message must carry (e.g. "Clients/Acme")
+	Label string `json:"label,omitempty"`
+
+	// Query is a search query evaluated on the message metadata, as for smart labels
+	// (e.g. "from:alerts@example.com is:important")
+	Query string `json:"query,omitempty"`
+
+	// Channel is the ID or name of a configured channel; empty uses the default channel
+	Channel string `json:"channel,omitempty"`
+
+	// MaxPerHour caps the posts this route makes in any hour (default 20); messages over the
+	// cap are counted and reported with the next post
+	MaxPerHour int `json:"max_per_hour,omitempty"`
 }
 
 // SlackChannel defines a Slack channel configuration
@@ -179,6 +289,11 @@ type LayoutConfig struct {
 
 	// Header field display
 	MaxRecipientLines int `json:"max_recipient_lines"`
+
+	// PanelSizes is the share of the content area, in percent, each side panel takes when it opens,
+	// by panel ("labels", "ai", "slack", "rsvp", "prompts", ...). Set by the panel_wider and
+	// panel_narrower keys; panels without an entry split the area evenly.
+	PanelSizes map[string]int `json:"panel_sizes,omitempty"`
 }
 
 // LayoutBreakpoint defines minimum dimensions for layout types
@@ -197,6 +312,9 @@ type AttachmentsConfig struct {
 
 	// MaxDownloadSize limits the maximum size for automatic downloads (in MB)
 	MaxDownloadSize int64 `json:"max_download_size"`
+
+	// PreviewMaxKB is the largest text attachment shown inline by the picker's preview (default 256)
+	PreviewMaxKB int `json:"preview_max_kb,omitempty"`
 }
 
 // ThreadingConfig defines message threading behavior and preferences
@@ -383,6 +501,9 @@ type KeyBindings struct {
 	// Undo functionality
 	Undo string `json:"undo"` // Undo last action
 
+	// Apply the actions usually done to the sender's messages (usual_actions)
+	UsualActions string `json:"usual_actions"`
+
 	// Prompt Configurator (Feature 2)
 	PromptRegenerate string `json:"prompt_regenerate"` // Regenerate prompt via LLM in configurator
 	SavePrompt       string `json:"save_prompt"`       // Save active prompt to library
@@ -391,6 +512,7 @@ type KeyBindings struct {
 	// Search panel
 	SearchToggleMode string `json:"search_toggle_mode"` // Toggle remote (Gmail) ↔ local filter in the search box
 	SearchAdvanced   string `json:"search_advanced"`    // Open the advanced search modal
+	SearchCheatSheet string `json:"search_cheatsheet"`  // Toggle the search operators cheat-sheet overlay
 
 	// Prompt pickers
 	PromptPreview string `json:"prompt_preview"` // Preview the selected prompt in a picker
@@ -404,9 +526,12 @@ type KeyBindings struct {
 	SavedQueryDel string `json:"saved_query_delete"` // Saved-queries picker: delete the selected query
 
 	// Picker / panel actions
-	AttachmentSave string `json:"attachment_save"` // Attachments picker: save the selected attachment
-	LinkCopy       string `json:"link_copy"`       // Links picker: copy the selected link
-	ComposeSend    string `json:"compose_send"`    // Composition: send the message
+	AttachmentSave    string `json:"attachment_save"`    // Attachments picker: save the selected attachment
+	AttachmentPreview string `json:"attachment_preview"` // Attachments picker: preview a text attachment inline
+	LinkCopy          string `json:"link_copy"`          // Links picker: copy the selected link
+	ComposeSend       string `json:"compose_send"`       // Composition: send the message
+	PanelWider        string `json:"panel_wider"`        // Widen the open side panel (remembered per panel)
+	PanelNarrower     string `json:"panel_narrower"`     // Narrow the open side panel (remembered per panel)
 
 	// Validation settings
 	ValidateShortcuts bool `json:"validate_shortcuts"` // Enable shortcut conflict validation (default: true)
@@ -416,6 +541,32 @@ type KeyBindings struct {
 type PerformanceConfig struct {
 	// Preloading controls background message preloading
 	Preloading PreloadingConfig `json:"preloading"`
+
+	// Caches bounds the in-memory message caches
+	Caches CacheLimitsConfig `json:"caches"`
+
+	// Quota drives the bulk job planner
+	Quota QuotaConfig `json:"quota"`
+}
+
+// QuotaConfig defines the Gmail quota budget used to plan large bulk operations. Jobs that
+// would eat into the reserve are offered to run across several one-minute windows or shrunk.
+type QuotaConfig struct {
+	// UnitsPerMinute is the per-user Gmail quota (0 = Gmail's 15,000 units/minute)
+	UnitsPerMinute int `json:"units_per_minute"`
+
+	// ReservePercent keeps this share of each window for interactive actions
+	ReservePercent int `json:"reserve_percent"`
+}
+
+// CacheLimitsConfig defines the in-memory cache limits. Like the preloading limits they are
+// global: every account gets the same budget, and the caches are emptied on account switch.
+type CacheLimitsConfig struct {
+	// MessageEntries limits how many opened messages are kept in memory (0 = default)
+	MessageEntries int `json:"message_entries"`
+
+	// RenderEntries limits how many rendered message bodies are kept in memory (0 = default)
+	RenderEntries int `json:"render_entries"`
 }
 
 // PreloadingConfig defines background message preloading settings
@@ -470,6 +621,27 @@ type PreloadingLimitsConfig struct {
 type DisplayConfig struct {
 	// ShowMessageNumbers enables message number column in list view
 	ShowMessageNumbers bool `json:"show_message_numbers"`
+
+	// ShowListFooter shows a stats row under the message list (loaded/estimated, unread, selected, query)
+	ShowListFooter bool `json:"show_list_footer"`
+
+	// ShowKeyHints shows a one-line bar of the most relevant keys for the focused context
+	ShowKeyHints bool `json:"show_key_hints"`
+
+	// ShowContentMinimap shows a scroll indicator with search match marks beside long messages
+	ShowContentMinimap bool `json:"show_content_minimap"`
+
+	// RowFormat replaces the flat list columns with a template, e.g.
+	// "{flags} {date:>6} {from:20} {subject:*} {labels:.24}". Empty keeps the columns.
+	RowFormat string `json:"row_format,omitempty"`
+
+	// StatusVerbosity chooses which status bar messages appear: "errors" (errors and warnings),
+	// "normal" or "verbose" (adds diagnostics such as cache hits)
+	StatusVerbosity string `json:"status_verbosity"`
+
+	// Density sets how much the list and reader show: "comfortable" (padding, full label chips,
+	// snippets, full headers) or "compact" (short chips, no snippets, Subject/From/Date headers)
+	Density string `json:"density"`
 }
 
 // RenderingConfig controls email body rendering.
@@ -482,6 +654,345 @@ type RenderingConfig struct {
 	DropTrackingImages bool `json:"drop_tracking_images"`
 }
 
+// LinksConfig controls the link picker.
+type LinksConfig struct {
+	// PreviewEnabled fetches each link's page title and description in the background.
+	// Off by default: fetching contacts the linked sites (following redirects), which the
+	// sender of a tracked link can observe.
+	PreviewEnabled bool `json:"preview_enabled"`
+	// PreviewTimeout bounds each fetch as a Go duration string (default "5s").
+	PreviewTimeout string `json:"preview_timeout"`
+	// PreviewMaxConcurrent limits how many links are fetched at once (default 4).
+	PreviewMaxConcurrent int `json:"preview_max_concurrent"`
+}
+
+// ComposeConfig controls outgoing mail.
+type ComposeConfig struct {
+	// SentLabels are applied to every sent message (label names; nested "Parent/Child" paths are
+	// created when missing).
+	SentLabels []string `json:"sent_labels,omitempty"`
+	// DomainLabels maps a recipient domain to labels applied to messages sent to it, e.g.
+	// {"acme.com": ["Clients/Acme"]}. Subdomains match too (eu.acme.com -> acme.com).
+	DomainLabels map[string][]string `json:"domain_labels,omitempty"`
+	// RecipientGroups maps a group name to its members, e.g. {"team": ["a@x.com", "b@x.com"]}.
+	// Typing the name in To/Cc/Bcc expands it to the members.
+	RecipientGroups map[string][]string `json:"recipient_groups,omitempty"`
+}
+
+// HTMLPreviewConfig controls :html, which writes a message's HTML part to a file and opens it in
+// a browser for faithful rendering.
+type HTMLPreviewConfig struct {
+	// Browser is the command that opens the file, e.g. "firefox" or "open -a Safari"; the file path
+	// is appended. Empty uses the system default (open / xdg-open).
+	Browser string `json:"browser,omitempty"`
+	// BlockImages replaces remote images with placeholders and forbids remote loads, so opening a
+	// newsletter does not reveal that it was read (default true). :html images overrides it once.
+	BlockImages bool `json:"block_images"`
+	// Dir holds the preview files; empty uses the system temp directory
+	Dir string `json:"dir,omitempty"`
+}
+
+// TimeMachineConfig controls the daily inbox snapshots behind :timemachine. A snapshot records
+// which messages were in the inbox (and unread) the first time the inbox is loaded each day.
+type TimeMachineConfig struct {
+	// Enabled takes the daily snapshots (default true); existing snapshots stay browsable when off
+	Enabled bool `json:"enabled"`
+	// MaxMessages caps how many inbox messages a snapshot records, newest first (default 2000)
+	MaxMessages int `json:"max_messages,omitempty"`
+	// RetentionDays drops older snapshots (default 365)
+	RetentionDays int `json:"retention_days,omitempty"`
+}
+
+// UnreadCountersConfig controls the unread and total counts of the inbox and other labels shown
+// in the message list title. They follow Gmail's history, so changes made elsewhere show up too.
+type UnreadCountersConfig struct {
+	// Enabled keeps the counters current (default true)
+	Enabled bool `json:"enabled"`
+	// Labels counted besides INBOX and the label being viewed, by name or ID, e.g. ["STARRED", "Projects"]
+	Labels []string `json:"labels,omitempty"`
+	// Interval between checks for changes, as a Go duration (default "30s", minimum "10s")
+	Interval string `json:"interval,omitempty"`
+}
+
+const (
+	unreadCountersDefaultInterval = 30 * time.Second
+	unreadCountersMinInterval     = 10 * time.Second
+)
+
+// ResolvedInterval parses Interval, falling back to the default and clamping to the minimum
+func (u UnreadCountersConfig) ResolvedInterval() time.Duration {
+	d, err := time.ParseDuration(u.Interval)
+	if err != nil || d <= 0 {
+		return unreadCountersDefaultInterval
+	}
+	return max(d, unreadCountersMinInterval)
+}
+
+// MailMergeConfig controls how fast :merge sends its messages, one at a time
+type MailMergeConfig struct {
+	// Interval between two messages, as a Go duration (default "3s", minimum "1s")
+	Interval string `json:"interval,omitempty"`
+}
+
+// UsualActionsConfig controls the "do usual" suggestions: when most of a sender's latest messages
+// got the same actions (say, label Receipts then archive), opening the next one suggests them in
+// the status bar and the usual_actions key applies them
+type UsualActionsConfig struct {
+	Enabled bool `json:"enabled"`
+	// MinRepeats is how many of the sender's last 5 acted-on messages must have had the same
+	// actions (default 3)
+	MinRepeats int `json:"min_repeats,omitempty"`
+}
+
+// Access modes (access.mode)
+const (
+	AccessModeFull     = "full"
+	AccessModeReadonly = "readonly"
+	AccessModeCustom   = "custom"
+)
+
+// AccessConfig chooses which Google permissions (OAuth scopes) sign-in asks for. A feature whose
+// permission was not asked for requests it the first time it is used.
+type AccessConfig struct {
+	// Mode is "full" (every feature at sign-in, the default), "readonly" (reading only) or
+	// "custom" (the features listed in Features)
+	Mode string `json:"mode,omitempty"`
+	// Features asked for at sign-in in custom mode: "read", "modify", "send", "drafts", "calendar"
+	Features []string `json:"features,omitempty"`
+}
+
+// SignInFeatures returns the features whose permissions sign-in asks for. Reading is always
+// among them.
+func (a AccessConfig) SignInFeatures() []string {
+	switch strings.ToLower(strings.TrimSpace(a.Mode)) {
+	case AccessModeReadonly:
+		return []string{"read"}
+	case AccessModeCustom:
+		return append([]string{"read"}, a.Features...)
+	default:
+		return []string{"read", "modify", "send", "drafts", "calendar"}
+	}
+}
+
+// QuickDatesConfig controls how quick dates ("tomorrow 9am", "next mon", "in 2h") are read
+type QuickDatesConfig struct {
+	// Locale adds weekday, month and relative words in another language: "es", "fr", "de" or
+	// "pt"; English is always understood (default "en")
+	Locale string `json:"locale,omitempty"`
+	// DefaultTime is the time of day of a date given without one, "HH:MM" (default "09:00")
+	DefaultTime string `json:"default_time,omitempty"`
+}
+
+// ResolvedDefaultTime returns the hour and minute of DefaultTime, falling back to 09:00
+func (q QuickDatesConfig) ResolvedDefaultTime() (hour, minute int) {
+	m, ok := parseClockMinutes(q.DefaultTime)
+	if !ok {
+		return 9, 0
+	}
+	return m / 60, m % 60
+}
+
+const (
+	mailMergeDefaultInterval = 3 * time.Second
+	mailMergeMinInterval     = time.Second
+)
+
+// ResolvedInterval parses Interval, falling back to the default and clamping to the minimum
+func (m MailMergeConfig) ResolvedInterval() time.Duration {
+	d, err := time.ParseDuration(m.Interval)
+	if err != nil || d <= 0 {
+		return mailMergeDefaultInterval
+	}
+	return max(d, mailMergeMinInterval)
+}
+
+// LabelVisibilityConfig controls which labels appear in the label pickers and in the message
+// list's label column. Hidden labels can still be applied by name (:label add).
+type LabelVisibilityConfig struct {
+	// FollowGmail honors Gmail's "show in label list" and "show in message list" settings for
+	// user labels (default true)
+	FollowGmail bool `json:"follow_gmail"`
+	// Show and Hide override Gmail for the listed label names (case-insensitive), everywhere
+	Show []string `json:"show,omitempty"`
+	Hide []string `json:"hide,omitempty"`
+}
+
+// AlertGroupsConfig groups repeated notification emails (CI runs, monitoring alerts) behind
+// :alerts. Each rule extracts an alert key from the subject; messages with the same rule and key
+// are shown as one row with a count and the latest occurrence.
+type AlertGroupsConfig struct {
+	Rules []AlertGroupRule `json:"rules,omitempty"`
+}
+
+// AlertGroupRule matches one family of notification emails
+type AlertGroupRule struct {
+	// Name labels the group in the list, e.g. "CI"
+	Name string `json:"name"`
+	// Subject is a regular expression; its first capture group (or the whole match) is the alert key
+	Subject string `json:"subject"`
+	// From optionally limits the rule to senders containing this text (case-insensitive)
+	From string `json:"from,omitempty"`
+}
+
+// Actions for Google Workspace notification emails
+const (
+	WorkspaceActionGroup   = "group"   // collapse them under :alerts
+	WorkspaceActionArchive = "archive" // also archive them as they arrive
+	WorkspaceActionOff     = "off"     // treat them as ordinary mail
+)
+
+// WorkspaceNotificationsConfig sets what happens to each kind of Google Workspace notification
+// email: Chat messages and mentions, Meet recordings and notes, and Drive/Docs comments. Each is
+// "group" (default), "archive" or "off".
+type WorkspaceNotificationsConfig struct {
+	Chat  string `json:"chat,omitempty"`
+	Meet  string `json:"meet,omitempty"`
+	Drive string `json:"drive,omitempty"`
+}
+
+// Action returns the action of a kind ("chat", "meet" or "drive"); empty or unknown values group
+func (w WorkspaceNotificationsConfig) Action(kind string) string {
+	var v string
+	switch kind {
+	case "chat":
+		v = w.Chat
+	case "meet":
+		v = w.Meet
+	case "drive":
+		v = w.Drive
+	}
+	switch v = strings.ToLower(strings.TrimSpace(v)); v {
+	case WorkspaceActionArchive, WorkspaceActionOff:
+		return v
+	}
+	return WorkspaceActionGroup
+}
+
+// LocalArchiveConfig controls the local archive: messages exported to an mbox file and indexed
+// for search before they are moved to Gmail's trash.
+type LocalArchiveConfig struct {
+	// Dir holds one mbox file per account; empty uses ~/.config/giztui/archive
+	Dir string `json:"dir,omitempty"`
+}
+
+// ResolvedDir returns the configured archive directory or the default one.
+func (c LocalArchiveConfig) ResolvedDir() string {
+	if strings.TrimSpace(c.Dir) != "" {
+		return c.Dir
+	}
+	return DefaultLocalArchiveDir()
+}
+
+// SharedPackConfig points the prompt library and saved queries at a team pack: prompts/*.md
+// (the prompt file format) and queries.json in a directory, or a git repository cloned into it
+// and pulled on startup.
+type SharedPackConfig struct {
+	// Repo is the git URL to clone and pull; empty reads Dir as a plain directory
+	Repo string `json:"repo,omitempty"`
+	// Dir holds the pack; empty uses ~/.config/giztui/shared-pack
+	Dir string `json:"dir,omitempty"`
+}
+
+// Enabled reports whether a pack is configured
+func (c SharedPackConfig) Enabled() bool {
+	return strings.TrimSpace(c.Repo) != "" || strings.TrimSpace(c.Dir) != ""
+}
+
+// ResolvedDir returns the configured pack directory or the default one.
+func (c SharedPackConfig) ResolvedDir() string {
+	if strings.TrimSpace(c.Dir) != "" {
+		return c.Dir
+	}
+	return DefaultSharedPackDir()
+}
+
+// DoNotDisturbConfig defines quiet hours. While one of the windows is active, new-mail banners and
+// notifications are suppressed; auto-refresh still syncs the list.
+type DoNotDisturbConfig struct {
+	Windows []QuietWindow `json:"windows,omitempty"`
+}
+
+// QuietWindow is a daily time range, e.g. {"start": "22:00", "end": "07:00"}. An end before the
+// start runs past midnight; equal times cover the whole day.
+type QuietWindow struct {
+	// Days limits the window to weekdays ("mon".."sun") on which it starts; empty means every day
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// Active reports whether t falls inside one of the quiet windows. Windows with invalid times are
+// ignored.
+func (c DoNotDisturbConfig) Active(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range c.Windows {
+		start, okStart := parseClockMinutes(w.Start)
+		end, okEnd := parseClockMinutes(w.End)
+		if !okStart || !okEnd {
+			continue
+		}
+		today, yesterday := w.onDay(t.Weekday()), w.onDay((t.Weekday()+6)%7)
+		switch {
+		case start == end:
+			if today {
+				return true
+			}
+		case start < end:
+			if today && minute >= start && minute < end {
+				return true
+			}
+		default: // wraps past midnight
+			if (today && minute >= start) || (yesterday && minute < end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w QuietWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	name := strings.ToLower(d.String()[:3])
+	for _, day := range w.Days {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(day)), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClockMinutes parses "HH:MM" into minutes after midnight
+func parseClockMinutes(s string) (int, bool) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// linkPreviewDefaultTimeout is used when PreviewTimeout is empty or unparseable.
+const linkPreviewDefaultTimeout = 5 * time.Second
+
+// ResolvedPreviewTimeout parses PreviewTimeout, falling back to the default.
+func (l LinksConfig) ResolvedPreviewTimeout() time.Duration {
+	d, err := time.ParseDuration(l.PreviewTimeout)
+	if err != nil || d <= 0 {
+		return linkPreviewDefaultTimeout
+	}
+	return d
+}
+
+// DefaultLinksConfig returns the default link picker configuration.
+func DefaultLinksConfig() LinksConfig {
+	return LinksConfig{
+		PreviewEnabled:       false,
+		PreviewTimeout:       "5s",
+		PreviewMaxConcurrent: 4,
+	}
+}
+
 // DefaultRenderingConfig returns the default rendering configuration.
 func DefaultRenderingConfig() RenderingConfig {
 	return RenderingConfig{
@@ -494,19 +1005,28 @@ func DefaultRenderingConfig() RenderingConfig {
 // DefaultConfig returns a Config with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		LLM:           DefaultLLMConfig(),
-		Slack:         DefaultSlackConfig(),
-		Layout:        DefaultLayoutConfig(),
-		Keys:          DefaultKeyBindings(),
-		Theme:         DefaultThemeConfig(),
-		Rendering:     DefaultRenderingConfig(),
-		Threading:     DefaultThreadingConfig(),
-		InboxAnalyzer: DefaultInboxAnalyzerConfig(),
-		AutoRefresh:   AutoRefreshConfig{Enabled: false, Interval: "5m", SlackSummary: false, SlackSummaryLimit: 5},
-		TTS:           TTSConfig{Enabled: false, Engine: "auto"},
-		Performance:   DefaultPerformanceConfig(),
-		Display:       DefaultDisplayConfig(),
-		LogFile:       "",
+		LLM:             DefaultLLMConfig(),
+		Slack:           DefaultSlackConfig(),
+		Layout:          DefaultLayoutConfig(),
+		Keys:            DefaultKeyBindings(),
+		Theme:           DefaultThemeConfig(),
+		Rendering:       DefaultRenderingConfig(),
+		Threading:       DefaultThreadingConfig(),
+		InboxAnalyzer:   DefaultInboxAnalyzerConfig(),
+		AutoRefresh:     AutoRefreshConfig{Enabled: false, Interval: "5m", SlackSummary: false, SlackSummaryLimit: 5},
+		TTS:             TTSConfig{Enabled: false, Engine: "auto"},
+		Performance:     DefaultPerformanceConfig(),
+		Display:         DefaultDisplayConfig(),
+		Links:           DefaultLinksConfig(),
+		HTMLPreview:     HTMLPreviewConfig{BlockImages: true},
+		TimeMachine:     TimeMachineConfig{Enabled: true},
+		LabelVisibility: LabelVisibilityConfig{FollowGmail: true},
+		UnreadCounters:  UnreadCountersConfig{Enabled: true, Interval: "30s"},
+		MailMerge:       MailMergeConfig{Interval: "3s"},
+		QuickDates:      QuickDatesConfig{Locale: "en", DefaultTime: "09:00"},
+		Access:          AccessConfig{Mode: AccessModeFull},
+		UsualActions:    UsualActionsConfig{Enabled: true, MinRepeats: 3},
+		LogFile:         "",
 	}
 }
 
@@ -531,6 +1051,7 @@ func DefaultLLMConfig() LLMConfig {
 		ReplyPrompt:     "",
 		LabelPrompt:     "",
 		TouchUpPrompt:   "",
+		Privacy:         LLMPrivacyConfig{RedactEmails: true, RedactPhones: true, RedactCards: true},
 	}
 }
 
@@ -636,6 +1157,8 @@ func DefaultKeyBindings() KeyBindings {
 		// Undo functionality
 		Undo: "U", // Undo last action
 
+		UsualActions: ".", // repeat what is usually done, like vim's dot
+
 		// Prompt Configurator
 		PromptRegenerate: "ctrl+r",
 		SavePrompt:       "ctrl+s",
@@ -644,6 +1167,7 @@ func DefaultKeyBindings() KeyBindings {
 		// Search panel
 		SearchToggleMode: "ctrl+t",
 		SearchAdvanced:   "ctrl+f",
+		SearchCheatSheet: "f1",
 
 		// Prompt pickers
 		PromptPreview: "ctrl+p",
@@ -657,9 +1181,12 @@ func DefaultKeyBindings() KeyBindings {
 		SavedQueryDel: "d",
 
 		// Picker / panel actions
-		AttachmentSave: "ctrl+s",
-		LinkCopy:       "ctrl+y",
-		ComposeSend:    "ctrl+j",
+		AttachmentSave:    "ctrl+s",
+		AttachmentPreview: "ctrl+p",
+		LinkCopy:          "ctrl+y",
+		ComposeSend:       "ctrl+j",
+		PanelWider:        "ctrl+left",
+		PanelNarrower:     "ctrl+right",
 
 		// Validation settings (default: enabled for safety)
 		ValidateShortcuts: true, // Enable shortcut conflict validation by default
@@ -744,6 +1271,14 @@ func DefaultPerformanceConfig() PerformanceConfig {
 				APIQuotaReservePercent: 20, // Reserve 20% of API quota for user actions
 			},
 		},
+		Caches: CacheLimitsConfig{
+			MessageEntries: 500, // Opened messages kept per session/account
+			RenderEntries:  256, // Rendered bodies (per mode/width)
+		},
+		Quota: QuotaConfig{
+			UnitsPerMinute: 15000, // Gmail per-user limit
+			ReservePercent: 20,    // Left for opening, searching and single actions
+		},
 	}
 }
 
@@ -751,6 +1286,11 @@ func DefaultPerformanceConfig() PerformanceConfig {
 func DefaultDisplayConfig() DisplayConfig {
 	return DisplayConfig{
 		ShowMessageNumbers: false, // Off by default - users enable via config or :numbers command
+		ShowListFooter:     false, // Off by default - users enable via config or :footer command
+		ShowKeyHints:       false, // Off by default - users enable via config or :hints command
+		ShowContentMinimap: true,  // Only drawn when the message overflows the pane
+		StatusVerbosity:    "normal",
+		Density:            "comfortable",
 	}
 }
 
@@ -884,7 +1424,7 @@ func ValidateKeyboardConfig(keys KeyBindings) []string {
 		"ctrl+r": {"prompt_regenerate": true, "remember_rule": true},
 		"ctrl+s": {"save_prompt": true, "attachment_save": true},
 		"ctrl+j": {"fast_down": true, "compose_send": true},
-		"ctrl+p": {"prev_thread": true, "prompt_preview": true},
+		"ctrl+p": {"prev_thread": true, "prompt_preview": true, "attachment_preview": true},
 	}
 
 	// Check for duplicate key assignments
@@ -1064,6 +1604,24 @@ func DefaultSavedDir() string {
 	return filepath.Join(home, ".config", "giztui", "saved")
 }
 
+// DefaultLocalArchiveDir returns the default local archive directory path
+func DefaultLocalArchiveDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "giztui", "archive")
+}
+
+// DefaultSharedPackDir returns the default shared pack directory path
+func DefaultSharedPackDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "giztui", "shared-pack")
+}
+
 // DefaultLogDir returns the default log directory path
 func DefaultLogDir() string {
 	home, err := os.UserHomeDir()
@@ -1145,6 +1703,46 @@ func (c *LLMConfig) GetSummarizePrompt() string {
 	return LoadTemplate(c.SummarizeTemplate, c.SummarizePrompt, fallback)
 }
 
+// SummaryPresets are the AI summary styles besides the default one, in menu order
+var SummaryPresets = []string{"oneline", "bullets", "actions", "eli5", "explain"}
+
+// summaryPresetFallbacks are the built-in prompts of the summary presets
+var summaryPresetFallbacks = map[string]string{
+	"oneline": "Summarize the following email in one sentence of at most 25 words. Output only that sentence.\n\n{{body}}",
+	"bullets": "Summarize the following email as 3 to 6 short bullet points starting with \"- \". Keep names, dates and numbers exact. Output only the bullets.\n\n{{body}}",
+	"actions": "List only the action items in the following email, one per line starting with \"- [ ] \", with the owner and deadline when stated. If there are none, answer \"No action items.\"\n\n{{body}}",
+	"eli5":    "Explain the following email in plain, simple words, as you would to someone with no background on the topic. Keep it short and avoid jargon.\n\n{{body}}",
+	"explain": "The reader of the following email is not a native speaker of its language. Rewrite it in {{language}} using short sentences and common words, and explain idioms, formal phrases and abbreviations. Then list what the sender asks the reader to do, one per line starting with \"👉 \", and every deadline or date, one per line starting with \"📅 \". Keep names, amounts and dates exact. Do not add anything that is not in the email.\n\n{{body}}",
+}
+
+// IsSummaryPreset reports whether name is a known summary preset ("" is the default summary)
+func IsSummaryPreset(name string) bool {
+	_, ok := summaryPresetFallbacks[name]
+	return ok || name == ""
+}
+
+// GetSummaryPresetPrompt returns the prompt of a summary preset, loading from the preset's
+// template file if present; "" or an unknown preset gives the default summarize prompt
+func (c *LLMConfig) GetSummaryPresetPrompt(preset string) string {
+	fallback, ok := summaryPresetFallbacks[preset]
+	if !ok {
+		return c.GetSummarizePrompt()
+	}
+	path := c.SummaryPresetTemplates[preset]
+	if path == "" {
+		path = "templates/ai/summarize_" + preset + ".md"
+	}
+	return LoadTemplate(path, "", fallback)
+}
+
+// ResolvedLanguage is the language the explain style writes in
+func (c *LLMConfig) ResolvedLanguage() string {
+	if lang := strings.TrimSpace(c.Language); lang != "" {
+		return lang
+	}
+	return "English"
+}
+
 // GetReplyPrompt returns the reply prompt, loading from template file if needed
 func (c *LLMConfig) GetReplyPrompt() string {
 	fallback := "Write a professional and friendly reply to the following email. Keep the same language as the input.\n\n{{body}}"
@@ -207,6 +207,25 @@ func TestLLMConfig_GetPromptMethods_WithInlineOverrides(t *testing.T) {
 	assert.Equal(t, "Custom touchup: {{body}}", cfg.GetTouchUpPrompt())
 }
 
+func TestLLMConfig_GetSummaryPresetPrompt(t *testing.T) {
+	cfg := LLMConfig{SummarizePrompt: "Default: {{body}}"}
+	for _, preset := range SummaryPresets {
+		prompt := cfg.GetSummaryPresetPrompt(preset)
+		assert.Contains(t, prompt, "{{body}}", preset)
+		assert.NotEqual(t, "Default: {{body}}", prompt, preset)
+		assert.True(t, IsSummaryPreset(preset))
+	}
+	assert.Equal(t, "Default: {{body}}", cfg.GetSummaryPresetPrompt(""))
+	assert.Equal(t, "Default: {{body}}", cfg.GetSummaryPresetPrompt("haiku"))
+	assert.False(t, IsSummaryPreset("haiku"))
+
+	// A configured template file wins over the built-in prompt
+	templateFile := filepath.Join(t.TempDir(), "bullets.md")
+	assert.NoError(t, os.WriteFile(templateFile, []byte("My bullets: {{body}}\n"), 0600))
+	cfg.SummaryPresetTemplates = map[string]string{"bullets": templateFile}
+	assert.Equal(t, "My bullets: {{body}}", cfg.GetSummaryPresetPrompt("bullets"))
+}
+
 func TestSlackConfig_GetSummaryPrompt(t *testing.T) {
 	cfg := DefaultSlackConfig()
 
@@ -620,3 +639,39 @@ func TestGetSlackSummaryPrompt(t *testing.T) {
 		t.Errorf("whitespace override should fall back to default")
 	}
 }
+
+func TestDoNotDisturbActive(t *testing.T) {
+	dnd := DoNotDisturbConfig{Windows: []QuietWindow{
+		{Start: "22:00", End: "07:00"},
+		{Days: []string{"sat", "Sunday"}, Start: "00:00", End: "00:00"},
+		{Start: "bogus", End: "08:00"},
+	}}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, time.Local) // Mar 3 2025 is a Monday
+	}
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(3, 21, 59), false},
+		{at(3, 22, 0), true},
+		{at(4, 6, 59), true}, // window started Monday night
+		{at(4, 7, 0), false},
+		{at(4, 12, 0), false},
+		{at(8, 12, 0), true}, // Saturday all day
+		{at(9, 15, 0), true}, // Sunday all day
+	}
+	for _, tc := range cases {
+		if got := dnd.Active(tc.t); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.t.Format("Mon 15:04"), got, tc.want)
+		}
+	}
+	if (DoNotDisturbConfig{}).Active(at(3, 23, 0)) {
+		t.Error("no windows should never be quiet")
+	}
+
+	weekdays := DoNotDisturbConfig{Windows: []QuietWindow{{Days: []string{"fri"}, Start: "22:00", End: "07:00"}}}
+	if !weekdays.Active(at(8, 6, 0)) || weekdays.Active(at(9, 6, 0)) {
+		t.Error("a Friday night window should cover early Saturday only")
+	}
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// actionLogMaxRows is how many entries an account keeps; older ones are dropped as new ones come
+const actionLogMaxRows = 20000
+
+// ActionLogEntry is one action done to a message: archive, trash, or adding or removing a label
+type ActionLogEntry struct {
+	AccountEmail string `json:"account_email"`
+	MessageID    string `json:"message_id"`
+	Sender       string `json:"sender"`
+	Action       string `json:"action"`
+	Label        string `json:"label"` // label ID for label actions
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// ActionLogStore handles database operations for the local action log
+type ActionLogStore struct {
+	db *sql.DB
+}
+
+// NewActionLogStore creates a new action log store
+func NewActionLogStore(store *Store) *ActionLogStore {
+	return &ActionLogStore{db: store.DB()}
+}
+
+// Append adds entries to the account's log and drops the oldest beyond actionLogMaxRows
+func (s *ActionLogStore) Append(ctx context.Context, accountEmail string, entries []ActionLogEntry) error {
+	if strings.TrimSpace(accountEmail) == "" {
+		return fmt.Errorf("account_email cannot be empty")
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	for _, e := range entries {
+		if e.CreatedAt == 0 {
+			e.CreatedAt = now
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO action_log (account_email, message_id, sender, action, label, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			accountEmail, e.MessageID, strings.ToLower(strings.TrimSpace(e.Sender)), e.Action, e.Label, e.CreatedAt); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to append action log: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM action_log WHERE account_email = ? AND id <= (
+			SELECT id FROM action_log WHERE account_email = ? ORDER BY id DESC LIMIT 1 OFFSET ?)`,
+		accountEmail, accountEmail, actionLogMaxRows); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to trim action log: %w", err)
+	}
+	return tx.Commit()
+}
+
+// BySender returns up to limit of the latest entries for a sender's messages, newest first
+func (s *ActionLogStore) BySender(ctx context.Context, accountEmail, sender string, limit int) ([]ActionLogEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT account_email, message_id, sender, action, label, created_at
+		FROM action_log WHERE account_email = ? AND sender = ?
+		ORDER BY id DESC LIMIT ?`,
+		accountEmail, strings.ToLower(strings.TrimSpace(sender)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var out []ActionLogEntry
+	for rows.Next() {
+		var e ActionLogEntry
+		if err := rows.Scan(&e.AccountEmail, &e.MessageID, &e.Sender, &e.Action, &e.Label, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan action log: %w", err)
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
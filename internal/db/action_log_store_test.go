@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActionLogStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/actions.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ls := NewActionLogStore(store)
+	const acct = "user@example.com"
+
+	if err := ls.Append(ctx, acct, []ActionLogEntry{
+		{MessageID: "m1", Sender: " News@Example.com ", Action: "label", Label: "Label_1"},
+		{MessageID: "m1", Sender: "news@example.com", Action: "archive"},
+		{MessageID: "m2", Sender: "boss@example.com", Action: "trash"},
+	}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := ls.Append(ctx, "", []ActionLogEntry{{MessageID: "m3", Action: "archive"}}); err == nil {
+		t.Fatal("want error appending without an account")
+	}
+
+	got, err := ls.BySender(ctx, acct, "NEWS@example.com", 10)
+	if err != nil || len(got) != 2 || got[0].Action != "archive" || got[1].Label != "Label_1" {
+		t.Fatalf("want archive then label for news, got %+v %v", got, err)
+	}
+	if got, err := ls.BySender(ctx, "else@example.com", "news@example.com", 10); err != nil || len(got) != 0 {
+		t.Fatalf("other account sees %+v, %v", got, err)
+	}
+	if got, err := ls.BySender(ctx, acct, "news@example.com", 1); err != nil || len(got) != 1 {
+		t.Fatalf("limit 1 = %+v, %v", got, err)
+	}
+}
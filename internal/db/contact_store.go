@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Contact is an address in the local contacts index
+type Contact struct {
+	AccountEmail string `json:"account_email"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Org          string `json:"org"`
+	Phone        string `json:"phone"`
+	Source       string `json:"source"` // where it was added from, e.g. the vCard attachment's filename
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// ContactStore handles database operations for the local contacts index
+type ContactStore struct {
+	db *sql.DB
+}
+
+// NewContactStore creates a new contact store
+func NewContactStore(store *Store) *ContactStore {
+	return &ContactStore{db: store.DB()}
+}
+
+// Save adds a contact, replacing the details stored for the same address
+func (s *ContactStore) Save(ctx context.Context, c Contact) (*Contact, error) {
+	c.Email = strings.ToLower(strings.TrimSpace(c.Email))
+	if strings.TrimSpace(c.AccountEmail) == "" || c.Email == "" {
+		return nil, fmt.Errorf("account_email and email cannot be empty")
+	}
+	c.UpdatedAt = time.Now().Unix()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO contacts (account_email, email, name, org, phone, source, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_email, email) DO UPDATE SET
+			name = excluded.name,
+			org = excluded.org,
+			phone = excluded.phone,
+			source = excluded.source,
+			updated_at = excluded.updated_at`,
+		c.AccountEmail, c.Email, c.Name, c.Org, c.Phone, c.Source, c.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to save contact: %w", err)
+	}
+	return &c, nil
+}
+
+// Search returns contacts whose name, address or organization contains query (all contacts when
+// query is empty), ordered by name
+func (s *ContactStore) Search(ctx context.Context, accountEmail, query string, limit int) ([]*Contact, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	like := "%" + strings.ToLower(strings.TrimSpace(query)) + "%"
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT account_email, email, name, org, phone, source, updated_at
+		FROM contacts
+		WHERE account_email = ? AND (LOWER(name) LIKE ? OR email LIKE ? OR LOWER(org) LIKE ?)
+		ORDER BY LOWER(CASE WHEN name = '' THEN email ELSE name END), email
+		LIMIT ?`,
+		accountEmail, like, like, like, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search contacts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*Contact
+	for rows.Next() {
+		c := &Contact{}
+		if err := rows.Scan(&c.AccountEmail, &c.Email, &c.Name, &c.Org, &c.Phone, &c.Source, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes an address from the index
+func (s *ContactStore) Delete(ctx context.Context, accountEmail, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if strings.TrimSpace(accountEmail) == "" || email == "" {
+		return fmt.Errorf("account_email and email cannot be empty")
+	}
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM contacts WHERE account_email = ? AND email = ?`,
+		accountEmail, email)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s is not in the contacts index", email)
+	}
+	return nil
+}
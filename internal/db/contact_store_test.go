@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContactStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/contacts.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	cs := NewContactStore(store)
+	const acct = "user@example.com"
+
+	if _, err := cs.Save(ctx, Contact{AccountEmail: acct, Email: " Jane@Example.com ", Name: "Jane Doe", Org: "Acme"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := cs.Save(ctx, Contact{AccountEmail: acct, Email: "jane@example.com", Name: "Jane Doe", Org: "Acme Corp", Source: "jane.vcf"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := cs.Save(ctx, Contact{AccountEmail: acct, Email: "bob@example.org", Name: "Bob"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := cs.Save(ctx, Contact{AccountEmail: acct, Email: " "}); err == nil {
+		t.Fatal("want error saving a contact without an address")
+	}
+
+	all, err := cs.Search(ctx, acct, "", 0)
+	if err != nil || len(all) != 2 || all[0].Name != "Bob" || all[1].Org != "Acme Corp" || all[1].Source != "jane.vcf" {
+		t.Fatalf("want Bob then the updated Jane, got %+v %v", all, err)
+	}
+	acme, _ := cs.Search(ctx, acct, "ACME", 0)
+	if len(acme) != 1 || acme[0].Email != "jane@example.com" {
+		t.Fatalf("want Jane by organization, got %+v", acme)
+	}
+	if other, _ := cs.Search(ctx, "else@example.com", "", 0); len(other) != 0 {
+		t.Fatalf("contacts must be scoped to the account, got %+v", other)
+	}
+
+	if err := cs.Delete(ctx, acct, "JANE@example.com"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := cs.Delete(ctx, acct, "jane@example.com"); err == nil {
+		t.Fatal("want error deleting twice")
+	}
+}
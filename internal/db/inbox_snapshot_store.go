@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MessageMeta caches the headers needed to list a message even after it left the mailbox
+type MessageMeta struct {
+	MessageID string `json:"message_id"`
+	ThreadID  string `json:"thread_id"`
+	Subject   string `json:"subject"`
+	From      string `json:"from"`
+	SentAt    int64  `json:"sent_at"`
+}
+
+// SnapshotEntry is one message present in the inbox on a snapshot day
+type SnapshotEntry struct {
+	MessageMeta
+	Unread bool `json:"unread"`
+}
+
+// InboxSnapshotStore keeps daily snapshots of which messages were in the inbox (and unread), plus
+// the metadata cache used to list them later
+type InboxSnapshotStore struct {
+	db *sql.DB
+}
+
+// NewInboxSnapshotStore creates a new inbox snapshot store
+func NewInboxSnapshotStore(store *Store) *InboxSnapshotStore {
+	return &InboxSnapshotStore{db: store.DB()}
+}
+
+// snapshotQueryChunk bounds the number of IDs bound in one IN (...) clause
+const snapshotQueryChunk = 500
+
+// SaveMeta stores or refreshes cached message metadata
+func (s *InboxSnapshotStore) SaveMeta(ctx context.Context, accountEmail string, metas []MessageMeta) error {
+	if strings.TrimSpace(accountEmail) == "" {
+		return fmt.Errorf("account_email cannot be empty")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, m := range metas {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_meta (account_email, message_id, thread_id, subject, from_addr, sent_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(account_email, message_id) DO UPDATE SET
+				thread_id = excluded.thread_id,
+				subject = excluded.subject,
+				from_addr = excluded.from_addr,
+				sent_at = excluded.sent_at`,
+			accountEmail, m.MessageID, m.ThreadID, m.Subject, m.From, m.SentAt); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to save message metadata: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// MissingMeta returns the IDs that have no cached metadata yet, in input order
+func (s *InboxSnapshotStore) MissingMeta(ctx context.Context, accountEmail string, ids []string) ([]string, error) {
+	known := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += snapshotQueryChunk {
+		end := start + snapshotQueryChunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[start:end]
+		args := make([]interface{}, 0, len(chunk)+1)
+		args = append(args, accountEmail)
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+		rows, err := s.db.QueryContext(ctx, `
+			SELECT message_id FROM message_meta
+			WHERE account_email = ? AND message_id IN (?`+strings.Repeat(",?", len(chunk)-1)+`)`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query message metadata: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			known[id] = true
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	var missing []string
+	for _, id := range ids {
+		if !known[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// SaveSnapshot replaces the snapshot for a day (YYYY-MM-DD) with the given inbox contents;
+// unread maps each message ID in the inbox to its unread state
+func (s *InboxSnapshotStore) SaveSnapshot(ctx context.Context, accountEmail, day string, unread map[string]bool) error {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(day) == "" {
+		return fmt.Errorf("account_email and day cannot be empty")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM inbox_snapshots WHERE account_email = ? AND day = ?`, accountEmail, day); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	for id, u := range unread {
+		flag := 0
+		if u {
+			flag = 1
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO inbox_snapshots (account_email, day, message_id, unread) VALUES (?, ?, ?, ?)`,
+			accountEmail, day, id, flag); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Days lists the snapshot days of an account, newest first
+func (s *InboxSnapshotStore) Days(ctx context.Context, accountEmail string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT day FROM inbox_snapshots WHERE account_email = ? ORDER BY day DESC`, accountEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var days []string
+	for rows.Next() {
+		var d string
+		if err := rows.Scan(&d); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// SnapshotOnOrBefore returns the latest snapshot taken on or before day, newest messages first.
+// The returned day is empty when there is no such snapshot.
+func (s *InboxSnapshotStore) SnapshotOnOrBefore(ctx context.Context, accountEmail, day string) (string, []*SnapshotEntry, error) {
+	var found sql.NullString
+	if err := s.db.QueryRowContext(ctx, `
+		SELECT MAX(day) FROM inbox_snapshots WHERE account_email = ? AND day <= ?`,
+		accountEmail, day).Scan(&found); err != nil {
+		return "", nil, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+	if !found.Valid {
+		return "", nil, nil
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.message_id, s.unread, COALESCE(m.thread_id, ''), COALESCE(m.subject, ''),
+			COALESCE(m.from_addr, ''), COALESCE(m.sent_at, 0)
+		FROM inbox_snapshots s
+		LEFT JOIN message_meta m ON m.account_email = s.account_email AND m.message_id = s.message_id
+		WHERE s.account_email = ? AND s.day = ?
+		ORDER BY COALESCE(m.sent_at, 0) DESC, s.message_id`,
+		accountEmail, found.String)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load snapshot: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var entries []*SnapshotEntry
+	for rows.Next() {
+		e := &SnapshotEntry{}
+		var unread int
+		if err := rows.Scan(&e.MessageID, &unread, &e.ThreadID, &e.Subject, &e.From, &e.SentAt); err != nil {
+			return "", nil, err
+		}
+		e.Unread = unread != 0
+		entries = append(entries, e)
+	}
+	return found.String, entries, rows.Err()
+}
+
+// PruneBefore drops snapshots older than day and the metadata no remaining snapshot refers to
+func (s *InboxSnapshotStore) PruneBefore(ctx context.Context, accountEmail, day string) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM inbox_snapshots WHERE account_email = ? AND day < ?`, accountEmail, day)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM message_meta WHERE account_email = ? AND message_id NOT IN (
+			SELECT message_id FROM inbox_snapshots WHERE account_email = ?)`,
+		accountEmail, accountEmail); err != nil {
+		return 0, fmt.Errorf("failed to prune message metadata: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestInboxSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/snap.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ss := NewInboxSnapshotStore(store)
+	const acct = "user@example.com"
+
+	if err := ss.SaveMeta(ctx, acct, []MessageMeta{
+		{MessageID: "m1", Subject: "Older", From: "a@x.com", SentAt: 100},
+		{MessageID: "m2", Subject: "Newer", From: "b@x.com", SentAt: 200},
+	}); err != nil {
+		t.Fatalf("save meta: %v", err)
+	}
+	missing, err := ss.MissingMeta(ctx, acct, []string{"m1", "m3", "m2"})
+	if err != nil || strings.Join(missing, ",") != "m3" {
+		t.Fatalf("want m3 missing, got %v %v", missing, err)
+	}
+
+	if err := ss.SaveSnapshot(ctx, acct, "2026-07-01", map[string]bool{"m1": true, "m2": false}); err != nil {
+		t.Fatalf("save snapshot: %v", err)
+	}
+	if err := ss.SaveSnapshot(ctx, acct, "2026-07-10", map[string]bool{"m2": false}); err != nil {
+		t.Fatalf("save snapshot: %v", err)
+	}
+
+	day, entries, err := ss.SnapshotOnOrBefore(ctx, acct, "2026-07-05")
+	if err != nil || day != "2026-07-01" || len(entries) != 2 {
+		t.Fatalf("want the 07-01 snapshot with 2 messages, got %q %d %v", day, len(entries), err)
+	}
+	if entries[0].MessageID != "m2" || entries[1].Subject != "Older" || !entries[1].Unread {
+		t.Fatalf("want newest first with metadata and unread state, got %+v %+v", entries[0], entries[1])
+	}
+	if day, _, _ := ss.SnapshotOnOrBefore(ctx, acct, "2026-06-30"); day != "" {
+		t.Fatalf("want no snapshot before the first one, got %q", day)
+	}
+	if days, _ := ss.Days(ctx, acct); strings.Join(days, ",") != "2026-07-10,2026-07-01" {
+		t.Fatalf("want days newest first, got %v", days)
+	}
+
+	n, err := ss.PruneBefore(ctx, acct, "2026-07-05")
+	if err != nil || n != 2 {
+		t.Fatalf("want 2 rows pruned, got %d %v", n, err)
+	}
+	if missing, _ := ss.MissingMeta(ctx, acct, []string{"m1", "m2"}); strings.Join(missing, ",") != "m1" {
+		t.Fatalf("want m1 metadata pruned with its last snapshot, got %v", missing)
+	}
+}
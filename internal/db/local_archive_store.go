@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LocalArchiveEntry indexes one message kept locally after being removed from Gmail. The raw
+// message lives in an mbox file at MboxPath, MboxLength bytes from MboxOffset.
+type LocalArchiveEntry struct {
+	ID           int64  `json:"id"`
+	AccountEmail string `json:"account_email"`
+	MessageID    string `json:"message_id"`
+	ThreadID     string `json:"thread_id"`
+	Subject      string `json:"subject"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	SentAt       int64  `json:"sent_at"`
+	Labels       string `json:"labels"`
+	MboxPath     string `json:"mbox_path"`
+	MboxOffset   int64  `json:"mbox_offset"`
+	MboxLength   int64  `json:"mbox_length"`
+	ArchivedAt   int64  `json:"archived_at"`
+}
+
+// LocalArchiveStore handles the local archive index and its full-text search table.
+type LocalArchiveStore struct {
+	db *sql.DB
+}
+
+// NewLocalArchiveStore creates a new local archive store.
+func NewLocalArchiveStore(store *Store) *LocalArchiveStore {
+	return &LocalArchiveStore{db: store.DB()}
+}
+
+const localArchiveColumns = `a.id, a.account_email, a.message_id, a.thread_id, a.subject, a.from_addr, a.to_addr,
+		a.sent_at, a.labels, a.mbox_path, a.mbox_offset, a.mbox_length, a.archived_at`
+
+// Add indexes an archived message and its plain-text body.
+func (s *LocalArchiveStore) Add(ctx context.Context, e *LocalArchiveEntry, body string) (*LocalArchiveEntry, error) {
+	if e == nil || strings.TrimSpace(e.AccountEmail) == "" || strings.TrimSpace(e.MessageID) == "" || e.MboxPath == "" {
+		return nil, fmt.Errorf("account_email, message_id and mbox_path cannot be empty")
+	}
+	if e.ArchivedAt == 0 {
+		e.ArchivedAt = time.Now().Unix()
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO local_archive (account_email, message_id, thread_id, subject, from_addr, to_addr,
+			sent_at, labels, mbox_path, mbox_offset, mbox_length, archived_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.AccountEmail, e.MessageID, e.ThreadID, e.Subject, e.From, e.To,
+		e.SentAt, e.Labels, e.MboxPath, e.MboxOffset, e.MboxLength, e.ArchivedAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to index archived message: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO local_archive_fts (rowid, subject, from_addr, to_addr, body) VALUES (?, ?, ?, ?, ?)`,
+		id, e.Subject, e.From, e.To, body); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to index archived message text: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	e.ID = id
+	return e, nil
+}
+
+// Has reports whether the message is already in the account's local archive.
+func (s *LocalArchiveStore) Has(ctx context.Context, accountEmail, messageID string) (bool, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(1) FROM local_archive WHERE account_email = ? AND message_id = ?`,
+		accountEmail, messageID).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("failed to check local archive: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Search returns the account's archived messages matching terms, best match first. Every term
+// must match as a word prefix in the subject, sender, recipients or body; empty terms list the
+// most recently archived messages.
+func (s *LocalArchiveStore) Search(ctx context.Context, accountEmail, terms string, limit int) ([]*LocalArchiveEntry, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	var rows *sql.Rows
+	var err error
+	if match := ftsQuery(terms); match == "" {
+		rows, err = s.db.QueryContext(ctx, `
+			SELECT `+localArchiveColumns+`
+			FROM local_archive a
+			WHERE a.account_email = ?
+			ORDER BY a.archived_at DESC, a.id DESC
+			LIMIT ?`, accountEmail, limit)
+	} else {
+		rows, err = s.db.QueryContext(ctx, `
+			SELECT `+localArchiveColumns+`
+			FROM local_archive_fts f
+			JOIN local_archive a ON a.id = f.rowid
+			WHERE local_archive_fts MATCH ? AND a.account_email = ?
+			ORDER BY bm25(local_archive_fts)
+			LIMIT ?`, match, accountEmail, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search local archive: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*LocalArchiveEntry
+	for rows.Next() {
+		e := &LocalArchiveEntry{}
+		if err := rows.Scan(&e.ID, &e.AccountEmail, &e.MessageID, &e.ThreadID, &e.Subject, &e.From, &e.To,
+			&e.SentAt, &e.Labels, &e.MboxPath, &e.MboxOffset, &e.MboxLength, &e.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan archived message: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// Body returns the indexed plain-text body of an archived message.
+func (s *LocalArchiveStore) Body(ctx context.Context, accountEmail string, id int64) (string, error) {
+	var body string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT f.body FROM local_archive_fts f
+		JOIN local_archive a ON a.id = f.rowid
+		WHERE a.account_email = ? AND a.id = ?`, accountEmail, id).Scan(&body)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("archived message not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load archived message: %w", err)
+	}
+	return body, nil
+}
+
+// ftsQuery turns free-text terms into an FTS5 query where each word is a quoted prefix match, so
+// user input never reaches the FTS5 query syntax.
+func ftsQuery(terms string) string {
+	var parts []string
+	for _, w := range strings.Fields(terms) {
+		w = strings.ReplaceAll(w, `"`, "")
+		if w == "" {
+			continue
+		}
+		parts = append(parts, `"`+w+`"*`)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLocalArchiveStore_AddSearchBody(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/archive.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ls := NewLocalArchiveStore(store)
+	const acct = "user@example.com"
+
+	invoice, err := ls.Add(ctx, &LocalArchiveEntry{AccountEmail: acct, MessageID: "m1", Subject: "Your invoice for March", From: "Billing <billing@vendor.example>", MboxPath: "/tmp/a.mbox", MboxLength: 100}, "Amount due: 42 EUR")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := ls.Add(ctx, &LocalArchiveEntry{AccountEmail: acct, MessageID: "m2", Subject: "Lunch on Friday?", From: "Pepe <pepe@example.org>", MboxPath: "/tmp/a.mbox", MboxOffset: 100, MboxLength: 80}, "Tapas at noon"); err != nil {
+		t.Fatalf("add 2: %v", err)
+	}
+	if _, err := ls.Add(ctx, &LocalArchiveEntry{AccountEmail: acct, MessageID: "m1", MboxPath: "/tmp/a.mbox"}, ""); err == nil {
+		t.Fatalf("expected duplicate message to be rejected")
+	}
+
+	got, err := ls.Search(ctx, acct, "tapa", 0)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(got) != 1 || got[0].MessageID != "m2" {
+		t.Fatalf("want body prefix match on m2, got %+v", got)
+	}
+	// FTS syntax in user input is treated as plain words
+	if got, err = ls.Search(ctx, acct, `vendor"`, 0); err != nil || len(got) != 1 || got[0].MessageID != "m1" {
+		t.Fatalf("sender search: %+v %v", got, err)
+	}
+	if got, _ = ls.Search(ctx, acct, "", 0); len(got) != 2 {
+		t.Fatalf("empty terms should list everything, got %d", len(got))
+	}
+	if got, _ = ls.Search(ctx, "other@example.com", "invoice", 0); len(got) != 0 {
+		t.Fatalf("other account should see nothing, got %d", len(got))
+	}
+
+	if ok, _ := ls.Has(ctx, acct, "m1"); !ok {
+		t.Fatalf("m1 should be archived")
+	}
+	body, err := ls.Body(ctx, acct, invoice.ID)
+	if err != nil || body != "Amount due: 42 EUR" {
+		t.Fatalf("body = %q, %v", body, err)
+	}
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MailMergeSend is the outcome of sending one mail merge message
+type MailMergeSend struct {
+	AccountEmail string `json:"account_email"`
+	JobKey       string `json:"job_key"`
+	Recipient    string `json:"recipient"`
+	Status       string `json:"status"`
+	LastError    string `json:"last_error"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// MailMergeStore handles persistence of mail merge progress. A job is identified by a key derived
+// from its recipient list and template, so running the same merge again picks up where it stopped.
+type MailMergeStore struct {
+	db *sql.DB
+}
+
+// NewMailMergeStore creates a new mail merge store
+func NewMailMergeStore(store *Store) *MailMergeStore {
+	return &MailMergeStore{db: store.DB()}
+}
+
+// Record saves the outcome for a recipient of a job, replacing the previous one
+func (s *MailMergeStore) Record(ctx context.Context, accountEmail, jobKey, recipient, status, lastError string) error {
+	recipient = strings.ToLower(strings.TrimSpace(recipient))
+	if strings.TrimSpace(accountEmail) == "" || jobKey == "" || recipient == "" {
+		return fmt.Errorf("account_email, job_key and recipient cannot be empty")
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO mail_merge_sends (account_email, job_key, recipient, status, last_error, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_email, job_key, recipient) DO UPDATE SET
+			status = excluded.status,
+			last_error = excluded.last_error,
+			updated_at = excluded.updated_at`,
+		accountEmail, jobKey, recipient, status, lastError, time.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to record mail merge send: %w", err)
+	}
+	return nil
+}
+
+// List returns the recorded outcomes of a job, keyed by lowercased recipient
+func (s *MailMergeStore) List(ctx context.Context, accountEmail, jobKey string) (map[string]*MailMergeSend, error) {
+	if strings.TrimSpace(accountEmail) == "" || jobKey == "" {
+		return nil, fmt.Errorf("account_email and job_key cannot be empty")
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT account_email, job_key, recipient, status, last_error, updated_at
+		FROM mail_merge_sends WHERE account_email = ? AND job_key = ?`,
+		accountEmail, jobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mail merge sends: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make(map[string]*MailMergeSend)
+	for rows.Next() {
+		e := &MailMergeSend{}
+		if err := rows.Scan(&e.AccountEmail, &e.JobKey, &e.Recipient, &e.Status, &e.LastError, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan mail merge send: %w", err)
+		}
+		out[e.Recipient] = e
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return out, nil
+}
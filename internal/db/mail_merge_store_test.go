@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMailMergeStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/merge.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ms := NewMailMergeStore(store)
+	const acct = "user@example.com"
+
+	if err := ms.Record(ctx, acct, "job1", " Ana@Example.com ", "failed", "quota"); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := ms.Record(ctx, acct, "job1", "ana@example.com", "sent", ""); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := ms.Record(ctx, acct, "job2", "bob@example.com", "sent", ""); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := ms.Record(ctx, acct, "job1", "", "sent", ""); err == nil {
+		t.Fatal("want error recording without a recipient")
+	}
+
+	got, err := ms.List(ctx, acct, "job1")
+	if err != nil || len(got) != 1 || got["ana@example.com"] == nil || got["ana@example.com"].Status != "sent" {
+		t.Fatalf("want ana sent only, got %+v %v", got, err)
+	}
+	if got, err := ms.List(ctx, "else@example.com", "job1"); err != nil || len(got) != 0 {
+		t.Fatalf("other account sees %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OutboxEntry is one message waiting in (or recently sent from) the offline outbox. Composition
+// holds the serialized composition so the store stays independent of the services types.
+type OutboxEntry struct {
+	ID           int64  `json:"id"`
+	AccountEmail string `json:"account_email"`
+	Composition  string `json:"composition"`
+	Status       string `json:"status"`
+	Attempts     int    `json:"attempts"`
+	LastError    string `json:"last_error"`
+	QueuedAt     int64  `json:"queued_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// OutboxStore handles persistence of the offline outbox.
+type OutboxStore struct {
+	db *sql.DB
+}
+
+// NewOutboxStore creates a new outbox store.
+func NewOutboxStore(store *Store) *OutboxStore {
+	return &OutboxStore{db: store.DB()}
+}
+
+// Enqueue appends a message to the account's outbox and returns it.
+func (s *OutboxStore) Enqueue(ctx context.Context, accountEmail, composition, status, lastError string) (*OutboxEntry, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(composition) == "" {
+		return nil, fmt.Errorf("account_email and composition cannot be empty")
+	}
+	now := time.Now().Unix()
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO outbox (account_email, composition, status, attempts, last_error, queued_at, updated_at)
+		VALUES (?, ?, ?, 0, ?, ?, ?)`,
+		accountEmail, composition, status, lastError, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue message: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	return &OutboxEntry{ID: id, AccountEmail: accountEmail, Composition: composition, Status: status, LastError: lastError, QueuedAt: now, UpdatedAt: now}, nil
+}
+
+// List returns the account's outbox in queue order (oldest first).
+func (s *OutboxStore) List(ctx context.Context, accountEmail string) ([]*OutboxEntry, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, account_email, composition, status, attempts, last_error, queued_at, updated_at
+		FROM outbox
+		WHERE account_email = ?
+		ORDER BY id ASC`, accountEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*OutboxEntry
+	for rows.Next() {
+		e := &OutboxEntry{}
+		if err := rows.Scan(&e.ID, &e.AccountEmail, &e.Composition, &e.Status, &e.Attempts, &e.LastError, &e.QueuedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return out, nil
+}
+
+// UpdateStatus records the outcome of a send attempt. countAttempt increments the attempt counter.
+func (s *OutboxStore) UpdateStatus(ctx context.Context, accountEmail string, id int64, status, lastError string, countAttempt bool) error {
+	if strings.TrimSpace(accountEmail) == "" || id <= 0 {
+		return fmt.Errorf("account_email cannot be empty and id must be positive")
+	}
+	inc := 0
+	if countAttempt {
+		inc = 1
+	}
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE outbox SET status = ?, last_error = ?, attempts = attempts + ?, updated_at = ?
+		WHERE account_email = ? AND id = ?`,
+		status, lastError, inc, time.Now().Unix(), accountEmail, id)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox entry: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("outbox entry not found")
+	}
+	return nil
+}
+
+// Delete removes an entry from the account's outbox.
+func (s *OutboxStore) Delete(ctx context.Context, accountEmail string, id int64) error {
+	if strings.TrimSpace(accountEmail) == "" || id <= 0 {
+		return fmt.Errorf("account_email cannot be empty and id must be positive")
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM outbox WHERE account_email = ? AND id = ?`, accountEmail, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete outbox entry: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("outbox entry not found")
+	}
+	return nil
+}
+
+// DeleteByStatus removes every entry of the account in the given status updated before cutoff
+// (unix seconds; 0 removes them all) and returns how many were removed.
+func (s *OutboxStore) DeleteByStatus(ctx context.Context, accountEmail, status string, cutoff int64) (int, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return 0, fmt.Errorf("account_email cannot be empty")
+	}
+	if cutoff <= 0 {
+		cutoff = time.Now().Unix() + 1
+	}
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM outbox WHERE account_email = ? AND status = ? AND updated_at < ?`,
+		accountEmail, status, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear outbox: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
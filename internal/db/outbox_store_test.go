@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOutboxStore_QueueUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/outbox.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	os := NewOutboxStore(store)
+	const acct = "user@example.com"
+
+	first, err := os.Enqueue(ctx, acct, `{"subject":"one"}`, "queued", "dial tcp: no route to host")
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if _, err := os.Enqueue(ctx, acct, `{"subject":"two"}`, "queued", ""); err != nil {
+		t.Fatalf("enqueue 2: %v", err)
+	}
+	if _, err := os.Enqueue(ctx, acct, "", "queued", ""); err == nil {
+		t.Fatalf("expected error for empty composition")
+	}
+
+	if err := os.UpdateStatus(ctx, acct, first.ID, "sent", "", true); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	entries, err := os.List(ctx, acct)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != first.ID {
+		t.Fatalf("want 2 entries oldest first, got %+v", entries)
+	}
+	if entries[0].Status != "sent" || entries[0].Attempts != 1 || entries[0].LastError != "" {
+		t.Fatalf("unexpected first entry after update: %+v", entries[0])
+	}
+
+	other, err := os.List(ctx, "someone@else.com")
+	if err != nil {
+		t.Fatalf("list other: %v", err)
+	}
+	if len(other) != 0 {
+		t.Fatalf("want 0 entries for other account, got %d", len(other))
+	}
+
+	n, err := os.DeleteByStatus(ctx, acct, "sent", 0)
+	if err != nil || n != 1 {
+		t.Fatalf("clear sent: n=%d err=%v", n, err)
+	}
+	if err := os.Delete(ctx, acct, entries[1].ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := os.Delete(ctx, acct, entries[1].ID); err == nil {
+		t.Fatalf("expected error deleting missing entry")
+	}
+}
@@ -29,7 +29,7 @@ func (ps *PromptStore) ListPromptTemplates(ctx context.Context, category string)
 		return nil, fmt.Errorf("prompt store not initialized")
 	}
 
-	query := `SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count
+	query := `SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count, source
 	          FROM prompt_templates`
 	args := []interface{}{}
 
@@ -55,7 +55,7 @@ func (ps *PromptStore) ListPromptTemplates(ctx context.Context, category string)
 	for rows.Next() {
 		t := &prompts.PromptTemplate{}
 		err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.PromptText, &t.Category,
-			&t.CreatedAt, &t.IsFavorite, &t.UsageCount)
+			&t.CreatedAt, &t.IsFavorite, &t.UsageCount, &t.Source)
 		if err != nil {
 			return nil, err
 		}
@@ -73,10 +73,10 @@ func (ps *PromptStore) GetPromptTemplate(ctx context.Context, id int) (*prompts.
 
 	t := &prompts.PromptTemplate{}
 	err := ps.db.QueryRowContext(ctx,
-		`SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count
+		`SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count, source
 		 FROM prompt_templates WHERE id = ?`, id).
 		Scan(&t.ID, &t.Name, &t.Description, &t.PromptText, &t.Category,
-			&t.CreatedAt, &t.IsFavorite, &t.UsageCount)
+			&t.CreatedAt, &t.IsFavorite, &t.UsageCount, &t.Source)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("prompt template not found")
@@ -359,10 +359,10 @@ func (ps *PromptStore) FindPromptByName(ctx context.Context, name string) (*prom
 
 	t := &prompts.PromptTemplate{}
 	err := ps.db.QueryRowContext(ctx,
-		`SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count
+		`SELECT id, name, description, prompt_text, category, created_at, is_favorite, usage_count, source
 		 FROM prompt_templates WHERE name = ?`, name).
 		Scan(&t.ID, &t.Name, &t.Description, &t.PromptText, &t.Category,
-			&t.CreatedAt, &t.IsFavorite, &t.UsageCount)
+			&t.CreatedAt, &t.IsFavorite, &t.UsageCount, &t.Source)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("prompt template with name '%s' not found", name)
@@ -19,6 +19,7 @@ type SavedQuery struct {
 	LastUsed     int64  `json:"last_used"`
 	UseCount     int    `json:"use_count"`
 	Category     string `json:"category"`
+	Source       string `json:"source,omitempty"` // shared pack the query came from; "" for the user's own
 }
 
 // QueryStore handles database operations for saved queries
@@ -85,12 +86,12 @@ func (s *QueryStore) GetQueryByName(ctx context.Context, accountEmail, name stri
 
 	query := &SavedQuery{}
 	err := s.db.QueryRowContext(ctx, `
-		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category
+		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category, source
 		FROM saved_queries
 		WHERE account_email = ? AND name = ?`,
 		accountEmail, name).Scan(
 		&query.ID, &query.AccountEmail, &query.Name, &query.Query,
-		&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category)
+		&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category, &query.Source)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("query not found")
@@ -110,12 +111,12 @@ func (s *QueryStore) GetQueryByID(ctx context.Context, accountEmail string, id i
 
 	query := &SavedQuery{}
 	err := s.db.QueryRowContext(ctx, `
-		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category
+		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category, source
 		FROM saved_queries
 		WHERE account_email = ? AND id = ?`,
 		accountEmail, id).Scan(
 		&query.ID, &query.AccountEmail, &query.Name, &query.Query,
-		&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category)
+		&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category, &query.Source)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("query not found")
@@ -139,7 +140,7 @@ func (s *QueryStore) ListQueries(ctx context.Context, accountEmail, category str
 	if strings.TrimSpace(category) == "" {
 		// Get all queries
 		rows, err = s.db.QueryContext(ctx, `
-			SELECT id, account_email, name, query, description, created_at, last_used, use_count, category
+			SELECT id, account_email, name, query, description, created_at, last_used, use_count, category, source
 			FROM saved_queries
 			WHERE account_email = ?
 			ORDER BY last_used DESC, use_count DESC, name ASC`,
@@ -147,7 +148,7 @@ func (s *QueryStore) ListQueries(ctx context.Context, accountEmail, category str
 	} else {
 		// Filter by category
 		rows, err = s.db.QueryContext(ctx, `
-			SELECT id, account_email, name, query, description, created_at, last_used, use_count, category
+			SELECT id, account_email, name, query, description, created_at, last_used, use_count, category, source
 			FROM saved_queries
 			WHERE account_email = ? AND category = ?
 			ORDER BY last_used DESC, use_count DESC, name ASC`,
@@ -168,7 +169,7 @@ func (s *QueryStore) ListQueries(ctx context.Context, accountEmail, category str
 	for rows.Next() {
 		query := &SavedQuery{}
 		err := rows.Scan(&query.ID, &query.AccountEmail, &query.Name, &query.Query,
-			&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category)
+			&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category, &query.Source)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan query: %w", err)
 		}
@@ -273,7 +274,7 @@ func (s *QueryStore) SearchQueries(ctx context.Context, accountEmail, searchTerm
 	searchPattern := "%" + strings.TrimSpace(searchTerm) + "%"
 
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category
+		SELECT id, account_email, name, query, description, created_at, last_used, use_count, category, source
 		FROM saved_queries
 		WHERE account_email = ? AND (name LIKE ? OR description LIKE ? OR query LIKE ?)
 		ORDER BY use_count DESC, last_used DESC, name ASC`,
@@ -293,7 +294,7 @@ func (s *QueryStore) SearchQueries(ctx context.Context, accountEmail, searchTerm
 	for rows.Next() {
 		query := &SavedQuery{}
 		err := rows.Scan(&query.ID, &query.AccountEmail, &query.Name, &query.Query,
-			&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category)
+			&query.Description, &query.CreatedAt, &query.LastUsed, &query.UseCount, &query.Category, &query.Source)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan query: %w", err)
 		}
@@ -345,3 +346,168 @@ func (s *QueryStore) GetCategories(ctx context.Context, accountEmail string) ([]
 
 	return categories, nil
 }
+
+// maxRecentParamValues is how many recent values are remembered per template parameter
+const maxRecentParamValues = 10
+
+// RecordParamValue remembers a value typed for a saved query template parameter, keeping only
+// the most recent values per parameter
+func (s *QueryStore) RecordParamValue(ctx context.Context, accountEmail, param, value string) error {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(param) == "" || strings.TrimSpace(value) == "" {
+		return fmt.Errorf("account_email, param, and value cannot be empty")
+	}
+
+	now := time.Now().UnixNano()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO query_param_values (account_email, param, value, used_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(account_email, param, value) DO UPDATE SET used_at = excluded.used_at`,
+		accountEmail, param, value, now); err != nil {
+		return fmt.Errorf("failed to record parameter value: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM query_param_values
+		WHERE account_email = ? AND param = ? AND value NOT IN (
+			SELECT value FROM query_param_values
+			WHERE account_email = ? AND param = ?
+			ORDER BY used_at DESC
+			LIMIT ?
+		)`,
+		accountEmail, param, accountEmail, param, maxRecentParamValues); err != nil {
+		return fmt.Errorf("failed to prune parameter values: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentParamValues returns the values most recently used for a template parameter, newest first
+func (s *QueryStore) GetRecentParamValues(ctx context.Context, accountEmail, param string, limit int) ([]string, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(param) == "" {
+		return nil, fmt.Errorf("account_email and param cannot be empty")
+	}
+	if limit <= 0 {
+		limit = maxRecentParamValues
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT value
+		FROM query_param_values
+		WHERE account_email = ? AND param = ?
+		ORDER BY used_at DESC
+		LIMIT ?`,
+		accountEmail, param, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter values: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			// Log error but don't fail the operation
+			_ = err
+		}
+	}()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan parameter value: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return values, nil
+}
+
+// SmartLabel links a saved query to a label applied automatically to new matching messages
+type SmartLabel struct {
+	QueryID   int64  `json:"query_id"`
+	QueryName string `json:"query_name"`
+	Query     string `json:"query"`
+	LabelName string `json:"label_name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// LinkSmartLabel links a saved query to a label, replacing any label it was linked to before
+func (s *QueryStore) LinkSmartLabel(ctx context.Context, accountEmail string, queryID int64, labelName string) error {
+	if strings.TrimSpace(accountEmail) == "" || queryID <= 0 || strings.TrimSpace(labelName) == "" {
+		return fmt.Errorf("account_email and label cannot be empty and query id must be positive")
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO smart_labels (account_email, query_id, label_name, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(account_email, query_id) DO UPDATE SET label_name = excluded.label_name`,
+		accountEmail, queryID, labelName, time.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to link smart label: %w", err)
+	}
+	return nil
+}
+
+// UnlinkSmartLabel removes the label link of a saved query
+func (s *QueryStore) UnlinkSmartLabel(ctx context.Context, accountEmail string, queryID int64) error {
+	if strings.TrimSpace(accountEmail) == "" || queryID <= 0 {
+		return fmt.Errorf("account_email cannot be empty and id must be positive")
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM smart_labels
+		WHERE account_email = ? AND query_id = ?`,
+		accountEmail, queryID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink smart label: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("smart label not found")
+	}
+	return nil
+}
+
+// ListSmartLabels returns the account's smart labels with their current query text. Links whose
+// saved query was deleted are skipped.
+func (s *QueryStore) ListSmartLabels(ctx context.Context, accountEmail string) ([]*SmartLabel, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT l.query_id, q.name, q.query, l.label_name, l.created_at
+		FROM smart_labels l
+		JOIN saved_queries q ON q.id = l.query_id AND q.account_email = l.account_email
+		WHERE l.account_email = ?
+		ORDER BY q.name ASC`,
+		accountEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list smart labels: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			// Log error but don't fail the operation
+			_ = err
+		}
+	}()
+
+	var links []*SmartLabel
+	for rows.Next() {
+		l := &SmartLabel{}
+		if err := rows.Scan(&l.QueryID, &l.QueryName, &l.Query, &l.LabelName, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan smart label: %w", err)
+		}
+		links = append(links, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestQueryStore_ParamValues(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/params.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	qs := NewQueryStore(store)
+	const acct = "user@example.com"
+
+	for _, v := range []string{"ana@example.com", "bob@example.com", "ana@example.com"} {
+		if err := qs.RecordParamValue(ctx, acct, "sender", v); err != nil {
+			t.Fatalf("record %q: %v", v, err)
+		}
+	}
+	values, err := qs.GetRecentParamValues(ctx, acct, "sender", 0)
+	if err != nil {
+		t.Fatalf("recent: %v", err)
+	}
+	if len(values) != 2 || values[0] != "ana@example.com" || values[1] != "bob@example.com" {
+		t.Fatalf("want [ana bob] newest first without duplicates, got %v", values)
+	}
+
+	// Other accounts and parameters are isolated
+	if other, _ := qs.GetRecentParamValues(ctx, "else@example.com", "sender", 0); len(other) != 0 {
+		t.Fatalf("want no values for another account, got %v", other)
+	}
+	if other, _ := qs.GetRecentParamValues(ctx, acct, "days", 0); len(other) != 0 {
+		t.Fatalf("want no values for another parameter, got %v", other)
+	}
+
+	// Only the most recent values are kept
+	for i := 0; i < maxRecentParamValues+5; i++ {
+		if err := qs.RecordParamValue(ctx, acct, "days", fmt.Sprintf("%d", i)); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	days, _ := qs.GetRecentParamValues(ctx, acct, "days", 100)
+	if len(days) != maxRecentParamValues || days[0] != fmt.Sprintf("%d", maxRecentParamValues+4) {
+		t.Fatalf("want %d newest values, got %v", maxRecentParamValues, days)
+	}
+
+	if err := qs.RecordParamValue(ctx, acct, "sender", "  "); err == nil {
+		t.Fatal("expected error for empty value")
+	}
+}
+
+func TestQueryStore_SmartLabels(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/smart.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	qs := NewQueryStore(store)
+	const acct = "user@example.com"
+
+	big, err := qs.SaveQuery(ctx, acct, "big-old", "larger:5M older_than:1y", "", "")
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	ci, err := qs.SaveQuery(ctx, acct, "ci", "from:/ci@.*/", "", "")
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := qs.LinkSmartLabel(ctx, acct, big.ID, "Cleanup"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if err := qs.LinkSmartLabel(ctx, acct, ci.ID, "CI"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	// Relinking replaces the label
+	if err := qs.LinkSmartLabel(ctx, acct, ci.ID, "Builds"); err != nil {
+		t.Fatalf("relink: %v", err)
+	}
+
+	links, err := qs.ListSmartLabels(ctx, acct)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(links) != 2 || links[0].QueryName != "big-old" || links[1].LabelName != "Builds" || links[1].Query != "from:/ci@.*/" {
+		t.Fatalf("unexpected links: %+v %+v", links[0], links[1])
+	}
+
+	// Deleting the saved query hides its link; other accounts see nothing
+	if err := qs.DeleteQuery(ctx, acct, big.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if links, _ = qs.ListSmartLabels(ctx, acct); len(links) != 1 {
+		t.Fatalf("want 1 link after deleting its query, got %d", len(links))
+	}
+	if other, _ := qs.ListSmartLabels(ctx, "else@example.com"); len(other) != 0 {
+		t.Fatalf("want no links for another account, got %d", len(other))
+	}
+
+	if err := qs.UnlinkSmartLabel(ctx, acct, ci.ID); err != nil {
+		t.Fatalf("unlink: %v", err)
+	}
+	if err := qs.UnlinkSmartLabel(ctx, acct, ci.ID); err == nil {
+		t.Fatal("want error unlinking twice")
+	}
+}
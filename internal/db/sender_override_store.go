@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SenderOverride is a local display override for one sender address: a custom name, an emoji
+// and a color tag shown in the list and the reader header
+type SenderOverride struct {
+	AccountEmail string `json:"account_email"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Emoji        string `json:"emoji"`
+	Color        string `json:"color"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// SenderOverrideStore handles database operations for sender display overrides
+type SenderOverrideStore struct {
+	db *sql.DB
+}
+
+// NewSenderOverrideStore creates a new sender override store
+func NewSenderOverrideStore(store *Store) *SenderOverrideStore {
+	return &SenderOverrideStore{db: store.DB()}
+}
+
+// Save sets the override for an address, replacing the one stored for it
+func (s *SenderOverrideStore) Save(ctx context.Context, o SenderOverride) (*SenderOverride, error) {
+	o.Email = strings.ToLower(strings.TrimSpace(o.Email))
+	if strings.TrimSpace(o.AccountEmail) == "" || o.Email == "" {
+		return nil, fmt.Errorf("account_email and email cannot be empty")
+	}
+	o.UpdatedAt = time.Now().Unix()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO sender_overrides (account_email, email, name, emoji, color, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_email, email) DO UPDATE SET
+			name = excluded.name,
+			emoji = excluded.emoji,
+			color = excluded.color,
+			updated_at = excluded.updated_at`,
+		o.AccountEmail, o.Email, o.Name, o.Emoji, o.Color, o.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to save sender override: %w", err)
+	}
+	return &o, nil
+}
+
+// Get returns the override for an address, or nil when there is none
+func (s *SenderOverrideStore) Get(ctx context.Context, accountEmail, email string) (*SenderOverride, error) {
+	o := &SenderOverride{}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT account_email, email, name, emoji, color, updated_at
+		FROM sender_overrides WHERE account_email = ? AND email = ?`,
+		accountEmail, strings.ToLower(strings.TrimSpace(email))).
+		Scan(&o.AccountEmail, &o.Email, &o.Name, &o.Emoji, &o.Color, &o.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender override: %w", err)
+	}
+	return o, nil
+}
+
+// List returns the account's overrides ordered by address
+func (s *SenderOverrideStore) List(ctx context.Context, accountEmail string) ([]*SenderOverride, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT account_email, email, name, emoji, color, updated_at
+		FROM sender_overrides WHERE account_email = ?
+		ORDER BY email`, accountEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sender overrides: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*SenderOverride
+	for rows.Next() {
+		o := &SenderOverride{}
+		if err := rows.Scan(&o.AccountEmail, &o.Email, &o.Name, &o.Emoji, &o.Color, &o.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sender override: %w", err)
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes the override for an address
+func (s *SenderOverrideStore) Delete(ctx context.Context, accountEmail, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if strings.TrimSpace(accountEmail) == "" || email == "" {
+		return fmt.Errorf("account_email and email cannot be empty")
+	}
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM sender_overrides WHERE account_email = ? AND email = ?`,
+		accountEmail, email)
+	if err != nil {
+		return fmt.Errorf("failed to delete sender override: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s has no display override", email)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSenderOverrideStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/overrides.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ss := NewSenderOverrideStore(store)
+	const acct = "user@example.com"
+
+	if _, err := ss.Save(ctx, SenderOverride{AccountEmail: acct, Email: " Alerts@PagerDuty.com ", Name: "PagerDuty", Emoji: "🚨"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := ss.Save(ctx, SenderOverride{AccountEmail: acct, Email: "alerts@pagerduty.com", Name: "PagerDuty", Emoji: "🚨", Color: "red"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := ss.Save(ctx, SenderOverride{AccountEmail: acct, Email: "boss@example.com", Emoji: "👩‍💼"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := ss.Save(ctx, SenderOverride{AccountEmail: acct, Email: ""}); err == nil {
+		t.Fatal("want error saving an override without an address")
+	}
+
+	all, err := ss.List(ctx, acct)
+	if err != nil || len(all) != 2 || all[0].Color != "red" || all[1].Email != "boss@example.com" {
+		t.Fatalf("want the updated PagerDuty then boss, got %+v %v", all, err)
+	}
+	if o, err := ss.Get(ctx, acct, "ALERTS@pagerduty.com"); err != nil || o == nil || o.Name != "PagerDuty" {
+		t.Fatalf("get = %+v, %v", o, err)
+	}
+	if o, err := ss.Get(ctx, "else@example.com", "boss@example.com"); err != nil || o != nil {
+		t.Fatalf("other account sees %+v, %v", o, err)
+	}
+
+	if err := ss.Delete(ctx, acct, "boss@example.com"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := ss.Delete(ctx, acct, "boss@example.com"); err == nil {
+		t.Fatal("want error deleting a missing override")
+	}
+}
@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/prompts"
+)
+
+// SharedSyncResult reports how a shared pack sync changed the prompts or saved queries
+type SharedSyncResult struct {
+	Added    int
+	Updated  int
+	Removed  int
+	Shadowed []string // pack items skipped because the user has their own with the same name
+}
+
+// sharedRow is an existing prompt or saved query as the sync sees it
+type sharedRow struct {
+	id      int64
+	source  string
+	content string // the synced fields joined, to tell whether an update changes anything
+}
+
+// sharedRowOps writes one kind of item inside the sync transaction
+type sharedRowOps struct {
+	insert func(name string) error
+	update func(id int64, name string) error
+	remove func(id int64) error
+}
+
+// syncSharedRows applies the pack's items (name → content) over the existing rows: names the
+// user owns are shadowed, the pack's own rows are updated when changed, and pack rows whose name
+// left the pack are removed
+func syncSharedRows(existing map[string]sharedRow, source string, items map[string]string, ops sharedRowOps) (SharedSyncResult, error) {
+	var res SharedSyncResult
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		row, ok := existing[name]
+		switch {
+		case !ok:
+			if err := ops.insert(name); err != nil {
+				return res, err
+			}
+			res.Added++
+		case row.source != source:
+			res.Shadowed = append(res.Shadowed, name)
+		case row.content != items[name]:
+			if err := ops.update(row.id, name); err != nil {
+				return res, err
+			}
+			res.Updated++
+		}
+	}
+	for name, row := range existing {
+		if _, keep := items[name]; keep || row.source != source {
+			continue
+		}
+		if err := ops.remove(row.id); err != nil {
+			return res, err
+		}
+		res.Removed++
+	}
+	return res, nil
+}
+
+// SyncSharedPrompts makes the prompts of a shared pack (source) match items. Removed prompts take
+// their cached results with them.
+func (ps *PromptStore) SyncSharedPrompts(ctx context.Context, source string, items []*prompts.PromptTemplate) (SharedSyncResult, error) {
+	if ps == nil || ps.db == nil {
+		return SharedSyncResult{}, fmt.Errorf("prompt store not initialized")
+	}
+	if strings.TrimSpace(source) == "" {
+		return SharedSyncResult{}, fmt.Errorf("shared pack source cannot be empty")
+	}
+
+	byName := make(map[string]*prompts.PromptTemplate, len(items))
+	contents := make(map[string]string, len(items))
+	for _, it := range items {
+		byName[it.Name] = it
+		contents[it.Name] = promptContent(it.Description, it.PromptText, it.Category)
+	}
+
+	tx, err := ps.db.BeginTx(ctx, nil)
+	if err != nil {
+		return SharedSyncResult{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing := make(map[string]sharedRow)
+	rows, err := tx.QueryContext(ctx, `SELECT id, name, description, prompt_text, category, source FROM prompt_templates`)
+	if err != nil {
+		return SharedSyncResult{}, fmt.Errorf("failed to read prompt templates: %w", err)
+	}
+	for rows.Next() {
+		var row sharedRow
+		var name, text, category string
+		var description sql.NullString
+		if err := rows.Scan(&row.id, &name, &description, &text, &category, &row.source); err != nil {
+			_ = rows.Close()
+			return SharedSyncResult{}, err
+		}
+		row.content = promptContent(description.String, text, category)
+		existing[name] = row
+	}
+	if err := rows.Close(); err != nil {
+		return SharedSyncResult{}, err
+	}
+
+	now := time.Now().Unix()
+	res, err := syncSharedRows(existing, source, contents, sharedRowOps{
+		insert: func(name string) error {
+			it := byName[name]
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO prompt_templates (name, description, prompt_text, category, created_at, is_favorite, usage_count, source)
+				 VALUES (?, ?, ?, ?, ?, FALSE, 0, ?)`,
+				name, it.Description, it.PromptText, it.Category, now, source)
+			return err
+		},
+		update: func(id int64, name string) error {
+			it := byName[name]
+			_, err := tx.ExecContext(ctx,
+				`UPDATE prompt_templates SET description = ?, prompt_text = ?, category = ? WHERE id = ?`,
+				it.Description, it.PromptText, it.Category, id)
+			return err
+		},
+		remove: func(id int64) error {
+			for _, stmt := range []string{
+				`DELETE FROM prompt_results WHERE prompt_id = ?`,
+				`DELETE FROM bulk_prompt_results WHERE prompt_id = ?`,
+				`DELETE FROM prompt_templates WHERE id = ?`,
+			} {
+				if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return SharedSyncResult{}, fmt.Errorf("failed to sync shared prompts: %w", err)
+	}
+	return res, tx.Commit()
+}
+
+// SyncSharedQueries makes the account's saved queries from a shared pack (source) match items
+func (s *QueryStore) SyncSharedQueries(ctx context.Context, accountEmail, source string, items []*SavedQuery) (SharedSyncResult, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(source) == "" {
+		return SharedSyncResult{}, fmt.Errorf("account_email and source cannot be empty")
+	}
+
+	byName := make(map[string]*SavedQuery, len(items))
+	contents := make(map[string]string, len(items))
+	for _, it := range items {
+		byName[it.Name] = it
+		contents[it.Name] = queryContent(it.Query, it.Description, it.Category)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return SharedSyncResult{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing := make(map[string]sharedRow)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, name, query, description, category, source
+		FROM saved_queries
+		WHERE account_email = ?`, accountEmail)
+	if err != nil {
+		return SharedSyncResult{}, fmt.Errorf("failed to read saved queries: %w", err)
+	}
+	for rows.Next() {
+		var row sharedRow
+		var name, query string
+		var description, category sql.NullString
+		if err := rows.Scan(&row.id, &name, &query, &description, &category, &row.source); err != nil {
+			_ = rows.Close()
+			return SharedSyncResult{}, err
+		}
+		row.content = queryContent(query, description.String, category.String)
+		existing[name] = row
+	}
+	if err := rows.Close(); err != nil {
+		return SharedSyncResult{}, err
+	}
+
+	now := time.Now().Unix()
+	res, err := syncSharedRows(existing, source, contents, sharedRowOps{
+		insert: func(name string) error {
+			it := byName[name]
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO saved_queries (account_email, name, query, description, created_at, last_used, use_count, category, source)
+				VALUES (?, ?, ?, ?, ?, ?, 0, ?, ?)`,
+				accountEmail, name, it.Query, it.Description, now, now, it.Category, source)
+			return err
+		},
+		update: func(id int64, name string) error {
+			it := byName[name]
+			_, err := tx.ExecContext(ctx, `
+				UPDATE saved_queries SET query = ?, description = ?, category = ?
+				WHERE account_email = ? AND id = ?`,
+				it.Query, it.Description, it.Category, accountEmail, id)
+			return err
+		},
+		remove: func(id int64) error {
+			_, err := tx.ExecContext(ctx, `DELETE FROM saved_queries WHERE account_email = ? AND id = ?`, accountEmail, id)
+			return err
+		},
+	})
+	if err != nil {
+		return SharedSyncResult{}, fmt.Errorf("failed to sync shared queries: %w", err)
+	}
+	return res, tx.Commit()
+}
+
+func promptContent(description, text, category string) string {
+	return description + "\x00" + text + "\x00" + category
+}
+
+func queryContent(query, description, category string) string {
+	return query + "\x00" + description + "\x00" + category
+}
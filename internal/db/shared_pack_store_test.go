@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/prompts"
+)
+
+func TestSyncSharedPrompts(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/pack.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ps := NewPromptStore(store)
+
+	if _, err := ps.CreatePromptTemplate(ctx, "Mine", "", "my text", "custom"); err != nil {
+		t.Fatal(err)
+	}
+	pack := []*prompts.PromptTemplate{
+		{Name: "Triage", PromptText: "triage {{body}}", Category: "team"},
+		{Name: "Mine", PromptText: "team text", Category: "team"},
+	}
+	res, err := ps.SyncSharedPrompts(ctx, "team", pack)
+	if err != nil || res.Added != 1 || len(res.Shadowed) != 1 || res.Shadowed[0] != "Mine" {
+		t.Fatalf("first sync = %+v, %v", res, err)
+	}
+	triage, err := ps.FindPromptByName(ctx, "Triage")
+	if err != nil || triage.Source != "team" {
+		t.Fatalf("shared prompt = %+v, %v", triage, err)
+	}
+	if mine, _ := ps.FindPromptByName(ctx, "Mine"); mine.Source != "" || mine.PromptText != "my text" {
+		t.Fatalf("the user's prompt was overwritten: %+v", mine)
+	}
+
+	// Unchanged items are left alone; edited ones update in place
+	if res, _ := ps.SyncSharedPrompts(ctx, "team", pack); res.Added+res.Updated+res.Removed != 0 {
+		t.Fatalf("resync without changes = %+v", res)
+	}
+	pack[0].PromptText = "triage v2 {{body}}"
+	if res, _ := ps.SyncSharedPrompts(ctx, "team", pack); res.Updated != 1 {
+		t.Fatalf("resync after an edit = %+v", res)
+	}
+
+	// A prompt that left the pack is removed with its cached results
+	if err := ps.SavePromptResult(ctx, "a@example.com", "m1", triage.ID, "result"); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := ps.SyncSharedPrompts(ctx, "team", nil); err != nil || res.Removed != 1 {
+		t.Fatalf("sync of an empty pack = %+v, %v", res, err)
+	}
+	if _, err := ps.FindPromptByName(ctx, "Triage"); err == nil {
+		t.Fatal("the removed shared prompt is still there")
+	}
+	if _, err := ps.FindPromptByName(ctx, "Mine"); err != nil {
+		t.Fatalf("the user's prompt went with the pack: %v", err)
+	}
+}
+
+func TestSyncSharedQueries(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/pack.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	qs := NewQueryStore(store)
+	const acct = "user@example.com"
+
+	pack := []*SavedQuery{{Name: "Escalations", Query: "label:escalation is:unread", Category: "team"}}
+	if res, err := qs.SyncSharedQueries(ctx, acct, "team", pack); err != nil || res.Added != 1 {
+		t.Fatalf("sync = %+v, %v", res, err)
+	}
+	q, err := qs.GetQueryByName(ctx, acct, "Escalations")
+	if err != nil || q.Source != "team" || q.Query != "label:escalation is:unread" {
+		t.Fatalf("shared query = %+v, %v", q, err)
+	}
+	if other, _ := qs.ListQueries(ctx, "else@example.com", ""); len(other) != 0 {
+		t.Fatalf("the pack leaked into another account: %+v", other)
+	}
+	if res, _ := qs.SyncSharedQueries(ctx, acct, "team", nil); res.Removed != 1 {
+		t.Fatalf("sync of an empty pack = %+v", res)
+	}
+}
@@ -412,6 +412,438 @@ CREATE TABLE IF NOT EXISTS analyzer_rules (
 		ver = 9
 	}
 
+	// v10: recent values typed for saved query template parameters ({sender}, {days}, ...)
+	if ver == 9 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS query_param_values (
+  account_email TEXT NOT NULL,
+  param         TEXT NOT NULL,
+  value         TEXT NOT NULL,
+  used_at       INTEGER NOT NULL,
+  PRIMARY KEY (account_email, param, value)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=10;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v10: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 10
+	}
+
+	// v11: outbox of messages composed while offline, sent when connectivity returns
+	if ver == 10 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS outbox (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_email TEXT NOT NULL,
+  composition   TEXT NOT NULL,
+  status        TEXT NOT NULL,
+  attempts      INTEGER NOT NULL DEFAULT 0,
+  last_error    TEXT NOT NULL DEFAULT '',
+  queued_at     INTEGER NOT NULL,
+  updated_at    INTEGER NOT NULL
+);`)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_outbox_account ON outbox(account_email, id);`)
+		}
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=11;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v11: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 11
+	}
+
+	// v12: messages moved out of Gmail into the local archive (raw copy in an mbox) with a
+	// full-text index over headers and body
+	if ver == 11 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS local_archive (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_email TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  thread_id     TEXT NOT NULL DEFAULT '',
+  subject       TEXT NOT NULL DEFAULT '',
+  from_addr     TEXT NOT NULL DEFAULT '',
+  to_addr       TEXT NOT NULL DEFAULT '',
+  sent_at       INTEGER NOT NULL DEFAULT 0,
+  labels        TEXT NOT NULL DEFAULT '',
+  mbox_path     TEXT NOT NULL,
+  mbox_offset   INTEGER NOT NULL,
+  mbox_length   INTEGER NOT NULL,
+  archived_at   INTEGER NOT NULL,
+  UNIQUE(account_email, message_id)
+);`)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+CREATE VIRTUAL TABLE IF NOT EXISTS local_archive_fts USING fts5(
+  subject, from_addr, to_addr, body
+);`)
+		}
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=12;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v12: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 12
+	}
+
+	// v13: smart labels — a saved query linked to a label that new messages matching the query
+	// get automatically
+	if ver == 12 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS smart_labels (
+  account_email TEXT NOT NULL,
+  query_id      INTEGER NOT NULL,
+  label_name    TEXT NOT NULL,
+  created_at    INTEGER NOT NULL,
+  PRIMARY KEY (account_email, query_id)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=13;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v13: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 13
+	}
+
+	// v14: notes pinned to a conversation (an AI thread summary or hand-written text), shown at the
+	// top of every message of the thread
+	if ver == 13 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS thread_notes (
+  account_email TEXT NOT NULL,
+  thread_id     TEXT NOT NULL,
+  note          TEXT NOT NULL,
+  source        TEXT NOT NULL DEFAULT 'manual',
+  updated_at    INTEGER NOT NULL,
+  PRIMARY KEY (account_email, thread_id)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=14;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v14: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 14
+	}
+
+	// v15: where a message was (its labels) before the app moved it to Trash or Spam, so a restore
+	// puts it back there instead of only in the inbox
+	if ver == 14 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS trash_origins (
+  account_email TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  labels        TEXT NOT NULL DEFAULT '',
+  folder        TEXT NOT NULL,
+  recorded_at   INTEGER NOT NULL,
+  PRIMARY KEY (account_email, message_id)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=15;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v15: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 15
+	}
+
+	// v16: daily inbox snapshots (the inbox message IDs and their unread state per day) and the
+	// metadata of those messages, for browsing the inbox as of a past date
+	if ver == 15 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS inbox_snapshots (
+  account_email TEXT NOT NULL,
+  day           TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  unread        INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (account_email, day, message_id)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS message_meta (
+  account_email TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  thread_id     TEXT NOT NULL DEFAULT '',
+  subject       TEXT NOT NULL DEFAULT '',
+  from_addr     TEXT NOT NULL DEFAULT '',
+  sent_at       INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (account_email, message_id)
+);`)
+		}
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=16;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v16: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 16
+	}
+
+	// v17: contacts added from vCard attachments
+	if ver == 16 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS contacts (
+  account_email TEXT NOT NULL,
+  email         TEXT NOT NULL,
+  name          TEXT NOT NULL DEFAULT '',
+  org           TEXT NOT NULL DEFAULT '',
+  phone         TEXT NOT NULL DEFAULT '',
+  source        TEXT NOT NULL DEFAULT '',
+  updated_at    INTEGER NOT NULL,
+  PRIMARY KEY (account_email, email)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=17;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v17: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 17
+	}
+
+	// v18: action items extracted from messages
+	if ver == 17 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS todos (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_email TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  thread_id     TEXT NOT NULL DEFAULT '',
+  subject       TEXT NOT NULL DEFAULT '',
+  task          TEXT NOT NULL,
+  owner         TEXT NOT NULL DEFAULT '',
+  due           TEXT NOT NULL DEFAULT '',
+  status        TEXT NOT NULL DEFAULT 'open',
+  created_at    INTEGER NOT NULL,
+  updated_at    INTEGER NOT NULL,
+  UNIQUE (account_email, message_id, task)
+);`)
+
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS idx_todos_account_status ON todos(account_email, status);")
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=18;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v18: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 18
+	}
+
+	// v19: prompts and saved queries merged from a shared pack carry its name and are read-only
+	if ver == 18 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "ALTER TABLE prompt_templates ADD COLUMN source TEXT NOT NULL DEFAULT '';")
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "ALTER TABLE saved_queries ADD COLUMN source TEXT NOT NULL DEFAULT '';")
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=19;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v19: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 19
+	}
+
+	// v20: per-sender display overrides (custom name, emoji, color tag) for the list and headers
+	if ver == 19 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS sender_overrides (
+  account_email TEXT NOT NULL,
+  email         TEXT NOT NULL,
+  name          TEXT NOT NULL DEFAULT '',
+  emoji         TEXT NOT NULL DEFAULT '',
+  color         TEXT NOT NULL DEFAULT '',
+  updated_at    INTEGER NOT NULL,
+  PRIMARY KEY (account_email, email)
+);`)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=20;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v20: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 20
+	}
+
+	// v21: per-recipient outcome of mail merge runs, so an interrupted run resumes where it stopped
+	if ver == 20 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS mail_merge_sends (
+  account_email TEXT NOT NULL,
+  job_key       TEXT NOT NULL,
+  recipient     TEXT NOT NULL,
+  status        TEXT NOT NULL,
+  last_error    TEXT NOT NULL DEFAULT '',
+  updated_at    INTEGER NOT NULL,
+  PRIMARY KEY (account_email, job_key, recipient)
+);`)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=21;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v21: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 21
+	}
+
+	// v22: local log of message actions, from which the usual actions per sender are learned
+	if ver == 21 {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS action_log (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  account_email TEXT NOT NULL,
+  message_id    TEXT NOT NULL,
+  sender        TEXT NOT NULL,
+  action        TEXT NOT NULL,
+  label         TEXT NOT NULL DEFAULT '',
+  created_at    INTEGER NOT NULL
+);`)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS idx_action_log_sender ON action_log(account_email, sender, id);")
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, "PRAGMA user_version=22;")
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate v22: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		ver = 22
+	}
+
 	return nil
 }
 
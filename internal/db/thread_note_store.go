@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ThreadNote is a note pinned to a conversation
+type ThreadNote struct {
+	AccountEmail string `json:"account_email"`
+	ThreadID     string `json:"thread_id"`
+	Note         string `json:"note"`
+	Source       string `json:"source"` // "ai" (pinned summary, possibly edited) or "manual"
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// ThreadNoteStore handles database operations for pinned conversation notes
+type ThreadNoteStore struct {
+	db *sql.DB
+}
+
+// NewThreadNoteStore creates a new thread note store
+func NewThreadNoteStore(store *Store) *ThreadNoteStore {
+	return &ThreadNoteStore{db: store.DB()}
+}
+
+// Save pins a note to a thread, replacing the previous one
+func (s *ThreadNoteStore) Save(ctx context.Context, accountEmail, threadID, note, source string) (*ThreadNote, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(threadID) == "" || strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("account_email, thread_id and note cannot be empty")
+	}
+	if source == "" {
+		source = "manual"
+	}
+	now := time.Now().Unix()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO thread_notes (account_email, thread_id, note, source, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(account_email, thread_id) DO UPDATE SET
+			note = excluded.note,
+			source = excluded.source,
+			updated_at = excluded.updated_at`,
+		accountEmail, threadID, note, source, now); err != nil {
+		return nil, fmt.Errorf("failed to save thread note: %w", err)
+	}
+	return &ThreadNote{AccountEmail: accountEmail, ThreadID: threadID, Note: note, Source: source, UpdatedAt: now}, nil
+}
+
+// Get returns the note pinned to a thread, or nil when there is none
+func (s *ThreadNoteStore) Get(ctx context.Context, accountEmail, threadID string) (*ThreadNote, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(threadID) == "" {
+		return nil, fmt.Errorf("account_email and thread_id cannot be empty")
+	}
+	n := &ThreadNote{}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT account_email, thread_id, note, source, updated_at
+		FROM thread_notes
+		WHERE account_email = ? AND thread_id = ?`,
+		accountEmail, threadID).Scan(&n.AccountEmail, &n.ThreadID, &n.Note, &n.Source, &n.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread note: %w", err)
+	}
+	return n, nil
+}
+
+// Delete unpins a thread's note
+func (s *ThreadNoteStore) Delete(ctx context.Context, accountEmail, threadID string) error {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(threadID) == "" {
+		return fmt.Errorf("account_email and thread_id cannot be empty")
+	}
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM thread_notes WHERE account_email = ? AND thread_id = ?`,
+		accountEmail, threadID)
+	if err != nil {
+		return fmt.Errorf("failed to delete thread note: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("no note pinned to this conversation")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestThreadNoteStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/notes.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ns := NewThreadNoteStore(store)
+	const acct = "user@example.com"
+
+	if n, err := ns.Get(ctx, acct, "t1"); err != nil || n != nil {
+		t.Fatalf("want no note, got %+v %v", n, err)
+	}
+	if _, err := ns.Save(ctx, acct, "t1", "Vendor agreed to 30-day terms", "ai"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := ns.Save(ctx, acct, "t1", "Vendor agreed to 45-day terms", ""); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	n, err := ns.Get(ctx, acct, "t1")
+	if err != nil || n == nil || n.Note != "Vendor agreed to 45-day terms" || n.Source != "manual" {
+		t.Fatalf("want updated manual note, got %+v %v", n, err)
+	}
+	if other, _ := ns.Get(ctx, "else@example.com", "t1"); other != nil {
+		t.Fatalf("notes must be scoped to the account, got %+v", other)
+	}
+	if _, err := ns.Save(ctx, acct, "t1", "  ", "manual"); err == nil {
+		t.Fatal("want error saving an empty note")
+	}
+
+	if err := ns.Delete(ctx, acct, "t1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := ns.Delete(ctx, acct, "t1"); err == nil {
+		t.Fatal("want error deleting twice")
+	}
+}
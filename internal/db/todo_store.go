@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Todo statuses
+const (
+	TodoOpen      = "open"
+	TodoDone      = "done"
+	TodoDismissed = "dismissed"
+)
+
+// Todo is an action item extracted from a message
+type Todo struct {
+	ID           int64  `json:"id"`
+	AccountEmail string `json:"account_email"`
+	MessageID    string `json:"message_id"` // source message, for jumping back to it
+	ThreadID     string `json:"thread_id"`
+	Subject      string `json:"subject"` // source message subject
+	Task         string `json:"task"`
+	Owner        string `json:"owner"`
+	Due          string `json:"due"` // as stated in the message, e.g. "Friday" or "2025-03-01"
+	Status       string `json:"status"`
+	CreatedAt    int64  `json:"created_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+// TodoStore handles database operations for extracted action items
+type TodoStore struct {
+	db *sql.DB
+}
+
+// NewTodoStore creates a new todo store
+func NewTodoStore(store *Store) *TodoStore {
+	return &TodoStore{db: store.DB()}
+}
+
+// Add stores an open action item. An item with the same task from the same message is kept as
+// is (status included), so extracting a message twice does not duplicate or reopen it; added
+// reports whether the item is new.
+func (s *TodoStore) Add(ctx context.Context, t Todo) (added bool, err error) {
+	t.Task = strings.TrimSpace(t.Task)
+	if strings.TrimSpace(t.AccountEmail) == "" || t.MessageID == "" || t.Task == "" {
+		return false, fmt.Errorf("account_email, message_id and task cannot be empty")
+	}
+	now := time.Now().Unix()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO todos (account_email, message_id, thread_id, subject, task, owner, due, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.AccountEmail, t.MessageID, t.ThreadID, t.Subject, t.Task, strings.TrimSpace(t.Owner), strings.TrimSpace(t.Due), TodoOpen, now, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to save todo: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// List returns the account's action items, open ones first and then by creation; done and
+// dismissed items are included only when all is set
+func (s *TodoStore) List(ctx context.Context, accountEmail string, all bool) ([]*Todo, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return nil, fmt.Errorf("account_email cannot be empty")
+	}
+	query := `
+		SELECT id, account_email, message_id, thread_id, subject, task, owner, due, status, created_at, updated_at
+		FROM todos
+		WHERE account_email = ?`
+	if !all {
+		query += ` AND status = 'open'`
+	}
+	query += ` ORDER BY CASE status WHEN 'open' THEN 0 WHEN 'done' THEN 1 ELSE 2 END, created_at, id`
+	rows, err := s.db.QueryContext(ctx, query, accountEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*Todo
+	for rows.Next() {
+		t := &Todo{}
+		if err := rows.Scan(&t.ID, &t.AccountEmail, &t.MessageID, &t.ThreadID, &t.Subject, &t.Task, &t.Owner, &t.Due, &t.Status, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// SetStatus marks an action item open, done or dismissed
+func (s *TodoStore) SetStatus(ctx context.Context, accountEmail string, id int64, status string) error {
+	switch status {
+	case TodoOpen, TodoDone, TodoDismissed:
+	default:
+		return fmt.Errorf("unknown todo status %q", status)
+	}
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE todos SET status = ?, updated_at = ? WHERE account_email = ? AND id = ?`,
+		status, time.Now().Unix(), accountEmail, id)
+	if err != nil {
+		return fmt.Errorf("failed to update todo: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("todo %d not found", id)
+	}
+	return nil
+}
+
+// DeleteClosed removes the account's done and dismissed items and returns how many were removed
+func (s *TodoStore) DeleteClosed(ctx context.Context, accountEmail string) (int, error) {
+	if strings.TrimSpace(accountEmail) == "" {
+		return 0, fmt.Errorf("account_email cannot be empty")
+	}
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM todos WHERE account_email = ? AND status != 'open'`,
+		accountEmail)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear todos: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
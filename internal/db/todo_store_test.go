@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTodoStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/todos.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ts := NewTodoStore(store)
+	const acct = "user@example.com"
+
+	for _, task := range []string{"Send the slides", "Book the room"} {
+		if added, err := ts.Add(ctx, Todo{AccountEmail: acct, MessageID: "m1", ThreadID: "t1", Subject: "Offsite", Task: task, Owner: "me"}); err != nil || !added {
+			t.Fatalf("add %q: added=%v err=%v", task, added, err)
+		}
+	}
+	if _, err := ts.Add(ctx, Todo{AccountEmail: acct, MessageID: "m1", Task: " "}); err == nil {
+		t.Fatal("want error adding a todo without a task")
+	}
+
+	open, err := ts.List(ctx, acct, false)
+	if err != nil || len(open) != 2 || open[0].Task != "Send the slides" || open[0].Status != TodoOpen || open[0].Subject != "Offsite" {
+		t.Fatalf("want the two open todos in order, got %+v %v", open, err)
+	}
+
+	if err := ts.SetStatus(ctx, acct, open[0].ID, TodoDone); err != nil {
+		t.Fatalf("done: %v", err)
+	}
+	if err := ts.SetStatus(ctx, acct, open[0].ID, "later"); err == nil {
+		t.Fatal("want error for an unknown status")
+	}
+	if err := ts.SetStatus(ctx, "else@example.com", open[0].ID, TodoOpen); err == nil {
+		t.Fatal("want error updating another account's todo")
+	}
+
+	// Extracting the same message again neither duplicates nor reopens the item
+	if added, err := ts.Add(ctx, Todo{AccountEmail: acct, MessageID: "m1", Task: "Send the slides"}); err != nil || added {
+		t.Fatalf("re-add: added=%v err=%v", added, err)
+	}
+	open, _ = ts.List(ctx, acct, false)
+	if len(open) != 1 || open[0].Task != "Book the room" {
+		t.Fatalf("want only the open todo, got %+v", open)
+	}
+	all, _ := ts.List(ctx, acct, true)
+	if len(all) != 2 || all[1].Status != TodoDone {
+		t.Fatalf("want the done todo listed last, got %+v", all)
+	}
+
+	if n, err := ts.DeleteClosed(ctx, acct); err != nil || n != 1 {
+		t.Fatalf("delete closed: n=%d err=%v", n, err)
+	}
+	if all, _ = ts.List(ctx, acct, true); len(all) != 1 {
+		t.Fatalf("want one todo left, got %+v", all)
+	}
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrashOrigin records the labels a message had before the app moved it to Trash or Spam
+type TrashOrigin struct {
+	AccountEmail string   `json:"account_email"`
+	MessageID    string   `json:"message_id"`
+	Labels       []string `json:"labels"`
+	Folder       string   `json:"folder"` // TRASH or SPAM
+	RecordedAt   int64    `json:"recorded_at"`
+}
+
+// TrashOriginStore handles database operations for trash/spam origins
+type TrashOriginStore struct {
+	db *sql.DB
+}
+
+// NewTrashOriginStore creates a new trash origin store
+func NewTrashOriginStore(store *Store) *TrashOriginStore {
+	return &TrashOriginStore{db: store.DB()}
+}
+
+// Save records a message's labels before it is trashed, replacing an earlier record
+func (s *TrashOriginStore) Save(ctx context.Context, accountEmail, messageID string, labels []string, folder string) error {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(messageID) == "" || strings.TrimSpace(folder) == "" {
+		return fmt.Errorf("account_email, message_id and folder cannot be empty")
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO trash_origins (account_email, message_id, labels, folder, recorded_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(account_email, message_id) DO UPDATE SET
+			labels = excluded.labels,
+			folder = excluded.folder,
+			recorded_at = excluded.recorded_at`,
+		accountEmail, messageID, strings.Join(labels, ","), folder, time.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to save trash origin: %w", err)
+	}
+	return nil
+}
+
+// Get returns the record for a message, or nil when the app did not trash it
+func (s *TrashOriginStore) Get(ctx context.Context, accountEmail, messageID string) (*TrashOrigin, error) {
+	if strings.TrimSpace(accountEmail) == "" || strings.TrimSpace(messageID) == "" {
+		return nil, fmt.Errorf("account_email and message_id cannot be empty")
+	}
+	o := &TrashOrigin{}
+	var labels string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT account_email, message_id, labels, folder, recorded_at
+		FROM trash_origins
+		WHERE account_email = ? AND message_id = ?`,
+		accountEmail, messageID).Scan(&o.AccountEmail, &o.MessageID, &labels, &o.Folder, &o.RecordedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash origin: %w", err)
+	}
+	if labels != "" {
+		o.Labels = strings.Split(labels, ",")
+	}
+	return o, nil
+}
+
+// Delete forgets a message's record once it has been restored
+func (s *TrashOriginStore) Delete(ctx context.Context, accountEmail, messageID string) error {
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM trash_origins WHERE account_email = ? AND message_id = ?`,
+		accountEmail, messageID); err != nil {
+		return fmt.Errorf("failed to delete trash origin: %w", err)
+	}
+	return nil
+}
+
+// DeleteOlderThan drops records made before cutoff (Unix seconds); Gmail empties Trash and Spam
+// after 30 days, so older records point at messages that are gone
+func (s *TrashOriginStore) DeleteOlderThan(ctx context.Context, accountEmail string, cutoff int64) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM trash_origins WHERE account_email = ? AND recorded_at < ?`,
+		accountEmail, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune trash origins: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrashOriginStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, t.TempDir()+"/trash.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ts := NewTrashOriginStore(store)
+	const acct = "user@example.com"
+
+	if o, err := ts.Get(ctx, acct, "m1"); err != nil || o != nil {
+		t.Fatalf("want no record, got %+v %v", o, err)
+	}
+	if err := ts.Save(ctx, acct, "m1", []string{"INBOX", "Label_7"}, "TRASH"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := ts.Save(ctx, acct, "m1", []string{"Label_7"}, "SPAM"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	o, err := ts.Get(ctx, acct, "m1")
+	if err != nil || o == nil || strings.Join(o.Labels, ",") != "Label_7" || o.Folder != "SPAM" {
+		t.Fatalf("want updated record, got %+v %v", o, err)
+	}
+	if other, _ := ts.Get(ctx, "else@example.com", "m1"); other != nil {
+		t.Fatalf("records must be scoped to the account, got %+v", other)
+	}
+	if err := ts.Save(ctx, acct, "m2", nil, "TRASH"); err != nil {
+		t.Fatalf("save without labels: %v", err)
+	}
+	if o, _ := ts.Get(ctx, acct, "m2"); o == nil || len(o.Labels) != 0 {
+		t.Fatalf("want record with no labels, got %+v", o)
+	}
+
+	if err := ts.Delete(ctx, acct, "m1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if o, _ := ts.Get(ctx, acct, "m1"); o != nil {
+		t.Fatalf("want record gone, got %+v", o)
+	}
+	if n, err := ts.DeleteOlderThan(ctx, acct, time.Now().Add(time.Hour).Unix()); err != nil || n != 1 {
+		t.Fatalf("prune: want 1 removed, got %d %v", n, err)
+	}
+}
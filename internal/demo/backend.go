@@ -0,0 +1,447 @@
+package demo
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// systemLabels are the Gmail labels every mailbox has
+var systemLabels = []string{"INBOX", "SENT", "DRAFT", "TRASH", "SPAM", "STARRED", "IMPORTANT", "UNREAD",
+	"CATEGORY_PERSONAL", "CATEGORY_SOCIAL", "CATEGORY_PROMOTIONS", "CATEGORY_UPDATES", "CATEGORY_FORUMS"}
+
+// storedMessage is a message of the fake mailbox with the fields search needs
+type storedMessage struct {
+	msg         *gmailapi.Message // full format, without Raw
+	raw         []byte
+	attachments map[string][]byte
+	text        string
+	filenames   []string
+	from        string
+	to          string
+	cc          string
+	bcc         string
+	subject     string
+	messageID   string // Message-ID header
+	date        time.Time
+}
+
+func (m *storedMessage) hasLabel(id string) bool {
+	for _, l := range m.msg.LabelIds {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Backend is an in-memory Gmail mailbox. It is safe for concurrent use, like the real API.
+type Backend struct {
+	mu       sync.Mutex
+	email    string
+	now      func() time.Time
+	messages map[string]*storedMessage
+	labels   map[string]*gmailapi.Label
+	drafts   map[string]string // draft ID -> message ID
+	seq      int
+	labelSeq int
+	history  uint64
+}
+
+// NewBackend seeds a backend with a fixture mailbox. Fixture ages are relative to now(), which is
+// also the clock of sent messages and relative searches; pass a fixed clock for deterministic tests.
+func NewBackend(mb *Mailbox, now func() time.Time) (*Backend, error) {
+	if now == nil {
+		now = time.Now
+	}
+	b := &Backend{
+		email:    mb.Email,
+		now:      now,
+		messages: make(map[string]*storedMessage),
+		labels:   make(map[string]*gmailapi.Label),
+		drafts:   make(map[string]string),
+		history:  1000,
+	}
+	for _, id := range systemLabels {
+		b.labels[id] = &gmailapi.Label{Id: id, Name: id, Type: "system"}
+	}
+	for _, name := range mb.Labels {
+		if _, err := b.createLabel(name); err != nil {
+			return nil, err
+		}
+	}
+	if err := b.seed(mb); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Email returns the mailbox owner's address
+func (b *Backend) Email() string { return b.email }
+
+func (b *Backend) nextID() string {
+	b.seq++
+	return fmt.Sprintf("18f0demo%08x", b.seq)
+}
+
+// seed adds the fixture messages, oldest first so IDs grow with time like Gmail's
+func (b *Backend) seed(mb *Mailbox) error {
+	type dated struct {
+		fm  FixtureMessage
+		age time.Duration
+	}
+	items := make([]dated, 0, len(mb.Messages))
+	for _, fm := range mb.Messages {
+		age, err := parseAge(fm.Ago)
+		if err != nil {
+			return fmt.Errorf("message %q: %w", fm.Subject, err)
+		}
+		items = append(items, dated{fm, age})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].age > items[j].age })
+
+	start := b.now()
+	threads := make(map[string]*storedMessage) // fixture thread key -> latest message
+	for _, it := range items {
+		fm := it.fm
+		id := b.nextID()
+		date := start.Add(-it.age).Truncate(time.Second)
+		to := fm.To
+		if to == "" {
+			to = formatOwner(mb)
+		}
+		headers := []header{
+			{"From", fm.From},
+			{"To", to},
+			{"Cc", fm.Cc},
+			{"Subject", fm.Subject},
+			{"Date", date.Format(time.RFC1123Z)},
+			{"Message-ID", fmt.Sprintf("<%s@demo.giztui>", id)},
+		}
+		threadID := id
+		if prev, ok := threads[fm.Thread]; ok && fm.Thread != "" {
+			threadID = prev.msg.ThreadId
+			headers = append(headers, header{"In-Reply-To", prev.messageID}, header{"References", prev.messageID})
+		}
+		keys := make([]string, 0, len(fm.Headers))
+		for k := range fm.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			headers = append(headers, header{k, fm.Headers[k]})
+		}
+		text := fm.Body
+		if text == "" && fm.HTML != "" {
+			text = strings.TrimSpace(spacePattern.ReplaceAllString(stripHTML(fm.HTML), " "))
+		}
+		labels, err := b.resolveLabels(fm.Labels)
+		if err != nil {
+			return fmt.Errorf("message %q: %w", fm.Subject, err)
+		}
+		sm, err := b.store(id, threadID, buildRaw(headers, text, fm.HTML, fm.Attachments), labels, date)
+		if err != nil {
+			return fmt.Errorf("message %q: %w", fm.Subject, err)
+		}
+		if fm.Thread != "" {
+			threads[fm.Thread] = sm
+		}
+	}
+	return nil
+}
+
+func formatOwner(mb *Mailbox) string {
+	if mb.Name == "" {
+		return mb.Email
+	}
+	return (&mail.Address{Name: mb.Name, Address: mb.Email}).String()
+}
+
+// resolveLabels maps fixture label references (system IDs or user label names) to label IDs,
+// creating user labels that were not declared
+func (b *Backend) resolveLabels(refs []string) ([]string, error) {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if l, ok := b.labels[strings.ToUpper(ref)]; ok && l.Type == "system" {
+			out = append(out, l.Id)
+			continue
+		}
+		l := b.labelByName(ref)
+		if l == nil {
+			var err error
+			if l, err = b.createLabel(ref); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, l.Id)
+	}
+	return out, nil
+}
+
+// store parses a raw message and adds it to the mailbox
+func (b *Backend) store(id, threadID string, raw []byte, labels []string, date time.Time) (*storedMessage, error) {
+	pm, err := parseRaw(raw, id)
+	if err != nil {
+		return nil, err
+	}
+	if !pm.date.IsZero() {
+		date = pm.date
+	}
+	b.history++
+	sm := &storedMessage{
+		raw:         raw,
+		attachments: pm.attachments,
+		text:        pm.text,
+		filenames:   pm.filenames,
+		from:        headerValue(pm.payload, "From"),
+		to:          headerValue(pm.payload, "To"),
+		cc:          headerValue(pm.payload, "Cc"),
+		bcc:         headerValue(pm.payload, "Bcc"),
+		subject:     headerValue(pm.payload, "Subject"),
+		messageID:   headerValue(pm.payload, "Message-ID"),
+		date:        date,
+		msg: &gmailapi.Message{
+			Id:           id,
+			ThreadId:     threadID,
+			LabelIds:     dedupLabels(labels),
+			Snippet:      makeSnippet(pm.text),
+			InternalDate: date.UnixMilli(),
+			SizeEstimate: int64(len(raw)),
+			HistoryId:    b.history,
+			Payload:      pm.payload,
+		},
+	}
+	b.messages[id] = sm
+	return sm, nil
+}
+
+func dedupLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if !seen[l] {
+			seen[l] = true
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func (b *Backend) labelByName(name string) *gmailapi.Label {
+	for _, l := range b.labels {
+		if strings.EqualFold(l.Name, name) {
+			return l
+		}
+	}
+	return nil
+}
+
+func (b *Backend) createLabel(name string) (*gmailapi.Label, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errInvalid("label name is required")
+	}
+	if b.labelByName(name) != nil {
+		return nil, &apiError{code: 409, status: "ALREADY_EXISTS", message: "Label name exists or conflicts"}
+	}
+	b.labelSeq++
+	l := &gmailapi.Label{
+		Id:                    fmt.Sprintf("Label_%d", b.labelSeq),
+		Name:                  name,
+		Type:                  "user",
+		LabelListVisibility:   "labelShow",
+		MessageListVisibility: "show",
+	}
+	b.labels[l.Id] = l
+	return l, nil
+}
+
+// sortedMessages returns the messages newest first
+func (b *Backend) sortedMessages() []*storedMessage {
+	out := make([]*storedMessage, 0, len(b.messages))
+	for _, m := range b.messages {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].msg.InternalDate != out[j].msg.InternalDate {
+			return out[i].msg.InternalDate > out[j].msg.InternalDate
+		}
+		return out[i].msg.Id > out[j].msg.Id
+	})
+	return out
+}
+
+// search returns the messages matching a query and label filter, newest first
+func (b *Backend) search(q string, labelIDs []string, includeSpamTrash bool) []*storedMessage {
+	parsed := parseQuery(q)
+	includeSpamTrash = includeSpamTrash || parsed.searchesSpamOrTrash()
+	for _, l := range labelIDs {
+		if l == "TRASH" || l == "SPAM" {
+			includeSpamTrash = true
+		}
+	}
+	var out []*storedMessage
+	for _, m := range b.sortedMessages() {
+		if !includeSpamTrash && (m.hasLabel("TRASH") || m.hasLabel("SPAM")) {
+			continue
+		}
+		ok := true
+		for _, l := range labelIDs {
+			if !m.hasLabel(l) {
+				ok = false
+				break
+			}
+		}
+		if ok && parsed.matches(b, m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func containsLabel(labels []string, id string) bool {
+	for _, l := range labels {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+// modify adds and removes labels of a message
+func (b *Backend) modify(id string, add, remove []string) (*storedMessage, error) {
+	m, ok := b.messages[id]
+	if !ok {
+		return nil, errNotFound()
+	}
+	for _, l := range append(append([]string{}, add...), remove...) {
+		if _, ok := b.labels[l]; !ok {
+			return nil, errInvalid("Invalid label: " + l)
+		}
+	}
+	labels := make([]string, 0, len(m.msg.LabelIds)+len(add))
+	for _, l := range m.msg.LabelIds {
+		if !containsLabel(remove, l) {
+			labels = append(labels, l)
+		}
+	}
+	labels = dedupLabels(append(labels, add...))
+	if containsLabel(add, "TRASH") || containsLabel(add, "SPAM") {
+		labels = removeLabel(labels, "INBOX")
+	}
+	m.msg.LabelIds = labels
+	b.history++
+	m.msg.HistoryId = b.history
+	return m, nil
+}
+
+func removeLabel(labels []string, id string) []string {
+	out := labels[:0]
+	for _, l := range labels {
+		if l != id {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// threadMessages returns the messages of a thread, oldest first
+func (b *Backend) threadMessages(threadID string) []*storedMessage {
+	var out []*storedMessage
+	for _, m := range b.messages {
+		if m.msg.ThreadId == threadID {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].msg.InternalDate != out[j].msg.InternalDate {
+			return out[i].msg.InternalDate < out[j].msg.InternalDate
+		}
+		return out[i].msg.Id < out[j].msg.Id
+	})
+	return out
+}
+
+// insertRaw stores a message built by a client (send or draft), threading it like Gmail: by the
+// given thread ID, or by In-Reply-To/References matching a known Message-ID
+func (b *Backend) insertRaw(raw []byte, threadID string, labels []string) (*storedMessage, error) {
+	id := b.nextID()
+	headers, _ := splitHeader(raw)
+	var extra []header
+	if getHeader(headers, "From") == "" {
+		extra = append(extra, header{"From", b.email})
+	}
+	if getHeader(headers, "Date") == "" {
+		extra = append(extra, header{"Date", b.now().Format(time.RFC1123Z)})
+	}
+	if getHeader(headers, "Message-ID") == "" {
+		extra = append(extra, header{"Message-ID", fmt.Sprintf("<%s@demo.giztui>", id)})
+	}
+	if len(extra) > 0 {
+		var pre strings.Builder
+		for _, h := range extra {
+			fmt.Fprintf(&pre, "%s: %s\r\n", h.name, h.value)
+		}
+		raw = append([]byte(pre.String()), raw...)
+	}
+	if threadID == "" || len(b.threadMessages(threadID)) == 0 {
+		threadID = id
+		refs := getHeader(headers, "In-Reply-To") + " " + getHeader(headers, "References")
+		for _, m := range b.messages {
+			if m.messageID != "" && strings.Contains(refs, m.messageID) {
+				threadID = m.msg.ThreadId
+				break
+			}
+		}
+		if threadID == id {
+			threadID = b.threadBySubject(getHeader(headers, "Subject"), getHeader(headers, "To")+","+getHeader(headers, "Cc"), id)
+		}
+	}
+	return b.store(id, threadID, raw, labels, b.now().Truncate(time.Second))
+}
+
+var replyPrefix = regexp.MustCompile(`(?i)^\s*((re|fwd?|aw|wg)\s*:\s*)+`)
+
+// threadBySubject mirrors Gmail's fallback threading for messages without references: a reply
+// ("Re: …") to someone in a conversation with the same base subject joins that conversation
+func (b *Backend) threadBySubject(subject, recipients, fallback string) string {
+	base := strings.ToLower(strings.TrimSpace(replyPrefix.ReplaceAllString(subject, "")))
+	if base == "" || base == strings.ToLower(strings.TrimSpace(subject)) {
+		return fallback
+	}
+	addrs, _ := mail.ParseAddressList(strings.Trim(recipients, ", "))
+	for _, m := range b.sortedMessages() {
+		if strings.ToLower(strings.TrimSpace(replyPrefix.ReplaceAllString(m.subject, ""))) != base {
+			continue
+		}
+		for _, a := range addrs {
+			if containsFold(m.from+" "+m.to+" "+m.cc, strings.ToLower(a.Address)) {
+				return m.msg.ThreadId
+			}
+		}
+	}
+	return fallback
+}
+
+// apiError is a Gmail-style error response
+type apiError struct {
+	code    int
+	status  string
+	message string
+}
+
+func (e *apiError) Error() string { return fmt.Sprintf("%d %s: %s", e.code, e.status, e.message) }
+
+func errNotFound() error {
+	return &apiError{code: 404, status: "NOT_FOUND", message: "Requested entity was not found."}
+}
+
+func errInvalid(msg string) error {
+	return &apiError{code: 400, status: "INVALID_ARGUMENT", message: msg}
+}
@@ -0,0 +1,220 @@
+package demo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/demo"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/services"
+)
+
+var fixedNow = time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+
+func newDemoClient(t *testing.T, name string) (*gmail.Client, *demo.Backend) {
+	t.Helper()
+	mb, err := demo.LoadMailbox(name)
+	if err != nil {
+		t.Fatalf("LoadMailbox(%q): %v", name, err)
+	}
+	client, backend, err := demo.NewClient(context.Background(), mb, func() time.Time { return fixedNow })
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, backend
+}
+
+func subjects(t *testing.T, client *gmail.Client, query string) []string {
+	t.Helper()
+	msgs, err := client.SearchMessages(query, 100)
+	if err != nil {
+		t.Fatalf("SearchMessages(%q): %v", query, err)
+	}
+	out := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		full, err := client.GetMessageMetadata(m.Id)
+		if err != nil {
+			t.Fatalf("GetMessageMetadata: %v", err)
+		}
+		out = append(out, client.ExtractHeader(full, "Subject"))
+	}
+	return out
+}
+
+func TestLoadMailbox_BuiltinsAndUnknown(t *testing.T) {
+	for _, name := range demo.MailboxNames() {
+		if _, err := demo.LoadMailbox(name); err != nil {
+			t.Errorf("LoadMailbox(%q): %v", name, err)
+		}
+	}
+	if _, err := demo.LoadMailbox("nope"); err == nil || !strings.Contains(err.Error(), "work") {
+		t.Fatalf("unknown mailbox error = %v, want list of available mailboxes", err)
+	}
+}
+
+func TestDemo_InboxIsNewestFirstAndPaged(t *testing.T) {
+	client, _ := newDemoClient(t, "work")
+	email, err := client.ActiveAccountEmail(context.Background())
+	if err != nil || email != "alex@demo.giztui.dev" {
+		t.Fatalf("profile email = %q, %v", email, err)
+	}
+	first, next, err := client.ListMessagesPage(5, "")
+	if err != nil || len(first) != 5 || next == "" {
+		t.Fatalf("first page = %d messages, next %q, err %v", len(first), next, err)
+	}
+	top, _ := client.GetMessageMetadata(first[0].Id)
+	if got := client.ExtractHeader(top, "Subject"); got != "Coffee next week?" {
+		t.Fatalf("newest inbox message = %q", got)
+	}
+	second, _, err := client.ListMessagesPage(5, next)
+	if err != nil || len(second) == 0 || second[0].Id == first[0].Id {
+		t.Fatalf("second page = %v, %v", second, err)
+	}
+	// Spam, trash and sent-only messages never show in the inbox
+	all, _ := client.ListMessages(100)
+	for _, m := range all {
+		full, _ := client.GetMessageMetadata(m.Id)
+		if s := client.ExtractHeader(full, "Subject"); s == "You have been selected!!!" || s == "Renewal reminder" {
+			t.Fatalf("inbox contains %q", s)
+		}
+	}
+}
+
+func TestDemo_Search(t *testing.T) {
+	client, _ := newDemoClient(t, "work")
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"from:jordan has:attachment", []string{"Invitation: Helios design review @ Tue 10:00"}},
+		{"subject:\"ci failed\"", []string{"[northwind/atlas] CI failed: main (#1043)", "[northwind/atlas] CI failed: main (#1042)"}},
+		{"label:projects-atlas is:unread", []string{"Re: Atlas launch checklist"}},
+		{"filename:csv", []string{"Q3 budget review — spreadsheet attached"}},
+		{"lisbon is:starred", []string{"Your trip to Lisbon — booking confirmed"}},
+		{"in:spam", []string{"You have been selected!!!"}},
+		{"prize", nil}, // spam stays out unless asked for
+		{"{coffee invoice} -label:finance", []string{"Coffee next week?"}},
+		{"newer_than:1h", []string{"Coffee next week?", "[northwind/atlas] CI passed: main (#1044)"}},
+	}
+	for _, c := range cases {
+		got := subjects(t, client, c.query)
+		if strings.Join(got, "|") != strings.Join(c.want, "|") {
+			t.Errorf("search %q = %q, want %q", c.query, got, c.want)
+		}
+	}
+	est, err := client.EstimateResultSize("from:github")
+	if err != nil || est != 3 {
+		t.Fatalf("estimate = %d, %v; want 3", est, err)
+	}
+}
+
+func TestDemo_ThreadsAndBody(t *testing.T) {
+	client, _ := newDemoClient(t, "work")
+	threads := services.NewThreadService(client, nil, nil)
+	page, err := threads.GetThreads(context.Background(), services.ThreadQueryOptions{Query: "subject:atlas launch", MaxResults: 10})
+	if err != nil || len(page.Threads) != 1 {
+		t.Fatalf("threads = %+v, %v", page, err)
+	}
+	if page.Threads[0].MessageCount != 3 || page.Threads[0].UnreadCount != 1 {
+		t.Fatalf("thread = %+v, want 3 messages with 1 unread", page.Threads[0])
+	}
+
+	msgs, _ := client.SearchMessages("subject:\"q3 budget\"", 1)
+	content, err := client.GetMessageWithContent(msgs[0].Id)
+	if err != nil || !strings.Contains(content.PlainText, "Q3 numbers") {
+		t.Fatalf("body = %+v, %v", content, err)
+	}
+	full, _ := client.GetMessage(msgs[0].Id)
+	var attID string
+	for _, p := range full.Payload.Parts {
+		if p.Filename == "q3-budget.csv" {
+			attID = p.Body.AttachmentId
+		}
+	}
+	data, name, err := client.GetAttachment(msgs[0].Id, attID)
+	if err != nil || name != "q3-budget.csv" || !strings.HasPrefix(string(data), "team,planned,actual") {
+		t.Fatalf("attachment = %q %q %v", name, data, err)
+	}
+}
+
+func TestDemo_BulkOpsAndLabels(t *testing.T) {
+	client, _ := newDemoClient(t, "work")
+	ctx := context.Background()
+	email := services.NewEmailService(services.NewMessageRepository(client), client, nil)
+
+	ci, _ := client.SearchMessages("from:github", 10)
+	ids := make([]string, len(ci))
+	for i, m := range ci {
+		ids[i] = m.Id
+	}
+	if err := email.BulkMarkAsRead(ctx, ids); err != nil {
+		t.Fatalf("BulkMarkAsRead: %v", err)
+	}
+	if err := email.BulkArchive(ctx, ids); err != nil {
+		t.Fatalf("BulkArchive: %v", err)
+	}
+	if got := subjects(t, client, "from:github in:inbox"); len(got) != 0 {
+		t.Fatalf("archived messages still in inbox: %q", got)
+	}
+	if got := subjects(t, client, "from:github is:unread"); len(got) != 0 {
+		t.Fatalf("messages still unread: %q", got)
+	}
+
+	labels := services.NewLabelService(client)
+	l, err := labels.EnsureLabelPath(ctx, "Projects/Atlas/CI")
+	if err != nil {
+		t.Fatalf("EnsureLabelPath: %v", err)
+	}
+	if err := labels.ApplyLabel(ctx, ids[0], l.Id); err != nil {
+		t.Fatalf("ApplyLabel: %v", err)
+	}
+	if got := subjects(t, client, "label:projects-atlas-ci"); len(got) != 1 {
+		t.Fatalf("label search = %q", got)
+	}
+	if err := client.TrashMessage(ids[0]); err != nil {
+		t.Fatalf("TrashMessage: %v", err)
+	}
+	if _, err := client.GetMessage("missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("missing message error = %v, want 404", err)
+	}
+}
+
+func TestDemo_Composition(t *testing.T) {
+	client, _ := newDemoClient(t, "work")
+	ctx := context.Background()
+	email := services.NewEmailService(services.NewMessageRepository(client), client, nil)
+	comp := services.NewCompositionService(email, client, services.NewMessageRepository(client))
+
+	// Reply lands in the original thread
+	orig, _ := client.SearchMessages("subject:\"coffee next week\"", 1)
+	reply, err := comp.CreateComposition(ctx, services.CompositionTypeReply, orig[0].Id)
+	if err != nil {
+		t.Fatalf("CreateComposition: %v", err)
+	}
+	reply.Body = "Wednesday works!"
+	if err := comp.SendComposition(ctx, reply); err != nil {
+		t.Fatalf("SendComposition: %v", err)
+	}
+	sent, _ := client.SearchMessages("in:sent wednesday works", 5)
+	if len(sent) != 1 || sent[0].ThreadId != orig[0].ThreadId {
+		t.Fatalf("sent reply = %+v, want it in thread %s", sent, orig[0].ThreadId)
+	}
+
+	// Drafts round-trip
+	id, err := client.CreateDraft("jordan@contoso.example", "Storage follow-up", "Draft body", nil)
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	if err := client.UpdateDraft(id, "jordan@contoso.example", "Storage follow-up v2", "Draft body", nil); err != nil {
+		t.Fatalf("UpdateDraft: %v", err)
+	}
+	drafts, err := client.ListDrafts(10)
+	if err != nil || len(drafts) != 1 || client.ExtractHeader(drafts[0].Message, "Subject") != "Storage follow-up v2" {
+		t.Fatalf("drafts = %+v, %v", drafts, err)
+	}
+	if err := client.DeleteDraft(id); err != nil {
+		t.Fatalf("DeleteDraft: %v", err)
+	}
+}
@@ -0,0 +1,558 @@
+package demo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+const apiPrefix = "/gmail/v1/users/"
+
+// ServeHTTP implements the subset of the Gmail REST API used by giztui
+func (b *Backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Path
+	if i := strings.Index(p, apiPrefix); i >= 0 {
+		p = p[i+len(apiPrefix):]
+	} else {
+		writeError(w, errNotFound())
+		return
+	}
+	// Drop the user ID ("me" or the mailbox address)
+	if i := strings.Index(p, "/"); i >= 0 {
+		p = p[i+1:]
+	} else {
+		p = ""
+	}
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var (
+		res interface{}
+		err error
+	)
+	q := r.URL.Query()
+	switch parts[0] {
+	case "profile":
+		res = b.profile()
+	case "labels":
+		res, err = b.handleLabels(r, parts[1:])
+	case "messages":
+		res, err = b.handleMessages(r, q, parts[1:])
+	case "threads":
+		res, err = b.handleThreads(r, q, parts[1:])
+	case "drafts":
+		res, err = b.handleDrafts(r, q, parts[1:])
+	default:
+		err = errNotFound()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var ae *apiError
+	if !errors.As(err, &ae) {
+		ae = &apiError{code: 500, status: "INTERNAL", message: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(ae.code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": ae.code, "message": ae.message, "status": ae.status},
+	})
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errInvalid(err.Error())
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errInvalid("invalid JSON payload: " + err.Error())
+	}
+	return nil
+}
+
+// decodeRaw accepts base64url with or without padding, like Gmail
+func decodeRaw(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalid("invalid raw message")
+	}
+	return data, nil
+}
+
+func (b *Backend) profile() *gmailapi.Profile {
+	threads := make(map[string]bool)
+	for _, m := range b.messages {
+		threads[m.msg.ThreadId] = true
+	}
+	return &gmailapi.Profile{
+		EmailAddress:  b.email,
+		MessagesTotal: int64(len(b.messages)),
+		ThreadsTotal:  int64(len(threads)),
+		HistoryId:     b.history,
+	}
+}
+
+// --- labels ---
+
+func (b *Backend) labelWithCounts(l *gmailapi.Label) *gmailapi.Label {
+	out := *l
+	threads, unreadThreads := make(map[string]bool), make(map[string]bool)
+	out.MessagesTotal, out.MessagesUnread = 0, 0
+	for _, m := range b.messages {
+		if !m.hasLabel(l.Id) {
+			continue
+		}
+		out.MessagesTotal++
+		threads[m.msg.ThreadId] = true
+		if m.hasLabel("UNREAD") {
+			out.MessagesUnread++
+			unreadThreads[m.msg.ThreadId] = true
+		}
+	}
+	out.ThreadsTotal = int64(len(threads))
+	out.ThreadsUnread = int64(len(unreadThreads))
+	out.ForceSendFields = []string{"MessagesTotal", "MessagesUnread", "ThreadsTotal", "ThreadsUnread"}
+	return &out
+}
+
+func (b *Backend) handleLabels(r *http.Request, parts []string) (interface{}, error) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			list := make([]*gmailapi.Label, 0, len(b.labels))
+			for _, l := range b.labels {
+				list = append(list, l)
+			}
+			sortLabels(list)
+			return &gmailapi.ListLabelsResponse{Labels: list}, nil
+		case http.MethodPost:
+			var req gmailapi.Label
+			if err := decodeBody(r, &req); err != nil {
+				return nil, err
+			}
+			return b.createLabel(req.Name)
+		}
+		return nil, errInvalid("unsupported method")
+	}
+	l, ok := b.labels[parts[0]]
+	if !ok {
+		return nil, errNotFound()
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return b.labelWithCounts(l), nil
+	case http.MethodPatch, http.MethodPut:
+		if l.Type == "system" {
+			return nil, errInvalid("Invalid update request")
+		}
+		var req gmailapi.Label
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		if req.Name != "" && !strings.EqualFold(req.Name, l.Name) {
+			if b.labelByName(req.Name) != nil {
+				return nil, &apiError{code: 409, status: "ALREADY_EXISTS", message: "Label name exists or conflicts"}
+			}
+			l.Name = req.Name
+		}
+		if req.Color != nil {
+			l.Color = req.Color
+		}
+		return l, nil
+	case http.MethodDelete:
+		if l.Type == "system" {
+			return nil, errInvalid("Invalid delete request")
+		}
+		delete(b.labels, l.Id)
+		for _, m := range b.messages {
+			m.msg.LabelIds = removeLabel(m.msg.LabelIds, l.Id)
+		}
+		return nil, nil
+	}
+	return nil, errInvalid("unsupported method")
+}
+
+// sortLabels orders system labels first, then user labels by name, for stable listings
+func sortLabels(list []*gmailapi.Label) {
+	rank := func(l *gmailapi.Label) int {
+		if l.Type == "system" {
+			return 0
+		}
+		return 1
+	}
+	for i := 1; i < len(list); i++ {
+		for j := i; j > 0; j-- {
+			a, c := list[j-1], list[j]
+			if rank(a) < rank(c) || (rank(a) == rank(c) && strings.ToLower(a.Name) <= strings.ToLower(c.Name)) {
+				break
+			}
+			list[j-1], list[j] = c, a
+		}
+	}
+}
+
+// --- messages ---
+
+// page slices results by offset tokens
+func page(total int, q url.Values) (start, end int, next string) {
+	max := 100
+	if v, err := strconv.Atoi(q.Get("maxResults")); err == nil && v > 0 {
+		max = v
+	}
+	if max > 500 {
+		max = 500
+	}
+	if v, err := strconv.Atoi(q.Get("pageToken")); err == nil && v > 0 {
+		start = v
+	}
+	if start > total {
+		start = total
+	}
+	end = start + max
+	if end > total {
+		end = total
+	}
+	if end < total {
+		next = strconv.Itoa(end)
+	}
+	return start, end, next
+}
+
+// render returns a message in the requested format
+func (b *Backend) render(m *storedMessage, format string, metadataHeaders []string) *gmailapi.Message {
+	out := *m.msg
+	out.LabelIds = append([]string(nil), m.msg.LabelIds...)
+	switch strings.ToLower(format) {
+	case "minimal":
+		out.Payload = nil
+	case "raw":
+		out.Payload = nil
+		out.Raw = base64.URLEncoding.EncodeToString(m.raw)
+	case "metadata":
+		p := &gmailapi.MessagePart{MimeType: m.msg.Payload.MimeType}
+		for _, h := range m.msg.Payload.Headers {
+			if len(metadataHeaders) == 0 || containsFoldAny(metadataHeaders, h.Name) {
+				p.Headers = append(p.Headers, h)
+			}
+		}
+		out.Payload = p
+	}
+	return &out
+}
+
+func containsFoldAny(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+type modifyRequest struct {
+	IDs            []string `json:"ids"`
+	AddLabelIDs    []string `json:"addLabelIds"`
+	RemoveLabelIDs []string `json:"removeLabelIds"`
+}
+
+func (b *Backend) handleMessages(r *http.Request, q url.Values, parts []string) (interface{}, error) {
+	if len(parts) == 0 || parts[0] == "" {
+		if r.Method != http.MethodGet {
+			return nil, errInvalid("unsupported method")
+		}
+		matches := b.search(q.Get("q"), q["labelIds"], q.Get("includeSpamTrash") == "true")
+		start, end, next := page(len(matches), q)
+		res := &gmailapi.ListMessagesResponse{NextPageToken: next, ResultSizeEstimate: int64(len(matches))}
+		for _, m := range matches[start:end] {
+			res.Messages = append(res.Messages, &gmailapi.Message{Id: m.msg.Id, ThreadId: m.msg.ThreadId})
+		}
+		res.ForceSendFields = []string{"ResultSizeEstimate"}
+		return res, nil
+	}
+
+	switch parts[0] {
+	case "send":
+		var req gmailapi.Message
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		raw, err := decodeRaw(req.Raw)
+		if err != nil {
+			return nil, err
+		}
+		sm, err := b.insertRaw(raw, req.ThreadId, []string{"SENT"})
+		if err != nil {
+			return nil, err
+		}
+		return &gmailapi.Message{Id: sm.msg.Id, ThreadId: sm.msg.ThreadId, LabelIds: sm.msg.LabelIds}, nil
+	case "batchModify":
+		var req modifyRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		for _, id := range req.IDs {
+			if _, err := b.modify(id, req.AddLabelIDs, req.RemoveLabelIDs); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case "batchDelete":
+		var req modifyRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		for _, id := range req.IDs {
+			delete(b.messages, id)
+		}
+		return nil, nil
+	}
+
+	m, ok := b.messages[parts[0]]
+	if !ok {
+		return nil, errNotFound()
+	}
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return b.render(m, q.Get("format"), q["metadataHeaders"]), nil
+		case http.MethodDelete:
+			delete(b.messages, m.msg.Id)
+			return nil, nil
+		}
+		return nil, errInvalid("unsupported method")
+	}
+	switch parts[1] {
+	case "modify":
+		var req modifyRequest
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		sm, err := b.modify(m.msg.Id, req.AddLabelIDs, req.RemoveLabelIDs)
+		if err != nil {
+			return nil, err
+		}
+		return b.render(sm, "minimal", nil), nil
+	case "trash":
+		sm, err := b.modify(m.msg.Id, []string{"TRASH"}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return b.render(sm, "minimal", nil), nil
+	case "untrash":
+		sm, err := b.modify(m.msg.Id, nil, []string{"TRASH"})
+		if err != nil {
+			return nil, err
+		}
+		return b.render(sm, "minimal", nil), nil
+	case "attachments":
+		if len(parts) < 3 {
+			return nil, errNotFound()
+		}
+		data, ok := m.attachments[parts[2]]
+		if !ok {
+			return nil, errNotFound()
+		}
+		return &gmailapi.MessagePartBody{
+			AttachmentId: parts[2],
+			Data:         base64.URLEncoding.EncodeToString(data),
+			Size:         int64(len(data)),
+		}, nil
+	}
+	return nil, errNotFound()
+}
+
+// --- threads ---
+
+func (b *Backend) handleThreads(r *http.Request, q url.Values, parts []string) (interface{}, error) {
+	if len(parts) == 0 || parts[0] == "" {
+		// A thread matches when any of its messages does; threads are ordered by their newest match
+		matches := b.search(q.Get("q"), q["labelIds"], q.Get("includeSpamTrash") == "true")
+		var ids []string
+		seen := make(map[string]bool)
+		for _, m := range matches {
+			if !seen[m.msg.ThreadId] {
+				seen[m.msg.ThreadId] = true
+				ids = append(ids, m.msg.ThreadId)
+			}
+		}
+		start, end, next := page(len(ids), q)
+		res := &gmailapi.ListThreadsResponse{NextPageToken: next, ResultSizeEstimate: int64(len(ids))}
+		for _, id := range ids[start:end] {
+			msgs := b.threadMessages(id)
+			last := msgs[len(msgs)-1]
+			res.Threads = append(res.Threads, &gmailapi.Thread{Id: id, Snippet: last.msg.Snippet, HistoryId: last.msg.HistoryId})
+		}
+		res.ForceSendFields = []string{"ResultSizeEstimate"}
+		return res, nil
+	}
+	msgs := b.threadMessages(parts[0])
+	if len(msgs) == 0 {
+		return nil, errNotFound()
+	}
+	if len(parts) > 1 {
+		var add, remove []string
+		switch parts[1] {
+		case "modify":
+			var req modifyRequest
+			if err := decodeBody(r, &req); err != nil {
+				return nil, err
+			}
+			add, remove = req.AddLabelIDs, req.RemoveLabelIDs
+		case "trash":
+			add = []string{"TRASH"}
+		case "untrash":
+			remove = []string{"TRASH"}
+		default:
+			return nil, errNotFound()
+		}
+		for _, m := range msgs {
+			if _, err := b.modify(m.msg.Id, add, remove); err != nil {
+				return nil, err
+			}
+		}
+		return &gmailapi.Thread{Id: parts[0]}, nil
+	}
+	if r.Method == http.MethodDelete {
+		for _, m := range msgs {
+			delete(b.messages, m.msg.Id)
+		}
+		return nil, nil
+	}
+	th := &gmailapi.Thread{Id: parts[0], HistoryId: msgs[len(msgs)-1].msg.HistoryId, Snippet: msgs[len(msgs)-1].msg.Snippet}
+	for _, m := range msgs {
+		th.Messages = append(th.Messages, b.render(m, q.Get("format"), q["metadataHeaders"]))
+	}
+	return th, nil
+}
+
+// --- drafts ---
+
+func (b *Backend) draft(id, format string) (*gmailapi.Draft, error) {
+	msgID, ok := b.drafts[id]
+	if !ok {
+		return nil, errNotFound()
+	}
+	m, ok := b.messages[msgID]
+	if !ok {
+		return nil, errNotFound()
+	}
+	return &gmailapi.Draft{Id: id, Message: b.render(m, format, nil)}, nil
+}
+
+func (b *Backend) handleDrafts(r *http.Request, q url.Values, parts []string) (interface{}, error) {
+	if len(parts) == 0 || parts[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			var ids []string
+			for _, m := range b.sortedMessages() {
+				for id, msgID := range b.drafts {
+					if msgID == m.msg.Id {
+						ids = append(ids, id)
+					}
+				}
+			}
+			start, end, next := page(len(ids), q)
+			res := &gmailapi.ListDraftsResponse{NextPageToken: next, ResultSizeEstimate: int64(len(ids))}
+			for _, id := range ids[start:end] {
+				m := b.messages[b.drafts[id]]
+				res.Drafts = append(res.Drafts, &gmailapi.Draft{Id: id, Message: &gmailapi.Message{Id: m.msg.Id, ThreadId: m.msg.ThreadId}})
+			}
+			return res, nil
+		case http.MethodPost:
+			var req gmailapi.Draft
+			if err := decodeBody(r, &req); err != nil {
+				return nil, err
+			}
+			if req.Message == nil {
+				return nil, errInvalid("draft message is required")
+			}
+			raw, err := decodeRaw(req.Message.Raw)
+			if err != nil {
+				return nil, err
+			}
+			sm, err := b.insertRaw(raw, req.Message.ThreadId, []string{"DRAFT"})
+			if err != nil {
+				return nil, err
+			}
+			id := "r" + strings.TrimPrefix(sm.msg.Id, "18f0demo")
+			b.drafts[id] = sm.msg.Id
+			return b.draft(id, "minimal")
+		}
+		return nil, errInvalid("unsupported method")
+	}
+
+	if parts[0] == "send" {
+		var req gmailapi.Draft
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		msgID, ok := b.drafts[req.Id]
+		if !ok {
+			return nil, errNotFound()
+		}
+		delete(b.drafts, req.Id)
+		sm, err := b.modify(msgID, []string{"SENT"}, []string{"DRAFT"})
+		if err != nil {
+			return nil, err
+		}
+		return b.render(sm, "minimal", nil), nil
+	}
+
+	msgID, ok := b.drafts[parts[0]]
+	if !ok {
+		return nil, errNotFound()
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return b.draft(parts[0], q.Get("format"))
+	case http.MethodPut:
+		var req gmailapi.Draft
+		if err := decodeBody(r, &req); err != nil {
+			return nil, err
+		}
+		if req.Message == nil {
+			return nil, errInvalid("draft message is required")
+		}
+		raw, err := decodeRaw(req.Message.Raw)
+		if err != nil {
+			return nil, err
+		}
+		old := b.messages[msgID]
+		delete(b.messages, msgID)
+		sm, err := b.insertRaw(raw, old.msg.ThreadId, []string{"DRAFT"})
+		if err != nil {
+			return nil, err
+		}
+		b.drafts[parts[0]] = sm.msg.Id
+		return b.draft(parts[0], "minimal")
+	case http.MethodDelete:
+		delete(b.drafts, parts[0])
+		delete(b.messages, msgID)
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported method %s", r.Method)
+}
@@ -0,0 +1,306 @@
+package demo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// header is an ordered header field of a message being built
+type header struct{ name, value string }
+
+// buildRaw renders an RFC 822 message: text/plain, optionally with an HTML alternative and
+// base64 attachments
+func buildRaw(headers []header, text, html string, attachments []FixtureAttachment) []byte {
+	var b bytes.Buffer
+	for _, h := range headers {
+		if h.value != "" {
+			fmt.Fprintf(&b, "%s: %s\r\n", h.name, h.value)
+		}
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	writeText := func(w io.Writer, contentType, body string) {
+		fmt.Fprintf(w, "Content-Type: %s; charset=UTF-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n", contentType)
+		qp := quotedprintable.NewWriter(w)
+		_, _ = qp.Write([]byte(body))
+		_ = qp.Close()
+		_, _ = io.WriteString(w, "\r\n")
+	}
+	alternative := func(w io.Writer, boundary string) {
+		fmt.Fprintf(w, "--%s\r\n", boundary)
+		writeText(w, "text/plain", text)
+		fmt.Fprintf(w, "--%s\r\n", boundary)
+		writeText(w, "text/html", html)
+		fmt.Fprintf(w, "--%s--\r\n", boundary)
+	}
+
+	switch {
+	case len(attachments) > 0:
+		fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=\"mixed\"\r\n\r\n--mixed\r\n")
+		if html != "" {
+			b.WriteString("Content-Type: multipart/alternative; boundary=\"alt\"\r\n\r\n")
+			alternative(&b, "alt")
+		} else {
+			writeText(&b, "text/plain", text)
+		}
+		for _, a := range attachments {
+			mt := a.MimeType
+			if mt == "" {
+				mt = mime.TypeByExtension(strings.ToLower(fileExt(a.Filename)))
+			}
+			if mt == "" {
+				mt = "application/octet-stream"
+			}
+			fmt.Fprintf(&b, "--mixed\r\nContent-Type: %s; name=%q\r\nContent-Disposition: attachment; filename=%q\r\nContent-Transfer-Encoding: base64\r\n\r\n",
+				mt, a.Filename, a.Filename)
+			b.WriteString(wrap76(base64.StdEncoding.EncodeToString([]byte(a.Content))))
+			b.WriteString("\r\n")
+		}
+		b.WriteString("--mixed--\r\n")
+	case html != "":
+		b.WriteString("Content-Type: multipart/alternative; boundary=\"alt\"\r\n\r\n")
+		alternative(&b, "alt")
+	default:
+		writeText(&b, "text/plain", text)
+	}
+	return b.Bytes()
+}
+
+func fileExt(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i:]
+	}
+	return ""
+}
+
+func wrap76(s string) string {
+	var b strings.Builder
+	for len(s) > 76 {
+		b.WriteString(s[:76])
+		b.WriteString("\r\n")
+		s = s[76:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// parsedMessage is a raw message decoded into the Gmail payload shape
+type parsedMessage struct {
+	payload     *gmailapi.MessagePart
+	attachments map[string][]byte // attachment ID -> content
+	text        string            // plain text (or stripped HTML) used for snippets and search
+	filenames   []string
+	date        time.Time
+}
+
+var wordDecoder = mime.WordDecoder{}
+
+// splitHeader separates the header block from the body, keeping field names and order as written
+// (net/mail would canonicalize "Message-ID" to "Message-Id", unlike Gmail)
+func splitHeader(data []byte) ([]header, []byte) {
+	text := string(data)
+	end, sep := strings.Index(text, "\r\n\r\n"), 4
+	if lf := strings.Index(text, "\n\n"); lf >= 0 && (end < 0 || lf < end) {
+		end, sep = lf, 2
+	}
+	var block, body string
+	if end < 0 {
+		block = text
+	} else {
+		block, body = text[:end], text[end+sep:]
+	}
+	var headers []header
+	for _, line := range strings.Split(strings.ReplaceAll(block, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(headers) > 0 {
+			headers[len(headers)-1].value += " " + strings.TrimSpace(line)
+			continue
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			headers = append(headers, header{name: strings.TrimSpace(line[:i]), value: strings.TrimSpace(line[i+1:])})
+		}
+	}
+	return headers, []byte(body)
+}
+
+func getHeader(headers []header, name string) string {
+	for _, h := range headers {
+		if strings.EqualFold(h.name, name) {
+			return h.value
+		}
+	}
+	return ""
+}
+
+// splitMultipart returns the raw parts (headers included) between boundary delimiters
+func splitMultipart(body []byte, boundary string) [][]byte {
+	delim := "--" + boundary
+	var parts [][]byte
+	var cur []string
+	in := false
+	for _, line := range strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimRight(line, " \t")
+		if trimmed == delim || trimmed == delim+"--" {
+			if in {
+				parts = append(parts, []byte(strings.Join(cur, "\r\n")))
+			}
+			cur = nil
+			in = trimmed == delim
+			if !in {
+				break
+			}
+			continue
+		}
+		if in {
+			cur = append(cur, line)
+		}
+	}
+	return parts
+}
+
+// parseRaw decodes an RFC 822 message. idPrefix keeps attachment IDs unique per message.
+func parseRaw(raw []byte, idPrefix string) (*parsedMessage, error) {
+	headers, body := splitHeader(raw)
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("invalid message: no headers")
+	}
+	pm := &parsedMessage{attachments: make(map[string][]byte)}
+	if d, err := mail.ParseDate(getHeader(headers, "Date")); err == nil {
+		pm.date = d
+	}
+	var plain, html strings.Builder
+	pm.payload = pm.parsePart(headers, body, "", idPrefix, &plain, &html)
+	pm.text = plain.String()
+	if strings.TrimSpace(pm.text) == "" {
+		pm.text = stripHTML(html.String())
+	}
+	return pm, nil
+}
+
+func (pm *parsedMessage) parsePart(h []header, body []byte, partID, idPrefix string, plain, html *strings.Builder) *gmailapi.MessagePart {
+	part := &gmailapi.MessagePart{PartId: partID, Headers: gmailHeaders(h), Body: &gmailapi.MessagePartBody{}}
+	mediaType, params, err := mime.ParseMediaType(getHeader(h, "Content-Type"))
+	if err != nil || mediaType == "" {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	part.MimeType = mediaType
+
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		for i, raw := range splitMultipart(body, params["boundary"]) {
+			ph, pb := splitHeader(raw)
+			childID := fmt.Sprint(i)
+			if partID != "" {
+				childID = partID + "." + childID
+			}
+			part.Parts = append(part.Parts, pm.parsePart(ph, pb, childID, idPrefix, plain, html))
+		}
+		return part
+	}
+
+	data := decodeTransfer(getHeader(h, "Content-Transfer-Encoding"), body)
+	filename := ""
+	if _, dp, err := mime.ParseMediaType(getHeader(h, "Content-Disposition")); err == nil {
+		filename = dp["filename"]
+	}
+	if filename == "" {
+		filename = params["name"]
+	}
+	if filename != "" {
+		if dec, err := wordDecoder.DecodeHeader(filename); err == nil {
+			filename = dec
+		}
+		part.Filename = filename
+		attID := fmt.Sprintf("%s_att%s", idPrefix, strings.ReplaceAll(partID, ".", "_"))
+		pm.attachments[attID] = data
+		pm.filenames = append(pm.filenames, filename)
+		part.Body.AttachmentId = attID
+		part.Body.Size = int64(len(data))
+		return part
+	}
+	part.Body.Data = base64.URLEncoding.EncodeToString(data)
+	part.Body.Size = int64(len(data))
+	switch mediaType {
+	case "text/plain":
+		plain.Write(data)
+	case "text/html":
+		html.Write(data)
+	}
+	return part
+}
+
+func decodeTransfer(encoding string, body []byte) []byte {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		clean := strings.Map(func(r rune) rune {
+			if r == '\r' || r == '\n' || r == ' ' {
+				return -1
+			}
+			return r
+		}, string(body))
+		if data, err := base64.StdEncoding.DecodeString(clean); err == nil {
+			return data
+		}
+	case "quoted-printable":
+		if data, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(body))); err == nil {
+			return data
+		}
+	}
+	return body
+}
+
+// gmailHeaders converts header fields to Gmail's list, decoding encoded words like Gmail does
+func gmailHeaders(h []header) []*gmailapi.MessagePartHeader {
+	out := make([]*gmailapi.MessagePartHeader, 0, len(h))
+	for _, f := range h {
+		v := f.value
+		if dec, err := wordDecoder.DecodeHeader(v); err == nil {
+			v = dec
+		}
+		out = append(out, &gmailapi.MessagePartHeader{Name: f.name, Value: v})
+	}
+	return out
+}
+
+var (
+	tagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern = regexp.MustCompile(`\s+`)
+)
+
+func stripHTML(s string) string {
+	return tagPattern.ReplaceAllString(s, " ")
+}
+
+// makeSnippet returns the first ~200 characters of text with whitespace collapsed
+func makeSnippet(text string) string {
+	s := strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+	r := []rune(s)
+	if len(r) > 200 {
+		r = r[:200]
+	}
+	return string(r)
+}
+
+// headerValue returns the first value of a header in a payload
+func headerValue(p *gmailapi.MessagePart, name string) string {
+	if p == nil {
+		return ""
+	}
+	for _, h := range p.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+package demo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryTerm is one search term: an operator ("" for free text) and its value
+type queryTerm struct {
+	negate bool
+	op     string
+	value  string
+}
+
+// query is a parsed Gmail search: every group must match, and a group matches when any of its
+// terms does ("a OR b", "{a b}")
+type query [][]queryTerm
+
+// tokenizeQuery splits a query on spaces, keeping quoted phrases and {…} groups together
+func tokenizeQuery(q string) []string {
+	var tokens []string
+	var cur strings.Builder
+	inQuote, depth := false, 0
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range q {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case r == '{' && !inQuote:
+			depth++
+			cur.WriteRune(r)
+		case r == '}' && !inQuote:
+			depth--
+			cur.WriteRune(r)
+		case (r == ' ' || r == '\t') && !inQuote && depth <= 0:
+			flush()
+		case (r == '(' || r == ')') && !inQuote && depth <= 0:
+			flush() // grouping parentheses are treated as plain AND
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func parseTerm(tok string) queryTerm {
+	t := queryTerm{}
+	if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+		t.negate = true
+		tok = tok[1:]
+	}
+	if i := strings.Index(tok, ":"); i > 0 && !strings.HasPrefix(tok, `"`) {
+		t.op = strings.ToLower(tok[:i])
+		tok = tok[i+1:]
+	}
+	t.value = strings.ToLower(strings.Trim(tok, `"`))
+	return t
+}
+
+// parseQuery parses the subset of Gmail's search syntax the fake backend understands
+func parseQuery(q string) query {
+	var out query
+	pendingOr := false
+	for _, tok := range tokenizeQuery(q) {
+		if tok == "OR" || tok == "|" {
+			pendingOr = len(out) > 0
+			continue
+		}
+		var group []queryTerm
+		if strings.HasPrefix(tok, "{") && strings.HasSuffix(tok, "}") {
+			for _, inner := range tokenizeQuery(tok[1 : len(tok)-1]) {
+				group = append(group, parseTerm(inner))
+			}
+		} else {
+			group = []queryTerm{parseTerm(tok)}
+		}
+		if pendingOr {
+			out[len(out)-1] = append(out[len(out)-1], group...)
+			pendingOr = false
+			continue
+		}
+		out = append(out, group)
+	}
+	return out
+}
+
+// searchesSpamOrTrash reports whether the query asks for spam/trash explicitly; otherwise Gmail
+// leaves those out of results
+func (q query) searchesSpamOrTrash() bool {
+	for _, group := range q {
+		for _, t := range group {
+			if !t.negate && t.op == "in" && (t.value == "trash" || t.value == "spam" || t.value == "anywhere") {
+				return true
+			}
+			if !t.negate && t.op == "label" && (t.value == "trash" || t.value == "spam") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (q query) matches(b *Backend, m *storedMessage) bool {
+	for _, group := range q {
+		matched := false
+		for _, t := range group {
+			if b.termMatches(t, m) != t.negate {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(haystack, needle string) bool {
+	return strings.Contains(strings.ToLower(haystack), needle)
+}
+
+// normalizeLabelName folds a label name the way Gmail matches it in label: ("My Work/Q1" ==
+// "my-work-q1")
+func normalizeLabelName(name string) string {
+	return strings.NewReplacer(" ", "-", "/", "-", "_", "-").Replace(strings.ToLower(name))
+}
+
+func (b *Backend) hasLabelNamed(m *storedMessage, name string) bool {
+	want := normalizeLabelName(name)
+	for _, id := range m.msg.LabelIds {
+		if normalizeLabelName(id) == want {
+			return true
+		}
+		if l, ok := b.labels[id]; ok && normalizeLabelName(l.Name) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Backend) termMatches(t queryTerm, m *storedMessage) bool {
+	v := t.value
+	switch t.op {
+	case "":
+		return containsFold(m.subject, v) || containsFold(m.from, v) || containsFold(m.to, v) ||
+			containsFold(m.text, v) || containsFold(strings.Join(m.filenames, " "), v)
+	case "from":
+		return containsFold(m.from, v) || (v == "me" && containsFold(m.from, strings.ToLower(b.email)))
+	case "to":
+		return containsFold(m.to, v) || containsFold(m.cc, v) || (v == "me" && containsFold(m.to+m.cc, strings.ToLower(b.email)))
+	case "cc":
+		return containsFold(m.cc, v)
+	case "bcc":
+		return containsFold(m.bcc, v)
+	case "subject":
+		return containsFold(m.subject, v)
+	case "filename":
+		return containsFold(strings.Join(m.filenames, " "), v)
+	case "has":
+		switch v {
+		case "attachment":
+			return len(m.filenames) > 0
+		case "userlabels":
+			for _, id := range m.msg.LabelIds {
+				if strings.HasPrefix(id, "Label_") {
+					return true
+				}
+			}
+			return false
+		}
+		return false
+	case "is":
+		switch v {
+		case "unread":
+			return m.hasLabel("UNREAD")
+		case "read":
+			return !m.hasLabel("UNREAD")
+		case "starred":
+			return m.hasLabel("STARRED")
+		case "important":
+			return m.hasLabel("IMPORTANT")
+		}
+		return b.hasLabelNamed(m, v)
+	case "in", "label":
+		switch v {
+		case "anywhere":
+			return true
+		case "archive":
+			return !m.hasLabel("INBOX") && !m.hasLabel("SENT") && !m.hasLabel("DRAFT") && !m.hasLabel("TRASH") && !m.hasLabel("SPAM")
+		case "drafts":
+			return m.hasLabel("DRAFT")
+		}
+		return b.hasLabelNamed(m, v)
+	case "category":
+		return m.hasLabel("CATEGORY_" + strings.ToUpper(v))
+	case "after", "newer":
+		d, ok := parseQueryDate(v)
+		return ok && !m.date.Before(d)
+	case "before", "older":
+		d, ok := parseQueryDate(v)
+		return ok && m.date.Before(d)
+	case "newer_than":
+		d, ok := parseRelative(v)
+		return ok && m.date.After(b.now().Add(-d))
+	case "older_than":
+		d, ok := parseRelative(v)
+		return ok && m.date.Before(b.now().Add(-d))
+	}
+	// Unknown operators match as text, like Gmail does
+	return containsFold(m.subject+" "+m.text, t.op+":"+v)
+}
+
+// parseQueryDate accepts YYYY/MM/DD, YYYY-MM-DD and Unix seconds
+func parseQueryDate(v string) (time.Time, bool) {
+	for _, layout := range []string{"2006/01/02", "2006-01-02", "2006/1/2"} {
+		if d, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return d, true
+		}
+	}
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), true
+	}
+	return time.Time{}, false
+}
+
+// parseRelative parses newer_than/older_than values: 3d, 2m (months), 1y, 12h
+func parseRelative(v string) (time.Duration, bool) {
+	if len(v) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil {
+		return 0, false
+	}
+	day := 24 * time.Hour
+	switch v[len(v)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * day, true
+	case 'w':
+		return time.Duration(n) * 7 * day, true
+	case 'm':
+		return time.Duration(n) * 30 * day, true
+	case 'y':
+		return time.Duration(n) * 365 * day, true
+	}
+	return 0, false
+}
@@ -0,0 +1,52 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	gmailapi "google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+// transport answers every request in-process from the backend; nothing leaves the machine
+type transport struct{ handler http.Handler }
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	res := rec.Result()
+	res.Request = req
+	return res, nil
+}
+
+// Service returns a Gmail API service backed by this mailbox
+func (b *Backend) Service(ctx context.Context) (*gmailapi.Service, error) {
+	svc, err := gmailapi.NewService(ctx,
+		option.WithHTTPClient(&http.Client{Transport: transport{b}}),
+		option.WithEndpoint("https://gmail.demo.invalid/"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create demo Gmail service: %w", err)
+	}
+	return svc, nil
+}
+
+// NewClient seeds a backend with the mailbox and returns a regular Gmail client talking to it
+func NewClient(ctx context.Context, mb *Mailbox, now func() time.Time) (*gmail.Client, *Backend, error) {
+	b, err := NewBackend(mb, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc, err := b.Service(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gmail.NewClient(svc), b, nil
+}
@@ -1,18 +1,14 @@
 package gmail
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
-	"io"
 	"mime"
-	"mime/quotedprintable"
 	"net/mail"
 	"strings"
 	"time"
 
-	"golang.org/x/net/html/charset"
 	"google.golang.org/api/gmail/v1"
 )
 
@@ -20,6 +16,26 @@ import (
 type Client struct {
 	Service      *gmail.Service
 	profileEmail string
+	labelSource  LabelNameSource
+}
+
+// LabelNameSource resolves label IDs to names from a cache instead of listing the labels from
+// Gmail on every message load, and is told when the client changes labels
+type LabelNameSource interface {
+	NameMap() map[string]string
+	Invalidate()
+}
+
+// SetLabelNameSource makes the client resolve label names through src; nil lists them each time
+func (c *Client) SetLabelNameSource(src LabelNameSource) {
+	c.labelSource = src
+}
+
+// labelsChanged tells the label name source that a label was created, renamed or deleted
+func (c *Client) labelsChanged() {
+	if c.labelSource != nil {
+		c.labelSource.Invalidate()
+	}
 }
 
 // NewClient creates a new Gmail client
@@ -55,6 +71,8 @@ type Message struct {
 	Cc        string
 	Date      time.Time
 	Labels    []string
+	// PartErrors lists the parts that could not be fully decoded; the content shown is partial
+	PartErrors []string
 }
 
 // ListMessages returns first page of inbox messages (backward-compatible)
@@ -118,6 +136,24 @@ func (c *Client) GetMessageMetadata(id string) (*gmail.Message, error) {
 	return msg, nil
 }
 
+// GetThreadMetadata retrieves a thread with only the metadata (headers, labels, dates) of its
+// messages. Unlike cached thread views it always asks Gmail, so it reflects replies that just arrived.
+func (c *Client) GetThreadMetadata(threadID string) (*gmail.Thread, error) {
+	if c.Service == nil {
+		return nil, fmt.Errorf("gmail client not initialized")
+	}
+
+	thread, err := c.Service.Users.Threads.Get("me", threadID).
+		Format("metadata").
+		MetadataHeaders("From", "Subject", "Date").
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("could not get thread: %w", err)
+	}
+
+	return thread, nil
+}
+
 // MessageResult represents the result of fetching a message
 type MessageResult struct {
 	Message *gmail.Message
@@ -236,6 +272,23 @@ func (c *Client) GetMessagesMetadataParallel(messageIDs []string, maxWorkers int
 	return messages, nil
 }
 
+// GetMessageRaw returns the RFC 5322 source of a message as stored by Gmail (format=raw)
+func (c *Client) GetMessageRaw(id string) ([]byte, error) {
+	user := "me"
+	msg, err := c.Service.Users.Messages.Get(user, id).Format("raw").Do()
+	if err != nil {
+		return nil, fmt.Errorf("could not get raw message: %w", err)
+	}
+	if msg.Raw == "" {
+		return nil, fmt.Errorf("could not get raw message: empty body")
+	}
+	data, err := base64.URLEncoding.DecodeString(msg.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode raw message: %w", err)
+	}
+	return data, nil
+}
+
 // GetMessageWithContent retrieves a message and extracts its content
 func (c *Client) GetMessageWithContent(id string) (*Message, error) {
 	msg, err := c.GetMessage(id)
@@ -253,25 +306,54 @@ func (c *Client) GetMessageWithContent(id string) (*Message, error) {
 	message.Date = extractDate(msg)
 	// Map label IDs to human-friendly names and filter system labels to align with labels UI
 	message.Labels = c.humanReadableLabels(extractLabels(msg))
+	if message.PartErrors = DiagnoseParts(msg); len(message.PartErrors) > 0 {
+		c.salvageFromRaw(id, message)
+	}
 
 	return message, nil
 }
 
+// salvageFromRaw retries a message whose parts did not decode by parsing its raw source, filling
+// in the content Gmail's parsed payload lost. A clean raw parse replaces the partial content.
+func (c *Client) salvageFromRaw(id string, message *Message) {
+	raw, err := c.GetMessageRaw(id)
+	if err != nil {
+		message.PartErrors = append(message.PartErrors, fmt.Sprintf("raw source retry: %v", err))
+		return
+	}
+	text, html, problems := ParseRawContent(raw)
+	if len(problems) == 0 && (text != "" || html != "") {
+		message.PlainText, message.HTML, message.PartErrors = text, html, nil
+		return
+	}
+	if message.PlainText == "" {
+		message.PlainText = text
+	}
+	if message.HTML == "" {
+		message.HTML = html
+	}
+}
+
 // humanReadableLabels converts label IDs to names and filters out non-actionable system labels
 func (c *Client) humanReadableLabels(labelIDs []string) []string {
 	if len(labelIDs) == 0 {
 		return []string{}
 	}
 
-	// Build ID->Name map once per call (fast enough and simple)
-	labels, err := c.ListLabels()
-	if err != nil {
-		// If we cannot load labels, return the raw IDs as a fallback
-		return labelIDs
-	}
-	idToName := make(map[string]string, len(labels))
-	for _, l := range labels {
-		idToName[l.Id] = l.Name
+	var idToName map[string]string
+	if c.labelSource != nil {
+		idToName = c.labelSource.NameMap()
+	} else {
+		// Build ID->Name map once per call (fast enough and simple)
+		labels, err := c.ListLabels()
+		if err != nil {
+			// If we cannot load labels, return the raw IDs as a fallback
+			return labelIDs
+		}
+		idToName = make(map[string]string, len(labels))
+		for _, l := range labels {
+			idToName[l.Id] = l.Name
+		}
 	}
 
 	var out []string
@@ -327,6 +409,55 @@ func (c *Client) SearchMessagesPage(query string, maxResults int64, pageToken st
 	return res.Messages, res.NextPageToken, nil
 }
 
+// EstimateResultSize returns Gmail's resultSizeEstimate for a query; an empty query estimates the
+// inbox (same scope as ListMessagesPage). The number is approximate by Gmail's own definition.
+func (c *Client) EstimateResultSize(query string) (int64, error) {
+	call := c.Service.Users.Messages.List("me").MaxResults(1).Fields("resultSizeEstimate")
+	if query == "" {
+		call = call.LabelIds("INBOX")
+	} else {
+		call = call.Q(query)
+	}
+	res, err := call.Do()
+	if err != nil {
+		return 0, fmt.Errorf("could not estimate result size: %w", err)
+	}
+	return res.ResultSizeEstimate, nil
+}
+
+// PageTokenAfter returns the page token that starts after the first skip results of a query
+// (empty query = inbox). Only message IDs and tokens are requested, in chunks of up to 500, so
+// deep pages can be reached without loading the metadata of every page before them.
+func (c *Client) PageTokenAfter(query string, skip int64) (string, error) {
+	token := ""
+	var seen int64
+	for seen < skip {
+		chunk := skip - seen
+		if chunk > 500 {
+			chunk = 500
+		}
+		call := c.Service.Users.Messages.List("me").MaxResults(chunk).Fields("messages/id", "nextPageToken")
+		if query == "" {
+			call = call.LabelIds("INBOX")
+		} else {
+			call = call.Q(query)
+		}
+		if token != "" {
+			call = call.PageToken(token)
+		}
+		res, err := call.Do()
+		if err != nil {
+			return "", fmt.Errorf("could not page through messages: %w", err)
+		}
+		seen += int64(len(res.Messages))
+		token = res.NextPageToken
+		if token == "" || len(res.Messages) == 0 {
+			return "", fmt.Errorf("only %d results available", seen)
+		}
+	}
+	return token, nil
+}
+
 // ListDrafts returns draft messages with full message content
 func (c *Client) ListDrafts(maxResults int64) ([]*gmail.Draft, error) {
 	user := "me"
@@ -599,6 +730,27 @@ func (c *Client) ArchiveMessage(messageID string) error {
 	return nil
 }
 
+// MaxBatchModifyIDs is the most message IDs Gmail accepts in one batchModify call
+const MaxBatchModifyIDs = 1000
+
+// BatchModifyMessages adds and removes labels on up to MaxBatchModifyIDs messages in one call.
+// Adding TRASH moves the messages to the trash like TrashMessage does.
+func (c *Client) BatchModifyMessages(messageIDs, addLabelIDs, removeLabelIDs []string) error {
+	user := "me"
+	if len(messageIDs) > MaxBatchModifyIDs {
+		return fmt.Errorf("batch modify accepts at most %d messages, got %d", MaxBatchModifyIDs, len(messageIDs))
+	}
+	req := &gmail.BatchModifyMessagesRequest{
+		Ids:            messageIDs,
+		AddLabelIds:    addLabelIDs,
+		RemoveLabelIds: removeLabelIDs,
+	}
+	if err := c.Service.Users.Messages.BatchModify(user, req).Do(); err != nil {
+		return fmt.Errorf("could not modify messages: %w", err)
+	}
+	return nil
+}
+
 // ListLabels returns all labels
 func (c *Client) ListLabels() ([]*gmail.Label, error) {
 	user := "me"
@@ -610,6 +762,53 @@ func (c *Client) ListLabels() ([]*gmail.Label, error) {
 	return res.Labels, nil
 }
 
+// GetLabel returns a label with its message and unread counts, which ListLabels leaves out
+func (c *Client) GetLabel(labelID string) (*gmail.Label, error) {
+	user := "me"
+	label, err := c.Service.Users.Labels.Get(user, labelID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("could not get label: %w", err)
+	}
+	return label, nil
+}
+
+// CurrentHistoryID returns the mailbox's latest history ID, the starting point for ListHistory
+func (c *Client) CurrentHistoryID() (uint64, error) {
+	prof, err := c.Service.Users.GetProfile("me").Fields("historyId").Do()
+	if err != nil {
+		return 0, fmt.Errorf("could not get history id: %w", err)
+	}
+	return prof.HistoryId, nil
+}
+
+// ListHistory returns the message additions, deletions and label changes since startHistoryID and
+// the mailbox's latest history ID. Gmail keeps about a week of history; an older start fails.
+func (c *Client) ListHistory(startHistoryID uint64) ([]*gmail.History, uint64, error) {
+	user := "me"
+	var out []*gmail.History
+	latest := startHistoryID
+	token := ""
+	for {
+		call := c.Service.Users.History.List(user).StartHistoryId(startHistoryID).
+			HistoryTypes("messageAdded", "messageDeleted", "labelAdded", "labelRemoved")
+		if token != "" {
+			call = call.PageToken(token)
+		}
+		res, err := call.Do()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not list history: %w", err)
+		}
+		out = append(out, res.History...)
+		if res.HistoryId > latest {
+			latest = res.HistoryId
+		}
+		if res.NextPageToken == "" {
+			return out, latest, nil
+		}
+		token = res.NextPageToken
+	}
+}
+
 // RenameLabel updates the name of an existing label
 func (c *Client) RenameLabel(labelID, newName string) (*gmail.Label, error) {
 	user := "me"
@@ -626,6 +825,7 @@ func (c *Client) RenameLabel(labelID, newName string) (*gmail.Label, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to rename label: %w", err)
 	}
+	c.labelsChanged()
 	return updated, nil
 }
 
@@ -642,6 +842,7 @@ func (c *Client) DeleteLabel(labelID string) error {
 	if err := c.Service.Users.Labels.Delete(user, labelID).Do(); err != nil {
 		return fmt.Errorf("failed to delete label: %w", err)
 	}
+	c.labelsChanged()
 	return nil
 }
 
@@ -656,6 +857,7 @@ func (c *Client) CreateLabel(name string) (*gmail.Label, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not create label: %w", err)
 	}
+	c.labelsChanged()
 
 	return createdLabel, nil
 }
@@ -771,6 +973,10 @@ func extractLabels(msg *gmail.Message) []string {
 	return msg.LabelIds
 }
 
+// AMPMimeType is the MIME type of the AMP for Email alternative; its markup is for Gmail's web
+// client, so it is never shown as the message text
+const AMPMimeType = "text/x-amp-html"
+
 // ExtractPlainText extracts plain text content from a Gmail message
 func ExtractPlainText(msg *gmail.Message) string {
 	if msg.Payload == nil {
@@ -781,46 +987,14 @@ func ExtractPlainText(msg *gmail.Message) string {
 }
 
 func extractTextFromPart(part *gmail.MessagePart) string {
-	if part == nil {
+	if part == nil || strings.EqualFold(part.MimeType, AMPMimeType) {
 		return ""
 	}
 
 	// If this part has text content
 	if part.Body != nil && part.Body.Data != "" {
-		data, err := base64.URLEncoding.DecodeString(part.Body.Data)
-		if err != nil {
-			return ""
-		}
-		// Decode quoted-printable if declared
-		isQP := false
-		charsetLabel := ""
-		for _, h := range part.Headers {
-			if strings.EqualFold(h.Name, "Content-Transfer-Encoding") && strings.Contains(strings.ToLower(h.Value), "quoted-printable") {
-				isQP = true
-			}
-			if strings.EqualFold(h.Name, "Content-Type") {
-				// naive charset extraction
-				lower := strings.ToLower(h.Value)
-				if idx := strings.Index(lower, "charset="); idx != -1 {
-					charsetLabel = strings.Trim(strings.TrimSpace(lower[idx+8:]), ";\" ")
-				}
-			}
-		}
-		raw := data
-		if isQP {
-			decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data)))
-			if err == nil {
-				raw = decoded
-			}
-		}
-		if charsetLabel != "" && !strings.EqualFold(charsetLabel, "utf-8") && !strings.EqualFold(charsetLabel, "utf8") {
-			if r, err := charset.NewReaderLabel(charsetLabel, bytes.NewReader(raw)); err == nil {
-				if b, err2 := io.ReadAll(r); err2 == nil {
-					return string(b)
-				}
-			}
-		}
-		return string(raw)
+		text, _ := decodeTextPart(part)
+		return text
 	}
 
 	// Recursively check parts
@@ -848,37 +1022,8 @@ func extractHTMLFromPart(part *gmail.MessagePart) string {
 
 	// If this part has html content
 	if part.Body != nil && part.Body.Data != "" && strings.EqualFold(part.MimeType, "text/html") {
-		data, err := base64.URLEncoding.DecodeString(part.Body.Data)
-		if err != nil {
-			return ""
-		}
-		isQP := false
-		charsetLabel := ""
-		for _, h := range part.Headers {
-			if strings.EqualFold(h.Name, "Content-Transfer-Encoding") && strings.Contains(strings.ToLower(h.Value), "quoted-printable") {
-				isQP = true
-			}
-			if strings.EqualFold(h.Name, "Content-Type") {
-				lower := strings.ToLower(h.Value)
-				if idx := strings.Index(lower, "charset="); idx != -1 {
-					charsetLabel = strings.Trim(strings.TrimSpace(lower[idx+8:]), ";\" ")
-				}
-			}
-		}
-		raw := data
-		if isQP {
-			if decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data))); err == nil {
-				raw = decoded
-			}
-		}
-		if charsetLabel != "" && !strings.EqualFold(charsetLabel, "utf-8") && !strings.EqualFold(charsetLabel, "utf8") {
-			if r, err := charset.NewReaderLabel(charsetLabel, bytes.NewReader(raw)); err == nil {
-				if b, err2 := io.ReadAll(r); err2 == nil {
-					return string(b)
-				}
-			}
-		}
-		return string(raw)
+		html, _ := decodeTextPart(part)
+		return html
 	}
 
 	// Recursively check parts
@@ -922,6 +1067,10 @@ func (c *Client) CreateMessageFromRaw(rawMsg *gmail.Message) *Message {
 	message.Cc = extractHeader(rawMsg, "Cc")
 	message.Date = extractDate(rawMsg)
 	message.Labels = c.humanReadableLabels(extractLabels(rawMsg))
+	// Only a message with undecodable parts costs the extra raw fetch
+	if message.PartErrors = DiagnoseParts(rawMsg); len(message.PartErrors) > 0 && rawMsg.Id != "" {
+		c.salvageFromRaw(rawMsg.Id, message)
+	}
 
 	return message
 }
@@ -2,6 +2,7 @@ package gmail
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 	"time"
 
@@ -203,6 +204,17 @@ func TestExtractPlainText_EmptyPayload(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestExtractPlainText_SkipsAMP(t *testing.T) {
+	part := func(mime, body string) *gmail.MessagePart {
+		return &gmail.MessagePart{MimeType: mime, Body: &gmail.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte(body))}}
+	}
+	msg := &gmail.Message{Payload: &gmail.MessagePart{MimeType: "multipart/alternative", Parts: []*gmail.MessagePart{
+		part(AMPMimeType, "<html ⚡4email><amp-img></amp-img></html>"),
+		part("text/plain", "Static text"),
+	}}}
+	assert.Equal(t, "Static text", ExtractPlainText(msg))
+}
+
 // Test standalone ExtractHTML function
 func TestExtractHTML_NilMessage(t *testing.T) {
 	// Note: ExtractHTML doesn't handle nil message properly - test with empty message
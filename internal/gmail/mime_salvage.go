@@ -0,0 +1,200 @@
+package gmail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+	"google.golang.org/api/gmail/v1"
+)
+
+// Messages with malformed MIME are rendered from whatever decodes: damaged base64 keeps the bytes
+// before the damage, unknown charsets show as UTF-8, and a message whose parts Gmail could not
+// decode is parsed again from its raw source. What failed is listed in Message.PartErrors.
+
+// maxSalvageDepth bounds multipart nesting when parsing a raw source
+const maxSalvageDepth = 10
+
+// decodePartBody decodes a part's base64url body. Damaged data is retried with the other base64
+// variants; when none decodes, the longest prefix any of them decoded is returned with the error.
+func decodePartBody(data string) ([]byte, error) {
+	out, err := base64.URLEncoding.DecodeString(data)
+	if err == nil {
+		return out, nil
+	}
+	clean := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, data)
+	for _, enc := range []*base64.Encoding{base64.URLEncoding, base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding} {
+		alt, altErr := enc.DecodeString(clean)
+		if altErr == nil {
+			return alt, nil
+		}
+		if len(alt) > len(out) {
+			out = alt
+		}
+	}
+	return out, fmt.Errorf("damaged base64 (kept %d of ~%d bytes)", len(out), len(clean)*3/4)
+}
+
+// decodeText applies a part's transfer encoding and charset to its bytes. Problems are reported
+// while the best available text is still returned.
+func decodeText(data []byte, transferEncoding, contentType string) (string, []string) {
+	var problems []string
+	raw := data
+	if strings.Contains(strings.ToLower(transferEncoding), "quoted-printable") {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			problems = append(problems, "malformed quoted-printable, shown undecoded")
+		} else {
+			raw = decoded
+		}
+	}
+	label := ""
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		label = strings.ToLower(params["charset"])
+	} else if idx := strings.Index(strings.ToLower(contentType), "charset="); idx != -1 {
+		// naive extraction for headers mime can't parse
+		label = strings.Trim(strings.TrimSpace(strings.ToLower(contentType)[idx+8:]), ";\" ")
+	}
+	if label == "" || label == "utf-8" || label == "utf8" || label == "us-ascii" {
+		return string(raw), problems
+	}
+	r, err := charset.NewReaderLabel(label, bytes.NewReader(raw))
+	if err != nil {
+		return string(raw), append(problems, fmt.Sprintf("unknown charset %q, shown as UTF-8", label))
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return string(raw), append(problems, fmt.Sprintf("charset %q failed to convert, shown as UTF-8", label))
+	}
+	return string(b), problems
+}
+
+// decodeTextPart decodes a Gmail payload part: base64url body, transfer encoding and charset
+func decodeTextPart(part *gmail.MessagePart) (string, []string) {
+	var problems []string
+	data, err := decodePartBody(part.Body.Data)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	var transferEncoding, contentType string
+	for _, h := range part.Headers {
+		switch {
+		case strings.EqualFold(h.Name, "Content-Transfer-Encoding"):
+			transferEncoding = h.Value
+		case strings.EqualFold(h.Name, "Content-Type"):
+			contentType = h.Value
+		}
+	}
+	text, more := decodeText(data, transferEncoding, contentType)
+	return text, append(problems, more...)
+}
+
+// DiagnoseParts lists what could not be decoded in msg's text parts, one "mime/type: problem"
+// entry each; nil means the message decoded cleanly
+func DiagnoseParts(msg *gmail.Message) []string {
+	if msg == nil || msg.Payload == nil {
+		return nil
+	}
+	var out []string
+	var walk func(p *gmail.MessagePart, depth int)
+	walk = func(p *gmail.MessagePart, depth int) {
+		if p == nil || depth > maxSalvageDepth {
+			return
+		}
+		mimeType := strings.ToLower(p.MimeType)
+		if strings.HasPrefix(mimeType, "multipart/") && len(p.Parts) == 0 && (p.Body == nil || p.Body.Data == "") {
+			out = append(out, mimeType+": no parts could be read")
+		}
+		if p.Body != nil && p.Body.Data != "" && p.Filename == "" && strings.HasPrefix(mimeType, "text/") {
+			_, problems := decodeTextPart(p)
+			for _, problem := range problems {
+				out = append(out, mimeType+": "+problem)
+			}
+		}
+		for _, child := range p.Parts {
+			walk(child, depth+1)
+		}
+	}
+	walk(msg.Payload, 0)
+	return out
+}
+
+// ParseRawContent salvages the first text/plain and text/html parts from a raw RFC 5322 source,
+// reading as much of a broken multipart structure as it can. Problems lists what was skipped.
+func ParseRawContent(raw []byte) (text, html string, problems []string) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return "", "", []string{fmt.Sprintf("raw source: %v", err)}
+	}
+	var walk func(header textproto.MIMEHeader, body io.Reader, depth int)
+	walk = func(header textproto.MIMEHeader, body io.Reader, depth int) {
+		contentType := header.Get("Content-Type")
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			if contentType != "" {
+				problems = append(problems, fmt.Sprintf("unreadable Content-Type %q, read as text/plain", contentType))
+			}
+			mediaType = "text/plain"
+		}
+		if strings.HasPrefix(mediaType, "multipart/") {
+			if depth >= maxSalvageDepth || params["boundary"] == "" {
+				problems = append(problems, mediaType+": missing boundary or nested too deep")
+				return
+			}
+			mr := multipart.NewReader(body, params["boundary"])
+			for {
+				part, err := mr.NextPart()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v (later parts skipped)", mediaType, err))
+					return
+				}
+				walk(part.Header, part, depth+1)
+			}
+		}
+		if (mediaType != "text/plain" || text != "") && (mediaType != "text/html" || html != "") {
+			return
+		}
+		if disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition")); disposition == "attachment" {
+			return
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: truncated (%v)", mediaType, err))
+		}
+		transferEncoding := header.Get("Content-Transfer-Encoding")
+		if strings.EqualFold(strings.TrimSpace(transferEncoding), "base64") {
+			decoded, err := decodePartBody(string(data))
+			if err != nil {
+				problems = append(problems, mediaType+": "+err.Error())
+			}
+			data, transferEncoding = decoded, ""
+		}
+		decoded, more := decodeText(data, transferEncoding, contentType)
+		for _, problem := range more {
+			problems = append(problems, mediaType+": "+problem)
+		}
+		if mediaType == "text/html" {
+			html = decoded
+		} else {
+			text = decoded
+		}
+	}
+	walk(textproto.MIMEHeader(msg.Header), msg.Body, 0)
+	return text, html, problems
+}
@@ -0,0 +1,76 @@
+package gmail
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestDecodePartBody_Lenient(t *testing.T) {
+	std := base64.StdEncoding.EncodeToString([]byte("hello?>>world"))
+	if out, err := decodePartBody(std); err != nil || string(out) != "hello?>>world" {
+		t.Fatalf("standard base64 = %q, %v", out, err)
+	}
+	raw := base64.RawURLEncoding.EncodeToString([]byte("no padding"))
+	if out, err := decodePartBody(raw); err != nil || string(out) != "no padding" {
+		t.Fatalf("unpadded base64 = %q, %v", out, err)
+	}
+	damaged := base64.URLEncoding.EncodeToString([]byte("kept part of the text")) + "!!*"
+	out, err := decodePartBody(damaged)
+	if err == nil || !strings.HasPrefix(string(out), "kept part") {
+		t.Fatalf("damaged base64 = %q, %v; want the decoded prefix and an error", out, err)
+	}
+}
+
+func TestDiagnoseParts(t *testing.T) {
+	good := base64.URLEncoding.EncodeToString([]byte("fine"))
+	msg := &gmail.Message{Payload: &gmail.MessagePart{
+		MimeType: "multipart/mixed",
+		Parts: []*gmail.MessagePart{
+			{MimeType: "text/plain", Body: &gmail.MessagePartBody{Data: good}},
+			{MimeType: "text/html", Body: &gmail.MessagePartBody{Data: "PGI+b2s8L2I+$$$"}},
+			{MimeType: "text/plain", Filename: "notes.txt", Body: &gmail.MessagePartBody{Data: "$$$"}},
+			{MimeType: "multipart/alternative"},
+		},
+	}}
+	problems := DiagnoseParts(msg)
+	if len(problems) != 2 || !strings.HasPrefix(problems[0], "text/html: damaged base64") || problems[1] != "multipart/alternative: no parts could be read" {
+		t.Fatalf("problems = %q", problems)
+	}
+	if html := ExtractHTML(msg); !strings.HasPrefix(html, "<b>ok</b>") {
+		t.Fatalf("html = %q, want the salvaged prefix", html)
+	}
+}
+
+func TestParseRawContent_BrokenMultipart(t *testing.T) {
+	raw := "From: a@example.com\r\n" +
+		"Subject: broken\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"b1\"\r\n\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n\r\n" +
+		"Caf=E9 tonight\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: base64\r\n\r\n" +
+		base64.StdEncoding.EncodeToString([]byte("<p>Café tonight</p>")) + "\r\n"
+	// The closing boundary is missing
+
+	text, html, problems := ParseRawContent([]byte(raw))
+	if strings.TrimSpace(text) != "Café tonight" {
+		t.Fatalf("text = %q", text)
+	}
+	if html != "<p>Café tonight</p>" {
+		t.Fatalf("html = %q", html)
+	}
+	if len(problems) == 0 || !strings.Contains(problems[len(problems)-1], "later parts skipped") {
+		t.Fatalf("problems = %q, want the unterminated multipart", problems)
+	}
+
+	if _, _, problems := ParseRawContent([]byte("not a message")); len(problems) != 1 {
+		t.Fatalf("problems for garbage = %q", problems)
+	}
+}
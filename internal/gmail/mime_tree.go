@@ -0,0 +1,244 @@
+package gmail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// MIMEPart is a node of a message's MIME structure, parsed from its raw source so that every
+// part, including the contents of forwarded messages, can be saved on its own
+type MIMEPart struct {
+	Path        string // position in the tree: "" for the message, "1", "1.2", ...
+	Depth       int
+	MimeType    string
+	Filename    string
+	ContentID   string
+	Disposition string // inline or attachment, when declared
+	Header      textproto.MIMEHeader
+	Body        []byte // transfer-decoded content; a message/rfc822 part keeps the embedded message's source
+	Parts       []*MIMEPart
+}
+
+// IsContainer reports whether the part only groups other parts (multipart/*)
+func (p *MIMEPart) IsContainer() bool {
+	return strings.HasPrefix(p.MimeType, "multipart/")
+}
+
+// Flatten lists the part and its descendants in document order
+func (p *MIMEPart) Flatten() []*MIMEPart {
+	out := []*MIMEPart{p}
+	for _, c := range p.Parts {
+		out = append(out, c.Flatten()...)
+	}
+	return out
+}
+
+// IsAttachment reports whether the part is a file rather than a body: it has a filename, is
+// declared as an attachment, or is neither text nor a container
+func (p *MIMEPart) IsAttachment() bool {
+	if p.IsContainer() {
+		return false
+	}
+	return p.Filename != "" || p.Disposition == "attachment" || !strings.HasPrefix(p.MimeType, "text/")
+}
+
+// EmbeddedMessages lists the message/rfc822 parts directly inside this message, not counting the
+// ones nested in them
+func (p *MIMEPart) EmbeddedMessages() []*MIMEPart {
+	var out []*MIMEPart
+	var walk func(*MIMEPart)
+	walk = func(n *MIMEPart) {
+		for _, c := range n.Parts {
+			if c.MimeType == "message/rfc822" {
+				out = append(out, c)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(p)
+	return out
+}
+
+// EmbeddedMessage builds a Message from a message/rfc822 part so it renders like any other; id
+// identifies it for render caches and is not a Gmail message ID
+func (p *MIMEPart) EmbeddedMessage(id string) (*Message, error) {
+	if p.MimeType != "message/rfc822" || len(p.Parts) == 0 {
+		return nil, fmt.Errorf("part %s is not a readable embedded message", p.Path)
+	}
+	inner := p.Parts[0]
+	raw := &gmail.Message{Id: id, Payload: inner.payload()}
+	m := &Message{Message: raw}
+	m.PlainText = ExtractPlainText(raw)
+	m.HTML = ExtractHTML(raw)
+	m.Subject = extractHeader(raw, "Subject")
+	m.From = extractHeader(raw, "From")
+	m.To = extractHeader(raw, "To")
+	m.Cc = extractHeader(raw, "Cc")
+	if t, err := mail.ParseDate(extractHeader(raw, "Date")); err == nil {
+		m.Date = t
+	}
+	m.Labels = []string{}
+	m.PartErrors = DiagnoseParts(raw)
+	return m, nil
+}
+
+// payload converts the part to the Gmail API shape, with MIME-word headers decoded and the
+// content already transfer-decoded as Gmail serves it
+func (p *MIMEPart) payload() *gmail.MessagePart {
+	mp := &gmail.MessagePart{
+		PartId:   p.Path,
+		MimeType: p.MimeType,
+		Filename: p.Filename,
+		Body:     &gmail.MessagePartBody{Size: int64(len(p.Body))},
+	}
+	if len(p.Body) > 0 && p.MimeType != "message/rfc822" {
+		mp.Body.Data = base64.URLEncoding.EncodeToString(p.Body)
+	}
+	dec := new(mime.WordDecoder)
+	names := make([]string, 0, len(p.Header))
+	for name := range p.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.EqualFold(name, "Content-Transfer-Encoding") {
+			continue
+		}
+		for _, v := range p.Header[name] {
+			if decoded, err := dec.DecodeHeader(v); err == nil {
+				v = decoded
+			}
+			mp.Headers = append(mp.Headers, &gmail.MessagePartHeader{Name: name, Value: v})
+		}
+	}
+	for _, c := range p.Parts {
+		mp.Parts = append(mp.Parts, c.payload())
+	}
+	return mp
+}
+
+// partExtensions are the extensions preferred for common types; mime.ExtensionsByType returns
+// several in no useful order for some of them
+var partExtensions = map[string]string{
+	"text/plain":     ".txt",
+	"text/html":      ".html",
+	"text/calendar":  ".ics",
+	"text/x-vcard":   ".vcf",
+	"text/vcard":     ".vcf",
+	"message/rfc822": ".eml",
+	"image/jpeg":     ".jpg",
+	AMPMimeType:      ".amp.html",
+}
+
+// SuggestedFilename is the part's filename, or one made from its position and type
+func (p *MIMEPart) SuggestedFilename() string {
+	if p.Filename != "" {
+		return p.Filename
+	}
+	ext, ok := partExtensions[p.MimeType]
+	if !ok {
+		if exts, err := mime.ExtensionsByType(p.MimeType); err == nil && len(exts) > 0 {
+			sort.Strings(exts)
+			ext = exts[0]
+		} else {
+			ext = ".bin"
+		}
+	}
+	path := p.Path
+	if path == "" {
+		path = "0"
+	}
+	return "part-" + strings.ReplaceAll(path, ".", "-") + ext
+}
+
+// ParseMIMETree parses the MIME structure of a raw RFC 5322 message. Broken multiparts keep the
+// parts read before the damage.
+func ParseMIMETree(raw []byte) (*MIMEPart, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse message: %w", err)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	return parseMIMEEntity(textproto.MIMEHeader(msg.Header), body, "", 0), nil
+}
+
+func parseMIMEEntity(header textproto.MIMEHeader, body []byte, path string, depth int) *MIMEPart {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+	p := &MIMEPart{
+		Path:      path,
+		Depth:     depth,
+		MimeType:  mediaType,
+		Header:    header,
+		ContentID: strings.Trim(header.Get("Content-Id"), "<> "),
+	}
+	disposition, dparams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	p.Disposition = disposition
+	p.Filename = dparams["filename"]
+	if p.Filename == "" {
+		p.Filename = params["name"]
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(p.Filename); err == nil {
+		p.Filename = decoded
+	}
+
+	child := func(i int) string {
+		if path == "" {
+			return strconv.Itoa(i)
+		}
+		return path + "." + strconv.Itoa(i)
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/") && depth < maxSalvageDepth && params["boundary"] != "":
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for i := 1; ; i++ {
+			// NextRawPart keeps the transfer encoding for decodeTransfer to handle
+			part, err := mr.NextRawPart()
+			if err != nil {
+				break
+			}
+			data, _ := io.ReadAll(part)
+			p.Parts = append(p.Parts, parseMIMEEntity(part.Header, data, child(i), depth+1))
+		}
+	case mediaType == "message/rfc822" && depth < maxSalvageDepth:
+		p.Body = decodeTransfer(body, header.Get("Content-Transfer-Encoding"))
+		if inner, err := mail.ReadMessage(bytes.NewReader(p.Body)); err == nil {
+			innerBody, _ := io.ReadAll(inner.Body)
+			p.Parts = append(p.Parts, parseMIMEEntity(textproto.MIMEHeader(inner.Header), innerBody, child(1), depth+1))
+		}
+	default:
+		p.Body = decodeTransfer(body, header.Get("Content-Transfer-Encoding"))
+	}
+	return p
+}
+
+// decodeTransfer undoes a Content-Transfer-Encoding, keeping what decodes when the data is damaged
+func decodeTransfer(body []byte, encoding string) []byte {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		out, _ := decodePartBody(string(body))
+		return out
+	case "quoted-printable":
+		out, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(body)))
+		if err != nil && len(out) == 0 {
+			return body
+		}
+		return out
+	}
+	return body
+}
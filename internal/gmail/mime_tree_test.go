@@ -0,0 +1,138 @@
+package gmail
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestParseMIMETree(t *testing.T) {
+	png := []byte("\x89PNG fake image bytes")
+	raw := strings.Join([]string{
+		"From: a@example.com",
+		"Subject: tree",
+		"Content-Type: multipart/mixed; boundary=outer",
+		"",
+		"--outer",
+		"Content-Type: multipart/alternative; boundary=alt",
+		"",
+		"--alt",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Caf=C3=A9",
+		"--alt",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>Café</p>",
+		"--alt--",
+		"--outer",
+		"Content-Type: image/png",
+		"Content-Id: <logo@x>",
+		"Content-Disposition: inline",
+		"Content-Transfer-Encoding: base64",
+		"",
+		base64.StdEncoding.EncodeToString(png),
+		"--outer",
+		"Content-Type: message/rfc822",
+		"Content-Disposition: attachment; filename=\"=?utf-8?q?fw=C3=A9.eml?=\"",
+		"",
+		"From: b@example.com",
+		"Subject: inner",
+		"Content-Type: text/calendar; method=REQUEST",
+		"",
+		"BEGIN:VCALENDAR",
+		"--outer--",
+	}, "\r\n")
+
+	root, err := ParseMIMETree([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := root.Flatten()
+	var got []string
+	for _, p := range parts {
+		got = append(got, p.Path+" "+p.MimeType)
+	}
+	want := []string{" multipart/mixed", "1 multipart/alternative", "1.1 text/plain", "1.2 text/html", "2 image/png", "3 message/rfc822", "3.1 text/calendar"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("tree = %q, want %q", got, want)
+	}
+	if string(parts[2].Body) != "Café" || string(parts[4].Body) != string(png) || parts[4].ContentID != "logo@x" {
+		t.Fatalf("decoded bodies: %q / %q (cid %q)", parts[2].Body, parts[4].Body, parts[4].ContentID)
+	}
+	if nested := parts[5]; nested.Filename != "fwé.eml" || !strings.HasPrefix(string(nested.Body), "From: b@example.com") {
+		t.Fatalf("nested message = %q %q", nested.Filename, nested.Body)
+	}
+	if !root.IsContainer() || parts[2].IsContainer() {
+		t.Fatal("only multiparts are containers")
+	}
+	for i, name := range map[int]string{3: "part-1-2.html", 4: "part-2.png", 5: "fwé.eml", 6: "part-3-1.ics"} {
+		if got := parts[i].SuggestedFilename(); got != name {
+			t.Errorf("SuggestedFilename(%s) = %q, want %q", parts[i].Path, got, name)
+		}
+	}
+}
+
+func TestEmbeddedMessage(t *testing.T) {
+	raw := strings.Join([]string{
+		"From: a@example.com",
+		"Subject: fwd",
+		"Content-Type: multipart/mixed; boundary=outer",
+		"",
+		"--outer",
+		"Content-Type: text/plain",
+		"",
+		"see below",
+		"--outer",
+		"Content-Type: message/rfc822",
+		"",
+		"From: =?utf-8?q?Jos=C3=A9?= <jose@example.com>",
+		"Subject: =?utf-8?q?Reuni=C3=B3n?=",
+		"Date: Mon, 02 Jan 2006 15:04:05 +0000",
+		"Content-Type: multipart/mixed; boundary=inner",
+		"",
+		"--inner",
+		"Content-Type: text/plain; charset=iso-8859-1",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Ma=F1ana",
+		"--inner",
+		"Content-Type: application/pdf; name=agenda.pdf",
+		"Content-Transfer-Encoding: base64",
+		"",
+		base64.StdEncoding.EncodeToString([]byte("%PDF")),
+		"--inner--",
+		"--outer--",
+	}, "\r\n")
+	root, err := ParseMIMETree([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded := root.EmbeddedMessages()
+	if len(embedded) != 1 {
+		t.Fatalf("embedded messages = %d, want 1", len(embedded))
+	}
+	m, err := embedded[0].EmbeddedMessage("id#2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Subject != "Reunión" || m.From != "José <jose@example.com>" || m.Date.Year() != 2006 {
+		t.Fatalf("headers = %q / %q / %v", m.Subject, m.From, m.Date)
+	}
+	if m.PlainText != "Mañana" || len(m.PartErrors) != 0 {
+		t.Fatalf("text = %q, problems %v", m.PlainText, m.PartErrors)
+	}
+	var files []string
+	for _, p := range embedded[0].Parts[0].Flatten() {
+		if p.IsAttachment() {
+			files = append(files, p.SuggestedFilename())
+		}
+	}
+	if strings.Join(files, ",") != "agenda.pdf" {
+		t.Fatalf("attachments = %v", files)
+	}
+	if _, err := root.Parts[0].EmbeddedMessage("x"); err == nil {
+		t.Fatal("a text part is not an embedded message")
+	}
+}
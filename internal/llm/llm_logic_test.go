@@ -53,3 +53,23 @@ func TestAnnotateBedrockError(t *testing.T) {
 		t.Error("an unrelated error should pass through unchanged")
 	}
 }
+
+func TestIsLocalProvider(t *testing.T) {
+	cases := []struct {
+		provider, endpoint string
+		want               bool
+	}{
+		{"ollama", "http://localhost:11434/api/generate", true},
+		{"", "http://127.0.0.1:11434", true},
+		{"ollama", "[::1]:11434", true},
+		{"ollama", "", true},
+		{"ollama", "http://gpu-box.lan:11434/api/generate", false},
+		{"bedrock", "", false},
+		{"openai", "http://localhost:8080", false},
+	}
+	for _, c := range cases {
+		if got := IsLocalProvider(c.provider, c.endpoint); got != c.want {
+			t.Errorf("IsLocalProvider(%q, %q) = %v, want %v", c.provider, c.endpoint, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package llm
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// IsLocalProvider reports whether a provider runs on this machine: Ollama with an endpoint on a
+// loopback address (or no endpoint). Cloud providers such as Bedrock are never local.
+func IsLocalProvider(provider, endpoint string) bool {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "", "ollama":
+	default:
+		return false
+	}
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return true
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
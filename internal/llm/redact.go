@@ -0,0 +1,197 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Built-in redaction patterns. Card numbers are 13-19 digits, optionally grouped by spaces or
+// dashes, and must pass the Luhn check. Phone numbers are digit groups (with an optional +country
+// code or (area) prefix) holding 8-15 digits; dates such as 2026-10-16 are left alone.
+var (
+	redactEmailRe = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactCardRe  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	redactPhoneRe = regexp.MustCompile(`(?:\+\d{1,3}[ \-.]?|\(\d{1,4}\)[ \-.]?|\b)\d{2,4}(?:[ \-.]\d{2,4}){1,4}\b|\+\d{8,15}\b`)
+	redactDateRe  = regexp.MustCompile(`^(?:\d{4}[\-./]\d{1,2}[\-./]\d{1,2}|\d{1,2}[\-./]\d{1,2}[\-./]\d{2,4})`)
+)
+
+// RedactOptions selects what a Redactor masks
+type RedactOptions struct {
+	Emails   bool
+	Phones   bool
+	Cards    bool
+	Patterns []string // extra regular expressions, masked as [REDACTED]
+}
+
+// Redactor masks personal data in text before it leaves the machine
+type Redactor struct {
+	emails, phones, cards bool
+	patterns              []*regexp.Regexp
+}
+
+// RedactionReport counts what a Redact call masked, by kind
+type RedactionReport map[string]int
+
+// Total returns the number of masked values
+func (r RedactionReport) Total() int {
+	n := 0
+	for _, c := range r {
+		n += c
+	}
+	return n
+}
+
+// NewRedactor compiles the redaction rules; an invalid custom pattern is an error
+func NewRedactor(opts RedactOptions) (*Redactor, error) {
+	r := &Redactor{emails: opts.Emails, phones: opts.Phones, cards: opts.Cards}
+	for _, p := range opts.Patterns {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact masks the configured values in text and reports how many of each kind it masked.
+// Cards run before phones so a card number is not half-masked as a phone.
+func (r *Redactor) Redact(text string) (string, RedactionReport) {
+	report := RedactionReport{}
+	if r == nil || text == "" {
+		return text, report
+	}
+	if r.emails {
+		text = redactEmailRe.ReplaceAllStringFunc(text, func(string) string {
+			report["email"]++
+			return "[EMAIL]"
+		})
+	}
+	if r.cards {
+		text = redactCardRe.ReplaceAllStringFunc(text, func(m string) string {
+			if !luhnValid(m) {
+				return m
+			}
+			report["card"]++
+			return "[CARD]"
+		})
+	}
+	if r.phones {
+		text = redactPhoneRe.ReplaceAllStringFunc(text, func(m string) string {
+			if n := countDigits(m); n < 8 || n > 15 || redactDateRe.MatchString(m) {
+				return m
+			}
+			report["phone"]++
+			return "[PHONE]"
+		})
+	}
+	for _, re := range r.patterns {
+		text = re.ReplaceAllStringFunc(text, func(string) string {
+			report["pattern"]++
+			return "[REDACTED]"
+		})
+	}
+	return text, report
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum used by card numbers
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ProviderTrusted reports whether a provider receives prompts unredacted. An empty trusted list
+// means only the local Ollama provider is trusted.
+func ProviderTrusted(provider string, trusted []string) bool {
+	if provider == "" {
+		provider = "ollama"
+	}
+	if trusted == nil {
+		trusted = []string{"ollama"}
+	}
+	for _, t := range trusted {
+		if strings.EqualFold(strings.TrimSpace(t), provider) {
+			return true
+		}
+	}
+	return false
+}
+
+// guardedProvider redacts every prompt before handing it to the wrapped provider
+type guardedProvider struct {
+	inner    Provider
+	redactor *Redactor
+}
+
+// streamGuardedProvider is a guardedProvider over a provider that streams
+type streamGuardedProvider struct {
+	guardedProvider
+}
+
+// NewGuardedProvider wraps p so prompts are redacted before they are sent. Streaming is kept
+// only when p streams, so callers' capability checks see the same provider.
+func NewGuardedProvider(p Provider, r *Redactor) Provider {
+	if p == nil || r == nil {
+		return p
+	}
+	g := guardedProvider{inner: p, redactor: r}
+	if _, ok := p.(StreamProvider); ok {
+		return &streamGuardedProvider{g}
+	}
+	return &g
+}
+
+func (g *guardedProvider) Name() string { return g.inner.Name() }
+
+func (g *guardedProvider) redact(prompt string) string {
+	out, _ := g.redactor.Redact(prompt)
+	return out
+}
+
+// Generate redacts the prompt and generates with the wrapped provider
+func (g *guardedProvider) Generate(prompt string) (string, error) {
+	return g.inner.Generate(g.redact(prompt))
+}
+
+// GenerateWithParams redacts the prompt; providers without parameters get a plain Generate
+func (g *guardedProvider) GenerateWithParams(prompt string, params map[string]interface{}) (string, error) {
+	if pp, ok := g.inner.(ParamProvider); ok {
+		return pp.GenerateWithParams(g.redact(prompt), params)
+	}
+	return g.inner.Generate(g.redact(prompt))
+}
+
+// GenerateStream redacts the prompt and streams from the wrapped provider
+func (g *streamGuardedProvider) GenerateStream(ctx context.Context, prompt string, onToken func(string)) error {
+	return g.inner.(StreamProvider).GenerateStream(ctx, g.redact(prompt), onToken)
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	r, err := NewRedactor(RedactOptions{Emails: true, Phones: true, Cards: true, Patterns: []string{`ACME-\d+`}})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	in := "Write to ana.garcia@example.com or call +34 612 345 678 / (555) 123-4567. " +
+		"Card 4111 1111 1111 1111, order 1234 5678 9012 3456 (not a card), ticket ACME-42, " +
+		"meeting on 2026-10-16 at 10:00."
+	out, report := r.Redact(in)
+	for _, gone := range []string{"ana.garcia@example.com", "612 345 678", "123-4567", "4111 1111", "ACME-42"} {
+		if strings.Contains(out, gone) {
+			t.Errorf("%q should be redacted:\n%s", gone, out)
+		}
+	}
+	for _, kept := range []string{"2026-10-16", "10:00", "[EMAIL]", "[PHONE]", "[CARD]", "[REDACTED]"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("output should contain %q:\n%s", kept, out)
+		}
+	}
+	if report["email"] != 1 || report["card"] != 1 || report["phone"] != 2 || report["pattern"] != 1 {
+		t.Fatalf("report = %v", report)
+	}
+
+	if _, err := NewRedactor(RedactOptions{Patterns: []string{"("}}); err == nil {
+		t.Fatal("an invalid pattern must be rejected")
+	}
+}
+
+func TestProviderTrusted(t *testing.T) {
+	if !ProviderTrusted("ollama", nil) || !ProviderTrusted("", nil) || ProviderTrusted("bedrock", nil) {
+		t.Fatal("by default only ollama is trusted")
+	}
+	if !ProviderTrusted("Bedrock", []string{"bedrock"}) || ProviderTrusted("ollama", []string{}) {
+		t.Fatal("an explicit list replaces the default")
+	}
+}
+
+type recordingProvider struct{ prompts []string }
+
+func (p *recordingProvider) Name() string { return "rec" }
+func (p *recordingProvider) Generate(prompt string) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	return "ok", nil
+}
+
+func TestNewGuardedProvider(t *testing.T) {
+	inner := &recordingProvider{}
+	r, _ := NewRedactor(RedactOptions{Emails: true})
+	g := NewGuardedProvider(inner, r)
+	if _, ok := g.(StreamProvider); ok {
+		t.Fatal("a non-streaming provider must not gain streaming")
+	}
+	if _, err := g.Generate("from bob@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.(ParamProvider).GenerateWithParams("cc eve@example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if inner.prompts[0] != "from [EMAIL]" || inner.prompts[1] != "cc [EMAIL]" {
+		t.Fatalf("prompts sent = %q", inner.prompts)
+	}
+	if NewGuardedProvider(inner, nil) != Provider(inner) {
+		t.Fatal("no redactor leaves the provider as is")
+	}
+	stream := NewGuardedProvider(NewClient("http://127.0.0.1:0", "m", 0), r)
+	if _, ok := stream.(StreamProvider); !ok {
+		t.Fatal("a streaming provider keeps streaming")
+	}
+}
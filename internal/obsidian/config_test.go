@@ -11,3 +11,21 @@ func TestDefaultObsidianConfig(t *testing.T) {
 		t.Errorf("defaults should be populated, got %+v", c)
 	}
 }
+
+func TestRepopackChunkBudget(t *testing.T) {
+	cases := []struct {
+		cfg  ObsidianConfig
+		want int
+	}{
+		{ObsidianConfig{}, DefaultRepopackChunkTokens * 4},
+		{ObsidianConfig{RepopackChunkTokens: 8000}, 32000},
+		{ObsidianConfig{RepopackChunkTokens: 8000, RepopackChunkChars: 5000}, 5000},
+		{ObsidianConfig{RepopackChunkTokens: -1}, 0},
+		{ObsidianConfig{RepopackChunkChars: -1, RepopackChunkTokens: 8000}, 0},
+	}
+	for _, c := range cases {
+		if got := c.cfg.RepopackChunkBudget(); got != c.want {
+			t.Errorf("RepopackChunkBudget(%+v) = %d, want %d", c.cfg, got, c.want)
+		}
+	}
+}
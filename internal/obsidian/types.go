@@ -45,6 +45,28 @@ type ObsidianConfig struct {
 	TemplateFile         string `json:"template_file,omitempty"`          // Path to template file (relative to config dir or absolute)
 	RepopackTemplateFile string `json:"repopack_template_file,omitempty"` // Path to repopack template file for bulk mode
 	Template             string `json:"template"`                         // Inline template (fallback)
+
+	// Repopack chunking: a repopack over the budget is split into parts under it, linked from an
+	// index note, so each part fits an LLM context window. Characters take precedence over tokens
+	// (estimated at 4 characters each); 0 uses DefaultRepopackChunkTokens and -1 keeps one file.
+	RepopackChunkTokens int `json:"repopack_chunk_tokens,omitempty"`
+	RepopackChunkChars  int `json:"repopack_chunk_chars,omitempty"`
+}
+
+// DefaultRepopackChunkTokens is the per-file repopack budget when none is configured
+const DefaultRepopackChunkTokens = 100000
+
+// RepopackChunkBudget returns the per-file repopack budget in characters; 0 means no chunking
+func (c *ObsidianConfig) RepopackChunkBudget() int {
+	switch {
+	case c.RepopackChunkChars > 0:
+		return c.RepopackChunkChars
+	case c.RepopackChunkChars < 0, c.RepopackChunkTokens < 0:
+		return 0
+	case c.RepopackChunkTokens > 0:
+		return c.RepopackChunkTokens * 4
+	}
+	return DefaultRepopackChunkTokens * 4
 }
 
 // DefaultObsidianConfig returns the default configuration
@@ -10,6 +10,8 @@ type PromptTemplate struct {
 	CreatedAt   int64  `json:"created_at"`
 	IsFavorite  bool   `json:"is_favorite"`
 	UsageCount  int    `json:"usage_count"`
+	// Source names the shared pack a prompt came from; shared prompts are read-only
+	Source string `json:"source,omitempty"`
 }
 
 // PromptResult represents a prompt execution result
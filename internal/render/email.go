@@ -183,7 +183,9 @@ type EmailRenderer struct {
 	headerKeyTag string // e.g., "[#50fa7b]"
 	// Optional label mapping and flags for list rendering enhancements
 	labelIdToName          map[string]string
+	hiddenLabelIDs         map[string]bool // labels never shown in the list (label_visibility)
 	showSystemLabelsInList bool
+	senderDisplays         map[string]SenderDisplay // per-sender overrides by lowercase address
 	config                 *config.Config
 }
 
@@ -207,6 +209,9 @@ func (er *EmailRenderer) SetLabelMap(m map[string]string) {
 	er.labelIdToName = m
 }
 
+// SetHiddenLabels sets the label IDs left out of the list's label chips and column
+func (er *EmailRenderer) SetHiddenLabels(ids map[string]bool) { er.hiddenLabelIDs = ids }
+
 // SetShowSystemLabelsInList toggles whether system labels (Inbox, Sent, Spam, etc.)
 // should be rendered as chips in the list view.
 func (er *EmailRenderer) SetShowSystemLabelsInList(v bool) { er.showSystemLabelsInList = v }
@@ -477,6 +482,9 @@ func (er *EmailRenderer) FormatLabelsForColumn(message *googleGmail.Message, max
 	// Extract label names using same logic as buildLabelChips
 	names := make([]string, 0, len(message.LabelIds))
 	for _, id := range message.LabelIds {
+		if er.hiddenLabelIDs[id] {
+			continue
+		}
 		name := id
 		if n, ok := er.labelIdToName[id]; ok && strings.TrimSpace(n) != "" {
 			name = n
@@ -625,6 +633,9 @@ func (er *EmailRenderer) buildIconsAndChips(message *googleGmail.Message) string
 	// Labels as chips (limit to 3 + +N). Use ID->Name map when available
 	names := make([]string, 0, len(message.LabelIds))
 	for _, id := range message.LabelIds {
+		if er.hiddenLabelIDs[id] {
+			continue
+		}
 		name := id
 		if n, ok := er.labelIdToName[id]; ok && strings.TrimSpace(n) != "" {
 			name = n
@@ -774,7 +785,7 @@ func (er *EmailRenderer) FormatHeaderPlainWithWidth(subject, from, to, cc string
 
 	// Format each header field with wrapping
 	er.writeWrappedHeaderField(&b, "Subject", subject, width)
-	er.writeWrappedHeaderField(&b, "From", from, width)
+	er.writeWrappedHeaderField(&b, "From", er.decorateFromHeader(from), width)
 
 	if strings.TrimSpace(to) != "" {
 		er.writeWrappedHeaderField(&b, "To", to, width)
@@ -789,6 +800,16 @@ func (er *EmailRenderer) FormatHeaderPlainWithWidth(subject, from, to, cc string
 	return strings.TrimRight(b.String(), "\n")
 }
 
+// FormatHeaderCompactWithWidth formats the reader header for the compact density: Subject, From
+// and Date only
+func (er *EmailRenderer) FormatHeaderCompactWithWidth(subject, from string, date time.Time, width int) string {
+	var b strings.Builder
+	er.writeWrappedHeaderField(&b, "Subject", subject, width)
+	er.writeWrappedHeaderField(&b, "From", er.decorateFromHeader(from), width)
+	er.writeWrappedHeaderField(&b, "Date", er.formatDate(date), width)
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // TruncateRecipientField truncates recipient fields to fit within specified line limit
 func (er *EmailRenderer) TruncateRecipientField(fieldName, value string, maxLines int, lineWidth int) string {
 	if maxLines <= 0 {
@@ -1006,6 +1027,14 @@ func (er *EmailRenderer) getDate(message *googleGmail.Message) time.Time {
 }
 
 func (er *EmailRenderer) extractSenderName(from string) string {
+	if d, ok := er.senderOverride(from); ok {
+		return d.Label(er.extractHeaderName(from))
+	}
+	return er.extractHeaderName(from)
+}
+
+// extractHeaderName is the display name of a From header value, or the value itself without one
+func (er *EmailRenderer) extractHeaderName(from string) string {
 	if from == "" {
 		return ""
 	}
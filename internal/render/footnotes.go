@@ -0,0 +1,141 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// ExtractHTMLLinks returns the links of an HTML body in document order, each URL once and
+// numbered from 1. The numbers are the footnote markers the reader shows after link text, so
+// the link picker uses the same list.
+func ExtractHTMLLinks(htmlStr string) []LinkRef {
+	doc, err := xhtml.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return nil
+	}
+	return collectHTMLLinks(doc)
+}
+
+// collectHTMLLinks walks a parsed document for <a href> links outside head, style and script
+func collectHTMLLinks(doc *xhtml.Node) []LinkRef {
+	var links []LinkRef
+	seen := make(map[string]bool)
+	var walk func(n *xhtml.Node)
+	walk = func(n *xhtml.Node) {
+		if n.Type == xhtml.ElementNode {
+			switch strings.ToLower(n.Data) {
+			case "head", "style", "script", "title":
+				return
+			case "a":
+				if href := anchorHref(n); href != "" && !seen[href] {
+					seen[href] = true
+					links = append(links, LinkRef{Index: len(links) + 1, URL: href, Text: anchorLabel(n, href)})
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return links
+}
+
+// anchorHref returns the trimmed href of an <a> element
+func anchorHref(n *xhtml.Node) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, "href") {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// anchorLabel returns the text of an <a> element, falling back to its aria-label, title or alt
+// and then to the URL
+func anchorLabel(n *xhtml.Node, href string) string {
+	var inner strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectText(&inner, c)
+	}
+	if label := strings.Join(strings.Fields(inner.String()), " "); label != "" {
+		return label
+	}
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, "aria-label") || strings.EqualFold(a.Key, "title") || strings.EqualFold(a.Key, "alt") {
+			if t := strings.TrimSpace(a.Val); t != "" {
+				return t
+			}
+		}
+	}
+	return href
+}
+
+// mdInlineLinkRe matches a Markdown inline link [text](url) with an optional "title"
+var mdInlineLinkRe = regexp.MustCompile(`\[([^\]]+)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// footnoteLinks replaces the inline links of Markdown converted from an HTML body with
+// "text [n]" markers and lists the URLs in a trailing "## Links" section. n is the link's
+// number in links (ExtractHTMLLinks of the same body); a URL missing from it is numbered
+// after them. Images, and links wrapped around images, are left alone.
+func footnoteLinks(md string, links []LinkRef) string {
+	numbers := make(map[string]int, len(links))
+	for _, l := range links {
+		numbers[footnoteKey(l.URL)] = l.Index
+	}
+	next := len(links)
+	used := make(map[int]string)
+
+	var b strings.Builder
+	last := 0
+	for _, m := range mdInlineLinkRe.FindAllStringSubmatchIndex(md, -1) {
+		start, end := m[0], m[1]
+		text, target := md[m[2]:m[3]], md[m[4]:m[5]]
+		if start > 0 && (md[start-1] == '!' || md[start-1] == '\\') || strings.HasPrefix(text, "!") {
+			continue
+		}
+		key := footnoteKey(target)
+		n, ok := numbers[key]
+		if !ok {
+			next++
+			n = next
+			numbers[key] = n
+		}
+		used[n] = target
+		b.WriteString(md[last:start])
+		fmt.Fprintf(&b, "%s [%d]", text, n)
+		last = end
+	}
+	if len(used) == 0 {
+		return md
+	}
+	b.WriteString(md[last:])
+
+	order := make([]int, 0, len(used))
+	for n := range used {
+		order = append(order, n)
+	}
+	sort.Ints(order)
+	out := strings.TrimRight(b.String(), "\n") + "\n\n## Links\n\n"
+	for _, n := range order {
+		out += fmt.Sprintf("- [%d] %s\n", n, used[n])
+	}
+	return out
+}
+
+// footnoteKey normalizes a URL for matching the converter's Markdown against the HTML: entities
+// and percent-encoding decoded, Markdown escapes dropped
+func footnoteKey(u string) string {
+	u = html.UnescapeString(strings.TrimSpace(u))
+	u = strings.ReplaceAll(u, `\`, "")
+	if unescaped, err := url.PathUnescape(u); err == nil {
+		u = unescaped
+	}
+	return u
+}
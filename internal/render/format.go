@@ -141,21 +141,22 @@ func FormatEmailForTerminal(ctx context.Context, msg *gmailwrap.Message, opts Fo
 	} else {
 		sort.Slice(links, func(i, j int) bool { return links[i].Index < links[j].Index })
 		seen := make(map[string]bool, len(links))
-		ordered := make([]string, 0, len(links))
+		ordered := make([]LinkRef, 0, len(links))
 		for _, lr := range links {
 			if lr.URL == "" {
 				continue
 			}
 			if !seen[lr.URL] {
 				seen[lr.URL] = true
-				ordered = append(ordered, lr.URL)
+				ordered = append(ordered, lr)
 			}
 		}
 		if len(ordered) == 0 {
 			out.WriteString("None\n")
 		} else {
-			for i, u := range ordered {
-				fmt.Fprintf(out, "(%d) %s\n", i+1, u)
+			// Numbered like the [n] markers in the body and the link picker
+			for _, lr := range ordered {
+				fmt.Fprintf(out, "(%d) %s\n", lr.Index, lr.URL)
 			}
 		}
 	}
@@ -172,17 +173,22 @@ func FormatEmailForTerminal(ctx context.Context, msg *gmailwrap.Message, opts Fo
 	return result, nil
 }
 
-// detectPlainTextLinks finds URLs in plain text and replaces them with [n] references
+// detectPlainTextLinks finds URLs in plain text and replaces them with [n] references. A
+// repeated URL keeps its first number, as in the link picker.
 func detectPlainTextLinks(input string) ([]LinkRef, string) {
-	// Rough URL regex (http/https schemas)
-	re := regexp.MustCompile(`(?i)\bhttps?://[\w\-\._~:/%\?#\[\]@!$&'()*+,;=]+`)
-	idx := 0
+	// Rough URL regex (http/https/ftp schemas)
+	re := regexp.MustCompile(`(?i)\b(https?|ftps?)://[\w\-\._~:/%\?#\[\]@!$&'()*+,;=]+`)
+	numbers := make(map[string]int)
 	links := make([]LinkRef, 0, 4)
 	replaced := re.ReplaceAllStringFunc(input, func(m string) string {
-		idx++
-		links = append(links, LinkRef{Index: idx, URL: m, Text: m})
+		n, ok := numbers[m]
+		if !ok {
+			n = len(links) + 1
+			numbers[m] = n
+			links = append(links, LinkRef{Index: n, URL: m, Text: m})
+		}
 		// Replace full URL in body with compact reference only
-		return fmt.Sprintf("[%d]", idx)
+		return fmt.Sprintf("[%d]", n)
 	})
 	return links, replaced
 }
@@ -413,9 +419,13 @@ func renderHTMLToText(htmlStr string) (string, []LinkRef, []AttachmentMeta, erro
 		return "", nil, nil, err
 	}
 	var b strings.Builder
-	links := make([]LinkRef, 0, 8)
+	// Links are numbered up front, each URL once, so a repeated link shows the same [n]
+	links := collectHTMLLinks(doc)
+	linkNumbers := make(map[string]int, len(links))
+	for _, l := range links {
+		linkNumbers[l.URL] = l.Index
+	}
 	images := make([]AttachmentMeta, 0, 4)
-	linkCounter := 0
 
 	var quoteDepth int
 	var inPre bool
@@ -544,9 +554,7 @@ func renderHTMLToText(htmlStr string) (string, []LinkRef, []AttachmentMeta, erro
 					label = href
 				}
 				if href != "" {
-					linkCounter++
-					links = append(links, LinkRef{Index: linkCounter, URL: href, Text: label})
-					b.WriteString(label + fmt.Sprintf(" [%d]", linkCounter))
+					b.WriteString(label + fmt.Sprintf(" [%d]", linkNumbers[href]))
 				} else {
 					b.WriteString(label)
 				}
@@ -612,9 +620,7 @@ func renderHTMLToText(htmlStr string) (string, []LinkRef, []AttachmentMeta, erro
 								label = href
 							}
 							if href != "" {
-								linkCounter++
-								links = append(links, LinkRef{Index: linkCounter, URL: href, Text: label})
-								buf.WriteString(label + fmt.Sprintf(" [%d]", linkCounter))
+								buf.WriteString(label + fmt.Sprintf(" [%d]", linkNumbers[href]))
 							} else {
 								buf.WriteString(label)
 							}
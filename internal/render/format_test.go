@@ -20,6 +20,31 @@ func TestDetectPlainTextLinks(t *testing.T) {
 	}
 }
 
+func TestDetectPlainTextLinks_RepeatedURLKeepsNumber(t *testing.T) {
+	links, replaced := detectPlainTextLinks("a https://x.io b https://y.io c https://x.io")
+	if len(links) != 2 || links[1].Index != 2 {
+		t.Fatalf("expected 2 distinct numbered links, got %+v", links)
+	}
+	if replaced != "a [1] b [2] c [1]" {
+		t.Fatalf("repeated URL should reuse its number, got: %s", replaced)
+	}
+}
+
+func TestRenderHTMLToText_FootnoteNumbersMatchExtractedLinks(t *testing.T) {
+	htmlStr := `<p><a href="https://a.io">A</a> <a href="https://b.io">B</a> <a href="https://a.io">A again</a></p>`
+	txt, links, _, err := renderHTMLToText(htmlStr)
+	if err != nil {
+		t.Fatalf("renderHTMLToText error: %v", err)
+	}
+	if !strings.Contains(txt, "A [1]") || !strings.Contains(txt, "B [2]") || !strings.Contains(txt, "A again [1]") {
+		t.Fatalf("markers should follow the link list, got: %q", txt)
+	}
+	extracted := ExtractHTMLLinks(htmlStr)
+	if len(links) != 2 || len(extracted) != 2 || links[1] != extracted[1] {
+		t.Fatalf("rendered links %+v should match ExtractHTMLLinks %+v", links, extracted)
+	}
+}
+
 func TestSanitizeBodyPreservingCode(t *testing.T) {
 	in := "Line … with – unicode\n```\nkeep 🚀 emoji inside code\n```\nBack • outside"
 	out := sanitizeBodyPreservingCode(in)
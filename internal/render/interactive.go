@@ -0,0 +1,82 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	gmailwrap "github.com/ajramos/giztui/internal/gmail"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+var (
+	formTagRe  = regexp.MustCompile(`(?i)<form[\s>]`)
+	fieldTagRe = regexp.MustCompile(`(?i)<(input|select|textarea)\b[^>]*>`)
+	hiddenRe   = regexp.MustCompile(`(?i)\btype\s*=\s*["']?hidden\b`)
+	ampHTMLRe  = regexp.MustCompile(`(?i)<html[^>]*\s(⚡4email|amp4email)[\s>=]`)
+)
+
+// InteractiveContent describes the parts of an email that only work in a browser: AMP for Email
+// and HTML forms
+type InteractiveContent struct {
+	AMP        bool // the message has an AMP version
+	AMPOnly    bool // ... and no static HTML or text version to show instead
+	Forms      int  // <form> elements in the HTML
+	FormFields int  // visible input, select and textarea fields
+}
+
+// Any reports whether the message has content the terminal can't run
+func (c InteractiveContent) Any() bool {
+	return c.AMP || c.Forms > 0
+}
+
+// DetectInteractiveContent looks for an AMP part (or an AMP document sent as HTML) and for forms
+// in the message's HTML
+func DetectInteractiveContent(m *gmailwrap.Message) InteractiveContent {
+	var c InteractiveContent
+	if m == nil {
+		return c
+	}
+	var hasHTML, hasText bool
+	if m.Message != nil {
+		walkParts(m.Payload, func(p *gmailapi.MessagePart) {
+			if p.Body == nil || p.Body.Data == "" || p.Filename != "" {
+				return
+			}
+			switch strings.ToLower(p.MimeType) {
+			case gmailwrap.AMPMimeType:
+				c.AMP = true
+			case "text/html":
+				hasHTML = true
+			case "text/plain":
+				hasText = true
+			}
+		})
+	}
+	// An AMP document sent as the HTML part renders as a broken page, so it isn't a static version
+	if ampHTMLRe.MatchString(m.HTML) {
+		c.AMP = true
+		hasHTML = false
+	}
+	c.AMPOnly = c.AMP && !hasHTML && !hasText
+
+	c.Forms = len(formTagRe.FindAllStringIndex(m.HTML, -1))
+	if c.Forms > 0 {
+		for _, tag := range fieldTagRe.FindAllString(m.HTML, -1) {
+			if !hiddenRe.MatchString(tag) {
+				c.FormFields++
+			}
+		}
+	}
+	return c
+}
+
+// walkParts calls fn for part and each of its descendants
+func walkParts(part *gmailapi.MessagePart, fn func(*gmailapi.MessagePart)) {
+	if part == nil {
+		return
+	}
+	fn(part)
+	for _, p := range part.Parts {
+		walkParts(p, fn)
+	}
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"encoding/base64"
+	"testing"
+
+	gmailwrap "github.com/ajramos/giztui/internal/gmail"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func mimePart(mime, body string) *gmailapi.MessagePart {
+	return &gmailapi.MessagePart{MimeType: mime, Body: &gmailapi.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte(body))}}
+}
+
+func TestDetectInteractiveContent(t *testing.T) {
+	alt := func(parts ...*gmailapi.MessagePart) *gmailapi.Message {
+		return &gmailapi.Message{Payload: &gmailapi.MessagePart{MimeType: "multipart/alternative", Parts: parts}}
+	}
+
+	amp := DetectInteractiveContent(&gmailwrap.Message{
+		Message:   alt(mimePart("text/plain", "Hi"), mimePart("text/x-amp-html", "<html ⚡4email></html>"), mimePart("text/html", "<p>Hi</p>")),
+		PlainText: "Hi",
+		HTML:      "<p>Hi</p>",
+	})
+	if !amp.AMP || amp.AMPOnly || amp.Forms != 0 {
+		t.Errorf("AMP with static versions = %+v", amp)
+	}
+
+	ampOnly := DetectInteractiveContent(&gmailwrap.Message{
+		Message: alt(mimePart("text/x-amp-html", "<html amp4email><body><amp-list></amp-list></body></html>")),
+	})
+	if !ampOnly.AMPOnly {
+		t.Errorf("AMP-only message = %+v", ampOnly)
+	}
+
+	// An AMP document sent as text/html is not a usable static version
+	ampAsHTML := `<!doctype html><html ⚡4email data-css-strict><body>x</body></html>`
+	if c := DetectInteractiveContent(&gmailwrap.Message{Message: alt(mimePart("text/html", ampAsHTML)), HTML: ampAsHTML}); !c.AMPOnly {
+		t.Errorf("AMP document as HTML = %+v", c)
+	}
+
+	form := `<p>Rate us</p><FORM action="https://x.example/vote"><input type="hidden" name="id" value="1">` +
+		`<input type="radio" name="r" value="5"><textarea name="c"></textarea><button>Send</button></form>`
+	c := DetectInteractiveContent(&gmailwrap.Message{Message: alt(mimePart("text/html", form)), HTML: form})
+	if c.AMP || c.Forms != 1 || c.FormFields != 2 {
+		t.Errorf("form message = %+v, want one form with two visible fields", c)
+	}
+
+	if c := DetectInteractiveContent(&gmailwrap.Message{Message: alt(mimePart("text/html", "<p>plain newsletter</p>")), HTML: "<p>plain newsletter</p>"}); c.Any() {
+		t.Errorf("ordinary HTML = %+v", c)
+	}
+}
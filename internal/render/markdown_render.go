@@ -92,9 +92,6 @@ func collapseEmptyTables(md string) string {
 	return strings.Join(out, "\n")
 }
 
-// mdLinkRe matches a Markdown inline link [text](http(s)://url).
-var mdLinkRe = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)]+)\)`)
-
 // MarkdownOptions controls markdown rendering and cleanup.
 type MarkdownOptions struct {
 	WrapWidth          int
@@ -103,15 +100,16 @@ type MarkdownOptions struct {
 }
 
 // cleanupMarkdown applies the newsletter cleanup pipeline to Markdown source.
-// Order matters: drop images and empty tables first, then reference URLs, then
-// reuse the existing terminal sanitizer (strips zero-width/spacer glyphs) and
-// near-duplicate paragraph deduper from format.go.
-func cleanupMarkdown(md string, opts MarkdownOptions) string {
+// Order matters: drop images and empty tables first, then turn links into
+// footnotes numbered like links (see footnoteLinks), then reuse the existing
+// terminal sanitizer (strips zero-width/spacer glyphs) and near-duplicate
+// paragraph deduper from format.go.
+func cleanupMarkdown(md string, links []LinkRef, opts MarkdownOptions) string {
 	if opts.DropTrackingImages {
 		md = dropTrackingImages(md)
 	}
 	md = collapseEmptyTables(md)
-	md = referenceLongURLs(md, 60)
+	md = footnoteLinks(md, links)
 	md = collapseDuplicateHalves(md)
 	md = sanitizeForTerminal(md)               // defined in format.go
 	md = dedupeNearDuplicateParagraphs(md, 32) // defined in format.go
@@ -152,40 +150,6 @@ func collapseLineDuplicate(line string) string {
 	return lead + strings.Join(fields[:half], " ")
 }
 
-// referenceLongURLs replaces inline links whose URL exceeds threshold characters
-// with "text [n]" numbered references, collected into a trailing "## Links"
-// section. Short links stay inline. Identical URLs share one reference number.
-func referenceLongURLs(md string, threshold int) string {
-	seen := map[string]int{}
-	order := make([]string, 0, 8)
-
-	body := mdLinkRe.ReplaceAllStringFunc(md, func(m string) string {
-		sub := mdLinkRe.FindStringSubmatch(m)
-		text, url := sub[1], sub[2]
-		if len(url) <= threshold {
-			return m
-		}
-		n, ok := seen[url]
-		if !ok {
-			n = len(order) + 1
-			seen[url] = n
-			order = append(order, url)
-		}
-		return fmt.Sprintf("%s [%d]", text, n)
-	})
-
-	if len(order) == 0 {
-		return body
-	}
-	var b strings.Builder
-	b.WriteString(strings.TrimRight(body, "\n"))
-	b.WriteString("\n\n## Links\n")
-	for i, url := range order {
-		fmt.Fprintf(&b, "%d. %s\n", i+1, url)
-	}
-	return b.String()
-}
-
 // RenderEmailMarkdown converts an email's HTML to cleaned, glamour-styled
 // terminal text. Returns an error if the message has no HTML body; callers fall
 // back to FormatEmailForTerminal on error.
@@ -197,7 +161,7 @@ func RenderEmailMarkdown(msg *gmailwrap.Message, opts MarkdownOptions) (string,
 	if err != nil {
 		return "", err
 	}
-	md = cleanupMarkdown(md, opts)
+	md = cleanupMarkdown(md, ExtractHTMLLinks(msg.HTML), opts)
 	if strings.TrimSpace(md) == "" {
 		return "", fmt.Errorf("empty after cleanup")
 	}
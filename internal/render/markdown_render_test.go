@@ -72,19 +72,25 @@ func TestCollapseEmptyTables(t *testing.T) {
 	}
 }
 
-func TestReferenceLongURLs(t *testing.T) {
-	longURL := "https://track.example.com/" + strings.Repeat("a", 80)
-	in := "Click [Buy now](" + longURL + ") today. Short [ok](https://x.io).\n"
-	got := referenceLongURLs(in, 60)
+func TestFootnoteLinks(t *testing.T) {
+	links := ExtractHTMLLinks(`<p><a href="https://a.io/x?b=1&amp;c=2">A</a> <a href="https://b.io">B</a></p>`)
+	in := "See [B](https://b.io), then [A](https://a.io/x?b=1&c=2) and [B again](https://b.io).\n" +
+		"![logo](https://img.io/l.png) [new](mailto:x@y.io)\n"
+	got := footnoteLinks(in, links)
 
-	if !strings.Contains(got, "Buy now [1]") {
-		t.Errorf("long link not referenced:\n%s", got)
+	if !strings.Contains(got, "See B [2], then A [1] and B again [2].") {
+		t.Errorf("links should carry the picker numbers:\n%s", got)
+	}
+	if !strings.Contains(got, "![logo](https://img.io/l.png)") {
+		t.Errorf("images must be left alone:\n%s", got)
 	}
-	if !strings.Contains(got, "## Links") || !strings.Contains(got, "1. "+longURL) {
-		t.Errorf("Links section missing/incorrect:\n%s", got)
+	if !strings.Contains(got, "new [3]") {
+		t.Errorf("a URL missing from the HTML links is numbered after them:\n%s", got)
 	}
-	if !strings.Contains(got, "[ok](https://x.io)") {
-		t.Errorf("short link should stay inline:\n%s", got)
+	section := got[strings.Index(got, "## Links"):]
+	want := "## Links\n\n- [1] https://a.io/x?b=1&c=2\n- [2] https://b.io\n- [3] mailto:x@y.io\n"
+	if section != want {
+		t.Errorf("footnotes section:\n got %q\nwant %q", section, want)
 	}
 }
 
@@ -95,7 +101,7 @@ func TestCleanupMarkdown(t *testing.T) {
 		"|  |  |\n| --- | --- |\n\n" +
 		"![](https://t.co/pixel.gif)\n\n" +
 		"# Real Heading\n\nBuy [now](" + longURL + ")\n"
-	got := cleanupMarkdown(in, MarkdownOptions{DropTrackingImages: true})
+	got := cleanupMarkdown(in, nil, MarkdownOptions{DropTrackingImages: true})
 
 	if strings.Contains(got, "\u200b") || strings.Contains(got, "\u034f") {
 		t.Errorf("zero-width chars not stripped:\n%q", got)
@@ -110,7 +116,7 @@ func TestCleanupMarkdown(t *testing.T) {
 		t.Errorf("real content lost:\n%s", got)
 	}
 	if !strings.Contains(got, "## Links") {
-		t.Errorf("long URL not referenced:\n%s", got)
+		t.Errorf("link not footnoted:\n%s", got)
 	}
 }
 
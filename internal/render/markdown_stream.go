@@ -0,0 +1,68 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/derailed/tview"
+)
+
+// MarkdownStream renders Markdown that arrives in pieces, such as a streamed LLM answer. Only
+// complete blocks go through the Markdown pipeline, so an unclosed code fence or half-written
+// list never flashes as broken formatting; the block still being written shows as plain text.
+// The rendered prefix is reused until another block completes.
+type MarkdownStream struct {
+	theme    string
+	width    int
+	done     int    // bytes of the source rendered, ending on a block boundary
+	rendered string // MarkdownToTerminal of source[:done]
+}
+
+// NewMarkdownStream creates a stream renderer with the glamour theme and wrap width
+func NewMarkdownStream(theme string, width int) *MarkdownStream {
+	return &MarkdownStream{theme: theme, width: width}
+}
+
+// Render returns tview text for the source received so far. Source is the whole text each time,
+// not the new piece.
+func (s *MarkdownStream) Render(source string) string {
+	if s.done > len(source) {
+		s.done, s.rendered = 0, ""
+	}
+	if cut := lastBlockBoundary(source); cut > s.done {
+		if out, err := MarkdownToTerminal(source[:cut], s.theme, s.width); err == nil {
+			s.done, s.rendered = cut, out
+		}
+	}
+	tail := source[s.done:]
+	if s.done == 0 {
+		return tview.Escape(tail)
+	}
+	return strings.TrimRight(s.rendered, "\n") + "\n\n" + tview.Escape(strings.TrimLeft(tail, "\n"))
+}
+
+// lastBlockBoundary returns the end of the last complete Markdown block: just after a blank line
+// or a closing code fence, never inside a fenced code block. 0 means no block is complete.
+func lastBlockBoundary(source string) int {
+	boundary, pos := 0, 0
+	inFence := false
+	fence := ""
+	for {
+		nl := strings.IndexByte(source[pos:], '\n')
+		if nl < 0 {
+			return boundary
+		}
+		line := strings.TrimSpace(source[pos : pos+nl])
+		pos += nl + 1
+		switch {
+		case inFence:
+			if strings.HasPrefix(line, fence) {
+				inFence = false
+				boundary = pos
+			}
+		case strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~"):
+			inFence, fence = true, line[:3]
+		case line == "":
+			boundary = pos
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLastBlockBoundary(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		want   int
+	}{
+		{"no block finished", "# Title\nsome text", 0},
+		{"after blank line", "# Title\n\nsome", len("# Title\n\n")},
+		{"inside open fence", "Intro\n\n```go\nfunc x() {\n\n", len("Intro\n\n")},
+		{"after closed fence", "```\ncode\n\n```\nmore", len("```\ncode\n\n```\n")},
+	}
+	for _, c := range cases {
+		if got := lastBlockBoundary(c.source); got != c.want {
+			t.Errorf("%s: got %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMarkdownStream_RendersCompleteBlocksOnly(t *testing.T) {
+	s := NewMarkdownStream("dark", 80)
+
+	// Nothing finished yet: plain, escaped text
+	if out := s.Render("**bold [tag]"); out != "**bold [tag[]" {
+		t.Errorf("unfinished block should be escaped plain text, got %q", out)
+	}
+
+	// First paragraph done: it is rendered, the tail stays plain
+	out := s.Render("**bold** first\n\nsecond **half")
+	if strings.Contains(out, "**bold**") {
+		t.Errorf("finished block should be rendered as Markdown: %q", out)
+	}
+	if !strings.HasSuffix(out, "second **half") {
+		t.Errorf("tail should stay plain text: %q", out)
+	}
+}
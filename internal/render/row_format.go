@@ -0,0 +1,230 @@
+package render
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	googleGmail "google.golang.org/api/gmail/v1"
+)
+
+// RowFormatFields lists the fields a row format template can use
+var RowFormatFields = []string{"flags", "date", "from", "email", "to", "subject", "labels", "size", "attach", "snippet"}
+
+// rowSegment is literal text or a field of a row format
+type rowSegment struct {
+	literal  string
+	field    string
+	width    int  // pad/truncate to exactly this many cells (0 = natural width)
+	maxWidth int  // truncate beyond this many cells, no padding (0 = unlimited)
+	right    bool // right-align within width
+	fill     bool // take the width left over by the rest of the row
+}
+
+// RowFormat is a parsed list row template, in the spirit of mutt's index_format:
+//
+//	{flags} {date:>6} {from:20} {subject:*} {labels:.24}
+//
+// {name} inserts a field as is; {name:20} pads or truncates it to 20 cells, {name:>8}
+// right-aligns it in 8; {name:.30} only truncates beyond 30; {name:*} fills the rest of the row
+// (one fill field at most). {{ and }} are literal braces.
+type RowFormat struct {
+	segments []rowSegment
+}
+
+// ParseRowFormat parses a row format template
+func ParseRowFormat(tmpl string) (*RowFormat, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		return nil, fmt.Errorf("row format is empty")
+	}
+	known := make(map[string]bool, len(RowFormatFields))
+	for _, f := range RowFormatFields {
+		known[f] = true
+	}
+	f := &RowFormat{}
+	var lit strings.Builder
+	fills := 0
+	for i := 0; i < len(tmpl); i++ {
+		c := tmpl[i]
+		switch {
+		case c == '{' && i+1 < len(tmpl) && tmpl[i+1] == '{':
+			lit.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(tmpl) && tmpl[i+1] == '}':
+			lit.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed { at position %d", i+1)
+			}
+			seg, err := parseRowField(tmpl[i+1:i+end], known)
+			if err != nil {
+				return nil, err
+			}
+			if seg.fill {
+				fills++
+			}
+			if lit.Len() > 0 {
+				f.segments = append(f.segments, rowSegment{literal: lit.String()})
+				lit.Reset()
+			}
+			f.segments = append(f.segments, seg)
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("unexpected } at position %d (use }} for a literal brace)", i+1)
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	if lit.Len() > 0 {
+		f.segments = append(f.segments, rowSegment{literal: lit.String()})
+	}
+	if fills > 1 {
+		return nil, fmt.Errorf("only one field can fill the row (:*)")
+	}
+	return f, nil
+}
+
+// parseRowField parses the inside of {name[:spec]}
+func parseRowField(s string, known map[string]bool) (rowSegment, error) {
+	name, spec, _ := strings.Cut(s, ":")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !known[name] {
+		return rowSegment{}, fmt.Errorf("unknown field {%s} (fields: %s)", name, strings.Join(RowFormatFields, ", "))
+	}
+	seg := rowSegment{field: name}
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "":
+	case spec == "*":
+		seg.fill = true
+	case strings.HasPrefix(spec, "."):
+		n, err := strconv.Atoi(spec[1:])
+		if err != nil || n <= 0 {
+			return rowSegment{}, fmt.Errorf("invalid width in {%s}", s)
+		}
+		seg.maxWidth = n
+	default:
+		if strings.HasPrefix(spec, ">") {
+			seg.right = true
+			spec = spec[1:]
+		} else {
+			spec = strings.TrimPrefix(spec, "<")
+		}
+		n, err := strconv.Atoi(spec)
+		if err != nil || n <= 0 {
+			return rowSegment{}, fmt.Errorf("invalid width in {%s}", s)
+		}
+		seg.width = n
+	}
+	return seg, nil
+}
+
+// Render lays out one row from field values; width is the room available for a fill field
+func (f *RowFormat) Render(values map[string]string, width int) string {
+	parts := make([]string, len(f.segments))
+	used, fillAt := 0, -1
+	for i, seg := range f.segments {
+		if seg.fill {
+			fillAt = i
+			continue
+		}
+		if seg.field == "" {
+			parts[i] = seg.literal
+		} else {
+			parts[i] = seg.apply(values[seg.field])
+		}
+		used += runewidth.StringWidth(parts[i])
+	}
+	if fillAt >= 0 {
+		parts[fillAt] = fitCells(values[f.segments[fillAt].field], width-used, false)
+	}
+	return strings.Join(parts, "")
+}
+
+// Header renders the template with the field names as values, for the column header
+func (f *RowFormat) Header(width int) string {
+	names := make(map[string]string, len(RowFormatFields))
+	for _, name := range RowFormatFields {
+		names[name] = strings.ToUpper(name[:1]) + name[1:]
+	}
+	names["flags"] = ""
+	names["attach"] = ""
+	return f.Render(names, width)
+}
+
+// apply sizes a field value according to its spec
+func (seg rowSegment) apply(v string) string {
+	switch {
+	case seg.width > 0:
+		return fitCells(v, seg.width, seg.right)
+	case seg.maxWidth > 0:
+		return runewidth.Truncate(v, seg.maxWidth, "…")
+	}
+	return v
+}
+
+// fitCells truncates (with an ellipsis) and pads a value to exactly width display cells
+func fitCells(v string, width int, right bool) string {
+	if width <= 0 {
+		return ""
+	}
+	v = runewidth.Truncate(v, width, "…")
+	pad := strings.Repeat(" ", width-runewidth.StringWidth(v))
+	if right {
+		return pad + v
+	}
+	return v + pad
+}
+
+// RowFields extracts the row format field values of a message; flags is left to the caller,
+// which knows about selection state
+func (er *EmailRenderer) RowFields(message *googleGmail.Message) map[string]string {
+	values := make(map[string]string, len(RowFormatFields))
+	if message == nil || message.Payload == nil {
+		return values
+	}
+	from := er.getHeader(message, "From")
+	values["from"] = er.extractHeaderName(from)
+	if values["from"] == "" {
+		values["from"] = "(No sender)"
+	}
+	if addr, err := mail.ParseAddress(from); err == nil {
+		values["email"] = addr.Address
+		if addr.Name != "" {
+			values["from"] = addr.Name
+		}
+	} else {
+		values["email"] = from
+	}
+	if d, ok := er.senderOverride(from); ok {
+		values["from"] = d.Label(values["from"])
+	}
+	values["to"] = er.getHeader(message, "To")
+	values["subject"] = er.getHeader(message, "Subject")
+	if values["subject"] == "" {
+		values["subject"] = "(No subject)"
+	}
+	values["date"] = er.formatRelativeTime(er.getDate(message))
+	values["labels"] = er.FormatLabelsForColumn(message, 1<<10)
+	values["size"] = compactSize(message.SizeEstimate)
+	values["attach"] = strings.TrimSpace(er.ExtractAttachmentIcon(message) + er.ExtractCalendarIcon(message))
+	values["snippet"] = message.Snippet
+	return values
+}
+
+// compactSize formats a byte count like 950, 12K or 1.4M
+func compactSize(n int64) string {
+	switch {
+	case n <= 0:
+		return ""
+	case n < 1024:
+		return strconv.FormatInt(n, 10)
+	case n < 1024*1024:
+		return fmt.Sprintf("%dK", (n+512)/1024)
+	}
+	return fmt.Sprintf("%.1fM", float64(n)/(1024*1024))
+}
@@ -0,0 +1,65 @@
+package render
+
+import "testing"
+
+func TestParseRowFormat_Errors(t *testing.T) {
+	for _, tmpl := range []string{"", "{nope}", "{from:abc}", "{from", "a } b", "{from:*} {subject:*}", "{date:.0}"} {
+		if _, err := ParseRowFormat(tmpl); err == nil {
+			t.Errorf("ParseRowFormat(%q) should fail", tmpl)
+		}
+	}
+}
+
+func TestRowFormat_Render(t *testing.T) {
+	values := map[string]string{"flags": "●", "date": "3h", "from": "Alice Wonderland", "subject": "Quarterly numbers", "size": "12K"}
+	tests := []struct {
+		tmpl  string
+		width int
+		want  string
+	}{
+		{"{flags} {from}", 80, "● Alice Wonderland"},
+		{"{date:>4}|{from:8}|", 80, "  3h|Alice W…|"},
+		{"{from:<10}|", 80, "Alice Won…|"},
+		{"{subject:.9}", 80, "Quarterl…"},
+		{"{from:5} {subject:*} {size:>4}", 20, "Alic… Quarterl…  12K"},
+		{"{{{size}}}", 80, "{12K}"},
+		{"{labels}", 80, ""},
+	}
+	for _, tt := range tests {
+		f, err := ParseRowFormat(tt.tmpl)
+		if err != nil {
+			t.Fatalf("ParseRowFormat(%q): %v", tt.tmpl, err)
+		}
+		if got := f.Render(values, tt.width); got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+}
+
+func TestRowFormat_Header(t *testing.T) {
+	f, err := ParseRowFormat("{flags} {date:>5} {from:6} {subject}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Header(80), "  Date From   Subject"; got != want {
+		t.Errorf("Header = %q, want %q", got, want)
+	}
+}
+
+func TestEmailRenderer_RowFields(t *testing.T) {
+	er := NewEmailRenderer(nil)
+	m := rmsg([]string{"INBOX", "Label_1"}, map[string]string{"From": "Bob Smith <bob@example.com>", "Subject": "Hi"}, 0)
+	m.SizeEstimate = 2048
+	m.Snippet = "see you"
+	er.SetLabelMap(map[string]string{"Label_1": "Work"})
+	v := er.RowFields(m)
+	want := map[string]string{"from": "Bob Smith", "email": "bob@example.com", "subject": "Hi", "size": "2K", "labels": "[Work]", "snippet": "see you", "attach": ""}
+	for k, w := range want {
+		if v[k] != w {
+			t.Errorf("field %s = %q, want %q", k, v[k], w)
+		}
+	}
+	if got := compactSize(3 * 1024 * 1024 / 2); got != "1.5M" {
+		t.Errorf("compactSize = %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"net/mail"
+	"sort"
+	"strings"
+)
+
+// senderColorDots maps the color tags a sender override can carry to the dot shown before the
+// name; dots keep the tag visible in any theme and in ANSI content without markup
+var senderColorDots = map[string]string{
+	"red":    "🔴",
+	"orange": "🟠",
+	"yellow": "🟡",
+	"green":  "🟢",
+	"blue":   "🔵",
+	"purple": "🟣",
+	"brown":  "🟤",
+	"black":  "⚫",
+	"white":  "⚪",
+}
+
+// SenderColors lists the color tags a sender override accepts
+func SenderColors() []string {
+	out := make([]string, 0, len(senderColorDots))
+	for c := range senderColorDots {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsSenderColor reports whether c is a known color tag
+func IsSenderColor(c string) bool {
+	_, ok := senderColorDots[strings.ToLower(c)]
+	return ok
+}
+
+// SenderDisplay is how a sender is shown instead of the name in its From header
+type SenderDisplay struct {
+	Name  string // replaces the header's display name; empty keeps it
+	Emoji string
+	Color string // one of SenderColors
+}
+
+// Label composes the displayed sender: color dot, emoji, then the custom or original name
+func (d SenderDisplay) Label(original string) string {
+	name := strings.TrimSpace(d.Name)
+	if name == "" {
+		name = original
+	}
+	parts := make([]string, 0, 3)
+	if dot := senderColorDots[strings.ToLower(d.Color)]; dot != "" {
+		parts = append(parts, dot)
+	}
+	if e := strings.TrimSpace(d.Emoji); e != "" {
+		parts = append(parts, e)
+	}
+	return strings.Join(append(parts, name), " ")
+}
+
+// SetSenderDisplays sets the per-sender display overrides, keyed by lowercase address
+func (er *EmailRenderer) SetSenderDisplays(m map[string]SenderDisplay) { er.senderDisplays = m }
+
+// SenderAddress extracts the lowercase email address of a From header value
+func SenderAddress(from string) string {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return ""
+	}
+	if addr, err := mail.ParseAddress(from); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+	if i, j := strings.LastIndex(from, "<"), strings.LastIndex(from, ">"); i != -1 && j > i {
+		return strings.ToLower(strings.TrimSpace(from[i+1 : j]))
+	}
+	if strings.Contains(from, "@") && !strings.ContainsAny(from, " \t") {
+		return strings.ToLower(from)
+	}
+	return ""
+}
+
+// senderOverride returns the display override for a From header value
+func (er *EmailRenderer) senderOverride(from string) (SenderDisplay, bool) {
+	if len(er.senderDisplays) == 0 {
+		return SenderDisplay{}, false
+	}
+	d, ok := er.senderDisplays[SenderAddress(from)]
+	return d, ok
+}
+
+// decorateFromHeader applies a sender override to the From line of the reader header, keeping
+// the address visible
+func (er *EmailRenderer) decorateFromHeader(from string) string {
+	d, ok := er.senderOverride(from)
+	if !ok {
+		return from
+	}
+	return d.Label(er.extractHeaderName(from)) + " <" + SenderAddress(from) + ">"
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSenderDisplay_Overrides(t *testing.T) {
+	er := NewEmailRenderer(nil)
+	er.SetSenderDisplays(map[string]SenderDisplay{
+		"alerts@pagerduty.com": {Name: "PagerDuty", Emoji: "🚨", Color: "red"},
+		"boss@example.com":     {Emoji: "👩‍💼"},
+	})
+
+	if got := er.ExtractSenderName("PD Alerts <ALERTS@pagerduty.com>"); got != "🔴 🚨 PagerDuty" {
+		t.Fatalf("overridden sender = %q", got)
+	}
+	if got := er.ExtractSenderName("Jane Boss <boss@example.com>"); got != "👩‍💼 Jane Boss" {
+		t.Fatalf("emoji-only override = %q, want the header name kept", got)
+	}
+	if got := er.ExtractSenderName("Bob <bob@example.com>"); got != "Bob" {
+		t.Fatalf("sender without override = %q", got)
+	}
+
+	fields := er.RowFields(rmsg(nil, map[string]string{"From": "Jane Boss <boss@example.com>"}, 0))
+	if fields["from"] != "👩‍💼 Jane Boss" || fields["email"] != "boss@example.com" {
+		t.Fatalf("row fields = %q / %q", fields["from"], fields["email"])
+	}
+
+	header := er.FormatHeaderCompactWithWidth("Down", "PD Alerts <alerts@pagerduty.com>", time.Now(), 120)
+	if !strings.Contains(header, "🔴 🚨 PagerDuty <alerts@pagerduty.com>") {
+		t.Fatalf("header lacks the override and address: %q", header)
+	}
+}
+
+func TestSenderAddress(t *testing.T) {
+	for in, want := range map[string]string{
+		"Jane <Jane@Example.com>":   "jane@example.com",
+		"jane@example.com":          "jane@example.com",
+		"Broken <jane@example.com":  "",
+		"Odd, Name <x@example.com>": "x@example.com",
+		"":                          "",
+	} {
+		if got := SenderAddress(in); got != want {
+			t.Errorf("SenderAddress(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
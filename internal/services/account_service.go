@@ -60,6 +60,8 @@ func (s *AccountServiceImpl) loadAccountsFromConfig() {
 			IsActive:    accountCfg.Active,
 			Status:      AccountStatusUnknown,
 			LastUsed:    time.Now(),
+
+			InternalDomains: accountCfg.InternalDomains,
 		}
 
 		// Try to extract email from existing token if possible
@@ -467,11 +469,7 @@ func (s *AccountServiceImpl) initializeClient(ctx context.Context, accountID str
 	// Create Gmail service with account context for better OAuth messaging
 	service, err := auth.NewGmailServiceWithAccount(ctx, credPath, tokenPath,
 		fmt.Sprintf("%s (%s)", account.DisplayName, account.ID),
-		"https://www.googleapis.com/auth/gmail.readonly",
-		"https://www.googleapis.com/auth/gmail.send",
-		"https://www.googleapis.com/auth/gmail.modify",
-		"https://www.googleapis.com/auth/gmail.compose",
-		"https://www.googleapis.com/auth/calendar.events",
+		auth.ScopesFor(s.signInFeatures()...)...,
 	)
 	if err != nil {
 		account.Status = AccountStatusError
@@ -487,6 +485,14 @@ func (s *AccountServiceImpl) initializeClient(ctx context.Context, accountID str
 	return nil
 }
 
+// signInFeatures returns the features whose permissions sign-in asks for (access.mode)
+func (s *AccountServiceImpl) signInFeatures() []string {
+	if s.config == nil {
+		return config.AccessConfig{}.SignInFeatures()
+	}
+	return s.config.Access.SignInFeatures()
+}
+
 // expandPath expands ~ to home directory
 func (s *AccountServiceImpl) expandPath(path string) string {
 	if !strings.HasPrefix(path, "~") {
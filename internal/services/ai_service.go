@@ -39,7 +39,7 @@ func (s *AIServiceImpl) GenerateSummary(ctx context.Context, content string, opt
 
 	// Check cache first if enabled and not forcing regeneration
 	if options.UseCache && !options.ForceRegenerate && s.cacheService != nil {
-		if cached, found, err := s.cacheService.GetSummary(ctx, options.AccountEmail, options.MessageID); err == nil && found {
+		if cached, found, err := s.cacheService.GetSummary(ctx, options.AccountEmail, SummaryCacheKey(options.MessageID, options.Preset)); err == nil && found {
 			return &SummaryResult{
 				Summary:   cached,
 				FromCache: true,
@@ -59,12 +59,12 @@ func (s *AIServiceImpl) GenerateSummary(ctx context.Context, content string, opt
 	}
 
 	// Build prompt
-	prompt := s.config.LLM.GetSummarizePrompt()
+	prompt := s.config.LLM.GetSummaryPresetPrompt(options.Preset)
 	if prompt == "" {
 		prompt = "Briefly summarize the following email. Keep it concise and factual.\n\n{{body}}"
 	}
 
-	prompt = strings.ReplaceAll(prompt, "{{body}}", content)
+	prompt = s.fillSummaryPrompt(prompt, content, options)
 
 	// Generate summary
 	summary, err := s.provider.Generate(prompt)
@@ -74,7 +74,7 @@ func (s *AIServiceImpl) GenerateSummary(ctx context.Context, content string, opt
 
 	// Cache the result if caching is enabled
 	if options.UseCache && s.cacheService != nil {
-		if err := s.cacheService.SaveSummary(ctx, options.AccountEmail, options.MessageID, summary); err != nil {
+		if err := s.cacheService.SaveSummary(ctx, options.AccountEmail, SummaryCacheKey(options.MessageID, options.Preset), summary); err != nil {
 			// Save to cache failed, but don't fail the entire operation
 			// Note: Cache failures are logged within the cache service if needed
 			_ = err // Acknowledge error is intentionally ignored
@@ -89,6 +89,15 @@ func (s *AIServiceImpl) GenerateSummary(ctx context.Context, content string, opt
 	}, nil
 }
 
+// SummaryCacheKey is the summary cache key of a message: the message ID for the default summary,
+// and a per-preset key otherwise so each style is cached separately
+func SummaryCacheKey(messageID, preset string) string {
+	if preset == "" {
+		return messageID
+	}
+	return fmt.Sprintf("summary_%s_%s", preset, messageID)
+}
+
 // GenerateSummaryStream generates a summary with streaming support
 func (s *AIServiceImpl) GenerateSummaryStream(ctx context.Context, content string, options SummaryOptions, onToken func(string)) (*SummaryResult, error) {
 	if s.provider == nil {
@@ -103,7 +112,7 @@ func (s *AIServiceImpl) GenerateSummaryStream(ctx context.Context, content strin
 
 	// Check cache first if enabled and not forcing regeneration
 	if options.UseCache && !options.ForceRegenerate && s.cacheService != nil {
-		if cached, found, err := s.cacheService.GetSummary(ctx, options.AccountEmail, options.MessageID); err == nil && found {
+		if cached, found, err := s.cacheService.GetSummary(ctx, options.AccountEmail, SummaryCacheKey(options.MessageID, options.Preset)); err == nil && found {
 			return &SummaryResult{
 				Summary:   cached,
 				FromCache: true,
@@ -123,12 +132,12 @@ func (s *AIServiceImpl) GenerateSummaryStream(ctx context.Context, content strin
 	}
 
 	// Build prompt
-	prompt := s.config.LLM.GetSummarizePrompt()
+	prompt := s.config.LLM.GetSummaryPresetPrompt(options.Preset)
 	if prompt == "" {
 		prompt = "Briefly summarize the following email. Keep it concise and factual.\n\n{{body}}"
 	}
 
-	prompt = strings.ReplaceAll(prompt, "{{body}}", content)
+	prompt = s.fillSummaryPrompt(prompt, content, options)
 
 	// Check if provider supports streaming
 	if streamer, ok := s.provider.(interface {
@@ -151,7 +160,7 @@ func (s *AIServiceImpl) GenerateSummaryStream(ctx context.Context, content strin
 
 		// Cache the result if caching is enabled
 		if options.UseCache && s.cacheService != nil {
-			if err := s.cacheService.SaveSummary(ctx, options.AccountEmail, options.MessageID, summary); err != nil {
+			if err := s.cacheService.SaveSummary(ctx, options.AccountEmail, SummaryCacheKey(options.MessageID, options.Preset), summary); err != nil {
 				// Save to cache failed, but don't fail the entire operation
 				// Note: Cache failures are logged within the cache service if needed
 				_ = err // Acknowledge error is intentionally ignored
@@ -170,6 +179,17 @@ func (s *AIServiceImpl) GenerateSummaryStream(ctx context.Context, content strin
 	return s.GenerateSummary(ctx, content, options)
 }
 
+// fillSummaryPrompt fills a summary prompt's {{body}} and {{language}}; options.Language, when
+// set, wins over the configured language
+func (s *AIServiceImpl) fillSummaryPrompt(prompt, content string, options SummaryOptions) string {
+	lang := strings.TrimSpace(options.Language)
+	if lang == "" {
+		lang = s.config.LLM.ResolvedLanguage()
+	}
+	prompt = strings.ReplaceAll(prompt, "{{language}}", lang)
+	return strings.ReplaceAll(prompt, "{{body}}", content)
+}
+
 func (s *AIServiceImpl) GenerateReply(ctx context.Context, content string, options ReplyOptions) (string, error) {
 	if s.provider == nil {
 		return "", fmt.Errorf("AI provider not available")
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/ajramos/giztui/internal/config"
@@ -199,3 +200,62 @@ func TestAIServiceImpl_GenerateSummary_CacheMiss(t *testing.T) {
 	provider.AssertExpectations(t)
 	cacheService.AssertExpectations(t)
 }
+
+// Test that a summary preset uses its own prompt and cache entry
+func TestAIServiceImpl_GenerateSummary_Preset(t *testing.T) {
+	ctx := context.Background()
+	provider := &MockLLMProvider{}
+	cacheService := &MockCacheService{}
+	cfg := &config.Config{
+		LLM: config.LLMConfig{
+			SummaryPresetTemplates: map[string]string{"bullets": "/nonexistent/bullets.md"},
+		},
+	}
+	service := NewAIService(provider, cacheService, cfg)
+
+	key := SummaryCacheKey("msg123", "bullets")
+	assert.Equal(t, "summary_bullets_msg123", key)
+	assert.Equal(t, "msg123", SummaryCacheKey("msg123", ""))
+
+	prompt := strings.ReplaceAll(cfg.LLM.GetSummaryPresetPrompt("bullets"), "{{body}}", "test content")
+	cacheService.On("GetSummary", ctx, "test@example.com", key).Return("", false, nil)
+	provider.On("Generate", prompt).Return("- one", nil)
+	cacheService.On("SaveSummary", ctx, "test@example.com", key, "- one").Return(nil)
+
+	result, err := service.GenerateSummary(ctx, "test content", SummaryOptions{
+		UseCache:     true,
+		AccountEmail: "test@example.com",
+		MessageID:    "msg123",
+		Preset:       "bullets",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "- one", result.Summary)
+	assert.Contains(t, prompt, "bullet")
+	provider.AssertExpectations(t)
+	cacheService.AssertExpectations(t)
+}
+
+// Test that the explain style writes in the configured language
+func TestAIServiceImpl_GenerateSummary_ExplainLanguage(t *testing.T) {
+	provider := &MockLLMProvider{}
+	cfg := &config.Config{
+		LLM: config.LLMConfig{
+			Language:               "Spanish",
+			SummaryPresetTemplates: map[string]string{"explain": "/nonexistent/explain.md"},
+		},
+	}
+	service := NewAIService(provider, nil, cfg)
+
+	provider.On("Generate", mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "in Spanish") && strings.Contains(prompt, "Dear customer") &&
+			!strings.Contains(prompt, "{{")
+	})).Return("Hola", nil)
+
+	result, err := service.GenerateSummary(context.Background(), "Dear customer", SummaryOptions{Preset: "explain"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hola", result.Summary)
+	provider.AssertExpectations(t)
+
+	assert.Equal(t, "English", (&config.LLMConfig{}).ResolvedLanguage())
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// AlertGroup is a set of notification emails sharing a rule and an alert key
+type AlertGroup struct {
+	Rule     string
+	Key      string
+	Messages []*gmail_v1.Message // newest first
+}
+
+// Count is the number of occurrences in the group
+func (g *AlertGroup) Count() int { return len(g.Messages) }
+
+// Latest is the date of the newest occurrence (zero when unknown)
+func (g *AlertGroup) Latest() time.Time {
+	if len(g.Messages) == 0 || g.Messages[0].InternalDate <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(g.Messages[0].InternalDate)
+}
+
+type alertRule struct {
+	name    string
+	subject *regexp.Regexp
+	from    string
+}
+
+// AlertGrouper collapses repeated notification emails according to alert_groups.rules. It only
+// looks at metadata (Subject and From headers, internal date), so it works on the loaded list.
+type AlertGrouper struct {
+	rules []alertRule
+}
+
+// NewAlertGrouper compiles the configured rules. It returns nil when there are none.
+func NewAlertGrouper(rules []config.AlertGroupRule) (*AlertGrouper, error) {
+	g := &AlertGrouper{}
+	for i, r := range rules {
+		if strings.TrimSpace(r.Subject) == "" {
+			return nil, fmt.Errorf("alert group rule %d: subject pattern is empty", i+1)
+		}
+		re, err := regexp.Compile(r.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("alert group rule %d: %w", i+1, err)
+		}
+		name := strings.TrimSpace(r.Name)
+		if name == "" {
+			name = fmt.Sprintf("alert %d", i+1)
+		}
+		g.rules = append(g.rules, alertRule{name: name, subject: re, from: strings.ToLower(strings.TrimSpace(r.From))})
+	}
+	if len(g.rules) == 0 {
+		return nil, nil
+	}
+	return g, nil
+}
+
+// Key returns the rule and alert key of a message; ok is false when no rule matches. The key is
+// the first capture group of the subject pattern, or the whole match, with spaces collapsed.
+func (g *AlertGrouper) Key(m *gmail_v1.Message) (rule, key string, ok bool) {
+	if g == nil || m == nil {
+		return "", "", false
+	}
+	subject := reportHeader(m, "Subject")
+	from := strings.ToLower(reportHeader(m, "From"))
+	for _, r := range g.rules {
+		if r.from != "" && !strings.Contains(from, r.from) {
+			continue
+		}
+		match := r.subject.FindStringSubmatch(subject)
+		if match == nil {
+			continue
+		}
+		key = match[0]
+		if len(match) > 1 && match[1] != "" {
+			key = match[1]
+		}
+		return r.name, strings.Join(strings.Fields(key), " "), true
+	}
+	return "", "", false
+}
+
+// Collapse keeps one row per alert group with two or more occurrences: the newest message,
+// placed where the group first appears in msgs. Other messages pass through in order. groups maps
+// the ID of each row that stands for a group to that group.
+func (g *AlertGrouper) Collapse(msgs []*gmail_v1.Message) (rows []*gmail_v1.Message, groups map[string]*AlertGroup) {
+	type slot struct {
+		group *AlertGroup
+		at    int // index in rows
+	}
+	byKey := make(map[string]*slot)
+	for _, m := range msgs {
+		rule, key, ok := g.Key(m)
+		if !ok {
+			rows = append(rows, m)
+			continue
+		}
+		id := rule + "\x00" + strings.ToLower(key)
+		s, seen := byKey[id]
+		if !seen {
+			byKey[id] = &slot{group: &AlertGroup{Rule: rule, Key: key, Messages: []*gmail_v1.Message{m}}, at: len(rows)}
+			rows = append(rows, m)
+			continue
+		}
+		s.group.Messages = insertByDate(s.group.Messages, m)
+	}
+
+	groups = make(map[string]*AlertGroup)
+	for _, s := range byKey {
+		if s.group.Count() < 2 {
+			continue
+		}
+		newest := s.group.Messages[0]
+		rows[s.at] = newest
+		groups[newest.Id] = s.group
+	}
+	return rows, groups
+}
+
+// insertByDate adds m to a newest-first list; messages without a date go last
+func insertByDate(list []*gmail_v1.Message, m *gmail_v1.Message) []*gmail_v1.Message {
+	i := 0
+	for i < len(list) && list[i].InternalDate >= m.InternalDate {
+		i++
+	}
+	list = append(list, nil)
+	copy(list[i+1:], list[i:])
+	list[i] = m
+	return list
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func alertMsg(id, from, subject string, date int64) *gmail_v1.Message {
+	return &gmail_v1.Message{Id: id, InternalDate: date, Payload: &gmail_v1.MessagePart{Headers: []*gmail_v1.MessagePartHeader{
+		{Name: "From", Value: from},
+		{Name: "Subject", Value: subject},
+	}}}
+}
+
+func TestNewAlertGrouper(t *testing.T) {
+	g, err := NewAlertGrouper(nil)
+	require.NoError(t, err)
+	assert.Nil(t, g)
+
+	_, err = NewAlertGrouper([]config.AlertGroupRule{{Name: "CI", Subject: "("}})
+	assert.Error(t, err)
+	_, err = NewAlertGrouper([]config.AlertGroupRule{{Name: "CI", Subject: " "}})
+	assert.Error(t, err)
+}
+
+func TestAlertGrouper_Key(t *testing.T) {
+	g, err := NewAlertGrouper([]config.AlertGroupRule{
+		{Name: "CI", Subject: `^\[CI\] (.+?) #\d+ failed`, From: "ci@example.com"},
+		{Subject: `(?i)disk usage high`},
+	})
+	require.NoError(t, err)
+
+	rule, key, ok := g.Key(alertMsg("1", "CI <ci@example.com>", "[CI] api   build #12 failed", 0))
+	assert.True(t, ok)
+	assert.Equal(t, "CI", rule)
+	assert.Equal(t, "api build", key)
+
+	// The sender filter applies
+	_, _, ok = g.Key(alertMsg("2", "bob@example.com", "[CI] api build #12 failed", 0))
+	assert.False(t, ok)
+
+	// No capture group: the whole match is the key; unnamed rules get a default name
+	rule, key, ok = g.Key(alertMsg("3", "mon@example.com", "Disk Usage High on db1", 0))
+	assert.True(t, ok)
+	assert.Equal(t, "alert 2", rule)
+	assert.Equal(t, "Disk Usage High", key)
+}
+
+func TestAlertGrouper_Collapse(t *testing.T) {
+	g, err := NewAlertGrouper([]config.AlertGroupRule{{Name: "CI", Subject: `^\[CI\] (\S+) failed`}})
+	require.NoError(t, err)
+
+	msgs := []*gmail_v1.Message{
+		alertMsg("a", "x", "Hello", 500),
+		alertMsg("b", "x", "[CI] api failed", 300),
+		alertMsg("c", "x", "[CI] web failed", 250),
+		alertMsg("d", "x", "[CI] API failed", 400), // newer but listed later: still one group
+		alertMsg("e", "x", "Lunch", 200),
+		alertMsg("f", "x", "[CI] api failed", 100),
+	}
+	rows, groups := g.Collapse(msgs)
+
+	ids := make([]string, len(rows))
+	for i, m := range rows {
+		ids[i] = m.Id
+	}
+	assert.Equal(t, []string{"a", "d", "c", "e"}, ids)
+	require.Len(t, groups, 1)
+	grp := groups["d"]
+	require.NotNil(t, grp)
+	assert.Equal(t, 3, grp.Count())
+	assert.Equal(t, "d", grp.Messages[0].Id)
+	assert.Equal(t, "f", grp.Messages[2].Id)
+	assert.Equal(t, int64(400), grp.Latest().UnixMilli())
+}
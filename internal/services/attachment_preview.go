@@ -0,0 +1,141 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultAttachmentPreviewMaxKB is the preview size limit when attachments.preview_max_kb is unset
+const defaultAttachmentPreviewMaxKB = 256
+
+// attachmentPreviewLanguages maps previewable extensions to the code block language used for
+// syntax highlighting ("" is plain text)
+var attachmentPreviewLanguages = map[string]string{
+	".txt": "", ".text": "", ".log": "", ".out": "",
+	".csv": "csv", ".tsv": "csv",
+	".json": "json", ".ndjson": "json",
+	".patch": "diff", ".diff": "diff",
+	".md": "markdown", ".markdown": "markdown",
+	".yaml": "yaml", ".yml": "yaml", ".toml": "toml", ".ini": "ini", ".conf": "ini", ".cfg": "ini",
+	".xml": "xml", ".sql": "sql", ".sh": "bash",
+	".ics": "ics", ".vcf": "vcard", ".vcard": "vcard",
+}
+
+// AttachmentPreview is the text of a small attachment ready to show in the content pane.
+// CSV, calendar and vCard attachments also carry their parsed form for the structured viewers.
+type AttachmentPreview struct {
+	Filename string
+	Language string // code block language for highlighting; "" for plain text
+	Text     string
+	Size     int
+
+	Table    *CSVTable  // CSV/TSV rows
+	Events   []ICSEvent // .ics events
+	Contacts []VCard    // .vcf contacts
+}
+
+// AttachmentPreviewLanguage reports whether an attachment is text-like enough to preview inline
+// and the language to highlight it with. The extension decides; other text/* types are plain text.
+func AttachmentPreviewLanguage(filename, mimeType string) (string, bool) {
+	if lang, ok := attachmentPreviewLanguages[strings.ToLower(filepath.Ext(filename))]; ok {
+		return lang, true
+	}
+	mimeType = strings.ToLower(mimeType)
+	switch {
+	case mimeType == "application/json":
+		return "json", true
+	case mimeType == "text/csv":
+		return "csv", true
+	case mimeType == "text/x-diff" || mimeType == "text/x-patch":
+		return "diff", true
+	case mimeType == "text/calendar":
+		return "ics", true
+	case mimeType == "text/vcard" || mimeType == "text/x-vcard":
+		return "vcard", true
+	case strings.HasPrefix(mimeType, "text/") && mimeType != "text/html":
+		return "", true
+	}
+	return "", false
+}
+
+// NewAttachmentPreview validates downloaded attachment data as text. JSON is pretty-printed when
+// it parses, and CSV, calendar and vCard data is parsed for the structured viewers (falling back
+// to the raw text when parsing finds nothing); binary content (NUL bytes or invalid UTF-8) is
+// rejected.
+func NewAttachmentPreview(filename, mimeType string, data []byte) (*AttachmentPreview, error) {
+	lang, ok := AttachmentPreviewLanguage(filename, mimeType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a text attachment", filename)
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return nil, fmt.Errorf("%s does not look like text", filename)
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if lang == "json" {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, data, "", "  "); err == nil {
+			text = pretty.String()
+		}
+	}
+	p := &AttachmentPreview{Filename: filename, Language: lang, Text: text, Size: len(data)}
+	switch lang {
+	case "csv":
+		delimiter := ','
+		if strings.EqualFold(filepath.Ext(filename), ".tsv") || strings.EqualFold(mimeType, "text/tab-separated-values") {
+			delimiter = '\t'
+		}
+		if table, err := ParseCSVTable(text, delimiter); err == nil {
+			p.Table = table
+		}
+	case "ics":
+		p.Events = ParseICSEvents(text)
+	case "vcard":
+		p.Contacts = ParseVCards(text)
+	}
+	return p, nil
+}
+
+// Markdown wraps the text in a fenced code block so the Markdown renderer highlights it
+func (p *AttachmentPreview) Markdown() string {
+	fence := "```"
+	for strings.Contains(p.Text, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s\n", fence, p.Language, strings.TrimRight(p.Text, "\n"), fence)
+}
+
+// PreviewMaxBytes is the largest attachment PreviewAttachment accepts
+func (s *AttachmentServiceImpl) PreviewMaxBytes() int64 {
+	kb := defaultAttachmentPreviewMaxKB
+	if s.config != nil && s.config.Attachments.PreviewMaxKB > 0 {
+		kb = s.config.Attachments.PreviewMaxKB
+	}
+	return int64(kb) * 1024
+}
+
+// PreviewAttachment downloads a small text-like attachment into memory for an inline preview
+func (s *AttachmentServiceImpl) PreviewAttachment(ctx context.Context, messageID string, att AttachmentInfo) (*AttachmentPreview, error) {
+	if messageID == "" || att.AttachmentID == "" {
+		return nil, fmt.Errorf("messageID and attachmentID cannot be empty")
+	}
+	if _, ok := AttachmentPreviewLanguage(att.Filename, att.MimeType); !ok {
+		return nil, fmt.Errorf("%s cannot be previewed; download it instead", att.Filename)
+	}
+	limit := s.PreviewMaxBytes()
+	if att.Size > limit {
+		return nil, fmt.Errorf("%s is larger than the %d KB preview limit; download it instead", att.Filename, limit/1024)
+	}
+	data, _, err := s.gmailClient.GetAttachment(messageID, att.AttachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than the %d KB preview limit; download it instead", att.Filename, limit/1024)
+	}
+	return NewAttachmentPreview(att.Filename, att.MimeType, data)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentPreviewLanguage(t *testing.T) {
+	cases := []struct {
+		filename, mime, lang string
+		ok                   bool
+	}{
+		{"notes.TXT", "application/octet-stream", "", true},
+		{"data.csv", "", "csv", true},
+		{"fix.patch", "", "diff", true},
+		{"app.log", "", "", true},
+		{"payload", "application/json", "json", true},
+		{"readme", "text/plain", "", true},
+		{"page.html", "text/html", "", false},
+		{"invite.ics", "text/calendar", "ics", true},
+		{"card", "text/x-vcard", "vcard", true},
+		{"report.pdf", "application/pdf", "", false},
+	}
+	for _, c := range cases {
+		lang, ok := AttachmentPreviewLanguage(c.filename, c.mime)
+		assert.Equal(t, c.ok, ok, c.filename)
+		assert.Equal(t, c.lang, lang, c.filename)
+	}
+}
+
+func TestNewAttachmentPreview(t *testing.T) {
+	p, err := NewAttachmentPreview("a.json", "", []byte(`{"a":[1,2]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    2\n  ]\n}", p.Text)
+	assert.Equal(t, "```json\n"+p.Text+"\n```\n", p.Markdown())
+
+	// Invalid JSON is shown as is
+	p, err = NewAttachmentPreview("a.json", "", []byte("{oops\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "{oops\n", p.Text)
+
+	_, err = NewAttachmentPreview("a.txt", "", []byte("bin\x00ary"))
+	assert.Error(t, err)
+	_, err = NewAttachmentPreview("a.pdf", "application/pdf", []byte("%PDF"))
+	assert.Error(t, err)
+
+	// Structured viewers get the parsed form; plain text stays available
+	p, err = NewAttachmentPreview("data.tsv", "", []byte("a\tb\n1\t2\n"))
+	require.NoError(t, err)
+	require.NotNil(t, p.Table)
+	assert.Equal(t, []string{"a", "b"}, p.Table.Header)
+	p, err = NewAttachmentPreview("jane.vcf", "", []byte("BEGIN:VCARD\nFN:Jane\nEMAIL:jane@example.com\nEND:VCARD\n"))
+	require.NoError(t, err)
+	require.Len(t, p.Contacts, 1)
+	p, err = NewAttachmentPreview("invite.ics", "text/calendar", []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Sync\nEND:VEVENT\nEND:VCALENDAR\n"))
+	require.NoError(t, err)
+	require.Len(t, p.Events, 1)
+
+	// Fences inside the text get a longer fence
+	p, err = NewAttachmentPreview("a.md", "", []byte("```go\nx\n```\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "````markdown\n```go\nx\n```\n````\n", p.Markdown())
+}
+
+func TestAttachmentService_PreviewLimits(t *testing.T) {
+	s := NewAttachmentService(nil, &config.Config{})
+	assert.Equal(t, int64(256*1024), s.PreviewMaxBytes())
+	s = NewAttachmentService(nil, &config.Config{Attachments: config.AttachmentsConfig{PreviewMaxKB: 8}})
+	_, err := s.PreviewAttachment(context.Background(), "m1", AttachmentInfo{AttachmentID: "a1", Filename: "big.log", Size: 9 * 1024})
+	assert.ErrorContains(t, err, "8 KB preview limit")
+	_, err = s.PreviewAttachment(context.Background(), "m1", AttachmentInfo{AttachmentID: "a1", Filename: "x.pdf"})
+	assert.ErrorContains(t, err, "cannot be previewed")
+}
@@ -0,0 +1,501 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// contentLine is one unfolded iCalendar (RFC 5545) or vCard (RFC 6350) property
+type contentLine struct {
+	Name   string            // upper-cased, without a vCard group prefix ("item1.EMAIL" -> "EMAIL")
+	Params map[string]string // upper-cased names; quotes removed
+	Value  string            // raw value (still escaped)
+}
+
+// parseContentLines unfolds continuation lines and splits each property into name, parameters
+// and value. Lines without a colon are skipped.
+func parseContentLines(text string) []contentLine {
+	var unfolded []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(unfolded) > 0 {
+			unfolded[len(unfolded)-1] += line[1:]
+			continue
+		}
+		unfolded = append(unfolded, line)
+	}
+
+	var out []contentLine
+	for _, line := range unfolded {
+		colon, inQuotes := -1, false
+		for i, r := range line {
+			if r == '"' {
+				inQuotes = !inQuotes
+			} else if r == ':' && !inQuotes {
+				colon = i
+				break
+			}
+		}
+		if colon <= 0 {
+			continue
+		}
+		parts := splitOutsideQuotes(line[:colon], ';')
+		name := strings.ToUpper(strings.TrimSpace(parts[0]))
+		if dot := strings.LastIndex(name, "."); dot >= 0 {
+			name = name[dot+1:]
+		}
+		cl := contentLine{Name: name, Params: map[string]string{}, Value: line[colon+1:]}
+		for _, p := range parts[1:] {
+			k, v, found := strings.Cut(p, "=")
+			if !found {
+				// vCard 2.1 bare types: TEL;CELL:...
+				k, v = "TYPE", p
+			}
+			k = strings.ToUpper(strings.TrimSpace(k))
+			v = strings.Trim(strings.TrimSpace(v), `"`)
+			if prev, ok := cl.Params[k]; ok && prev != "" {
+				v = prev + "," + v
+			}
+			cl.Params[k] = v
+		}
+		out = append(out, cl)
+	}
+	return out
+}
+
+// splitOutsideQuotes splits s on sep, ignoring separators inside double quotes
+func splitOutsideQuotes(s string, sep rune) []string {
+	var parts []string
+	start, inQuotes := 0, false
+	for i, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeText decodes TEXT value escapes (\n, \, \; and \\)
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitStructured splits a structured value (N, ADR, ORG) on unescaped semicolons and unescapes
+// each component
+func splitStructured(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ';' {
+			parts = append(parts, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, unescapeText(s[start:]))
+}
+
+// joinNonEmpty joins the trimmed, non-empty parts with sep
+func joinNonEmpty(parts []string, sep string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// ICSAttendee is an attendee of a calendar event
+type ICSAttendee struct {
+	Name   string
+	Email  string
+	Status string // PARTSTAT: ACCEPTED, DECLINED, TENTATIVE, NEEDS-ACTION, ...
+}
+
+// ICSEvent is a VEVENT from an .ics attachment
+type ICSEvent struct {
+	Summary     string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Location    string
+	Description string
+	Organizer   string
+	Attendees   []ICSAttendee
+	Recurrence  string // raw RRULE
+	Status      string
+	URL         string
+	Method      string // the calendar's METHOD (REQUEST, CANCEL, PUBLISH, ...)
+}
+
+// ParseICSEvents returns the events in an iCalendar file, in file order. Properties of nested
+// components (alarms) are ignored.
+func ParseICSEvents(text string) []ICSEvent {
+	var (
+		events []ICSEvent
+		stack  []string
+		cur    *ICSEvent
+		method string
+	)
+	for _, cl := range parseContentLines(text) {
+		switch cl.Name {
+		case "BEGIN":
+			comp := strings.ToUpper(strings.TrimSpace(cl.Value))
+			stack = append(stack, comp)
+			if comp == "VEVENT" {
+				cur = &ICSEvent{}
+			}
+			continue
+		case "END":
+			if len(stack) > 0 {
+				if stack[len(stack)-1] == "VEVENT" && cur != nil {
+					events = append(events, *cur)
+					cur = nil
+				}
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		if stack[len(stack)-1] == "VCALENDAR" && cl.Name == "METHOD" {
+			method = strings.ToUpper(strings.TrimSpace(cl.Value))
+			continue
+		}
+		if cur == nil || stack[len(stack)-1] != "VEVENT" {
+			continue
+		}
+		switch cl.Name {
+		case "SUMMARY":
+			cur.Summary = unescapeText(cl.Value)
+		case "DTSTART":
+			cur.Start, cur.AllDay = parseICSTime(cl)
+		case "DTEND":
+			cur.End, _ = parseICSTime(cl)
+		case "LOCATION":
+			cur.Location = unescapeText(cl.Value)
+		case "DESCRIPTION":
+			cur.Description = unescapeText(cl.Value)
+		case "ORGANIZER":
+			cur.Organizer = formatICSAddress(cl.Params["CN"], cl.Value)
+		case "ATTENDEE":
+			cur.Attendees = append(cur.Attendees, ICSAttendee{
+				Name:   cl.Params["CN"],
+				Email:  stripMailto(cl.Value),
+				Status: strings.ToUpper(cl.Params["PARTSTAT"]),
+			})
+		case "RRULE":
+			cur.Recurrence = cl.Value
+		case "STATUS":
+			cur.Status = strings.ToUpper(cl.Value)
+		case "URL":
+			cur.URL = cl.Value
+		}
+	}
+	for i := range events {
+		events[i].Method = method
+	}
+	return events
+}
+
+// parseICSTime parses a DATE or DATE-TIME value: UTC ("Z"), with a TZID, floating (local time)
+// or a whole day
+func parseICSTime(cl contentLine) (time.Time, bool) {
+	v := strings.TrimSpace(cl.Value)
+	if len(v) == 8 || strings.EqualFold(cl.Params["VALUE"], "DATE") {
+		t, err := time.ParseInLocation("20060102", v, time.Local)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse("20060102T150405Z", v)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t.Local(), false
+	}
+	loc := time.Local
+	if tzid := cl.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", v, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.Local(), false
+}
+
+func stripMailto(v string) string {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(strings.ToLower(v), "mailto:") {
+		return v[len("mailto:"):]
+	}
+	return v
+}
+
+func formatICSAddress(name, value string) string {
+	email := stripMailto(value)
+	if name == "" || name == email {
+		return email
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
+
+// When formats the event's time span for display
+func (e ICSEvent) When() string {
+	if e.Start.IsZero() {
+		return ""
+	}
+	if e.AllDay {
+		// DTEND is exclusive for all-day events
+		last := e.End.AddDate(0, 0, -1)
+		if e.End.IsZero() || !last.After(e.Start) {
+			return e.Start.Format("Mon Jan 2, 2006") + " (all day)"
+		}
+		return e.Start.Format("Mon Jan 2") + " – " + last.Format("Mon Jan 2, 2006") + " (all day)"
+	}
+	start := e.Start.Format("Mon Jan 2, 2006 15:04")
+	switch {
+	case e.End.IsZero():
+		return start
+	case e.End.YearDay() == e.Start.YearDay() && e.End.Year() == e.Start.Year():
+		return start + " – " + e.End.Format("15:04")
+	default:
+		return start + " – " + e.End.Format("Mon Jan 2, 2006 15:04")
+	}
+}
+
+// VCardField is a vCard value with its TYPE parameter (home, work, cell, ...)
+type VCardField struct {
+	Value string
+	Type  string
+}
+
+// VCard is a contact from a .vcf attachment
+type VCard struct {
+	Name     string // FN, or built from N when FN is missing
+	Emails   []VCardField
+	Phones   []VCardField
+	Org      string
+	Title    string
+	Address  string
+	URL      string
+	Birthday string
+	Note     string
+}
+
+// PrimaryEmail is the contact's first email address, or "" when it has none
+func (c VCard) PrimaryEmail() string {
+	if len(c.Emails) == 0 {
+		return ""
+	}
+	return c.Emails[0].Value
+}
+
+// ParseVCards returns the contacts in a vCard file (2.1, 3.0 or 4.0), in file order
+func ParseVCards(text string) []VCard {
+	var (
+		cards []VCard
+		cur   *VCard
+		n     []string
+	)
+	for _, cl := range parseContentLines(text) {
+		switch cl.Name {
+		case "BEGIN":
+			if strings.EqualFold(strings.TrimSpace(cl.Value), "VCARD") {
+				cur, n = &VCard{}, nil
+			}
+			continue
+		case "END":
+			if cur != nil && strings.EqualFold(strings.TrimSpace(cl.Value), "VCARD") {
+				if cur.Name == "" && len(n) > 1 {
+					// N is family;given;additional;prefix;suffix
+					cur.Name = joinNonEmpty([]string{n[1], n[0]}, " ")
+				}
+				if cur.Name == "" {
+					cur.Name = cur.PrimaryEmail()
+				}
+				cards = append(cards, *cur)
+				cur = nil
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		typ := strings.ToLower(joinNonEmpty(strings.Split(cl.Params["TYPE"], ","), ","))
+		switch cl.Name {
+		case "FN":
+			cur.Name = strings.TrimSpace(unescapeText(cl.Value))
+		case "N":
+			n = splitStructured(cl.Value)
+		case "EMAIL":
+			if v := strings.TrimSpace(stripMailto(unescapeText(cl.Value))); v != "" {
+				cur.Emails = append(cur.Emails, VCardField{Value: v, Type: typ})
+			}
+		case "TEL":
+			if v := strings.TrimSpace(strings.TrimPrefix(cl.Value, "tel:")); v != "" {
+				cur.Phones = append(cur.Phones, VCardField{Value: v, Type: typ})
+			}
+		case "ORG":
+			cur.Org = joinNonEmpty(splitStructured(cl.Value), ", ")
+		case "TITLE":
+			cur.Title = unescapeText(cl.Value)
+		case "ADR":
+			cur.Address = joinNonEmpty(splitStructured(cl.Value), ", ")
+		case "URL":
+			cur.URL = cl.Value
+		case "BDAY":
+			cur.Birthday = cl.Value
+		case "NOTE":
+			cur.Note = unescapeText(cl.Value)
+		}
+	}
+	return cards
+}
+
+// defaultCSVCellWidth caps column widths in the table view; longer cells are cut with an ellipsis
+const defaultCSVCellWidth = 40
+
+// CSVTable is a parsed CSV/TSV attachment
+type CSVTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+// ParseCSVTable parses delimited text; rows may have different lengths. The first row is the
+// header.
+func ParseCSVTable(text string, delimiter rune) (*CSVTable, error) {
+	r := csv.NewReader(strings.NewReader(text))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	var records [][]string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no rows")
+	}
+	return &CSVTable{Header: records[0], Rows: records[1:]}, nil
+}
+
+// Columns is the widest row's cell count
+func (t *CSVTable) Columns() int {
+	n := len(t.Header)
+	for _, row := range t.Rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	return n
+}
+
+// Lines lays the table out as aligned text: the header, a rule, then the rows. Cells are cut to
+// maxCell display columns (0 uses the default) and columns where every value is a number are
+// right-aligned, header included.
+func (t *CSVTable) Lines(maxCell int) []string {
+	if maxCell <= 0 {
+		maxCell = defaultCSVCellWidth
+	}
+	cols := t.Columns()
+	widths := make([]int, cols)
+	numeric := make([]bool, cols)
+	for c := range numeric {
+		numeric[c] = true
+	}
+	cell := func(row []string, c int) string {
+		if c >= len(row) {
+			return ""
+		}
+		return runewidth.Truncate(strings.TrimSpace(row[c]), maxCell, "…")
+	}
+	measure := func(row []string, isHeader bool) {
+		for c := 0; c < cols; c++ {
+			v := cell(row, c)
+			if w := runewidth.StringWidth(v); w > widths[c] {
+				widths[c] = w
+			}
+			if !isHeader && v != "" {
+				if _, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64); err != nil {
+					numeric[c] = false
+				}
+			}
+		}
+	}
+	measure(t.Header, true)
+	for _, row := range t.Rows {
+		measure(row, false)
+	}
+
+	format := func(row []string) string {
+		// Trailing empty cells are left out rather than drawn as empty columns
+		n := cols
+		for n > 0 && cell(row, n-1) == "" {
+			n--
+		}
+		parts := make([]string, n)
+		for c := 0; c < n; c++ {
+			v := cell(row, c)
+			pad := strings.Repeat(" ", widths[c]-runewidth.StringWidth(v))
+			if numeric[c] {
+				parts[c] = pad + v
+			} else {
+				parts[c] = v + pad
+			}
+		}
+		return strings.TrimRight(strings.Join(parts, " │ "), " ")
+	}
+	rules := make([]string, cols)
+	for c, w := range widths {
+		rules[c] = strings.Repeat("─", w)
+	}
+
+	lines := []string{format(t.Header), strings.Join(rules, "─┼─")}
+	for _, row := range t.Rows {
+		lines = append(lines, format(row))
+	}
+	return lines
+}
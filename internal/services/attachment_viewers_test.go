@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICSEvents(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nMETHOD:PUBLISH\r\nBEGIN:VEVENT\r\n" +
+		"SUMMARY:Quarterly review\\, Q3\r\n" +
+		"DTSTART:20261020T140000Z\r\nDTEND:20261020T150000Z\r\n" +
+		"LOCATION:Room 4\r\n" +
+		"DESCRIPTION:Agenda:\\n1. Numbers\\n2. Plans that are long enough to be\r\n  folded\r\n" +
+		"ORGANIZER;CN=\"Doe, Jane\":mailto:jane@example.com\r\n" +
+		"ATTENDEE;CN=Bob;PARTSTAT=ACCEPTED:mailto:bob@example.com\r\n" +
+		"ATTENDEE;PARTSTAT=needs-action:MAILTO:carol@example.com\r\n" +
+		"RRULE:FREQ=MONTHLY;COUNT=3\r\n" +
+		"BEGIN:VALARM\r\nDESCRIPTION:Reminder\r\nEND:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Offsite\r\nDTSTART;VALUE=DATE:20261101\r\nDTEND;VALUE=DATE:20261103\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events := ParseICSEvents(ics)
+	require.Len(t, events, 2)
+	e := events[0]
+	assert.Equal(t, "Quarterly review, Q3", e.Summary)
+	assert.Equal(t, time.Date(2026, 10, 20, 14, 0, 0, 0, time.UTC), e.Start.UTC())
+	assert.Equal(t, "Agenda:\n1. Numbers\n2. Plans that are long enough to be folded", e.Description)
+	assert.Equal(t, "Doe, Jane <jane@example.com>", e.Organizer)
+	assert.Equal(t, []ICSAttendee{{Name: "Bob", Email: "bob@example.com", Status: "ACCEPTED"}, {Email: "carol@example.com", Status: "NEEDS-ACTION"}}, e.Attendees)
+	assert.Equal(t, "FREQ=MONTHLY;COUNT=3", e.Recurrence)
+	assert.Equal(t, "PUBLISH", e.Method)
+
+	offsite := events[1]
+	assert.True(t, offsite.AllDay)
+	assert.Equal(t, "Sun Nov 1 – Mon Nov 2, 2026 (all day)", offsite.When())
+}
+
+func TestParseICSTime_TZID(t *testing.T) {
+	start, allDay := parseICSTime(contentLine{Params: map[string]string{"TZID": "America/New_York"}, Value: "20260115T090000"})
+	assert.False(t, allDay)
+	assert.Equal(t, time.Date(2026, 1, 15, 14, 0, 0, 0, time.UTC), start.UTC())
+}
+
+func TestParseVCards(t *testing.T) {
+	vcf := "BEGIN:VCARD\nVERSION:3.0\nFN:Jane Doe\nN:Doe;Jane;;;\n" +
+		"item1.EMAIL;TYPE=INTERNET,WORK:jane@acme.com\nEMAIL;TYPE=home:jane@home.net\n" +
+		"TEL;TYPE=CELL:+1 555 0100\nORG:Acme;Sales\nTITLE:Director\\, EMEA\n" +
+		"ADR;TYPE=WORK:;;1 Main St;Springfield;;12345;USA\nEND:VCARD\n" +
+		"BEGIN:VCARD\nVERSION:2.1\nN:Smith;John\nTEL;CELL:555\nEND:VCARD\n"
+
+	cards := ParseVCards(vcf)
+	require.Len(t, cards, 2)
+	jane := cards[0]
+	assert.Equal(t, "Jane Doe", jane.Name)
+	assert.Equal(t, []VCardField{{Value: "jane@acme.com", Type: "internet,work"}, {Value: "jane@home.net", Type: "home"}}, jane.Emails)
+	assert.Equal(t, "jane@acme.com", jane.PrimaryEmail())
+	assert.Equal(t, "Acme, Sales", jane.Org)
+	assert.Equal(t, "Director, EMEA", jane.Title)
+	assert.Equal(t, "1 Main St, Springfield, 12345, USA", jane.Address)
+
+	john := cards[1]
+	assert.Equal(t, "John Smith", john.Name)
+	assert.Equal(t, []VCardField{{Value: "555", Type: "cell"}}, john.Phones)
+	assert.Empty(t, john.PrimaryEmail())
+}
+
+func TestCSVTableLines(t *testing.T) {
+	table, err := ParseCSVTable("name,qty,note\nwidget,3,\"ok, fine\"\ngizmo,1200\n", ',')
+	require.NoError(t, err)
+	assert.Equal(t, 3, table.Columns())
+	assert.Equal(t, []string{
+		"name   │  qty │ note",
+		"───────┼──────┼─────────",
+		"widget │    3 │ ok, fine",
+		"gizmo  │ 1200",
+	}, table.Lines(0))
+
+	// Long cells are cut
+	table, _ = ParseCSVTable("a\tb\nabcdefgh\t1\n", '\t')
+	assert.Equal(t, "abcd… │ 1", table.Lines(5)[2])
+}
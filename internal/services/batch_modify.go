@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/gmail"
+)
+
+// BatchModifier changes the labels of many messages per request (users.messages.batchModify);
+// *gmail.Client satisfies it
+type BatchModifier interface {
+	BatchModifyMessages(messageIDs, addLabelIDs, removeLabelIDs []string) error
+}
+
+// batchModifyAttempts is how often a chunk is tried while Gmail answers with transient errors
+const batchModifyAttempts = 3
+
+// batchChunkError is a chunk of a bulk job Gmail rejected
+type batchChunkError struct {
+	ids []string
+	err error
+}
+
+// runBatchModify applies the label change to ids in chunks of gmail.MaxBatchModifyIDs. A chunk
+// that still fails after retrying transient errors doesn't stop the others; progress is reported
+// after each chunk. Returns the failed chunks.
+func runBatchModify(ctx context.Context, m BatchModifier, ids, add, remove []string, onProgress []func(done, total int)) []batchChunkError {
+	var failed []batchChunkError
+	for start := 0; start < len(ids); start += gmail.MaxBatchModifyIDs {
+		end := min(start+gmail.MaxBatchModifyIDs, len(ids))
+		chunk := ids[start:end]
+		err := RetryTransient(ctx, batchModifyAttempts, func() error {
+			return ClassifyError("batch modify messages", m.BatchModifyMessages(chunk, add, remove))
+		})
+		if err != nil {
+			failed = append(failed, batchChunkError{ids: chunk, err: err})
+		}
+		reportProgress(onProgress, end, len(ids))
+	}
+	return failed
+}
+
+// batchFailedIDs lists the IDs of the failed chunks
+func batchFailedIDs(failed []batchChunkError) map[string]bool {
+	out := make(map[string]bool)
+	for _, f := range failed {
+		for _, id := range f.ids {
+			out[id] = true
+		}
+	}
+	return out
+}
+
+// batchErrors describes the failed chunks of a bulk job, e.g. "bulk archive errors: failed to
+// archive 1000 message(s) (a … z): quota exceeded"; nil when every chunk succeeded
+func batchErrors(op, verb string, failed []batchChunkError) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	errs := make([]string, 0, len(failed))
+	for _, f := range failed {
+		span := f.ids[0]
+		if len(f.ids) > 1 {
+			span += " … " + f.ids[len(f.ids)-1]
+		}
+		errs = append(errs, fmt.Sprintf("failed to %s %d message(s) (%s): %v", verb, len(f.ids), span, f.err))
+	}
+	return fmt.Errorf("bulk %s errors: %s", op, strings.Join(errs, "; "))
+}
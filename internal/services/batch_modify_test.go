@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/stretchr/testify/assert"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// recordingBatchModifier keeps the chunks it was given and fails the ones listed in failChunk
+type recordingBatchModifier struct {
+	chunks    [][]string
+	add       []string
+	remove    []string
+	failChunk map[int]error
+}
+
+func (m *recordingBatchModifier) BatchModifyMessages(ids, add, remove []string) error {
+	m.chunks = append(m.chunks, append([]string(nil), ids...))
+	m.add, m.remove = add, remove
+	return m.failChunk[len(m.chunks)-1]
+}
+
+func batchTestIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("m%04d", i)
+	}
+	return ids
+}
+
+func TestBulkArchive_UsesBatchModifyInChunks(t *testing.T) {
+	batch := &recordingBatchModifier{}
+	// No repository expectations: the per-message path must not run
+	svc := NewEmailService(&MockEmailRepository{}, &MockGmailServiceClient{}, &render.EmailRenderer{})
+	svc.SetBatchModifier(batch)
+
+	var progress [][2]int
+	err := svc.BulkArchive(context.Background(), batchTestIDs(2500), func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	assert.NoError(t, err)
+	assert.Len(t, batch.chunks, 3)
+	assert.Len(t, batch.chunks[0], gmail.MaxBatchModifyIDs)
+	assert.Len(t, batch.chunks[2], 500)
+	assert.Equal(t, []string{"INBOX"}, batch.remove)
+	assert.Nil(t, batch.add)
+	assert.Equal(t, [][2]int{{1000, 2500}, {2000, 2500}, {2500, 2500}}, progress)
+}
+
+func TestBulkTrash_BatchChunkFailureKeepsOtherChunks(t *testing.T) {
+	ctx := context.Background()
+	ids := batchTestIDs(1001)
+	repo := &MockEmailRepository{}
+	for _, id := range ids {
+		repo.On("GetMessage", ctx, id).Return(&gmail.Message{Message: &gmail_v1.Message{Id: id, LabelIds: []string{"INBOX"}}}, nil)
+	}
+	batch := &recordingBatchModifier{failChunk: map[int]error{1: errors.New("backend error")}}
+	svc := NewEmailService(repo, &MockGmailServiceClient{}, &render.EmailRenderer{})
+	svc.SetBatchModifier(batch)
+	rec := &recordingTrashOrigins{}
+	svc.SetTrashOriginRecorder(rec)
+
+	err := svc.BulkTrash(ctx, ids)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bulk trash errors: failed to trash 1 message(s) (m1000)")
+	assert.Equal(t, []string{"TRASH"}, batch.add)
+	// Only the trashed chunk has its origins recorded
+	assert.Len(t, rec.labels, 1000)
+	assert.NotContains(t, rec.labels, "m1000")
+}
+
+func TestBulkApplyLabel_UsesBatchModify(t *testing.T) {
+	batch := &recordingBatchModifier{failChunk: map[int]error{0: errors.New("invalid label")}}
+	svc := NewLabelService(nil)
+	svc.SetBatchModifier(batch)
+
+	err := svc.BulkApplyLabel(context.Background(), []string{"a", "b", "c"}, "Label_1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to apply label to 3 message(s) (a … c)")
+	assert.Contains(t, err.Error(), "invalid label")
+	assert.Equal(t, []string{"Label_1"}, batch.add)
+
+	batch.failChunk = nil
+	assert.NoError(t, svc.BulkRemoveLabel(context.Background(), []string{"a", "b"}, "Label_1"))
+	assert.Equal(t, []string{"Label_1"}, batch.remove)
+}
@@ -112,3 +112,22 @@ func TestCompositionService_DecodeHeaderValue(t *testing.T) {
 		t.Errorf("plain header should pass through, got %q", got)
 	}
 }
+
+func TestNewerThreadMessages(t *testing.T) {
+	original := &gmail_v1.Message{Id: "m2", InternalDate: 200}
+	thread := []*gmail_v1.Message{
+		{Id: "m1", InternalDate: 100},
+		original,
+		{Id: "m4", InternalDate: 400},
+		{Id: "d1", InternalDate: 500, LabelIds: []string{"DRAFT"}},
+		{Id: "m3", InternalDate: 300},
+		nil,
+	}
+	got := newerThreadMessages(thread, original)
+	if len(got) != 2 || got[0].Id != "m3" || got[1].Id != "m4" {
+		t.Fatalf("expected [m3 m4] oldest first, got %+v", got)
+	}
+	if got := newerThreadMessages(thread[:2], original); len(got) != 0 {
+		t.Errorf("no newer messages expected, got %d", len(got))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"mime"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +22,14 @@ type CompositionServiceImpl struct {
 	gmailClient  *gmail.Client
 	messageRepo  MessageRepository
 	logger       *log.Logger
+
+	// Labels applied to sent messages (see sent_labels.go)
+	labelService LabelService
+	sentLabels   []string
+	domainLabels map[string][]string
+
+	// Optional AI forwarding note (see forward.go)
+	aiService AIService
 }
 
 // NewCompositionService creates a new composition service
@@ -91,6 +100,10 @@ func (s *CompositionServiceImpl) CreateComposition(ctx context.Context, composit
 
 		composition.Subject = forwardContext.Subject
 		composition.Body = forwardContext.ForwardedBody
+		// Attachments are carried over by default; the composer can deselect them
+		if len(forwardContext.Attachments) > 0 {
+			composition.Attachments = forwardContext.Attachments
+		}
 		// Recipients remain empty for user selection
 
 	case CompositionTypeNew:
@@ -251,6 +264,7 @@ func (s *CompositionServiceImpl) SendComposition(ctx context.Context, compositio
 	}
 
 	// Handle different composition types
+	var sentID string
 	switch composition.Type {
 	case CompositionTypeNew, CompositionTypeForward, CompositionTypeDraft:
 		// Convert CC and BCC recipients to string slices
@@ -263,11 +277,18 @@ func (s *CompositionServiceImpl) SendComposition(ctx context.Context, compositio
 			bcc[i] = recipient.Email
 		}
 
-		// Send as new message
-		err := s.emailService.SendMessage(ctx, "", to, composition.Subject, composition.Body, cc, bcc)
+		// Send as new message; attachments need a multipart message
+		var id string
+		var err error
+		if len(composition.Attachments) > 0 {
+			id, err = s.sendWithAttachments(composition, to, cc, bcc)
+		} else {
+			id, err = s.emailService.SendMessageReturningID(ctx, "", to, composition.Subject, composition.Body, cc, bcc)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to send message: %w", err)
 		}
+		sentID = id
 
 		// If this was a draft, delete it from Gmail after successful send
 		if composition.DraftID != "" {
@@ -295,10 +316,11 @@ func (s *CompositionServiceImpl) SendComposition(ctx context.Context, compositio
 			ccList = append(ccList, recipient.Email)
 		}
 
-		err := s.emailService.ReplyToMessage(ctx, composition.OriginalID, composition.Body, true, ccList)
+		id, err := s.emailService.ReplyReturningID(ctx, composition.OriginalID, composition.Body, true, ccList)
 		if err != nil {
 			return fmt.Errorf("failed to send reply: %w", err)
 		}
+		sentID = id
 
 	default:
 		return fmt.Errorf("unsupported composition type for sending: %s", composition.Type)
@@ -308,7 +330,7 @@ func (s *CompositionServiceImpl) SendComposition(ctx context.Context, compositio
 		s.logger.Printf("CompositionService: Sent composition %s (type: %s)", composition.ID, composition.Type)
 	}
 
-	return nil
+	return s.applySentLabels(ctx, composition, sentID)
 }
 
 // ValidateComposition validates a composition and returns any errors
@@ -579,6 +601,7 @@ func (s *CompositionServiceImpl) ProcessForward(ctx context.Context, originalMes
 		ForwardedBody:   forwardedBody,
 		OriginalSender:  originalSender,
 		OriginalDate:    originalDate,
+		Attachments:     forwardableAttachments(message.Payload),
 	}
 
 	if s.logger != nil {
@@ -588,6 +611,51 @@ func (s *CompositionServiceImpl) ProcessForward(ctx context.Context, originalMes
 	return forwardContext, nil
 }
 
+// NewerThreadMessages returns the messages that arrived in the thread after the one being replied
+// to, oldest first, so the composer can warn before sending a redundant reply. Drafts are ignored.
+func (s *CompositionServiceImpl) NewerThreadMessages(ctx context.Context, originalMessageID string) ([]*gmail_v1.Message, error) {
+	if originalMessageID == "" {
+		return nil, fmt.Errorf("original message ID required")
+	}
+	if s.gmailClient == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	original, err := s.gmailClient.GetMessageMetadata(originalMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original message: %w", err)
+	}
+	if original.ThreadId == "" {
+		return nil, nil
+	}
+	thread, err := s.gmailClient.GetThreadMetadata(original.ThreadId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread: %w", err)
+	}
+	return newerThreadMessages(thread.Messages, original), nil
+}
+
+// newerThreadMessages filters thread messages received after original (excluding drafts), oldest first
+func newerThreadMessages(messages []*gmail_v1.Message, original *gmail_v1.Message) []*gmail_v1.Message {
+	var newer []*gmail_v1.Message
+	for _, m := range messages {
+		if m == nil || m.Id == original.Id || m.InternalDate <= original.InternalDate {
+			continue
+		}
+		draft := false
+		for _, l := range m.LabelIds {
+			if l == "DRAFT" {
+				draft = true
+				break
+			}
+		}
+		if !draft {
+			newer = append(newer, m)
+		}
+	}
+	sort.Slice(newer, func(i, j int) bool { return newer[i].InternalDate < newer[j].InternalDate })
+	return newer
+}
+
 // GetTemplates returns available email templates
 func (s *CompositionServiceImpl) GetTemplates(ctx context.Context, category string) ([]*EmailTemplate, error) {
 	// For now, return some basic templates
@@ -742,6 +810,11 @@ func (s *CompositionServiceImpl) createQuotedBody(message *gmail.Message, sender
 
 // createForwardedBody creates a forwarded body
 func (s *CompositionServiceImpl) createForwardedBody(message *gmail.Message, sender Recipient, date time.Time) string {
+	return s.buildForwardedBody(message, sender, date, true)
+}
+
+// buildForwardedBody creates a forwarded body, optionally without the original's quoted history
+func (s *CompositionServiceImpl) buildForwardedBody(message *gmail.Message, sender Recipient, date time.Time, includeQuoted bool) string {
 	var body strings.Builder
 
 	body.WriteString("\n\n---------- Forwarded message ---------\n")
@@ -766,6 +839,9 @@ func (s *CompositionServiceImpl) createForwardedBody(message *gmail.Message, sen
 		// Fallback to snippet if body extraction fails
 		content = message.Snippet
 	}
+	if !includeQuoted {
+		content = StripQuotedHistory(content)
+	}
 	body.WriteString(content)
 
 	return body.String()
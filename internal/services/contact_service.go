@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// ContactServiceImpl implements ContactService
+type ContactServiceImpl struct {
+	store        *db.ContactStore
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewContactService creates the contacts index service
+func NewContactService(store *db.ContactStore) *ContactServiceImpl {
+	return &ContactServiceImpl{store: store}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *ContactServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *ContactServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("contact store not available")
+	}
+	return email, nil
+}
+
+// AddVCards adds every address of the cards to the index (one entry per address, carrying the
+// card's name, organization and first phone) and returns how many were added or updated.
+// Cards without an email address are skipped.
+func (s *ContactServiceImpl) AddVCards(ctx context.Context, cards []VCard, source string) (int, error) {
+	email, err := s.account()
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, card := range cards {
+		phone := ""
+		if len(card.Phones) > 0 {
+			phone = card.Phones[0].Value
+		}
+		for _, addr := range card.Emails {
+			if _, err := s.store.Save(ctx, db.Contact{
+				AccountEmail: email,
+				Email:        addr.Value,
+				Name:         card.Name,
+				Org:          card.Org,
+				Phone:        phone,
+				Source:       source,
+			}); err != nil {
+				return added, err
+			}
+			added++
+		}
+	}
+	if added == 0 {
+		return 0, fmt.Errorf("no email addresses to add")
+	}
+	return added, nil
+}
+
+// Search returns indexed contacts matching query by name, address or organization
+func (s *ContactServiceImpl) Search(ctx context.Context, query string, limit int) ([]ContactInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := s.store.Search(ctx, email, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ContactInfo, 0, len(contacts))
+	for _, c := range contacts {
+		out = append(out, ContactInfo{
+			Email:     c.Email,
+			Name:      c.Name,
+			Org:       c.Org,
+			Phone:     c.Phone,
+			Source:    c.Source,
+			UpdatedAt: time.Unix(c.UpdatedAt, 0),
+		})
+	}
+	return out, nil
+}
+
+// Remove drops an address from the index
+func (s *ContactServiceImpl) Remove(ctx context.Context, addr string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, email, addr)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactService(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/contacts.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	svc := NewContactService(db.NewContactStore(store))
+	_, err = svc.Search(ctx, "", 0)
+	assert.EqualError(t, err, "account email not set")
+	svc.SetAccountEmail("me@example.com")
+
+	cards := []VCard{
+		{Name: "Jane Doe", Org: "Acme", Emails: []VCardField{{Value: "jane@acme.com"}, {Value: "jane@home.net"}}, Phones: []VCardField{{Value: "+1 555 0100"}}},
+		{Name: "No Address"},
+	}
+	n, err := svc.AddVCards(ctx, cards, "jane.vcf")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = svc.AddVCards(ctx, cards[1:], "x.vcf")
+	assert.EqualError(t, err, "no email addresses to add")
+
+	found, err := svc.Search(ctx, "home", 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, ContactInfo{Email: "jane@home.net", Name: "Jane Doe", Org: "Acme", Phone: "+1 555 0100", Source: "jane.vcf", UpdatedAt: found[0].UpdatedAt}, found[0])
+
+	require.NoError(t, svc.Remove(ctx, "jane@home.net"))
+	all, _ := svc.Search(ctx, "", 0)
+	assert.Len(t, all, 1)
+}
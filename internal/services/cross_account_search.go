@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// crossAccountSearcher is the slice of the Gmail client used by cross-account search
+type crossAccountSearcher interface {
+	SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmailapi.Message, string, error)
+	GetMessagesMetadataParallel(messageIDs []string, maxWorkers int) ([]*gmailapi.Message, error)
+}
+
+// CrossAccountSearchServiceImpl implements CrossAccountSearchService on top of AccountService
+type CrossAccountSearchServiceImpl struct {
+	listAccounts func(ctx context.Context) ([]*Account, error)
+	searcherFor  func(ctx context.Context, accountID string) (crossAccountSearcher, error)
+}
+
+// NewCrossAccountSearchService creates a cross-account search service
+func NewCrossAccountSearchService(accountService AccountService) *CrossAccountSearchServiceImpl {
+	return &CrossAccountSearchServiceImpl{
+		listAccounts: accountService.ListAccounts,
+		searcherFor: func(ctx context.Context, accountID string) (crossAccountSearcher, error) {
+			client, err := accountService.GetAccountClient(ctx, accountID)
+			if err != nil {
+				return nil, err
+			}
+			return client, nil
+		},
+	}
+}
+
+// Search runs the first page of query on every searchable account concurrently
+func (s *CrossAccountSearchServiceImpl) Search(ctx context.Context, query string, pageSize int64) (*CrossAccountSearchResult, error) {
+	accounts, err := s.searchableAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pages := make(map[string]string, len(accounts))
+	for _, acc := range accounts {
+		pages[acc.ID] = ""
+	}
+	return s.run(ctx, query, pageSize, accounts, pages)
+}
+
+// NextPage fetches the next page for every account that still has a page token
+func (s *CrossAccountSearchServiceImpl) NextPage(ctx context.Context, query string, pageSize int64, tokens map[string]string) (*CrossAccountSearchResult, error) {
+	accounts, err := s.searchableAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pages := make(map[string]string, len(tokens))
+	var pending []*Account
+	for _, acc := range accounts {
+		if tok := tokens[acc.ID]; tok != "" {
+			pages[acc.ID] = tok
+			pending = append(pending, acc)
+		}
+	}
+	if len(pending) == 0 {
+		return &CrossAccountSearchResult{NextPageTokens: map[string]string{}, Errors: map[string]error{}}, nil
+	}
+	return s.run(ctx, query, pageSize, pending, pages)
+}
+
+// searchableAccounts returns the configured accounts that are not known to be broken
+func (s *CrossAccountSearchServiceImpl) searchableAccounts(ctx context.Context) ([]*Account, error) {
+	if s.listAccounts == nil || s.searcherFor == nil {
+		return nil, fmt.Errorf("cross-account search not available")
+	}
+	all, err := s.listAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	accounts := make([]*Account, 0, len(all))
+	for _, acc := range all {
+		if acc == nil || acc.Status == AccountStatusError {
+			continue
+		}
+		accounts = append(accounts, acc)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no accounts available for cross-account search")
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	return accounts, nil
+}
+
+// run searches one page per account concurrently and merges the results newest first.
+// A failing account is reported in Errors and keeps its page token so the page can be retried;
+// the call only fails when every account fails.
+func (s *CrossAccountSearchServiceImpl) run(ctx context.Context, query string, pageSize int64, accounts []*Account, pages map[string]string) (*CrossAccountSearchResult, error) {
+	type accountPage struct {
+		account  *Account
+		messages []*gmailapi.Message
+		next     string
+		err      error
+	}
+	results := make([]accountPage, len(accounts))
+	var wg sync.WaitGroup
+	for i, acc := range accounts {
+		wg.Add(1)
+		go func(i int, acc *Account) {
+			defer wg.Done()
+			res := accountPage{account: acc}
+			defer func() { results[i] = res }()
+
+			searcher, err := s.searcherFor(ctx, acc.ID)
+			if err != nil {
+				res.err = err
+				return
+			}
+			page, next, err := searcher.SearchMessagesPage(query, pageSize, pages[acc.ID])
+			if err != nil {
+				res.err = err
+				return
+			}
+			res.next = next
+			if len(page) == 0 {
+				return
+			}
+			ids := make([]string, len(page))
+			for j, m := range page {
+				ids[j] = m.Id
+			}
+			meta, err := searcher.GetMessagesMetadataParallel(ids, 10)
+			if err != nil {
+				res.err = err
+				return
+			}
+			res.messages = meta
+		}(i, acc)
+	}
+	wg.Wait()
+
+	out := &CrossAccountSearchResult{
+		NextPageTokens: make(map[string]string),
+		Errors:         make(map[string]error),
+	}
+	for _, r := range results {
+		if r.err != nil {
+			out.Errors[r.account.ID] = r.err
+			if tok := pages[r.account.ID]; tok != "" {
+				out.NextPageTokens[r.account.ID] = tok
+			}
+			continue
+		}
+		if r.next != "" {
+			out.NextPageTokens[r.account.ID] = r.next
+		}
+		for _, m := range r.messages {
+			if m == nil {
+				continue
+			}
+			out.Messages = append(out.Messages, CrossAccountMessage{
+				AccountID:    r.account.ID,
+				AccountLabel: CrossAccountLabel(r.account),
+				Message:      m,
+			})
+		}
+	}
+	if len(out.Errors) == len(accounts) {
+		for id, err := range out.Errors {
+			return nil, fmt.Errorf("search failed for account %s: %w", id, err)
+		}
+	}
+	sort.SliceStable(out.Messages, func(i, j int) bool {
+		return out.Messages[i].Message.InternalDate > out.Messages[j].Message.InternalDate
+	})
+	return out, nil
+}
+
+// CrossAccountLabel is the short name shown in the account column of merged results
+func CrossAccountLabel(acc *Account) string {
+	if acc == nil {
+		return ""
+	}
+	if acc.DisplayName != "" {
+		return acc.DisplayName
+	}
+	if acc.Email != "" {
+		return acc.Email
+	}
+	return acc.ID
+}
+
+var _ crossAccountSearcher = (*gmail.Client)(nil)
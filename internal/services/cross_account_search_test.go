@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// fakeSearcher serves pre-baked pages keyed by page token
+type fakeSearcher struct {
+	pages map[string][]*gmailapi.Message
+	next  map[string]string
+	err   error
+	seen  []string
+}
+
+func (f *fakeSearcher) SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmailapi.Message, string, error) {
+	f.seen = append(f.seen, pageToken)
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	return f.pages[pageToken], f.next[pageToken], nil
+}
+
+func (f *fakeSearcher) GetMessagesMetadataParallel(ids []string, maxWorkers int) ([]*gmailapi.Message, error) {
+	var out []*gmailapi.Message
+	for _, page := range f.pages {
+		for _, m := range page {
+			for _, id := range ids {
+				if m.Id == id {
+					out = append(out, m)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func newTestCrossAccountSearch(accounts []*Account, searchers map[string]*fakeSearcher) *CrossAccountSearchServiceImpl {
+	return &CrossAccountSearchServiceImpl{
+		listAccounts: func(ctx context.Context) ([]*Account, error) { return accounts, nil },
+		searcherFor: func(ctx context.Context, id string) (crossAccountSearcher, error) {
+			s, ok := searchers[id]
+			if !ok {
+				return nil, errors.New("no client")
+			}
+			return s, nil
+		},
+	}
+}
+
+func crossMsg(id string, date int64) *gmailapi.Message {
+	return &gmailapi.Message{Id: id, InternalDate: date}
+}
+
+func TestCrossAccountSearch_MergesNewestFirst(t *testing.T) {
+	accounts := []*Account{{ID: "work", DisplayName: "Work"}, {ID: "personal", Email: "me@example.com"}}
+	svc := newTestCrossAccountSearch(accounts, map[string]*fakeSearcher{
+		"work":     {pages: map[string][]*gmailapi.Message{"": {crossMsg("w1", 300), crossMsg("w2", 100)}}, next: map[string]string{"": "w-next"}},
+		"personal": {pages: map[string][]*gmailapi.Message{"": {crossMsg("p1", 200)}}},
+	})
+
+	res, err := svc.Search(context.Background(), "from:bob", 50)
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 3)
+	assert.Equal(t, "w1", res.Messages[0].Message.Id)
+	assert.Equal(t, "p1", res.Messages[1].Message.Id)
+	assert.Equal(t, "personal", res.Messages[1].AccountID)
+	assert.Equal(t, "me@example.com", res.Messages[1].AccountLabel)
+	assert.Equal(t, "Work", res.Messages[2].AccountLabel)
+	assert.Equal(t, map[string]string{"work": "w-next"}, res.NextPageTokens)
+	assert.Empty(t, res.Errors)
+}
+
+func TestCrossAccountSearch_NextPageOnlyQueriesAccountsWithTokens(t *testing.T) {
+	work := &fakeSearcher{pages: map[string][]*gmailapi.Message{"w-next": {crossMsg("w3", 50)}}}
+	personal := &fakeSearcher{}
+	svc := newTestCrossAccountSearch([]*Account{{ID: "work"}, {ID: "personal"}}, map[string]*fakeSearcher{
+		"work": work, "personal": personal,
+	})
+
+	res, err := svc.NextPage(context.Background(), "from:bob", 50, map[string]string{"work": "w-next"})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	assert.Equal(t, "w3", res.Messages[0].Message.Id)
+	assert.Equal(t, []string{"w-next"}, work.seen)
+	assert.Empty(t, personal.seen)
+	assert.Empty(t, res.NextPageTokens)
+
+	res, err = svc.NextPage(context.Background(), "from:bob", 50, map[string]string{})
+	require.NoError(t, err)
+	assert.Empty(t, res.Messages)
+}
+
+func TestCrossAccountSearch_PartialFailure(t *testing.T) {
+	svc := newTestCrossAccountSearch([]*Account{{ID: "work"}, {ID: "personal"}, {ID: "broken", Status: AccountStatusError}}, map[string]*fakeSearcher{
+		"work":     {err: errors.New("quota")},
+		"personal": {pages: map[string][]*gmailapi.Message{"": {crossMsg("p1", 1)}}},
+	})
+
+	res, err := svc.Search(context.Background(), "x", 50)
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	assert.Contains(t, res.Errors, "work")
+	assert.NotContains(t, res.Errors, "broken", "accounts in error state are skipped")
+}
+
+func TestCrossAccountSearch_AllFail(t *testing.T) {
+	svc := newTestCrossAccountSearch([]*Account{{ID: "work"}}, map[string]*fakeSearcher{
+		"work": {err: errors.New("offline")},
+	})
+	_, err := svc.Search(context.Background(), "x", 50)
+	assert.Error(t, err)
+}
+
+func TestCrossAccountSearch_NextPageKeepsFailedTokens(t *testing.T) {
+	svc := newTestCrossAccountSearch([]*Account{{ID: "work"}, {ID: "personal"}}, map[string]*fakeSearcher{
+		"work":     {err: errors.New("quota")},
+		"personal": {pages: map[string][]*gmailapi.Message{"p-next": {crossMsg("p2", 1)}}},
+	})
+
+	res, err := svc.NextPage(context.Background(), "x", 50, map[string]string{"work": "w-next", "personal": "p-next"})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	assert.Contains(t, res.Errors, "work")
+	assert.Equal(t, map[string]string{"work": "w-next"}, res.NextPageTokens, "the failed page stays loadable")
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+const (
+	// digestMaxMessages caps how many unread messages a digest reads
+	digestMaxMessages = 500
+	// digestOldestN is how many of the oldest unread messages get a one-liner
+	digestOldestN = 10
+	// digestDefaultQuery is the unread mail a digest covers by default
+	digestDefaultQuery = "is:unread in:inbox"
+	// digestBodyLimit bounds the message text sent for a one-liner
+	digestBodyLimit = 8000
+)
+
+// DigestClient is the subset of *gmail.Client the digest service depends on
+type DigestClient interface {
+	metadataSearcher
+	GetMessageWithContent(id string) (*gmail.Message, error)
+}
+
+// DigestServiceImpl implements DigestService. Counts come from message metadata; the AI service
+// only writes the one-liners, through the cached "oneline" summary style.
+type DigestServiceImpl struct {
+	client    DigestClient
+	aiService AIService
+	now       func() time.Time
+}
+
+// NewDigestService creates the digest service. aiService may be nil (no one-liners).
+func NewDigestService(client DigestClient, aiService AIService) *DigestServiceImpl {
+	return &DigestServiceImpl{client: client, aiService: aiService, now: time.Now}
+}
+
+// Generate builds the digest of the unread mail matching opts.Query
+func (s *DigestServiceImpl) Generate(ctx context.Context, opts DigestOptions) (*UnreadDigest, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	query := strings.TrimSpace(opts.Query)
+	if query == "" {
+		query = digestDefaultQuery
+	}
+	msgs, err := searchMetadata(ctx, s.client, query, digestMaxMessages)
+	if err != nil {
+		return nil, err
+	}
+	d := BuildUnreadDigest(msgs, s.now())
+	d.Query = query
+	d.Capped = len(msgs) >= digestMaxMessages
+	if opts.NoAI || len(d.Oldest) == 0 {
+		return d, nil
+	}
+	if s.aiService == nil {
+		d.OneLinerError = "AI service not available"
+		return d, nil
+	}
+	for i := range d.Oldest {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		line, err := s.oneLiner(ctx, d.Oldest[i].ID, opts.AccountEmail)
+		if err != nil {
+			d.OneLinerError = err.Error()
+			continue
+		}
+		d.Oldest[i].OneLiner = line
+	}
+	return d, nil
+}
+
+// oneLiner summarizes a message in one sentence with the cached "oneline" summary style
+func (s *DigestServiceImpl) oneLiner(ctx context.Context, id, accountEmail string) (string, error) {
+	msg, err := s.client.GetMessageWithContent(id)
+	if err != nil {
+		return "", ClassifyError("get message", err)
+	}
+	body := strings.TrimSpace(msg.PlainText)
+	if body == "" && msg.Message != nil {
+		body = msg.Snippet
+	}
+	if body == "" {
+		return "", nil
+	}
+	if len(body) > digestBodyLimit {
+		body = body[:digestBodyLimit]
+	}
+	res, err := s.aiService.GenerateSummary(ctx, "Subject: "+msg.Subject+"\n\n"+body, SummaryOptions{
+		MaxLength:    digestBodyLimit,
+		UseCache:     true,
+		MessageID:    id,
+		AccountEmail: accountEmail,
+		Preset:       "oneline",
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(res.Summary), " "), nil
+}
+
+// BuildUnreadDigest counts unread messages by sender and picks the oldest ones
+func BuildUnreadDigest(msgs []*gmail_v1.Message, at time.Time) *UnreadDigest {
+	d := &UnreadDigest{At: at, Unread: len(msgs)}
+	senderCounts := make(map[string]int)
+	senderNames := make(map[string]string)
+	items := make([]DigestItem, 0, len(msgs))
+	for _, m := range msgs {
+		from := reportHeader(m, "From")
+		addr, display := from, from
+		if a, err := mail.ParseAddress(from); err == nil {
+			addr, display = a.Address, a.Address
+			if a.Name != "" {
+				display = a.Name + " <" + a.Address + ">"
+			}
+		}
+		key := strings.ToLower(addr)
+		senderCounts[key]++
+		if _, ok := senderNames[key]; !ok {
+			senderNames[key] = display
+		}
+		items = append(items, DigestItem{ReportMessage: ReportMessage{
+			ID:      m.Id,
+			From:    display,
+			Subject: reportHeader(m, "Subject"),
+			Date:    time.UnixMilli(m.InternalDate),
+		}})
+	}
+	d.TopSenders = topCounts(senderCounts, senderNames, reportTopN)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Date.Before(items[j].Date) })
+	if len(items) > digestOldestN {
+		items = items[:digestOldestN]
+	}
+	d.Oldest = items
+	return d
+}
+
+// Subject is the digest email subject, e.g. "Unread digest · Sat Oct 17 18:00"
+func (d *UnreadDigest) Subject() string {
+	return "Unread digest · " + d.At.Format("Mon Jan 2 15:04")
+}
+
+// Body renders the digest as the plain text of an email
+func (d *UnreadDigest) Body() string {
+	var b strings.Builder
+	count := fmt.Sprintf("%d", d.Unread)
+	if d.Capped {
+		count += "+"
+	}
+	fmt.Fprintf(&b, "%s unread (%s) as of %s.\n", count, d.Query, d.At.Format("Mon Jan 2 15:04"))
+	if d.Unread == 0 {
+		b.WriteString("\nNothing left unread.\n")
+		return b.String()
+	}
+
+	b.WriteString("\nTop senders\n\n")
+	for _, c := range d.TopSenders {
+		fmt.Fprintf(&b, "- %s: %d\n", c.Name, c.Count)
+	}
+
+	fmt.Fprintf(&b, "\nOldest unread\n\n")
+	for i, m := range d.Oldest {
+		subject := strings.TrimSpace(m.Subject)
+		if subject == "" {
+			subject = "(no subject)"
+		}
+		fmt.Fprintf(&b, "%d. %s — %s (%s)\n", i+1, subject, m.From, m.Date.Format("Mon Jan 2 15:04"))
+		if m.OneLiner != "" {
+			fmt.Fprintf(&b, "   %s\n", m.OneLiner)
+		}
+	}
+	return b.String()
+}
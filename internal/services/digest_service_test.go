@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestBuildUnreadDigest(t *testing.T) {
+	at := time.Date(2025, 3, 10, 18, 0, 0, 0, time.Local)
+	var msgs []*gmail_v1.Message
+	for i := 0; i < 12; i++ {
+		msgs = append(msgs, reportMsg(fmt.Sprintf("n%d", i), "", "News <news@example.com>", fmt.Sprintf("Issue %d", i), at.Add(-time.Duration(i)*time.Hour)))
+	}
+	msgs = append(msgs, reportMsg("a1", "", "Alice <ALICE@example.com>", "", at.AddDate(0, 0, -3)))
+	msgs = append(msgs, reportMsg("a2", "", "alice@example.com", "Lunch?", at.AddDate(0, 0, -2)))
+
+	d := BuildUnreadDigest(msgs, at)
+	assert.Equal(t, 14, d.Unread)
+	assert.Equal(t, []ReportCount{{Name: "News <news@example.com>", Count: 12}, {Name: "Alice <ALICE@example.com>", Count: 2}}, d.TopSenders)
+	if assert.Len(t, d.Oldest, digestOldestN) {
+		assert.Equal(t, "a1", d.Oldest[0].ID)
+		assert.Equal(t, "a2", d.Oldest[1].ID)
+		assert.Equal(t, "n11", d.Oldest[2].ID)
+	}
+
+	d.Query = "is:unread in:inbox"
+	d.Oldest[1].OneLiner = "Alice asks about lunch on Friday."
+	body := d.Body()
+	assert.True(t, strings.HasPrefix(body, "14 unread (is:unread in:inbox) as of Mon Mar 10 18:00.\n"))
+	assert.Contains(t, body, "- News <news@example.com>: 12\n")
+	assert.Contains(t, body, "1. (no subject) — Alice <ALICE@example.com> (Fri Mar 7 18:00)\n")
+	assert.Contains(t, body, "2. Lunch? — alice@example.com (Sat Mar 8 18:00)\n   Alice asks about lunch on Friday.\n")
+	assert.Equal(t, "Unread digest · Mon Mar 10 18:00", d.Subject())
+}
+
+func TestUnreadDigest_BodyEmpty(t *testing.T) {
+	d := BuildUnreadDigest(nil, time.Date(2025, 3, 10, 9, 5, 0, 0, time.Local))
+	d.Query = "is:unread"
+	assert.Equal(t, "0 unread (is:unread) as of Mon Mar 10 09:05.\n\nNothing left unread.\n", d.Body())
+}
@@ -25,13 +25,20 @@ type GmailClient interface {
 	GetMessagesParallel(messageIDs []string, maxWorkers int) ([]*gmail_v1.Message, error)
 }
 
+// TrashOriginRecorder remembers where messages were before they are moved to Trash or Spam
+type TrashOriginRecorder interface {
+	RecordOrigins(ctx context.Context, folder string, labels map[string][]string) error
+}
+
 // EmailServiceImpl implements EmailService
 type EmailServiceImpl struct {
-	repo        MessageRepository
-	gmailClient GmailClient
-	renderer    *render.EmailRenderer
-	undoService UndoService // Optional - for recording undo actions
-	logger      *log.Logger // Optional - for debug logging
+	repo         MessageRepository
+	gmailClient  GmailClient
+	renderer     *render.EmailRenderer
+	undoService  UndoService         // Optional - for recording undo actions
+	trashOrigins TrashOriginRecorder // Optional - for restoring trashed messages to their labels
+	batch        BatchModifier       // Optional - bulk jobs use batchModify instead of one call per message
+	logger       *log.Logger         // Optional - for debug logging
 }
 
 // NewEmailService creates a new email service
@@ -49,6 +56,61 @@ func (s *EmailServiceImpl) SetUndoService(undoService UndoService) {
 	s.undoService = undoService
 }
 
+// SetTrashOriginRecorder sets where the labels of trashed/spammed messages are recorded
+func (s *EmailServiceImpl) SetTrashOriginRecorder(recorder TrashOriginRecorder) {
+	s.trashOrigins = recorder
+}
+
+// SetBatchModifier makes the bulk jobs change up to 1000 messages per Gmail call
+func (s *EmailServiceImpl) SetBatchModifier(batch BatchModifier) {
+	s.batch = batch
+}
+
+// trashOriginLabels captures the labels of messages about to move to Trash/Spam, reusing those
+// already captured for undo (captured may be nil) and reading the rest from Gmail. Returns nil
+// when no recorder is set.
+func (s *EmailServiceImpl) trashOriginLabels(ctx context.Context, messageIDs []string, captured map[string]ActionState) map[string][]string {
+	if s.trashOrigins == nil {
+		return nil
+	}
+	labels := make(map[string][]string, len(messageIDs))
+	for _, id := range messageIDs {
+		if state, ok := captured[id]; ok {
+			labels[id] = state.Labels
+			continue
+		}
+		msg, err := s.repo.GetMessage(ctx, id)
+		if err != nil || msg == nil || msg.Message == nil {
+			if s.logger != nil {
+				s.logger.Printf("Failed to capture labels of %s before trashing: %v", id, err)
+			}
+			continue
+		}
+		labels[id] = msg.LabelIds
+	}
+	return labels
+}
+
+// recordTrashOrigins hands the labels of messages that did move to Trash/Spam to the recorder;
+// moved lists the messages whose move succeeded
+func (s *EmailServiceImpl) recordTrashOrigins(ctx context.Context, folder string, origins map[string][]string, moved ...string) {
+	if s.trashOrigins == nil || len(origins) == 0 {
+		return
+	}
+	labels := make(map[string][]string, len(moved))
+	for _, id := range moved {
+		if l, ok := origins[id]; ok {
+			labels[id] = l
+		}
+	}
+	if len(labels) == 0 {
+		return
+	}
+	if err := s.trashOrigins.RecordOrigins(ctx, folder, labels); err != nil && s.logger != nil {
+		s.logger.Printf("Failed to record trash origins: %v", err)
+	}
+}
+
 // SetLogger sets the logger for debug output
 func (s *EmailServiceImpl) SetLogger(logger *log.Logger) {
 	s.logger = logger
@@ -219,6 +281,11 @@ func (s *EmailServiceImpl) BulkMarkAsRead(ctx context.Context, messageIDs []stri
 		}
 	}
 
+	if s.batch != nil {
+		failed := runBatchModify(ctx, s.batch, messageIDs, nil, []string{"UNREAD"}, onProgress)
+		return batchErrors("mark as read", "mark as read", failed)
+	}
+
 	// Perform the actual operations using repository directly (to avoid double undo recording)
 	var errs []string
 	for i, id := range messageIDs {
@@ -273,6 +340,11 @@ func (s *EmailServiceImpl) BulkMarkAsUnread(ctx context.Context, messageIDs []st
 		}
 	}
 
+	if s.batch != nil {
+		failed := runBatchModify(ctx, s.batch, messageIDs, []string{"UNREAD"}, nil, onProgress)
+		return batchErrors("mark as unread", "mark as unread", failed)
+	}
+
 	// Perform the actual operations using repository directly (to avoid double undo recording)
 	var errs []string
 	for i, id := range messageIDs {
@@ -332,6 +404,7 @@ func (s *EmailServiceImpl) TrashMessage(ctx context.Context, messageID string) e
 	}
 
 	// Record undo action before performing the operation
+	var captured map[string]ActionState
 	if s.undoService != nil {
 		// Capture current state for undo
 		if undoServiceImpl, ok := s.undoService.(*UndoServiceImpl); ok {
@@ -349,29 +422,47 @@ func (s *EmailServiceImpl) TrashMessage(ctx context.Context, messageID string) e
 						s.logger.Printf("Failed to record undo action: %v", err)
 					}
 				}
+				captured = action.PrevState
 			}
 		}
 	}
 
-	return s.gmailClient.TrashMessage(messageID)
+	origins := s.trashOriginLabels(ctx, []string{messageID}, captured)
+	if err := s.gmailClient.TrashMessage(messageID); err != nil {
+		return ClassifyError("trash message", err)
+	}
+	s.recordTrashOrigins(ctx, "TRASH", origins, messageID)
+	return nil
 }
 
 func (s *EmailServiceImpl) SendMessage(ctx context.Context, from, to, subject, body string, cc, bcc []string) error {
+	_, err := s.SendMessageReturningID(ctx, from, to, subject, body, cc, bcc)
+	return err
+}
+
+// SendMessageReturningID sends a message and returns the ID of the sent message
+func (s *EmailServiceImpl) SendMessageReturningID(ctx context.Context, from, to, subject, body string, cc, bcc []string) (string, error) {
 	if to == "" || subject == "" || body == "" {
-		return fmt.Errorf("to, subject, and body cannot be empty")
+		return "", fmt.Errorf("to, subject, and body cannot be empty")
 	}
 
-	_, err := s.gmailClient.SendMessage(from, to, subject, body, cc, bcc)
-	return err
+	id, err := s.gmailClient.SendMessage(from, to, subject, body, cc, bcc)
+	return id, ClassifyError("send message", err)
 }
 
 func (s *EmailServiceImpl) ReplyToMessage(ctx context.Context, originalID, replyBody string, send bool, cc []string) error {
+	_, err := s.ReplyReturningID(ctx, originalID, replyBody, send, cc)
+	return err
+}
+
+// ReplyReturningID replies to a message and returns the ID of the sent reply (or draft)
+func (s *EmailServiceImpl) ReplyReturningID(ctx context.Context, originalID, replyBody string, send bool, cc []string) (string, error) {
 	if originalID == "" || replyBody == "" {
-		return fmt.Errorf("originalID and replyBody cannot be empty")
+		return "", fmt.Errorf("originalID and replyBody cannot be empty")
 	}
 
-	_, err := s.gmailClient.ReplyMessage(originalID, replyBody, send, cc)
-	return err
+	id, err := s.gmailClient.ReplyMessage(originalID, replyBody, send, cc)
+	return id, ClassifyError("reply to message", err)
 }
 
 func (s *EmailServiceImpl) BulkArchive(ctx context.Context, messageIDs []string, onProgress ...func(done, total int)) error {
@@ -408,6 +499,11 @@ func (s *EmailServiceImpl) BulkArchive(ctx context.Context, messageIDs []string,
 		}
 	}
 
+	if s.batch != nil {
+		failed := runBatchModify(ctx, s.batch, messageIDs, nil, []string{"INBOX"}, onProgress)
+		return batchErrors("archive", "archive", failed)
+	}
+
 	// Perform the actual archiving using repository directly (to avoid double undo recording)
 	var errs []string
 	for i, id := range messageIDs {
@@ -433,6 +529,7 @@ func (s *EmailServiceImpl) BulkTrash(ctx context.Context, messageIDs []string, o
 	}
 
 	// Record bulk undo action before performing operations
+	var captured map[string]ActionState
 	if s.undoService != nil {
 		if undoServiceImpl, ok := s.undoService.(*UndoServiceImpl); ok {
 			// Capture state for all messages
@@ -458,17 +555,38 @@ func (s *EmailServiceImpl) BulkTrash(ctx context.Context, messageIDs []string, o
 					}
 				}
 			}
+			captured = prevStates
+		}
+	}
+
+	origins := s.trashOriginLabels(ctx, messageIDs, captured)
+
+	if s.batch != nil {
+		// Adding TRASH is what messages.trash does, one chunk of messages at a time
+		failed := runBatchModify(ctx, s.batch, messageIDs, []string{"TRASH"}, nil, onProgress)
+		skip := batchFailedIDs(failed)
+		trashed := make([]string, 0, len(messageIDs))
+		for _, id := range messageIDs {
+			if !skip[id] {
+				trashed = append(trashed, id)
+			}
 		}
+		s.recordTrashOrigins(ctx, "TRASH", origins, trashed...)
+		return batchErrors("trash", "trash", failed)
 	}
 
 	// Perform the actual trashing using Gmail client directly (to avoid double undo recording)
 	var errs []string
+	trashed := make([]string, 0, len(messageIDs))
 	for i, id := range messageIDs {
 		if err := s.gmailClient.TrashMessage(id); err != nil {
 			errs = append(errs, fmt.Sprintf("failed to trash %s: %v", id, err))
+		} else {
+			trashed = append(trashed, id)
 		}
 		reportProgress(onProgress, i+1, len(messageIDs))
 	}
+	s.recordTrashOrigins(ctx, "TRASH", origins, trashed...)
 
 	if len(errs) > 0 {
 		return fmt.Errorf("bulk trash errors: %s", strings.Join(errs, "; "))
@@ -485,7 +603,7 @@ func (s *EmailServiceImpl) SaveMessageToFile(ctx context.Context, messageID, fil
 	// Get message with content
 	msg, err := s.gmailClient.GetMessageWithContent(messageID)
 	if err != nil {
-		return fmt.Errorf("failed to get message content: %w", err)
+		return ClassifyError("get message content", err)
 	}
 
 	// Ensure directory exists
@@ -547,6 +665,7 @@ func (s *EmailServiceImpl) MoveToSystemFolder(ctx context.Context, messageID, sy
 	}
 
 	// Record move undo action before performing the operation
+	var captured map[string]ActionState
 	if s.undoService != nil {
 		if undoServiceImpl, ok := s.undoService.(*UndoServiceImpl); ok {
 			prevState, err := undoServiceImpl.CaptureMessageState(ctx, messageID)
@@ -571,6 +690,7 @@ func (s *EmailServiceImpl) MoveToSystemFolder(ctx context.Context, messageID, sy
 						s.logger.Printf("Failed to record undo action: %v", err)
 					}
 				}
+				captured = action.PrevState
 			} else {
 				if s.logger != nil {
 					s.logger.Printf("Failed to capture message state for undo: %v", err)
@@ -644,21 +764,18 @@ func (s *EmailServiceImpl) MoveToSystemFolder(ctx context.Context, messageID, sy
 
 		return nil
 
-	case "TRASH":
-		// Move to Trash: Add TRASH, remove INBOX
+	case "TRASH", "SPAM":
+		// Move to Trash/Spam: Add the folder, remove INBOX; remember the labels once it moved
+		origins := s.trashOriginLabels(ctx, []string{messageID}, captured)
 		updates := MessageUpdates{
-			AddLabels:    []string{"TRASH"},
+			AddLabels:    []string{systemFolderID},
 			RemoveLabels: []string{"INBOX"},
 		}
-		return s.repo.UpdateMessage(ctx, messageID, updates)
-
-	case "SPAM":
-		// Move to Spam: Add SPAM, remove INBOX
-		updates := MessageUpdates{
-			AddLabels:    []string{"SPAM"},
-			RemoveLabels: []string{"INBOX"},
+		if err := s.repo.UpdateMessage(ctx, messageID, updates); err != nil {
+			return err
 		}
-		return s.repo.UpdateMessage(ctx, messageID, updates)
+		s.recordTrashOrigins(ctx, systemFolderID, origins, messageID)
+		return nil
 
 	default:
 		return fmt.Errorf("unsupported system folder: %s", systemFolderID)
@@ -595,3 +595,58 @@ func TestBulkArchiveProgress(t *testing.T) {
 		t.Fatalf("final progress should be {3,3}, got %v", calls[2])
 	}
 }
+
+// recordingTrashOrigins is a TrashOriginRecorder that keeps what it was given
+type recordingTrashOrigins struct {
+	folder string
+	labels map[string][]string
+}
+
+func (r *recordingTrashOrigins) RecordOrigins(_ context.Context, folder string, labels map[string][]string) error {
+	r.folder = folder
+	r.labels = labels
+	return nil
+}
+
+func TestEmailService_TrashOrigins_RecordedOnlyAfterSuccess(t *testing.T) {
+	ctx := context.Background()
+	labeled := func(id string, labels ...string) *gmail.Message {
+		return &gmail.Message{Message: &gmail_v1.Message{Id: id, LabelIds: labels}}
+	}
+	repo := &MockEmailRepository{}
+	repo.On("GetMessage", ctx, "msg1").Return(labeled("msg1", "INBOX", "Label_1"), nil)
+	repo.On("GetMessage", ctx, "msg2").Return(labeled("msg2", "Label_2"), nil)
+	client := &MockGmailServiceClient{}
+	client.On("TrashMessage", "msg1").Return(nil)
+	client.On("TrashMessage", "msg2").Return(errors.New("API error"))
+
+	// No undo service wired: origins are still captured
+	service := NewEmailService(repo, client, &render.EmailRenderer{})
+	rec := &recordingTrashOrigins{}
+	service.SetTrashOriginRecorder(rec)
+
+	assert.NoError(t, service.TrashMessage(ctx, "msg1"))
+	assert.Equal(t, "TRASH", rec.folder)
+	assert.Equal(t, map[string][]string{"msg1": {"INBOX", "Label_1"}}, rec.labels)
+
+	// A failed trash leaves nothing behind
+	rec.labels = nil
+	assert.Error(t, service.TrashMessage(ctx, "msg2"))
+	assert.Nil(t, rec.labels)
+
+	// Bulk: only the messages that were trashed are recorded
+	assert.Error(t, service.BulkTrash(ctx, []string{"msg1", "msg2"}))
+	assert.Equal(t, map[string][]string{"msg1": {"INBOX", "Label_1"}}, rec.labels)
+
+	// Move to Spam records under SPAM once the label change succeeded
+	rec.labels = nil
+	spam := MessageUpdates{AddLabels: []string{"SPAM"}, RemoveLabels: []string{"INBOX"}}
+	repo.On("UpdateMessage", ctx, "msg1", spam).Return(nil)
+	repo.On("UpdateMessage", ctx, "msg2", spam).Return(errors.New("API error"))
+	assert.NoError(t, service.MoveToSystemFolder(ctx, "msg1", "SPAM", "Spam"))
+	assert.Equal(t, "SPAM", rec.folder)
+	assert.Equal(t, map[string][]string{"msg1": {"INBOX", "Label_1"}}, rec.labels)
+	rec.labels = nil
+	assert.Error(t, service.MoveToSystemFolder(ctx, "msg2", "SPAM", "Spam"))
+	assert.Nil(t, rec.labels)
+}
@@ -1,6 +1,19 @@
 package services
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
 
 // Standard service errors for comprehensive error handling testing
 var (
@@ -15,6 +28,7 @@ var (
 	ErrInvalidInput  = errors.New("invalid input provided")
 	ErrInvalidFormat = errors.New("invalid format")
 	ErrDataCorrupted = errors.New("data corrupted")
+	ErrReadOnly      = errors.New("read-only: it comes from the shared pack")
 
 	// Cache errors
 	ErrCacheUnavailable = errors.New("cache unavailable")
@@ -37,3 +51,177 @@ var (
 	ErrInvalidMessageID = errors.New("invalid message ID")
 	ErrInvalidLabelID   = errors.New("invalid label ID")
 )
+
+// Typed errors for failures the user can act on. Services wrap Gmail API errors with
+// ClassifyError so the UI can pick a consistent message and suggested action, and callers
+// can decide whether a retry makes sense. Each type also matches the sentinel above with
+// errors.Is (e.g. errors.Is(err, ErrNotFound) for a *NotFoundError).
+
+// AuthError means Gmail rejected the credentials: expired or revoked token, or a missing scope
+type AuthError struct {
+	Op  string
+	Err error
+}
+
+func (e *AuthError) Error() string        { return opError(e.Op, e.Err) }
+func (e *AuthError) Unwrap() error        { return e.Err }
+func (e *AuthError) Is(target error) bool { return target == ErrUnauthorized }
+
+// QuotaError means Gmail throttled the request. RetryAfter is the server hint, zero if none.
+type QuotaError struct {
+	Op         string
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *QuotaError) Error() string { return opError(e.Op, e.Err) }
+func (e *QuotaError) Unwrap() error { return e.Err }
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded || target == ErrRateLimited
+}
+
+// NetworkError means Gmail could not be reached or answered with a transient server error
+type NetworkError struct {
+	Op  string
+	Err error
+}
+
+func (e *NetworkError) Error() string        { return opError(e.Op, e.Err) }
+func (e *NetworkError) Unwrap() error        { return e.Err }
+func (e *NetworkError) Is(target error) bool { return target == ErrNetworkUnavailable }
+
+// NotFoundError means the message, label or draft no longer exists (often deleted elsewhere)
+type NotFoundError struct {
+	Op  string
+	Err error
+}
+
+func (e *NotFoundError) Error() string { return opError(e.Op, e.Err) }
+func (e *NotFoundError) Unwrap() error { return e.Err }
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound || target == ErrMessageNotFound
+}
+
+// opError keeps the "failed to <op>: <cause>" wording used across the services
+func opError(op string, err error) string {
+	if op == "" {
+		return err.Error()
+	}
+	return fmt.Sprintf("failed to %s: %v", op, err)
+}
+
+// ClassifyError wraps err in the matching typed error, or in a plain "failed to <op>" error
+// when it is not one the user can act on. Already-typed errors are returned unchanged.
+func ClassifyError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var (
+		authErr     *AuthError
+		quotaErr    *QuotaError
+		networkErr  *NetworkError
+		notFoundErr *NotFoundError
+	)
+	if errors.As(err, &authErr) || errors.As(err, &quotaErr) || errors.As(err, &networkErr) || errors.As(err, &notFoundErr) {
+		return err
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == http.StatusUnauthorized:
+			return &AuthError{Op: op, Err: err}
+		case apiErr.Code == http.StatusTooManyRequests || (apiErr.Code == http.StatusForbidden && isQuotaReason(apiErr)):
+			return &QuotaError{Op: op, Err: err, RetryAfter: retryAfter(apiErr.Header)}
+		case apiErr.Code == http.StatusForbidden:
+			return &AuthError{Op: op, Err: err}
+		case apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone:
+			return &NotFoundError{Op: op, Err: err}
+		case apiErr.Code >= 500:
+			return &NetworkError{Op: op, Err: err}
+		}
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return &AuthError{Op: op, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &NetworkError{Op: op, Err: err}
+	}
+
+	if op == "" {
+		return err
+	}
+	return fmt.Errorf("failed to %s: %w", op, err)
+}
+
+// isQuotaReason reports whether a 403 is Gmail's rate/usage limit rather than a permission problem
+func isQuotaReason(apiErr *googleapi.Error) bool {
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded", "quotaExceeded", "dailyLimitExceeded":
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "rate limit")
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(h http.Header) time.Duration {
+	if h == nil {
+		return 0
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(h.Get("Retry-After"))); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// IsRetryable reports whether retrying the same call later can succeed (throttling or a transient network failure)
+func IsRetryable(err error) bool {
+	var (
+		quotaErr   *QuotaError
+		networkErr *NetworkError
+	)
+	return errors.As(err, &quotaErr) || errors.As(err, &networkErr)
+}
+
+// Retry backoff bounds for RetryTransient
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// RetryDelay returns how long to wait before retry number attempt (0-based): the server's
+// Retry-After when Gmail sent one, otherwise exponential backoff capped at retryMaxDelay.
+func RetryDelay(err error, attempt int) time.Duration {
+	var quotaErr *QuotaError
+	if errors.As(err, &quotaErr) && quotaErr.RetryAfter > 0 {
+		return quotaErr.RetryAfter
+	}
+	d := retryBaseDelay << attempt
+	if d <= 0 || d > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return d
+}
+
+// RetryTransient runs fn up to attempts times, backing off between tries while the error is
+// retryable. Auth, not-found and other errors are returned right away.
+func RetryTransient(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(); err == nil || !IsRetryable(err) || attempt == attempts-1 {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(RetryDelay(err, attempt)):
+		}
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+func apiErr(code int, reason string, header http.Header) error {
+	e := &googleapi.Error{Code: code, Message: "boom", Header: header}
+	if reason != "" {
+		e.Errors = []googleapi.ErrorItem{{Reason: reason}}
+	}
+	// The Gmail client wraps API errors before they reach the services
+	return fmt.Errorf("could not modify message: %w", e)
+}
+
+func TestClassifyError_GmailStatusCodes(t *testing.T) {
+	cases := []struct {
+		err  error
+		want error
+	}{
+		{apiErr(401, "", nil), ErrUnauthorized},
+		{apiErr(403, "insufficientPermissions", nil), ErrUnauthorized},
+		{apiErr(403, "userRateLimitExceeded", nil), ErrRateLimited},
+		{apiErr(429, "", nil), ErrQuotaExceeded},
+		{apiErr(404, "", nil), ErrNotFound},
+		{apiErr(503, "backendError", nil), ErrNetworkUnavailable},
+		{&oauth2.RetrieveError{}, ErrUnauthorized},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, ErrNetworkUnavailable},
+		{context.DeadlineExceeded, ErrNetworkUnavailable},
+	}
+	for _, c := range cases {
+		got := ClassifyError("archive message", c.err)
+		assert.ErrorIs(t, got, c.want, "classify %v", c.err)
+		assert.ErrorIs(t, got, c.err, "cause must stay reachable")
+		assert.Contains(t, got.Error(), "failed to archive message: ")
+	}
+}
+
+func TestClassifyError_PassThrough(t *testing.T) {
+	assert.NoError(t, ClassifyError("x", nil))
+
+	plain := errors.New("bad request")
+	got := ClassifyError("send message", plain)
+	assert.EqualError(t, got, "failed to send message: bad request")
+	assert.False(t, IsRetryable(got))
+	assert.Same(t, plain, ClassifyError("", plain))
+
+	// Already-typed errors keep their original operation
+	typed := &NotFoundError{Op: "get message m1", Err: plain}
+	assert.Same(t, typed, ClassifyError("reload", typed))
+}
+
+func TestRetryDelay_PrefersRetryAfter(t *testing.T) {
+	throttled := ClassifyError("list", apiErr(429, "", http.Header{"Retry-After": []string{"7"}}))
+	assert.Equal(t, 7*time.Second, RetryDelay(throttled, 0))
+
+	network := ClassifyError("list", context.DeadlineExceeded)
+	assert.Equal(t, retryBaseDelay, RetryDelay(network, 0))
+	assert.Equal(t, 4*retryBaseDelay, RetryDelay(network, 2))
+	assert.Equal(t, retryMaxDelay, RetryDelay(network, 20))
+}
+
+func TestRetryTransient(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	err := RetryTransient(ctx, 3, func() error {
+		calls++
+		if calls < 2 {
+			return &NetworkError{Err: errors.New("reset")}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Auth failures are not retried
+	calls = 0
+	err = RetryTransient(ctx, 3, func() error {
+		calls++
+		return &AuthError{Err: errors.New("token revoked")}
+	})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 1, calls)
+
+	// A cancelled context stops the backoff
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	calls = 0
+	err = RetryTransient(cancelled, 5, func() error {
+		calls++
+		return &QuotaError{Err: errors.New("slow down"), RetryAfter: time.Hour}
+	})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, calls)
+}
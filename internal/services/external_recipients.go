@@ -0,0 +1,45 @@
+package services
+
+import (
+	"strings"
+)
+
+// ExternalRecipients returns the To, Cc and Bcc addresses of a composition whose domain is not one
+// of internalDomains or a subdomain of one, in order and without duplicates. With no internal
+// domains configured every recipient counts as internal.
+func ExternalRecipients(comp *Composition, internalDomains []string) []string {
+	if comp == nil || len(internalDomains) == 0 {
+		return nil
+	}
+	var external []string
+	seen := make(map[string]bool)
+	for _, list := range [][]Recipient{comp.To, comp.CC, comp.BCC} {
+		for _, r := range list {
+			addr := strings.ToLower(strings.TrimSpace(r.Email))
+			if addr == "" || seen[addr] {
+				continue
+			}
+			seen[addr] = true
+			if !IsInternalAddress(addr, internalDomains) {
+				external = append(external, r.Email)
+			}
+		}
+	}
+	return external
+}
+
+// IsInternalAddress reports whether addr belongs to one of the domains or their subdomains
+func IsInternalAddress(addr string, domains []string) bool {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return false
+	}
+	host := strings.ToLower(strings.TrimSpace(addr[at+1:]))
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" && (host == d || strings.HasSuffix(host, "."+d)) {
+			return true
+		}
+	}
+	return false
+}
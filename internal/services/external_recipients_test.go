@@ -0,0 +1,23 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalRecipients(t *testing.T) {
+	comp := &Composition{
+		To:  []Recipient{{Email: "ana@example.com"}, {Email: "bob@partner.io"}},
+		CC:  []Recipient{{Email: "carl@eu.Example.com"}, {Email: "BOB@partner.io"}},
+		BCC: []Recipient{{Email: "dee@notexample.com"}},
+	}
+	domains := []string{"example.com", " @Corp.example "}
+
+	assert.Equal(t, []string{"bob@partner.io", "dee@notexample.com"}, ExternalRecipients(comp, domains))
+	assert.Nil(t, ExternalRecipients(comp, nil))
+	assert.Nil(t, ExternalRecipients(nil, domains))
+
+	assert.True(t, IsInternalAddress("x@mail.corp.example", domains))
+	assert.False(t, IsInternalAddress("not-an-address", domains))
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// forwardedMarker opens the forwarded section of a forward's body
+const forwardedMarker = "---------- Forwarded message ---------"
+
+// quoteAttributionRe matches the line mail clients put above quoted history ("On Mon, Jan 2, 2026
+// at 3:04 PM Ana <a@x.com> wrote:") and the Outlook-style separator
+var quoteAttributionRe = regexp.MustCompile(`(?i)^\s*(on\s.+wrote:|-{2,}\s*original message\s*-{2,}|-{5,}\s*forwarded message\s*-{5,})\s*$`)
+
+// forwardNotePrompt asks for the one-line note put above a forwarded message
+const forwardNotePrompt = `Write ONE short sentence (max 25 words) to put above this email when forwarding it, telling the recipient why it is being forwarded or what to look at. Write in the email's language, first person, no greeting, no quotes, no preamble.
+
+Subject: %s
+From: %s
+
+%s`
+
+// StripQuotedHistory removes the prior conversation quoted in a message: '>'-prefixed lines and
+// everything from an "On … wrote:" attribution (or an earlier forwarded/original message
+// separator) on. Trailing blank lines are dropped.
+func StripQuotedHistory(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if quoteAttributionRe.MatchString(line) {
+			break
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), ">") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), " \t\n")
+}
+
+// ReplaceForwardedSection swaps the forwarded part of a forward's body for forwarded, keeping
+// whatever the user wrote above it
+func ReplaceForwardedSection(body, forwarded string) string {
+	i := strings.Index(body, forwardedMarker)
+	if i < 0 {
+		return strings.TrimRight(body, "\n") + forwarded
+	}
+	return strings.TrimRight(body[:i], "\n") + forwarded
+}
+
+// forwardableAttachments lists the attachments of a message that can be carried over to a forward
+func forwardableAttachments(part *gmail_v1.MessagePart) []Attachment {
+	if part == nil {
+		return nil
+	}
+	var out []Attachment
+	if part.Filename != "" && part.Body != nil && part.Body.AttachmentId != "" {
+		out = append(out, Attachment{
+			ID:       part.Body.AttachmentId,
+			Filename: part.Filename,
+			MimeType: part.MimeType,
+			Size:     part.Body.Size,
+		})
+	}
+	for _, p := range part.Parts {
+		out = append(out, forwardableAttachments(p)...)
+	}
+	return out
+}
+
+// SetAIService enables the AI forwarding note
+func (s *CompositionServiceImpl) SetAIService(aiService AIService) {
+	s.aiService = aiService
+}
+
+// ForwardBody rebuilds the forwarded section of a forward, with or without the quoted history
+// of the original message
+func (s *CompositionServiceImpl) ForwardBody(ctx context.Context, originalMessageID string, includeQuoted bool) (string, error) {
+	fc, err := s.ProcessForward(ctx, originalMessageID)
+	if err != nil {
+		return "", err
+	}
+	if includeQuoted {
+		return fc.ForwardedBody, nil
+	}
+	return s.buildForwardedBody(fc.OriginalMessage, fc.OriginalSender, fc.OriginalDate, false), nil
+}
+
+// ForwardNote asks the LLM for a one-line note explaining why the message is forwarded
+func (s *CompositionServiceImpl) ForwardNote(ctx context.Context, originalMessageID string) (string, error) {
+	if s.aiService == nil {
+		return "", fmt.Errorf("AI service not available")
+	}
+	fc, err := s.ProcessForward(ctx, originalMessageID)
+	if err != nil {
+		return "", err
+	}
+	content := StripQuotedHistory(fc.OriginalMessage.PlainText)
+	if content == "" {
+		content = fc.OriginalMessage.Snippet
+	}
+	const maxContent = 6000
+	if r := []rune(content); len(r) > maxContent {
+		content = string(r[:maxContent])
+	}
+	subject := strings.TrimPrefix(fc.Subject, "Fwd: ")
+	note, err := s.aiService.ApplyCustomPrompt(ctx, fmt.Sprintf(forwardNotePrompt, subject, fc.OriginalSender.Email, content), nil)
+	if err != nil {
+		return "", err
+	}
+	note = strings.Trim(strings.TrimSpace(note), `"`)
+	if i := strings.Index(note, "\n"); i >= 0 {
+		note = strings.TrimSpace(note[:i])
+	}
+	if note == "" {
+		return "", fmt.Errorf("the AI returned an empty note")
+	}
+	return note, nil
+}
+
+// sendWithAttachments sends a new message or forward carrying attachments of the original
+// message as a multipart/mixed MIME message
+func (s *CompositionServiceImpl) sendWithAttachments(composition *Composition, to string, cc, bcc []string) (string, error) {
+	if s.gmailClient == nil {
+		return "", fmt.Errorf("gmail client not available")
+	}
+	atts := make([]Attachment, 0, len(composition.Attachments))
+	for _, a := range composition.Attachments {
+		if a.Data == nil {
+			if composition.OriginalID == "" {
+				return "", fmt.Errorf("attachment %s has no content", a.Filename)
+			}
+			data, _, err := s.gmailClient.GetAttachment(composition.OriginalID, a.ID)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch attachment %s: %w", a.Filename, err)
+			}
+			a.Data = data
+		}
+		atts = append(atts, a)
+	}
+	raw, err := buildMultipartMessage(to, composition.Subject, composition.Body, cc, bcc, atts)
+	if err != nil {
+		return "", err
+	}
+	id, err := s.gmailClient.SendRawMIME(raw)
+	return id, ClassifyError("send message", err)
+}
+
+// buildMultipartMessage renders a multipart/mixed message: the plain-text body, then each
+// attachment base64-encoded
+func buildMultipartMessage(to, subject, body string, cc, bcc []string, atts []Attachment) (string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	var head strings.Builder
+	fmt.Fprintf(&head, "To: %s\r\n", to)
+	if len(cc) > 0 {
+		fmt.Fprintf(&head, "Cc: %s\r\n", strings.Join(cc, ", "))
+	}
+	if len(bcc) > 0 {
+		fmt.Fprintf(&head, "Bcc: %s\r\n", strings.Join(bcc, ", "))
+	}
+	fmt.Fprintf(&head, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	head.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&head, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", w.Boundary())
+
+	text, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"8bit"},
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := text.Write([]byte(body)); err != nil {
+		return "", err
+	}
+
+	for _, a := range atts {
+		mimeType := a.MimeType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		name := mime.QEncoding.Encode("UTF-8", a.Filename)
+		part, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", mimeType, name)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", name)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return "", err
+		}
+		enc := base64.StdEncoding.EncodeToString(a.Data)
+		for len(enc) > 76 {
+			if _, err := part.Write([]byte(enc[:76] + "\r\n")); err != nil {
+				return "", err
+			}
+			enc = enc[76:]
+		}
+		if _, err := part.Write([]byte(enc + "\r\n")); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return head.String() + buf.String(), nil
+}
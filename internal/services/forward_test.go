@@ -0,0 +1,90 @@
+package services
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestStripQuotedHistory(t *testing.T) {
+	text := "Please review.\r\n> old line\r\nThanks\r\n\r\nOn Mon, Jan 2, 2026 at 3:04 PM Ana <a@x.com> wrote:\r\n> earlier\r\nmore history"
+	assert.Equal(t, "Please review.\nThanks", StripQuotedHistory(text))
+
+	outlook := "Top\n\n-----Original Message-----\nFrom: b@y.com"
+	assert.Equal(t, "Top", StripQuotedHistory(outlook))
+
+	assert.Equal(t, "no quotes", StripQuotedHistory("no quotes\n\n"))
+}
+
+func TestReplaceForwardedSection(t *testing.T) {
+	forwarded := "\n\n" + forwardedMarker + "\nFrom: a@x.com\n\nnew"
+	body := "FYI, see below\n\n" + forwardedMarker + "\nFrom: a@x.com\n\nold"
+	assert.Equal(t, "FYI, see below"+forwarded, ReplaceForwardedSection(body, forwarded))
+	assert.Equal(t, "typed"+forwarded, ReplaceForwardedSection("typed\n", forwarded))
+}
+
+func TestForwardableAttachments(t *testing.T) {
+	payload := &gmail_v1.MessagePart{
+		MimeType: "multipart/mixed",
+		Parts: []*gmail_v1.MessagePart{
+			{MimeType: "text/plain", Body: &gmail_v1.MessagePartBody{Data: "aGk="}},
+			{MimeType: "multipart/related", Parts: []*gmail_v1.MessagePart{
+				{Filename: "logo.png", MimeType: "image/png", Body: &gmail_v1.MessagePartBody{AttachmentId: "att-2", Size: 10}},
+			}},
+			{Filename: "report.pdf", MimeType: "application/pdf", Body: &gmail_v1.MessagePartBody{AttachmentId: "att-1", Size: 2048}},
+			{Filename: "inline.txt", MimeType: "text/plain", Body: &gmail_v1.MessagePartBody{Data: "eA=="}},
+		},
+	}
+	atts := forwardableAttachments(payload)
+	require.Len(t, atts, 2)
+	assert.Equal(t, Attachment{ID: "att-2", Filename: "logo.png", MimeType: "image/png", Size: 10}, atts[0])
+	assert.Equal(t, "report.pdf", atts[1].Filename)
+	assert.Nil(t, forwardableAttachments(nil))
+}
+
+func TestBuildMultipartMessage(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 20))
+	raw, err := buildMultipartMessage("to@x.com", "Fwd: Report", "See attached", []string{"cc@x.com"}, nil,
+		[]Attachment{{Filename: "report.pdf", MimeType: "application/pdf", Data: data}})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "to@x.com", msg.Header.Get("To"))
+	assert.Equal(t, "cc@x.com", msg.Header.Get("Cc"))
+	assert.Empty(t, msg.Header.Get("Bcc"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	text, err := r.NextPart()
+	require.NoError(t, err)
+	body, _ := io.ReadAll(text)
+	assert.Equal(t, "See attached", string(body))
+
+	att, err := r.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "report.pdf", att.FileName())
+	// multipart.Reader decodes quoted-printable only, so check the base64 lines stay within 76 columns
+	encoded, _ := io.ReadAll(att)
+	lines := strings.Split(strings.TrimSpace(string(encoded)), "\r\n")
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = r.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
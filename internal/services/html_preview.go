@@ -0,0 +1,273 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"golang.org/x/net/html"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// blockedImagePlaceholder is a transparent 1x1 GIF; it keeps width/height-sized layouts intact
+const blockedImagePlaceholder = "data:image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7"
+
+// Content-Security-Policy put in every preview: scripts and frames never run; remote images,
+// styles and fonts only load when images are allowed
+const (
+	previewCSPBlocked = "default-src 'none'; img-src data:; style-src 'unsafe-inline' data:; font-src data:; media-src data:"
+	previewCSPAllowed = "default-src 'none'; img-src data: http: https:; style-src 'unsafe-inline' data: http: https:; font-src data: http: https:; media-src data: http: https:"
+)
+
+// cssRemoteURLRe matches CSS url(...) references to remote resources
+var cssRemoteURLRe = regexp.MustCompile(`(?i)url\(\s*['"]?\s*(?:https?:)?//[^)]*\)`)
+
+// HTMLPreviewClient is the subset of *gmail.Client the HTML preview needs to inline cid: images
+type HTMLPreviewClient interface {
+	GetAttachment(messageID, attachmentID string) ([]byte, string, error)
+}
+
+// HTMLPreviewServiceImpl implements HTMLPreviewService
+type HTMLPreviewServiceImpl struct {
+	client HTMLPreviewClient
+	cfg    config.HTMLPreviewConfig
+}
+
+// NewHTMLPreviewService creates the HTML preview service
+func NewHTMLPreviewService(client HTMLPreviewClient, cfg config.HTMLPreviewConfig) *HTMLPreviewServiceImpl {
+	return &HTMLPreviewServiceImpl{client: client, cfg: cfg}
+}
+
+// WritePreview writes the message's HTML part to a file in the preview directory. Images sent
+// inline (cid:) are embedded; remote images are replaced by placeholders when blockImages is set.
+func (s *HTMLPreviewServiceImpl) WritePreview(ctx context.Context, message *gmail.Message, blockImages bool) (*HTMLPreview, error) {
+	if message == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	doc := message.HTML
+	if doc == "" && message.Message != nil {
+		doc = gmail.ExtractHTML(message.Message)
+	}
+	if strings.TrimSpace(doc) == "" {
+		return nil, fmt.Errorf("message has no HTML part")
+	}
+
+	inline := s.inlineImages(ctx, message, doc)
+	rewritten, blocked := rewritePreviewHTML(doc, blockImages, inline)
+
+	dir := s.cfg.Dir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create preview directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "giztui-*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview file: %w", err)
+	}
+	if _, err := f.WriteString(rewritten); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write preview file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write preview file: %w", err)
+	}
+	return &HTMLPreview{Path: f.Name(), BlockedImages: blocked, InlineImages: len(inline)}, nil
+}
+
+// OpenInBrowser opens a preview file with the configured browser, or the system default
+func (s *HTMLPreviewServiceImpl) OpenInBrowser(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("preview file not found: %w", err)
+	}
+	// The browser command comes from the user's own config and path is a file this service wrote
+	var cmd *exec.Cmd
+	if fields := strings.Fields(s.cfg.Browser); len(fields) > 0 {
+		cmd = exec.CommandContext(ctx, fields[0], append(fields[1:], path)...) // #nosec G204
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.CommandContext(ctx, "open", path) // #nosec G204
+		case "linux":
+			cmd = exec.CommandContext(ctx, "xdg-open", path) // #nosec G204
+		case "windows":
+			cmd = exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", path) // #nosec G204
+		default:
+			return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// BlockImagesByDefault reports the configured image blocking
+func (s *HTMLPreviewServiceImpl) BlockImagesByDefault() bool {
+	return s.cfg.BlockImages
+}
+
+// inlineImages returns data: URIs for the inline parts the HTML references by Content-ID.
+// Parts that cannot be fetched are skipped; the browser shows them as broken images.
+func (s *HTMLPreviewServiceImpl) inlineImages(ctx context.Context, message *gmail.Message, doc string) map[string]string {
+	out := make(map[string]string)
+	if message.Message == nil || !strings.Contains(strings.ToLower(doc), "cid:") {
+		return out
+	}
+	var walk func(part *gmail_v1.MessagePart)
+	walk = func(part *gmail_v1.MessagePart) {
+		if part == nil || ctx.Err() != nil {
+			return
+		}
+		if cid := partContentID(part); cid != "" && part.Body != nil && strings.Contains(doc, "cid:"+cid) {
+			var data []byte
+			switch {
+			case part.Body.Data != "":
+				data, _ = base64.URLEncoding.DecodeString(part.Body.Data)
+			case part.Body.AttachmentId != "" && s.client != nil:
+				data, _, _ = s.client.GetAttachment(message.Id, part.Body.AttachmentId)
+			}
+			if len(data) > 0 {
+				mimeType := part.MimeType
+				if mimeType == "" {
+					mimeType = "application/octet-stream"
+				}
+				out[cid] = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+			}
+		}
+		for _, p := range part.Parts {
+			walk(p)
+		}
+	}
+	walk(message.Payload)
+	return out
+}
+
+// partContentID returns a part's Content-ID without the angle brackets
+func partContentID(part *gmail_v1.MessagePart) string {
+	for _, h := range part.Headers {
+		if strings.EqualFold(h.Name, "Content-ID") {
+			return strings.Trim(strings.TrimSpace(h.Value), "<>")
+		}
+	}
+	return ""
+}
+
+// isRemoteRef reports whether a URL attribute loads something from the network
+func isRemoteRef(v string) bool {
+	v = strings.ToLower(strings.TrimSpace(v))
+	return strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") || strings.HasPrefix(v, "//")
+}
+
+// rewritePreviewHTML prepares an email's HTML for a local browser: it adds a charset and a
+// Content-Security-Policy to <head>, points cid: references at the inline data, and with
+// blockImages swaps remote images (src, srcset, poster, background, CSS url()) for placeholders.
+// It returns the document and how many remote references were blocked.
+func rewritePreviewHTML(doc string, blockImages bool, inline map[string]string) (string, int) {
+	csp := previewCSPAllowed
+	if blockImages {
+		csp = previewCSPBlocked
+	}
+	headInject := `<meta charset="utf-8"><meta http-equiv="Content-Security-Policy" content="` + csp + `">`
+
+	var out bytes.Buffer
+	blocked := 0
+	injected := false
+	inStyle := false
+	z := html.NewTokenizer(strings.NewReader(doc))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				// Unparseable remainder: keep it as-is rather than lose content
+				out.Write(z.Raw())
+			}
+			break
+		}
+		raw := append([]byte(nil), z.Raw()...) // Token() may reuse the buffer
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if !injected && tok.Data != "html" {
+				injected = true
+				if tok.Data != "head" {
+					out.WriteString(headInject)
+				} else {
+					out.Write(raw)
+					out.WriteString(headInject)
+					continue
+				}
+			}
+			inStyle = tok.Data == "style" && tt == html.StartTagToken
+			if n, changed := rewritePreviewAttrs(&tok, blockImages, inline); changed {
+				blocked += n
+				out.WriteString(tok.String())
+				continue
+			}
+		case html.EndTagToken:
+			inStyle = false
+		case html.TextToken:
+			if inStyle && blockImages {
+				text := string(raw)
+				n := len(cssRemoteURLRe.FindAllStringIndex(text, -1))
+				if n > 0 {
+					blocked += n
+					out.WriteString(cssRemoteURLRe.ReplaceAllString(text, "none"))
+					continue
+				}
+			}
+		}
+		out.Write(raw)
+	}
+	if !injected {
+		return headInject + out.String(), blocked
+	}
+	return out.String(), blocked
+}
+
+// rewritePreviewAttrs rewrites one tag's URL attributes; it reports the remote references blocked
+// and whether the tag changed
+func rewritePreviewAttrs(tok *html.Token, blockImages bool, inline map[string]string) (int, bool) {
+	blocked := 0
+	changed := false
+	attrs := tok.Attr[:0]
+	for _, at := range tok.Attr {
+		key := strings.ToLower(at.Key)
+		switch {
+		case (key == "src" || key == "background") && strings.HasPrefix(strings.ToLower(at.Val), "cid:"):
+			if data, ok := inline[strings.Trim(at.Val[4:], "<>")]; ok {
+				at.Val = data
+				changed = true
+			}
+		case !blockImages:
+		case key == "src" && (tok.Data == "img" || tok.Data == "input") && isRemoteRef(at.Val):
+			at.Val = blockedImagePlaceholder
+			blocked++
+			changed = true
+		case (key == "srcset" || key == "background" || key == "poster") && at.Val != "":
+			// srcset lists several candidates; drop it along with remote backgrounds and posters
+			if key == "srcset" || isRemoteRef(at.Val) {
+				blocked++
+				changed = true
+				continue
+			}
+		case key == "style" && cssRemoteURLRe.MatchString(at.Val):
+			blocked += len(cssRemoteURLRe.FindAllStringIndex(at.Val, -1))
+			at.Val = cssRemoteURLRe.ReplaceAllString(at.Val, "none")
+			changed = true
+		}
+		attrs = append(attrs, at)
+	}
+	tok.Attr = attrs
+	return blocked, changed
+}
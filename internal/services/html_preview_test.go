@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type stubPreviewClient struct {
+	data map[string][]byte
+}
+
+func (c *stubPreviewClient) GetAttachment(_, attachmentID string) ([]byte, string, error) {
+	return c.data[attachmentID], "", nil
+}
+
+func TestRewritePreviewHTML_BlocksRemoteImages(t *testing.T) {
+	doc := `<!DOCTYPE html><html><head><title>News</title><style>.hero{background:url("https://t.example/bg.png")}</style></head>` +
+		`<body background="https://t.example/body.png"><img src="https://t.example/pixel.gif" width="1" alt="px">` +
+		`<img src="cid:logo@x" alt="logo"><img srcset="https://t.example/a.png 2x" src="data:image/png;base64,AA==">` +
+		`<div style="background-image: url(//t.example/d.png)">Hi</div></body></html>`
+
+	out, blocked := rewritePreviewHTML(doc, true, map[string]string{"logo@x": "data:image/png;base64,TE9HTw=="})
+
+	assert.Equal(t, 5, blocked)
+	assert.NotContains(t, out, "t.example")
+	assert.Contains(t, out, `<head><meta charset="utf-8"><meta http-equiv="Content-Security-Policy" content="`+previewCSPBlocked+`">`)
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"), "doctype must stay first")
+	assert.Contains(t, out, `src="`+blockedImagePlaceholder+`"`)
+	assert.Contains(t, out, `alt="px"`)
+	assert.Contains(t, out, `src="data:image/png;base64,TE9HTw=="`)
+	assert.Contains(t, out, "<title>News</title>")
+}
+
+func TestRewritePreviewHTML_AllowsImages(t *testing.T) {
+	doc := `<p>Hello</p><img src="https://cdn.example/a.png">`
+	out, blocked := rewritePreviewHTML(doc, false, nil)
+
+	assert.Zero(t, blocked)
+	assert.Contains(t, out, `https://cdn.example/a.png`)
+	assert.True(t, strings.HasPrefix(out, `<meta charset="utf-8"><meta http-equiv="Content-Security-Policy" content="`+previewCSPAllowed+`">`))
+	assert.Contains(t, out, "<p>Hello</p>")
+}
+
+func TestHTMLPreviewService_WritePreview(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewHTMLPreviewService(&stubPreviewClient{data: map[string][]byte{"att-1": []byte("PNG")}},
+		config.HTMLPreviewConfig{Dir: dir, BlockImages: true})
+	assert.True(t, svc.BlockImagesByDefault())
+
+	msg := &gmail.Message{
+		Message: &gmail_v1.Message{Id: "m1", Payload: &gmail_v1.MessagePart{
+			MimeType: "multipart/related",
+			Parts: []*gmail_v1.MessagePart{
+				{MimeType: "image/png", Headers: []*gmail_v1.MessagePartHeader{{Name: "Content-ID", Value: "<logo>"}},
+					Body: &gmail_v1.MessagePartBody{AttachmentId: "att-1"}},
+				{MimeType: "image/gif", Headers: []*gmail_v1.MessagePartHeader{{Name: "Content-Id", Value: "<unused>"}},
+					Body: &gmail_v1.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("GIF"))}},
+			},
+		}},
+		HTML: `<html><body><img src="cid:logo"><img src="https://t.example/p.gif"></body></html>`,
+	}
+
+	preview, err := svc.WritePreview(context.Background(), msg, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, preview.BlockedImages)
+	assert.Equal(t, 1, preview.InlineImages)
+	assert.Equal(t, dir, filepath.Dir(preview.Path))
+
+	data, err := os.ReadFile(preview.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte("PNG")))
+
+	_, err = svc.WritePreview(context.Background(), &gmail.Message{PlainText: "text only"}, true)
+	assert.Error(t, err)
+}
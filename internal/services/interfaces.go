@@ -8,6 +8,7 @@ import (
 	"github.com/ajramos/giztui/internal/gmail"
 	"github.com/ajramos/giztui/internal/obsidian"
 	"github.com/ajramos/giztui/internal/prompts"
+	"github.com/ajramos/giztui/internal/render"
 	gmail_v1 "google.golang.org/api/gmail/v1"
 )
 
@@ -32,6 +33,8 @@ type EmailService interface {
 	TrashMessage(ctx context.Context, messageID string) error
 	SendMessage(ctx context.Context, from, to, subject, body string, cc, bcc []string) error
 	ReplyToMessage(ctx context.Context, originalID, replyBody string, send bool, cc []string) error
+	SendMessageReturningID(ctx context.Context, from, to, subject, body string, cc, bcc []string) (string, error)
+	ReplyReturningID(ctx context.Context, originalID, replyBody string, send bool, cc []string) (string, error)
 	BulkArchive(ctx context.Context, messageIDs []string, onProgress ...func(done, total int)) error
 	BulkTrash(ctx context.Context, messageIDs []string, onProgress ...func(done, total int)) error
 	SaveMessageToFile(ctx context.Context, messageID, filePath string) error
@@ -43,6 +46,7 @@ type EmailService interface {
 type LabelService interface {
 	ListLabels(ctx context.Context) ([]*gmail_v1.Label, error)
 	CreateLabel(ctx context.Context, name string) (*gmail_v1.Label, error)
+	EnsureLabelPath(ctx context.Context, name string) (*gmail_v1.Label, error)
 	RenameLabel(ctx context.Context, labelID, newName string) (*gmail_v1.Label, error)
 	DeleteLabel(ctx context.Context, labelID string) error
 	ApplyLabel(ctx context.Context, messageID, labelID string) error
@@ -52,6 +56,26 @@ type LabelService interface {
 	GetMessageLabels(ctx context.Context, messageID string) ([]string, error)
 }
 
+// LabelCatalog caches the account's labels so list reloads, filters and renderers don't list
+// them from Gmail each time. Label changes made through the Gmail client invalidate it.
+type LabelCatalog interface {
+	Labels(ctx context.Context) ([]*gmail_v1.Label, error)
+	// NameMap and Name answer synchronously from the cache (refreshing it in the background)
+	NameMap() map[string]string
+	Name(id string) string
+	Invalidate()
+}
+
+// UnreadCounterService keeps the unread and total counts of the inbox and other key labels
+// current from Gmail's history
+type UnreadCounterService interface {
+	Track(labelIDs ...string)
+	Count(labelID string) (UnreadCount, bool)
+	Counts() []UnreadCount
+	// Refresh rereads the labels that changed and reports whether any count moved
+	Refresh(ctx context.Context) (bool, error)
+}
+
 // LabelVisibility defines label visibility options
 type LabelVisibility string
 
@@ -106,6 +130,9 @@ type SlackService interface {
 	// SendNewMailDigest posts an auto-refresh notification for the given new message IDs to the
 	// default channel, optionally including a per-email AI summary (capped by opts.SummaryLimit).
 	SendNewMailDigest(ctx context.Context, messageIDs []string, opts NewMailDigestOptions) error
+	// RouteNewMail posts new messages matching the slack.routes rules to their channels, with
+	// per-route dedupe and hourly rate limits
+	RouteNewMail(ctx context.Context, messageIDs []string, opts SlackRouteOptions) ([]SlackRouteResult, error)
 }
 
 // SearchService handles search operations
@@ -196,6 +223,8 @@ type SummaryOptions struct {
 	ForceRegenerate bool
 	MessageID       string
 	AccountEmail    string
+	// Preset selects a summary style (see config.SummaryPresets); "" is the default summary
+	Preset string
 }
 
 type SummaryResult struct {
@@ -313,6 +342,20 @@ type NewMailDigestOptions struct {
 	LinkFor      func(messageID string) string // optional Gmail hyperlink builder; nil = no link
 }
 
+// SlackRouteOptions carries what routing needs from the caller.
+type SlackRouteOptions struct {
+	LabelNames map[string]string             // label ID → name, for label: rules and queries
+	LinkFor    func(messageID string) string // optional Gmail hyperlink builder; nil = no link
+	Now        func() time.Time              // clock for rate limits and query dates; nil = time.Now
+}
+
+// SlackRouteResult reports what one route did with a batch of new mail.
+type SlackRouteResult struct {
+	Route      string
+	Posted     int // messages posted to the channel
+	Suppressed int // matching messages held back by the hourly limit
+}
+
 type SlackChannel struct {
 	ID          string `json:"id"`          // Internal ID
 	Name        string `json:"name"`        // Display name: "team-updates", "personal-dm"
@@ -354,6 +397,8 @@ type ObsidianService interface {
 	IngestEmailToObsidian(ctx context.Context, message *gmail.Message, options obsidian.ObsidianOptions) (*obsidian.ObsidianIngestResult, error)
 	IngestBulkEmailsToObsidian(ctx context.Context, messages []*gmail.Message, accountEmail string, onProgress func(int, int, error)) (*obsidian.BulkObsidianResult, error)
 	IngestEmailsToSingleFile(ctx context.Context, messages []*gmail.Message, accountEmail string, options obsidian.ObsidianOptions) (*obsidian.ObsidianIngestResult, error)
+	// SaveNote writes a Markdown note (e.g. a meeting brief) to the ingest folder of the vault
+	SaveNote(ctx context.Context, title, content string) (string, error)
 	GetObsidianTemplates(ctx context.Context) ([]*obsidian.ObsidianTemplate, error)
 	ValidateObsidianConnection(ctx context.Context) error
 	GetObsidianVaultPath() string
@@ -364,8 +409,17 @@ type ObsidianService interface {
 // LinkService handles link extraction and opening operations
 type LinkService interface {
 	GetMessageLinks(ctx context.Context, messageID string) ([]LinkInfo, error)
+	// LinksFromMessage extracts the links of an already loaded message (e.g. an embedded one)
+	LinksFromMessage(message *gmail.Message) []LinkInfo
+	// LinksFromMarkdown extracts the links of Markdown text (an AI summary or prompt result)
+	LinksFromMarkdown(md string) []LinkInfo
 	OpenLink(ctx context.Context, url string) error
 	ValidateURL(url string) error
+	// FetchLinkPreview fetches the page title and meta description of an http(s) URL.
+	// Results (including failures) are cached per URL for the session.
+	FetchLinkPreview(ctx context.Context, url string) (*LinkPreview, error)
+	IsPreviewEnabled() bool
+	SetPreviewEnabled(enabled bool)
 }
 
 // LinkInfo represents a link found in an email message
@@ -373,7 +427,15 @@ type LinkInfo struct {
 	Index int    `json:"index"` // Reference number [1], [2], etc.
 	URL   string `json:"url"`   // Full URL
 	Text  string `json:"text"`  // Link text/description
-	Type  string `json:"type"`  // "html" or "plain" or "email" or "file"
+	Type  string `json:"type"`  // "html" or "plain" or "email" or "file", "ai" for links of the AI pane
+}
+
+// LinkPreview holds the page metadata fetched for a link
+type LinkPreview struct {
+	URL         string `json:"url"`         // Requested URL
+	FinalURL    string `json:"final_url"`   // URL after redirects (e.g. the target of a bit.ly link)
+	Title       string `json:"title"`       // <title> or og:title
+	Description string `json:"description"` // meta description or og:description
 }
 
 // AttachmentService handles attachment extraction and download operations
@@ -383,6 +445,8 @@ type AttachmentService interface {
 	DownloadAttachmentWithFilename(ctx context.Context, messageID, attachmentID, savePath, suggestedFilename string) (string, error)
 	OpenAttachment(ctx context.Context, filePath string) error
 	GetDefaultDownloadPath() string
+	// PreviewAttachment loads a small text-like attachment for showing it in the content pane
+	PreviewAttachment(ctx context.Context, messageID string, att AttachmentInfo) (*AttachmentPreview, error)
 }
 
 // AttachmentInfo represents an attachment found in an email message
@@ -494,6 +558,10 @@ type QueryService interface {
 	// Query organization
 	GetCategories(ctx context.Context) ([]string, error)
 	UpdateQueryCategory(ctx context.Context, id int64, category string) error
+
+	// Template parameters ({sender}, {days}, ...) and their recently used values
+	RecordParamValues(ctx context.Context, values map[string]string) error
+	GetRecentParamValues(ctx context.Context, param string, limit int) ([]string, error)
 }
 
 // SavedQueryInfo represents information about a saved query
@@ -506,6 +574,7 @@ type SavedQueryInfo struct {
 	UseCount    int    `json:"use_count"`
 	LastUsed    int64  `json:"last_used"`
 	CreatedAt   int64  `json:"created_at"`
+	Source      string `json:"source,omitempty"` // shared pack the query came from; such queries are read-only
 }
 
 // ThreadService handles message threading operations
@@ -704,12 +773,16 @@ type CompositionService interface {
 	SaveDraft(ctx context.Context, composition *Composition) (string, error)
 	DeleteComposition(ctx context.Context, compositionID string) error
 	SendComposition(ctx context.Context, composition *Composition) error
+	SentLabelsFor(composition *Composition) []string
 
 	// Validation & processing
 	ValidateComposition(composition *Composition) []ValidationError
 	ProcessReply(ctx context.Context, originalMessageID string) (*ReplyContext, error)
 	ProcessReplyAll(ctx context.Context, originalMessageID string) (*ReplyAllContext, error)
 	ProcessForward(ctx context.Context, originalMessageID string) (*ForwardContext, error)
+	ForwardBody(ctx context.Context, originalMessageID string, includeQuoted bool) (string, error)
+	ForwardNote(ctx context.Context, originalMessageID string) (string, error)
+	NewerThreadMessages(ctx context.Context, originalMessageID string) ([]*gmail_v1.Message, error)
 
 	// Templates & suggestions
 	GetTemplates(ctx context.Context, category string) ([]*EmailTemplate, error)
@@ -740,6 +813,7 @@ type Composition struct {
 	Subject     string          `json:"subject"`
 	Body        string          `json:"body"`
 	Attachments []Attachment    `json:"attachments"`
+	Labels      []string        `json:"labels,omitempty"` // label names applied to the sent message
 	OriginalID  string          `json:"original_id,omitempty"`
 	DraftID     string          `json:"draft_id,omitempty"`
 	IsDraft     bool            `json:"is_draft"`
@@ -789,6 +863,7 @@ type ForwardContext struct {
 	ForwardedBody   string         `json:"forwarded_body"`
 	OriginalSender  Recipient      `json:"original_sender"`
 	OriginalDate    time.Time      `json:"original_date"`
+	Attachments     []Attachment   `json:"attachments,omitempty"` // original's attachments, without data
 }
 
 // EmailTemplate represents a reusable email template
@@ -826,6 +901,10 @@ type MessagePreloader interface {
 	GetCachedMessage(ctx context.Context, messageID string) (*gmail_v1.Message, bool)
 	ClearCache(ctx context.Context) error
 
+	// Account partitioning: caches only ever hold data of the account last switched to
+	SwitchAccount(ctx context.Context, accountID string, client *gmail.Client) error
+	AccountID() string
+
 	// Configuration management
 	IsEnabled() bool
 	IsNextPageEnabled() bool
@@ -902,6 +981,28 @@ type AccountService interface {
 	RefreshAccountClient(ctx context.Context, accountID string) error
 }
 
+// CrossAccountSearchService runs one Gmail search across every configured account
+type CrossAccountSearchService interface {
+	// Search fetches the first page of query for each account and merges them newest first
+	Search(ctx context.Context, query string, pageSize int64) (*CrossAccountSearchResult, error)
+	// NextPage fetches the following page for each account with a non-empty token
+	NextPage(ctx context.Context, query string, pageSize int64, tokens map[string]string) (*CrossAccountSearchResult, error)
+}
+
+// CrossAccountMessage is a search hit tagged with the account it belongs to
+type CrossAccountMessage struct {
+	AccountID    string
+	AccountLabel string
+	Message      *gmail_v1.Message
+}
+
+// CrossAccountSearchResult is one merged page of a cross-account search
+type CrossAccountSearchResult struct {
+	Messages       []CrossAccountMessage
+	NextPageTokens map[string]string // accountID -> token; accounts without more pages are absent, failed pages keep theirs
+	Errors         map[string]error  // accountID -> error for accounts that failed this page
+}
+
 // Account represents a configured Gmail account
 type Account struct {
 	ID          string        `json:"id"`           // unique identifier (e.g., "personal", "work")
@@ -913,6 +1014,8 @@ type Account struct {
 	Status      AccountStatus `json:"status"`       // connection status
 	LastUsed    time.Time     `json:"last_used"`    // last time account was active
 	Client      *gmail.Client `json:"-"`            // Gmail API client (not serialized)
+	// InternalDomains are the domains treated as internal when sending (see ExternalRecipients)
+	InternalDomains []string `json:"internal_domains,omitempty"`
 }
 
 // AccountStatus represents the connection state of an account
@@ -1066,3 +1169,355 @@ type AnalyzerRulesService interface {
 	// (e.g. "Always archive emails from tldr.tech"). Pure — no I/O.
 	SuggestRuleFromContext(from, action string, negate bool) string
 }
+
+// QuotaPlannerService estimates the Gmail quota a bulk job needs and, when it would eat into
+// the reserve kept for interactive actions, splits it across quota windows.
+type QuotaPlannerService interface {
+	Plan(op QuotaOperation, messages int) QuotaPlan
+	RunScheduled(ctx context.Context, plan QuotaPlan, ids []string, run func(batch []string, offset int) error, onWait func(next, total int, resume time.Time)) error
+	Record(units int)
+}
+
+// OutboxService queues messages composed while Gmail is unreachable and sends them, in order, once
+// connectivity returns. Each queued message keeps its own status for the outbox panel.
+type OutboxService interface {
+	IsOffline() bool
+	SetOffline(offline bool)
+	Counts() (pending, failed int)
+	Enqueue(ctx context.Context, composition *Composition, cause error) (*OutboxItem, error)
+	List(ctx context.Context) ([]*OutboxItem, error)
+	Flush(ctx context.Context) (OutboxFlushResult, error)
+	SendNow(ctx context.Context, id int64) error
+	Discard(ctx context.Context, id int64) error
+	ClearSent(ctx context.Context) (int, error)
+}
+
+// MailMergeService sends a template filled for each row of a recipient list, one message at a
+// time, remembering what was sent so an interrupted merge resumes
+type MailMergeService interface {
+	SetAccountEmail(email string)
+	LoadStatus(ctx context.Context, job *MailMergeJob) error
+	Run(ctx context.Context, job *MailMergeJob, onUpdate func(i int, it *MergeItem)) (MergeRunResult, error)
+}
+
+// LocalArchiveService moves messages out of Gmail while keeping a searchable local copy: the raw
+// message goes to an mbox file and its text to a full-text index, then the message is trashed.
+type LocalArchiveService interface {
+	ArchiveLocally(ctx context.Context, messageID string) error
+	Search(ctx context.Context, terms string, limit int) ([]*LocalArchiveInfo, error)
+	Body(ctx context.Context, id int64) (string, error)
+	Raw(ctx context.Context, info *LocalArchiveInfo) ([]byte, error)
+	MboxPath() string
+}
+
+// LocalArchiveInfo describes a message in the local archive
+type LocalArchiveInfo struct {
+	ID         int64
+	MessageID  string
+	ThreadID   string
+	Subject    string
+	From       string
+	To         string
+	Date       time.Time
+	Labels     []string
+	MboxPath   string
+	ArchivedAt time.Time
+
+	offset, length int64 // location of the record in MboxPath
+}
+
+// SmartLabelService links saved queries to labels that are applied automatically to new messages
+// matching the query, evaluated locally so queries can go beyond what Gmail filters support
+type SmartLabelService interface {
+	Link(ctx context.Context, queryName, labelName string) (*SmartLabelInfo, error)
+	Unlink(ctx context.Context, queryName string) error
+	List(ctx context.Context) ([]*SmartLabelInfo, error)
+	Apply(ctx context.Context, msgs []*gmail_v1.Message) ([]SmartLabelMatch, error)
+}
+
+// SmartLabelInfo is a saved query linked to a label
+type SmartLabelInfo struct {
+	QueryID   int64
+	QueryName string
+	Query     string
+	LabelName string
+}
+
+// SmartLabelMatch records a label applied by a smart label
+type SmartLabelMatch struct {
+	MessageID string
+	LabelID   string
+	LabelName string
+	QueryName string
+}
+
+// RecipientGroupService manages named recipient groups (compose.recipient_groups) and expands
+// group names typed in the composer's recipient fields
+type RecipientGroupService interface {
+	List() []RecipientGroup
+	Set(name string, members []string) (*RecipientGroup, error)
+	Delete(name string) error
+	Expand(recipients []Recipient) ([]Recipient, bool)
+}
+
+// RecipientGroup is a named list of addresses
+type RecipientGroup struct {
+	Name    string
+	Members []string
+}
+
+// ThreadNoteService keeps notes pinned to conversations: an AI thread summary (edited or not) or
+// free text, shown at the top of the thread's messages
+type ThreadNoteService interface {
+	Get(ctx context.Context, threadID string) (*ThreadNoteInfo, error)
+	Pin(ctx context.Context, threadID, note, source string) (*ThreadNoteInfo, error)
+	Unpin(ctx context.Context, threadID string) error
+	Summary(ctx context.Context, threadID string, force bool) (string, error)
+	Regenerate(ctx context.Context, threadID string) (*ThreadNoteInfo, error)
+}
+
+// ThreadNoteInfo is a note pinned to a conversation
+type ThreadNoteInfo struct {
+	ThreadID  string
+	Note      string
+	Source    string // ThreadNoteAI or ThreadNoteManual
+	UpdatedAt time.Time
+}
+
+// ReportService builds periodic email reports (volume by label, top senders, response times,
+// important unread) from message metadata, optionally with an AI-written narrative
+type ReportService interface {
+	Generate(ctx context.Context, opts ReportOptions) (*EmailReport, error)
+}
+
+// ReportOptions configures a report
+type ReportOptions struct {
+	Days      int  // period length, 7 when zero
+	Narrative bool // ask the AI service for a narrative paragraph
+}
+
+// EmailReport is a computed email report
+type EmailReport struct {
+	Since, Until    time.Time
+	Received        int
+	Unread          int
+	Sent            int
+	Replies         int
+	MedianResponse  time.Duration
+	AverageResponse time.Duration
+	ByLabel         []ReportCount
+	TopSenders      []ReportCount
+	ImportantUnread []ReportMessage
+	Narrative       string
+	NarrativeError  string // why the narrative is missing when one was requested
+}
+
+// ReportCount is one row of a report ranking
+type ReportCount struct {
+	Name  string
+	Count int
+}
+
+// ReportMessage is a message listed in a report
+type ReportMessage struct {
+	ID      string
+	From    string
+	Subject string
+	Date    time.Time
+}
+
+// HTMLPreviewService writes a message's HTML part to a local file and opens it in a browser, for
+// newsletters the terminal renderer cannot do justice to
+type HTMLPreviewService interface {
+	WritePreview(ctx context.Context, message *gmail.Message, blockImages bool) (*HTMLPreview, error)
+	OpenInBrowser(ctx context.Context, path string) error
+	BlockImagesByDefault() bool
+}
+
+// HTMLPreview is a written preview file
+type HTMLPreview struct {
+	Path          string
+	BlockedImages int // remote image references replaced or removed
+	InlineImages  int // cid: images embedded from the message
+}
+
+// TrashRestoreService takes messages out of Trash/Spam back to where they were: the labels
+// recorded when the app moved them there, or the inbox when there is no record
+type TrashRestoreService interface {
+	RecordOrigins(ctx context.Context, folder string, labels map[string][]string) error
+	Restore(ctx context.Context, messageIDs []string, onProgress func(done, total int)) (*RestoreResult, error)
+	Prune(ctx context.Context) error
+}
+
+// RestoreResult summarizes a restore
+type RestoreResult struct {
+	Restored   int
+	FromRecord int // restored to the labels recorded when trashed
+	Inferred   int // no record: restored to the inbox (or Sent)
+	NotTrashed int // already out of Trash and Spam; left alone
+	Failed     []string
+	Errors     []string
+}
+
+// TimeMachineService keeps daily inbox snapshots and shows the inbox approximately as it was on a
+// past date
+type TimeMachineService interface {
+	Capture(ctx context.Context, force bool) (*InboxSnapshot, error)
+	At(ctx context.Context, date time.Time) (*TimeMachineView, error)
+	Days(ctx context.Context) ([]string, error)
+}
+
+// InboxSnapshot summarizes a snapshot that was just taken
+type InboxSnapshot struct {
+	Day      string
+	Messages int
+	Unread   int
+}
+
+// TimeMachineView is the inbox as recorded by the snapshot closest to (not after) the requested date
+type TimeMachineView struct {
+	Requested   time.Time
+	SnapshotDay string
+	Messages    []*gmail_v1.Message // list metadata from the local cache, newest first
+	Unread      int
+}
+
+// ContactService keeps the local contacts index: addresses added from vCard attachments
+type ContactService interface {
+	AddVCards(ctx context.Context, cards []VCard, source string) (int, error)
+	Search(ctx context.Context, query string, limit int) ([]ContactInfo, error)
+	Remove(ctx context.Context, email string) error
+}
+
+// ContactInfo is an address in the contacts index
+type ContactInfo struct {
+	Email     string
+	Name      string
+	Org       string
+	Phone     string
+	Source    string
+	UpdatedAt time.Time
+}
+
+// SenderOverrideService keeps the local display overrides (name, emoji, color tag) of senders
+type SenderOverrideService interface {
+	List(ctx context.Context) ([]SenderOverrideInfo, error)
+	Edit(ctx context.Context, addr string, edit func(o *SenderOverrideInfo)) (*SenderOverrideInfo, error)
+	Remove(ctx context.Context, addr string) error
+	Displays(ctx context.Context) (map[string]render.SenderDisplay, error)
+}
+
+// SenderOverrideInfo is how one sender address is displayed in the list and the reader header
+type SenderOverrideInfo struct {
+	Email     string
+	Name      string
+	Emoji     string
+	Color     string
+	UpdatedAt time.Time
+}
+
+// TodoService keeps the action items extracted from messages with AI
+type TodoService interface {
+	Extract(ctx context.Context, src TodoSource) (found, added int, err error)
+	List(ctx context.Context, all bool) ([]TodoItem, error)
+	SetStatus(ctx context.Context, id int64, status string) error
+	ClearClosed(ctx context.Context) (int, error)
+}
+
+// TodoSource is a message or conversation to extract action items from; the items link back to
+// MessageID
+type TodoSource struct {
+	MessageID string
+	ThreadID  string
+	Subject   string
+	Content   string
+}
+
+// TodoItem is an extracted action item
+type TodoItem struct {
+	ID        int64
+	MessageID string
+	ThreadID  string
+	Subject   string
+	Task      string
+	Owner     string
+	Due       string
+	Status    string // TodoOpen, TodoDone or TodoDismissed
+	CreatedAt time.Time
+}
+
+// MeetingBriefingService builds AI pre-meeting briefs from calendar invites: the invite details,
+// the conversation that led to it and its attachments
+type MeetingBriefingService interface {
+	Generate(ctx context.Context, messageID string) (*MeetingBriefing, error)
+}
+
+// SharedPackService merges a team's prompts and saved queries, kept in a git repository or a
+// directory, read-only into the local sets
+type SharedPackService interface {
+	Sync(ctx context.Context, pull bool) (*SharedPackReport, error)
+}
+
+// SharedPackReport describes a shared pack sync
+type SharedPackReport struct {
+	Dir      string // pack directory; "" when no pack is configured
+	Pulled   bool   // the repository was cloned or pulled
+	PullErr  error  // the pull failed; the existing checkout was merged
+	Prompts  db.SharedSyncResult
+	Queries  db.SharedSyncResult
+	Problems []string // pack files or entries skipped as invalid
+}
+
+// ActionRecorder is told about each message action as it is recorded for undo
+type ActionRecorder interface {
+	Record(ctx context.Context, action *UndoableAction)
+}
+
+// UsualActionsService learns, from a local log of message actions, what is usually done to a
+// sender's messages (e.g. "label Receipts + archive")
+type UsualActionsService interface {
+	ActionRecorder
+	// Suggest returns the usual actions for a sender's messages, or nil when there is no habit
+	Suggest(ctx context.Context, sender string) (*UsualActions, error)
+}
+
+// UsualActions is the set of actions usually done to a sender's messages
+type UsualActions struct {
+	Sender       string
+	AddLabels    []string // label IDs
+	RemoveLabels []string // label IDs
+	Archive      bool
+	Trash        bool
+	Times        int // recent messages of the sender the set was done to
+	Of           int // recent messages of the sender that were acted on
+}
+
+// DigestService builds a digest of the unread mail (top senders, AI one-liners for the oldest
+// unread) to send to yourself for end-of-day review or handing off context
+type DigestService interface {
+	Generate(ctx context.Context, opts DigestOptions) (*UnreadDigest, error)
+}
+
+// DigestOptions configures an unread digest
+type DigestOptions struct {
+	Query        string // unread mail to digest; "is:unread in:inbox" when empty
+	AccountEmail string // scopes the cached one-line summaries
+	NoAI         bool   // skip the one-liners
+}
+
+// UnreadDigest is a computed unread digest
+type UnreadDigest struct {
+	At            time.Time
+	Query         string
+	Unread        int  // unread messages found
+	Capped        bool // more unread messages exist than were read
+	TopSenders    []ReportCount
+	Oldest        []DigestItem // oldest unread first
+	OneLinerError string       // why one-liners are missing when they were asked for
+}
+
+// DigestItem is an unread message listed in a digest with its AI one-line summary
+type DigestItem struct {
+	ReportMessage
+	OneLiner string
+}
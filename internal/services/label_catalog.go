@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// DefaultLabelCatalogTTL is how long listed labels are trusted; changes made in this app
+// invalidate them sooner, so the TTL only covers labels edited elsewhere (e.g. Gmail web)
+const DefaultLabelCatalogTTL = 5 * time.Minute
+
+// LabelCatalogClient is the part of *gmail.Client the catalog uses
+type LabelCatalogClient interface {
+	ListLabels() ([]*gmail_v1.Label, error)
+}
+
+// LabelCatalogImpl caches the labels of one account. Labels waits for a fresh list when the
+// cache has expired; NameMap and Name answer from the cache and refresh it in the background.
+type LabelCatalogImpl struct {
+	client LabelCatalogClient
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu         sync.RWMutex
+	labels     []*gmail_v1.Label
+	names      map[string]string
+	loadedAt   time.Time // zero when never loaded or invalidated
+	generation int       // bumped by Invalidate so an in-flight list doesn't store stale labels
+
+	fetchMu    sync.Mutex // one ListLabels call at a time
+	refreshing bool
+}
+
+// NewLabelCatalog creates a label catalog; ttl <= 0 uses DefaultLabelCatalogTTL
+func NewLabelCatalog(client LabelCatalogClient, ttl time.Duration) *LabelCatalogImpl {
+	if ttl <= 0 {
+		ttl = DefaultLabelCatalogTTL
+	}
+	return &LabelCatalogImpl{client: client, ttl: ttl, now: time.Now, names: map[string]string{}}
+}
+
+// Labels returns the account's labels, listing them from Gmail when the cache is empty, expired
+// or invalidated. A failed list falls back to the previous labels, if any.
+func (c *LabelCatalogImpl) Labels(ctx context.Context) ([]*gmail_v1.Label, error) {
+	if labels, ok := c.fresh(); ok {
+		return labels, nil
+	}
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	// Another caller may have listed them while this one waited
+	if labels, ok := c.fresh(); ok {
+		return labels, nil
+	}
+	if err := c.load(); err != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		if c.labels != nil {
+			return c.labels, nil
+		}
+		return nil, ClassifyError("list labels", err)
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.labels, nil
+}
+
+// NameMap returns a copy of the label ID → name map. Only the first call waits for Gmail;
+// afterwards an expired map is returned as is while a background refresh updates it.
+func (c *LabelCatalogImpl) NameMap() map[string]string {
+	c.mu.RLock()
+	loaded := c.labels != nil
+	c.mu.RUnlock()
+	if !loaded {
+		_, _ = c.Labels(context.Background())
+	} else if _, ok := c.fresh(); !ok {
+		c.refreshAsync()
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]string, len(c.names))
+	for id, name := range c.names {
+		out[id] = name
+	}
+	return out
+}
+
+// Name returns the name of a label ID from the cache, "" when unknown
+func (c *LabelCatalogImpl) Name(id string) string {
+	c.mu.RLock()
+	name, loaded := c.names[id], c.labels != nil
+	c.mu.RUnlock()
+	if !loaded {
+		return c.NameMap()[id]
+	}
+	if _, ok := c.fresh(); !ok {
+		c.refreshAsync()
+	}
+	return name
+}
+
+// Invalidate marks the cache stale so the next Labels call lists them again; call it when a
+// label is created, renamed or deleted
+func (c *LabelCatalogImpl) Invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.generation++
+	c.mu.Unlock()
+}
+
+// fresh returns the cached labels if they are still within the TTL
+func (c *LabelCatalogImpl) fresh() ([]*gmail_v1.Label, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.labels == nil || c.loadedAt.IsZero() || c.now().Sub(c.loadedAt) >= c.ttl {
+		return nil, false
+	}
+	return c.labels, true
+}
+
+// load lists the labels and stores them unless the cache was invalidated meanwhile
+func (c *LabelCatalogImpl) load() error {
+	c.mu.RLock()
+	gen := c.generation
+	c.mu.RUnlock()
+	labels, err := c.client.ListLabels()
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(labels))
+	for _, l := range labels {
+		if l != nil {
+			names[l.Id] = l.Name
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.labels, c.names = labels, names
+	if gen == c.generation {
+		c.loadedAt = c.now()
+	}
+	return nil
+}
+
+// refreshAsync reloads the labels in the background, once at a time
+func (c *LabelCatalogImpl) refreshAsync() {
+	c.mu.Lock()
+	if c.refreshing {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing = true
+	c.mu.Unlock()
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			c.refreshing = false
+			c.mu.Unlock()
+		}()
+		_, _ = c.Labels(context.Background())
+	}()
+}
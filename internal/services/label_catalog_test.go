@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type countingLabelClient struct {
+	mu     sync.Mutex
+	calls  int
+	labels []*gmail_v1.Label
+	err    error
+}
+
+func (c *countingLabelClient) ListLabels() ([]*gmail_v1.Label, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return c.labels, c.err
+}
+
+func (c *countingLabelClient) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestLabelCatalog_CachesUntilTTLOrInvalidate(t *testing.T) {
+	client := &countingLabelClient{labels: []*gmail_v1.Label{{Id: "Label_1", Name: "Projects"}}}
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewLabelCatalog(client, time.Minute)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Labels(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := c.Name("Label_1"); got != "Projects" || client.count() != 1 {
+		t.Fatalf("name %q after %d lists, want one list", got, client.count())
+	}
+
+	client.labels = []*gmail_v1.Label{{Id: "Label_1", Name: "Work"}}
+	c.Invalidate()
+	if labels, _ := c.Labels(context.Background()); labels[0].Name != "Work" || client.count() != 2 {
+		t.Fatalf("invalidate should list again: %q after %d lists", labels[0].Name, client.count())
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := c.Labels(context.Background()); err != nil || client.count() != 3 {
+		t.Fatalf("expired cache should list again (%d lists)", client.count())
+	}
+}
+
+func TestLabelCatalog_StaleNameRefreshesInBackground(t *testing.T) {
+	client := &countingLabelClient{labels: []*gmail_v1.Label{{Id: "L", Name: "Old"}}}
+	now := time.Now()
+	c := NewLabelCatalog(client, time.Minute)
+	c.now = func() time.Time { return now }
+	if m := c.NameMap(); m["L"] != "Old" {
+		t.Fatalf("first NameMap = %v", m)
+	}
+	client.mu.Lock()
+	client.labels = []*gmail_v1.Label{{Id: "L", Name: "New"}}
+	client.mu.Unlock()
+	now = now.Add(2 * time.Minute)
+	if got := c.Name("L"); got != "Old" {
+		t.Fatalf("a stale name is served while refreshing, got %q", got)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for c.Name("L") != "New" {
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not land")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLabelCatalog_ErrorKeepsPreviousLabels(t *testing.T) {
+	client := &countingLabelClient{err: errors.New("offline")}
+	c := NewLabelCatalog(client, time.Minute)
+	if _, err := c.Labels(context.Background()); err == nil {
+		t.Fatal("expected an error with nothing cached")
+	}
+	client.err = nil
+	client.labels = []*gmail_v1.Label{{Id: "L", Name: "Kept"}}
+	if _, err := c.Labels(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	client.err = errors.New("offline")
+	c.Invalidate()
+	if labels, err := c.Labels(context.Background()); err != nil || labels[0].Name != "Kept" {
+		t.Fatalf("labels = %v, err = %v", labels, err)
+	}
+}
@@ -112,3 +112,24 @@ func TestLabelService_ListLabels(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, got)
 }
+
+func TestLabelService_EnsureLabelPath(t *testing.T) {
+	c := &MockLabelClient{}
+	svc := NewLabelService(c)
+	ctx := context.Background()
+	_, err := svc.EnsureLabelPath(ctx, "Work//Q3")
+	assert.Error(t, err, "empty path segments are rejected")
+
+	c.On("ListLabels").Return([]*gmail_v1.Label{{Id: "L1", Name: "work"}}, nil)
+	c.On("CreateLabel", "Work/Q3").Return(&gmail_v1.Label{Id: "L2", Name: "Work/Q3"}, nil)
+	c.On("CreateLabel", "Work/Q3/Invoices").Return(&gmail_v1.Label{Id: "L3", Name: "Work/Q3/Invoices"}, nil)
+	got, err := svc.EnsureLabelPath(ctx, " Work / Q3 / Invoices ")
+	assert.NoError(t, err)
+	assert.Equal(t, "L3", got.Id)
+	c.AssertNotCalled(t, "CreateLabel", "Work")
+
+	got, err = svc.EnsureLabelPath(ctx, "WORK")
+	assert.NoError(t, err)
+	assert.Equal(t, "L1", got.Id, "existing labels are reused case-insensitively")
+	c.AssertExpectations(t)
+}
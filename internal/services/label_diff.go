@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LabelState is what a message looked like at one point of a bulk job
+type LabelState struct {
+	Subject  string
+	LabelIDs []string
+}
+
+// LabelDiffJob describes a bulk label or move job so its result can be checked message by message
+type LabelDiffJob struct {
+	Operation string   // shown in the report title, e.g. "Move to Projects"
+	Add       []string // label IDs every message should have afterwards
+	Remove    []string // label IDs no message should have afterwards
+	Failures  map[string]string
+	Err       error // error of the job as a whole, when per-message failures are not known
+}
+
+// LabelDiffEntry is one message of a label diff report
+type LabelDiffEntry struct {
+	MessageID string
+	Subject   string
+	Before    []string // label names
+	After     []string
+	Failed    string // why the expected change did not land; "" when it did
+}
+
+// Added lists the labels the message gained
+func (e LabelDiffEntry) Added() []string { return missingFrom(e.After, e.Before) }
+
+// Removed lists the labels the message lost
+func (e LabelDiffEntry) Removed() []string { return missingFrom(e.Before, e.After) }
+
+// LabelDiff is the before → after report of a bulk label or move job
+type LabelDiff struct {
+	Operation string
+	Finished  time.Time
+	Entries   []LabelDiffEntry
+}
+
+// Diff compares the label state of ids before and after the job. after is read back from Gmail;
+// a message missing from it could not be verified. names maps label IDs to display names.
+func (j LabelDiffJob) Diff(ids []string, before, after map[string]LabelState, names map[string]string) *LabelDiff {
+	d := &LabelDiff{Operation: j.Operation, Finished: time.Now()}
+	for _, id := range ids {
+		b, a := before[id], after[id]
+		e := LabelDiffEntry{MessageID: id, Subject: b.Subject, Before: labelNames(b.LabelIDs, names)}
+		if e.Subject == "" {
+			e.Subject = a.Subject
+		}
+		if _, ok := after[id]; !ok {
+			e.After = e.Before
+			e.Failed = "could not read the labels after the job"
+		} else {
+			e.After = labelNames(a.LabelIDs, names)
+			// A job with no expected change (e.g. restore) only has its reported failures
+			if !j.landed(a.LabelIDs) || (len(j.Add)+len(j.Remove) == 0 && j.Failures[id] != "") {
+				e.Failed = j.reason(id)
+			}
+		}
+		d.Entries = append(d.Entries, e)
+	}
+	return d
+}
+
+// landed reports whether labels reflect the job's expected change
+func (j LabelDiffJob) landed(labels []string) bool {
+	has := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		has[l] = true
+	}
+	for _, l := range j.Add {
+		if !has[l] {
+			return false
+		}
+	}
+	for _, l := range j.Remove {
+		if has[l] {
+			return false
+		}
+	}
+	return true
+}
+
+func (j LabelDiffJob) reason(id string) string {
+	if r := j.Failures[id]; r != "" {
+		return r
+	}
+	if j.Err != nil {
+		return j.Err.Error()
+	}
+	return "the change is not visible in Gmail"
+}
+
+// Counts returns how many messages changed, were left unchanged and failed
+func (d *LabelDiff) Counts() (changed, unchanged, failed int) {
+	for _, e := range d.Entries {
+		switch {
+		case e.Failed != "":
+			failed++
+		case len(e.Added())+len(e.Removed()) > 0:
+			changed++
+		default:
+			unchanged++
+		}
+	}
+	return changed, unchanged, failed
+}
+
+// Format renders the report as plain text: a summary, then each message with its removed (-)
+// and added (+) labels, failures first
+func (d *LabelDiff) Format() string {
+	var b strings.Builder
+	changed, unchanged, failed := d.Counts()
+	fmt.Fprintf(&b, "Bulk label report — %s (%s)\n", d.Operation, d.Finished.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "%d messages: %d changed, %d unchanged, %d failed\n", len(d.Entries), changed, unchanged, failed)
+	entries := make([]LabelDiffEntry, len(d.Entries))
+	copy(entries, d.Entries)
+	sort.SliceStable(entries, func(i, k int) bool { return entries[i].Failed != "" && entries[k].Failed == "" })
+	for _, e := range entries {
+		subject := strings.TrimSpace(e.Subject)
+		if subject == "" {
+			subject = "(no subject)"
+		}
+		fmt.Fprintf(&b, "\n%s  [%s]\n", subject, e.MessageID)
+		for _, l := range e.Removed() {
+			fmt.Fprintf(&b, "  - %s\n", l)
+		}
+		for _, l := range e.Added() {
+			fmt.Fprintf(&b, "  + %s\n", l)
+		}
+		if e.Failed != "" {
+			fmt.Fprintf(&b, "  ✗ failed: %s\n", e.Failed)
+		} else if len(e.Removed())+len(e.Added()) == 0 {
+			b.WriteString("  = no label change\n")
+		}
+	}
+	return b.String()
+}
+
+// labelNames maps label IDs to sorted display names; unknown IDs are kept as they are
+func labelNames(ids []string, names map[string]string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if n := names[id]; n != "" {
+			out = append(out, n)
+		} else {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// missingFrom lists the items of a that are not in b
+func missingFrom(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !in[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLabelDiffJob_Diff(t *testing.T) {
+	names := map[string]string{"Label_1": "Projects"}
+	before := map[string]LabelState{
+		"m1": {Subject: "Budget", LabelIDs: []string{"INBOX", "UNREAD"}},
+		"m2": {Subject: "Trip", LabelIDs: []string{"INBOX"}},
+		"m3": {Subject: "Old", LabelIDs: []string{"Label_1"}},
+		"m4": {LabelIDs: []string{"INBOX"}},
+	}
+	after := map[string]LabelState{
+		"m1": {LabelIDs: []string{"UNREAD", "Label_1"}},
+		"m2": {LabelIDs: []string{"INBOX"}},
+		"m3": {LabelIDs: []string{"Label_1"}},
+	}
+	job := LabelDiffJob{Operation: "Move to Projects", Add: []string{"Label_1"}, Remove: []string{"INBOX"}, Failures: map[string]string{"m2": "rate limited"}}
+	d := job.Diff([]string{"m1", "m2", "m3", "m4"}, before, after, names)
+
+	if got := d.Entries[0]; strings.Join(got.Added(), ",") != "Projects" || strings.Join(got.Removed(), ",") != "INBOX" || got.Failed != "" {
+		t.Fatalf("m1 = %+v", got)
+	}
+	if d.Entries[1].Failed != "rate limited" {
+		t.Fatalf("m2 failure = %q", d.Entries[1].Failed)
+	}
+	if d.Entries[2].Failed != "" || len(d.Entries[2].Added())+len(d.Entries[2].Removed()) != 0 {
+		t.Fatalf("m3 already matched: %+v", d.Entries[2])
+	}
+	if !strings.Contains(d.Entries[3].Failed, "could not read") {
+		t.Fatalf("m4 failure = %q", d.Entries[3].Failed)
+	}
+	if changed, unchanged, failed := d.Counts(); changed != 1 || unchanged != 1 || failed != 2 {
+		t.Fatalf("counts = %d/%d/%d", changed, unchanged, failed)
+	}
+
+	out := d.Format()
+	for _, want := range []string{"Move to Projects", "4 messages: 1 changed, 1 unchanged, 2 failed", "Budget  [m1]\n  - INBOX\n  + Projects", "✗ failed: rate limited", "= no label change", "(no subject)  [m4]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "[m2]") > strings.Index(out, "[m1]") {
+		t.Errorf("failures should be listed first:\n%s", out)
+	}
+}
+
+func TestLabelDiffJob_JobError(t *testing.T) {
+	job := LabelDiffJob{Operation: "Apply label", Add: []string{"L"}, Err: errors.New("quota exceeded")}
+	before := map[string]LabelState{"m1": {}, "m2": {}}
+	after := map[string]LabelState{"m1": {LabelIDs: []string{"L"}}, "m2": {}}
+	d := job.Diff([]string{"m1", "m2"}, before, after, nil)
+	if d.Entries[0].Failed != "" || d.Entries[1].Failed != "quota exceeded" {
+		t.Fatalf("entries = %+v", d.Entries)
+	}
+}
@@ -25,7 +25,9 @@ type LabelClient interface {
 // LabelServiceImpl implements LabelService
 type LabelServiceImpl struct {
 	gmailClient LabelClient
-	undoService UndoService // Optional - for recording undo actions
+	undoService UndoService   // Optional - for recording undo actions
+	catalog     LabelCatalog  // Optional - cached label list
+	batch       BatchModifier // Optional - bulk jobs use batchModify instead of one call per message
 }
 
 // NewLabelService creates a new label service
@@ -41,10 +43,23 @@ func (s *LabelServiceImpl) SetUndoService(undoService UndoService) {
 	s.undoService = undoService
 }
 
+// SetBatchModifier makes the bulk label jobs change up to 1000 messages per Gmail call
+func (s *LabelServiceImpl) SetBatchModifier(batch BatchModifier) {
+	s.batch = batch
+}
+
+// SetLabelCatalog makes ListLabels answer from the label cache
+func (s *LabelServiceImpl) SetLabelCatalog(catalog LabelCatalog) {
+	s.catalog = catalog
+}
+
 func (s *LabelServiceImpl) ListLabels(ctx context.Context) ([]*gmail_v1.Label, error) {
+	if s.catalog != nil {
+		return s.catalog.Labels(ctx)
+	}
 	labels, err := s.gmailClient.ListLabels()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list labels: %w", err)
+		return nil, ClassifyError("list labels", err)
 	}
 
 	return labels, nil
@@ -57,9 +72,48 @@ func (s *LabelServiceImpl) CreateLabel(ctx context.Context, name string) (*gmail
 
 	label, err := s.gmailClient.CreateLabel(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create label: %w", err)
+		return nil, ClassifyError("create label", err)
+	}
+
+	return label, nil
+}
+
+// EnsureLabelPath returns the label with the given name, creating it when missing. Nested names
+// ("Parent/Child") create each missing ancestor first so Gmail shows them as a hierarchy. Existing
+// labels are matched case-insensitively, as Gmail does.
+func (s *LabelServiceImpl) EnsureLabelPath(ctx context.Context, name string) (*gmail_v1.Label, error) {
+	segments := strings.Split(strings.TrimSpace(name), "/")
+	for i := range segments {
+		segments[i] = strings.TrimSpace(segments[i])
+		if segments[i] == "" {
+			return nil, fmt.Errorf("invalid label name %q", name)
+		}
+	}
+
+	labels, err := s.gmailClient.ListLabels()
+	if err != nil {
+		return nil, ClassifyError("list labels", err)
+	}
+	existing := make(map[string]*gmail_v1.Label, len(labels))
+	for _, l := range labels {
+		if l != nil {
+			existing[strings.ToLower(l.Name)] = l
+		}
 	}
 
+	var label *gmail_v1.Label
+	for i := range segments {
+		path := strings.Join(segments[:i+1], "/")
+		if l, ok := existing[strings.ToLower(path)]; ok {
+			label = l
+			continue
+		}
+		label, err = s.gmailClient.CreateLabel(path)
+		if err != nil {
+			return nil, ClassifyError(fmt.Sprintf("create label %q", path), err)
+		}
+		existing[strings.ToLower(path)] = label
+	}
 	return label, nil
 }
 
@@ -70,7 +124,7 @@ func (s *LabelServiceImpl) RenameLabel(ctx context.Context, labelID, newName str
 
 	label, err := s.gmailClient.RenameLabel(labelID, newName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to rename label: %w", err)
+		return nil, ClassifyError("rename label", err)
 	}
 
 	return label, nil
@@ -82,7 +136,7 @@ func (s *LabelServiceImpl) DeleteLabel(ctx context.Context, labelID string) erro
 	}
 
 	if err := s.gmailClient.DeleteLabel(labelID); err != nil {
-		return fmt.Errorf("failed to delete label: %w", err)
+		return ClassifyError("delete label", err)
 	}
 
 	return nil
@@ -111,7 +165,7 @@ func (s *LabelServiceImpl) ApplyLabel(ctx context.Context, messageID, labelID st
 	}
 
 	if err := s.gmailClient.ApplyLabel(messageID, labelID); err != nil {
-		return fmt.Errorf("failed to apply label: %w", err)
+		return ClassifyError("apply label", err)
 	}
 
 	return nil
@@ -140,7 +194,7 @@ func (s *LabelServiceImpl) RemoveLabel(ctx context.Context, messageID, labelID s
 	}
 
 	if err := s.gmailClient.RemoveLabel(messageID, labelID); err != nil {
-		return fmt.Errorf("failed to remove label: %w", err)
+		return ClassifyError("remove label", err)
 	}
 
 	return nil
@@ -153,7 +207,7 @@ func (s *LabelServiceImpl) GetMessageLabels(ctx context.Context, messageID strin
 
 	message, err := s.gmailClient.GetMessage(messageID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get message: %w", err)
+		return nil, ClassifyError("get message", err)
 	}
 
 	return s.gmailClient.ExtractLabels(message), nil
@@ -185,6 +239,11 @@ func (s *LabelServiceImpl) BulkApplyLabel(ctx context.Context, messageIDs []stri
 		}
 	}
 
+	if s.batch != nil {
+		failed := runBatchModify(ctx, s.batch, messageIDs, []string{labelID}, nil, onProgress)
+		return batchErrors("apply label", "apply label to", failed)
+	}
+
 	// Apply label to all messages using Gmail client directly (to avoid double undo recording)
 	var errs []string
 	for i, messageID := range messageIDs {
@@ -227,6 +286,11 @@ func (s *LabelServiceImpl) BulkRemoveLabel(ctx context.Context, messageIDs []str
 		}
 	}
 
+	if s.batch != nil {
+		failed := runBatchModify(ctx, s.batch, messageIDs, nil, []string{labelID}, nil)
+		return batchErrors("remove label", "remove label from", failed)
+	}
+
 	// Remove label from all messages using Gmail client directly (to avoid double undo recording)
 	var errs []string
 	for _, messageID := range messageIDs {
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// gmailMessageListHide is Gmail's messageListVisibility value for labels hidden in the message list
+const gmailMessageListHide = "hide"
+
+// LabelVisibilityRules decides which labels the pickers and the message list show, following Gmail's
+// per-label settings and the label_visibility overrides. A nil *LabelVisibilityRules shows everything.
+type LabelVisibilityRules struct {
+	followGmail bool
+	show        map[string]bool
+	hide        map[string]bool
+}
+
+// NewLabelVisibilityRules builds the visibility rules from the config
+func NewLabelVisibilityRules(cfg config.LabelVisibilityConfig) *LabelVisibilityRules {
+	v := &LabelVisibilityRules{followGmail: cfg.FollowGmail, show: make(map[string]bool), hide: make(map[string]bool)}
+	for _, n := range cfg.Show {
+		if n = strings.TrimSpace(n); n != "" {
+			v.show[strings.ToLower(n)] = true
+		}
+	}
+	for _, n := range cfg.Hide {
+		if n = strings.TrimSpace(n); n != "" {
+			v.hide[strings.ToLower(n)] = true
+		}
+	}
+	return v
+}
+
+// InPickers reports whether a label is offered in the label pickers. Gmail's settings only apply
+// to user labels: system labels carry flags of their own that the pickers already handle.
+func (v *LabelVisibilityRules) InPickers(l *gmail_v1.Label) bool {
+	return v.visible(l, l != nil && l.LabelListVisibility == string(LabelVisibilityHide))
+}
+
+// InMessageList reports whether a label is shown in the message list's label column
+func (v *LabelVisibilityRules) InMessageList(l *gmail_v1.Label) bool {
+	return v.visible(l, l != nil && l.MessageListVisibility == gmailMessageListHide)
+}
+
+func (v *LabelVisibilityRules) visible(l *gmail_v1.Label, hiddenInGmail bool) bool {
+	if v == nil || l == nil {
+		return true
+	}
+	name := strings.ToLower(l.Name)
+	switch {
+	case v.show[name]:
+		return true
+	case v.hide[name]:
+		return false
+	}
+	return !(v.followGmail && l.Type == "user" && hiddenInGmail)
+}
+
+// HiddenInMessageList returns the IDs of the labels the message list should not show
+func (v *LabelVisibilityRules) HiddenInMessageList(labels []*gmail_v1.Label) map[string]bool {
+	hidden := make(map[string]bool)
+	for _, l := range labels {
+		if l != nil && !v.InMessageList(l) {
+			hidden[l.Id] = true
+		}
+	}
+	return hidden
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestLabelVisibility(t *testing.T) {
+	hiddenUser := &gmail_v1.Label{Id: "L1", Name: "Receipts", Type: "user", LabelListVisibility: "labelHide", MessageListVisibility: "hide"}
+	shownUser := &gmail_v1.Label{Id: "L2", Name: "Work", Type: "user", LabelListVisibility: "labelShowIfUnread", MessageListVisibility: "show"}
+	system := &gmail_v1.Label{Id: "INBOX", Name: "INBOX", Type: "system", LabelListVisibility: "labelHide", MessageListVisibility: "hide"}
+
+	v := NewLabelVisibilityRules(config.LabelVisibilityConfig{FollowGmail: true})
+	assert.False(t, v.InPickers(hiddenUser))
+	assert.False(t, v.InMessageList(hiddenUser))
+	assert.True(t, v.InPickers(shownUser))
+	assert.True(t, v.InMessageList(shownUser))
+	// Gmail's flags on system labels are left to the existing system label handling
+	assert.True(t, v.InPickers(system))
+	assert.Equal(t, map[string]bool{"L1": true}, v.HiddenInMessageList([]*gmail_v1.Label{hiddenUser, shownUser, system}))
+
+	// Overrides win over Gmail and apply to any label
+	v = NewLabelVisibilityRules(config.LabelVisibilityConfig{FollowGmail: true, Show: []string{"receipts"}, Hide: []string{" WORK ", "inbox"}})
+	assert.True(t, v.InPickers(hiddenUser))
+	assert.False(t, v.InMessageList(shownUser))
+	assert.False(t, v.InPickers(system))
+
+	// Not following Gmail shows everything not hidden explicitly
+	v = NewLabelVisibilityRules(config.LabelVisibilityConfig{})
+	assert.True(t, v.InPickers(hiddenUser))
+
+	var none *LabelVisibilityRules
+	assert.True(t, none.InMessageList(hiddenUser))
+}
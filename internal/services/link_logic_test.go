@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/ajramos/giztui/internal/config"
 	"github.com/ajramos/giztui/internal/gmail"
 )
 
@@ -89,3 +92,100 @@ func TestLinkService_GetMessageLinks(t *testing.T) {
 		t.Fatalf("link extraction wrong: %+v", links)
 	}
 }
+
+func TestLinkService_FetchLinkPreview(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, "/page", http.StatusFound)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head><title>
+				Quarterly   report</title>
+				<meta property="og:description" content="og fallback">
+				<meta name="description" content="Numbers for Q3">
+				</head><body><title>ignored</title></body></html>`))
+		case "/og":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<head><meta property="og:title" content="OG Title"><meta property="og:description" content="OG Desc"></head>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc := NewLinkService(&mockLinkClient{}, nil)
+	ctx := context.Background()
+
+	p, err := svc.FetchLinkPreview(ctx, srv.URL+"/short")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Title != "Quarterly report" || p.Description != "Numbers for Q3" {
+		t.Errorf("preview metadata wrong: %+v", p)
+	}
+	if p.FinalURL != srv.URL+"/page" {
+		t.Errorf("FinalURL should follow the redirect, got %q", p.FinalURL)
+	}
+
+	// Second call is served from the cache
+	before := hits
+	if _, err := svc.FetchLinkPreview(ctx, srv.URL+"/short"); err != nil {
+		t.Fatalf("unexpected error on cached call: %v", err)
+	}
+	if hits != before {
+		t.Errorf("cached preview should not hit the server again")
+	}
+
+	// OpenGraph tags are used when plain ones are missing
+	p, err = svc.FetchLinkPreview(ctx, srv.URL+"/og")
+	if err != nil || p.Title != "OG Title" || p.Description != "OG Desc" {
+		t.Errorf("og fallback wrong: %+v err=%v", p, err)
+	}
+
+	if _, err := svc.FetchLinkPreview(ctx, srv.URL+"/missing"); err == nil {
+		t.Error("404 should be reported as an error")
+	}
+	if _, err := svc.FetchLinkPreview(ctx, "mailto:a@x.com"); err == nil {
+		t.Error("non-web links should not be fetched")
+	}
+}
+
+func TestLinkService_PreviewToggle(t *testing.T) {
+	svc := NewLinkService(&mockLinkClient{}, nil)
+	if svc.IsPreviewEnabled() {
+		t.Fatal("previews must be off by default (privacy)")
+	}
+	svc.SetPreviewConfig(config.LinksConfig{PreviewEnabled: true, PreviewTimeout: "2s"})
+	if !svc.IsPreviewEnabled() {
+		t.Error("config should enable previews")
+	}
+	svc.SetPreviewEnabled(false)
+	if svc.IsPreviewEnabled() {
+		t.Error("runtime toggle should disable previews")
+	}
+}
+
+func TestLinkService_LinksFromMarkdown(t *testing.T) {
+	svc := NewLinkService(&mockLinkClient{}, nil)
+	md := "## Next steps\n\n- Review [the PR](https://github.com/o/r/pull/7)\n" +
+		"- Dashboard: https://grafana.example.com/d/1.\n" +
+		"- Same PR again: https://github.com/o/r/pull/7\n"
+	links := svc.LinksFromMarkdown(md)
+	if len(links) != 2 {
+		t.Fatalf("want 2 links, got %+v", links)
+	}
+	if links[0].URL != "https://github.com/o/r/pull/7" || links[0].Text != "the PR" || links[0].Index != 1 {
+		t.Errorf("markdown link wrong: %+v", links[0])
+	}
+	if links[1].URL != "https://grafana.example.com/d/1" || links[1].Index != 2 {
+		t.Errorf("bare URL wrong (trailing period must go): %+v", links[1])
+	}
+	for _, l := range links {
+		if l.Type != "ai" {
+			t.Errorf("AI links should be typed ai: %+v", l)
+		}
+	}
+}
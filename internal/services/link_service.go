@@ -3,16 +3,32 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"os/exec"
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/ajramos/giztui/internal/config"
 	"github.com/ajramos/giztui/internal/gmail"
 	"github.com/ajramos/giztui/internal/render"
+	"golang.org/x/net/html"
 )
 
+// linkPreviewMaxBytes caps how much of a page is read while looking for <head> metadata.
+const linkPreviewMaxBytes = 512 * 1024
+
+// linkPreviewEntry is a cached preview result; failures are cached too so a dead link
+// is not re-fetched every time the picker opens.
+type linkPreviewEntry struct {
+	preview *LinkPreview
+	err     error
+}
+
 // LinkClient is the subset of *gmail.Client that LinkService depends on (satisfied by *gmail.Client).
 type LinkClient interface {
 	GetMessageWithContent(id string) (*gmail.Message, error)
@@ -22,14 +38,168 @@ type LinkClient interface {
 type LinkServiceImpl struct {
 	gmailClient   LinkClient
 	emailRenderer *render.EmailRenderer
+	httpClient    *http.Client
+
+	previewMu      sync.RWMutex
+	previewEnabled bool
+	previewTimeout time.Duration
+	previewCache   map[string]linkPreviewEntry
 }
 
 // NewLinkService creates a new link service
 func NewLinkService(gmailClient LinkClient, emailRenderer *render.EmailRenderer) *LinkServiceImpl {
 	return &LinkServiceImpl{
-		gmailClient:   gmailClient,
-		emailRenderer: emailRenderer,
+		gmailClient:    gmailClient,
+		emailRenderer:  emailRenderer,
+		httpClient:     &http.Client{},
+		previewTimeout: config.DefaultLinksConfig().ResolvedPreviewTimeout(),
+		previewCache:   make(map[string]linkPreviewEntry),
+	}
+}
+
+// SetPreviewConfig applies the link preview settings from config
+func (s *LinkServiceImpl) SetPreviewConfig(cfg config.LinksConfig) {
+	s.previewMu.Lock()
+	defer s.previewMu.Unlock()
+	s.previewEnabled = cfg.PreviewEnabled
+	s.previewTimeout = cfg.ResolvedPreviewTimeout()
+}
+
+// IsPreviewEnabled reports whether background link previews are allowed
+func (s *LinkServiceImpl) IsPreviewEnabled() bool {
+	s.previewMu.RLock()
+	defer s.previewMu.RUnlock()
+	return s.previewEnabled
+}
+
+// SetPreviewEnabled toggles background link previews for this session
+func (s *LinkServiceImpl) SetPreviewEnabled(enabled bool) {
+	s.previewMu.Lock()
+	defer s.previewMu.Unlock()
+	s.previewEnabled = enabled
+}
+
+// FetchLinkPreview fetches the title and description of a web page
+func (s *LinkServiceImpl) FetchLinkPreview(ctx context.Context, rawURL string) (*LinkPreview, error) {
+	parsedURL, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL format: %w", err)
+	}
+	if scheme := strings.ToLower(parsedURL.Scheme); scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("previews are only available for web links")
+	}
+
+	s.previewMu.RLock()
+	entry, cached := s.previewCache[rawURL]
+	timeout := s.previewTimeout
+	s.previewMu.RUnlock()
+	if cached {
+		return entry.preview, entry.err
+	}
+
+	preview, err := s.fetchPreview(ctx, rawURL, timeout)
+	// Don't cache cancellations: the picker was closed, not the link broken.
+	if ctx.Err() == nil {
+		s.previewMu.Lock()
+		s.previewCache[rawURL] = linkPreviewEntry{preview: preview, err: err}
+		s.previewMu.Unlock()
+	}
+	return preview, err
+}
+
+// fetchPreview performs the HTTP request and extracts head metadata
+func (s *LinkServiceImpl) fetchPreview(ctx context.Context, rawURL string, timeout time.Duration) (*LinkPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("page returned status %d", resp.StatusCode)
+	}
+
+	preview := &LinkPreview{URL: rawURL, FinalURL: resp.Request.URL.String()}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(strings.ToLower(ct), "html") {
+		// Not a web page (PDF, image...): the final URL is still useful on its own.
+		return preview, nil
 	}
+	preview.Title, preview.Description = extractHeadMetadata(io.LimitReader(resp.Body, linkPreviewMaxBytes))
+	return preview, nil
+}
+
+// extractHeadMetadata scans an HTML document for its title and description, preferring
+// the plain <title>/description over their OpenGraph equivalents. Stops at <body>.
+func extractHeadMetadata(r io.Reader) (title, description string) {
+	var ogTitle, ogDescription string
+	z := html.NewTokenizer(r)
+	inTitle := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return firstNonEmpty(title, ogTitle), firstNonEmpty(description, ogDescription)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "title":
+				inTitle = true
+			case "body":
+				return firstNonEmpty(title, ogTitle), firstNonEmpty(description, ogDescription)
+			case "meta":
+				var key, content string
+				for _, attr := range tok.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "name", "property":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = collapseWhitespace(attr.Val)
+					}
+				}
+				switch key {
+				case "description":
+					description = content
+				case "og:title":
+					ogTitle = content
+				case "og:description":
+					ogDescription = content
+				}
+			}
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = collapseWhitespace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			if tok := z.Token(); tok.Data == "title" {
+				inTitle = false
+			} else if tok.Data == "head" {
+				return firstNonEmpty(title, ogTitle), firstNonEmpty(description, ogDescription)
+			}
+		}
+	}
+}
+
+// collapseWhitespace trims s and folds internal runs of whitespace into single spaces
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
 // GetMessageLinks extracts all links from a message
@@ -44,6 +214,11 @@ func (s *LinkServiceImpl) GetMessageLinks(ctx context.Context, messageID string)
 		return nil, fmt.Errorf("failed to get message content: %w", err)
 	}
 
+	return s.LinksFromMessage(message), nil
+}
+
+// LinksFromMessage extracts the links of an already loaded message
+func (s *LinkServiceImpl) LinksFromMessage(message *gmail.Message) []LinkInfo {
 	// Extract links using the existing render functionality
 	links := s.extractLinksFromMessage(message)
 
@@ -59,7 +234,32 @@ func (s *LinkServiceImpl) GetMessageLinks(ctx context.Context, messageID string)
 		linkInfos = append(linkInfos, linkInfo)
 	}
 
-	return linkInfos, nil
+	return linkInfos
+}
+
+// markdownLinkRegex matches [text](url) Markdown links
+var markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// LinksFromMarkdown extracts the links of Markdown text such as an AI summary: [text](url) links
+// first, then bare URLs, each once, typed "ai"
+func (s *LinkServiceImpl) LinksFromMarkdown(md string) []LinkInfo {
+	var infos []LinkInfo
+	seen := make(map[string]bool)
+	add := func(url, text string) {
+		url = strings.TrimRight(url, ".,;:")
+		if url == "" || seen[url] || s.ValidateURL(url) != nil {
+			return
+		}
+		seen[url] = true
+		infos = append(infos, LinkInfo{Index: len(infos) + 1, URL: url, Text: text, Type: "ai"})
+	}
+	for _, m := range markdownLinkRegex.FindAllStringSubmatch(md, -1) {
+		add(m[2], strings.TrimSpace(m[1]))
+	}
+	for _, link := range s.extractLinksFromPlainText(markdownLinkRegex.ReplaceAllString(md, "$1")) {
+		add(strings.TrimRight(link.URL, ")"), link.URL)
+	}
+	return infos
 }
 
 // OpenLink opens a URL using the system default browser
@@ -130,7 +330,6 @@ func (s *LinkServiceImpl) extractLinksFromMessage(message *gmail.Message) []rend
 
 	// Try HTML content first
 	if strings.TrimSpace(message.HTML) != "" {
-		// Use similar logic to render.renderHTMLToText but focused on link extraction
 		htmlLinks := s.extractLinksFromHTML(message.HTML)
 		links = append(links, htmlLinks...)
 	}
@@ -144,30 +343,10 @@ func (s *LinkServiceImpl) extractLinksFromMessage(message *gmail.Message) []rend
 	return links
 }
 
-// extractLinksFromHTML extracts links from HTML content
+// extractLinksFromHTML extracts links from HTML content, numbered like the footnote markers
+// the reader shows
 func (s *LinkServiceImpl) extractLinksFromHTML(htmlContent string) []render.LinkRef {
-	var links []render.LinkRef
-
-	// Use regex to find href attributes (simplified approach)
-	// In a full implementation, you'd want to use an HTML parser
-	hrefRegex := regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["'][^>]*>([^<]+)</a>`)
-	matches := hrefRegex.FindAllStringSubmatch(htmlContent, -1)
-
-	for i, match := range matches {
-		if len(match) >= 3 {
-			url := strings.TrimSpace(match[1])
-			text := strings.TrimSpace(match[2])
-			if url != "" {
-				links = append(links, render.LinkRef{
-					Index: i + 1,
-					URL:   url,
-					Text:  text,
-				})
-			}
-		}
-	}
-
-	return links
+	return render.ExtractHTMLLinks(htmlContent)
 }
 
 // extractLinksFromPlainText extracts URLs from plain text
@@ -179,10 +358,10 @@ func (s *LinkServiceImpl) extractLinksFromPlainText(plainText string) []render.L
 	var links []render.LinkRef
 	seen := make(map[string]bool)
 
-	for i, match := range matches {
+	for _, match := range matches {
 		if !seen[match] {
 			links = append(links, render.LinkRef{
-				Index: i + 1,
+				Index: len(links) + 1,
 				URL:   match,
 				Text:  match, // For plain text, URL is both the link and the text
 			})
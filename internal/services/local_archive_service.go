@@ -0,0 +1,289 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/gmail"
+)
+
+// LocalArchiveClient is the subset of *gmail.Client the local archive depends on
+type LocalArchiveClient interface {
+	GetMessageRaw(id string) ([]byte, error)
+	GetMessageWithContent(id string) (*gmail.Message, error)
+}
+
+// LocalArchiveServiceImpl implements LocalArchiveService. Messages are appended to a per-account
+// mbox file (mboxrd quoting, so other mail clients can import it) and indexed in the FTS table.
+type LocalArchiveServiceImpl struct {
+	store        *db.LocalArchiveStore
+	client       LocalArchiveClient
+	trash        func(ctx context.Context, messageID string) error
+	dir          string
+	accountEmail string
+	mu           sync.RWMutex
+	writeMu      sync.Mutex // serializes mbox appends so offsets stay exact
+}
+
+// NewLocalArchiveService creates the local archive. trash removes a message from Gmail once its
+// local copy is safe (EmailService.TrashMessage, so the removal can be undone).
+func NewLocalArchiveService(store *db.LocalArchiveStore, client LocalArchiveClient, trash func(ctx context.Context, messageID string) error, dir string) *LocalArchiveServiceImpl {
+	return &LocalArchiveServiceImpl{store: store, client: client, trash: trash, dir: dir}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *LocalArchiveServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *LocalArchiveServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("local archive store not available")
+	}
+	return email, nil
+}
+
+// MboxPath returns the mbox file holding the active account's archive
+func (s *LocalArchiveServiceImpl) MboxPath() string {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(strings.TrimSpace(email))
+	if name == "" {
+		name = "archive"
+	}
+	return filepath.Join(s.dir, name+".mbox")
+}
+
+// ArchiveLocally exports a message to the local archive and then moves it to Gmail's trash. A
+// message already archived (e.g. the trash failed last time) is only trashed again.
+func (s *LocalArchiveServiceImpl) ArchiveLocally(ctx context.Context, messageID string) error {
+	if messageID == "" {
+		return fmt.Errorf("messageID cannot be empty")
+	}
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	if s.client == nil || s.trash == nil {
+		return fmt.Errorf("gmail client not available")
+	}
+
+	archived, err := s.store.Has(ctx, email, messageID)
+	if err != nil {
+		return err
+	}
+	if !archived {
+		if err := s.export(ctx, email, messageID); err != nil {
+			return err
+		}
+	}
+	if err := s.trash(ctx, messageID); err != nil {
+		return fmt.Errorf("saved locally but not removed from Gmail: %w", err)
+	}
+	return nil
+}
+
+// export appends the raw message to the mbox and indexes it
+func (s *LocalArchiveServiceImpl) export(ctx context.Context, email, messageID string) error {
+	raw, err := s.client.GetMessageRaw(messageID)
+	if err != nil {
+		return ClassifyError("get raw message", err)
+	}
+	msg, err := s.client.GetMessageWithContent(messageID)
+	if err != nil {
+		return ClassifyError("get message content", err)
+	}
+
+	path := s.MboxPath()
+	offset, length, err := s.appendMbox(path, msg.From, raw)
+	if err != nil {
+		return err
+	}
+
+	body := msg.PlainText
+	if strings.TrimSpace(body) == "" && msg.Message != nil {
+		body = msg.Snippet
+	}
+	entry := &db.LocalArchiveEntry{
+		AccountEmail: email,
+		MessageID:    messageID,
+		Subject:      msg.Subject,
+		From:         msg.From,
+		To:           msg.To,
+		Labels:       strings.Join(msg.Labels, ","),
+		MboxPath:     path,
+		MboxOffset:   offset,
+		MboxLength:   length,
+	}
+	if msg.Message != nil {
+		entry.ThreadID = msg.ThreadId
+	}
+	if !msg.Date.IsZero() {
+		entry.SentAt = msg.Date.Unix()
+	}
+	_, err = s.store.Add(ctx, entry, body)
+	return err
+}
+
+// appendMbox writes one mboxrd record and returns where it starts and how long it is
+func (s *LocalArchiveServiceImpl) appendMbox(path, from string, raw []byte) (int64, int64, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, 0, fmt.Errorf("failed to create archive folder: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	record := mboxRecord(from, raw, time.Now())
+	if _, err := f.Write(record); err != nil {
+		return 0, 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return 0, 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return info.Size(), int64(len(record)), nil
+}
+
+// mboxFromLine matches body lines that mboxrd quotes with an extra '>'
+var mboxFromLine = regexp.MustCompile(`^>*From `)
+
+// mboxRecord renders raw as an mboxrd record: a "From " separator line, the message with LF line
+// endings and quoted "From " lines, and a blank line
+func mboxRecord(from string, raw []byte, at time.Time) []byte {
+	sender := "MAILER-DAEMON"
+	if addr, err := mail.ParseAddress(from); err == nil && addr.Address != "" {
+		sender = addr.Address
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From %s %s\n", sender, at.UTC().Format(time.ANSIC))
+	text := strings.ReplaceAll(string(raw), "\r\n", "\n")
+	text = strings.TrimRight(text, "\n")
+	for _, line := range strings.Split(text, "\n") {
+		if mboxFromLine.MatchString(line) {
+			b.WriteByte('>')
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	return b.Bytes()
+}
+
+// parseMboxRecord reverses mboxRecord, returning the message without the separator line
+func parseMboxRecord(record []byte) ([]byte, error) {
+	sc := bufio.NewScanner(bytes.NewReader(record))
+	sc.Buffer(make([]byte, 0, 64*1024), len(record)+1)
+	if !sc.Scan() || !strings.HasPrefix(sc.Text(), "From ") {
+		return nil, fmt.Errorf("not an mbox record")
+	}
+	var lines []string
+	for sc.Scan() {
+		line := sc.Text()
+		if mboxFromLine.MatchString(line) && strings.HasPrefix(line, ">") {
+			line = line[1:]
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	// Drop the record's trailing blank separator line
+	if n := len(lines); n > 0 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func localArchiveInfo(e *db.LocalArchiveEntry) *LocalArchiveInfo {
+	info := &LocalArchiveInfo{
+		ID:         e.ID,
+		MessageID:  e.MessageID,
+		ThreadID:   e.ThreadID,
+		Subject:    e.Subject,
+		From:       e.From,
+		To:         e.To,
+		MboxPath:   e.MboxPath,
+		ArchivedAt: time.Unix(e.ArchivedAt, 0),
+		offset:     e.MboxOffset,
+		length:     e.MboxLength,
+	}
+	if e.SentAt > 0 {
+		info.Date = time.Unix(e.SentAt, 0)
+	}
+	if e.Labels != "" {
+		info.Labels = strings.Split(e.Labels, ",")
+	}
+	return info
+}
+
+// Search finds archived messages by words in the subject, sender, recipients or body; empty
+// terms list the most recently archived ones
+func (s *LocalArchiveServiceImpl) Search(ctx context.Context, terms string, limit int) ([]*LocalArchiveInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.store.Search(ctx, email, terms, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*LocalArchiveInfo, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, localArchiveInfo(e))
+	}
+	return out, nil
+}
+
+// Body returns the plain-text body indexed for an archived message
+func (s *LocalArchiveServiceImpl) Body(ctx context.Context, id int64) (string, error) {
+	email, err := s.account()
+	if err != nil {
+		return "", err
+	}
+	return s.store.Body(ctx, email, id)
+}
+
+// Raw reads an archived message back from its mbox file
+func (s *LocalArchiveServiceImpl) Raw(ctx context.Context, info *LocalArchiveInfo) ([]byte, error) {
+	if info == nil || info.length <= 0 {
+		return nil, fmt.Errorf("archived message not found")
+	}
+	f, err := os.Open(info.MboxPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	record := make([]byte, info.length)
+	if _, err := f.ReadAt(record, info.offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	return parseMboxRecord(record)
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type fakeArchiveClient struct {
+	raw map[string]string
+}
+
+func (f *fakeArchiveClient) GetMessageRaw(id string) ([]byte, error) {
+	raw, ok := f.raw[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(raw), nil
+}
+
+func (f *fakeArchiveClient) GetMessageWithContent(id string) (*gmail.Message, error) {
+	body := f.raw[id][strings.Index(f.raw[id], "\r\n\r\n")+4:]
+	return &gmail.Message{
+		Message:   &gmail_v1.Message{Id: id, ThreadId: "t-" + id},
+		Subject:   "Invoice " + id,
+		From:      "Billing <billing@vendor.example>",
+		To:        "me@example.com",
+		Date:      time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC),
+		Labels:    []string{"Finance"},
+		PlainText: body,
+	}, nil
+}
+
+func newTestLocalArchive(t *testing.T, trash func(ctx context.Context, id string) error) (*LocalArchiveServiceImpl, *fakeArchiveClient) {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := db.Open(context.Background(), dir+"/archive.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	client := &fakeArchiveClient{raw: map[string]string{
+		"m1": "From: Billing <billing@vendor.example>\r\nSubject: Invoice m1\r\n\r\nAmount due: 42 EUR\r\nFrom now on, pay by card.\r\n>From the archive\r\n",
+		"m2": "From: Billing <billing@vendor.example>\r\nSubject: Invoice m2\r\n\r\nReceipt attached\r\n",
+	}}
+	svc := NewLocalArchiveService(db.NewLocalArchiveStore(store), client, trash, dir+"/archive")
+	svc.SetAccountEmail("me@example.com")
+	return svc, client
+}
+
+func TestLocalArchive_ExportsIndexesThenTrashes(t *testing.T) {
+	ctx := context.Background()
+	var trashed []string
+	svc, client := newTestLocalArchive(t, func(ctx context.Context, id string) error {
+		trashed = append(trashed, id)
+		return nil
+	})
+
+	require.NoError(t, svc.ArchiveLocally(ctx, "m1"))
+	require.NoError(t, svc.ArchiveLocally(ctx, "m2"))
+	assert.Equal(t, []string{"m1", "m2"}, trashed)
+
+	data, err := os.ReadFile(svc.MboxPath())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "From billing@vendor.example "))
+	assert.Contains(t, string(data), "\n>From now on")
+	assert.Contains(t, string(data), "\n>>From the archive")
+
+	found, err := svc.Search(ctx, "card", 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "m1", found[0].MessageID)
+	assert.Equal(t, "t-m1", found[0].ThreadID)
+	assert.Equal(t, []string{"Finance"}, found[0].Labels)
+
+	// The mbox copy reads back byte-for-byte (modulo line endings)
+	raw, err := svc.Raw(ctx, found[0])
+	require.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(client.raw["m1"], "\r\n", "\n"), string(raw))
+
+	body, err := svc.Body(ctx, found[0].ID)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Amount due")
+}
+
+func TestLocalArchive_TrashFailureKeepsLocalCopyAndRetries(t *testing.T) {
+	ctx := context.Background()
+	fail := true
+	trashes := 0
+	svc, _ := newTestLocalArchive(t, func(ctx context.Context, id string) error {
+		trashes++
+		if fail {
+			return &NetworkError{Op: "trash message", Err: errors.New("connection reset")}
+		}
+		return nil
+	})
+
+	err := svc.ArchiveLocally(ctx, "m2")
+	assert.ErrorIs(t, err, ErrNetworkUnavailable)
+	assert.Contains(t, err.Error(), "saved locally")
+
+	// Retrying only trashes: the message is not exported twice
+	fail = false
+	require.NoError(t, svc.ArchiveLocally(ctx, "m2"))
+	assert.Equal(t, 2, trashes)
+	all, err := svc.Search(ctx, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	// A message Gmail cannot export is neither indexed nor trashed
+	assert.Error(t, svc.ArchiveLocally(ctx, "missing"))
+	assert.Equal(t, 2, trashes)
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// LocalQuery is a Gmail-style search query evaluated against message metadata without asking
+// Gmail. It understands the common operators plus extensions Gmail filters cannot express:
+// /regex/ values and hour-level ages. Terms are ANDed; OR joins adjacent terms; '-' negates.
+type LocalQuery struct {
+	clauses [][]localTerm // AND of ORs
+}
+
+// localTerm is one (possibly negated) condition
+type localTerm struct {
+	neg   bool
+	match func(m *localMessage) bool
+}
+
+// localMessage is the searchable view of a message's metadata
+type localMessage struct {
+	from, to, cc, subject, snippet string // lower-cased
+	labels                         map[string]bool
+	size                           int64
+	date                           time.Time
+	now                            time.Time
+}
+
+// ParseLocalQuery compiles q. Operators that cannot be evaluated from metadata (has:attachment,
+// filename:, …) are rejected so a smart label never silently matches the wrong messages.
+func ParseLocalQuery(q string) (*LocalQuery, error) {
+	tokens, err := tokenizeLocalQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	lq := &LocalQuery{}
+	orNext := false
+	for _, tok := range tokens {
+		if tok == "OR" {
+			if len(lq.clauses) == 0 {
+				return nil, fmt.Errorf("OR needs a term on each side")
+			}
+			orNext = true
+			continue
+		}
+		term, err := parseLocalTerm(tok)
+		if err != nil {
+			return nil, err
+		}
+		if orNext {
+			last := len(lq.clauses) - 1
+			lq.clauses[last] = append(lq.clauses[last], term)
+			orNext = false
+		} else {
+			lq.clauses = append(lq.clauses, []localTerm{term})
+		}
+	}
+	if orNext {
+		return nil, fmt.Errorf("OR needs a term on each side")
+	}
+	if len(lq.clauses) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return lq, nil
+}
+
+// Match reports whether a message matches. labelNames maps label IDs to names so label: terms
+// work with user labels; now anchors older_than/newer_than.
+func (q *LocalQuery) Match(m *gmail_v1.Message, labelNames map[string]string, now time.Time) bool {
+	if q == nil || m == nil {
+		return false
+	}
+	view := newLocalMessage(m, labelNames, now)
+	for _, clause := range q.clauses {
+		ok := false
+		for _, t := range clause {
+			if t.match(view) != t.neg {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func newLocalMessage(m *gmail_v1.Message, labelNames map[string]string, now time.Time) *localMessage {
+	v := &localMessage{
+		snippet: strings.ToLower(m.Snippet),
+		labels:  make(map[string]bool, len(m.LabelIds)*2),
+		size:    m.SizeEstimate,
+		now:     now,
+	}
+	if m.InternalDate > 0 {
+		v.date = time.UnixMilli(m.InternalDate)
+	}
+	if m.Payload != nil {
+		for _, h := range m.Payload.Headers {
+			switch strings.ToLower(h.Name) {
+			case "from":
+				v.from = strings.ToLower(h.Value)
+			case "to":
+				v.to = strings.ToLower(h.Value)
+			case "cc":
+				v.cc = strings.ToLower(h.Value)
+			case "subject":
+				v.subject = strings.ToLower(h.Value)
+			}
+		}
+	}
+	for _, id := range m.LabelIds {
+		v.labels[strings.ToLower(id)] = true
+		if name, ok := labelNames[id]; ok {
+			v.labels[normalizeLabelName(name)] = true
+		}
+	}
+	return v
+}
+
+// normalizeLabelName folds the separators Gmail treats as equivalent in label: searches
+func normalizeLabelName(name string) string {
+	return strings.NewReplacer(" ", "-", "/", "-").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// tokenizeLocalQuery splits q on whitespace, keeping "quoted phrases" and /regex/ values whole
+func tokenizeLocalQuery(q string) ([]string, error) {
+	rs := []rune(q)
+	var tokens []string
+	for i := 0; i < len(rs); {
+		if unicode.IsSpace(rs[i]) {
+			i++
+			continue
+		}
+		start := i
+		if rs[i] == '-' {
+			i++
+		}
+		// Optional operator prefix
+		j := i
+		for j < len(rs) && (unicode.IsLetter(rs[j]) || rs[j] == '_') {
+			j++
+		}
+		if j < len(rs) && rs[j] == ':' {
+			i = j + 1
+		}
+		switch {
+		case i < len(rs) && rs[i] == '"':
+			end := i + 1
+			for end < len(rs) && rs[end] != '"' {
+				end++
+			}
+			if end >= len(rs) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			i = end + 1
+		case i < len(rs) && rs[i] == '/':
+			end := i + 1
+			for end < len(rs) && rs[end] != '/' {
+				if rs[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(rs) {
+				return nil, fmt.Errorf("unterminated /regex/")
+			}
+			i = end + 1
+		default:
+			for i < len(rs) && !unicode.IsSpace(rs[i]) {
+				i++
+			}
+		}
+		tokens = append(tokens, string(rs[start:i]))
+	}
+	return tokens, nil
+}
+
+// textMatcher returns a matcher for a plain, quoted or /regex/ value (case-insensitive)
+func textMatcher(value string) (func(s string) bool, error) {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		re, err := regexp.Compile("(?i)" + value[1:len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %s: %w", value, err)
+		}
+		return re.MatchString, nil
+	}
+	needle := strings.ToLower(strings.Trim(value, `"`))
+	if needle == "" {
+		return nil, fmt.Errorf("empty search value")
+	}
+	return func(s string) bool { return strings.Contains(s, needle) }, nil
+}
+
+func parseLocalTerm(tok string) (localTerm, error) {
+	t := localTerm{}
+	if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+		t.neg = true
+		tok = tok[1:]
+	}
+	op, value := "", tok
+	if i := strings.Index(tok, ":"); i > 0 && !strings.HasPrefix(tok, `"`) && !strings.HasPrefix(tok, "/") {
+		op, value = strings.ToLower(tok[:i]), tok[i+1:]
+	}
+
+	var err error
+	switch op {
+	case "":
+		var match func(string) bool
+		if match, err = textMatcher(value); err == nil {
+			t.match = func(m *localMessage) bool {
+				return match(m.subject) || match(m.from) || match(m.to) || match(m.snippet)
+			}
+		}
+	case "from", "to", "cc", "subject":
+		var match func(string) bool
+		if match, err = textMatcher(value); err == nil {
+			field := map[string]func(m *localMessage) string{
+				"from":    func(m *localMessage) string { return m.from },
+				"to":      func(m *localMessage) string { return m.to },
+				"cc":      func(m *localMessage) string { return m.cc },
+				"subject": func(m *localMessage) string { return m.subject },
+			}[op]
+			t.match = func(m *localMessage) bool { return match(field(m)) }
+		}
+	case "label":
+		name := normalizeLabelName(strings.Trim(value, `"`))
+		t.match = func(m *localMessage) bool { return m.labels[name] }
+	case "is", "in":
+		t.match, err = stateMatcher(op, strings.ToLower(value))
+	case "larger", "smaller":
+		var n int64
+		if n, err = parseLocalSize(value); err == nil {
+			if op == "larger" {
+				t.match = func(m *localMessage) bool { return m.size > n }
+			} else {
+				t.match = func(m *localMessage) bool { return m.size < n }
+			}
+		}
+	case "older_than", "newer_than":
+		var d time.Duration
+		if d, err = parseLocalAge(value); err == nil {
+			if op == "older_than" {
+				t.match = func(m *localMessage) bool { return !m.date.IsZero() && m.now.Sub(m.date) > d }
+			} else {
+				t.match = func(m *localMessage) bool { return !m.date.IsZero() && m.now.Sub(m.date) <= d }
+			}
+		}
+	case "after", "before":
+		var day time.Time
+		if day, err = parseLocalDate(value); err == nil {
+			if op == "after" {
+				t.match = func(m *localMessage) bool { return !m.date.Before(day) }
+			} else {
+				t.match = func(m *localMessage) bool { return m.date.Before(day) }
+			}
+		}
+	default:
+		err = fmt.Errorf("%s: is not supported in local queries", op)
+	}
+	return t, err
+}
+
+// stateMatcher handles is:/in: against system labels
+func stateMatcher(op, value string) (func(m *localMessage) bool, error) {
+	has := func(label string) func(m *localMessage) bool {
+		return func(m *localMessage) bool { return m.labels[label] }
+	}
+	switch value {
+	case "unread", "starred", "important", "inbox", "sent", "trash", "spam", "draft":
+		return has(value), nil
+	case "read":
+		return func(m *localMessage) bool { return !m.labels["unread"] }, nil
+	case "drafts":
+		return has("draft"), nil
+	case "anywhere":
+		return func(m *localMessage) bool { return true }, nil
+	}
+	return nil, fmt.Errorf("%s:%s is not supported in local queries", op, value)
+}
+
+// parseLocalSize parses Gmail sizes: bytes, or a K/M suffix
+func parseLocalSize(v string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(v))
+	mult := int64(1)
+	switch {
+	case strings.HasSuffix(s, "K"):
+		mult, s = 1024, strings.TrimSuffix(s, "K")
+	case strings.HasSuffix(s, "M"):
+		mult, s = 1024*1024, strings.TrimSuffix(s, "M")
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500K or 5M)", v)
+	}
+	return n * mult, nil
+}
+
+// parseLocalAge parses Gmail ages (d, m, y) plus h for hours
+func parseLocalAge(v string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(v))
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 3m or 1y)", v)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 3m or 1y)", v)
+	}
+	day := 24 * time.Hour
+	switch s[len(s)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * day, nil
+	case 'm':
+		return time.Duration(n) * 30 * day, nil
+	case 'y':
+		return time.Duration(n) * 365 * day, nil
+	}
+	return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 3m or 1y)", v)
+}
+
+// parseLocalDate parses after:/before: dates (YYYY/MM/DD or YYYY-MM-DD, local time)
+func parseLocalDate(v string) (time.Time, error) {
+	for _, layout := range []string{"2006/01/02", "2006-01-02", "2006/1/2"} {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY/MM/DD)", v)
+}
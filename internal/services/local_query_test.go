@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func localQueryMessage(from, subject string, size int64, age time.Duration, now time.Time, labels ...string) *gmail_v1.Message {
+	return &gmail_v1.Message{
+		Id:           "m",
+		Snippet:      "Build #42 finished",
+		SizeEstimate: size,
+		InternalDate: now.Add(-age).UnixMilli(),
+		LabelIds:     labels,
+		Payload: &gmail_v1.MessagePart{Headers: []*gmail_v1.MessagePartHeader{
+			{Name: "From", Value: from},
+			{Name: "To", Value: "me@example.com"},
+			{Name: "Subject", Value: subject},
+		}},
+	}
+}
+
+func TestLocalQuery_Match(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	names := map[string]string{"Label_1": "Work/CI"}
+	ci := localQueryMessage("GitHub <noreply@github.com>", "[repo] Run failed: main", 40*1024, 2*time.Hour, now, "INBOX", "UNREAD", "Label_1")
+	big := localQueryMessage("Alice <alice@example.com>", "Holiday photos", 12*1024*1024, 400*24*time.Hour, now, "INBOX")
+
+	cases := []struct {
+		query   string
+		ci, big bool
+	}{
+		{"from:github.com", true, false},
+		{`subject:/run (failed|cancelled)/`, true, false},
+		{`"holiday photos"`, false, true},
+		{"larger:5M older_than:1y", false, true},
+		{"smaller:100K newer_than:3h", true, false},
+		{"newer_than:1h", false, false},
+		{"is:unread label:work-ci", true, false},
+		{"label:Work/CI", true, false},
+		{"in:inbox -from:alice", true, false},
+		{"from:alice OR from:github", true, true},
+		{"build", true, true}, // snippet
+		{"after:2026/01/01 before:2026/12/31", true, false},
+		{"is:read", false, true},
+	}
+	for _, tc := range cases {
+		q, err := ParseLocalQuery(tc.query)
+		require.NoError(t, err, tc.query)
+		assert.Equal(t, tc.ci, q.Match(ci, names, now), "ci: %s", tc.query)
+		assert.Equal(t, tc.big, q.Match(big, names, now), "big: %s", tc.query)
+	}
+}
+
+func TestParseLocalQuery_Errors(t *testing.T) {
+	for _, q := range []string{
+		"",
+		"has:attachment",
+		"OR from:a",
+		"from:a OR",
+		`subject:"unterminated`,
+		"subject:/[/",
+		"larger:lots",
+		"older_than:7w",
+		"after:yesterday",
+		"is:muted",
+	} {
+		_, err := ParseLocalQuery(q)
+		assert.Error(t, err, q)
+	}
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// MergeStatus is the state of one mail merge message
+type MergeStatus string
+
+const (
+	MergePending MergeStatus = "pending" // not sent yet
+	MergeSent    MergeStatus = "sent"    // delivered; skipped when the merge runs again
+	MergeFailed  MergeStatus = "failed"  // rendering or sending failed; retried on the next run
+)
+
+// MergeRecipient is one row of a mail merge CSV: the address and every column as a variable
+type MergeRecipient struct {
+	Row   int               // line in the CSV, for error messages
+	Email string            // the "email" column
+	Vars  map[string]string // every column by lowercased header, email included
+}
+
+// MergeTemplate is the message sent to each recipient; {{column}} is replaced by the row's value
+type MergeTemplate struct {
+	Name    string
+	Subject string
+	Body    string
+}
+
+// MergeItem is one generated message with its status
+type MergeItem struct {
+	Recipient   MergeRecipient
+	Composition *Composition // nil when the template could not be filled
+	RenderError error
+	Status      MergeStatus
+	LastError   string
+}
+
+// MailMergeJob is a template filled for every row of a recipient list. Its key identifies the
+// job across sessions so a rerun skips the rows already sent.
+type MailMergeJob struct {
+	Key      string
+	Template MergeTemplate
+	Items    []*MergeItem
+}
+
+// MergeRunResult summarizes one pass over a job
+type MergeRunResult struct {
+	Sent      int
+	Failed    int
+	Skipped   int   // already sent by an earlier run
+	Remaining int   // left pending because the run stopped
+	StoppedBy error // set when the run stopped early: cancelled, offline or over Gmail's limits
+}
+
+// mergeVarPattern matches {{ variable }} placeholders
+var mergeVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// ParseMergeCSV reads a recipient list: a header row naming the columns, one of them "email",
+// then one row per recipient. Blank rows are skipped; a row without a valid address is an error.
+func ParseMergeCSV(r io.Reader) ([]MergeRecipient, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("recipient list is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recipient list: %w", err)
+	}
+	emailCol := -1
+	for i, h := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if header[i] == "email" {
+			emailCol = i
+		}
+	}
+	if emailCol < 0 {
+		return nil, fmt.Errorf("recipient list has no \"email\" column")
+	}
+
+	var out []MergeRecipient
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recipient list: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		vars := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(record) && h != "" {
+				vars[h] = strings.TrimSpace(record[i])
+			}
+		}
+		addr, err := mail.ParseAddress(vars["email"])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid email %q", line, vars["email"])
+		}
+		out = append(out, MergeRecipient{Row: line, Email: addr.Address, Vars: vars})
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("recipient list has no recipients")
+	}
+	return out, nil
+}
+
+// ParseMergeTemplate reads a template file: an optional "Subject: ..." first line, then the body
+func ParseMergeTemplate(name, text string) MergeTemplate {
+	t := MergeTemplate{Name: name}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	first, rest, _ := strings.Cut(text, "\n")
+	if len(first) >= 8 && strings.EqualFold(first[:8], "subject:") {
+		t.Subject = strings.TrimSpace(first[8:])
+		text = strings.TrimPrefix(rest, "\n")
+	}
+	t.Body = text
+	return t
+}
+
+// MergeTemplateFromEmailTemplate turns a composer template into a merge template
+func MergeTemplateFromEmailTemplate(et *EmailTemplate) MergeTemplate {
+	return MergeTemplate{Name: et.ID, Subject: et.Subject, Body: et.Body}
+}
+
+// RenderMergeMessage fills the template with a recipient's columns. A placeholder without a
+// column is an error, so nothing goes out with a literal {{name}} in it.
+func RenderMergeMessage(t MergeTemplate, r MergeRecipient) (*Composition, error) {
+	var missing []string
+	fill := func(s string) string {
+		return mergeVarPattern.ReplaceAllStringFunc(s, func(m string) string {
+			name := strings.ToLower(mergeVarPattern.FindStringSubmatch(m)[1])
+			v, ok := r.Vars[name]
+			if !ok {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+				return m
+			}
+			return v
+		})
+	}
+	subject, body := fill(t.Subject), fill(t.Body)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("row %d: no column for %s", r.Row, strings.Join(missing, ", "))
+	}
+	now := time.Now()
+	return &Composition{
+		ID:         fmt.Sprintf("merge_%d", r.Row),
+		Type:       CompositionTypeNew,
+		To:         []Recipient{{Email: r.Email, Name: r.Vars["name"]}},
+		Subject:    subject,
+		Body:       body,
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}, nil
+}
+
+// NewMailMergeJob fills the template for every recipient
+func NewMailMergeJob(t MergeTemplate, recipients []MergeRecipient) *MailMergeJob {
+	job := &MailMergeJob{Key: mergeJobKey(t, recipients), Template: t}
+	for _, r := range recipients {
+		comp, err := RenderMergeMessage(t, r)
+		job.Items = append(job.Items, &MergeItem{Recipient: r, Composition: comp, RenderError: err, Status: MergePending})
+	}
+	return job
+}
+
+// mergeJobKey hashes the template and the rows, so editing either starts a new job
+func mergeJobKey(t MergeTemplate, recipients []MergeRecipient) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", t.Subject, t.Body)
+	for _, r := range recipients {
+		keys := make([]string, 0, len(r.Vars))
+		for k := range r.Vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(h, "%s=%s\x00", k, r.Vars[k])
+		}
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Counts tallies the items by status
+func (j *MailMergeJob) Counts() (pending, sent, failed int) {
+	for _, it := range j.Items {
+		switch it.Status {
+		case MergeSent:
+			sent++
+		case MergeFailed:
+			failed++
+		default:
+			pending++
+		}
+	}
+	return pending, sent, failed
+}
+
+// MailMergeServiceImpl sends mail merge jobs one message at a time through the composition
+// service, waiting between messages, and records each outcome so a stopped job can resume.
+type MailMergeServiceImpl struct {
+	store        *db.MailMergeStore
+	send         func(ctx context.Context, c *Composition) error
+	accountEmail string
+	interval     time.Duration
+}
+
+// NewMailMergeService creates a mail merge service that sends through the composition service
+// with interval between two messages
+func NewMailMergeService(store *db.MailMergeStore, composition CompositionService, interval time.Duration) *MailMergeServiceImpl {
+	s := &MailMergeServiceImpl{store: store, interval: interval}
+	if composition != nil {
+		s.send = composition.SendComposition
+	}
+	return s
+}
+
+// SetAccountEmail sets the active account for scoping the recorded outcomes
+func (s *MailMergeServiceImpl) SetAccountEmail(email string) {
+	s.accountEmail = email
+}
+
+// SetSender replaces the send function (tests)
+func (s *MailMergeServiceImpl) SetSender(send func(ctx context.Context, c *Composition) error) {
+	s.send = send
+}
+
+// LoadStatus fills the job's statuses from earlier runs of the same job
+func (s *MailMergeServiceImpl) LoadStatus(ctx context.Context, job *MailMergeJob) error {
+	if s.store == nil || s.accountEmail == "" {
+		return nil
+	}
+	recorded, err := s.store.List(ctx, s.accountEmail, job.Key)
+	if err != nil {
+		return err
+	}
+	for _, it := range job.Items {
+		if rec, ok := recorded[strings.ToLower(it.Recipient.Email)]; ok {
+			it.Status, it.LastError = MergeStatus(rec.Status), rec.LastError
+		}
+	}
+	return nil
+}
+
+// Run sends every message of the job not sent yet, in order, calling onUpdate after each one. It
+// stops early, leaving the rest pending, when ctx is cancelled or Gmail can't be reached or
+// refuses more mail; a message Gmail rejects is marked failed and the run goes on.
+func (s *MailMergeServiceImpl) Run(ctx context.Context, job *MailMergeJob, onUpdate func(i int, it *MergeItem)) (MergeRunResult, error) {
+	var res MergeRunResult
+	if s.send == nil {
+		return res, fmt.Errorf("sending not available")
+	}
+	if err := s.LoadStatus(ctx, job); err != nil {
+		return res, err
+	}
+	first := true
+	for i, it := range job.Items {
+		if it.Status == MergeSent {
+			res.Skipped++
+			continue
+		}
+		if res.StoppedBy != nil {
+			res.Remaining++
+			continue
+		}
+		if it.RenderError != nil {
+			s.record(ctx, job, it, MergeFailed, it.RenderError.Error())
+			res.Failed++
+			s.notify(onUpdate, i, it)
+			continue
+		}
+		if !first {
+			if err := sleepContext(ctx, s.interval); err != nil {
+				res.StoppedBy = err
+				res.Remaining++
+				continue
+			}
+		}
+		first = false
+		if ctx.Err() != nil {
+			res.StoppedBy = ctx.Err()
+			res.Remaining++
+			continue
+		}
+
+		err := s.send(ctx, it.Composition)
+		var labelErr *SentLabelsError
+		if errors.As(err, &labelErr) {
+			err = nil
+		}
+		if err != nil {
+			if stop := mergeStopError(err); stop != nil {
+				res.StoppedBy = stop
+				res.Remaining++
+				continue
+			}
+			s.record(ctx, job, it, MergeFailed, err.Error())
+			res.Failed++
+		} else {
+			s.record(ctx, job, it, MergeSent, "")
+			res.Sent++
+		}
+		s.notify(onUpdate, i, it)
+	}
+	return res, nil
+}
+
+// mergeStopError returns the reason to stop a run when a send error means the following ones
+// would fail too: no network, or Gmail's sending quota or rate limit
+func mergeStopError(err error) error {
+	classified := ClassifyError("send", err)
+	for _, stop := range []error{ErrNetworkUnavailable, ErrQuotaExceeded, ErrRateLimited} {
+		if errors.Is(classified, stop) {
+			return classified
+		}
+	}
+	return nil
+}
+
+// record sets an item's status and stores it for later runs; a storage failure only costs resuming
+func (s *MailMergeServiceImpl) record(ctx context.Context, job *MailMergeJob, it *MergeItem, status MergeStatus, lastError string) {
+	it.Status, it.LastError = status, lastError
+	if s.store == nil || s.accountEmail == "" {
+		return
+	}
+	_ = s.store.Record(ctx, s.accountEmail, job.Key, it.Recipient.Email, string(status), lastError)
+}
+
+func (s *MailMergeServiceImpl) notify(onUpdate func(int, *MergeItem), i int, it *MergeItem) {
+	if onUpdate != nil {
+		onUpdate(i, it)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mergeTestCSV = "Email,Name,Company\nana@example.com,Ana,Acme\n\n\"Bob <bob@example.com>\",Bob,\"Bits, Inc\"\ncarl@example.com,Carl,Carl Co\n"
+
+func newTestMailMerge(t *testing.T) *MailMergeServiceImpl {
+	t.Helper()
+	store, err := db.Open(context.Background(), t.TempDir()+"/merge.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	svc := NewMailMergeService(db.NewMailMergeStore(store), nil, 0)
+	svc.SetAccountEmail("me@example.com")
+	return svc
+}
+
+func TestParseMergeCSV(t *testing.T) {
+	rs, err := ParseMergeCSV(strings.NewReader(mergeTestCSV))
+	require.NoError(t, err)
+	require.Len(t, rs, 3)
+	assert.Equal(t, "bob@example.com", rs[1].Email)
+	assert.Equal(t, "Bits, Inc", rs[1].Vars["company"])
+	assert.Equal(t, 4, rs[1].Row)
+
+	_, err = ParseMergeCSV(strings.NewReader("name\nAna\n"))
+	assert.ErrorContains(t, err, `no "email" column`)
+	_, err = ParseMergeCSV(strings.NewReader("email\nnot-an-address\n"))
+	assert.ErrorContains(t, err, "row 2: invalid email")
+}
+
+func TestRenderMergeMessage(t *testing.T) {
+	tmpl := ParseMergeTemplate("offer", "Subject: Offer for {{ company }}\n\nHi {{Name}},\nsee {{company}}.\n")
+	assert.Equal(t, "Offer for {{ company }}", tmpl.Subject)
+
+	r := MergeRecipient{Row: 2, Email: "ana@example.com", Vars: map[string]string{"name": "Ana", "company": "Acme"}}
+	c, err := RenderMergeMessage(tmpl, r)
+	require.NoError(t, err)
+	assert.Equal(t, "Offer for Acme", c.Subject)
+	assert.Equal(t, "Hi Ana,\nsee Acme.\n", c.Body)
+	assert.Equal(t, []Recipient{{Email: "ana@example.com", Name: "Ana"}}, c.To)
+	assert.Equal(t, CompositionTypeNew, c.Type)
+
+	_, err = RenderMergeMessage(ParseMergeTemplate("x", "Dear {{title}} {{name}} {{title}}"), r)
+	assert.EqualError(t, err, "row 2: no column for title")
+}
+
+func TestMailMerge_RunResumesAfterStop(t *testing.T) {
+	ctx := context.Background()
+	rs, err := ParseMergeCSV(strings.NewReader(mergeTestCSV))
+	require.NoError(t, err)
+	tmpl := ParseMergeTemplate("t", "Subject: Hi {{name}}\n\nHello")
+
+	svc := newTestMailMerge(t)
+	var sent []string
+	offline := false
+	svc.SetSender(func(ctx context.Context, c *Composition) error {
+		if offline {
+			return &NetworkError{Op: "send message", Err: errors.New("no route to host")}
+		}
+		if c.To[0].Email == "bob@example.com" {
+			return errors.New("invalid recipient")
+		}
+		sent = append(sent, c.Subject)
+		if len(sent) == 1 {
+			offline = true
+		}
+		return nil
+	})
+
+	var updates []int
+	job := NewMailMergeJob(tmpl, rs)
+	res, err := svc.Run(ctx, job, func(i int, _ *MergeItem) { updates = append(updates, i) })
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Sent)
+	assert.Equal(t, 2, res.Remaining)
+	assert.ErrorIs(t, res.StoppedBy, ErrNetworkUnavailable)
+	assert.Equal(t, []int{0}, updates)
+
+	// The same merge loaded again knows Ana got hers
+	offline = false
+	job = NewMailMergeJob(tmpl, rs)
+	res, err = svc.Run(ctx, job, nil)
+	require.NoError(t, err)
+	assert.Equal(t, MergeRunResult{Sent: 1, Failed: 1, Skipped: 1}, res)
+	assert.Equal(t, []string{"Hi Ana", "Hi Carl"}, sent)
+	assert.Equal(t, MergeFailed, job.Items[1].Status)
+	assert.Equal(t, "invalid recipient", job.Items[1].LastError)
+	pending, done, failed := job.Counts()
+	assert.Equal(t, [3]int{0, 2, 1}, [3]int{pending, done, failed})
+
+	// Editing the template makes it a new job
+	assert.NotEqual(t, job.Key, NewMailMergeJob(ParseMergeTemplate("t", "Hello again"), rs).Key)
+}
+
+func TestMailMerge_RunStopsWhenCancelled(t *testing.T) {
+	rs, err := ParseMergeCSV(strings.NewReader(mergeTestCSV))
+	require.NoError(t, err)
+	svc := newTestMailMerge(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.SetSender(func(context.Context, *Composition) error {
+		cancel()
+		return nil
+	})
+	res, err := svc.Run(ctx, NewMailMergeJob(ParseMergeTemplate("t", "Hello"), rs), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Sent)
+	assert.Equal(t, 2, res.Remaining)
+	assert.ErrorIs(t, res.StoppedBy, context.Canceled)
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// Briefing input limits: conversation messages and characters per message, and characters per
+// attachment, so the prompt stays within what local models handle
+const (
+	briefingMaxMessages       = 6
+	briefingMaxMessageChars   = 2500
+	briefingMaxAttachmentText = 3000
+)
+
+const meetingBriefingPrompt = `You are preparing someone for a meeting. Using ONLY the material below (the calendar invite, the email conversation that led to it and the attached documents), write a short pre-meeting brief in Markdown with these sections:
+
+## Purpose
+One or two sentences on what the meeting is for.
+## Background
+The key points of the conversation so far: decisions, open questions, positions of the people involved.
+## Attachments
+One line per attached document on what it contains and why it matters (omit the section if there are none).
+## Prepare
+Bullet points of what to read, decide or bring before the meeting.
+
+Be factual and concise, keep names, dates and numbers exact, and do not invent anything.
+
+%s`
+
+// BriefingClient is the subset of *gmail.Client the meeting briefing depends on
+type BriefingClient interface {
+	GetMessageWithContent(id string) (*gmail.Message, error)
+	GetAttachment(messageID, attachmentID string) ([]byte, string, error)
+}
+
+// BriefingAttachment is an attachment of the invite; Text is set for attachments that could be
+// read as text
+type BriefingAttachment struct {
+	Filename string
+	MimeType string
+	Size     int64
+	Text     string
+}
+
+// MeetingBriefing is a pre-meeting brief built from a calendar invite
+type MeetingBriefing struct {
+	MessageID   string
+	Subject     string
+	Event       ICSEvent
+	Messages    int // conversation messages the brief draws on
+	Attachments []BriefingAttachment
+	Brief       string // AI-written Markdown
+	CreatedAt   time.Time
+}
+
+// Title is the briefing's heading: the event summary, or the message subject
+func (b *MeetingBriefing) Title() string {
+	title := strings.TrimSpace(b.Event.Summary)
+	if title == "" {
+		title = strings.TrimSpace(b.Subject)
+	}
+	if title == "" {
+		title = "Meeting"
+	}
+	return "Briefing: " + title
+}
+
+// Markdown renders the briefing as a Markdown note: the invite details, the AI brief and the
+// attachment list
+func (b *MeetingBriefing) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", b.Title())
+	field := func(label, value string) {
+		if strings.TrimSpace(value) != "" {
+			fmt.Fprintf(&sb, "- **%s:** %s\n", label, value)
+		}
+	}
+	field("When", b.Event.When())
+	field("Where", b.Event.Location)
+	field("Organizer", b.Event.Organizer)
+	var people []string
+	for _, at := range b.Event.Attendees {
+		who := at.Name
+		if who == "" {
+			who = at.Email
+		}
+		if at.Status != "" {
+			who += " (" + strings.ToLower(at.Status) + ")"
+		}
+		people = append(people, who)
+	}
+	field("Attendees", strings.Join(people, ", "))
+	field("Link", b.Event.URL)
+	fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(b.Brief))
+	if len(b.Attachments) > 0 {
+		sb.WriteString("\n## Attached files\n\n")
+		for _, att := range b.Attachments {
+			fmt.Fprintf(&sb, "- %s (%s)\n", att.Filename, att.MimeType)
+		}
+	}
+	fmt.Fprintf(&sb, "\n---\nPrepared %s from %d message(s) of the conversation.\n", b.CreatedAt.Format("2006-01-02 15:04"), b.Messages)
+	return sb.String()
+}
+
+// MeetingBriefingServiceImpl implements MeetingBriefingService
+type MeetingBriefingServiceImpl struct {
+	client      BriefingClient
+	threads     ThreadService
+	attachments AttachmentService
+	aiService   AIService
+	now         func() time.Time
+}
+
+// NewMeetingBriefingService creates the briefing service. threads and attachments may be nil
+// (the brief then leaves out the conversation or the attachments).
+func NewMeetingBriefingService(client BriefingClient, threads ThreadService, attachments AttachmentService, aiService AIService) *MeetingBriefingServiceImpl {
+	return &MeetingBriefingServiceImpl{client: client, threads: threads, attachments: attachments, aiService: aiService, now: time.Now}
+}
+
+// Generate builds the brief for a calendar invite message
+func (s *MeetingBriefingServiceImpl) Generate(ctx context.Context, messageID string) (*MeetingBriefing, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	if s.aiService == nil {
+		return nil, fmt.Errorf("AI service not available")
+	}
+	m, err := s.client.GetMessageWithContent(messageID)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := s.inviteCalendar(m.Message)
+	if !ok {
+		return nil, fmt.Errorf("message is not a calendar invite")
+	}
+	events := ParseICSEvents(string(raw))
+	if len(events) == 0 {
+		return nil, fmt.Errorf("could not read the invite's event")
+	}
+
+	b := &MeetingBriefing{MessageID: messageID, Subject: m.Subject, Event: events[0], CreatedAt: s.now()}
+	conversation := s.conversation(ctx, m)
+	b.Messages = len(conversation)
+	b.Attachments = s.readAttachments(ctx, messageID)
+
+	brief, err := s.aiService.ApplyCustomPrompt(ctx, fmt.Sprintf(meetingBriefingPrompt, briefingMaterial(b, conversation)), nil)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(brief) == "" {
+		return nil, fmt.Errorf("the AI returned an empty brief")
+	}
+	b.Brief = brief
+	return b, nil
+}
+
+// inviteCalendar returns the iCalendar data of the message's invite part
+func (s *MeetingBriefingServiceImpl) inviteCalendar(msg *gmail_v1.Message) ([]byte, bool) {
+	if msg == nil || msg.Payload == nil {
+		return nil, false
+	}
+	var walk func(p *gmail_v1.MessagePart) []byte
+	walk = func(p *gmail_v1.MessagePart) []byte {
+		mt := strings.ToLower(p.MimeType)
+		if (strings.Contains(mt, "text/calendar") || strings.Contains(mt, "application/ics") ||
+			strings.HasSuffix(strings.ToLower(p.Filename), ".ics")) && p.Body != nil {
+			if p.Body.Data != "" {
+				if data, err := base64.URLEncoding.DecodeString(p.Body.Data); err == nil {
+					return data
+				}
+			} else if p.Body.AttachmentId != "" {
+				if data, _, err := s.client.GetAttachment(msg.Id, p.Body.AttachmentId); err == nil {
+					return data
+				}
+			}
+		}
+		for _, c := range p.Parts {
+			if data := walk(c); data != nil {
+				return data
+			}
+		}
+		return nil
+	}
+	data := walk(msg.Payload)
+	return data, len(data) > 0
+}
+
+// briefingMessage is one message of the conversation, as given to the AI
+type briefingMessage struct {
+	From string
+	Date string
+	Text string
+}
+
+// conversation returns the latest messages of the invite's thread, oldest first, without their
+// quoted history; the invite alone when the thread cannot be read
+func (s *MeetingBriefingServiceImpl) conversation(ctx context.Context, m *gmail.Message) []briefingMessage {
+	invite := briefingMessage{From: m.From, Date: m.Date.Format("2006-01-02 15:04"), Text: m.PlainText}
+	if s.threads == nil || m.ThreadId == "" {
+		return []briefingMessage{invite}
+	}
+	msgs, err := s.threads.GetThreadMessages(ctx, m.ThreadId, MessageQueryOptions{Format: "full", SortOrder: "asc"})
+	if err != nil || len(msgs) == 0 {
+		return []briefingMessage{invite}
+	}
+	if len(msgs) > briefingMaxMessages {
+		msgs = msgs[len(msgs)-briefingMaxMessages:]
+	}
+	out := make([]briefingMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		date := ""
+		if msg.InternalDate > 0 {
+			date = time.UnixMilli(msg.InternalDate).Format("2006-01-02 15:04")
+		}
+		out = append(out, briefingMessage{
+			From: reportHeader(msg, "From"),
+			Date: date,
+			Text: StripQuotedHistory(gmail.ExtractPlainText(msg)),
+		})
+	}
+	return out
+}
+
+// readAttachments lists the invite's attachments (other than the calendar itself) with the text
+// of those that can be previewed
+func (s *MeetingBriefingServiceImpl) readAttachments(ctx context.Context, messageID string) []BriefingAttachment {
+	if s.attachments == nil {
+		return nil
+	}
+	infos, err := s.attachments.GetMessageAttachments(ctx, messageID)
+	if err != nil {
+		return nil
+	}
+	var out []BriefingAttachment
+	for _, info := range infos {
+		name := strings.ToLower(info.Filename)
+		if info.Inline || strings.HasSuffix(name, ".ics") || strings.Contains(strings.ToLower(info.MimeType), "calendar") {
+			continue
+		}
+		att := BriefingAttachment{Filename: info.Filename, MimeType: info.MimeType, Size: info.Size}
+		if p, err := s.attachments.PreviewAttachment(ctx, messageID, info); err == nil {
+			att.Text = truncateRunes(p.Text, briefingMaxAttachmentText)
+		}
+		out = append(out, att)
+	}
+	return out
+}
+
+// briefingMaterial lays out the invite, the conversation and the attachments for the prompt
+func briefingMaterial(b *MeetingBriefing, conversation []briefingMessage) string {
+	var sb strings.Builder
+	e := b.Event
+	sb.WriteString("=== INVITE ===\n")
+	fmt.Fprintf(&sb, "Title: %s\nWhen: %s\n", e.Summary, e.When())
+	if e.Location != "" {
+		fmt.Fprintf(&sb, "Where: %s\n", e.Location)
+	}
+	if e.Organizer != "" {
+		fmt.Fprintf(&sb, "Organizer: %s\n", e.Organizer)
+	}
+	for _, at := range e.Attendees {
+		fmt.Fprintf(&sb, "Attendee: %s <%s> %s\n", at.Name, at.Email, strings.ToLower(at.Status))
+	}
+	if d := strings.TrimSpace(e.Description); d != "" {
+		fmt.Fprintf(&sb, "Description:\n%s\n", truncateRunes(d, briefingMaxMessageChars))
+	}
+	sb.WriteString("\n=== CONVERSATION ===\n")
+	for i, m := range conversation {
+		fmt.Fprintf(&sb, "--- Message %d from %s (%s) ---\n%s\n", i+1, m.From, m.Date, truncateRunes(strings.TrimSpace(m.Text), briefingMaxMessageChars))
+	}
+	if len(b.Attachments) > 0 {
+		sb.WriteString("\n=== ATTACHMENTS ===\n")
+		for _, att := range b.Attachments {
+			fmt.Fprintf(&sb, "--- %s (%s) ---\n", att.Filename, att.MimeType)
+			if att.Text != "" {
+				sb.WriteString(att.Text + "\n")
+			} else {
+				sb.WriteString("[content not readable as text]\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// truncateRunes cuts text to max runes, marking the cut
+func truncateRunes(text string, max int) string {
+	if r := []rune(text); len(r) > max {
+		return string(r[:max]) + " […]"
+	}
+	return text
+}
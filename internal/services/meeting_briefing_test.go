@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type briefingStubClient struct {
+	msg *gmail.Message
+}
+
+func (c *briefingStubClient) GetMessageWithContent(id string) (*gmail.Message, error) {
+	if c.msg == nil || c.msg.Id != id {
+		return nil, fmt.Errorf("message %s not found", id)
+	}
+	return c.msg, nil
+}
+
+func (c *briefingStubClient) GetAttachment(_, _ string) ([]byte, string, error) {
+	return nil, "", fmt.Errorf("no attachments")
+}
+
+func briefingInvite(ics string) *gmail.Message {
+	return &gmail.Message{
+		Message: &gmail_v1.Message{Id: "m1", Payload: &gmail_v1.MessagePart{
+			MimeType: "multipart/mixed",
+			Parts: []*gmail_v1.MessagePart{
+				{MimeType: "text/plain", Body: &gmail_v1.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("See you there"))}},
+				{MimeType: "text/calendar", Body: &gmail_v1.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte(ics))}},
+			},
+		}},
+		Subject:   "Invitation: Budget review",
+		From:      "Ana <ana@example.com>",
+		PlainText: "Let's go over the Q3 numbers.",
+	}
+}
+
+func TestMeetingBriefing_Generate(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nBEGIN:VEVENT\r\nSUMMARY:Budget review\r\n" +
+		"DTSTART:20261020T100000Z\r\nDTEND:20261020T110000Z\r\nLOCATION:Room 4\r\n" +
+		"ORGANIZER;CN=Ana:mailto:ana@example.com\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	ai := &slackStubAI{result: "## Purpose\nAgree the Q3 budget."}
+	svc := NewMeetingBriefingService(&briefingStubClient{msg: briefingInvite(ics)}, nil, nil, ai)
+	svc.now = func() time.Time { return time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC) }
+
+	b, err := svc.Generate(context.Background(), "m1")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if b.Title() != "Briefing: Budget review" || b.Messages != 1 || b.Event.Location != "Room 4" {
+		t.Fatalf("unexpected briefing %+v", b)
+	}
+	md := b.Markdown()
+	for _, want := range []string{"# Briefing: Budget review", "- **Where:** Room 4", "Agree the Q3 budget.", "Prepared 2026-10-19 09:00 from 1 message(s)"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	// A message without a calendar part is not an invite
+	plain := briefingInvite(ics)
+	plain.Payload.Parts = plain.Payload.Parts[:1]
+	svc = NewMeetingBriefingService(&briefingStubClient{msg: plain}, nil, nil, ai)
+	if _, err := svc.Generate(context.Background(), "m1"); err == nil {
+		t.Fatal("want error for a message that is not an invite")
+	}
+}
+
+func TestBriefingMaterial(t *testing.T) {
+	b := &MeetingBriefing{
+		Event:       ICSEvent{Summary: "Kickoff", Organizer: "Ana"},
+		Attachments: []BriefingAttachment{{Filename: "plan.pdf", MimeType: "application/pdf"}, {Filename: "notes.txt", MimeType: "text/plain", Text: "Agenda items"}},
+	}
+	conv := []briefingMessage{{From: "Ana", Date: "2026-10-01 09:00", Text: strings.Repeat("x", briefingMaxMessageChars+10)}}
+	out := briefingMaterial(b, conv)
+	for _, want := range []string{"Title: Kickoff", "Organizer: Ana", "--- Message 1 from Ana (2026-10-01 09:00) ---", "[content not readable as text]", "Agenda items", "[…]"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("material missing %q:\n%s", want, out)
+		}
+	}
+	if got := (&MeetingBriefing{Subject: "Sync"}).Title(); got != "Briefing: Sync" {
+		t.Fatalf("title falls back to the subject, got %q", got)
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/obsidian"
+)
+
+const (
+	// repopackNavReserve is room left in each part for its title and the index/previous/next links
+	repopackNavReserve = 600
+	// minRepopackChunkChars keeps a tiny budget (or a large template) from producing thousands of parts
+	minRepopackChunkChars = 2000
+	repopackContinued     = "*(continued)*\n\n"
+)
+
+// repopackPiece is a message section of a repopack, or a slice of one too large for a part
+type repopackPiece struct {
+	email int // index of the message the text belongs to
+	text  string
+}
+
+// chunkRepopackSections packs the message sections into parts of at most budget characters,
+// keeping messages whole when they fit and splitting the ones that don't at line breaks
+func chunkRepopackSections(sections []string, budget int) [][]repopackPiece {
+	var chunks [][]repopackPiece
+	var cur []repopackPiece
+	size := 0
+	for i, section := range sections {
+		pieces := []string{section}
+		if len(section) > budget {
+			pieces = splitRepopackText(section, budget-len(repopackContinued))
+			for j := 1; j < len(pieces); j++ {
+				pieces[j] = repopackContinued + pieces[j]
+			}
+		}
+		for _, p := range pieces {
+			if size+len(p) > budget && len(cur) > 0 {
+				chunks = append(chunks, cur)
+				cur, size = nil, 0
+			}
+			cur = append(cur, repopackPiece{email: i, text: p})
+			size += len(p)
+		}
+	}
+	if len(cur) > 0 {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
+
+// splitRepopackText cuts text into pieces of at most limit bytes at line breaks; a longer line is
+// cut at a rune boundary
+func splitRepopackText(text string, limit int) []string {
+	var pieces []string
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(line) > limit {
+			if b.Len() > 0 {
+				pieces = append(pieces, b.String())
+				b.Reset()
+			}
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			pieces = append(pieces, line[:cut])
+			line = line[cut:]
+		}
+		if b.Len() > 0 && b.Len()+len(line) > limit {
+			pieces = append(pieces, b.String())
+			b.Reset()
+		}
+		b.WriteString(line)
+	}
+	if b.Len() > 0 {
+		pieces = append(pieces, b.String())
+	}
+	return pieces
+}
+
+// ingestChunkedRepopack writes a repopack over the budget as parts in their own folder of the
+// ingest folder, with an index note linking the parts and listing which emails each holds
+func (s *ObsidianServiceImpl) ingestChunkedRepopack(ctx context.Context, messages []*gmail.Message, contents, messageIDs []string, options obsidian.ObsidianOptions, budget int) (*obsidian.ObsidianIngestResult, error) {
+	sections := s.repopackSections(contents, messageIDs, messages)
+	overhead, err := s.formatRepopackForObsidian(messages, "", options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format repopack: %w", err)
+	}
+	bodyBudget := budget - len(overhead) - repopackNavReserve
+	if bodyBudget < minRepopackChunkChars {
+		bodyBudget = minRepopackChunkChars
+	}
+	chunks := chunkRepopackSections(sections, bodyBudget)
+
+	base := fmt.Sprintf("%s_repopack_%d_messages", time.Now().Format("2006-01-02_15-04-05"), len(contents))
+	dir := filepath.Join(s.config.VaultPath, s.config.IngestFolder, base)
+	indexName := base + " - index"
+	partName := func(i int) string { return fmt.Sprintf("%s - part %d of %d", base, i+1, len(chunks)) }
+
+	fail := func(err error) (*obsidian.ObsidianIngestResult, error) {
+		if s.logger != nil {
+			s.logger.Printf("Obsidian repopack failed: %v", err)
+		}
+		s.recordRepopackFailure(ctx, messages, options, err)
+		return &obsidian.ObsidianIngestResult{
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("failed to create repopack file: %v", err),
+			RepopackMode: true,
+			MessageCount: len(contents),
+		}, nil
+	}
+
+	var totalSize int64
+	partPaths := make([]string, 0, len(chunks))
+	partOf := make(map[int]int, len(contents)) // message -> first part holding it
+	partSizes := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		nav := []string{"[[" + indexName + "|Index]]"}
+		if i > 0 {
+			nav = append(nav, "[["+partName(i-1)+"|← Previous]]")
+		}
+		if i < len(chunks)-1 {
+			nav = append(nav, "[["+partName(i+1)+"|Next →]]")
+		}
+		var body strings.Builder
+		fmt.Fprintf(&body, "# 📧 Compiled Email Messages - part %d of %d\n\n%s\n\n", i+1, len(chunks), strings.Join(nav, " · "))
+		for _, p := range chunk {
+			body.WriteString(p.text)
+			if _, ok := partOf[p.email]; !ok {
+				partOf[p.email] = i
+			}
+		}
+		content, err := s.formatRepopackForObsidian(messages, body.String(), options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format repopack: %w", err)
+		}
+		path := filepath.Join(dir, partName(i)+".md")
+		if err := s.createObsidianFile(path, content); err != nil {
+			return fail(err)
+		}
+		partPaths = append(partPaths, path)
+		partSizes[i] = len(content)
+		totalSize += int64(len(content))
+	}
+
+	index := s.formatRepopackIndex(messages, contents, options, chunks, partSizes, partName, partOf, budget)
+	indexPath := filepath.Join(dir, indexName+".md")
+	if err := s.createObsidianFile(indexPath, index); err != nil {
+		return fail(err)
+	}
+	totalSize += int64(len(index))
+
+	if s.logger != nil {
+		s.logger.Printf("Obsidian repopack successful: created %d parts and index %s with %d messages", len(chunks), indexPath, len(contents))
+	}
+
+	metadata := map[string]interface{}{
+		"message_count": len(contents),
+		"message_ids":   messageIDs,
+		"repopack":      true,
+		"chunk_count":   len(chunks),
+		"chunk_paths":   partPaths,
+	}
+	if err := s.store.RecordForward(ctx, &obsidian.ObsidianForwardRecord{
+		MessageID:    fmt.Sprintf("repopack_%d_messages", len(contents)),
+		AccountEmail: options.AccountEmail,
+		ObsidianPath: indexPath,
+		TemplateUsed: "repopack_template",
+		ForwardDate:  time.Now(),
+		Status:       "success",
+		FileSize:     totalSize,
+		Metadata:     metadata,
+	}); err != nil && s.logger != nil {
+		s.logger.Printf("Warning: failed to record repopack forward: %v", err)
+	}
+
+	return &obsidian.ObsidianIngestResult{
+		Success:      true,
+		FilePath:     indexPath,
+		FileSize:     totalSize,
+		TemplateUsed: "repopack_template",
+		RepopackMode: true,
+		MessageCount: len(contents),
+		Metadata:     metadata,
+	}, nil
+}
+
+// formatRepopackIndex renders the index note of a chunked repopack: its parts with the emails
+// each holds, then every email with the part it starts in
+func (s *ObsidianServiceImpl) formatRepopackIndex(messages []*gmail.Message, contents []string, options obsidian.ObsidianOptions, chunks [][]repopackPiece, partSizes []int, partName func(int) string, partOf map[int]int, budget int) string {
+	compilationDate := time.Now().Format("2006-01-02 15:04:05")
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ntitle: \"Email Repopack Index - %s\"\ndate: %s\ntype: email_repopack_index\nmessage_count: %d\nchunk_count: %d\naccount: %s\nrepopack: true\n---\n\n",
+		compilationDate, compilationDate, len(contents), len(chunks), options.AccountEmail)
+	fmt.Fprintf(&b, "# 📦 Email Repopack - %d Messages in %d Parts\n\n", len(contents), len(chunks))
+	fmt.Fprintf(&b, "**Compiled:** %s\n**Account:** %s\n", compilationDate, options.AccountEmail)
+	if comment, ok := options.CustomMetadata["comment"].(string); ok && comment != "" {
+		fmt.Fprintf(&b, "**Comment:** %s\n", comment)
+	}
+	fmt.Fprintf(&b, "\nEach part stays under %d characters (~%d tokens) to fit an LLM context window.\n\n## Parts\n\n", budget, budget/4)
+	for i, chunk := range chunks {
+		first, last := chunk[0].email+1, chunk[len(chunk)-1].email+1
+		emails := fmt.Sprintf("email %d", first)
+		if last != first {
+			emails = fmt.Sprintf("emails %d-%d", first, last)
+		}
+		fmt.Fprintf(&b, "- [[%s]] - %s (~%d tokens)\n", partName(i), emails, partSizes[i]/4)
+	}
+	b.WriteString("\n## Emails\n\n")
+	for i := range contents {
+		subject := "(No subject)"
+		if i < len(messages) && messages[i] != nil && messages[i].Subject != "" {
+			subject = messages[i].Subject
+		}
+		from := ""
+		if i < len(messages) && messages[i] != nil {
+			if f := s.extractHeader(messages[i], "From"); f != "" {
+				from = " - " + f
+			}
+		}
+		fmt.Fprintf(&b, "%d. %s%s → [[%s|part %d]]\n", i+1, subject, from, partName(partOf[i]), partOf[i]+1)
+	}
+	fmt.Fprintf(&b, "\n---\n\n*Compiled from Gmail using GizTUI repopack mode on %s*\n", compilationDate)
+	return b.String()
+}
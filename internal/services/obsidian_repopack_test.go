@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/obsidian"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestChunkRepopackSections(t *testing.T) {
+	small := strings.Repeat("a", 300)
+	big := strings.Repeat("line of text\n", 200) // 2600 bytes
+	chunks := chunkRepopackSections([]string{small, small, big, small}, 1000)
+
+	total := 0
+	for i, chunk := range chunks {
+		size := 0
+		for _, p := range chunk {
+			size += len(p.text)
+		}
+		if size > 1000 {
+			t.Fatalf("chunk %d has %d bytes, over the budget", i, size)
+		}
+		total += size
+	}
+	if len(chunks[0]) != 2 || chunks[0][1].email != 1 || chunks[1][0].email != 2 {
+		t.Fatalf("chunks = %+v, want the small emails together and the large one starting a part", chunks)
+	}
+	last := chunks[len(chunks)-1]
+	if last[len(last)-1].email != 3 {
+		t.Fatal("last email missing from the last chunk")
+	}
+	if strings.HasPrefix(chunks[1][0].text, repopackContinued) || !strings.HasPrefix(chunks[2][0].text, repopackContinued) {
+		t.Fatalf("a split email should mark its continuation, got %q", chunks[2][0].text[:20])
+	}
+	if want := 3*300 + 2600; total < want {
+		t.Fatalf("chunks hold %d bytes, lost content (want at least %d)", total, want)
+	}
+
+	for _, p := range splitRepopackText(strings.Repeat("é", 700), 100) {
+		if len(p) > 100 || !strings.HasPrefix(p, "é") {
+			t.Fatalf("long line not cut at rune boundaries: %q", p)
+		}
+	}
+}
+
+func TestIngestEmailsToSingleFile_Chunked(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, filepath.Join(t.TempDir(), "obsidian.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	vault := t.TempDir()
+	cfg := obsidian.DefaultObsidianConfig()
+	cfg.VaultPath = vault
+	cfg.RepopackTemplateFile = ""
+	cfg.RepopackChunkChars = 6000
+	svc := NewObsidianService(db.NewObsidianStore(store), cfg, nil)
+
+	var messages []*gmail.Message
+	for i := 0; i < 6; i++ {
+		body := base64.URLEncoding.EncodeToString([]byte(strings.Repeat("Quarterly numbers look fine.\n", 80)))
+		messages = append(messages, &gmail.Message{
+			Message: &gmail_v1.Message{Id: "m" + string(rune('a'+i)), Payload: &gmail_v1.MessagePart{
+				MimeType: "text/plain",
+				Body:     &gmail_v1.MessagePartBody{Data: body},
+				Headers:  []*gmail_v1.MessagePartHeader{{Name: "From", Value: "cfo@example.com"}},
+			}},
+			Subject: "Report",
+		})
+	}
+	result, err := svc.IngestEmailsToSingleFile(ctx, messages, "me@example.com", obsidian.ObsidianOptions{AccountEmail: "me@example.com", RepopackMode: true})
+	if err != nil || !result.Success {
+		t.Fatalf("ingest = %+v, %v", result, err)
+	}
+	chunkCount, _ := result.Metadata["chunk_count"].(int)
+	if chunkCount < 2 || !strings.HasSuffix(result.FilePath, " - index.md") {
+		t.Fatalf("want an index over several parts, got %s with %d parts", result.FilePath, chunkCount)
+	}
+
+	index, err := os.ReadFile(result.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range result.Metadata["chunk_paths"].([]string) {
+		name := strings.TrimSuffix(filepath.Base(path), ".md")
+		if !strings.Contains(string(index), "[["+name+"]]") {
+			t.Fatalf("index does not link %s", name)
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.Size() > 6000 {
+			t.Fatalf("part %s: %v, size %d over the budget", name, err, info.Size())
+		}
+	}
+}
@@ -330,6 +330,22 @@ func (s *ObsidianServiceImpl) createObsidianFile(filePath, content string) error
 	return nil
 }
 
+// SaveNote writes a Markdown note to the ingest folder of the vault, named after the date and title
+func (s *ObsidianServiceImpl) SaveNote(ctx context.Context, title, content string) (string, error) {
+	if strings.TrimSpace(s.config.VaultPath) == "" {
+		return "", fmt.Errorf("obsidian vault path not configured")
+	}
+	filename := fmt.Sprintf("%s_%s.md", time.Now().Format("2006-01-02"), s.sanitizeFilename(title))
+	filePath := filepath.Join(s.config.VaultPath, s.config.IngestFolder, filename)
+	if err := s.createObsidianFile(filePath, content); err != nil {
+		return "", err
+	}
+	if s.logger != nil {
+		s.logger.Printf("Obsidian note saved: %s", filePath)
+	}
+	return filePath, nil
+}
+
 // recordForwardFailure records a failed forward attempt
 func (s *ObsidianServiceImpl) recordForwardFailure(ctx context.Context, message *gmail.Message, options obsidian.ObsidianOptions, err error) {
 	if s.store == nil {
@@ -503,6 +519,11 @@ func (s *ObsidianServiceImpl) IngestEmailsToSingleFile(ctx context.Context, mess
 		return nil, fmt.Errorf("failed to format repopack: %w", err)
 	}
 
+	// Split a repopack too large for one LLM context into linked parts
+	if budget := s.config.RepopackChunkBudget(); budget > 0 && len(repopackContent) > budget {
+		return s.ingestChunkedRepopack(ctx, messages, messageContents, messageIDs, options, budget)
+	}
+
 	// Generate repopack file path
 	filePath, err := s.generateRepopackFilePath(messages, len(messageContents))
 	if err != nil {
@@ -611,11 +632,15 @@ func (s *ObsidianServiceImpl) combineMessageContents(contents []string, messageI
 	if len(contents) == 0 {
 		return ""
 	}
+	return "# 📧 Compiled Email Messages\n\n" + strings.Join(s.repopackSections(contents, messageIDs, messages), "")
+}
 
-	var combined strings.Builder
-	combined.WriteString("# 📧 Compiled Email Messages\n\n")
-
+// repopackSections renders each message of a repopack as its own Markdown section
+func (s *ObsidianServiceImpl) repopackSections(contents []string, messageIDs []string, messages []*gmail.Message) []string {
+	sections := make([]string, 0, len(contents))
 	for i, content := range contents {
+		var combined strings.Builder
+
 		// Get message metadata
 		var subject, from, date string
 		if i < len(messages) && messages[i] != nil {
@@ -646,9 +671,9 @@ func (s *ObsidianServiceImpl) combineMessageContents(contents []string, messageI
 		combined.WriteString(cleanContent)
 
 		combined.WriteString("\n\n---\n\n")
+		sections = append(sections, combined.String())
 	}
-
-	return combined.String()
+	return sections
 }
 
 // cleanEmailContentForRepopack processes email content for repopack (lighter cleaning than bulk prompts)
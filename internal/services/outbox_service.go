@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// OutboxStatus is the state of a message in the offline outbox
+type OutboxStatus string
+
+const (
+	OutboxQueued  OutboxStatus = "queued"  // waiting for connectivity
+	OutboxSending OutboxStatus = "sending" // being sent right now
+	OutboxSent    OutboxStatus = "sent"    // delivered; kept for a day so the outcome stays visible
+	OutboxFailed  OutboxStatus = "failed"  // Gmail rejected it; needs a retry or discard
+)
+
+// outboxSentRetention is how long delivered messages stay listed in the outbox
+const outboxSentRetention = 24 * time.Hour
+
+// OutboxItem is one message composed while offline
+type OutboxItem struct {
+	ID          int64
+	Composition *Composition
+	Status      OutboxStatus
+	Attempts    int
+	LastError   string
+	QueuedAt    time.Time
+	UpdatedAt   time.Time
+}
+
+// OutboxFlushResult summarizes one pass over the outbox
+type OutboxFlushResult struct {
+	Sent      int
+	Failed    int
+	Remaining int  // still queued
+	Offline   bool // the pass stopped because the network is still unavailable
+}
+
+// OutboxServiceImpl implements OutboxService on top of the composition service. Sending a queued
+// message doubles as the connectivity probe: a network error leaves it queued and keeps the
+// service offline, anything else brings it back online.
+type OutboxServiceImpl struct {
+	store        *db.OutboxStore
+	send         func(ctx context.Context, c *Composition) error
+	accountEmail string
+	offline      bool
+	pending      int
+	failed       int
+	mu           sync.RWMutex
+	flushMu      sync.Mutex
+}
+
+// NewOutboxService creates an outbox that delivers through the composition service.
+func NewOutboxService(store *db.OutboxStore, composition CompositionService) *OutboxServiceImpl {
+	s := &OutboxServiceImpl{store: store}
+	if composition != nil {
+		s.send = composition.SendComposition
+	}
+	return s
+}
+
+// SetAccountEmail sets the active account for scoping and loads its queue counters.
+func (s *OutboxServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	s.accountEmail = email
+	s.mu.Unlock()
+	_ = s.refreshCounts(context.Background())
+}
+
+// SetSender replaces the send function (tests)
+func (s *OutboxServiceImpl) SetSender(send func(ctx context.Context, c *Composition) error) {
+	s.send = send
+}
+
+func (s *OutboxServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("outbox store not available")
+	}
+	return email, nil
+}
+
+// IsOffline reports whether the last send attempt failed for lack of connectivity
+func (s *OutboxServiceImpl) IsOffline() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.offline
+}
+
+// SetOffline records the connectivity state observed outside the outbox
+func (s *OutboxServiceImpl) SetOffline(offline bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offline = offline
+}
+
+// Counts returns the messages waiting to be sent and the ones Gmail rejected. It reads cached
+// counters, so it is cheap enough for the status bar.
+func (s *OutboxServiceImpl) Counts() (pending, failed int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pending, s.failed
+}
+
+func (s *OutboxServiceImpl) refreshCounts(ctx context.Context) error {
+	items, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	pending, failed := 0, 0
+	for _, it := range items {
+		switch it.Status {
+		case OutboxQueued, OutboxSending:
+			pending++
+		case OutboxFailed:
+			failed++
+		}
+	}
+	s.mu.Lock()
+	s.pending, s.failed = pending, failed
+	s.mu.Unlock()
+	return nil
+}
+
+// Enqueue stores a composition that could not be sent; cause is the send error, if any
+func (s *OutboxServiceImpl) Enqueue(ctx context.Context, composition *Composition, cause error) (*OutboxItem, error) {
+	if composition == nil {
+		return nil, fmt.Errorf("composition cannot be nil")
+	}
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(composition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode composition: %w", err)
+	}
+	lastError := ""
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	entry, err := s.store.Enqueue(ctx, email, string(data), string(OutboxQueued), lastError)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.refreshCounts(ctx)
+	return outboxItemFromEntry(entry, composition), nil
+}
+
+func outboxItemFromEntry(e *db.OutboxEntry, c *Composition) *OutboxItem {
+	return &OutboxItem{
+		ID:          e.ID,
+		Composition: c,
+		Status:      OutboxStatus(e.Status),
+		Attempts:    e.Attempts,
+		LastError:   e.LastError,
+		QueuedAt:    time.Unix(e.QueuedAt, 0),
+		UpdatedAt:   time.Unix(e.UpdatedAt, 0),
+	}
+}
+
+// List returns the outbox in queue order, dropping delivered messages older than a day
+func (s *OutboxServiceImpl) List(ctx context.Context) ([]*OutboxItem, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	_, _ = s.store.DeleteByStatus(ctx, email, string(OutboxSent), time.Now().Add(-outboxSentRetention).Unix())
+	entries, err := s.store.List(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*OutboxItem, 0, len(entries))
+	for _, e := range entries {
+		c := &Composition{}
+		if err := json.Unmarshal([]byte(e.Composition), c); err != nil {
+			return nil, fmt.Errorf("failed to decode outbox entry %d: %w", e.ID, err)
+		}
+		out = append(out, outboxItemFromEntry(e, c))
+	}
+	return out, nil
+}
+
+// Flush sends the queued messages in order. It stops at the first network error (still offline);
+// other errors mark that message failed and the pass continues. Concurrent calls are dropped.
+func (s *OutboxServiceImpl) Flush(ctx context.Context) (OutboxFlushResult, error) {
+	var res OutboxFlushResult
+	if !s.flushMu.TryLock() {
+		return res, nil
+	}
+	defer s.flushMu.Unlock()
+
+	items, err := s.List(ctx)
+	if err != nil {
+		return res, err
+	}
+	for i, it := range items {
+		if it.Status != OutboxQueued && it.Status != OutboxSending {
+			continue
+		}
+		sent, err := s.sendItem(ctx, it)
+		switch {
+		case sent:
+			res.Sent++
+		case errors.Is(err, ErrNetworkUnavailable) || ctx.Err() != nil:
+			res.Offline = true
+			for _, rest := range items[i:] {
+				if rest.Status == OutboxQueued || rest.Status == OutboxSending {
+					res.Remaining++
+				}
+			}
+			_ = s.refreshCounts(ctx)
+			return res, nil
+		default:
+			res.Failed++
+		}
+	}
+	_ = s.refreshCounts(ctx)
+	return res, nil
+}
+
+// SendNow sends one message right away, whatever its status (retry from the outbox panel)
+func (s *OutboxServiceImpl) SendNow(ctx context.Context, id int64) error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	items, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, it := range items {
+		if it.ID != id {
+			continue
+		}
+		if it.Status == OutboxSent {
+			return nil
+		}
+		_, err := s.sendItem(ctx, it)
+		_ = s.refreshCounts(ctx)
+		return err
+	}
+	return fmt.Errorf("outbox entry not found")
+}
+
+// sendItem delivers one message and records the outcome. Labels that could not be applied do not
+// make the send fail, matching the composer.
+func (s *OutboxServiceImpl) sendItem(ctx context.Context, it *OutboxItem) (bool, error) {
+	email, err := s.account()
+	if err != nil {
+		return false, err
+	}
+	if s.send == nil {
+		return false, fmt.Errorf("composition service not available")
+	}
+	_ = s.store.UpdateStatus(ctx, email, it.ID, string(OutboxSending), it.LastError, false)
+
+	err = s.send(ctx, it.Composition)
+	var labelErr *SentLabelsError
+	if err == nil || errors.As(err, &labelErr) {
+		s.SetOffline(false)
+		return true, s.store.UpdateStatus(ctx, email, it.ID, string(OutboxSent), "", true)
+	}
+	status := OutboxFailed
+	if errors.Is(err, ErrNetworkUnavailable) {
+		status = OutboxQueued
+		s.SetOffline(true)
+	} else {
+		s.SetOffline(false)
+	}
+	// Record the outcome even when ctx was cancelled mid-send
+	_ = s.store.UpdateStatus(context.Background(), email, it.ID, string(status), err.Error(), true)
+	return false, err
+}
+
+// Discard removes a message from the outbox without sending it
+func (s *OutboxServiceImpl) Discard(ctx context.Context, id int64) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, email, id); err != nil {
+		return err
+	}
+	_ = s.refreshCounts(ctx)
+	return nil
+}
+
+// ClearSent removes the delivered messages from the list and returns how many were removed
+func (s *OutboxServiceImpl) ClearSent(ctx context.Context) (int, error) {
+	email, err := s.account()
+	if err != nil {
+		return 0, err
+	}
+	return s.store.DeleteByStatus(ctx, email, string(OutboxSent), 0)
+}
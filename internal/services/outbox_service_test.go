@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOutbox(t *testing.T) *OutboxServiceImpl {
+	t.Helper()
+	store, err := db.Open(context.Background(), t.TempDir()+"/outbox.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	svc := NewOutboxService(db.NewOutboxStore(store), nil)
+	svc.SetAccountEmail("me@example.com")
+	return svc
+}
+
+func outboxComposition(subject string) *Composition {
+	return &Composition{Type: CompositionTypeNew, Subject: subject, Body: "body", To: []Recipient{{Email: "ana@example.com"}}}
+}
+
+func TestOutbox_QueuesAndSendsInOrderOnceOnline(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestOutbox(t)
+	offline := &NetworkError{Op: "send message", Err: errors.New("no route to host")}
+
+	var sent []string
+	online := false
+	svc.SetSender(func(ctx context.Context, c *Composition) error {
+		if !online {
+			return offline
+		}
+		sent = append(sent, c.Subject)
+		return nil
+	})
+
+	_, err := svc.Enqueue(ctx, outboxComposition("first"), offline)
+	require.NoError(t, err)
+	_, err = svc.Enqueue(ctx, outboxComposition("second"), nil)
+	require.NoError(t, err)
+	pending, failed := svc.Counts()
+	assert.Equal(t, 2, pending)
+	assert.Zero(t, failed)
+
+	// Still offline: nothing leaves, the first message records the attempt
+	res, err := svc.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxFlushResult{Remaining: 2, Offline: true}, res)
+	assert.True(t, svc.IsOffline())
+	items, err := svc.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxQueued, items[0].Status)
+	assert.Equal(t, 1, items[0].Attempts)
+	assert.Contains(t, items[0].LastError, "no route to host")
+
+	online = true
+	res, err = svc.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxFlushResult{Sent: 2}, res)
+	assert.Equal(t, []string{"first", "second"}, sent)
+	assert.False(t, svc.IsOffline())
+	pending, _ = svc.Counts()
+	assert.Zero(t, pending)
+
+	items, err = svc.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxSent, items[1].Status)
+	n, err := svc.ClearSent(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestOutbox_RejectedMessagesFailWithoutBlockingTheQueue(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestOutbox(t)
+	svc.SetSender(func(ctx context.Context, c *Composition) error {
+		switch c.Subject {
+		case "bad":
+			return errors.New("invalid To header")
+		case "labels":
+			return &SentLabelsError{Labels: []string{"Clients"}, Err: errors.New("boom")}
+		}
+		return nil
+	})
+	for _, s := range []string{"bad", "labels", "ok"} {
+		_, err := svc.Enqueue(ctx, outboxComposition(s), nil)
+		require.NoError(t, err)
+	}
+
+	res, err := svc.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxFlushResult{Sent: 2, Failed: 1}, res)
+	pending, failed := svc.Counts()
+	assert.Zero(t, pending)
+	assert.Equal(t, 1, failed)
+
+	items, err := svc.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxFailed, items[0].Status)
+	assert.Equal(t, "invalid To header", items[0].LastError)
+
+	// A failed message can be retried or discarded from the panel
+	assert.Error(t, svc.SendNow(ctx, items[0].ID))
+	require.NoError(t, svc.Discard(ctx, items[0].ID))
+	_, failed = svc.Counts()
+	assert.Zero(t, failed)
+}
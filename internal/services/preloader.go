@@ -20,6 +20,11 @@ type MessagePreloaderImpl struct {
 	logger   *log.Logger
 	configMu sync.RWMutex
 
+	// Account partition: client, accountID and generation are guarded by cacheMu. Every switch
+	// bumps generation so results of tasks queued for the previous account are dropped.
+	accountID  string
+	generation uint64
+
 	// Cache management
 	messageCache   map[string]*CacheItem
 	pageCache      map[string]*PageCacheItem
@@ -68,6 +73,8 @@ type preloadTask struct {
 	Priority   int // 1=high, 2=normal, 3=low
 	CreatedAt  time.Time
 	Context    context.Context
+	Client     *gmail.Client // account client captured when the task was queued
+	Generation uint64        // account generation the task belongs to
 }
 
 // NewMessagePreloader creates a new MessagePreloader instance
@@ -165,6 +172,7 @@ func (p *MessagePreloaderImpl) PreloadNextPage(ctx context.Context, currentPageT
 		CreatedAt:  time.Now(),
 		Context:    ctx,
 	}
+	task.Client, task.Generation = p.partition()
 
 	// Queue task for background processing
 	select {
@@ -230,6 +238,7 @@ func (p *MessagePreloaderImpl) PreloadAdjacentMessages(ctx context.Context, curr
 		CreatedAt:  time.Now(),
 		Context:    ctx,
 	}
+	task.Client, task.Generation = p.partition()
 
 	// Queue task for background processing
 	select {
@@ -322,6 +331,39 @@ func (p *MessagePreloaderImpl) ClearCache(ctx context.Context) error {
 	return nil
 }
 
+// SwitchAccount points the preloader at another account: the client is replaced, both caches
+// are emptied and results of tasks still running for the previous account are discarded
+func (p *MessagePreloaderImpl) SwitchAccount(ctx context.Context, accountID string, client *gmail.Client) error {
+	if client == nil {
+		return fmt.Errorf("no Gmail client for account %s", accountID)
+	}
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+
+	p.client = client
+	p.accountID = accountID
+	p.generation++
+	p.messageCache = make(map[string]*CacheItem)
+	p.pageCache = make(map[string]*PageCacheItem)
+	p.evictionList = list.New()
+	p.currentMemory = 0
+	return nil
+}
+
+// AccountID returns the account the cached data belongs to
+func (p *MessagePreloaderImpl) AccountID() string {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	return p.accountID
+}
+
+// partition returns the current client and account generation
+func (p *MessagePreloaderImpl) partition() (*gmail.Client, uint64) {
+	p.cacheMu.RLock()
+	defer p.cacheMu.RUnlock()
+	return p.client, p.generation
+}
+
 // Configuration methods
 func (p *MessagePreloaderImpl) IsEnabled() bool {
 	p.configMu.RLock()
@@ -476,10 +518,10 @@ func (p *MessagePreloaderImpl) processNextPageTask(task *preloadTask) {
 	// Use appropriate API method based on whether we have a query
 	if task.Query != "" {
 		// For search queries, use search API
-		messages, nextPageToken, err = p.client.SearchMessagesPage(task.Query, task.MaxResults, task.PageToken)
+		messages, nextPageToken, err = task.Client.SearchMessagesPage(task.Query, task.MaxResults, task.PageToken)
 	} else {
 		// For inbox listing, use list API
-		messages, nextPageToken, err = p.client.ListMessagesPage(task.MaxResults, task.PageToken)
+		messages, nextPageToken, err = task.Client.ListMessagesPage(task.MaxResults, task.PageToken)
 	}
 
 	if err != nil {
@@ -498,7 +540,7 @@ func (p *MessagePreloaderImpl) processNextPageTask(task *preloadTask) {
 		}
 
 		// Use the existing parallel metadata fetching (optimized for lists)
-		detailedMessages, err := p.client.GetMessagesMetadataParallel(messageIDs, minInt(p.config.BackgroundWorkers, len(messageIDs)))
+		detailedMessages, err := task.Client.GetMessagesMetadataParallel(messageIDs, minInt(p.config.BackgroundWorkers, len(messageIDs)))
 		if err != nil {
 			// Log error but don't fail
 			return
@@ -508,6 +550,11 @@ func (p *MessagePreloaderImpl) processNextPageTask(task *preloadTask) {
 		p.cacheMu.Lock()
 		defer p.cacheMu.Unlock()
 
+		// The account changed while this page was loading: it belongs to another partition
+		if task.Generation != p.generation {
+			return
+		}
+
 		// Cache the detailed messages for this page along with next token
 		// Use a cache key that includes the query for differentiation
 		cacheKey := task.PageToken
@@ -571,7 +618,7 @@ func (p *MessagePreloaderImpl) processAdjacentTask(task *preloadTask) {
 	}
 
 	// Use existing parallel loading to fetch adjacent messages metadata
-	messages, err := p.client.GetMessagesMetadataParallel(uncachedIDs, minInt(p.config.BackgroundWorkers, len(uncachedIDs)))
+	messages, err := task.Client.GetMessagesMetadataParallel(uncachedIDs, minInt(p.config.BackgroundWorkers, len(uncachedIDs)))
 	if err != nil {
 		// Log error but don't fail
 		if p.logger != nil {
@@ -584,8 +631,9 @@ func (p *MessagePreloaderImpl) processAdjacentTask(task *preloadTask) {
 	cachedCount := 0
 	for _, message := range messages {
 		if message != nil {
-			p.cacheMessage(message.Id, message)
-			cachedCount++
+			if p.cacheMessage(task.Generation, message.Id, message) {
+				cachedCount++
+			}
 		}
 	}
 
@@ -595,18 +643,23 @@ func (p *MessagePreloaderImpl) processAdjacentTask(task *preloadTask) {
 	}
 }
 
-// cacheMessage adds a message to the cache with LRU management
-func (p *MessagePreloaderImpl) cacheMessage(messageID string, message *gmail_v1.Message) {
+// cacheMessage adds a message to the cache with LRU management. It reports whether the message
+// was stored; messages fetched for a previous account generation are dropped.
+func (p *MessagePreloaderImpl) cacheMessage(generation uint64, messageID string, message *gmail_v1.Message) bool {
 	if message == nil {
-		return // Don't cache nil messages
+		return false // Don't cache nil messages
 	}
 
 	p.cacheMu.Lock()
 	defer p.cacheMu.Unlock()
 
+	if generation != p.generation {
+		return false
+	}
+
 	// Check if already cached
 	if _, exists := p.messageCache[messageID]; exists {
-		return // Already cached
+		return false // Already cached
 	}
 
 	// Estimate message size based on content
@@ -636,6 +689,7 @@ func (p *MessagePreloaderImpl) cacheMessage(messageID string, message *gmail_v1.
 	p.statsMu.Lock()
 	p.stats.TotalDataPreloadedMB += float64(messageSize) / (1024 * 1024)
 	p.statsMu.Unlock()
+	return true
 }
 
 // estimateMessageSize estimates the memory size of a Gmail message
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/stretchr/testify/assert"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestPreloader_SwitchAccountClearsPartition(t *testing.T) {
+	p := NewMessagePreloader(&gmail.Client{}, DefaultPreloadConfig(), nil)
+	defer p.Shutdown()
+	ctx := context.Background()
+
+	assert.NoError(t, p.SwitchAccount(ctx, "work", &gmail.Client{}))
+	_, gen := p.partition()
+	assert.True(t, p.cacheMessage(gen, "m1", &gmail_v1.Message{Id: "m1"}))
+	p.cacheMu.Lock()
+	p.pageCache["tok"] = &PageCacheItem{Messages: []*gmail_v1.Message{{Id: "m1"}}}
+	p.cacheMu.Unlock()
+
+	assert.NoError(t, p.SwitchAccount(ctx, "personal", &gmail.Client{}))
+	assert.Equal(t, "personal", p.AccountID())
+	_, found := p.GetCachedMessage(ctx, "m1")
+	assert.False(t, found, "message of the previous account must not be served")
+	_, found = p.GetCachedMessages(ctx, "tok")
+	assert.False(t, found, "page of the previous account must not be served")
+
+	// A task queued for "work" finishing after the switch is dropped
+	assert.False(t, p.cacheMessage(gen, "m2", &gmail_v1.Message{Id: "m2"}))
+	_, found = p.GetCachedMessage(ctx, "m2")
+	assert.False(t, found)
+
+	assert.Error(t, p.SwitchAccount(ctx, "broken", nil))
+	assert.Equal(t, "personal", p.AccountID())
+}
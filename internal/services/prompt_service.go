@@ -339,6 +339,9 @@ func (s *PromptServiceImpl) UpdatePrompt(ctx context.Context, id int, name, desc
 	if s.store == nil {
 		return fmt.Errorf("store not available")
 	}
+	if err := s.checkWritable(ctx, id); err != nil {
+		return err
+	}
 	return s.store.UpdatePromptTemplate(ctx, id, name, description, promptText, category)
 }
 
@@ -347,9 +350,24 @@ func (s *PromptServiceImpl) DeletePrompt(ctx context.Context, id int) error {
 	if s.store == nil {
 		return fmt.Errorf("store not available")
 	}
+	if err := s.checkWritable(ctx, id); err != nil {
+		return err
+	}
 	return s.store.DeletePromptTemplate(ctx, id)
 }
 
+// checkWritable refuses changes to prompts merged from the shared pack
+func (s *PromptServiceImpl) checkWritable(ctx context.Context, id int) error {
+	p, err := s.store.GetPromptTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p.Source != "" {
+		return fmt.Errorf("prompt %q is %w", p.Name, ErrReadOnly)
+	}
+	return nil
+}
+
 // FindPromptByName finds a prompt template by name
 func (s *PromptServiceImpl) FindPromptByName(ctx context.Context, name string) (*PromptTemplate, error) {
 	if s.store == nil {
@@ -409,6 +427,9 @@ func (s *PromptServiceImpl) CreateFromFile(ctx context.Context, filePath string)
 	// Check if prompt with same name already exists
 	existing, err := s.store.FindPromptByName(ctx, frontMatter.Name)
 	if err == nil && existing != nil {
+		if existing.Source != "" {
+			return 0, fmt.Errorf("prompt %q is %w", existing.Name, ErrReadOnly)
+		}
 		// Prompt exists, update it
 		return existing.ID, s.store.UpdatePromptTemplate(ctx, existing.ID, frontMatter.Name, frontMatter.Description, promptText, frontMatter.Category)
 	}
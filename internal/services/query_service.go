@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -70,6 +71,10 @@ func (s *QueryServiceImpl) SaveQuery(ctx context.Context, name, query, descripti
 		category = "general"
 	}
 
+	if err := s.checkWritable(s.store.GetQueryByName(ctx, email, name)); err != nil {
+		return nil, err
+	}
+
 	savedQuery, err := s.store.SaveQuery(ctx, s.accountEmail, name, query, description, category)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save query: %w", err)
@@ -198,6 +203,10 @@ func (s *QueryServiceImpl) DeleteQuery(ctx context.Context, id int64) error {
 		return fmt.Errorf("invalid query ID")
 	}
 
+	if err := s.checkWritable(s.store.GetQueryByID(ctx, s.accountEmail, id)); err != nil {
+		return err
+	}
+
 	if err := s.store.DeleteQuery(ctx, s.accountEmail, id); err != nil {
 		return fmt.Errorf("failed to delete query: %w", err)
 	}
@@ -219,6 +228,10 @@ func (s *QueryServiceImpl) DeleteQueryByName(ctx context.Context, name string) e
 		return fmt.Errorf("query name cannot be empty")
 	}
 
+	if err := s.checkWritable(s.store.GetQueryByName(ctx, s.accountEmail, name)); err != nil {
+		return err
+	}
+
 	if err := s.store.DeleteQueryByName(ctx, s.accountEmail, name); err != nil {
 		return fmt.Errorf("failed to delete query: %w", err)
 	}
@@ -292,6 +305,9 @@ func (s *QueryServiceImpl) UpdateQueryCategory(ctx context.Context, id int64, ca
 	if err != nil {
 		return fmt.Errorf("failed to get query: %w", err)
 	}
+	if err := s.checkWritable(savedQuery, nil); err != nil {
+		return err
+	}
 
 	// Update the category by saving the query again
 	_, err = s.store.SaveQuery(ctx, s.accountEmail, savedQuery.Name, savedQuery.Query, savedQuery.Description, category)
@@ -302,6 +318,15 @@ func (s *QueryServiceImpl) UpdateQueryCategory(ctx context.Context, id int64, ca
 	return nil
 }
 
+// checkWritable refuses changes to a query merged from the shared pack; a lookup error means
+// there is no such query yet, which the caller handles
+func (s *QueryServiceImpl) checkWritable(sq *db.SavedQuery, err error) error {
+	if err != nil || sq == nil || sq.Source == "" {
+		return nil
+	}
+	return fmt.Errorf("query %q is %w", sq.Name, ErrReadOnly)
+}
+
 // convertToSavedQueryInfo converts a db.SavedQuery to SavedQueryInfo
 func (s *QueryServiceImpl) convertToSavedQueryInfo(sq *db.SavedQuery) *SavedQueryInfo {
 	return &SavedQueryInfo{
@@ -313,6 +338,7 @@ func (s *QueryServiceImpl) convertToSavedQueryInfo(sq *db.SavedQuery) *SavedQuer
 		UseCount:    sq.UseCount,
 		LastUsed:    sq.LastUsed,
 		CreatedAt:   sq.CreatedAt,
+		Source:      sq.Source,
 	}
 }
 
@@ -413,3 +439,75 @@ func (s *QueryServiceImpl) GetRecentQueries(ctx context.Context, limit int) ([]*
 
 	return recentQueries, nil
 }
+
+// queryParamPattern matches template parameters such as {sender} or {days}. Only identifier-like
+// names count, so Gmail's own brace OR-groups ({from:a from:b}) are left untouched.
+var queryParamPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// QueryParams returns the template parameter names of a saved query, in order of first use
+func QueryParams(query string) []string {
+	var params []string
+	seen := make(map[string]bool)
+	for _, m := range queryParamPattern.FindAllStringSubmatch(query, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			params = append(params, m[1])
+		}
+	}
+	return params
+}
+
+// ExpandQueryParams substitutes parameter values into a query template. Every parameter needs a
+// non-empty value.
+func ExpandQueryParams(query string, values map[string]string) (string, error) {
+	for _, p := range QueryParams(query) {
+		if strings.TrimSpace(values[p]) == "" {
+			return "", fmt.Errorf("missing value for {%s}", p)
+		}
+	}
+	return queryParamPattern.ReplaceAllStringFunc(query, func(m string) string {
+		return strings.TrimSpace(values[m[1:len(m)-1]])
+	}), nil
+}
+
+// RecordParamValues remembers the values used for template parameters
+func (s *QueryServiceImpl) RecordParamValues(ctx context.Context, values map[string]string) error {
+	if s.store == nil {
+		return fmt.Errorf("query store not available")
+	}
+
+	email := s.GetAccountEmail()
+	if strings.TrimSpace(email) == "" {
+		return fmt.Errorf("account email not set")
+	}
+
+	for param, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if err := s.store.RecordParamValue(ctx, email, param, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("failed to record parameter value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetRecentParamValues returns recently used values for a template parameter, newest first
+func (s *QueryServiceImpl) GetRecentParamValues(ctx context.Context, param string, limit int) ([]string, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("query store not available")
+	}
+
+	email := s.GetAccountEmail()
+	if strings.TrimSpace(email) == "" {
+		return nil, fmt.Errorf("account email not set")
+	}
+
+	values, err := s.store.GetRecentParamValues(ctx, email, param, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parameter values: %w", err)
+	}
+
+	return values, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -191,6 +192,35 @@ func TestQueryServiceImpl_StateManagement(t *testing.T) {
 	}
 }
 
+func TestQueryParams(t *testing.T) {
+	assert.Equal(t, []string{"sender", "days"}, QueryParams("from:{sender} newer_than:{days}d OR to:{sender}"))
+	assert.Empty(t, QueryParams("from:ana is:unread"))
+	// Gmail brace OR-groups are not parameters
+	assert.Empty(t, QueryParams("{from:ana from:bob} { invoice }"))
+}
+
+func TestExpandQueryParams(t *testing.T) {
+	q, err := ExpandQueryParams("from:{sender} newer_than:{days}d", map[string]string{"sender": " ana@example.com ", "days": "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "from:ana@example.com newer_than:7d", q)
+
+	_, err = ExpandQueryParams("from:{sender} newer_than:{days}d", map[string]string{"sender": "ana"})
+	assert.Error(t, err)
+
+	q, err = ExpandQueryParams("{from:a from:b}", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "{from:a from:b}", q)
+}
+
+func TestQueryServiceImpl_ParamValues_NoStore(t *testing.T) {
+	service := NewQueryService(nil, nil)
+	service.SetAccountEmail("test@example.com")
+
+	assert.Error(t, service.RecordParamValues(context.Background(), map[string]string{"sender": "x"}))
+	_, err := service.GetRecentParamValues(context.Background(), "sender", 5)
+	assert.Error(t, err)
+}
+
 // Benchmark query service operations
 func BenchmarkQueryService_SetAccountEmail(b *testing.B) {
 	service := NewQueryService(nil, nil)
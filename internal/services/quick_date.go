@@ -0,0 +1,551 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quickDateLocale holds the words quick dates understand in one language. Each weekday (Sunday
+// first) and month lists its accepted spellings, the first being the one previews show.
+type quickDateLocale struct {
+	weekdays    [7][]string
+	months      [12][]string
+	now         []string
+	today       []string
+	tomorrow    []string
+	dayAfter    []string // the day after tomorrow, as one word
+	yesterday   []string
+	next        []string
+	last        []string // "last tue", "last week"
+	ago         []string // words marking a duration as past: "3 days ago", "hace 3 días"
+	week        []string
+	month       []string
+	noon        []string
+	in          []string // words starting a duration: "in 2h"
+	timeFillers []string // words before a bare hour: "at 9"
+	fillers     []string // words ignored anywhere
+}
+
+// quickDateLocales are the languages quick dates can add to English (quick_dates.locale)
+var quickDateLocales = map[string]quickDateLocale{
+	"en": {
+		weekdays: [7][]string{
+			{"sun", "sunday"}, {"mon", "monday"}, {"tue", "tuesday", "tues"}, {"wed", "wednesday"},
+			{"thu", "thursday", "thur", "thurs"}, {"fri", "friday"}, {"sat", "saturday"},
+		},
+		months: [12][]string{
+			{"jan", "january"}, {"feb", "february"}, {"mar", "march"}, {"apr", "april"}, {"may"}, {"jun", "june"},
+			{"jul", "july"}, {"aug", "august"}, {"sep", "september", "sept"}, {"oct", "october"}, {"nov", "november"}, {"dec", "december"},
+		},
+		now:         []string{"now"},
+		today:       []string{"today"},
+		tomorrow:    []string{"tomorrow", "tmrw"},
+		yesterday:   []string{"yesterday"},
+		next:        []string{"next", "coming"},
+		last:        []string{"last", "previous", "past"},
+		ago:         []string{"ago"},
+		week:        []string{"week"},
+		month:       []string{"month"},
+		noon:        []string{"noon", "midday"},
+		in:          []string{"in"},
+		timeFillers: []string{"at", "@"},
+		fillers:     []string{"on", "the", "of", "and", "this"},
+	},
+	"es": {
+		weekdays: [7][]string{
+			{"dom", "domingo"}, {"lun", "lunes"}, {"mar", "martes"}, {"mié", "miércoles", "miercoles", "mie"},
+			{"jue", "jueves"}, {"vie", "viernes"}, {"sáb", "sábado", "sabado", "sab"},
+		},
+		months: [12][]string{
+			{"ene", "enero"}, {"feb", "febrero"}, {"mar", "marzo"}, {"abr", "abril"}, {"may", "mayo"}, {"jun", "junio"},
+			{"jul", "julio"}, {"ago", "agosto"}, {"sep", "septiembre", "setiembre", "sept"}, {"oct", "octubre"}, {"nov", "noviembre"}, {"dic", "diciembre"},
+		},
+		now:         []string{"ahora"},
+		today:       []string{"hoy"},
+		tomorrow:    []string{"mañana", "manana"},
+		dayAfter:    []string{"pasado-mañana", "pasadomañana"},
+		yesterday:   []string{"ayer"},
+		next:        []string{"próximo", "proximo", "próxima", "proxima", "siguiente"},
+		last:        []string{"pasado", "pasada", "anterior"},
+		ago:         []string{"hace"},
+		week:        []string{"semana"},
+		month:       []string{"mes"},
+		noon:        []string{"mediodía", "mediodia"},
+		in:          []string{"en", "dentro"},
+		timeFillers: []string{"a", "las", "la"},
+		fillers:     []string{"el", "de", "del", "y", "este", "esta"},
+	},
+	"fr": {
+		weekdays: [7][]string{
+			{"dim", "dimanche"}, {"lun", "lundi"}, {"mar", "mardi"}, {"mer", "mercredi"},
+			{"jeu", "jeudi"}, {"ven", "vendredi"}, {"sam", "samedi"},
+		},
+		months: [12][]string{
+			{"janv", "janvier", "jan"}, {"févr", "février", "fevrier", "fev"}, {"mars"}, {"avr", "avril"}, {"mai"}, {"juin"},
+			{"juil", "juillet"}, {"août", "aout"}, {"sept", "septembre"}, {"oct", "octobre"}, {"nov", "novembre"}, {"déc", "décembre", "decembre", "dec"},
+		},
+		now:         []string{"maintenant"},
+		today:       []string{"aujourd'hui", "aujourdhui"},
+		tomorrow:    []string{"demain"},
+		dayAfter:    []string{"après-demain", "apres-demain"},
+		yesterday:   []string{"hier"},
+		next:        []string{"prochain", "prochaine"},
+		last:        []string{"dernier", "dernière", "derniere"},
+		week:        []string{"semaine"},
+		month:       []string{"mois"},
+		noon:        []string{"midi"},
+		in:          []string{"dans"},
+		timeFillers: []string{"à", "a"},
+		fillers:     []string{"le", "la", "de", "du", "et", "ce", "cette"},
+	},
+	"de": {
+		weekdays: [7][]string{
+			{"so", "sonntag"}, {"mo", "montag"}, {"di", "dienstag"}, {"mi", "mittwoch"},
+			{"do", "donnerstag"}, {"fr", "freitag"}, {"sa", "samstag", "sonnabend"},
+		},
+		months: [12][]string{
+			{"jan", "januar"}, {"feb", "februar"}, {"mär", "märz", "maerz", "mrz"}, {"apr", "april"}, {"mai"}, {"jun", "juni"},
+			{"jul", "juli"}, {"aug", "august"}, {"sep", "september", "sept"}, {"okt", "oktober"}, {"nov", "november"}, {"dez", "dezember"},
+		},
+		now:         []string{"jetzt"},
+		today:       []string{"heute"},
+		tomorrow:    []string{"morgen"},
+		dayAfter:    []string{"übermorgen", "uebermorgen"},
+		yesterday:   []string{"gestern"},
+		next:        []string{"nächsten", "nächster", "nächste", "naechsten", "kommenden", "kommender"},
+		last:        []string{"letzten", "letzter", "letzte", "vorigen", "vergangenen"},
+		ago:         []string{"vor"},
+		week:        []string{"woche"},
+		month:       []string{"monat"},
+		noon:        []string{"mittag"},
+		in:          []string{"in"},
+		timeFillers: []string{"um"},
+		fillers:     []string{"am", "den", "der", "und", "uhr", "diesen"},
+	},
+	"pt": {
+		weekdays: [7][]string{
+			{"dom", "domingo"}, {"seg", "segunda", "segunda-feira"}, {"ter", "terça", "terca", "terça-feira"}, {"qua", "quarta", "quarta-feira"},
+			{"qui", "quinta", "quinta-feira"}, {"sex", "sexta", "sexta-feira"}, {"sáb", "sábado", "sabado", "sab"},
+		},
+		months: [12][]string{
+			{"jan", "janeiro"}, {"fev", "fevereiro"}, {"mar", "março", "marco"}, {"abr", "abril"}, {"mai", "maio"}, {"jun", "junho"},
+			{"jul", "julho"}, {"ago", "agosto"}, {"set", "setembro"}, {"out", "outubro"}, {"nov", "novembro"}, {"dez", "dezembro"},
+		},
+		now:         []string{"agora"},
+		today:       []string{"hoje"},
+		tomorrow:    []string{"amanhã", "amanha"},
+		dayAfter:    []string{"depois-de-amanhã"},
+		yesterday:   []string{"ontem"},
+		next:        []string{"próximo", "proximo", "próxima", "proxima"},
+		last:        []string{"passado", "passada", "último", "ultimo", "última", "ultima"},
+		ago:         []string{"há", "atrás", "atras"},
+		week:        []string{"semana"},
+		month:       []string{"mês", "mes"},
+		noon:        []string{"meio-dia"},
+		in:          []string{"em", "daqui"},
+		timeFillers: []string{"às", "as"},
+		fillers:     []string{"a", "o", "de", "do", "da", "e", "este", "esta"},
+	},
+}
+
+// quickDateUnits are the duration units of "in 2h" in every language
+var quickDateUnits = map[string]time.Duration{
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"minuto": time.Minute, "minutos": time.Minute, "minuten": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour, "hora": time.Hour,
+	"horas": time.Hour, "heure": time.Hour, "heures": time.Hour, "stunde": time.Hour, "stunden": time.Hour, "std": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour, "día": 24 * time.Hour, "días": 24 * time.Hour,
+	"dia": 24 * time.Hour, "dias": 24 * time.Hour, "jour": 24 * time.Hour, "jours": 24 * time.Hour, "tag": 24 * time.Hour, "tage": 24 * time.Hour, "tagen": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"semana": 7 * 24 * time.Hour, "semanas": 7 * 24 * time.Hour, "semaine": 7 * 24 * time.Hour, "semaines": 7 * 24 * time.Hour,
+	"woche": 7 * 24 * time.Hour, "wochen": 7 * 24 * time.Hour,
+}
+
+var (
+	// quickTimePattern matches a time of day: 9am, 9:30, 9.30pm, 17h, 17h30
+	quickTimePattern = regexp.MustCompile(`^(\d{1,2})(?:([:.h])(\d{2})?)?(am|pm)?$`)
+	// quickISODatePattern matches 2026-10-20 and 2026/10/20
+	quickISODatePattern = regexp.MustCompile(`^(\d{4})[-/](\d{1,2})[-/](\d{1,2})$`)
+	// quickDurationPattern matches the amounts of a duration: 2h, 1h30m, 2 hours 30 minutes
+	quickDurationPattern = regexp.MustCompile(`(\d+)\s*([^\d\s]*)`)
+)
+
+// QuickDateOptions are the settings of ParseQuickDate
+type QuickDateOptions struct {
+	// Locale adds the words of "es", "fr", "de" or "pt" to English
+	Locale string
+	// DefaultHour and DefaultMinute are the time of a date given without one
+	DefaultHour, DefaultMinute int
+	// Past reads dates backwards, to look something up: a weekday or date is its latest
+	// occurrence, a Gmail age such as 10d, 2m or 1y counts back, and the result must not be in
+	// the future
+	Past bool
+}
+
+// quickDateWords returns the word lists for a locale: English plus the locale's own
+func quickDateWords(locale string) []quickDateLocale {
+	words := []quickDateLocale{quickDateLocales["en"]}
+	if l, ok := quickDateLocales[strings.ToLower(locale)]; ok && !strings.EqualFold(locale, "en") {
+		words = append(words, l)
+	}
+	return words
+}
+
+// quickWordIn reports whether word is one of the words a field of the locales lists
+func quickWordIn(locales []quickDateLocale, field func(quickDateLocale) []string, word string) bool {
+	for _, l := range locales {
+		for _, w := range field(l) {
+			if w == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quickNameIndex returns the index of word among weekdays or months (-1 when it is none)
+func quickNameIndex[T [7][]string | [12][]string](locales []quickDateLocale, names func(quickDateLocale) T, word string) int {
+	for _, l := range locales {
+		list := names(l)
+		for i := range len(list) {
+			for _, w := range list[i] {
+				if w == word {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// quickDateTokens lowercases and splits input, joining a number with a following am/pm
+func quickDateTokens(input string) []string {
+	fields := strings.Fields(strings.NewReplacer(",", " ").Replace(strings.ToLower(input)))
+	var tokens []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if i+1 < len(fields) && (fields[i+1] == "am" || fields[i+1] == "pm") && quickTimePattern.MatchString(f+fields[i+1]) {
+			f += fields[i+1]
+			i++
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}
+
+// ParseQuickDate reads a date and time typed the short way, relative to now: "in 2h", "tomorrow
+// 9am", "next mon", "friday 14:30", "oct 20", "2026-10-20 8:00" or "noon", in English or the
+// configured locale ("mañana 9am", "lundi prochain", "nächsten freitag um 10"). A weekday is its
+// next occurrence after today; a date without a time gets the default time; a time alone is
+// today, or tomorrow once it has passed. The result must be in the future. With opts.Past it
+// reads "yesterday", "last fri", "3 days ago" or "oct 1" as the latest such time instead.
+func ParseQuickDate(input string, now time.Time, opts QuickDateOptions) (time.Time, error) {
+	locales := quickDateWords(opts.Locale)
+	tokens := quickDateTokens(input)
+	if len(tokens) == 0 {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	if len(tokens) == 1 && quickWordIn(locales, func(l quickDateLocale) []string { return l.now }, tokens[0]) {
+		return now, nil
+	}
+	if opts.Past && len(tokens) == 1 {
+		if age, err := parseLocalAge(tokens[0]); err == nil {
+			return now.Add(-age), nil
+		}
+	}
+	if quickWordIn(locales, func(l quickDateLocale) []string { return l.in }, tokens[0]) {
+		d, err := parseQuickDuration(tokens[1:], locales)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return quickDirection(now.Add(d), now, opts)
+	}
+	isAgo := func(tok string) bool {
+		return quickWordIn(locales, func(l quickDateLocale) []string { return l.ago }, tok)
+	}
+	if isAgo(tokens[0]) || isAgo(tokens[len(tokens)-1]) {
+		rest := tokens[1:]
+		if !isAgo(tokens[0]) {
+			rest = tokens[:len(tokens)-1]
+		}
+		// not a duration: "3 ago" is 3 August in Spanish
+		if d, err := parseQuickDuration(rest, locales); err == nil {
+			return quickDirection(now.Add(-d), now, opts)
+		}
+	}
+
+	var (
+		dayOffset, hasDayOffset   = 0, false
+		weekday, month, day       = -1, -1, -1
+		hour, minute              = -1, 0
+		next, nextWeek, nextMonth bool
+		last                      bool
+		isoDate                   time.Time
+		expectHour, hasDayNumber  bool
+	)
+	var names []string // words that may be a weekday or a month ("mar" in Spanish)
+	for _, tok := range tokens {
+		is := func(field func(quickDateLocale) []string) bool { return quickWordIn(locales, field, tok) }
+		wasExpectingHour := expectHour
+		expectHour = false
+		switch {
+		case is(func(l quickDateLocale) []string { return l.today }):
+			dayOffset, hasDayOffset = 0, true
+		case is(func(l quickDateLocale) []string { return l.tomorrow }):
+			dayOffset, hasDayOffset = 1, true
+		case is(func(l quickDateLocale) []string { return l.dayAfter }):
+			dayOffset, hasDayOffset = 2, true
+		case is(func(l quickDateLocale) []string { return l.yesterday }):
+			dayOffset, hasDayOffset = -1, true
+		case is(func(l quickDateLocale) []string { return l.noon }):
+			hour, minute = 12, 0
+		case is(func(l quickDateLocale) []string { return l.week }):
+			nextWeek = true
+		case is(func(l quickDateLocale) []string { return l.month }):
+			nextMonth = true
+		case is(func(l quickDateLocale) []string { return l.next }):
+			next = true
+		case is(func(l quickDateLocale) []string { return l.last }):
+			last = true
+		case quickISODatePattern.MatchString(tok):
+			t, err := time.ParseInLocation("2006-1-2", strings.ReplaceAll(tok, "/", "-"), now.Location())
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid date %q", tok)
+			}
+			isoDate = t
+		case quickTimePattern.MatchString(tok) && (wasExpectingHour || !isPlainNumber(tok)):
+			h, m, err := parseQuickTime(tok)
+			if err != nil {
+				return time.Time{}, err
+			}
+			hour, minute = h, m
+		case isPlainNumber(tok):
+			n, _ := strconv.Atoi(tok)
+			if n < 1 || n > 31 {
+				return time.Time{}, fmt.Errorf("%q is not a day of the month", tok)
+			}
+			day, hasDayNumber = n, true
+		case quickNameIndex(locales, func(l quickDateLocale) [7][]string { return l.weekdays }, tok) >= 0 ||
+			quickNameIndex(locales, func(l quickDateLocale) [12][]string { return l.months }, tok) >= 0:
+			names = append(names, tok)
+		case is(func(l quickDateLocale) []string { return l.timeFillers }):
+			expectHour = true
+		case is(func(l quickDateLocale) []string { return l.fillers }):
+		default:
+			return time.Time{}, fmt.Errorf("unknown word %q", tok)
+		}
+	}
+	// A name is a month next to a day number ("mar 3"), otherwise a weekday ("mar")
+	for _, name := range names {
+		m := quickNameIndex(locales, func(l quickDateLocale) [12][]string { return l.months }, name)
+		w := quickNameIndex(locales, func(l quickDateLocale) [7][]string { return l.weekdays }, name)
+		if m >= 0 && (hasDayNumber || w < 0) {
+			month = m + 1
+		} else {
+			weekday = w
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	back := opts.Past || last
+	var date time.Time
+	switch {
+	case !isoDate.IsZero():
+		date = isoDate
+	case month > 0:
+		if day < 0 {
+			day = 1
+		}
+		date = time.Date(now.Year(), time.Month(month), day, 0, 0, 0, 0, now.Location())
+		if !opts.Past && date.Before(today) {
+			date = date.AddDate(1, 0, 0)
+		} else if opts.Past && date.After(today) {
+			date = date.AddDate(-1, 0, 0)
+		}
+	case weekday >= 0:
+		ahead := (weekday - int(now.Weekday()) + 7) % 7
+		if back {
+			ahead = -((int(now.Weekday()) - weekday + 7) % 7)
+		}
+		if ahead == 0 {
+			ahead = 7
+			if back {
+				ahead = -7
+			}
+		}
+		date = today.AddDate(0, 0, ahead)
+	case hasDayOffset:
+		date = today.AddDate(0, 0, dayOffset)
+	case nextWeek && (next || last):
+		monday := today.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+		if next {
+			date = monday.AddDate(0, 0, 7)
+		} else {
+			date = monday.AddDate(0, 0, -7)
+		}
+	case nextMonth && (next || last):
+		if next {
+			date = time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+		} else {
+			date = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
+		}
+	case day > 0:
+		date = time.Date(now.Year(), now.Month(), day, 0, 0, 0, 0, now.Location())
+		if !opts.Past && date.Before(today) {
+			date = date.AddDate(0, 1, 0)
+		} else if opts.Past && date.After(today) {
+			date = date.AddDate(0, -1, 0)
+		}
+	case hour >= 0:
+		t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !opts.Past && !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		} else if opts.Past && t.After(now) {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("no date or time in %q", input)
+	}
+
+	if hour < 0 {
+		switch {
+		case date.Equal(today) && opts.Past:
+			return now, nil
+		case date.Equal(today):
+			// "today" alone: the start of the next hour
+			return now.Truncate(time.Hour).Add(time.Hour), nil
+		}
+		hour, minute = opts.DefaultHour, opts.DefaultMinute
+	}
+	t := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+	return quickDirection(t, now, opts)
+}
+
+// quickDirection rejects a resolved time on the wrong side of now: in the past, or with
+// opts.Past in the future
+func quickDirection(t, now time.Time, opts QuickDateOptions) (time.Time, error) {
+	if opts.Past && t.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the future", t.Format("Mon 2 Jan 15:04"))
+	}
+	if !opts.Past && !t.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the past", t.Format("Mon 2 Jan 15:04"))
+	}
+	return t, nil
+}
+
+// isPlainNumber reports whether tok is only digits
+func isPlainNumber(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseQuickTime reads a time token such as 9am, 9:30pm, 17h30 or (after "at") 9
+func parseQuickTime(tok string) (hour, minute int, err error) {
+	m := quickTimePattern.FindStringSubmatch(tok)
+	hour, _ = strconv.Atoi(m[1])
+	if m[3] != "" {
+		minute, _ = strconv.Atoi(m[3])
+	}
+	if m[4] != "" && (hour < 1 || hour > 12) {
+		return 0, 0, fmt.Errorf("invalid time %q", tok)
+	}
+	switch m[4] {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time %q", tok)
+	}
+	return hour, minute, nil
+}
+
+// parseQuickDuration reads the duration after "in" (or of "ago"): 2h, 30 min, 1h30, 3 days
+func parseQuickDuration(tokens []string, locales []quickDateLocale) (time.Duration, error) {
+	var kept []string
+	for _, tok := range tokens {
+		if quickWordIn(locales, func(l quickDateLocale) []string { return l.fillers }, tok) ||
+			quickWordIn(locales, func(l quickDateLocale) []string { return l.timeFillers }, tok) {
+			continue
+		}
+		kept = append(kept, tok)
+	}
+	text := strings.Join(kept, " ")
+	matches := quickDurationPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 || strings.TrimSpace(quickDurationPattern.ReplaceAllString(text, "")) != "" {
+		return 0, fmt.Errorf("invalid duration %q (e.g. 2h, 30m, 3d)", text)
+	}
+	var total, last time.Duration
+	for _, m := range matches {
+		n, _ := strconv.Atoi(m[1])
+		unit, ok := quickDateUnits[m[2]]
+		if m[2] == "" && last == time.Hour {
+			unit, ok = time.Minute, true // 1h30
+		}
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q (use m, h, d or w)", m[2])
+		}
+		total += time.Duration(n) * unit
+		last = unit
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("the duration must be positive")
+	}
+	return total, nil
+}
+
+// FormatQuickDate shows a resolved quick date for confirmation, with weekday and month named in
+// the locale and the time left, or gone for a past one: "Fri 17 Oct 09:00 (in 2d 3h)". The year
+// appears when it is not the current one.
+func FormatQuickDate(t, now time.Time, locale string) string {
+	l, ok := quickDateLocales[strings.ToLower(locale)]
+	if !ok {
+		l = quickDateLocales["en"]
+	}
+	title := func(s string) string {
+		r := []rune(s)
+		return strings.ToUpper(string(r[0])) + string(r[1:])
+	}
+	s := fmt.Sprintf("%s %d %s", title(l.weekdays[t.Weekday()][0]), t.Day(), title(l.months[t.Month()-1][0]))
+	if t.Year() != now.Year() {
+		s += fmt.Sprintf(" %d", t.Year())
+	}
+	if t.Before(now) {
+		return s + " " + t.Format("15:04") + " (" + formatQuickWait(now.Sub(t)) + " ago)"
+	}
+	return s + " " + t.Format("15:04") + " (in " + formatQuickWait(t.Sub(now)) + ")"
+}
+
+// formatQuickWait renders a wait in its two largest units: 45m, 3h 10m, 2d 4h
+func formatQuickWait(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days, hours, minutes := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuickDate(t *testing.T) {
+	// Saturday 17 October 2026, 15:20
+	now := time.Date(2026, 10, 17, 15, 20, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	opts := QuickDateOptions{DefaultHour: 9}
+	cases := []struct {
+		locale, input string
+		want          time.Time
+	}{
+		{"", "in 2h", now.Add(2 * time.Hour)},
+		{"", "in 1h30", now.Add(90 * time.Minute)},
+		{"", "in 3 days", now.AddDate(0, 0, 3)},
+		{"", "tomorrow 9am", at(10, 18, 9, 0)},
+		{"", "tomorrow", at(10, 18, 9, 0)},
+		{"", "next mon", at(10, 19, 9, 0)},
+		{"", "friday", at(10, 23, 9, 0)},
+		{"", "saturday", at(10, 24, 9, 0)}, // today: the next one
+		{"", "fri 2:30pm", at(10, 23, 14, 30)},
+		{"", "at 16", at(10, 17, 16, 0)},
+		{"", "9am", at(10, 18, 9, 0)}, // passed today
+		{"", "oct 20 8:00", at(10, 20, 8, 0)},
+		{"", "3 mar", time.Date(2027, 3, 3, 9, 0, 0, 0, time.UTC)},
+		{"", "next week", at(10, 19, 9, 0)},
+		{"", "next month", at(11, 1, 9, 0)},
+		{"", "2026-12-01 noon", at(12, 1, 12, 0)},
+		{"", "today", at(10, 17, 16, 0)},
+		{"es", "mañana a las 10", at(10, 18, 10, 0)},
+		{"es", "próximo mar", at(10, 20, 9, 0)},                         // martes
+		{"es", "3 de mar", time.Date(2027, 3, 3, 9, 0, 0, 0, time.UTC)}, // marzo
+		{"es", "dentro de 2 horas", now.Add(2 * time.Hour)},
+		{"fr", "lundi prochain 8h30", at(10, 19, 8, 30)},
+		{"de", "nächsten freitag um 10", at(10, 23, 10, 0)},
+		{"pt", "amanhã às 14h", at(10, 18, 14, 0)},
+	}
+	for _, c := range cases {
+		o := opts
+		o.Locale = c.locale
+		got, err := ParseQuickDate(c.input, now, o)
+		if err != nil {
+			t.Errorf("%q (%s): %v", c.input, c.locale, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("%q (%s): got %s, want %s", c.input, c.locale, got.Format(time.RFC1123), c.want.Format(time.RFC1123))
+		}
+	}
+
+	for _, bad := range []string{"", "whenever", "today 8am", "in 2 fortnights", "13pm", "32"} {
+		if _, err := ParseQuickDate(bad, now, opts); err == nil {
+			t.Errorf("%q should not parse", bad)
+		}
+	}
+	if _, err := ParseQuickDate("mañana", now, opts); err == nil {
+		t.Error("locale words need the locale")
+	}
+}
+
+func TestParseQuickDate_Past(t *testing.T) {
+	// Saturday 17 October 2026, 15:20
+	now := time.Date(2026, 10, 17, 15, 20, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	opts := QuickDateOptions{DefaultHour: 9, Past: true}
+	cases := []struct {
+		locale, input string
+		want          time.Time
+	}{
+		{"", "2026-08-01", at(8, 1, 9, 0)},
+		{"", "2026/08/01", at(8, 1, 9, 0)},
+		{"", "yesterday", at(10, 16, 9, 0)},
+		{"", "10d", now.Add(-10 * 24 * time.Hour)},
+		{"", "2m", now.Add(-60 * 24 * time.Hour)}, // a Gmail age: months
+		{"", "3 days ago", now.AddDate(0, 0, -3)},
+		{"", "last tuesday", at(10, 13, 9, 0)},
+		{"", "tue", at(10, 13, 9, 0)},
+		{"", "saturday", at(10, 10, 9, 0)}, // today: the previous one
+		{"", "oct 20", time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)},
+		{"", "20", at(9, 20, 9, 0)},
+		{"", "last week", at(10, 5, 9, 0)},
+		{"", "last month", at(9, 1, 9, 0)},
+		{"", "today", now},
+		{"", "16:00", at(10, 16, 16, 0)}, // not yet today
+		{"es", "ayer", at(10, 16, 9, 0)},
+		{"es", "el martes pasado", at(10, 13, 9, 0)},
+		{"es", "hace 2 días", now.AddDate(0, 0, -2)},
+		{"es", "3 ago", at(8, 3, 9, 0)}, // agosto
+		{"fr", "mardi dernier", at(10, 13, 9, 0)},
+		{"de", "vor 3 tagen", now.AddDate(0, 0, -3)},
+		{"pt", "ontem", at(10, 16, 9, 0)},
+	}
+	for _, c := range cases {
+		o := opts
+		o.Locale = c.locale
+		got, err := ParseQuickDate(c.input, now, o)
+		if err != nil {
+			t.Errorf("%q (%s): %v", c.input, c.locale, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("%q (%s): got %s, want %s", c.input, c.locale, got.Format(time.RFC1123), c.want.Format(time.RFC1123))
+		}
+	}
+
+	for _, bad := range []string{"tomorrow", "in 2h", "next month", "2026-12-01"} {
+		if _, err := ParseQuickDate(bad, now, opts); err == nil {
+			t.Errorf("%q should not parse as a past date", bad)
+		}
+	}
+	if _, err := ParseQuickDate("last tuesday", now, QuickDateOptions{DefaultHour: 9}); err == nil {
+		t.Error("a past date should not parse for a reminder")
+	}
+}
+
+func TestFormatQuickDate(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 20, 0, 0, time.UTC)
+	when := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+	if got := FormatQuickDate(when, now, "en"); got != "Mon 19 Oct 18:00 (in 2d 2h)" {
+		t.Errorf("en preview: %q", got)
+	}
+	if got := FormatQuickDate(when, now, "es"); !strings.HasPrefix(got, "Lun 19 Oct 18:00") {
+		t.Errorf("es preview: %q", got)
+	}
+	if got := FormatQuickDate(time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC), now, "de"); !strings.HasPrefix(got, "Mo 4 Jan 2027 09:00") {
+		t.Errorf("another year shows it: %q", got)
+	}
+	if got := FormatQuickDate(time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC), now, "en"); got != "Tue 13 Oct 09:00 (4d 6h ago)" {
+		t.Errorf("past preview: %q", got)
+	}
+}
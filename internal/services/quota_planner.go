@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/gmail"
+)
+
+// QuotaOperation names a bulk job kind for quota planning
+type QuotaOperation string
+
+const (
+	QuotaOpArchive    QuotaOperation = "archive"
+	QuotaOpTrash      QuotaOperation = "trash"
+	QuotaOpMarkRead   QuotaOperation = "mark_read"
+	QuotaOpApplyLabel QuotaOperation = "apply_label"
+	QuotaOpRemove     QuotaOperation = "remove_label"
+	QuotaOpLocal      QuotaOperation = "local_archive"
+)
+
+// quotaUnitsPerMessage is the per-message Gmail quota cost of each bulk job. The label changes
+// go through batchModify (see quotaBatchModifyUnits) and cost nothing per message; archive,
+// trash and read/unread also fetch each message's labels first to record undo, 5 units per
+// messages.get. The local archive fetches the raw and the full message and trashes it one by one.
+var quotaUnitsPerMessage = map[QuotaOperation]int{
+	QuotaOpArchive:    5,
+	QuotaOpTrash:      5,
+	QuotaOpMarkRead:   5,
+	QuotaOpApplyLabel: 0,
+	QuotaOpRemove:     0,
+	QuotaOpLocal:      20,
+}
+
+// quotaBatchModifyUnits is the cost of one batchModify call, which changes up to
+// gmail.MaxBatchModifyIDs messages; every bulk job but the local archive is made of them
+const quotaBatchModifyUnits = 50
+
+// QuotaUnits returns the estimated quota units a job of op on that many messages consumes
+func QuotaUnits(op QuotaOperation, messages int) int {
+	if messages <= 0 {
+		return 0
+	}
+	perMessage, ok := quotaUnitsPerMessage[op]
+	if !ok {
+		perMessage = 5
+	}
+	units := messages * perMessage
+	if op != QuotaOpLocal {
+		chunks := (messages + gmail.MaxBatchModifyIDs - 1) / gmail.MaxBatchModifyIDs
+		units += chunks * quotaBatchModifyUnits
+	}
+	return units
+}
+
+// quotaFit returns how many messages of op fit in units
+func quotaFit(op QuotaOperation, units int) int {
+	if units <= 0 {
+		return 0
+	}
+	perMessage, ok := quotaUnitsPerMessage[op]
+	if !ok {
+		perMessage = 5
+	}
+	if op == QuotaOpLocal {
+		return units / perMessage
+	}
+	// Each batchModify chunk adds its call; try one more chunk while a whole chunk still fits
+	best := 0
+	for chunks := 1; chunks*quotaBatchModifyUnits <= units; chunks++ {
+		n := chunks * gmail.MaxBatchModifyIDs
+		if perMessage > 0 {
+			n = min(n, (units-chunks*quotaBatchModifyUnits)/perMessage)
+		}
+		best = max(best, n)
+		if n < chunks*gmail.MaxBatchModifyIDs {
+			break
+		}
+	}
+	return best
+}
+
+// Gmail's per-user limit is 15,000 quota units per minute
+const (
+	DefaultQuotaUnitsPerMinute = 15000
+	DefaultQuotaReservePercent = 20
+	quotaWindow                = time.Minute
+)
+
+// QuotaPlan is the planner's estimate for one bulk job
+type QuotaPlan struct {
+	Operation QuotaOperation
+	Messages  int
+	Units     int           // estimated quota units for the whole job
+	Budget    int           // units per window available to bulk jobs (limit minus reserve)
+	Available int           // units left in the current window after recent usage
+	Window    time.Duration // length of one quota window
+	Batches   []int         // messages per window; a single batch means the job runs now
+}
+
+// Fits reports whether the whole job runs in the current window
+func (p QuotaPlan) Fits() bool { return len(p.Batches) <= 1 }
+
+// FitsNow returns how many messages can run right away without touching the reserve
+func (p QuotaPlan) FitsNow() int {
+	if len(p.Batches) == 0 {
+		return 0
+	}
+	return p.Batches[0]
+}
+
+// Duration is roughly how long a scheduled job takes: one window per extra batch
+func (p QuotaPlan) Duration() time.Duration {
+	if len(p.Batches) <= 1 {
+		return 0
+	}
+	return time.Duration(len(p.Batches)-1) * p.Window
+}
+
+// quotaUsage is quota spent at a point in time
+type quotaUsage struct {
+	at    time.Time
+	units int
+}
+
+// QuotaPlannerServiceImpl implements QuotaPlannerService with a sliding one-minute usage ledger
+type QuotaPlannerServiceImpl struct {
+	mu             sync.Mutex
+	unitsPerMinute int
+	reservePercent int
+	usage          []quotaUsage
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewQuotaPlannerService creates a planner; zero or invalid limits fall back to Gmail's defaults
+func NewQuotaPlannerService(unitsPerMinute, reservePercent int) *QuotaPlannerServiceImpl {
+	if unitsPerMinute <= 0 {
+		unitsPerMinute = DefaultQuotaUnitsPerMinute
+	}
+	if reservePercent < 0 || reservePercent >= 100 {
+		reservePercent = DefaultQuotaReservePercent
+	}
+	return &QuotaPlannerServiceImpl{
+		unitsPerMinute: unitsPerMinute,
+		reservePercent: reservePercent,
+		now:            time.Now,
+		sleep:          sleepContext,
+	}
+}
+
+// SetClock replaces the time source and sleeper (tests)
+func (s *QuotaPlannerServiceImpl) SetClock(now func() time.Time, sleep func(ctx context.Context, d time.Duration) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	s.sleep = sleep
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// budget is the units per window bulk jobs may use, keeping the reserve for interactive actions
+func (s *QuotaPlannerServiceImpl) budget() int {
+	return s.unitsPerMinute * (100 - s.reservePercent) / 100
+}
+
+// usedLocked sums usage inside the current window and drops older entries
+func (s *QuotaPlannerServiceImpl) usedLocked(now time.Time) int {
+	cutoff := now.Add(-quotaWindow)
+	kept := s.usage[:0]
+	used := 0
+	for _, u := range s.usage {
+		if u.at.After(cutoff) {
+			kept = append(kept, u)
+			used += u.units
+		}
+	}
+	s.usage = kept
+	return used
+}
+
+// Record adds quota spent outside the planner so later plans account for it
+func (s *QuotaPlannerServiceImpl) Record(units int) {
+	if units <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = append(s.usage, quotaUsage{at: s.now(), units: units})
+}
+
+// Plan estimates a bulk job of the given size and splits it into per-window batches
+func (s *QuotaPlannerServiceImpl) Plan(op QuotaOperation, messages int) QuotaPlan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	budget := s.budget()
+	available := budget - s.usedLocked(s.now())
+	if available < 0 {
+		available = 0
+	}
+	plan := QuotaPlan{
+		Operation: op,
+		Messages:  messages,
+		Units:     QuotaUnits(op, messages),
+		Budget:    budget,
+		Available: available,
+		Window:    quotaWindow,
+	}
+	if messages <= 0 {
+		return plan
+	}
+
+	perWindow := quotaFit(op, budget)
+	if perWindow < 1 {
+		perWindow = 1
+	}
+	first := min(quotaFit(op, available), messages)
+	remaining := messages - first
+	plan.Batches = append(plan.Batches, first)
+	for remaining > 0 {
+		n := perWindow
+		if n > remaining {
+			n = remaining
+		}
+		plan.Batches = append(plan.Batches, n)
+		remaining -= n
+	}
+	return plan
+}
+
+// RunScheduled runs ids in the plan's batches, waiting one window between batches. run gets each
+// batch and its offset in ids; onWait is called before each wait with the next batch number
+// (1-based), the batch count and when it resumes. Usage is recorded as batches complete.
+func (s *QuotaPlannerServiceImpl) RunScheduled(ctx context.Context, plan QuotaPlan, ids []string, run func(batch []string, offset int) error, onWait func(next, total int, resume time.Time)) error {
+	batches := plan.Batches
+	if len(batches) == 0 {
+		batches = []int{len(ids)}
+	}
+	offset := 0
+	for i, n := range batches {
+		if offset >= len(ids) {
+			break
+		}
+		if n == 0 {
+			continue // nothing fits in the current window; the next batch waits for a fresh one
+		}
+		if i > 0 {
+			s.mu.Lock()
+			now, sleep := s.now, s.sleep
+			s.mu.Unlock()
+			if onWait != nil {
+				onWait(i+1, len(batches), now().Add(plan.Window))
+			}
+			if err := sleep(ctx, plan.Window); err != nil {
+				return err
+			}
+		}
+		end := offset + n
+		if end > len(ids) {
+			end = len(ids)
+		}
+		err := run(ids[offset:end], offset)
+		s.Record(QuotaUnits(plan.Operation, end-offset))
+		if err != nil {
+			return err
+		}
+		offset = end
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQuotaClock advances time only when the planner sleeps
+type fakeQuotaClock struct {
+	t      time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeQuotaClock) now() time.Time { return c.t }
+func (c *fakeQuotaClock) sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.sleeps = append(c.sleeps, d)
+	c.t = c.t.Add(d)
+	return nil
+}
+
+func newTestPlanner(unitsPerMinute, reserve int) (*QuotaPlannerServiceImpl, *fakeQuotaClock) {
+	clock := &fakeQuotaClock{t: time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)}
+	p := NewQuotaPlannerService(unitsPerMinute, reserve)
+	p.SetClock(clock.now, clock.sleep)
+	return p, clock
+}
+
+func quotaTestIDs(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("m%d", i)
+	}
+	return out
+}
+
+func TestQuotaPlanner_SmallJobFits(t *testing.T) {
+	p, _ := newTestPlanner(0, -1) // defaults: 15,000 units/min, 20% reserve
+	plan := p.Plan(QuotaOpArchive, 100)
+	assert.True(t, plan.Fits())
+	assert.Equal(t, 550, plan.Units) // 100 gets for undo + one batchModify
+	assert.Equal(t, 12000, plan.Budget)
+	assert.Equal(t, []int{100}, plan.Batches)
+	assert.Zero(t, plan.Duration())
+
+	// Label changes only pay for the batchModify calls, 1000 messages each
+	plan = p.Plan(QuotaOpApplyLabel, 5000)
+	assert.True(t, plan.Fits())
+	assert.Equal(t, 250, plan.Units)
+}
+
+func TestQuotaUnitsAndFit(t *testing.T) {
+	assert.Equal(t, 0, QuotaUnits(QuotaOpArchive, 0))
+	assert.Equal(t, 50, QuotaUnits(QuotaOpRemove, 1000))
+	assert.Equal(t, 100, QuotaUnits(QuotaOpRemove, 1001))
+	assert.Equal(t, 10100, QuotaUnits(QuotaOpTrash, 2000))
+	assert.Equal(t, 200, QuotaUnits(QuotaOpLocal, 10))
+
+	assert.Equal(t, 0, quotaFit(QuotaOpApplyLabel, 49))
+	assert.Equal(t, 2000, quotaFit(QuotaOpApplyLabel, 120))
+	assert.Equal(t, 90, quotaFit(QuotaOpMarkRead, 500))
+	assert.Equal(t, 1000, quotaFit(QuotaOpArchive, 5100), "a second chunk doesn't fit with its call")
+	assert.Equal(t, 1001, quotaFit(QuotaOpArchive, 5105))
+	assert.Equal(t, 25, quotaFit(QuotaOpLocal, 500))
+}
+
+func TestQuotaPlanner_LargeJobIsSplitAcrossWindows(t *testing.T) {
+	p, _ := newTestPlanner(1000, 20) // 800 units/min for bulk jobs
+	p.Record(300)                    // interactive usage this minute
+
+	plan := p.Plan(QuotaOpArchive, 250) // 5 units each plus 50 per batchModify = 1,300 units
+	assert.False(t, plan.Fits())
+	assert.Equal(t, 1300, plan.Units)
+	assert.Equal(t, 500, plan.Available)
+	assert.Equal(t, []int{90, 150, 10}, plan.Batches)
+	assert.Equal(t, 90, plan.FitsNow())
+	assert.Equal(t, 2*time.Minute, plan.Duration())
+}
+
+func TestQuotaPlanner_UsageExpiresAfterWindow(t *testing.T) {
+	p, clock := newTestPlanner(1000, 0)
+	p.Record(1000)
+	assert.Equal(t, 0, p.Plan(QuotaOpApplyLabel, 10).FitsNow())
+	clock.t = clock.t.Add(61 * time.Second)
+	assert.True(t, p.Plan(QuotaOpApplyLabel, 10).Fits())
+}
+
+func TestQuotaPlanner_RunScheduled(t *testing.T) {
+	p, clock := newTestPlanner(1000, 20)
+	p.Record(600)
+	plan := p.Plan(QuotaOpArchive, 300) // available 200 → 30 now, then 150, 120
+	assert.Equal(t, []int{30, 150, 120}, plan.Batches)
+
+	var got [][2]int
+	var waits []int
+	err := p.RunScheduled(context.Background(), plan, quotaTestIDs(300), func(batch []string, offset int) error {
+		got = append(got, [2]int{offset, len(batch)})
+		return nil
+	}, func(next, total int, resume time.Time) {
+		waits = append(waits, next)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, clock.t.Add(time.Minute), resume)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 30}, {30, 150}, {180, 120}}, got)
+	assert.Equal(t, []int{2, 3}, waits)
+	assert.Equal(t, []time.Duration{time.Minute, time.Minute}, clock.sleeps)
+}
+
+func TestQuotaPlanner_RunScheduledWaitsWhenNothingFitsNow(t *testing.T) {
+	p, clock := newTestPlanner(1000, 0)
+	p.Record(1000)
+	plan := p.Plan(QuotaOpApplyLabel, 30)
+	assert.Equal(t, []int{0, 30}, plan.Batches)
+
+	runs := 0
+	err := p.RunScheduled(context.Background(), plan, quotaTestIDs(30), func(batch []string, offset int) error {
+		runs++
+		return nil
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runs)
+	assert.Len(t, clock.sleeps, 1)
+}
+
+func TestQuotaPlanner_RunScheduledStopsOnErrorAndCancel(t *testing.T) {
+	p, _ := newTestPlanner(100, 0)
+	plan := p.Plan(QuotaOpArchive, 60) // 10 per window
+	boom := errors.New("boom")
+	runs := 0
+	err := p.RunScheduled(context.Background(), plan, quotaTestIDs(60), func(batch []string, offset int) error {
+		runs++
+		return boom
+	}, nil)
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, runs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = p.RunScheduled(ctx, p.Plan(QuotaOpArchive, 60), quotaTestIDs(60), func(batch []string, offset int) error { return nil }, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ajramos/giztui/internal/config"
+)
+
+// RecipientGroupServiceImpl implements RecipientGroupService on top of compose.recipient_groups in
+// the config file
+type RecipientGroupServiceImpl struct {
+	cfg  *config.Config
+	save func() error
+	mu   sync.RWMutex
+}
+
+// NewRecipientGroupService creates the recipient group service. save persists the config after a
+// change; it may be nil to keep changes in memory only.
+func NewRecipientGroupService(cfg *config.Config, save func() error) *RecipientGroupServiceImpl {
+	return &RecipientGroupServiceImpl{cfg: cfg, save: save}
+}
+
+// List returns the groups sorted by name
+func (s *RecipientGroupServiceImpl) List() []RecipientGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cfg == nil {
+		return nil
+	}
+	out := make([]RecipientGroup, 0, len(s.cfg.Compose.RecipientGroups))
+	for name, members := range s.cfg.Compose.RecipientGroups {
+		out = append(out, RecipientGroup{Name: name, Members: append([]string(nil), members...)})
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out
+}
+
+// Set creates or replaces a group. members are addresses, optionally with a display name
+// ("Ana <ana@x.com>").
+func (s *RecipientGroupServiceImpl) Set(name string, members []string) (*RecipientGroup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, "@,<> \t") {
+		return nil, fmt.Errorf("group name must be a single word without '@' or commas")
+	}
+	var clean []string
+	seen := make(map[string]bool)
+	for _, m := range members {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q", m)
+		}
+		if key := strings.ToLower(addr.Address); !seen[key] {
+			seen[key] = true
+			clean = append(clean, m)
+		}
+	}
+	if len(clean) == 0 {
+		return nil, fmt.Errorf("a group needs at least one address")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg == nil {
+		return nil, fmt.Errorf("configuration not available")
+	}
+	if s.cfg.Compose.RecipientGroups == nil {
+		s.cfg.Compose.RecipientGroups = make(map[string][]string)
+	}
+	// Names are matched case-insensitively, so replace an existing group spelled differently
+	if existing, ok := s.lookup(name); ok {
+		delete(s.cfg.Compose.RecipientGroups, existing)
+	}
+	s.cfg.Compose.RecipientGroups[name] = clean
+	if err := s.persist(); err != nil {
+		return nil, err
+	}
+	return &RecipientGroup{Name: name, Members: append([]string(nil), clean...)}, nil
+}
+
+// Delete removes a group
+func (s *RecipientGroupServiceImpl) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg == nil {
+		return fmt.Errorf("configuration not available")
+	}
+	existing, ok := s.lookup(strings.TrimSpace(name))
+	if !ok {
+		return fmt.Errorf("no recipient group named %q", name)
+	}
+	delete(s.cfg.Compose.RecipientGroups, existing)
+	return s.persist()
+}
+
+// Expand replaces recipients that name a group (a bare word, no '@') with the group's members,
+// dropping duplicate addresses. changed reports whether any group was expanded.
+func (s *RecipientGroupServiceImpl) Expand(recipients []Recipient) ([]Recipient, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cfg == nil || len(s.cfg.Compose.RecipientGroups) == 0 {
+		return recipients, false
+	}
+	out := make([]Recipient, 0, len(recipients))
+	seen := make(map[string]bool)
+	changed := false
+	add := func(r Recipient) {
+		key := strings.ToLower(strings.TrimSpace(r.Email))
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, r)
+	}
+	for _, r := range recipients {
+		if r.Name == "" && !strings.Contains(r.Email, "@") {
+			if name, ok := s.lookup(strings.TrimSpace(r.Email)); ok {
+				changed = true
+				for _, m := range s.cfg.Compose.RecipientGroups[name] {
+					if addr, err := mail.ParseAddress(m); err == nil {
+						add(Recipient{Name: addr.Name, Email: addr.Address})
+					}
+				}
+				continue
+			}
+		}
+		add(r)
+	}
+	return out, changed
+}
+
+// lookup finds a group name case-insensitively. Callers hold s.mu.
+func (s *RecipientGroupServiceImpl) lookup(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	if _, ok := s.cfg.Compose.RecipientGroups[name]; ok {
+		return name, true
+	}
+	for existing := range s.cfg.Compose.RecipientGroups {
+		if strings.EqualFold(existing, name) {
+			return existing, true
+		}
+	}
+	return "", false
+}
+
+// persist saves the config when a saver is set. Callers hold s.mu.
+func (s *RecipientGroupServiceImpl) persist() error {
+	if s.save == nil {
+		return nil
+	}
+	if err := s.save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// ParseRecipientGroupMembers splits a comma- or semicolon-separated member list
+func ParseRecipientGroupMembers(s string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
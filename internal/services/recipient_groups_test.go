@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipientGroupService_SetListDelete(t *testing.T) {
+	cfg := &config.Config{}
+	saves := 0
+	svc := NewRecipientGroupService(cfg, func() error { saves++; return nil })
+
+	g, err := svc.Set("team", []string{"a@x.com", "Bea <b@x.com>", "A@x.com", " "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@x.com", "Bea <b@x.com>"}, g.Members)
+	assert.Equal(t, 1, saves)
+
+	_, err = svc.Set("Alpha", []string{"c@x.com"})
+	require.NoError(t, err)
+	// Same name in another case replaces the group instead of adding a second one
+	_, err = svc.Set("TEAM", []string{"d@x.com"})
+	require.NoError(t, err)
+
+	groups := svc.List()
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Alpha", groups[0].Name)
+	assert.Equal(t, RecipientGroup{Name: "TEAM", Members: []string{"d@x.com"}}, groups[1])
+
+	require.NoError(t, svc.Delete("team"))
+	assert.Len(t, svc.List(), 1)
+	assert.Error(t, svc.Delete("team"))
+}
+
+func TestRecipientGroupService_SetValidation(t *testing.T) {
+	svc := NewRecipientGroupService(&config.Config{}, nil)
+
+	_, err := svc.Set("my team", []string{"a@x.com"})
+	assert.Error(t, err)
+	_, err = svc.Set("team", []string{"not-an-address"})
+	assert.Error(t, err)
+	_, err = svc.Set("team", nil)
+	assert.Error(t, err)
+
+	failing := NewRecipientGroupService(&config.Config{}, func() error { return errors.New("disk full") })
+	_, err = failing.Set("team", []string{"a@x.com"})
+	assert.ErrorContains(t, err, "disk full")
+}
+
+func TestRecipientGroupService_Expand(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Compose.RecipientGroups = map[string][]string{"Team": {"Ana <a@x.com>", "b@x.com"}}
+	svc := NewRecipientGroupService(cfg, nil)
+
+	out, changed := svc.Expand([]Recipient{{Email: "b@x.com"}, {Email: "team"}, {Email: "other"}})
+	assert.True(t, changed)
+	assert.Equal(t, []Recipient{{Email: "b@x.com"}, {Name: "Ana", Email: "a@x.com"}, {Email: "other"}}, out)
+
+	in := []Recipient{{Email: "c@x.com"}}
+	out, changed = svc.Expand(in)
+	assert.False(t, changed)
+	assert.Equal(t, in, out)
+}
+
+func TestParseRecipientGroupMembers(t *testing.T) {
+	assert.Equal(t, []string{"a@x.com", "Bea <b@x.com>", "c@x.com"}, ParseRecipientGroupMembers(" a@x.com, Bea <b@x.com>;c@x.com ,"))
+	assert.Nil(t, ParseRecipientGroupMembers("  "))
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// reportMaxMessages caps how many received (and sent) messages a report reads
+const reportMaxMessages = 1000
+
+// reportTopN is how many labels, senders and unread messages a report lists
+const reportTopN = 10
+
+// reportNarrativePrompt asks the LLM for a short narrative on top of the computed statistics
+const reportNarrativePrompt = `You are writing the opening paragraph of a personal weekly email report.
+Using ONLY the statistics below, write 3-5 sentences in plain prose: how busy the period was,
+who or what dominated, how quickly replies went out, and what unread important mail needs attention.
+Do not invent numbers, do not use headings or bullet points.
+
+%s`
+
+// ReportClient is the subset of *gmail.Client the report service depends on
+type ReportClient interface {
+	SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmail_v1.Message, string, error)
+	GetMessagesMetadataParallel(messageIDs []string, maxWorkers int) ([]*gmail_v1.Message, error)
+	ListLabels() ([]*gmail_v1.Label, error)
+}
+
+// ReportServiceImpl implements ReportService. Statistics are computed locally from message
+// metadata; the AI service is only used for the optional narrative.
+type ReportServiceImpl struct {
+	client    ReportClient
+	aiService AIService
+	now       func() time.Time
+}
+
+// NewReportService creates the report service. aiService may be nil (no narrative).
+func NewReportService(client ReportClient, aiService AIService) *ReportServiceImpl {
+	return &ReportServiceImpl{client: client, aiService: aiService, now: time.Now}
+}
+
+// Generate builds the report for the last opts.Days days (7 by default)
+func (s *ReportServiceImpl) Generate(ctx context.Context, opts ReportOptions) (*EmailReport, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	days := opts.Days
+	if days <= 0 {
+		days = 7
+	}
+	until := s.now()
+	since := until.AddDate(0, 0, -days)
+
+	received, err := s.fetch(ctx, fmt.Sprintf("newer_than:%dd -in:sent -in:chats", days))
+	if err != nil {
+		return nil, err
+	}
+	sent, err := s.fetch(ctx, fmt.Sprintf("newer_than:%dd in:sent", days))
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.client.ListLabels()
+	if err != nil {
+		return nil, ClassifyError("list labels", err)
+	}
+	names := make(map[string]string, len(labels))
+	for _, l := range labels {
+		names[l.Id] = l.Name
+	}
+
+	report := BuildEmailReport(received, sent, names, since, until)
+	if opts.Narrative {
+		if s.aiService == nil {
+			report.NarrativeError = "AI service not available"
+		} else if text, err := s.aiService.ApplyCustomPrompt(ctx, fmt.Sprintf(reportNarrativePrompt, report.Markdown()), nil); err != nil {
+			report.NarrativeError = err.Error()
+		} else {
+			report.Narrative = strings.TrimSpace(text)
+		}
+	}
+	return report, nil
+}
+
+// fetch returns the metadata of up to reportMaxMessages messages matching query
+func (s *ReportServiceImpl) fetch(ctx context.Context, query string) ([]*gmail_v1.Message, error) {
+	return searchMetadata(ctx, s.client, query, reportMaxMessages)
+}
+
+// metadataSearcher is the subset of *gmail.Client that lists messages with their metadata
+type metadataSearcher interface {
+	SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmail_v1.Message, string, error)
+	GetMessagesMetadataParallel(messageIDs []string, maxWorkers int) ([]*gmail_v1.Message, error)
+}
+
+// searchMetadata returns the metadata of up to limit messages matching query, newest first
+func searchMetadata(ctx context.Context, client metadataSearcher, query string, limit int) ([]*gmail_v1.Message, error) {
+	var ids []string
+	token := ""
+	for len(ids) < limit {
+		var page []*gmail_v1.Message
+		err := RetryTransient(ctx, readAttempts, func() (err error) {
+			page, token, err = client.SearchMessagesPage(query, 500, token)
+			return ClassifyError("search messages", err)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			ids = append(ids, m.Id)
+		}
+		if token == "" || len(page) == 0 {
+			break
+		}
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	msgs, err := client.GetMessagesMetadataParallel(ids, 10)
+	return msgs, ClassifyError("get message metadata", err)
+}
+
+// BuildEmailReport computes the report statistics from message metadata. received and sent are
+// the messages in the period; replies are matched to the latest received message of their thread.
+func BuildEmailReport(received, sent []*gmail_v1.Message, labelNames map[string]string, since, until time.Time) *EmailReport {
+	r := &EmailReport{Since: since, Until: until, Received: len(received), Sent: len(sent)}
+
+	labelCounts := make(map[string]int)
+	senderCounts := make(map[string]int)
+	senderNames := make(map[string]string)
+	var important []ReportMessage
+	for _, m := range received {
+		unread, flagged := false, false
+		for _, id := range m.LabelIds {
+			switch id {
+			case "UNREAD":
+				unread = true
+			case "IMPORTANT", "STARRED":
+				flagged = true
+			}
+			if name := reportLabelName(id, labelNames); name != "" {
+				labelCounts[name]++
+			}
+		}
+		if unread {
+			r.Unread++
+		}
+		from := reportHeader(m, "From")
+		addr, display := from, from
+		if a, err := mail.ParseAddress(from); err == nil {
+			addr, display = a.Address, a.Address
+			if a.Name != "" {
+				display = a.Name + " <" + a.Address + ">"
+			}
+		}
+		key := strings.ToLower(addr)
+		senderCounts[key]++
+		if _, ok := senderNames[key]; !ok {
+			senderNames[key] = display
+		}
+		if unread && flagged {
+			important = append(important, ReportMessage{
+				ID:      m.Id,
+				From:    display,
+				Subject: reportHeader(m, "Subject"),
+				Date:    time.UnixMilli(m.InternalDate),
+			})
+		}
+	}
+	r.ByLabel = topCounts(labelCounts, nil, reportTopN)
+	r.TopSenders = topCounts(senderCounts, senderNames, reportTopN)
+	sort.SliceStable(important, func(i, j int) bool { return important[i].Date.After(important[j].Date) })
+	if len(important) > reportTopN {
+		important = important[:reportTopN]
+	}
+	r.ImportantUnread = important
+
+	// Response times: a sent message answers the latest received message of its thread before it
+	byThread := make(map[string][]int64)
+	for _, m := range received {
+		byThread[m.ThreadId] = append(byThread[m.ThreadId], m.InternalDate)
+	}
+	var times []time.Duration
+	for _, m := range sent {
+		var prev int64
+		for _, at := range byThread[m.ThreadId] {
+			if at < m.InternalDate && at > prev {
+				prev = at
+			}
+		}
+		if prev > 0 {
+			times = append(times, time.Duration(m.InternalDate-prev)*time.Millisecond)
+		}
+	}
+	if len(times) > 0 {
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		var total time.Duration
+		for _, d := range times {
+			total += d
+		}
+		r.Replies = len(times)
+		r.MedianResponse = times[len(times)/2]
+		r.AverageResponse = total / time.Duration(len(times))
+	}
+	return r
+}
+
+// reportLabelName returns the label shown in "by label" counts; state labels are skipped
+func reportLabelName(id string, names map[string]string) string {
+	switch id {
+	case "UNREAD", "INBOX", "SENT", "DRAFT", "CHAT", "IMPORTANT", "STARRED", "TRASH", "SPAM":
+		return ""
+	}
+	if strings.HasPrefix(id, "CATEGORY_") {
+		c := strings.ToLower(strings.TrimPrefix(id, "CATEGORY_"))
+		if c == "personal" {
+			return ""
+		}
+		return "Category: " + strings.ToUpper(c[:1]) + c[1:]
+	}
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
+
+func reportHeader(m *gmail_v1.Message, name string) string {
+	if m.Payload == nil {
+		return ""
+	}
+	for _, h := range m.Payload.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// topCounts sorts counts (most first, then by name) and keeps the first n
+func topCounts(counts map[string]int, display map[string]string, n int) []ReportCount {
+	out := make([]ReportCount, 0, len(counts))
+	for k, c := range counts {
+		name := k
+		if d, ok := display[k]; ok && d != "" {
+			name = d
+		}
+		out = append(out, ReportCount{Name: name, Count: c})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Title is the report heading, e.g. "Email report · Mar 3 – Mar 10, 2025"
+func (r *EmailReport) Title() string {
+	return fmt.Sprintf("Email report · %s – %s", r.Since.Format("Jan 2"), r.Until.Format("Jan 2, 2006"))
+}
+
+// Markdown renders the report (with the narrative, when present) as Markdown
+func (r *EmailReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title())
+	if r.Narrative != "" {
+		fmt.Fprintf(&b, "%s\n\n", r.Narrative)
+	}
+
+	b.WriteString("## Overview\n\n")
+	fmt.Fprintf(&b, "- Received: %d (%d still unread)\n", r.Received, r.Unread)
+	fmt.Fprintf(&b, "- Sent: %d\n", r.Sent)
+	if r.Replies > 0 {
+		fmt.Fprintf(&b, "- Response time: median %s, average %s (%d replies)\n",
+			formatReportDuration(r.MedianResponse), formatReportDuration(r.AverageResponse), r.Replies)
+	} else {
+		b.WriteString("- Response time: no replies in this period\n")
+	}
+
+	if len(r.ByLabel) > 0 {
+		b.WriteString("\n## By label\n\n")
+		for _, c := range r.ByLabel {
+			fmt.Fprintf(&b, "- %s: %d\n", c.Name, c.Count)
+		}
+	}
+	if len(r.TopSenders) > 0 {
+		b.WriteString("\n## Top senders\n\n")
+		for _, c := range r.TopSenders {
+			fmt.Fprintf(&b, "- %s: %d\n", c.Name, c.Count)
+		}
+	}
+	b.WriteString("\n## Important unread\n\n")
+	if len(r.ImportantUnread) == 0 {
+		b.WriteString("Nothing important left unread.\n")
+	}
+	for _, m := range r.ImportantUnread {
+		subject := strings.TrimSpace(m.Subject)
+		if subject == "" {
+			subject = "(no subject)"
+		}
+		fmt.Fprintf(&b, "- %s — %s (%s)\n", subject, m.From, m.Date.Format("Mon Jan 2 15:04"))
+	}
+	return b.String()
+}
+
+// formatReportDuration renders a response time at a readable precision: 45m, 3h20m, 2d4h
+func formatReportDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	case d < 24*time.Hour:
+		d = d.Round(10 * time.Minute)
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		d = d.Round(time.Hour)
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func reportMsg(id, thread, from, subject string, at time.Time, labels ...string) *gmail_v1.Message {
+	return &gmail_v1.Message{
+		Id:           id,
+		ThreadId:     thread,
+		InternalDate: at.UnixMilli(),
+		LabelIds:     labels,
+		Payload: &gmail_v1.MessagePart{Headers: []*gmail_v1.MessagePartHeader{
+			{Name: "From", Value: from},
+			{Name: "Subject", Value: subject},
+		}},
+	}
+}
+
+func TestBuildEmailReport(t *testing.T) {
+	until := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, 0, -7)
+	received := []*gmail_v1.Message{
+		reportMsg("r1", "t1", "Alice <alice@example.com>", "Budget", until.Add(-50*time.Hour), "INBOX", "Label_1"),
+		reportMsg("r2", "t2", "ALICE@example.com", "Offsite", until.Add(-30*time.Hour), "INBOX", "UNREAD", "IMPORTANT", "Label_1"),
+		reportMsg("r3", "t3", "news@shop.example", "Sale", until.Add(-10*time.Hour), "INBOX", "UNREAD", "CATEGORY_PROMOTIONS"),
+		reportMsg("r4", "t1", "Alice <alice@example.com>", "Re: Budget", until.Add(-20*time.Hour), "INBOX"),
+	}
+	sent := []*gmail_v1.Message{
+		reportMsg("s1", "t1", "me@example.com", "Re: Budget", until.Add(-49*time.Hour), "SENT"), // 1h after r1
+		reportMsg("s2", "t1", "me@example.com", "Re: Budget", until.Add(-17*time.Hour), "SENT"), // 3h after r4
+		reportMsg("s3", "t9", "me@example.com", "Hello", until.Add(-5*time.Hour), "SENT"),       // new thread
+	}
+	r := BuildEmailReport(received, sent, map[string]string{"Label_1": "Work"}, since, until)
+
+	assert.Equal(t, 4, r.Received)
+	assert.Equal(t, 2, r.Unread)
+	assert.Equal(t, 3, r.Sent)
+	assert.Equal(t, 2, r.Replies)
+	assert.Equal(t, 3*time.Hour, r.MedianResponse)
+	assert.Equal(t, 2*time.Hour, r.AverageResponse)
+	assert.Equal(t, []ReportCount{{"Work", 2}, {"Category: Promotions", 1}}, r.ByLabel)
+	require.Len(t, r.TopSenders, 2)
+	assert.Equal(t, ReportCount{"Alice <alice@example.com>", 3}, r.TopSenders[0])
+	require.Len(t, r.ImportantUnread, 1)
+	assert.Equal(t, "r2", r.ImportantUnread[0].ID)
+
+	md := r.Markdown()
+	assert.Contains(t, md, "# Email report · Mar 3 – Mar 10, 2025")
+	assert.Contains(t, md, "- Received: 4 (2 still unread)")
+	assert.Contains(t, md, "median 3h00m, average 2h00m (2 replies)")
+	assert.Contains(t, md, "- Offsite — ALICE@example.com")
+}
+
+func TestFormatReportDuration(t *testing.T) {
+	assert.Equal(t, "45m", formatReportDuration(45*time.Minute))
+	assert.Equal(t, "3h20m", formatReportDuration(3*time.Hour+18*time.Minute))
+	assert.Equal(t, "2d4h", formatReportDuration(52*time.Hour))
+}
+
+type fakeReportClient struct {
+	queries []string
+	byQuery map[string][]*gmail_v1.Message
+}
+
+func (f *fakeReportClient) SearchMessagesPage(query string, _ int64, _ string) ([]*gmail_v1.Message, string, error) {
+	f.queries = append(f.queries, query)
+	var out []*gmail_v1.Message
+	for _, m := range f.byQuery[query] {
+		out = append(out, &gmail_v1.Message{Id: m.Id})
+	}
+	return out, "", nil
+}
+
+func (f *fakeReportClient) GetMessagesMetadataParallel(ids []string, _ int) ([]*gmail_v1.Message, error) {
+	var out []*gmail_v1.Message
+	for _, msgs := range f.byQuery {
+		for _, m := range msgs {
+			for _, id := range ids {
+				if m.Id == id {
+					out = append(out, m)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReportClient) ListLabels() ([]*gmail_v1.Label, error) { return nil, nil }
+
+func TestReportService_GenerateWithNarrative(t *testing.T) {
+	now := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+	client := &fakeReportClient{byQuery: map[string][]*gmail_v1.Message{
+		"newer_than:14d -in:sent -in:chats": {reportMsg("r1", "t1", "a@example.com", "Hi", now.Add(-time.Hour), "INBOX")},
+	}}
+	ai := &mockAIService{}
+	ai.On("ApplyCustomPrompt", mock.Anything, mock.MatchedBy(func(p string) bool {
+		return strings.Contains(p, "- Received: 1 (0 still unread)")
+	}), mock.Anything).Return("  A quiet fortnight.  ", nil).Once()
+
+	svc := NewReportService(client, ai)
+	svc.now = func() time.Time { return now }
+	r, err := svc.Generate(context.Background(), ReportOptions{Days: 14, Narrative: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"newer_than:14d -in:sent -in:chats", "newer_than:14d in:sent"}, client.queries)
+	assert.Equal(t, 1, r.Received)
+	assert.Equal(t, "A quiet fortnight.", r.Narrative)
+	assert.True(t, strings.HasPrefix(r.Markdown(), "# Email report · Feb 24 – Mar 10, 2025\n\nA quiet fortnight.\n"))
+	ai.AssertExpectations(t)
+
+	// Without an AI service the report still comes back, with the reason
+	r, err = NewReportService(client, nil).Generate(context.Background(), ReportOptions{Narrative: true})
+	require.NoError(t, err)
+	assert.Equal(t, "AI service not available", r.NarrativeError)
+}
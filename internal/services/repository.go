@@ -8,6 +8,9 @@ import (
 	gmail_v1 "google.golang.org/api/gmail/v1"
 )
 
+// readAttempts bounds retries of idempotent reads on throttling or transient network errors
+const readAttempts = 3
+
 // MessageRepositoryImpl implements MessageRepository
 type MessageRepositoryImpl struct {
 	gmailClient *gmail.Client
@@ -21,9 +24,14 @@ func NewMessageRepository(gmailClient *gmail.Client) *MessageRepositoryImpl {
 }
 
 func (r *MessageRepositoryImpl) GetMessages(ctx context.Context, opts QueryOptions) (*MessagePage, error) {
-	messages, nextToken, err := r.gmailClient.ListMessagesPage(opts.MaxResults, opts.PageToken)
+	var messages []*gmail_v1.Message
+	var nextToken string
+	err := RetryTransient(ctx, readAttempts, func() (err error) {
+		messages, nextToken, err = r.gmailClient.ListMessagesPage(opts.MaxResults, opts.PageToken)
+		return ClassifyError("get messages", err)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get messages: %w", err)
+		return nil, err
 	}
 
 	return &MessagePage{
@@ -38,9 +46,13 @@ func (r *MessageRepositoryImpl) GetMessage(ctx context.Context, id string) (*gma
 		return nil, fmt.Errorf("message ID cannot be empty")
 	}
 
-	msg, err := r.gmailClient.GetMessageWithContent(id)
+	var msg *gmail.Message
+	err := RetryTransient(ctx, readAttempts, func() (err error) {
+		msg, err = r.gmailClient.GetMessageWithContent(id)
+		return ClassifyError("get message "+id, err)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get message %s: %w", id, err)
+		return nil, err
 	}
 
 	return msg, nil
@@ -51,9 +63,14 @@ func (r *MessageRepositoryImpl) SearchMessages(ctx context.Context, query string
 		return nil, fmt.Errorf("search query cannot be empty")
 	}
 
-	messages, nextToken, err := r.gmailClient.SearchMessagesPage(query, opts.MaxResults, opts.PageToken)
+	var messages []*gmail_v1.Message
+	var nextToken string
+	err := RetryTransient(ctx, readAttempts, func() (err error) {
+		messages, nextToken, err = r.gmailClient.SearchMessagesPage(query, opts.MaxResults, opts.PageToken)
+		return ClassifyError("search messages", err)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to search messages: %w", err)
+		return nil, err
 	}
 
 	return &MessagePage{
@@ -71,14 +88,14 @@ func (r *MessageRepositoryImpl) UpdateMessage(ctx context.Context, id string, up
 	// Apply label additions
 	for _, labelID := range updates.AddLabels {
 		if err := r.gmailClient.ApplyLabel(id, labelID); err != nil {
-			return fmt.Errorf("failed to add label %s to message %s: %w", labelID, id, err)
+			return ClassifyError(fmt.Sprintf("add label %s to message %s", labelID, id), err)
 		}
 	}
 
 	// Apply label removals
 	for _, labelID := range updates.RemoveLabels {
 		if err := r.gmailClient.RemoveLabel(id, labelID); err != nil {
-			return fmt.Errorf("failed to remove label %s from message %s: %w", labelID, id, err)
+			return ClassifyError(fmt.Sprintf("remove label %s from message %s", labelID, id), err)
 		}
 	}
 
@@ -86,11 +103,11 @@ func (r *MessageRepositoryImpl) UpdateMessage(ctx context.Context, id string, up
 	if updates.MarkAsRead != nil {
 		if *updates.MarkAsRead {
 			if err := r.gmailClient.MarkAsRead(id); err != nil {
-				return fmt.Errorf("failed to mark message as read: %w", err)
+				return ClassifyError("mark message as read", err)
 			}
 		} else {
 			if err := r.gmailClient.MarkAsUnread(id); err != nil {
-				return fmt.Errorf("failed to mark message as unread: %w", err)
+				return ClassifyError("mark message as unread", err)
 			}
 		}
 	}
@@ -101,7 +118,7 @@ func (r *MessageRepositoryImpl) UpdateMessage(ctx context.Context, id string, up
 func (r *MessageRepositoryImpl) GetDrafts(ctx context.Context, maxResults int64) ([]*gmail_v1.Draft, error) {
 	drafts, err := r.gmailClient.ListDrafts(maxResults)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get drafts: %w", err)
+		return nil, ClassifyError("get drafts", err)
 	}
 
 	return drafts, nil
@@ -110,7 +127,7 @@ func (r *MessageRepositoryImpl) GetDrafts(ctx context.Context, maxResults int64)
 func (r *MessageRepositoryImpl) GetDraft(ctx context.Context, draftID string) (*gmail_v1.Draft, error) {
 	draft, err := r.gmailClient.GetDraft(draftID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get draft %s: %w", draftID, err)
+		return nil, ClassifyError("get draft "+draftID, err)
 	}
 
 	return draft, nil
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/render"
+)
+
+// SenderOverrideServiceImpl implements SenderOverrideService
+type SenderOverrideServiceImpl struct {
+	store        *db.SenderOverrideStore
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewSenderOverrideService creates the sender display override service
+func NewSenderOverrideService(store *db.SenderOverrideStore) *SenderOverrideServiceImpl {
+	return &SenderOverrideServiceImpl{store: store}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *SenderOverrideServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *SenderOverrideServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("sender override store not available")
+	}
+	return email, nil
+}
+
+// List returns the overrides of the active account ordered by address
+func (s *SenderOverrideServiceImpl) List(ctx context.Context) ([]SenderOverrideInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.store.List(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SenderOverrideInfo, 0, len(overrides))
+	for _, o := range overrides {
+		out = append(out, senderOverrideInfo(o))
+	}
+	return out, nil
+}
+
+// Edit applies edit to the override of addr (a blank one when it has none) and saves it. An
+// override left without name, emoji and color is removed, and nil is returned.
+func (s *SenderOverrideServiceImpl) Edit(ctx context.Context, addr string, edit func(o *SenderOverrideInfo)) (*SenderOverrideInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := mail.ParseAddress(strings.TrimSpace(addr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q", addr)
+	}
+	addr = strings.ToLower(parsed.Address)
+
+	info := SenderOverrideInfo{Email: addr}
+	existing, err := s.store.Get(ctx, email, addr)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		info = senderOverrideInfo(existing)
+	}
+	edit(&info)
+	info.Name, info.Emoji = strings.TrimSpace(info.Name), strings.TrimSpace(info.Emoji)
+	info.Color = strings.ToLower(strings.TrimSpace(info.Color))
+	if info.Color != "" && !render.IsSenderColor(info.Color) {
+		return nil, fmt.Errorf("unknown color %q (use %s)", info.Color, strings.Join(render.SenderColors(), ", "))
+	}
+
+	if info.Name == "" && info.Emoji == "" && info.Color == "" {
+		if existing != nil {
+			if err := s.store.Delete(ctx, email, addr); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	saved, err := s.store.Save(ctx, db.SenderOverride{
+		AccountEmail: email,
+		Email:        addr,
+		Name:         info.Name,
+		Emoji:        info.Emoji,
+		Color:        info.Color,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := senderOverrideInfo(saved)
+	return &out, nil
+}
+
+// Remove drops the override of an address
+func (s *SenderOverrideServiceImpl) Remove(ctx context.Context, addr string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, email, addr)
+}
+
+// Displays returns the overrides keyed by lowercase address, ready for the list renderer
+func (s *SenderOverrideServiceImpl) Displays(ctx context.Context) (map[string]render.SenderDisplay, error) {
+	overrides, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]render.SenderDisplay, len(overrides))
+	for _, o := range overrides {
+		out[o.Email] = render.SenderDisplay{Name: o.Name, Emoji: o.Emoji, Color: o.Color}
+	}
+	return out, nil
+}
+
+func senderOverrideInfo(o *db.SenderOverride) SenderOverrideInfo {
+	return SenderOverrideInfo{
+		Email:     o.Email,
+		Name:      o.Name,
+		Emoji:     o.Emoji,
+		Color:     o.Color,
+		UpdatedAt: time.Unix(o.UpdatedAt, 0),
+	}
+}
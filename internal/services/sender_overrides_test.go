@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderOverrideService(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/overrides.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	svc := NewSenderOverrideService(db.NewSenderOverrideStore(store))
+	_, err = svc.List(ctx)
+	assert.EqualError(t, err, "account email not set")
+	svc.SetAccountEmail("me@example.com")
+
+	o, err := svc.Edit(ctx, "PD Alerts <Alerts@PagerDuty.com>", func(o *SenderOverrideInfo) { o.Name = " PagerDuty " })
+	require.NoError(t, err)
+	assert.Equal(t, "alerts@pagerduty.com", o.Email)
+	// Later edits keep the fields set before
+	o, err = svc.Edit(ctx, "alerts@pagerduty.com", func(o *SenderOverrideInfo) { o.Emoji, o.Color = "🚨", "Red" })
+	require.NoError(t, err)
+	assert.Equal(t, SenderOverrideInfo{Email: "alerts@pagerduty.com", Name: "PagerDuty", Emoji: "🚨", Color: "red", UpdatedAt: o.UpdatedAt}, *o)
+
+	_, err = svc.Edit(ctx, "alerts@pagerduty.com", func(o *SenderOverrideInfo) { o.Color = "teal" })
+	assert.ErrorContains(t, err, `unknown color "teal"`)
+	_, err = svc.Edit(ctx, "not an address", func(o *SenderOverrideInfo) { o.Name = "x" })
+	assert.Error(t, err)
+
+	displays, err := svc.Displays(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]render.SenderDisplay{"alerts@pagerduty.com": {Name: "PagerDuty", Emoji: "🚨", Color: "red"}}, displays)
+
+	// Clearing every field removes the override
+	o, err = svc.Edit(ctx, "alerts@pagerduty.com", func(o *SenderOverrideInfo) { *o = SenderOverrideInfo{} })
+	require.NoError(t, err)
+	assert.Nil(t, o)
+	all, _ := svc.List(ctx)
+	assert.Empty(t, all)
+	assert.Error(t, svc.Remove(ctx, "alerts@pagerduty.com"))
+}
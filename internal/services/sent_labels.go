@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SentLabelsError reports labels that could not be applied to a message that WAS sent. Callers
+// should surface it as a warning, not as a failed send.
+type SentLabelsError struct {
+	Labels []string
+	Err    error
+}
+
+func (e *SentLabelsError) Error() string {
+	return fmt.Sprintf("sent, but labels %s not applied: %v", strings.Join(e.Labels, ", "), e.Err)
+}
+
+func (e *SentLabelsError) Unwrap() error { return e.Err }
+
+// ResolveSentLabels merges the labels chosen in the composer with the configured defaults: labels
+// applied to every sent message and labels mapped to the recipients' domains. A domain rule also
+// matches subdomains. The result keeps first-seen order and drops case-insensitive duplicates.
+func ResolveSentLabels(explicit, always []string, byDomain map[string][]string, recipients []Recipient) []string {
+	var out []string
+	seen := make(map[string]bool)
+	add := func(names ...string) {
+		for _, n := range names {
+			n = strings.TrimSpace(n)
+			if n == "" || seen[strings.ToLower(n)] {
+				continue
+			}
+			seen[strings.ToLower(n)] = true
+			out = append(out, n)
+		}
+	}
+	add(explicit...)
+	add(always...)
+	if len(byDomain) == 0 {
+		return out
+	}
+	rules := make(map[string][]string, len(byDomain))
+	for d, labels := range byDomain {
+		rules[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))] = labels
+	}
+	for _, r := range recipients {
+		at := strings.LastIndex(r.Email, "@")
+		if at < 0 {
+			continue
+		}
+		domain := strings.ToLower(strings.TrimRight(strings.TrimSpace(r.Email[at+1:]), ">"))
+		for domain != "" {
+			if labels, ok := rules[domain]; ok {
+				add(labels...)
+				break
+			}
+			dot := strings.Index(domain, ".")
+			if dot < 0 {
+				break
+			}
+			domain = domain[dot+1:]
+		}
+	}
+	return out
+}
+
+// SetLabelService enables labeling sent messages (labels are created when missing)
+func (s *CompositionServiceImpl) SetLabelService(labelService LabelService) {
+	s.labelService = labelService
+}
+
+// SetSentLabelRules configures the labels applied to every sent message and per recipient domain
+func (s *CompositionServiceImpl) SetSentLabelRules(always []string, byDomain map[string][]string) {
+	s.sentLabels = always
+	s.domainLabels = byDomain
+}
+
+// SentLabelsFor returns the labels a composition would get when sent now
+func (s *CompositionServiceImpl) SentLabelsFor(composition *Composition) []string {
+	if composition == nil {
+		return nil
+	}
+	recipients := make([]Recipient, 0, len(composition.To)+len(composition.CC)+len(composition.BCC))
+	recipients = append(recipients, composition.To...)
+	recipients = append(recipients, composition.CC...)
+	recipients = append(recipients, composition.BCC...)
+	return ResolveSentLabels(composition.Labels, s.sentLabels, s.domainLabels, recipients)
+}
+
+// applySentLabels labels a just-sent message. Labels are applied with the Gmail client directly so
+// they do not land on the undo stack.
+func (s *CompositionServiceImpl) applySentLabels(ctx context.Context, composition *Composition, messageID string) error {
+	names := s.SentLabelsFor(composition)
+	if len(names) == 0 {
+		return nil
+	}
+	if s.labelService == nil || s.gmailClient == nil || messageID == "" {
+		return &SentLabelsError{Labels: names, Err: fmt.Errorf("labeling not available")}
+	}
+	var failed []string
+	var lastErr error
+	for _, name := range names {
+		label, err := s.labelService.EnsureLabelPath(ctx, name)
+		if err == nil {
+			err = s.gmailClient.ApplyLabel(messageID, label.Id)
+		}
+		if err != nil {
+			failed = append(failed, name)
+			lastErr = err
+			if s.logger != nil {
+				s.logger.Printf("CompositionService: failed to apply sent label %q to %s: %v", name, messageID, err)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return &SentLabelsError{Labels: failed, Err: lastErr}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSentLabels(t *testing.T) {
+	byDomain := map[string][]string{
+		"acme.com":     {"Clients/Acme"},
+		"@initech.com": {"Clients/Initech", "sent"},
+	}
+	recipients := []Recipient{{Email: "bob@eu.acme.com"}, {Email: "Peter <peter@INITECH.com>"}, {Email: "no-at"}}
+
+	got := ResolveSentLabels([]string{"Sent", " "}, []string{"Outbox"}, byDomain, recipients)
+	assert.Equal(t, []string{"Sent", "Outbox", "Clients/Acme", "Clients/Initech"}, got)
+
+	assert.Empty(t, ResolveSentLabels(nil, nil, byDomain, []Recipient{{Email: "a@notacme.com"}}))
+}
+
+func TestCompositionService_SentLabelsFor(t *testing.T) {
+	s := &CompositionServiceImpl{}
+	s.SetSentLabelRules(nil, map[string][]string{"acme.com": {"Clients/Acme"}})
+	c := &Composition{Labels: []string{"Projects"}, BCC: []Recipient{{Email: "x@acme.com"}}}
+	assert.Equal(t, []string{"Projects", "Clients/Acme"}, s.SentLabelsFor(c))
+	assert.Nil(t, s.SentLabelsFor(nil))
+
+	// Without a label service the labels are reported, not silently dropped
+	err := s.applySentLabels(context.Background(), c, "m1")
+	var labelErr *SentLabelsError
+	assert.True(t, errors.As(err, &labelErr))
+	assert.Equal(t, []string{"Projects", "Clients/Acme"}, labelErr.Labels)
+}
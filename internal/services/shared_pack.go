@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// SharedPackSource is the source recorded on prompts and saved queries merged from the pack
+const SharedPackSource = "shared"
+
+// sharedPackGitTimeout bounds a clone or pull so an unreachable remote cannot stall the sync
+const sharedPackGitTimeout = 60 * time.Second
+
+// sharedPackQuery is one entry of the pack's queries.json
+type sharedPackQuery struct {
+	Name        string `json:"name"`
+	Query       string `json:"query"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+}
+
+// SharedPack is the content read from a pack directory
+type SharedPack struct {
+	Prompts  []*PromptTemplate
+	Queries  []*db.SavedQuery
+	Problems []string // files or entries skipped as invalid
+}
+
+// LoadSharedPack reads prompts/*.md (front matter like prompt files) and queries.json from dir.
+// Invalid entries are skipped and listed in Problems; a missing dir is an error.
+func LoadSharedPack(dir string) (*SharedPack, error) {
+	if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+		return nil, fmt.Errorf("shared pack directory %s not found", dir)
+	}
+	pack := &SharedPack{}
+	seen := make(map[string]bool)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "prompts", "*.md"))
+	sort.Strings(files)
+	parser := &PromptServiceImpl{}
+	for _, f := range files {
+		content, err := os.ReadFile(f) // #nosec G304 -- files inside the configured pack directory
+		if err != nil {
+			pack.Problems = append(pack.Problems, fmt.Sprintf("%s: %v", filepath.Base(f), err))
+			continue
+		}
+		fm, text, err := parser.parseFrontMatter(content)
+		switch {
+		case err != nil:
+			pack.Problems = append(pack.Problems, fmt.Sprintf("%s: %v", filepath.Base(f), err))
+			continue
+		case strings.TrimSpace(fm.Name) == "" || strings.TrimSpace(fm.Category) == "" || strings.TrimSpace(text) == "":
+			pack.Problems = append(pack.Problems, fmt.Sprintf("%s: needs a name, a category and prompt text", filepath.Base(f)))
+			continue
+		case seen[fm.Name]:
+			pack.Problems = append(pack.Problems, fmt.Sprintf("%s: duplicate prompt %q", filepath.Base(f), fm.Name))
+			continue
+		}
+		seen[fm.Name] = true
+		pack.Prompts = append(pack.Prompts, &PromptTemplate{Name: fm.Name, Description: fm.Description, PromptText: text, Category: fm.Category})
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "queries.json")) // #nosec G304 -- file inside the configured pack directory
+	if errors.Is(err, os.ErrNotExist) {
+		return pack, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queries.json: %w", err)
+	}
+	var queries []sharedPackQuery
+	if err := json.Unmarshal(raw, &queries); err != nil {
+		pack.Problems = append(pack.Problems, fmt.Sprintf("queries.json: %v", err))
+		return pack, nil
+	}
+	seen = make(map[string]bool)
+	for i, q := range queries {
+		name := strings.TrimSpace(q.Name)
+		switch {
+		case name == "" || strings.TrimSpace(q.Query) == "":
+			pack.Problems = append(pack.Problems, fmt.Sprintf("queries.json #%d: needs a name and a query", i+1))
+			continue
+		case seen[name]:
+			pack.Problems = append(pack.Problems, fmt.Sprintf("queries.json: duplicate query %q", name))
+			continue
+		}
+		seen[name] = true
+		category := strings.TrimSpace(q.Category)
+		if category == "" {
+			category = "general"
+		}
+		pack.Queries = append(pack.Queries, &db.SavedQuery{Name: name, Query: q.Query, Description: q.Description, Category: category})
+	}
+	return pack, nil
+}
+
+// SharedPackServiceImpl implements SharedPackService
+type SharedPackServiceImpl struct {
+	config       *config.Config
+	prompts      *db.PromptStore
+	queries      *db.QueryStore
+	accountEmail string
+	mu           sync.Mutex // one sync at a time
+	runGit       func(ctx context.Context, args ...string) error
+}
+
+// NewSharedPackService creates the shared pack service over the account database stores
+func NewSharedPackService(cfg *config.Config, prompts *db.PromptStore, queries *db.QueryStore) *SharedPackServiceImpl {
+	return &SharedPackServiceImpl{config: cfg, prompts: prompts, queries: queries, runGit: runGitCommand}
+}
+
+// SetAccountEmail sets the account whose saved queries receive the pack's queries
+func (s *SharedPackServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+// runGitCommand runs git with args and returns its output as the error on failure
+func runGitCommand(ctx context.Context, args ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, sharedPackGitTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 -- repo URL and dir come from the user's config
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// pull clones the pack repository into dir, or fast-forwards an existing clone
+func (s *SharedPackServiceImpl) pull(ctx context.Context, repo, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return s.runGit(ctx, "-C", dir, "pull", "--ff-only", "--quiet")
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s is not a git checkout of %s", dir, repo)
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0750); err != nil {
+		return err
+	}
+	return s.runGit(ctx, "clone", "--depth", "1", "--quiet", repo, dir)
+}
+
+// Sync pulls the pack (when pull is set and a repository is configured) and merges its prompts
+// and saved queries. A failed pull is reported and the existing checkout is still merged; a pack
+// that cannot be read leaves the local sets untouched. Without a configured pack, items merged
+// earlier are removed.
+func (s *SharedPackServiceImpl) Sync(ctx context.Context, pull bool) (*SharedPackReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return nil, fmt.Errorf("config not available")
+	}
+
+	pc := s.config.SharedPack
+	report := &SharedPackReport{}
+	pack := &SharedPack{}
+	if pc.Enabled() {
+		report.Dir = expandHomePath(strings.TrimSpace(pc.ResolvedDir()))
+		if repo := strings.TrimSpace(pc.Repo); repo != "" && pull {
+			report.PullErr = s.pull(ctx, repo, report.Dir)
+			report.Pulled = report.PullErr == nil
+		}
+		loaded, err := LoadSharedPack(report.Dir)
+		if err != nil {
+			if report.PullErr != nil {
+				return report, fmt.Errorf("%w (pull failed: %v)", err, report.PullErr)
+			}
+			return report, err
+		}
+		pack = loaded
+		report.Problems = pack.Problems
+	}
+
+	var err error
+	if s.prompts != nil {
+		if report.Prompts, err = s.prompts.SyncSharedPrompts(ctx, SharedPackSource, pack.Prompts); err != nil {
+			return report, err
+		}
+	}
+	if s.queries != nil && strings.TrimSpace(s.accountEmail) != "" {
+		if report.Queries, err = s.queries.SyncSharedQueries(ctx, s.accountEmail, SharedPackSource, pack.Queries); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+)
+
+func writePackFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSharedPack(t *testing.T) {
+	dir := t.TempDir()
+	writePackFile(t, filepath.Join(dir, "prompts", "triage.md"), "---\nname: Team triage\ncategory: team\n---\nTriage this: {{body}}\n")
+	writePackFile(t, filepath.Join(dir, "prompts", "broken.md"), "no front matter")
+	writePackFile(t, filepath.Join(dir, "queries.json"), `[
+  {"name": "Escalations", "query": "label:escalation is:unread"},
+  {"name": "", "query": "from:x"}
+]`)
+
+	pack, err := LoadSharedPack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pack.Prompts) != 1 || pack.Prompts[0].Name != "Team triage" || !strings.Contains(pack.Prompts[0].PromptText, "{{body}}") {
+		t.Fatalf("prompts = %+v", pack.Prompts)
+	}
+	if len(pack.Queries) != 1 || pack.Queries[0].Category != "general" {
+		t.Fatalf("queries = %+v", pack.Queries)
+	}
+	if len(pack.Problems) != 2 {
+		t.Fatalf("problems = %q, want the broken prompt and the unnamed query", pack.Problems)
+	}
+	if _, err := LoadSharedPack(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("want an error for a missing pack directory")
+	}
+}
+
+func TestSharedPackSync_ReadOnlyMerge(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, filepath.Join(t.TempDir(), "pack.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	dir := filepath.Join(t.TempDir(), "pack")
+	cfg := &config.Config{SharedPack: config.SharedPackConfig{Repo: "https://example.com/team/pack.git", Dir: dir}}
+	promptStore, queryStore := db.NewPromptStore(store), db.NewQueryStore(store)
+	svc := NewSharedPackService(cfg, promptStore, queryStore)
+	svc.SetAccountEmail("me@example.com")
+	var gitCalls []string
+	svc.runGit = func(ctx context.Context, args ...string) error {
+		gitCalls = append(gitCalls, strings.Join(args, " "))
+		if args[0] == "clone" {
+			writePackFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/main\n")
+			writePackFile(t, filepath.Join(dir, "prompts", "triage.md"), "---\nname: Team triage\ncategory: team\n---\nTriage: {{body}}\n")
+			writePackFile(t, filepath.Join(dir, "queries.json"), `[{"name": "Escalations", "query": "label:escalation"}]`)
+			return nil
+		}
+		return errors.New("offline")
+	}
+
+	report, err := svc.Sync(ctx, true)
+	if err != nil || !report.Pulled || report.Prompts.Added != 1 || report.Queries.Added != 1 {
+		t.Fatalf("first sync = %+v, %v", report, err)
+	}
+	if len(gitCalls) != 1 || !strings.HasPrefix(gitCalls[0], "clone") {
+		t.Fatalf("git calls = %q, want a clone", gitCalls)
+	}
+
+	// A failed pull still merges the existing checkout
+	report, err = svc.Sync(ctx, true)
+	if err != nil || report.Pulled || report.PullErr == nil || !strings.Contains(gitCalls[1], "pull --ff-only") {
+		t.Fatalf("sync with a failed pull = %+v, %v (git %q)", report, err, gitCalls)
+	}
+
+	prompts := NewPromptService(promptStore, nil, nil)
+	p, err := prompts.FindPromptByName(ctx, "Team triage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prompts.DeletePrompt(ctx, p.ID); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("deleting a shared prompt: %v, want ErrReadOnly", err)
+	}
+	queries := NewQueryService(queryStore, cfg)
+	queries.SetAccountEmail("me@example.com")
+	if _, err := queries.SaveQuery(ctx, "Escalations", "label:mine", "", ""); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("overwriting a shared query: %v, want ErrReadOnly", err)
+	}
+	if err := queries.DeleteQueryByName(ctx, "Escalations"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("deleting a shared query: %v, want ErrReadOnly", err)
+	}
+
+	// Dropping the pack from the config removes what it merged
+	cfg.SharedPack = config.SharedPackConfig{}
+	if report, err = svc.Sync(ctx, false); err != nil || report.Prompts.Removed != 1 || report.Queries.Removed != 1 {
+		t.Fatalf("sync without a pack = %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+const (
+	// slackRouteDefaultPerHour caps posts per route when max_per_hour is unset
+	slackRouteDefaultPerHour = 20
+	// slackRouteSeenTTL is how long a routed message is remembered for dedupe
+	slackRouteSeenTTL = 24 * time.Hour
+)
+
+// slackRouteState remembers what the routes posted: message IDs already sent per route
+// (dedupe), post times per route (rate limit) and messages held back by the limit
+type slackRouteState struct {
+	mu         sync.Mutex
+	seen       map[string]time.Time   // route + "\x00" + message ID → when it was routed
+	posts      map[string][]time.Time // route → post times within the last hour
+	suppressed map[string]int         // route → messages dropped by the rate limit since the last post
+}
+
+// claim filters ids down to those the route has not sent yet, applies the hourly cap and records
+// the post. It returns the IDs to post, how many earlier messages the cap dropped and how many
+// of ids the cap holds back now.
+func (st *slackRouteState) claim(route string, ids []string, perHour int, now time.Time) (post []string, dropped, held int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.seen == nil {
+		st.seen = make(map[string]time.Time)
+		st.posts = make(map[string][]time.Time)
+		st.suppressed = make(map[string]int)
+	}
+	for k, at := range st.seen {
+		if now.Sub(at) > slackRouteSeenTTL {
+			delete(st.seen, k)
+		}
+	}
+
+	fresh := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := route + "\x00" + id
+		if _, ok := st.seen[key]; ok {
+			continue
+		}
+		st.seen[key] = now
+		fresh = append(fresh, id)
+	}
+	if len(fresh) == 0 {
+		return nil, 0, 0
+	}
+
+	recent := st.posts[route][:0]
+	for _, at := range st.posts[route] {
+		if now.Sub(at) < time.Hour {
+			recent = append(recent, at)
+		}
+	}
+	if perHour <= 0 {
+		perHour = slackRouteDefaultPerHour
+	}
+	if len(recent) >= perHour {
+		st.posts[route] = recent
+		st.suppressed[route] += len(fresh)
+		return nil, 0, len(fresh)
+	}
+	st.posts[route] = append(recent, now)
+	dropped = st.suppressed[route]
+	st.suppressed[route] = 0
+	return fresh, dropped, 0
+}
+
+// release undoes a claim whose post failed so the next cycle retries the IDs and still reports
+// what the limit dropped
+func (st *slackRouteState) release(route string, ids []string, dropped int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.suppressed[route] += dropped
+	for _, id := range ids {
+		delete(st.seen, route+"\x00"+id)
+	}
+	if posts := st.posts[route]; len(posts) > 0 {
+		st.posts[route] = posts[:len(posts)-1]
+	}
+}
+
+// slackRouteMatches reports whether a message matches the route's label and query
+func slackRouteMatches(route config.SlackRoute, q *LocalQuery, m *gmailapi.Message, names map[string]string, now time.Time) bool {
+	if m == nil {
+		return false
+	}
+	if label := strings.TrimSpace(route.Label); label != "" && !hasLabelNamed(m, names, label) {
+		return false
+	}
+	return q == nil || q.Match(m, names, now)
+}
+
+// slackChannelWebhook resolves a route's channel by ID or name; empty uses the default channel
+func slackChannelWebhook(cfg *config.Config, channel string) (string, error) {
+	channel = strings.TrimSpace(channel)
+	if channel == "" {
+		return defaultSlackWebhook(cfg)
+	}
+	for _, ch := range cfg.Slack.Channels {
+		if strings.EqualFold(ch.ID, channel) || strings.EqualFold(ch.Name, channel) {
+			if strings.TrimSpace(ch.WebhookURL) == "" {
+				return "", fmt.Errorf("slack channel %q has no webhook", channel)
+			}
+			return ch.WebhookURL, nil
+		}
+	}
+	return "", fmt.Errorf("slack channel %q is not configured", channel)
+}
+
+// slackRouteName names a route for notifications: its name, else its label or query
+func slackRouteName(route config.SlackRoute) string {
+	for _, s := range []string{route.Name, route.Label, route.Query} {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return "route"
+}
+
+// RouteNewMail posts each route's matching new messages to its channel, one post per route.
+// A message is sent at most once per route, and each route keeps to its hourly cap. Routes that
+// fail (bad query, unknown channel, webhook error) are reported without stopping the others.
+func (s *SlackServiceImpl) RouteNewMail(ctx context.Context, messageIDs []string, opts SlackRouteOptions) ([]SlackRouteResult, error) {
+	if s.config == nil || len(s.config.Slack.Routes) == 0 || len(messageIDs) == 0 {
+		return nil, nil
+	}
+	if s.client == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	metas, err := s.client.GetMessagesMetadataParallel(messageIDs, 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch new mail metadata: %w", err)
+	}
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	var results []SlackRouteResult
+	var lastErr error
+	for _, route := range s.config.Slack.Routes {
+		name := slackRouteName(route)
+		if strings.TrimSpace(route.Label) == "" && strings.TrimSpace(route.Query) == "" {
+			lastErr = fmt.Errorf("slack route %q: needs a label or a query", name)
+			continue
+		}
+		var q *LocalQuery
+		if strings.TrimSpace(route.Query) != "" {
+			if q, err = ParseLocalQuery(route.Query); err != nil {
+				lastErr = fmt.Errorf("slack route %q: %w", name, err)
+				continue
+			}
+		}
+
+		var ids []string
+		byID := make(map[string]*gmailapi.Message)
+		for _, m := range metas {
+			if slackRouteMatches(route, q, m, opts.LabelNames, now) {
+				ids = append(ids, m.Id)
+				byID[m.Id] = m
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		webhook, err := slackChannelWebhook(s.config, route.Channel)
+		if err != nil {
+			lastErr = fmt.Errorf("slack route %q: %w", name, err)
+			continue
+		}
+
+		post, dropped, held := s.routes.claim(name, ids, route.MaxPerHour, now)
+		if held > 0 {
+			results = append(results, SlackRouteResult{Route: name, Suppressed: held})
+		}
+		if len(post) == 0 {
+			continue
+		}
+		items := make([]digestItem, 0, len(post))
+		for _, id := range post {
+			hdr := s.extractEmailMetadata(byID[id])
+			link := ""
+			if opts.LinkFor != nil {
+				link = opts.LinkFor(id)
+			}
+			items = append(items, digestItem{Subject: hdr["subject"], From: hdr["from"], Link: link})
+		}
+		if err := s.sendToSlack(ctx, SlackMessage{Text: buildRouteDigest(name, items, dropped)}, webhook); err != nil {
+			s.routes.release(name, post, dropped)
+			lastErr = fmt.Errorf("slack route %q: %w", name, err)
+			continue
+		}
+		results = append(results, SlackRouteResult{Route: name, Posted: len(post)})
+	}
+	return results, lastErr
+}
+
+// buildRouteDigest formats a route's notification; dropped counts messages an earlier rate limit
+// held back
+func buildRouteDigest(route string, items []digestItem, dropped int) string {
+	title := fmt.Sprintf("📬 %s: %d new email(s):", route, len(items))
+	if dropped > 0 {
+		title = fmt.Sprintf("📬 %s: %d new email(s) (%d more skipped by the hourly limit):", route, len(items), dropped)
+	}
+	return formatDigest(title, items)
+}
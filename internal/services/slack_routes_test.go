@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestRouteNewMail_DedupeAndRateLimit(t *testing.T) {
+	posts := map[string][]string{} // path → texts
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var m SlackMessage
+		_ = json.Unmarshal(b, &m)
+		if status == http.StatusOK {
+			posts[r.URL.Path] = append(posts[r.URL.Path], m.Text)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.Slack = config.DefaultSlackConfig()
+	cfg.Slack.Enabled = true
+	cfg.Slack.Channels = []config.SlackChannel{
+		{ID: "general", Name: "general", WebhookURL: srv.URL + "/general", Default: true},
+		{ID: "acme", Name: "acme-alerts", WebhookURL: srv.URL + "/acme"},
+	}
+	cfg.Slack.Routes = []config.SlackRoute{
+		{Name: "Acme", Label: "Clients/Acme", Channel: "acme-alerts", MaxPerHour: 1},
+		{Name: "Pager", Query: "from:pager@example.com"},
+	}
+
+	meta := func(id, from string, labels ...string) *gmailapi.Message {
+		return &gmailapi.Message{Id: id, LabelIds: labels, Payload: &gmailapi.MessagePart{Headers: []*gmailapi.MessagePartHeader{
+			{Name: "Subject", Value: "Subject " + id}, {Name: "From", Value: from},
+		}}}
+	}
+	gc := &slackStubGmail{meta: []*gmailapi.Message{
+		meta("m1", "bob@acme.com", "L1"),
+		meta("m2", "pager@example.com"),
+		meta("m3", "ann@other.com"),
+	}}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	opts := SlackRouteOptions{LabelNames: map[string]string{"L1": "Clients/Acme"}, Now: func() time.Time { return now }}
+	s := &SlackServiceImpl{client: gc, config: cfg, httpClient: srv.Client()}
+
+	results, err := s.RouteNewMail(context.Background(), []string{"m1", "m2", "m3"}, opts)
+	if err != nil {
+		t.Fatalf("RouteNewMail: %v", err)
+	}
+	if len(results) != 2 || results[0].Posted != 1 || results[1].Posted != 1 {
+		t.Fatalf("results = %+v, want one post per route", results)
+	}
+	if got := posts["/acme"]; len(got) != 1 || !strings.Contains(got[0], "Acme: 1 new email(s)") || !strings.Contains(got[0], "Subject m1") {
+		t.Fatalf("acme channel posts = %q", got)
+	}
+	if got := posts["/general"]; len(got) != 1 || !strings.Contains(got[0], "Subject m2") || strings.Contains(got[0], "Subject m3") {
+		t.Fatalf("default channel posts = %q", got)
+	}
+
+	// The same messages again: already routed, nothing posted
+	if results, _ := s.RouteNewMail(context.Background(), []string{"m1", "m2"}, opts); len(results) != 0 {
+		t.Fatalf("repeat routing = %+v, want dedupe", results)
+	}
+
+	// A second Acme message within the hour exceeds max_per_hour
+	gc.meta = []*gmailapi.Message{meta("m4", "bob@acme.com", "L1")}
+	results, _ = s.RouteNewMail(context.Background(), []string{"m4"}, opts)
+	if len(results) != 1 || results[0].Suppressed != 1 || len(posts["/acme"]) != 1 {
+		t.Fatalf("rate limit: results = %+v, acme posts = %d", results, len(posts["/acme"]))
+	}
+
+	// An hour later the next post reports what the limit held back
+	now = now.Add(time.Hour)
+	gc.meta = []*gmailapi.Message{meta("m5", "bob@acme.com", "L1")}
+	if _, err := s.RouteNewMail(context.Background(), []string{"m5"}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := posts["/acme"]; len(got) != 2 || !strings.Contains(got[1], "1 more skipped by the hourly limit") {
+		t.Fatalf("acme posts after the limit = %q", got)
+	}
+
+	// A failed post is retried on the next cycle
+	gc.meta = []*gmailapi.Message{meta("m6", "pager@example.com")}
+	status = http.StatusInternalServerError
+	if _, err := s.RouteNewMail(context.Background(), []string{"m6"}, opts); err == nil {
+		t.Fatal("expected the webhook error")
+	}
+	status = http.StatusOK
+	if results, err := s.RouteNewMail(context.Background(), []string{"m6"}, opts); err != nil || len(results) != 1 || results[0].Posted != 1 {
+		t.Fatalf("retry after failure = %+v, %v", results, err)
+	}
+}
+
+func TestRouteNewMail_InvalidRoutes(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Slack.Channels = []config.SlackChannel{{Name: "general", WebhookURL: "http://127.0.0.1:0", Default: true}}
+	cfg.Slack.Routes = []config.SlackRoute{
+		{Name: "empty"},
+		{Name: "bad", Query: "has:attachment"},
+		{Name: "lost", Query: "from:a@example.com", Channel: "nowhere"},
+	}
+	gc := &slackStubGmail{meta: []*gmailapi.Message{{Id: "m1", Payload: &gmailapi.MessagePart{Headers: []*gmailapi.MessagePartHeader{{Name: "From", Value: "a@example.com"}}}}}}
+	s := &SlackServiceImpl{client: gc, config: cfg, httpClient: http.DefaultClient}
+	results, err := s.RouteNewMail(context.Background(), []string{"m1"}, SlackRouteOptions{})
+	if len(results) != 0 || err == nil || !strings.Contains(err.Error(), "nowhere") {
+		t.Fatalf("results = %+v, err = %v; want the last route's channel error", results, err)
+	}
+}
@@ -30,6 +30,7 @@ type SlackServiceImpl struct {
 	config     *config.Config
 	aiService  AIService
 	httpClient *http.Client
+	routes     slackRouteState // dedupe and rate limits for slack.routes
 }
 
 // NewSlackService creates a new SlackService implementation
@@ -514,8 +515,13 @@ type digestItem struct {
 // buildNewMailDigest formats the new-mail Slack notification, capping listed rows at digestMaxList.
 // A non-empty Summary renders as an indented italic line under the row.
 func buildNewMailDigest(items []digestItem) string {
+	return formatDigest(fmt.Sprintf("📬 %d new email(s):", len(items)), items)
+}
+
+// formatDigest renders a title line followed by the digest rows
+func formatDigest(title string, items []digestItem) string {
 	var b strings.Builder
-	fmt.Fprintf(&b, "📬 %d new email(s):", len(items))
+	b.WriteString(title)
 	for i, it := range items {
 		if i >= digestMaxList {
 			fmt.Fprintf(&b, "\n…and %d more", len(items)-digestMaxList)
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// SmartLabelServiceImpl implements SmartLabelService. Saved queries are evaluated locally
+// (ParseLocalQuery) against the metadata of new messages, so they can use regexes and
+// size/age combinations Gmail filters cannot express.
+type SmartLabelServiceImpl struct {
+	store        *db.QueryStore
+	labelService LabelService
+	apply        func(messageID, labelID string) error
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewSmartLabelService creates the smart label service. apply adds a label to a message without
+// recording undo (gmail.Client.ApplyLabel): background labeling is not a user action.
+func NewSmartLabelService(store *db.QueryStore, labelService LabelService, apply func(messageID, labelID string) error) *SmartLabelServiceImpl {
+	return &SmartLabelServiceImpl{store: store, labelService: labelService, apply: apply}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *SmartLabelServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *SmartLabelServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("query store not available")
+	}
+	return email, nil
+}
+
+// Link turns a saved query into a smart label. The query must be evaluable locally.
+func (s *SmartLabelServiceImpl) Link(ctx context.Context, queryName, labelName string) (*SmartLabelInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	labelName = strings.TrimSpace(labelName)
+	if labelName == "" {
+		return nil, fmt.Errorf("label name cannot be empty")
+	}
+	q, err := s.store.GetQueryByName(ctx, email, strings.TrimSpace(queryName))
+	if err != nil {
+		return nil, fmt.Errorf("saved query %q: %w", queryName, err)
+	}
+	if _, err := ParseLocalQuery(q.Query); err != nil {
+		return nil, fmt.Errorf("saved query %q cannot be evaluated locally: %w", q.Name, err)
+	}
+	if err := s.store.LinkSmartLabel(ctx, email, q.ID, labelName); err != nil {
+		return nil, err
+	}
+	return &SmartLabelInfo{QueryID: q.ID, QueryName: q.Name, Query: q.Query, LabelName: labelName}, nil
+}
+
+// Unlink stops auto-applying a label for a saved query. Labels already applied are kept.
+func (s *SmartLabelServiceImpl) Unlink(ctx context.Context, queryName string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	q, err := s.store.GetQueryByName(ctx, email, strings.TrimSpace(queryName))
+	if err != nil {
+		return fmt.Errorf("saved query %q: %w", queryName, err)
+	}
+	return s.store.UnlinkSmartLabel(ctx, email, q.ID)
+}
+
+// List returns the account's smart labels
+func (s *SmartLabelServiceImpl) List(ctx context.Context) ([]*SmartLabelInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.store.ListSmartLabels(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*SmartLabelInfo, 0, len(links))
+	for _, l := range links {
+		out = append(out, &SmartLabelInfo{QueryID: l.QueryID, QueryName: l.QueryName, Query: l.Query, LabelName: l.LabelName})
+	}
+	return out, nil
+}
+
+// Apply evaluates every smart label against msgs (metadata is enough) and labels the matches.
+// Messages that already carry the label are skipped. Queries that no longer parse are reported
+// but do not stop the others.
+func (s *SmartLabelServiceImpl) Apply(ctx context.Context, msgs []*gmail_v1.Message) ([]SmartLabelMatch, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	links, err := s.List(ctx)
+	if err != nil || len(links) == 0 {
+		return nil, err
+	}
+	if s.labelService == nil || s.apply == nil {
+		return nil, fmt.Errorf("labeling not available")
+	}
+
+	labels, err := s.labelService.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(labels))
+	for _, l := range labels {
+		names[l.Id] = l.Name
+	}
+
+	now := time.Now()
+	var matches []SmartLabelMatch
+	var lastErr error
+	for _, link := range links {
+		q, err := ParseLocalQuery(link.Query)
+		if err != nil {
+			lastErr = fmt.Errorf("smart label %q: %w", link.QueryName, err)
+			continue
+		}
+		var labelID string
+		for _, m := range msgs {
+			if m == nil || !q.Match(m, names, now) || hasLabelNamed(m, names, link.LabelName) {
+				continue
+			}
+			if labelID == "" {
+				label, err := s.labelService.EnsureLabelPath(ctx, link.LabelName)
+				if err != nil {
+					lastErr = fmt.Errorf("smart label %q: %w", link.QueryName, err)
+					break
+				}
+				labelID = label.Id
+				names[label.Id] = label.Name
+			}
+			if err := s.apply(m.Id, labelID); err != nil {
+				lastErr = ClassifyError("apply smart label", err)
+				continue
+			}
+			m.LabelIds = append(m.LabelIds, labelID)
+			matches = append(matches, SmartLabelMatch{MessageID: m.Id, LabelID: labelID, LabelName: link.LabelName, QueryName: link.QueryName})
+		}
+	}
+	return matches, lastErr
+}
+
+// hasLabelNamed reports whether m already carries the label called name
+func hasLabelNamed(m *gmail_v1.Message, names map[string]string, name string) bool {
+	for _, id := range m.LabelIds {
+		if strings.EqualFold(names[id], name) {
+			return true
+		}
+	}
+	return false
+}
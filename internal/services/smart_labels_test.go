@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestSmartLabelService_LinkAndApply(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/smart.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	qs := db.NewQueryStore(store)
+	const acct = "me@example.com"
+	_, err = qs.SaveQuery(ctx, acct, "ci", "from:/noreply@(github|gitlab)\\.com/", "", "")
+	require.NoError(t, err)
+	_, err = qs.SaveQuery(ctx, acct, "attachments", "has:attachment", "", "")
+	require.NoError(t, err)
+
+	client := &MockLabelClient{}
+	client.On("ListLabels").Return([]*gmail_v1.Label{{Id: "INBOX", Name: "INBOX"}, {Id: "L_ci", Name: "CI"}}, nil)
+	var applied []string
+	svc := NewSmartLabelService(qs, NewLabelService(client), func(messageID, labelID string) error {
+		applied = append(applied, messageID+":"+labelID)
+		return nil
+	})
+
+	_, err = svc.Link(ctx, "ci", "CI")
+	assert.EqualError(t, err, "account email not set")
+	svc.SetAccountEmail(acct)
+
+	_, err = svc.Link(ctx, "attachments", "Files")
+	assert.ErrorContains(t, err, "cannot be evaluated locally")
+	_, err = svc.Link(ctx, "missing", "X")
+	assert.Error(t, err)
+	info, err := svc.Link(ctx, "ci", "CI")
+	require.NoError(t, err)
+	assert.Equal(t, "CI", info.LabelName)
+
+	from := func(id, addr string, labels ...string) *gmail_v1.Message {
+		return &gmail_v1.Message{Id: id, LabelIds: labels, Payload: &gmail_v1.MessagePart{
+			Headers: []*gmail_v1.MessagePartHeader{{Name: "From", Value: addr}},
+		}}
+	}
+	msgs := []*gmail_v1.Message{
+		from("m1", "GitHub <noreply@github.com>", "INBOX"),
+		from("m2", "Alice <alice@example.com>", "INBOX"),
+		from("m3", "GitLab <noreply@gitlab.com>", "INBOX", "L_ci"), // already labeled
+	}
+	matches, err := svc.Apply(ctx, msgs)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, SmartLabelMatch{MessageID: "m1", LabelID: "L_ci", LabelName: "CI", QueryName: "ci"}, matches[0])
+	assert.Equal(t, []string{"m1:L_ci"}, applied)
+	assert.Contains(t, msgs[0].LabelIds, "L_ci")
+	client.AssertNotCalled(t, "CreateLabel", mock.Anything)
+
+	require.NoError(t, svc.Unlink(ctx, "ci"))
+	links, err := svc.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+}
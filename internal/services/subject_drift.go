@@ -0,0 +1,81 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// subjectDriftSimilarity is the share of the shorter subject's words two subjects must have in
+// common to count as the same topic
+const subjectDriftSimilarity = 0.5
+
+// replyPrefixPattern matches reply and forward prefixes in the languages mail clients commonly
+// use, including counted forms like "Re[2]:" and "RE:RE:"
+var replyPrefixPattern = regexp.MustCompile(`(?i)^\s*(re|fw|fwd|aw|wg|sv|vs|tr|rv|antw|odp|ynt)(\[\d+\]|\(\d+\))?\s*:\s*`)
+
+// wasSuffixPattern matches the "(was: old subject)" people append when they change a topic
+var wasSuffixPattern = regexp.MustCompile(`(?i)\s*[\(\[]\s*was\s*:.*[\)\]]\s*$`)
+
+// NormalizeThreadSubject strips reply/forward prefixes and a "(was: ...)" suffix from a subject
+func NormalizeThreadSubject(subject string) string {
+	s := strings.TrimSpace(subject)
+	for {
+		trimmed := replyPrefixPattern.ReplaceAllString(s, "")
+		if trimmed == s {
+			break
+		}
+		s = trimmed
+	}
+	return strings.TrimSpace(wasSuffixPattern.ReplaceAllString(s, ""))
+}
+
+// SubjectDriftPoints returns the indexes of the messages, in thread order, whose subject starts a
+// new topic: it differs materially from the subject of the message before it, beyond reply
+// prefixes, case, punctuation or a few added words. Messages without a subject keep the topic.
+func SubjectDriftPoints(subjects []string) []int {
+	var points []int
+	var topic []string
+	for i, subject := range subjects {
+		words := subjectWords(NormalizeThreadSubject(subject))
+		if len(words) == 0 {
+			continue
+		}
+		if topic != nil && subjectSimilarity(topic, words) < subjectDriftSimilarity {
+			points = append(points, i)
+		}
+		topic = words
+	}
+	return points
+}
+
+// subjectWords splits a subject into lowercased words, dropping punctuation
+func subjectWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// subjectSimilarity is the overlap coefficient of two word lists: shared words over the size of
+// the smaller set, so "Budget" and "Budget for Q3" are the same topic
+func subjectSimilarity(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, w := range a {
+		set[w] = true
+	}
+	shared, seen := 0, make(map[string]bool, len(b))
+	for _, w := range b {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		if set[w] {
+			shared++
+		}
+	}
+	smaller := min(len(set), len(seen))
+	if smaller == 0 {
+		return 1
+	}
+	return float64(shared) / float64(smaller)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeThreadSubject(t *testing.T) {
+	assert.Equal(t, "Budget Q3", NormalizeThreadSubject("RE: Fwd: re[2]: Budget Q3"))
+	assert.Equal(t, "Offsite venue", NormalizeThreadSubject("AW: Offsite venue (was: Budget Q3)"))
+	assert.Equal(t, "Rebate", NormalizeThreadSubject("Rebate"))
+}
+
+func TestSubjectDriftPoints(t *testing.T) {
+	subjects := []string{
+		"Budget Q3",
+		"Re: Budget Q3",
+		"RE: Budget Q3 - revised numbers",
+		"",
+		"Re: Offsite venue (was: Budget Q3)",
+		"Re: Offsite venue",
+		"Re: offsite venue!",
+		"Lunch on Friday?",
+	}
+	assert.Equal(t, []int{4, 7}, SubjectDriftPoints(subjects))
+	assert.Empty(t, SubjectDriftPoints([]string{"Invoice 42", "Re: Invoice 42", "Fw: Re: Invoice #42 paid"}))
+	assert.Empty(t, SubjectDriftPoints(nil))
+}
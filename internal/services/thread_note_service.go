@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// Thread note sources
+const (
+	ThreadNoteAI     = "ai"     // pinned AI summary (possibly edited afterwards)
+	ThreadNoteManual = "manual" // written by hand
+)
+
+// ThreadNoteServiceImpl implements ThreadNoteService
+type ThreadNoteServiceImpl struct {
+	store        *db.ThreadNoteStore
+	summarize    func(ctx context.Context, threadID string, force bool) (string, error)
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewThreadNoteService creates the pinned-note service. summarize returns the AI summary of a
+// thread, regenerating it when force is set; it may be nil when AI is not configured.
+func NewThreadNoteService(store *db.ThreadNoteStore, summarize func(ctx context.Context, threadID string, force bool) (string, error)) *ThreadNoteServiceImpl {
+	return &ThreadNoteServiceImpl{store: store, summarize: summarize}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *ThreadNoteServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *ThreadNoteServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("thread note store not available")
+	}
+	return email, nil
+}
+
+func threadNoteInfo(n *db.ThreadNote) *ThreadNoteInfo {
+	if n == nil {
+		return nil
+	}
+	return &ThreadNoteInfo{ThreadID: n.ThreadID, Note: n.Note, Source: n.Source, UpdatedAt: time.Unix(n.UpdatedAt, 0)}
+}
+
+// Get returns the note pinned to a thread, or nil when there is none
+func (s *ThreadNoteServiceImpl) Get(ctx context.Context, threadID string) (*ThreadNoteInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.store.Get(ctx, email, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return threadNoteInfo(n), nil
+}
+
+// Pin saves note as the thread's pinned note. An empty note unpins it.
+func (s *ThreadNoteServiceImpl) Pin(ctx context.Context, threadID, note, source string) (*ThreadNoteInfo, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		if err := s.store.Delete(ctx, email, threadID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	n, err := s.store.Save(ctx, email, threadID, note, source)
+	if err != nil {
+		return nil, err
+	}
+	return threadNoteInfo(n), nil
+}
+
+// Unpin removes the thread's note
+func (s *ThreadNoteServiceImpl) Unpin(ctx context.Context, threadID string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, email, threadID)
+}
+
+// Summary returns the AI summary of a thread to pin; force regenerates it instead of using the
+// cached one
+func (s *ThreadNoteServiceImpl) Summary(ctx context.Context, threadID string, force bool) (string, error) {
+	if s.summarize == nil {
+		return "", fmt.Errorf("AI service not available")
+	}
+	summary, err := s.summarize(ctx, threadID, force)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(summary) == "" {
+		return "", fmt.Errorf("the AI returned an empty summary")
+	}
+	return strings.TrimSpace(summary), nil
+}
+
+// Regenerate replaces the pinned note with a fresh AI summary of the thread
+func (s *ThreadNoteServiceImpl) Regenerate(ctx context.Context, threadID string) (*ThreadNoteInfo, error) {
+	if _, err := s.account(); err != nil {
+		return nil, err
+	}
+	summary, err := s.Summary(ctx, threadID, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.Pin(ctx, threadID, summary, ThreadNoteAI)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadNoteService(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/notes.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	var forced []bool
+	svc := NewThreadNoteService(db.NewThreadNoteStore(store), func(_ context.Context, threadID string, force bool) (string, error) {
+		forced = append(forced, force)
+		if threadID == "broken" {
+			return "", errors.New("llm down")
+		}
+		return "  Summary of " + threadID + "\n", nil
+	})
+
+	_, err = svc.Get(ctx, "t1")
+	assert.EqualError(t, err, "account email not set")
+	svc.SetAccountEmail("me@example.com")
+
+	note, err := svc.Get(ctx, "t1")
+	require.NoError(t, err)
+	assert.Nil(t, note)
+
+	summary, err := svc.Summary(ctx, "t1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Summary of t1", summary)
+
+	note, err = svc.Pin(ctx, "t1", summary+" — edited", ThreadNoteAI)
+	require.NoError(t, err)
+	assert.Equal(t, "Summary of t1 — edited", note.Note)
+
+	note, err = svc.Regenerate(ctx, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, "Summary of t1", note.Note)
+	assert.Equal(t, ThreadNoteAI, note.Source)
+	assert.Equal(t, []bool{false, true}, forced)
+
+	// A failed regeneration keeps the existing note
+	_, err = svc.Pin(ctx, "broken", "keep me", ThreadNoteManual)
+	require.NoError(t, err)
+	_, err = svc.Regenerate(ctx, "broken")
+	assert.Error(t, err)
+	note, _ = svc.Get(ctx, "broken")
+	assert.Equal(t, "keep me", note.Note)
+
+	// Saving an empty note unpins
+	note, err = svc.Pin(ctx, "t1", "   ", ThreadNoteManual)
+	require.NoError(t, err)
+	assert.Nil(t, note)
+	note, _ = svc.Get(ctx, "t1")
+	assert.Nil(t, note)
+	assert.Error(t, svc.Unpin(ctx, "t1"))
+
+	_, err = NewThreadNoteService(db.NewThreadNoteStore(store), nil).Summary(ctx, "t1", false)
+	assert.EqualError(t, err, "AI service not available")
+}
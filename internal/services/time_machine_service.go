@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// Defaults for config.TimeMachineConfig zero values
+const (
+	timeMachineMaxMessages   = 2000
+	timeMachineRetentionDays = 365
+)
+
+// timeMachineDayLayout is the snapshot day key (local time)
+const timeMachineDayLayout = "2006-01-02"
+
+// TimeMachineClient is the subset of *gmail.Client the snapshots depend on
+type TimeMachineClient interface {
+	SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmail_v1.Message, string, error)
+	GetMessagesMetadataParallel(messageIDs []string, maxWorkers int) ([]*gmail_v1.Message, error)
+}
+
+// TimeMachineServiceImpl implements TimeMachineService
+type TimeMachineServiceImpl struct {
+	store         *db.InboxSnapshotStore
+	client        TimeMachineClient
+	enabled       bool
+	maxMessages   int
+	retentionDays int
+	accountEmail  string
+	mu            sync.RWMutex
+	now           func() time.Time
+}
+
+// NewTimeMachineService creates the time machine service. client may be nil, in which case
+// snapshots can be browsed but not taken.
+func NewTimeMachineService(store *db.InboxSnapshotStore, client TimeMachineClient, cfg config.TimeMachineConfig) *TimeMachineServiceImpl {
+	s := &TimeMachineServiceImpl{
+		store:         store,
+		client:        client,
+		enabled:       cfg.Enabled,
+		maxMessages:   cfg.MaxMessages,
+		retentionDays: cfg.RetentionDays,
+		now:           time.Now,
+	}
+	if s.maxMessages <= 0 {
+		s.maxMessages = timeMachineMaxMessages
+	}
+	if s.retentionDays <= 0 {
+		s.retentionDays = timeMachineRetentionDays
+	}
+	return s
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *TimeMachineServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *TimeMachineServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("snapshot store not available")
+	}
+	return email, nil
+}
+
+// Capture records today's inbox snapshot. Unless force is set it does nothing when snapshots are
+// disabled or today's snapshot already exists (the result is nil then).
+func (s *TimeMachineServiceImpl) Capture(ctx context.Context, force bool) (*InboxSnapshot, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	if s.client == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+	day := s.now().Format(timeMachineDayLayout)
+	if !force {
+		if !s.enabled {
+			return nil, nil
+		}
+		days, err := s.store.Days(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if len(days) > 0 && days[0] == day {
+			return nil, nil
+		}
+	}
+
+	ids, err := s.listIDs(ctx, "in:inbox")
+	if err != nil {
+		return nil, err
+	}
+	unreadIDs, err := s.listIDs(ctx, "in:inbox is:unread")
+	if err != nil {
+		return nil, err
+	}
+	unread := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		unread[id] = false
+	}
+	for _, id := range unreadIDs {
+		if _, ok := unread[id]; ok {
+			unread[id] = true
+		}
+	}
+
+	if err := s.cacheMeta(ctx, email, ids); err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveSnapshot(ctx, email, day, unread); err != nil {
+		return nil, err
+	}
+	cutoff := s.now().AddDate(0, 0, -s.retentionDays).Format(timeMachineDayLayout)
+	if _, err := s.store.PruneBefore(ctx, email, cutoff); err != nil {
+		return nil, err
+	}
+
+	snap := &InboxSnapshot{Day: day, Messages: len(ids)}
+	for _, u := range unread {
+		if u {
+			snap.Unread++
+		}
+	}
+	return snap, nil
+}
+
+// listIDs lists message IDs for a query, newest first, up to maxMessages
+func (s *TimeMachineServiceImpl) listIDs(ctx context.Context, query string) ([]string, error) {
+	var ids []string
+	token := ""
+	for len(ids) < s.maxMessages {
+		var page []*gmail_v1.Message
+		err := RetryTransient(ctx, readAttempts, func() (err error) {
+			page, token, err = s.client.SearchMessagesPage(query, 500, token)
+			return ClassifyError("search messages", err)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			ids = append(ids, m.Id)
+		}
+		if token == "" || len(page) == 0 {
+			break
+		}
+	}
+	if len(ids) > s.maxMessages {
+		ids = ids[:s.maxMessages]
+	}
+	return ids, nil
+}
+
+// cacheMeta fetches and stores metadata for the messages not cached yet, so they can be listed
+// after they leave the mailbox
+func (s *TimeMachineServiceImpl) cacheMeta(ctx context.Context, email string, ids []string) error {
+	missing, err := s.store.MissingMeta(ctx, email, ids)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	msgs, err := s.client.GetMessagesMetadataParallel(missing, 10)
+	if err != nil {
+		return ClassifyError("get message metadata", err)
+	}
+	metas := make([]db.MessageMeta, 0, len(msgs))
+	for _, m := range msgs {
+		if m == nil {
+			continue
+		}
+		metas = append(metas, db.MessageMeta{
+			MessageID: m.Id,
+			ThreadID:  m.ThreadId,
+			Subject:   extractHeader(m, "Subject"),
+			From:      extractHeader(m, "From"),
+			SentAt:    m.InternalDate / 1000,
+		})
+	}
+	return s.store.SaveMeta(ctx, email, metas)
+}
+
+// At returns the inbox as recorded by the latest snapshot taken on or before date
+func (s *TimeMachineServiceImpl) At(ctx context.Context, date time.Time) (*TimeMachineView, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	day, entries, err := s.store.SnapshotOnOrBefore(ctx, email, date.Format(timeMachineDayLayout))
+	if err != nil {
+		return nil, err
+	}
+	if day == "" {
+		days, _ := s.store.Days(ctx, email)
+		if len(days) == 0 {
+			return nil, fmt.Errorf("no inbox snapshots yet; one is taken the first time the inbox loads each day")
+		}
+		return nil, fmt.Errorf("no snapshot on or before %s; the oldest is from %s", date.Format(timeMachineDayLayout), days[len(days)-1])
+	}
+	view := &TimeMachineView{Requested: date, SnapshotDay: day}
+	for _, e := range entries {
+		if e.Unread {
+			view.Unread++
+		}
+		view.Messages = append(view.Messages, snapshotMessage(e))
+	}
+	return view, nil
+}
+
+// Days lists the snapshot days, newest first
+func (s *TimeMachineServiceImpl) Days(ctx context.Context) ([]string, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	return s.store.Days(ctx, email)
+}
+
+// snapshotMessage turns a snapshot entry into list metadata the renderer understands
+func snapshotMessage(e *db.SnapshotEntry) *gmail_v1.Message {
+	labels := []string{"INBOX"}
+	if e.Unread {
+		labels = append(labels, "UNREAD")
+	}
+	subject := e.Subject
+	if e.SentAt == 0 && subject == "" {
+		subject = "(message details not cached)"
+	}
+	headers := []*gmail_v1.MessagePartHeader{
+		{Name: "Subject", Value: subject},
+		{Name: "From", Value: e.From},
+	}
+	if e.SentAt > 0 {
+		headers = append(headers, &gmail_v1.MessagePartHeader{Name: "Date", Value: time.Unix(e.SentAt, 0).Format(time.RFC1123Z)})
+	}
+	return &gmail_v1.Message{
+		Id:           e.MessageID,
+		ThreadId:     e.ThreadID,
+		LabelIds:     labels,
+		InternalDate: e.SentAt * 1000,
+		Payload:      &gmail_v1.MessagePart{Headers: headers},
+	}
+}
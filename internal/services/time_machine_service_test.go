@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type fakeTimeMachineClient struct {
+	results     map[string][]string
+	metaFetched []string
+}
+
+func (c *fakeTimeMachineClient) SearchMessagesPage(query string, _ int64, _ string) ([]*gmail_v1.Message, string, error) {
+	var page []*gmail_v1.Message
+	for _, id := range c.results[query] {
+		page = append(page, &gmail_v1.Message{Id: id})
+	}
+	return page, "", nil
+}
+
+func (c *fakeTimeMachineClient) GetMessagesMetadataParallel(ids []string, _ int) ([]*gmail_v1.Message, error) {
+	c.metaFetched = append(c.metaFetched, ids...)
+	var out []*gmail_v1.Message
+	for i, id := range ids {
+		out = append(out, &gmail_v1.Message{
+			Id:           id,
+			InternalDate: int64(2000-i) * 1000, // listed newest first
+			Payload:      &gmail_v1.MessagePart{Headers: []*gmail_v1.MessagePartHeader{{Name: "Subject", Value: "About " + id}}},
+		})
+	}
+	return out, nil
+}
+
+func TestTimeMachineService_CaptureAndAt(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/tm.db")
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	client := &fakeTimeMachineClient{results: map[string][]string{
+		"in:inbox":           {"m2", "m1"},
+		"in:inbox is:unread": {"m2", "archived"},
+	}}
+	svc := NewTimeMachineService(db.NewInboxSnapshotStore(store), client, config.TimeMachineConfig{Enabled: true})
+	day1 := time.Date(2026, 7, 1, 9, 0, 0, 0, time.Local)
+	svc.now = func() time.Time { return day1 }
+
+	_, err = svc.Capture(ctx, false)
+	assert.Error(t, err, "account must be set")
+	svc.SetAccountEmail("me@example.com")
+
+	snap, err := svc.Capture(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, &InboxSnapshot{Day: "2026-07-01", Messages: 2, Unread: 1}, snap)
+
+	again, err := svc.Capture(ctx, false)
+	require.NoError(t, err)
+	assert.Nil(t, again, "one snapshot a day unless forced")
+
+	// A week later m1 is gone and m3 arrived; only m3's metadata is fetched
+	client.results["in:inbox"] = []string{"m3", "m2"}
+	client.results["in:inbox is:unread"] = nil
+	svc.now = func() time.Time { return day1.AddDate(0, 0, 7) }
+	_, err = svc.Capture(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"m2", "m1", "m3"}, client.metaFetched)
+
+	view, err := svc.At(ctx, day1.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-07-01", view.SnapshotDay)
+	assert.Equal(t, 1, view.Unread)
+	require.Len(t, view.Messages, 2)
+	assert.Equal(t, "m2", view.Messages[0].Id, "newest first")
+	assert.Equal(t, []string{"INBOX", "UNREAD"}, view.Messages[0].LabelIds)
+	assert.Equal(t, "About m1", extractHeader(view.Messages[1], "Subject"))
+
+	_, err = svc.At(ctx, day1.AddDate(0, 0, -1))
+	assert.ErrorContains(t, err, "the oldest is from 2026-07-01")
+
+	days, err := svc.Days(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-07-08", "2026-07-01"}, days)
+}
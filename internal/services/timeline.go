@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// timelineMaxMessages caps how many messages a contact timeline reads from Gmail
+const timelineMaxMessages = 300
+
+// TimelineClient is the part of *gmail.Client the contact timeline uses
+type TimelineClient interface {
+	SearchMessagesPage(query string, maxResults int64, pageToken string) ([]*gmail_v1.Message, string, error)
+	GetMessagesMetadataParallel(messageIDs []string, maxWorkers int) ([]*gmail_v1.Message, error)
+}
+
+// TimelineEntry is one message exchanged with a contact
+type TimelineEntry struct {
+	MessageID string
+	ThreadID  string
+	Subject   string
+	Snippet   string
+	Date      time.Time
+	Outgoing  bool // sent by the account owner
+	Archived  bool // only in the local archive, no longer in Gmail
+}
+
+// ContactTimeline is every message exchanged with one address, oldest first
+type ContactTimeline struct {
+	Contact   string
+	Entries   []TimelineEntry
+	Truncated bool // more messages matched than were read
+}
+
+// Counts returns how many messages were sent to and received from the contact
+func (t *ContactTimeline) Counts() (sent, received int) {
+	for _, e := range t.Entries {
+		if e.Outgoing {
+			sent++
+		} else {
+			received++
+		}
+	}
+	return sent, received
+}
+
+// TimelineServiceImpl builds contact timelines from one Gmail search for the address in any
+// recipient or sender field, plus the messages moved to the local archive
+type TimelineServiceImpl struct {
+	client  TimelineClient
+	archive LocalArchiveService
+	self    string
+}
+
+// NewTimelineService creates a timeline service; self is the account's address, which tells
+// outgoing messages from incoming ones
+func NewTimelineService(client TimelineClient, self string) *TimelineServiceImpl {
+	return &TimelineServiceImpl{client: client, self: strings.ToLower(self)}
+}
+
+// SetLocalArchive adds the locally archived messages to the timelines
+func (s *TimelineServiceImpl) SetLocalArchive(archive LocalArchiveService) {
+	s.archive = archive
+}
+
+// TimelineQuery is the Gmail search for every message exchanged with contact
+func TimelineQuery(contact string) string {
+	return fmt.Sprintf("from:%[1]s OR to:%[1]s OR cc:%[1]s OR bcc:%[1]s", contact)
+}
+
+// Build returns the timeline of a contact address
+func (s *TimelineServiceImpl) Build(ctx context.Context, contact string) (*ContactTimeline, error) {
+	addr, err := mail.ParseAddress(contact)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address %q", contact)
+	}
+	t := &ContactTimeline{Contact: strings.ToLower(addr.Address)}
+
+	var ids []string
+	page := ""
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		msgs, next, err := s.client.SearchMessagesPage(TimelineQuery(t.Contact), 100, page)
+		if err != nil {
+			return nil, ClassifyError("search contact messages", err)
+		}
+		for _, m := range msgs {
+			ids = append(ids, m.Id)
+		}
+		if next == "" {
+			break
+		}
+		if len(ids) >= timelineMaxMessages {
+			t.Truncated = true
+			break
+		}
+		page = next
+	}
+	if len(ids) > timelineMaxMessages {
+		ids = ids[:timelineMaxMessages]
+		t.Truncated = true
+	}
+
+	seen := make(map[string]bool, len(ids))
+	if len(ids) > 0 {
+		metas, err := s.client.GetMessagesMetadataParallel(ids, 10)
+		if err != nil {
+			return nil, ClassifyError("load contact messages", err)
+		}
+		for _, m := range metas {
+			if m == nil || seen[m.Id] {
+				continue
+			}
+			seen[m.Id] = true
+			t.Entries = append(t.Entries, TimelineEntryFromMessage(m, s.self))
+		}
+	}
+
+	if s.archive != nil {
+		archived, err := s.archive.Search(ctx, t.Contact, timelineMaxMessages)
+		if err == nil {
+			for _, a := range archived {
+				if seen[a.MessageID] || !addressListHas(a.From+","+a.To, t.Contact) {
+					continue
+				}
+				seen[a.MessageID] = true
+				t.Entries = append(t.Entries, TimelineEntry{
+					MessageID: a.MessageID,
+					ThreadID:  a.ThreadID,
+					Subject:   a.Subject,
+					Date:      a.Date,
+					Outgoing:  addressListHas(a.From, s.self),
+					Archived:  true,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(t.Entries, func(i, j int) bool { return t.Entries[i].Date.Before(t.Entries[j].Date) })
+	return t, nil
+}
+
+// TimelineEntryFromMessage reads an entry from message metadata; a message in SENT or from self
+// is outgoing
+func TimelineEntryFromMessage(m *gmail_v1.Message, self string) TimelineEntry {
+	e := TimelineEntry{
+		MessageID: m.Id,
+		ThreadID:  m.ThreadId,
+		Subject:   reportHeader(m, "Subject"),
+		Snippet:   m.Snippet,
+		Date:      time.UnixMilli(m.InternalDate),
+	}
+	e.Outgoing = slices.Contains(m.LabelIds, "SENT") || (self != "" && addressListHas(reportHeader(m, "From"), self))
+	return e
+}
+
+// addressListHas reports whether a header value holds addr, compared case-insensitively
+func addressListHas(header, addr string) bool {
+	addr = strings.ToLower(addr)
+	if list, err := mail.ParseAddressList(header); err == nil {
+		for _, a := range list {
+			if strings.ToLower(a.Address) == addr {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(strings.ToLower(header), addr)
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type fakeTimelineClient struct {
+	messages map[string]*gmail_v1.Message
+	pages    [][]string
+	queries  []string
+}
+
+func (f *fakeTimelineClient) SearchMessagesPage(query string, _ int64, pageToken string) ([]*gmail_v1.Message, string, error) {
+	f.queries = append(f.queries, query)
+	i := 0
+	if pageToken != "" {
+		_, _ = fmt.Sscanf(pageToken, "p%d", &i)
+	}
+	var out []*gmail_v1.Message
+	for _, id := range f.pages[i] {
+		out = append(out, &gmail_v1.Message{Id: id})
+	}
+	next := ""
+	if i+1 < len(f.pages) {
+		next = fmt.Sprintf("p%d", i+1)
+	}
+	return out, next, nil
+}
+
+func (f *fakeTimelineClient) GetMessagesMetadataParallel(ids []string, _ int) ([]*gmail_v1.Message, error) {
+	var out []*gmail_v1.Message
+	for _, id := range ids {
+		out = append(out, f.messages[id])
+	}
+	return out, nil
+}
+
+type fakeTimelineArchive struct {
+	LocalArchiveService
+	infos []*LocalArchiveInfo
+}
+
+func (f *fakeTimelineArchive) Search(context.Context, string, int) ([]*LocalArchiveInfo, error) {
+	return f.infos, nil
+}
+
+func timelineMessage(id, from string, labels []string, day int) *gmail_v1.Message {
+	return &gmail_v1.Message{
+		Id:           id,
+		ThreadId:     "t-" + id,
+		LabelIds:     labels,
+		Snippet:      "snippet " + id,
+		InternalDate: time.Date(2025, 4, day, 10, 0, 0, 0, time.UTC).UnixMilli(),
+		Payload: &gmail_v1.MessagePart{Headers: []*gmail_v1.MessagePartHeader{
+			{Name: "From", Value: from},
+			{Name: "Subject", Value: "Subject " + id},
+		}},
+	}
+}
+
+func TestTimeline_BuildSortsAndMarksDirection(t *testing.T) {
+	client := &fakeTimelineClient{
+		pages: [][]string{{"m3", "m1"}, {"m2", "m1"}},
+		messages: map[string]*gmail_v1.Message{
+			"m1": timelineMessage("m1", "Ana <ana@example.com>", []string{"INBOX"}, 3),
+			"m2": timelineMessage("m2", "Me <me@example.com>", nil, 5),
+			"m3": timelineMessage("m3", "someone@else.example", []string{"SENT"}, 9),
+		},
+	}
+	svc := NewTimelineService(client, "Me@Example.com")
+	svc.SetLocalArchive(&fakeTimelineArchive{infos: []*LocalArchiveInfo{
+		{MessageID: "old", Subject: "Old", From: "Ana <ana@example.com>", To: "me@example.com", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{MessageID: "other", Subject: "Mentions ana", From: "bob@example.com", To: "me@example.com"},
+		{MessageID: "m1", From: "ana@example.com"},
+	}})
+
+	tl, err := svc.Build(context.Background(), "Ana <ANA@example.com>")
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", tl.Contact)
+	assert.Equal(t, []string{TimelineQuery("ana@example.com"), TimelineQuery("ana@example.com")}, client.queries)
+	assert.False(t, tl.Truncated)
+
+	var ids []string
+	for _, e := range tl.Entries {
+		ids = append(ids, e.MessageID)
+	}
+	assert.Equal(t, []string{"old", "m1", "m2", "m3"}, ids)
+	assert.True(t, tl.Entries[0].Archived)
+	assert.False(t, tl.Entries[1].Outgoing)
+	assert.True(t, tl.Entries[2].Outgoing, "from the account owner")
+	assert.True(t, tl.Entries[3].Outgoing, "in SENT")
+	assert.Equal(t, "Subject m1", tl.Entries[1].Subject)
+
+	sent, received := tl.Counts()
+	assert.Equal(t, [2]int{2, 2}, [2]int{sent, received})
+}
+
+func TestTimeline_BuildCapsMessages(t *testing.T) {
+	client := &fakeTimelineClient{messages: map[string]*gmail_v1.Message{}}
+	for p := 0; p < 4; p++ {
+		var page []string
+		for i := 0; i < 100; i++ {
+			id := fmt.Sprintf("m%d-%d", p, i)
+			page = append(page, id)
+			client.messages[id] = timelineMessage(id, "ana@example.com", nil, 1)
+		}
+		client.pages = append(client.pages, page)
+	}
+	tl, err := NewTimelineService(client, "me@example.com").Build(context.Background(), "ana@example.com")
+	require.NoError(t, err)
+	assert.True(t, tl.Truncated)
+	assert.Len(t, tl.Entries, timelineMaxMessages)
+	assert.Len(t, client.queries, 3)
+
+	_, err = NewTimelineService(client, "").Build(context.Background(), "not an address")
+	assert.Error(t, err)
+}
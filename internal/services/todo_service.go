@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// Action item statuses
+const (
+	TodoOpen      = db.TodoOpen
+	TodoDone      = db.TodoDone
+	TodoDismissed = db.TodoDismissed
+)
+
+// todoMaxContent caps the text sent to the LLM for extraction
+const todoMaxContent = 12000
+
+const todoExtractPrompt = `Extract the action items from the email below: concrete tasks someone is asked or has agreed to do. For each, give the task as a short imperative sentence, the owner (the person expected to do it, "me" if it is the reader, empty if unclear) and the due date or deadline exactly as stated (empty if none). Do not invent tasks; informational content is not an action item.
+
+Answer with JSON only, in this form:
+{"items": [{"task": "Send the Q3 slides", "owner": "me", "due": "Friday"}]}
+Use {"items": []} when there are none.
+
+Subject: %s
+
+%s`
+
+// todoRawItem mirrors the JSON the LLM returns for one action item
+type todoRawItem struct {
+	Task  string `json:"task"`
+	Owner string `json:"owner"`
+	Due   string `json:"due"`
+}
+
+// parseTodoResponse parses the LLM's answer into action items, skipping entries without a task
+func parseTodoResponse(raw string) ([]todoRawItem, error) {
+	obj := extractJSONObject(raw)
+	if obj == "" {
+		return nil, fmt.Errorf("no JSON object in the AI response")
+	}
+	var parsed struct {
+		Items []todoRawItem `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(obj), &parsed); err != nil {
+		return nil, fmt.Errorf("malformed action items JSON: %w", err)
+	}
+	out := make([]todoRawItem, 0, len(parsed.Items))
+	for _, it := range parsed.Items {
+		it.Task = strings.TrimSpace(it.Task)
+		if it.Task == "" {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out, nil
+}
+
+// TodoServiceImpl implements TodoService
+type TodoServiceImpl struct {
+	store        *db.TodoStore
+	aiService    AIService
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewTodoService creates the action items service. aiService may be nil (no extraction).
+func NewTodoService(store *db.TodoStore, aiService AIService) *TodoServiceImpl {
+	return &TodoServiceImpl{store: store, aiService: aiService}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *TodoServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *TodoServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("todo store not available")
+	}
+	return email, nil
+}
+
+// Extract asks the LLM for the action items of a message or conversation and stores them. It
+// returns how many were found and how many of those were new (items already extracted from the
+// same message keep their status).
+func (s *TodoServiceImpl) Extract(ctx context.Context, src TodoSource) (int, int, error) {
+	email, err := s.account()
+	if err != nil {
+		return 0, 0, err
+	}
+	if s.aiService == nil {
+		return 0, 0, fmt.Errorf("AI service not available")
+	}
+	content := strings.TrimSpace(src.Content)
+	if content == "" {
+		return 0, 0, fmt.Errorf("message has no text to extract action items from")
+	}
+	if r := []rune(content); len(r) > todoMaxContent {
+		content = string(r[:todoMaxContent])
+	}
+	raw, err := s.aiService.ApplyCustomPrompt(ctx, fmt.Sprintf(todoExtractPrompt, src.Subject, content), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	items, err := parseTodoResponse(raw)
+	if err != nil {
+		return 0, 0, err
+	}
+	added := 0
+	for _, it := range items {
+		isNew, err := s.store.Add(ctx, db.Todo{
+			AccountEmail: email,
+			MessageID:    src.MessageID,
+			ThreadID:     src.ThreadID,
+			Subject:      src.Subject,
+			Task:         it.Task,
+			Owner:        it.Owner,
+			Due:          it.Due,
+		})
+		if err != nil {
+			return len(items), added, err
+		}
+		if isNew {
+			added++
+		}
+	}
+	return len(items), added, nil
+}
+
+// List returns the open action items, or all of them (done and dismissed last) when all is set
+func (s *TodoServiceImpl) List(ctx context.Context, all bool) ([]TodoItem, error) {
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	todos, err := s.store.List(ctx, email, all)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TodoItem, 0, len(todos))
+	for _, t := range todos {
+		out = append(out, TodoItem{
+			ID:        t.ID,
+			MessageID: t.MessageID,
+			ThreadID:  t.ThreadID,
+			Subject:   t.Subject,
+			Task:      t.Task,
+			Owner:     t.Owner,
+			Due:       t.Due,
+			Status:    t.Status,
+			CreatedAt: time.Unix(t.CreatedAt, 0),
+		})
+	}
+	return out, nil
+}
+
+// SetStatus marks an action item open, done or dismissed
+func (s *TodoServiceImpl) SetStatus(ctx context.Context, id int64, status string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	return s.store.SetStatus(ctx, email, id, status)
+}
+
+// ClearClosed deletes the done and dismissed action items
+func (s *TodoServiceImpl) ClearClosed(ctx context.Context) (int, error) {
+	email, err := s.account()
+	if err != nil {
+		return 0, err
+	}
+	return s.store.DeleteClosed(ctx, email)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTodoResponse(t *testing.T) {
+	items, err := parseTodoResponse("Sure!\n```json\n{\"items\": [{\"task\": \" Send slides \", \"owner\": \"me\", \"due\": \"Friday\"}, {\"task\": \"\"}]}\n```")
+	require.NoError(t, err)
+	assert.Equal(t, []todoRawItem{{Task: "Send slides", Owner: "me", Due: "Friday"}}, items)
+
+	items, err = parseTodoResponse(`{"items": []}`)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	_, err = parseTodoResponse("No action items.")
+	assert.Error(t, err)
+}
+
+func TestTodoService(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/todos.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	ai := &slackStubAI{result: `{"items": [{"task": "Book the room", "owner": "Ana", "due": "Mon"}, {"task": "Send slides"}]}`}
+	svc := NewTodoService(db.NewTodoStore(store), ai)
+	_, _, err = svc.Extract(ctx, TodoSource{MessageID: "m1", Content: "x"})
+	assert.EqualError(t, err, "account email not set")
+	svc.SetAccountEmail("me@example.com")
+
+	_, _, err = svc.Extract(ctx, TodoSource{MessageID: "m1", Content: "  "})
+	assert.Error(t, err)
+
+	src := TodoSource{MessageID: "m1", ThreadID: "t1", Subject: "Offsite", Content: "Ana, book the room by Monday"}
+	found, added, err := svc.Extract(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found)
+	assert.Equal(t, 2, added)
+
+	items, err := svc.List(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Book the room", items[0].Task)
+	assert.Equal(t, "Ana", items[0].Owner)
+	assert.Equal(t, "Mon", items[0].Due)
+	assert.Equal(t, "Offsite", items[0].Subject)
+	assert.Equal(t, TodoOpen, items[0].Status)
+
+	require.NoError(t, svc.SetStatus(ctx, items[0].ID, TodoDismissed))
+	found, added, err = svc.Extract(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found)
+	assert.Equal(t, 0, added)
+
+	open, _ := svc.List(ctx, false)
+	assert.Len(t, open, 1)
+	n, err := svc.ClearClosed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	noAI := NewTodoService(db.NewTodoStore(store), nil)
+	noAI.SetAccountEmail("me@example.com")
+	_, _, err = noAI.Extract(ctx, src)
+	assert.EqualError(t, err, "AI service not available")
+}
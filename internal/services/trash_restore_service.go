@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// trashOriginRetention is how long trash/spam origins are kept; Gmail deletes messages from Trash
+// and Spam after 30 days
+const trashOriginRetention = 60 * 24 * time.Hour
+
+// Outcomes of restoring one message
+const (
+	restoreFromRecord = iota
+	restoreInferred
+	restoreNotTrashed
+)
+
+// TrashRestoreClient is the subset of *gmail.Client the restore needs to read current labels
+type TrashRestoreClient interface {
+	GetMessageMetadata(id string) (*gmail_v1.Message, error)
+}
+
+// TrashRestoreServiceImpl implements TrashRestoreService
+type TrashRestoreServiceImpl struct {
+	store        *db.TrashOriginStore
+	client       TrashRestoreClient
+	repo         MessageRepository
+	accountEmail string
+	mu           sync.RWMutex
+}
+
+// NewTrashRestoreService creates the trash/spam restore service
+func NewTrashRestoreService(store *db.TrashOriginStore, client TrashRestoreClient, repo MessageRepository) *TrashRestoreServiceImpl {
+	return &TrashRestoreServiceImpl{store: store, client: client, repo: repo}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *TrashRestoreServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+func (s *TrashRestoreServiceImpl) account() (string, error) {
+	s.mu.RLock()
+	email := s.accountEmail
+	s.mu.RUnlock()
+	if strings.TrimSpace(email) == "" {
+		return "", fmt.Errorf("account email not set")
+	}
+	if s.store == nil {
+		return "", fmt.Errorf("trash origin store not available")
+	}
+	return email, nil
+}
+
+// RecordOrigins remembers the labels messages had before being moved to folder (TRASH or SPAM).
+// labels maps message IDs to their labels at that moment.
+func (s *TrashRestoreServiceImpl) RecordOrigins(ctx context.Context, folder string, labels map[string][]string) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	for id, ls := range labels {
+		if err := s.store.Save(ctx, email, id, restorableLabels(ls), folder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune drops origins older than Gmail keeps trashed mail
+func (s *TrashRestoreServiceImpl) Prune(ctx context.Context) error {
+	email, err := s.account()
+	if err != nil {
+		return err
+	}
+	_, err = s.store.DeleteOlderThan(ctx, email, time.Now().Add(-trashOriginRetention).Unix())
+	return err
+}
+
+// Restore moves messages out of Trash/Spam back to where they were: the labels recorded when the
+// app trashed them, otherwise the inbox (inferred). onProgress is called after each message.
+func (s *TrashRestoreServiceImpl) Restore(ctx context.Context, messageIDs []string, onProgress func(done, total int)) (*RestoreResult, error) {
+	if len(messageIDs) == 0 {
+		return nil, fmt.Errorf("no messages to restore")
+	}
+	email, err := s.account()
+	if err != nil {
+		return nil, err
+	}
+	if s.client == nil || s.repo == nil {
+		return nil, fmt.Errorf("gmail client not available")
+	}
+
+	result := &RestoreResult{}
+	for i, id := range messageIDs {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		outcome, err := s.restoreOne(ctx, email, id)
+		switch {
+		case err != nil:
+			// The record is kept for the next attempt
+			result.Failed = append(result.Failed, id)
+			result.Errors = append(result.Errors, err.Error())
+		case outcome == restoreFromRecord:
+			result.Restored++
+			result.FromRecord++
+		case outcome == restoreInferred:
+			result.Restored++
+			result.Inferred++
+		default:
+			result.NotTrashed++
+		}
+		if err == nil {
+			_ = s.store.Delete(ctx, email, id)
+		}
+		reportProgress([]func(done, total int){onProgress}, i+1, len(messageIDs))
+	}
+	return result, nil
+}
+
+// restoreOne restores one message and reports how
+func (s *TrashRestoreServiceImpl) restoreOne(ctx context.Context, email, id string) (int, error) {
+	msg, err := s.client.GetMessageMetadata(id)
+	if err != nil {
+		return 0, ClassifyError("get message "+id, err)
+	}
+	origin, err := s.store.Get(ctx, email, id)
+	if err != nil {
+		return 0, err
+	}
+	var recorded []string
+	if origin != nil {
+		recorded = origin.Labels
+	}
+	add, remove := restorePlan(msg.LabelIds, recorded, origin != nil)
+	if len(remove) == 0 {
+		return restoreNotTrashed, nil
+	}
+	err = s.repo.UpdateMessage(ctx, id, MessageUpdates{AddLabels: add, RemoveLabels: remove})
+	if err != nil && origin != nil {
+		// A recorded user label may have been deleted since; restore the system labels only
+		err = s.repo.UpdateMessage(ctx, id, MessageUpdates{AddLabels: systemLabelsOnly(add), RemoveLabels: remove})
+	}
+	if err != nil {
+		return 0, err
+	}
+	if origin != nil {
+		return restoreFromRecord, nil
+	}
+	return restoreInferred, nil
+}
+
+// restorableLabels keeps the labels that place a message (INBOX, user labels, categories,
+// STARRED, IMPORTANT) and drops the ones that cannot or should not be re-applied
+func restorableLabels(labels []string) []string {
+	var out []string
+	for _, l := range labels {
+		switch l {
+		case "", "TRASH", "SPAM", "UNREAD", "SENT", "DRAFT", "CHAT":
+			continue
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+// restorePlan works out the label changes that take a message out of Trash/Spam: the recorded
+// labels when there is a record, otherwise INBOX unless the message is one you sent (those go
+// back to Sent only)
+func restorePlan(current, recorded []string, haveRecord bool) (add, remove []string) {
+	has := make(map[string]bool, len(current))
+	for _, l := range current {
+		has[l] = true
+	}
+	if !has["TRASH"] && !has["SPAM"] {
+		return nil, nil
+	}
+	for _, l := range []string{"TRASH", "SPAM"} {
+		if has[l] {
+			remove = append(remove, l)
+		}
+	}
+	target := recorded
+	if !haveRecord {
+		target = nil
+		if !has["SENT"] {
+			target = []string{"INBOX"}
+		}
+	}
+	for _, l := range restorableLabels(target) {
+		if !has[l] {
+			add = append(add, l)
+		}
+	}
+	return add, remove
+}
+
+// systemLabelsOnly drops user labels (Label_…) from a label ID list
+func systemLabelsOnly(labels []string) []string {
+	var out []string
+	for _, l := range labels {
+		if !strings.HasPrefix(l, "Label_") {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+type fakeTrashClient struct {
+	labels map[string][]string
+}
+
+func (c *fakeTrashClient) GetMessageMetadata(id string) (*gmail_v1.Message, error) {
+	ls, ok := c.labels[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &gmail_v1.Message{Id: id, LabelIds: ls}, nil
+}
+
+func TestRestorePlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    []string
+		recorded   []string
+		haveRecord bool
+		add        []string
+		remove     []string
+	}{
+		{"recorded labels", []string{"TRASH", "UNREAD"}, []string{"INBOX", "Label_1"}, true, []string{"INBOX", "Label_1"}, []string{"TRASH"}},
+		{"recorded archived message", []string{"TRASH", "Label_1"}, []string{"Label_1"}, true, nil, []string{"TRASH"}},
+		{"inferred inbox", []string{"SPAM", "CATEGORY_PROMOTIONS"}, nil, false, []string{"INBOX"}, []string{"SPAM"}},
+		{"inferred sent", []string{"TRASH", "SENT"}, nil, false, nil, []string{"TRASH"}},
+		{"not trashed", []string{"INBOX"}, []string{"INBOX"}, true, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := restorePlan(tt.current, tt.recorded, tt.haveRecord)
+			assert.Equal(t, tt.add, add)
+			assert.Equal(t, tt.remove, remove)
+		})
+	}
+	assert.Equal(t, []string{"INBOX", "STARRED"}, restorableLabels([]string{"INBOX", "TRASH", "UNREAD", "SENT", "STARRED"}))
+}
+
+func TestTrashRestoreService_Restore(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/trash.db")
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	client := &fakeTrashClient{labels: map[string][]string{
+		"m1": {"TRASH", "Label_gone"},
+		"m2": {"TRASH"},
+		"m4": {"INBOX"},
+	}}
+	repo := &MockEmailRepository{}
+	svc := NewTrashRestoreService(db.NewTrashOriginStore(store), client, repo)
+
+	_, err = svc.Restore(ctx, []string{"m1"}, nil)
+	assert.Error(t, err, "account must be set")
+	svc.SetAccountEmail("me@example.com")
+
+	require.NoError(t, svc.RecordOrigins(ctx, "TRASH", map[string][]string{"m1": {"INBOX", "UNREAD", "Label_gone"}}))
+
+	// m1's recorded label was deleted meanwhile: the first update fails, the retry restores INBOX
+	repo.On("UpdateMessage", ctx, "m1", MessageUpdates{AddLabels: []string{"INBOX"}, RemoveLabels: []string{"TRASH"}}).Return(nil).Once()
+	repo.On("UpdateMessage", ctx, "m1", mock.Anything).Return(errors.New("invalid label")).Once()
+	repo.On("UpdateMessage", ctx, "m2", MessageUpdates{AddLabels: []string{"INBOX"}, RemoveLabels: []string{"TRASH"}}).Return(nil).Once()
+
+	var progress []int
+	res, err := svc.Restore(ctx, []string{"m1", "m2", "m3", "m4"}, func(done, total int) { progress = append(progress, done) })
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Restored)
+	assert.Equal(t, 1, res.FromRecord)
+	assert.Equal(t, 1, res.Inferred)
+	assert.Equal(t, 1, res.NotTrashed)
+	assert.Equal(t, []string{"m3"}, res.Failed)
+	assert.Equal(t, []int{1, 2, 3, 4}, progress)
+
+	origin, err := db.NewTrashOriginStore(store).Get(ctx, "me@example.com", "m1")
+	require.NoError(t, err)
+	assert.Nil(t, origin, "record is dropped once restored")
+}
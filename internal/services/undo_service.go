@@ -17,7 +17,8 @@ type UndoServiceImpl struct {
 	gmailClient  *gmail.Client
 	lastAction   *UndoableAction
 	mu           sync.RWMutex
-	logger       *log.Logger // Optional - for debug logging
+	logger       *log.Logger    // Optional - for debug logging
+	recorder     ActionRecorder // Optional - keeps the action log the usual actions are learned from
 }
 
 // NewUndoService creates a new undo service
@@ -34,6 +35,13 @@ func (s *UndoServiceImpl) SetLogger(logger *log.Logger) {
 	s.logger = logger
 }
 
+// SetActionRecorder sets the recorder told about every recorded action (nil for none)
+func (s *UndoServiceImpl) SetActionRecorder(r ActionRecorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recorder = r
+}
+
 // RecordAction records an action for potential undo
 func (s *UndoServiceImpl) RecordAction(ctx context.Context, action *UndoableAction) error {
 	if action == nil {
@@ -48,9 +56,13 @@ func (s *UndoServiceImpl) RecordAction(ctx context.Context, action *UndoableActi
 		action.Timestamp = time.Now()
 	}
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	// Store the action (single-level undo for MVP)
 	s.lastAction = action
+	recorder := s.recorder
+	s.mu.Unlock()
+	if recorder != nil {
+		recorder.Record(ctx, action)
+	}
 	return nil
 }
 
@@ -257,6 +269,10 @@ func (s *UndoServiceImpl) undoLabelAdd(ctx context.Context, action *UndoableActi
 	// To undo label add, remove the labels that were added
 	// Use Gmail client directly to avoid circular undo recording
 	if labelsToRemove, exists := action.ExtraData["added_labels"].([]string); exists {
+		if action.IsBulk {
+			failed := runBatchModify(ctx, s.gmailClient, action.MessageIDs, nil, labelsToRemove, nil)
+			return batchErrors("undo label", "remove labels from", failed)
+		}
 		for _, messageID := range action.MessageIDs {
 			for _, labelID := range labelsToRemove {
 				if err := s.gmailClient.RemoveLabel(messageID, labelID); err != nil {
@@ -271,6 +287,10 @@ func (s *UndoServiceImpl) undoLabelRemove(ctx context.Context, action *UndoableA
 	// To undo label remove, re-add the labels that were removed
 	// Use Gmail client directly to avoid circular undo recording
 	if labelsToAdd, exists := action.ExtraData["removed_labels"].([]string); exists {
+		if action.IsBulk {
+			failed := runBatchModify(ctx, s.gmailClient, action.MessageIDs, labelsToAdd, nil, nil)
+			return batchErrors("undo label", "re-add labels to", failed)
+		}
 		for _, messageID := range action.MessageIDs {
 			for _, labelID := range labelsToAdd {
 				if err := s.gmailClient.ApplyLabel(messageID, labelID); err != nil {
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// UnreadCounterClient is the part of *gmail.Client the unread counters use
+type UnreadCounterClient interface {
+	GetLabel(labelID string) (*gmail_v1.Label, error)
+	CurrentHistoryID() (uint64, error)
+	ListHistory(startHistoryID uint64) ([]*gmail_v1.History, uint64, error)
+}
+
+// UnreadCount is the message count of a label as Gmail reports it
+type UnreadCount struct {
+	LabelID string
+	Unread  int64
+	Total   int64
+	Updated time.Time
+}
+
+// UnreadCounterServiceImpl keeps the unread and total counts of a few labels current. Each
+// refresh asks Gmail's history what changed since the last one and only reads the labels the
+// changes touched, so an idle mailbox costs one history call per refresh.
+type UnreadCounterServiceImpl struct {
+	client UnreadCounterClient
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	tracked   []string
+	counts    map[string]UnreadCount
+	historyID uint64 // 0 until the first refresh
+}
+
+// NewUnreadCounterService creates the counters for labelIDs; more can be tracked later
+func NewUnreadCounterService(client UnreadCounterClient, labelIDs ...string) *UnreadCounterServiceImpl {
+	s := &UnreadCounterServiceImpl{client: client, now: time.Now, counts: map[string]UnreadCount{}}
+	s.Track(labelIDs...)
+	return s
+}
+
+// Track adds labels to the counted ones; they are read on the next refresh
+func (s *UnreadCounterServiceImpl) Track(labelIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range labelIDs {
+		if id != "" && !slices.Contains(s.tracked, id) {
+			s.tracked = append(s.tracked, id)
+		}
+	}
+}
+
+// Count returns the last known counts of a label
+func (s *UnreadCounterServiceImpl) Count(labelID string) (UnreadCount, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.counts[labelID]
+	return c, ok
+}
+
+// Counts returns the last known counts of every tracked label that has been read
+func (s *UnreadCounterServiceImpl) Counts() []UnreadCount {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]UnreadCount, 0, len(s.counts))
+	for _, id := range s.tracked {
+		if c, ok := s.counts[id]; ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Refresh rereads the counts of the tracked labels that changed since the last refresh and
+// reports whether any count moved. When the history is gone (it only reaches back about a week)
+// every tracked label is reread. A label that can't be read keeps its old count and is retried.
+func (s *UnreadCounterServiceImpl) Refresh(ctx context.Context) (bool, error) {
+	s.mu.RLock()
+	tracked := append([]string(nil), s.tracked...)
+	start := s.historyID
+	uncounted := make(map[string]bool)
+	for _, id := range tracked {
+		if _, ok := s.counts[id]; !ok {
+			uncounted[id] = true
+		}
+	}
+	s.mu.RUnlock()
+
+	var stale []string
+	history, latest, err := s.listHistory(start)
+	if err != nil || start == 0 {
+		// Read the history ID before the labels so no change between the two is missed
+		if latest, err = s.client.CurrentHistoryID(); err != nil {
+			return false, ClassifyError("get history id", err)
+		}
+		stale = tracked
+	} else {
+		touched, all := labelsTouchedBy(history)
+		for _, id := range tracked {
+			if all || touched[id] || uncounted[id] {
+				stale = append(stale, id)
+			}
+		}
+	}
+
+	changed := false
+	var firstErr error
+	for _, id := range stale {
+		if ctx.Err() != nil {
+			return changed, ctx.Err()
+		}
+		label, err := s.client.GetLabel(id)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = ClassifyError("get label counts", err)
+			}
+			continue
+		}
+		c := UnreadCount{LabelID: id, Unread: label.MessagesUnread, Total: label.MessagesTotal, Updated: s.now()}
+		s.mu.Lock()
+		if old, ok := s.counts[id]; !ok || old.Unread != c.Unread || old.Total != c.Total {
+			changed = true
+		}
+		s.counts[id] = c
+		s.mu.Unlock()
+	}
+	// Keep the old starting point when a label failed so its change is picked up next time
+	if firstErr == nil {
+		s.mu.Lock()
+		s.historyID = latest
+		s.mu.Unlock()
+	}
+	return changed, firstErr
+}
+
+// listHistory returns the history since start, or nothing when there is no start yet
+func (s *UnreadCounterServiceImpl) listHistory(start uint64) ([]*gmail_v1.History, uint64, error) {
+	if start == 0 {
+		return nil, 0, nil
+	}
+	return s.client.ListHistory(start)
+}
+
+// labelsTouchedBy lists the labels whose counts history may have changed: the labels of every
+// message added, deleted or relabeled, plus the labels added or removed. all is set when a change
+// doesn't say which labels the message had.
+func labelsTouchedBy(history []*gmail_v1.History) (touched map[string]bool, all bool) {
+	touched = make(map[string]bool)
+	note := func(m *gmail_v1.Message, changed []string) {
+		if m == nil || len(m.LabelIds) == 0 {
+			all = true
+		} else {
+			for _, id := range m.LabelIds {
+				touched[id] = true
+			}
+		}
+		for _, id := range changed {
+			touched[id] = true
+		}
+	}
+	for _, h := range history {
+		for _, m := range h.MessagesAdded {
+			note(m.Message, nil)
+		}
+		for _, m := range h.MessagesDeleted {
+			note(m.Message, nil)
+		}
+		for _, l := range h.LabelsAdded {
+			note(l.Message, l.LabelIds)
+		}
+		for _, l := range h.LabelsRemoved {
+			note(l.Message, l.LabelIds)
+		}
+	}
+	return touched, all
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// fakeCounterClient serves label counts and a scripted history
+type fakeCounterClient struct {
+	labels     map[string]*gmail_v1.Label
+	history    []*gmail_v1.History
+	historyErr error
+	latest     uint64
+	gets       []string
+}
+
+func (f *fakeCounterClient) GetLabel(id string) (*gmail_v1.Label, error) {
+	f.gets = append(f.gets, id)
+	if l, ok := f.labels[id]; ok {
+		return l, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeCounterClient) CurrentHistoryID() (uint64, error) { return f.latest, nil }
+
+func (f *fakeCounterClient) ListHistory(start uint64) ([]*gmail_v1.History, uint64, error) {
+	if f.historyErr != nil {
+		return nil, 0, f.historyErr
+	}
+	return f.history, f.latest, nil
+}
+
+func TestUnreadCounters_OnlyRereadsLabelsTheHistoryTouched(t *testing.T) {
+	client := &fakeCounterClient{
+		labels: map[string]*gmail_v1.Label{
+			"INBOX":   {Id: "INBOX", MessagesUnread: 12, MessagesTotal: 243},
+			"Label_1": {Id: "Label_1", MessagesUnread: 1, MessagesTotal: 9},
+		},
+		latest: 100,
+	}
+	s := NewUnreadCounterService(client, "INBOX", "Label_1")
+	ctx := context.Background()
+
+	changed, err := s.Refresh(ctx)
+	if err != nil || !changed || len(client.gets) != 2 {
+		t.Fatalf("first refresh: changed=%v err=%v gets=%v", changed, err, client.gets)
+	}
+	if c, _ := s.Count("INBOX"); c.Unread != 12 || c.Total != 243 {
+		t.Fatalf("INBOX = %+v", c)
+	}
+
+	// Nothing happened: no label is read
+	client.gets = nil
+	if changed, _ := s.Refresh(ctx); changed || len(client.gets) != 0 {
+		t.Fatalf("idle refresh read %v", client.gets)
+	}
+
+	// An inbox message was read: only INBOX is reread
+	client.labels["INBOX"] = &gmail_v1.Label{Id: "INBOX", MessagesUnread: 11, MessagesTotal: 243}
+	client.history = []*gmail_v1.History{{LabelsRemoved: []*gmail_v1.HistoryLabelRemoved{{
+		LabelIds: []string{"UNREAD"},
+		Message:  &gmail_v1.Message{Id: "m1", LabelIds: []string{"INBOX"}},
+	}}}}
+	client.latest = 101
+	if changed, err := s.Refresh(ctx); !changed || err != nil || len(client.gets) != 1 || client.gets[0] != "INBOX" {
+		t.Fatalf("changed=%v err=%v gets=%v", changed, err, client.gets)
+	}
+	if c, _ := s.Count("INBOX"); c.Unread != 11 {
+		t.Fatalf("INBOX unread = %d, want 11", c.Unread)
+	}
+}
+
+func TestUnreadCounters_ExpiredHistoryRereadsEverything(t *testing.T) {
+	client := &fakeCounterClient{
+		labels: map[string]*gmail_v1.Label{"INBOX": {Id: "INBOX", MessagesUnread: 3, MessagesTotal: 5}},
+		latest: 7,
+	}
+	s := NewUnreadCounterService(client, "INBOX")
+	if _, err := s.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	client.gets = nil
+	client.historyErr = errors.New("404 history too old")
+	if _, err := s.Refresh(context.Background()); err != nil || len(client.gets) != 1 {
+		t.Fatalf("err=%v gets=%v", err, client.gets)
+	}
+
+	// A newly tracked label is read on the next refresh even without history
+	client.historyErr = nil
+	client.history = nil
+	client.labels["STARRED"] = &gmail_v1.Label{Id: "STARRED", MessagesTotal: 4}
+	s.Track("STARRED")
+	client.gets = nil
+	if _, err := s.Refresh(context.Background()); err != nil || len(client.gets) != 1 || client.gets[0] != "STARRED" {
+		t.Fatalf("err=%v gets=%v", err, client.gets)
+	}
+	if got := len(s.Counts()); got != 2 {
+		t.Fatalf("Counts() has %d labels, want 2", got)
+	}
+}
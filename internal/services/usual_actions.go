@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+// Actions kept in the action log
+const (
+	logActionArchive = "archive"
+	logActionTrash   = "trash"
+	logActionLabel   = "label"
+	logActionUnlabel = "unlabel"
+)
+
+const (
+	// usualActionsWindow is how many of a sender's latest acted-on messages a habit is looked for in
+	usualActionsWindow = 5
+	// usualActionsLogLimit bounds the log entries read for one sender
+	usualActionsLogLimit = 100
+	// DefaultUsualActionsMinRepeats is how many of those messages must have had the same actions
+	DefaultUsualActionsMinRepeats = 3
+)
+
+// UsualActionsServiceImpl implements UsualActionsService on the local action log
+type UsualActionsServiceImpl struct {
+	store        *db.ActionLogStore
+	minRepeats   int
+	accountEmail string
+	senderOf     func(messageID string) string
+	logger       *log.Logger
+	mu           sync.RWMutex
+}
+
+// NewUsualActionsService creates the usual actions service; minRepeats <= 0 uses the default
+func NewUsualActionsService(store *db.ActionLogStore, minRepeats int) *UsualActionsServiceImpl {
+	if minRepeats <= 0 {
+		minRepeats = DefaultUsualActionsMinRepeats
+	}
+	return &UsualActionsServiceImpl{store: store, minRepeats: minRepeats}
+}
+
+// SetAccountEmail sets the active account for scoping.
+func (s *UsualActionsServiceImpl) SetAccountEmail(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accountEmail = email
+}
+
+// SetSenderResolver sets how the sender address of a message is found; messages it returns ""
+// for are not logged
+func (s *UsualActionsServiceImpl) SetSenderResolver(fn func(messageID string) string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.senderOf = fn
+}
+
+// SetLogger sets the logger for failed log writes
+func (s *UsualActionsServiceImpl) SetLogger(logger *log.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = logger
+}
+
+// Record logs an action recorded for undo, once per message it touched. Marking read or unread
+// and undoing are not logged: opening a message marks it read.
+func (s *UsualActionsServiceImpl) Record(ctx context.Context, action *UndoableAction) {
+	s.mu.RLock()
+	email, senderOf, logger := s.accountEmail, s.senderOf, s.logger
+	s.mu.RUnlock()
+	if action == nil || s.store == nil || senderOf == nil || strings.TrimSpace(email) == "" {
+		return
+	}
+	kinds := actionLogKinds(action)
+	if len(kinds) == 0 {
+		return
+	}
+	var entries []db.ActionLogEntry
+	for _, id := range action.MessageIDs {
+		sender := senderOf(id)
+		if sender == "" {
+			continue
+		}
+		for _, k := range kinds {
+			entries = append(entries, db.ActionLogEntry{MessageID: id, Sender: sender, Action: k[0], Label: k[1]})
+		}
+	}
+	if err := s.store.Append(ctx, email, entries); err != nil && logger != nil {
+		logger.Printf("usual actions: %v", err)
+	}
+}
+
+// actionLogKinds returns the (action, label) pairs an undoable action is logged as
+func actionLogKinds(action *UndoableAction) [][2]string {
+	labels := func(key string) []string {
+		ids, _ := action.ExtraData[key].([]string)
+		return ids
+	}
+	var kinds [][2]string
+	switch action.Type {
+	case UndoActionArchive:
+		kinds = append(kinds, [2]string{logActionArchive, ""})
+	case UndoActionTrash:
+		kinds = append(kinds, [2]string{logActionTrash, ""})
+	case UndoActionLabelAdd:
+		for _, id := range labels("added_labels") {
+			kinds = append(kinds, [2]string{logActionLabel, id})
+		}
+	case UndoActionLabelRemove:
+		for _, id := range labels("removed_labels") {
+			kinds = append(kinds, [2]string{logActionUnlabel, id})
+		}
+	case UndoActionMove:
+		applied := labels("applied_labels")
+		if system, _ := action.ExtraData["system_folder"].(bool); system {
+			if slices.Contains(applied, "TRASH") {
+				kinds = append(kinds, [2]string{logActionTrash, ""})
+			}
+			return kinds
+		}
+		for _, id := range applied {
+			kinds = append(kinds, [2]string{logActionLabel, id})
+		}
+		kinds = append(kinds, [2]string{logActionArchive, ""})
+	}
+	return kinds
+}
+
+// Suggest returns the set of actions done to at least minRepeats, and most, of the sender's
+// latest usualActionsWindow acted-on messages; nil when there is none
+func (s *UsualActionsServiceImpl) Suggest(ctx context.Context, sender string) (*UsualActions, error) {
+	s.mu.RLock()
+	email, minRepeats := s.accountEmail, s.minRepeats
+	s.mu.RUnlock()
+	sender = strings.ToLower(strings.TrimSpace(sender))
+	if s.store == nil || strings.TrimSpace(email) == "" || sender == "" {
+		return nil, nil
+	}
+	entries, err := s.store.BySender(ctx, email, sender, usualActionsLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	return usualActionsFrom(sender, entries, minRepeats), nil
+}
+
+// usualActionsFrom finds the habit in a sender's log entries, newest first
+func usualActionsFrom(sender string, entries []db.ActionLogEntry, minRepeats int) *UsualActions {
+	var order []string
+	byMessage := make(map[string]*UsualActions)
+	for _, e := range entries {
+		u, ok := byMessage[e.MessageID]
+		if !ok {
+			if len(order) == usualActionsWindow {
+				continue
+			}
+			u = &UsualActions{Sender: sender}
+			byMessage[e.MessageID] = u
+			order = append(order, e.MessageID)
+		}
+		switch e.Action {
+		case logActionArchive:
+			u.Archive = true
+		case logActionTrash:
+			u.Trash = true
+		case logActionLabel:
+			if !slices.Contains(u.AddLabels, e.Label) {
+				u.AddLabels = append(u.AddLabels, e.Label)
+			}
+		case logActionUnlabel:
+			if !slices.Contains(u.RemoveLabels, e.Label) {
+				u.RemoveLabels = append(u.RemoveLabels, e.Label)
+			}
+		}
+	}
+
+	counts := make(map[string]int)
+	var best *UsualActions
+	bestKey := ""
+	for _, id := range order {
+		u := byMessage[id]
+		slices.Sort(u.AddLabels)
+		slices.Sort(u.RemoveLabels)
+		key := u.key()
+		counts[key]++
+		if best == nil || counts[key] > counts[bestKey] {
+			best, bestKey = u, key
+		}
+	}
+	if best == nil || counts[bestKey] < minRepeats || counts[bestKey]*2 <= len(order) {
+		return nil
+	}
+	best.Times, best.Of = counts[bestKey], len(order)
+	return best
+}
+
+// key identifies the set of actions
+func (u *UsualActions) key() string {
+	return fmt.Sprintf("%v|%v|%t|%t", u.AddLabels, u.RemoveLabels, u.Archive, u.Trash)
+}
+
+// Describe names the actions, e.g. "label Receipts + archive"; labelName turns label IDs into names
+func (u *UsualActions) Describe(labelName func(id string) string) string {
+	var parts []string
+	for _, id := range u.AddLabels {
+		parts = append(parts, "label "+labelName(id))
+	}
+	for _, id := range u.RemoveLabels {
+		parts = append(parts, "unlabel "+labelName(id))
+	}
+	switch {
+	case u.Trash:
+		parts = append(parts, "trash")
+	case u.Archive:
+		parts = append(parts, "archive")
+	}
+	return strings.Join(parts, " + ")
+}
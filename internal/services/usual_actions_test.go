@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/db"
+)
+
+func TestUsualActions_LearnsFromRecordedActions(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(ctx, t.TempDir()+"/usual.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	svc := NewUsualActionsService(db.NewActionLogStore(store), 0)
+	svc.SetAccountEmail("me@example.com")
+	senders := map[string]string{"r1": "shop@example.com", "r2": "shop@example.com", "r3": "shop@example.com", "b1": "boss@example.com"}
+	svc.SetSenderResolver(func(id string) string { return senders[id] })
+
+	for _, id := range []string{"r1", "r2"} {
+		svc.Record(ctx, &UndoableAction{Type: UndoActionLabelAdd, MessageIDs: []string{id}, ExtraData: map[string]interface{}{"added_labels": []string{"Label_Receipts"}}})
+		svc.Record(ctx, &UndoableAction{Type: UndoActionArchive, MessageIDs: []string{id}})
+	}
+	svc.Record(ctx, &UndoableAction{Type: UndoActionMarkRead, MessageIDs: []string{"r3"}})
+	svc.Record(ctx, &UndoableAction{Type: UndoActionArchive, MessageIDs: []string{"b1"}})
+
+	if got, err := svc.Suggest(ctx, "shop@example.com"); err != nil || got != nil {
+		t.Fatalf("two repeats must not suggest yet, got %+v %v", got, err)
+	}
+
+	// A move is logged as its label plus archive, the same set as above
+	svc.Record(ctx, &UndoableAction{Type: UndoActionMove, MessageIDs: []string{"r3"}, ExtraData: map[string]interface{}{"applied_labels": []string{"Label_Receipts"}}})
+	got, err := svc.Suggest(ctx, "Shop@Example.com")
+	if err != nil || got == nil {
+		t.Fatalf("want a suggestion after three repeats, got %+v %v", got, err)
+	}
+	if got.Times != 3 || got.Of != 3 || !got.Archive || got.Trash || len(got.AddLabels) != 1 {
+		t.Fatalf("suggestion = %+v", got)
+	}
+	names := map[string]string{"Label_Receipts": "Receipts"}
+	if d := got.Describe(func(id string) string { return names[id] }); d != "label Receipts + archive" {
+		t.Fatalf("describe = %q", d)
+	}
+}
+
+func TestUsualActionsFrom_NeedsMostRecentMessages(t *testing.T) {
+	entry := func(id, action string) db.ActionLogEntry {
+		return db.ActionLogEntry{MessageID: id, Action: action}
+	}
+	// Newest first: two trashed lately, three archived before
+	entries := []db.ActionLogEntry{
+		entry("m5", "trash"), entry("m4", "trash"),
+		entry("m3", "archive"), entry("m2", "archive"), entry("m1", "archive"),
+	}
+	got := usualActionsFrom("x@example.com", entries, 3)
+	if got == nil || !got.Archive || got.Times != 3 || got.Of != 5 {
+		t.Fatalf("want archive 3 of 5, got %+v", got)
+	}
+
+	// A sixth, older message falls outside the window
+	entries = append([]db.ActionLogEntry{entry("m6", "trash")}, entries...)
+	if got := usualActionsFrom("x@example.com", entries, 3); got == nil || !got.Trash || got.Times != 3 {
+		t.Fatalf("want trash 3 of 5 within the window, got %+v", got)
+	}
+
+	// No majority
+	mixed := []db.ActionLogEntry{
+		entry("a", "trash"), entry("b", "archive"), entry("c", "trash"), entry("d", "archive"),
+	}
+	if got := usualActionsFrom("x@example.com", mixed, 2); got != nil {
+		t.Fatalf("want no suggestion without a majority, got %+v", got)
+	}
+}
+
+func TestActionLogKinds_SystemFolderMove(t *testing.T) {
+	trash := &UndoableAction{Type: UndoActionMove, ExtraData: map[string]interface{}{"applied_labels": []string{"TRASH"}, "system_folder": true}}
+	if k := actionLogKinds(trash); len(k) != 1 || k[0][0] != logActionTrash {
+		t.Fatalf("move to Trash = %v", k)
+	}
+	spam := &UndoableAction{Type: UndoActionMove, ExtraData: map[string]interface{}{"applied_labels": []string{"SPAM"}, "system_folder": true}}
+	if k := actionLogKinds(spam); len(k) != 0 {
+		t.Fatalf("move to Spam = %v", k)
+	}
+}
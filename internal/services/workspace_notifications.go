@@ -0,0 +1,108 @@
+package services
+
+import (
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+// Kinds of Google Workspace notification emails
+const (
+	WorkspaceChat  = "chat"
+	WorkspaceMeet  = "meet"
+	WorkspaceDrive = "drive"
+)
+
+// WorkspaceKinds lists the kinds in display order
+var WorkspaceKinds = []string{WorkspaceChat, WorkspaceMeet, WorkspaceDrive}
+
+// workspaceSenders are the addresses Google sends each kind of notification from
+var workspaceSenders = map[string]string{
+	"chat-noreply@google.com":            WorkspaceChat,
+	"meetings-noreply@google.com":        WorkspaceMeet,
+	"meet-recordings-noreply@google.com": WorkspaceMeet,
+	"gemini-notes@google.com":            WorkspaceMeet,
+	"comments-noreply@docs.google.com":   WorkspaceDrive,
+}
+
+// workspaceNames are the group names shown in the list
+var workspaceNames = map[string]string{
+	WorkspaceChat:  "Google Chat",
+	WorkspaceMeet:  "Google Meet",
+	WorkspaceDrive: "Drive comments",
+}
+
+// WorkspaceKindName returns the display name of a kind
+func WorkspaceKindName(kind string) string {
+	if name, ok := workspaceNames[kind]; ok {
+		return name
+	}
+	return kind
+}
+
+// WorkspaceNotificationKind recognizes a Google Chat, Meet or Drive comment notification by its
+// sender; ok is false for any other message
+func WorkspaceNotificationKind(m *gmail_v1.Message) (kind string, ok bool) {
+	if m == nil {
+		return "", false
+	}
+	from := reportHeader(m, "From")
+	if addr, err := mail.ParseAddress(from); err == nil {
+		from = addr.Address
+	}
+	kind, ok = workspaceSenders[strings.ToLower(strings.TrimSpace(from))]
+	return kind, ok
+}
+
+// WorkspaceAlertRules returns the built-in alert group rules for the kinds not switched off. Chat
+// notifications group by person or space ("New message from Ana", "… in Project X"), Drive
+// comments by document title, Meet notifications all together.
+func WorkspaceAlertRules(cfg config.WorkspaceNotificationsConfig) []config.AlertGroupRule {
+	senders := make([]string, 0, len(workspaceSenders))
+	for sender := range workspaceSenders {
+		senders = append(senders, sender)
+	}
+	sort.Strings(senders)
+	var rules []config.AlertGroupRule
+	for _, sender := range senders {
+		kind := workspaceSenders[sender]
+		if cfg.Action(kind) == config.WorkspaceActionOff {
+			continue
+		}
+		name := workspaceNames[kind]
+		switch kind {
+		case WorkspaceChat:
+			rules = append(rules, config.AlertGroupRule{Name: name, From: sender, Subject: `(?i)\b(?:from|in) (.+)$`})
+		case WorkspaceDrive:
+			rules = append(rules, config.AlertGroupRule{Name: name, From: sender, Subject: `"([^"]+)"`})
+		}
+		// Anything else from the sender: one group per kind
+		rules = append(rules, config.AlertGroupRule{Name: name, From: sender, Subject: `^`})
+	}
+	return rules
+}
+
+// WorkspaceToArchive returns the IDs of the messages whose kind is set to be archived
+func WorkspaceToArchive(cfg config.WorkspaceNotificationsConfig, msgs []*gmail_v1.Message) []string {
+	var ids []string
+	for _, m := range msgs {
+		if kind, ok := WorkspaceNotificationKind(m); ok && cfg.Action(kind) == config.WorkspaceActionArchive {
+			ids = append(ids, m.Id)
+		}
+	}
+	return ids
+}
+
+// WorkspaceNotificationIDs groups the IDs of the notifications in msgs by kind
+func WorkspaceNotificationIDs(msgs []*gmail_v1.Message) map[string][]string {
+	out := make(map[string][]string)
+	for _, m := range msgs {
+		if kind, ok := WorkspaceNotificationKind(m); ok {
+			out[kind] = append(out[kind], m.Id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmail_v1 "google.golang.org/api/gmail/v1"
+)
+
+func TestWorkspaceNotificationKind(t *testing.T) {
+	cases := map[string]string{
+		"Google Chat <chat-noreply@google.com>":                      WorkspaceChat,
+		"Ana (via Google Meet) <meet-recordings-noreply@google.com>": WorkspaceMeet,
+		`"Ana (Google Docs)" <comments-noreply@docs.google.com>`:     WorkspaceDrive,
+		"Ana <ana@example.com>":                                      "",
+	}
+	for from, want := range cases {
+		kind, ok := WorkspaceNotificationKind(alertMsg("1", from, "x", 0))
+		assert.Equal(t, want, kind, from)
+		assert.Equal(t, want != "", ok, from)
+	}
+}
+
+func TestWorkspaceAlertRules_GroupPerConversationAndDocument(t *testing.T) {
+	g, err := NewAlertGrouper(WorkspaceAlertRules(config.WorkspaceNotificationsConfig{Meet: "off"}))
+	require.NoError(t, err)
+
+	chat := "Google Chat <chat-noreply@google.com>"
+	docs := "Ana (Google Docs) <comments-noreply@docs.google.com>"
+	rows, groups := g.Collapse([]*gmail_v1.Message{
+		alertMsg("c1", chat, "New message from Ana", 3),
+		alertMsg("d1", docs, `Ana replied to a comment in "Q3 Plan"`, 2),
+		alertMsg("c2", chat, "New message from Ana", 1),
+		alertMsg("d2", docs, `New comments on "Q3 Plan"`, 1),
+		alertMsg("m1", "Google Meet <meetings-noreply@google.com>", "Notes: weekly", 1),
+		alertMsg("m2", "Google Meet <meetings-noreply@google.com>", "Notes: weekly", 1),
+	})
+	assert.Len(t, rows, 4, "chat and doc notifications collapse, Meet is switched off")
+	require.Contains(t, groups, "c1")
+	assert.Equal(t, "Ana", groups["c1"].Key)
+	require.Contains(t, groups, "d1")
+	assert.Equal(t, "Drive comments", groups["d1"].Rule)
+	assert.Equal(t, "Q3 Plan", groups["d1"].Key)
+}
+
+func TestWorkspaceToArchive(t *testing.T) {
+	cfg := config.WorkspaceNotificationsConfig{Chat: "archive", Drive: "ARCHIVE ", Meet: "group"}
+	msgs := []*gmail_v1.Message{
+		alertMsg("c", "chat-noreply@google.com", "New message from Ana", 0),
+		alertMsg("d", "comments-noreply@docs.google.com", `"Plan"`, 0),
+		alertMsg("m", "meetings-noreply@google.com", "Recording", 0),
+		alertMsg("x", "ana@example.com", "Hi", 0),
+	}
+	assert.Equal(t, []string{"c", "d"}, WorkspaceToArchive(cfg, msgs))
+	assert.Equal(t, config.WorkspaceActionGroup, config.WorkspaceNotificationsConfig{Chat: "bogus"}.Action("chat"))
+}
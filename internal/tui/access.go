@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/pkg/auth"
+)
+
+// With a narrower access.mode, sign-in leaves out the permissions of some features; the first
+// request of such a feature asks for its permission in the browser while the request waits.
+
+// consentPrompt is the auth.ConsentPrompt while the UI owns the terminal: it opens the
+// authorization page in the browser and keeps the status bar on it until the consent finishes
+func (a *App) consentPrompt(account string, scopes []string, authURL string) func(error) {
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = auth.ScopeName(s)
+	}
+	what := "access"
+	if len(names) > 0 {
+		what = strings.Join(names, ", ")
+	}
+	if account != "" {
+		what += " for " + account
+	}
+
+	eh := a.GetErrorHandler()
+	eh.ShowProgress(a.ctx, fmt.Sprintf("🔐 Grant %s in your browser to continue…", what))
+	_, _, _, _, _, _, _, _, linkService, _, _, _ := a.GetServices()
+	if linkService == nil || linkService.OpenLink(a.ctx, authURL) != nil {
+		if a.logger != nil {
+			a.logger.Printf("consent: open this link to grant %s: %s", what, authURL)
+		}
+		eh.ShowProgress(a.ctx, fmt.Sprintf("🔐 Grant %s: open the link from the log file in a browser", what))
+	}
+
+	return func(err error) {
+		eh.ClearProgress()
+		if err != nil {
+			go eh.ShowError(a.ctx, fmt.Sprintf("🔐 Permission not granted: %v", err))
+			return
+		}
+		go eh.ShowSuccess(a.ctx, fmt.Sprintf("🔐 Granted %s", what))
+	}
+}
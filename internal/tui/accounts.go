@@ -232,7 +232,7 @@ func (a *App) openAccountPicker() {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "accounts")
 			}
 			a.SetFocus(input)
 			a.focus.set("labels") // Reuse labels focus infrastructure
@@ -275,6 +275,34 @@ func (a *App) closeAccountPicker() {
 	}
 }
 
+// resetAccountScopedState clears the in-memory state that belongs to the previous account: the
+// message/render/invite/AI caches, the preloader partition, search snapshots, the bulk selection and
+// the label map. Limits (performance.*) are global and carry over unchanged. Must run after
+// a.Client points at the new account.
+func (a *App) resetAccountScopedState(accountID string) {
+	a.caches.reset()
+	if preloader := a.GetPreloaderService(); preloader != nil {
+		if err := preloader.SwitchAccount(a.ctx, accountID, a.Client); err != nil && a.logger != nil {
+			a.logger.Printf("resetAccountScopedState: preloader switch failed: %v", err)
+		}
+	}
+	a.crossSearch.reset()
+	a.alerts.reset()
+	a.syncState.reset()
+	a.search.clear()
+	a.search.captureSnapshot(nil, nil, "", "")
+	a.nextPageToken = ""
+	a.bulk.clear()
+	a.bulk.setMode(false)
+	if a.emailRenderer != nil {
+		a.emailRenderer.SetLabelMap(map[string]string{})
+		a.emailRenderer.SetHiddenLabels(nil)
+	}
+	if a.logger != nil {
+		a.logger.Printf("resetAccountScopedState: account-scoped caches cleared for %s", accountID)
+	}
+}
+
 // switchToAccount switches to the selected account with proper cleanup
 func (a *App) switchToAccount(accountID, accountName string) {
 	if a.logger != nil {
@@ -296,6 +324,10 @@ func (a *App) switchToAccount(accountID, accountName string) {
 	// Show progress
 	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Switching to %s...", accountName))
 
+	// Not while startup is still attaching the LLM or the first account's database
+	a.uiLifecycle.wiring.Lock()
+	defer a.uiLifecycle.wiring.Unlock()
+
 	// Switch account
 	if err := accountService.SwitchAccount(a.ctx, accountID); err != nil {
 		a.GetErrorHandler().ClearProgress()
@@ -327,6 +359,9 @@ func (a *App) switchToAccount(accountID, accountName string) {
 		}
 	}
 
+	// Drop everything cached for the previous account before any view can read it
+	a.resetAccountScopedState(newActiveAccount.ID)
+
 	// Switch to the new account's database using DatabaseManager BEFORE reinitializing services
 	if a.databaseManager != nil && newActiveAccount.Email != "" {
 		if err := a.databaseManager.SwitchToAccountDatabase(a.ctx, newActiveAccount.Email); err != nil {
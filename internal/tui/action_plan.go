@@ -299,7 +299,7 @@ func (a *App) openActionPlanWithText(customPromptText string) {
 			}
 			a.labelsView = state.container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1)
+			a.showSidePanel(split, a.labelsView, "action_plan")
 		}
 		a.SetFocus(state.tree)
 		a.updateActionPlanFooter(state)
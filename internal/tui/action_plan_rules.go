@@ -210,7 +210,7 @@ func (a *App) openAnalyzerRulesManager() {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "action_plan")
 	}
 	a.setActivePicker(PickerAnalyzerRules)
 	a.focus.set("analyzer_rules")
@@ -121,7 +121,7 @@ func (a *App) toggleAISummary() {
 	// Safety check: ensure contentSplit exists and is accessible
 	if split, ok := a.views["contentSplit"]; ok && split != nil {
 		if contentSplit, ok := split.(*tview.Flex); ok {
-			contentSplit.ResizeItem(a.aiSummaryView, 0, 1)
+			a.showSidePanel(contentSplit, a.aiSummaryView, "ai")
 		}
 	}
 
@@ -148,7 +148,7 @@ func (a *App) toggleAISummary() {
 		a.aiSummaryView.SetBorderColor(a.GetComponentColors("ai").Border.Color())
 		a.aiSummaryView.SetBackgroundColor(a.GetComponentColors("ai").Background.Color())
 		// Reset title to AI Summary when switching from prompt mode
-		a.aiSummaryView.SetTitle(" 🧠 AI Summary ")
+		a.aiSummaryView.SetTitle(summaryPaneTitle(a.aiPanel.summaryPreset()))
 		a.aiSummaryView.SetTitleColor(a.GetComponentColors("ai").Title.Color())
 		a.updateFocusIndicators("summary")
 	} else {
@@ -177,6 +177,7 @@ func (a *App) closeAISummary() {
 	}
 	a.aiPanel.visible.Store(false)
 	a.aiPanel.inPromptMode = false // Reset prompt mode flag when hiding panel
+	a.aiPanel.setSummaryPreset("") // presets are per invocation
 
 	// Cancel any active streaming operations when hiding panel
 	a.aiPanel.cancelStreaming()
@@ -213,21 +214,23 @@ func (a *App) generateOrShowSummaryWithOptions(messageID string, forceRegenerate
 		return
 	}
 
+	preset := a.aiPanel.summaryPreset()
+	cacheKey := services.SummaryCacheKey(messageID, preset)
+
 	// Check cache first using cache service directly (instant lookup)
 	if !forceRegenerate {
 		_, _, _, cacheService, _, _, _, _, _, _, _, _ := a.GetServices()
 		if cacheService != nil {
 			accountEmail := a.getActiveAccountEmail()
-			if cached, found, err := cacheService.GetSummary(a.ctx, accountEmail, messageID); err == nil && found && cached != "" {
-				a.aiSummaryView.SetText(sanitizeForTerminal(cached))
-				a.aiSummaryView.ScrollToBeginning()
+			if cached, found, err := cacheService.GetSummary(a.ctx, accountEmail, cacheKey); err == nil && found && cached != "" {
+				a.showAIResult(sanitizeForTerminal(cached))
 				return
 			}
 		}
 	}
 
 	// Check if already processing
-	if a.caches.aiInFlightHas(messageID) {
+	if a.caches.aiInFlightHas(cacheKey) {
 		if a.debug {
 			a.logger.Printf("generateOrShowSummary: already processing message '%s'", messageID)
 		}
@@ -241,27 +244,28 @@ func (a *App) generateOrShowSummaryWithOptions(messageID string, forceRegenerate
 		if a.debug {
 			a.logger.Printf("generateOrShowSummary: ERROR - LLM is nil")
 		}
-		a.aiSummaryView.SetText("⚠️ LLM not available\n\nPlease check your LLM configuration.")
+		a.aiSummaryView.SetText(a.aiUnavailableText("summarize this message") + "\n\nPlease check your LLM configuration.")
 		a.aiSummaryView.ScrollToBeginning()
 		return
 	}
 
 	// Show loading message
+	a.aiPanel.setSource("")
 	a.aiSummaryView.SetText("🧠 Summarizing…")
 	a.aiSummaryView.ScrollToBeginning()
 
 	// Mark as in flight
-	a.caches.aiInFlightSet(messageID)
+	a.caches.aiInFlightSet(cacheKey)
 
 	// Generate summary in background following the working pattern
 	go func(id string) {
 		defer func() {
 			// Always clean up in-flight status
-			a.caches.aiInFlightDelete(id)
+			a.caches.aiInFlightDelete(cacheKey)
 		}()
 
 		// Get message content
-		m, err := a.Client.GetMessageWithContent(id)
+		m, err := a.messageClient(id).GetMessageWithContent(id)
 		if err != nil {
 			if a.debug {
 				a.logger.Printf("generateOrShowSummary: GetMessageWithContent error: %v", err)
@@ -305,13 +309,14 @@ func (a *App) generateOrShowSummaryWithOptions(messageID string, forceRegenerate
 			ForceRegenerate: forceRegenerate,
 			MessageID:       id,
 			AccountEmail:    accountEmail,
+			Preset:          preset,
 		}
 
 		// Use streaming summary generation if enabled
 		var finalResult string
 		if options.StreamEnabled {
 			// Set up streaming with UI updates
-			var streamBuilder strings.Builder
+			stream := a.newAIStream()
 			result, streamErr := aiService.GenerateSummaryStream(a.ctx, body, options, func(token string) {
 				// CRITICAL: NEVER use QueueUpdateDraw in streaming callbacks — it can
 				// deadlock with the ESC handler. Update the view directly, guarded by ctx.
@@ -320,9 +325,9 @@ func (a *App) generateOrShowSummaryWithOptions(messageID string, forceRegenerate
 					return // Exit early if cancelled (e.g. ESC pressed mid-stream)
 				default:
 				}
-				streamBuilder.WriteString(token)
+				text := stream.write(token, "🧠 ")
 				if a.ctx.Err() == nil && a.aiSummaryView != nil {
-					a.aiSummaryView.SetText("🧠 " + streamBuilder.String())
+					a.aiSummaryView.SetText(text)
 					a.aiSummaryView.ScrollToEnd()
 					a.ForceDraw()
 				}
@@ -355,11 +360,10 @@ func (a *App) generateOrShowSummaryWithOptions(messageID string, forceRegenerate
 
 		// Caching is handled by the AI service - no need for duplicate UI cache
 
-		// If we used streaming, the UI is already updated. If not, show the final result
-		if finalResult != "" && !strings.Contains(a.aiSummaryView.GetText(true), finalResult) {
+		// Render the whole answer once more: the stream shows its last block as plain text
+		if finalResult != "" {
 			a.QueueUpdateDraw(func() {
-				a.aiSummaryView.SetText(sanitizeForTerminal(finalResult))
-				a.aiSummaryView.ScrollToBeginning()
+				a.showAIResult(sanitizeForTerminal(finalResult))
 			})
 		}
 
@@ -415,7 +419,7 @@ func (a *App) suggestLabel() {
 	}
 	a.setStatusPersistent("🔖 Suggesting labels…")
 	go func() {
-		m, err := a.Client.GetMessageWithContent(messageID)
+		m, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 		if err != nil {
 			if a.logger != nil {
 				a.logger.Printf("suggestLabel: GetMessageWithContent error: %v", err)
@@ -423,7 +427,7 @@ func (a *App) suggestLabel() {
 			a.showError("❌ Error loading message")
 			return
 		}
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil || len(labels) == 0 {
 			if a.logger != nil {
 				a.logger.Printf("suggestLabel: ListLabels error: %v", err)
@@ -518,7 +522,7 @@ func (a *App) showLabelSuggestions(messageID string, suggestions []string) {
 	a.setStatusPersistent("🔖 Showing suggested labels…")
 	// Do network work off the UI thread
 	go func() {
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil {
 			a.showError("❌ Error loading labels")
 			return
@@ -626,7 +630,7 @@ func (a *App) showLabelSuggestions(messageID string, suggestions []string) {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "labels")
 			}
 			a.setActivePicker(PickerAI)
 			a.markFocus("labels")
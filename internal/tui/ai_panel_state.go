@@ -19,6 +19,8 @@ type aiPanelState struct {
 
 	mu              sync.Mutex
 	streamingCancel context.CancelFunc
+	preset          string // summary preset shown in the pane; "" is the default summary
+	source          string // Markdown of the AI answer shown in the pane, for the link picker
 }
 
 // setStreamingCancel records the active streaming cancel func (replacing any previous).
@@ -56,3 +58,31 @@ func (s *aiPanelState) isStreaming() bool {
 	defer s.mu.Unlock()
 	return s.streamingCancel != nil
 }
+
+// summaryPreset returns the summary preset of the pane ("" is the default summary).
+func (s *aiPanelState) summaryPreset() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.preset
+}
+
+// setSummaryPreset picks the summary preset the pane generates next.
+func (s *aiPanelState) setSummaryPreset(preset string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preset = preset
+}
+
+// setSource records the Markdown of the answer the pane shows ("" while none is shown).
+func (s *aiPanelState) setSource(md string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.source = md
+}
+
+// paneSource returns the Markdown of the answer the pane shows.
+func (s *aiPanelState) paneSource() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source
+}
@@ -1,6 +1,10 @@
 package tui
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
 
 func TestAIPanelState_Visible(t *testing.T) {
 	var s aiPanelState
@@ -59,3 +63,23 @@ func TestAIPanelState_StreamingCancel(t *testing.T) {
 		t.Fatal("must not be streaming after clear")
 	}
 }
+
+func TestAIPaneLinks(t *testing.T) {
+	a := &App{}
+	svc := services.NewLinkService(nil, nil)
+	own := []services.LinkInfo{{Index: 1, URL: "https://example.com/a"}}
+	a.aiPanel.setSource("See [a](https://example.com/a) and [b](https://example.com/b)")
+
+	if got := a.aiPaneLinks(svc, own); got != nil {
+		t.Fatalf("hidden AI pane must add no links, got %+v", got)
+	}
+	a.aiPanel.visible.Store(true)
+	got := a.aiPaneLinks(svc, own)
+	if len(got) != 1 || got[0].URL != "https://example.com/b" || got[0].Index != 2 {
+		t.Errorf("want only the new link numbered after the message's, got %+v", got)
+	}
+	a.aiPanel.setSource("")
+	if got := a.aiPaneLinks(svc, own); got != nil {
+		t.Errorf("no answer shown must add no links, got %+v", got)
+	}
+}
@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// alertGroupState tracks a list collapsed by :alerts. Rows are rendered by loading goroutines,
+// so everything is guarded by mu; use it via a.alerts.*.
+type alertGroupState struct {
+	mu       sync.RWMutex
+	active   bool
+	expanded bool                            // showing the occurrences of one group
+	groups   map[string]*services.AlertGroup // ID of the row standing for a group -> group
+	// The list before collapsing, restored by :alerts off
+	ids   []string
+	meta  []*gmailapi.Message
+	title string
+}
+
+// begin records the list being collapsed and its groups
+func (s *alertGroupState) begin(ids []string, meta []*gmailapi.Message, title string, groups map[string]*services.AlertGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = true
+	s.expanded = false
+	s.ids = ids
+	s.meta = meta
+	s.title = title
+	s.groups = groups
+}
+
+// reset forgets the collapsed list (a new list was loaded)
+func (s *alertGroupState) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = false
+	s.expanded = false
+	s.groups = nil
+	s.ids = nil
+	s.meta = nil
+	s.title = ""
+}
+
+func (s *alertGroupState) isActive() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// setExpanded records whether one group's occurrences are shown instead of the collapsed list
+func (s *alertGroupState) setExpanded(expanded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expanded = expanded
+}
+
+func (s *alertGroupState) isExpanded() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.expanded
+}
+
+// group returns the group a row of the collapsed list stands for, or nil
+func (s *alertGroupState) group(messageID string) *services.AlertGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.expanded {
+		return nil
+	}
+	return s.groups[messageID]
+}
+
+// saved returns the list as it was before collapsing
+func (s *alertGroupState) saved() ([]string, []*gmailapi.Message, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ids, s.meta, s.title
+}
+
+// alertSubject prefixes the subject of a row that stands for a group with its size
+func (a *App) alertSubject(messageID, subject string) string {
+	if g := a.alerts.group(messageID); g != nil {
+		return fmt.Sprintf("🔔×%d %s", g.Count(), subject)
+	}
+	return subject
+}
+
+// executeAlertsCommand handles :alerts [expand|off]. Without arguments it collapses the loaded
+// list by alert_groups.rules; expand lists every occurrence of the group under the cursor and
+// off brings the full list back.
+func (a *App) executeAlertsCommand(args []string) {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "", "collapse", "on":
+		if a.alertGrouper == nil {
+			a.showError("No alert groups configured (alert_groups.rules)")
+			return
+		}
+		if a.GetCurrentThreadViewMode() == ThreadViewThread {
+			a.showError("Alert grouping works on the flat list; switch off threading first")
+			return
+		}
+		if a.alerts.isExpanded() {
+			a.showCollapsedAlerts()
+			return
+		}
+		if a.alerts.isActive() {
+			a.showInfo("🔔 Alerts already collapsed; :alerts expand on a 🔔 row, :alerts off for all messages")
+			return
+		}
+		a.collapseAlerts()
+	case "expand", "x":
+		id := a.getCurrentSelectedMessageID()
+		g := a.alerts.group(id)
+		if g == nil {
+			a.showError("Not an alert group row (🔔×N); run :alerts first")
+			return
+		}
+		a.showAlertGroup(g)
+	case "off", "all":
+		if !a.alerts.isActive() {
+			a.showInfo("🔔 Alerts are not collapsed")
+			return
+		}
+		a.restoreAlerts()
+	default:
+		a.showError("Usage: alerts [expand|off]")
+	}
+}
+
+// collapseAlerts replaces the loaded list with one row per alert group
+func (a *App) collapseAlerts() {
+	ids := a.GetMessageIDs()
+	a.mu.RLock()
+	meta := append([]*gmailapi.Message(nil), a.messagesMeta...)
+	a.mu.RUnlock()
+	loading := len(meta) < len(ids)
+	for i := 0; !loading && i < len(ids); i++ {
+		loading = meta[i] == nil
+	}
+	if loading {
+		a.showError("Messages are still loading; try again in a moment")
+		return
+	}
+
+	rows, groups := a.alertGrouper.Collapse(meta[:len(ids)])
+	if len(groups) == 0 {
+		a.showInfo("🔔 No repeated alerts in the loaded messages")
+		return
+	}
+	title := ""
+	table, _ := a.views["list"].(*tview.Table)
+	if table != nil {
+		title = table.GetTitle()
+	}
+	a.alerts.begin(ids, meta, title, groups)
+
+	a.setListRows(rows, alertCollapseTitle(len(ids)-len(rows)+len(groups), len(groups)))
+	a.showInfo("🔔 Alerts collapsed — :alerts expand on a 🔔 row lists its occurrences, :alerts off shows all")
+}
+
+// showCollapsedAlerts goes back from an expanded group to the collapsed list
+func (a *App) showCollapsedAlerts() {
+	ids, meta, title := a.alerts.saved()
+	rows, groups := a.alertGrouper.Collapse(meta[:len(ids)])
+	a.alerts.begin(ids, meta, title, groups)
+	a.setListRows(rows, alertCollapseTitle(len(ids)-len(rows)+len(groups), len(groups)))
+}
+
+// showAlertGroup lists every occurrence of a group, newest first
+func (a *App) showAlertGroup(g *services.AlertGroup) {
+	a.alerts.setExpanded(true)
+	a.setListRows(g.Messages, alertGroupTitle(g, time.Now()))
+	a.showInfo("🔔 :alerts goes back to the collapsed list, :alerts off shows all messages")
+}
+
+// restoreAlerts brings back the list as it was before :alerts
+func (a *App) restoreAlerts() {
+	ids, meta, title := a.alerts.saved()
+	a.alerts.reset()
+	a.setListRows(meta[:len(ids)], title)
+}
+
+// setListRows shows msgs as the message list; runs on the UI goroutine
+func (a *App) setListRows(msgs []*gmailapi.Message, title string) {
+	ids := make([]string, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.Id
+	}
+	a.SetMessageIDs(ids)
+	a.mu.Lock()
+	a.messagesMeta = append([]*gmailapi.Message(nil), msgs...)
+	a.mu.Unlock()
+	a.refreshTableDisplay()
+	if table, ok := a.views["list"].(*tview.Table); ok {
+		table.SetTitle(title)
+		if table.GetRowCount() > 1 {
+			table.Select(1, 0)
+		}
+	}
+}
+
+// alertCollapseTitle summarizes a collapsed list
+func alertCollapseTitle(alerts, groups int) string {
+	return fmt.Sprintf(" 🔔 %d alert(s) collapsed into %d group(s) ", alerts, groups)
+}
+
+// alertGroupTitle names an expanded group with its size and latest occurrence
+func alertGroupTitle(g *services.AlertGroup, now time.Time) string {
+	title := fmt.Sprintf(" 🔔 %s: %s — %d occurrence(s)", g.Rule, g.Key, g.Count())
+	if latest := g.Latest(); !latest.IsZero() {
+		title += ", latest " + formatAlertAge(now.Sub(latest))
+	}
+	return title + " "
+}
+
+// formatAlertAge formats how long ago an alert fired
+func formatAlertAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
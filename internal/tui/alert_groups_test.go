@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestAlertGroupTitle(t *testing.T) {
+	now := time.Date(2026, 7, 3, 12, 0, 0, 0, time.UTC)
+	g := &services.AlertGroup{Rule: "CI", Key: "api build", Messages: []*gmailapi.Message{
+		{Id: "b", InternalDate: now.Add(-3 * time.Hour).UnixMilli()},
+		{Id: "a", InternalDate: now.Add(-5 * time.Hour).UnixMilli()},
+	}}
+	if got, want := alertGroupTitle(g, now), " 🔔 CI: api build — 2 occurrence(s), latest 3h ago "; got != want {
+		t.Errorf("alertGroupTitle = %q, want %q", got, want)
+	}
+}
+
+func TestFormatAlertAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		20 * time.Second: "just now",
+		45 * time.Minute: "45m ago",
+		30 * time.Hour:   "30h ago",
+		72 * time.Hour:   "3d ago",
+	}
+	for d, want := range cases {
+		if got := formatAlertAge(d); got != want {
+			t.Errorf("formatAlertAge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestAlertGroupState_ExpandedHidesCounts(t *testing.T) {
+	g := &services.AlertGroup{Messages: []*gmailapi.Message{{Id: "a"}, {Id: "b"}}}
+	a := &App{}
+	a.alerts.begin(nil, nil, "", map[string]*services.AlertGroup{"a": g})
+	if got := a.alertSubject("a", "Build failed"); got != "🔔×2 Build failed" {
+		t.Errorf("alertSubject = %q", got)
+	}
+	a.alerts.setExpanded(true)
+	if got := a.alertSubject("a", "Build failed"); got != "Build failed" {
+		t.Errorf("alertSubject while expanded = %q", got)
+	}
+	a.alerts.reset()
+	if a.alerts.isActive() || a.alerts.group("a") != nil {
+		t.Error("reset should drop the groups")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ajramos/giztui/internal/services"
 	"github.com/ajramos/giztui/internal/tts"
 	"github.com/ajramos/giztui/internal/version"
+	"github.com/ajramos/giztui/pkg/auth"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 	gmailapi "google.golang.org/api/gmail/v1"
@@ -47,6 +48,13 @@ const (
 	PickerContentSearch      ActivePicker = "content_search"
 	PickerRSVP               ActivePicker = "rsvp"
 	PickerAccounts           ActivePicker = "accounts"
+	PickerSync               ActivePicker = "sync"
+	PickerOutbox             ActivePicker = "outbox"
+	PickerTodos              ActivePicker = "todos"
+	PickerLocalArchive       ActivePicker = "local_archive"
+	PickerSmartLabels        ActivePicker = "smart_labels"
+	PickerRecipientGroups    ActivePicker = "recipient_groups"
+	PickerMIMEParts          ActivePicker = "mime_parts"
 )
 
 // App encapsulates the terminal UI and the Gmail client
@@ -72,6 +80,9 @@ type App struct {
 	ids            []string
 	messagesMeta   []*gmailapi.Message
 	currentThreads []*services.ThreadInfo // Current threads for column system
+	splitThreads   map[string]bool        // conversations split at subject changes by :thread-split (under mu)
+	// kind of panel last shown in each side panel view, for layout.panel_sizes (UI goroutine only)
+	sidePanelKinds map[tview.Primitive]string
 	draft          draftState
 	showHelp       bool
 	// Reader-content backups for full-pane overlays (type in overlay_backup.go)
@@ -92,6 +103,17 @@ type App struct {
 
 	// Search/Filter state (state machine in search_state.go)
 	search searchState
+	// Cross-account search (":search --all"): owning account of each merged result
+	crossSearch crossAccountState
+	// Repeated notification emails collapsed by :alerts (alert_groups.go)
+	alerts       alertGroupState
+	alertGrouper *services.AlertGrouper // nil when alert_groups.rules is empty or invalid
+	// Labels hidden from pickers and the list (Gmail settings plus label_visibility overrides)
+	labelVisibility *services.LabelVisibilityRules
+	// Flag changes applied locally but not confirmed by Gmail yet (sync_state.go)
+	syncState syncTracker
+	// Forwarded-as-attachment messages opened in the content pane (nested_messages.go)
+	nested nestedStack
 	// AI Summary pane
 	aiSummaryView *tview.TextView
 	// aiPanel groups AI-pane visibility, prompt-mode, and streaming-cancel state (ai_panel_state.go)
@@ -119,6 +141,9 @@ type App struct {
 	currentActivePicker ActivePicker // Replaces labelsVisible - tracks which picker is active
 	labelsExpanded      bool
 
+	// Cancels in-flight link preview fetches when the link picker closes (guarded by mu)
+	linkPreviewCancel context.CancelFunc
+
 	// Slack contextual panel
 	slackView    *tview.Flex
 	slackVisible bool
@@ -143,13 +168,25 @@ type App struct {
 
 	// Message display options
 	showMessageNumbers bool
+	showListFooter     bool
+	showKeyHints       bool
+	showContentMinimap bool
+	contentMinimap     *contentMinimap   // scroll/match gutter beside the message text
+	rowFormat          *render.RowFormat // flat list row template (display.row_format); nil shows columns
+	rowFormatText      string
+	// Estimated total matches of the current view for the list footer (list_footer.go)
+	footer listFooterState
 
 	// Services (new architecture)
 	accountService          services.AccountService
+	crossSearchService      services.CrossAccountSearchService
 	databaseManager         services.DatabaseManager
 	emailService            services.EmailService
 	aiService               services.AIService
 	labelService            services.LabelService
+	labelCatalog            services.LabelCatalog // cached labels of the active account (label_catalog.go)
+	unreadCounters          services.UnreadCounterService
+	unreadCountersStarted   atomic.Bool
 	cacheService            services.CacheService
 	repository              services.MessageRepository
 	compositionService      services.CompositionService
@@ -173,6 +210,31 @@ type App struct {
 	undoService             services.UndoService
 	preloaderService        services.MessagePreloader
 	autoRefreshService      services.AutoRefreshService
+	quotaPlanner            services.QuotaPlannerService
+	outboxService           services.OutboxService
+	localArchiveService     services.LocalArchiveService
+	smartLabelService       services.SmartLabelService
+	threadNoteService       services.ThreadNoteService
+	contactService          services.ContactService
+	senderOverrideService   services.SenderOverrideService
+	usualActionsService     services.UsualActionsService
+	usualSuggestion         atomic.Pointer[usualSuggestion] // usual actions offered for the selected message
+	todoService             services.TodoService
+	mailMergeService        services.MailMergeService
+	mailMerge               *mailMergeState // the merge loaded by :merge (UI goroutine only)
+	sharedPackService       services.SharedPackService
+	sharedPackPulled        atomic.Bool // the pack repository is pulled once per run (and by :pack)
+	// vCards of the attachment previewed last, for :contacts add (UI goroutine only)
+	previewedContacts       []services.VCard
+	previewedContactsSource string
+	lastBriefing            *services.MeetingBriefing // last :briefing, for :briefing obsidian (UI goroutine only)
+	recipientGroupService   services.RecipientGroupService
+	reportService           services.ReportService
+	digestService           services.DigestService
+	htmlPreviewService      services.HTMLPreviewService
+	trashRestoreService     services.TrashRestoreService
+	timeMachineService      services.TimeMachineService
+	dnd                     dndState // manual do-not-disturb override of the quiet hours
 	speechService           services.SpeechService
 	currentTheme            *config.ColorsConfig // Current theme cache for helper functions
 	errorHandler            *ErrorHandler
@@ -182,6 +244,16 @@ type App struct {
 	autoRefreshStop    chan struct{}
 	autoRefreshRunning bool
 	pendingNewCount    int
+
+	// Offline outbox: retry loop guard and the open panel's reload (UI thread only)
+	outboxWatching atomic.Bool
+	outboxReload   func()
+
+	// Reload of the open todos panel, so extractions show up in it (UI thread only)
+	todosReload func()
+
+	// Before → after report of the last bulk label or move job, for :labeldiff
+	lastLabelDiff atomic.Pointer[services.LabelDiff]
 }
 
 // Pages manages the application pages and navigation
@@ -291,7 +363,7 @@ func NewApp(client *gmail.Client, calendarClient *calclient.Client, llmClient ll
 		Config:             cfg,
 		Client:             client,
 		Calendar:           calendarClient,
-		LLM:                llmClient,
+		LLM:                guardLLM(cfg, llmClient, logger),
 		Keys:               cfg.Keys,
 		ctx:                ctx,
 		cancel:             cancel,
@@ -315,14 +387,43 @@ func NewApp(client *gmail.Client, calendarClient *calclient.Client, llmClient ll
 		bulk:               newBulkState(),
 		messagesLoading:    false,
 		showMessageNumbers: cfg.Display.ShowMessageNumbers, // Load from config
+		showListFooter:     cfg.Display.ShowListFooter,
+		showKeyHints:       cfg.Display.ShowKeyHints,
+		showContentMinimap: cfg.Display.ShowContentMinimap,
 	}
 
 	// Set services passed from main.go
 	app.accountService = accountService
 
+	// Custom list row template; an invalid one falls back to the columns
+	if strings.TrimSpace(cfg.Display.RowFormat) != "" {
+		if f, err := render.ParseRowFormat(cfg.Display.RowFormat); err != nil {
+			if logger != nil {
+				logger.Printf("display.row_format ignored: %v", err)
+			}
+		} else {
+			app.rowFormat = f
+			app.rowFormatText = cfg.Display.RowFormat
+		}
+	}
+
+	app.labelVisibility = services.NewLabelVisibilityRules(cfg.LabelVisibility)
+
+	// Alert grouping rules, then the built-in ones for Workspace notifications; invalid ones disable :alerts
+	alertRules := append(append([]config.AlertGroupRule(nil), cfg.AlertGroups.Rules...), services.WorkspaceAlertRules(cfg.WorkspaceNotifications)...)
+	if grouper, err := services.NewAlertGrouper(alertRules); err != nil {
+		if logger != nil {
+			logger.Printf("alert_groups ignored: %v", err)
+		}
+	} else {
+		app.alertGrouper = grouper
+	}
+
 	// Skip logger initialization since we're using the passed logger
 	// app.initLogger() // Removed - using passed logger
 
+	app.uiLifecycle.boot.begin(time.Now())
+
 	// Initialize pages
 	app.Pages = NewPages()
 
@@ -343,6 +444,7 @@ func NewApp(client *gmail.Client, calendarClient *calclient.Client, llmClient ll
 		// Mark UI as ready on first draw
 		if !app.uiLifecycle.ready.Load() {
 			app.uiLifecycle.ready.Store(true)
+			go app.markBoot("ui")
 		}
 		w, h := screen.Size()
 		if curW, curH := app.layout.size(); w != curW || h != curH {
@@ -477,6 +579,16 @@ func (a *App) reinitializeServices() {
 		}
 	}
 
+	// The AI service may have been re-created above; rebind the report narrative, the digest
+	// one-liners and the forwarding note to it
+	if a.Client != nil {
+		a.reportService = services.NewReportService(a.Client, a.aiService)
+		a.digestService = services.NewDigestService(a.Client, a.aiService)
+	}
+	if compositionService, ok := a.compositionService.(*services.CompositionServiceImpl); ok {
+		compositionService.SetAIService(a.aiService)
+	}
+
 	// Now update prompt service with bulk service
 	if a.promptService != nil && a.bulkPromptService != nil {
 		// We need to update the prompt service to include the bulk service
@@ -529,6 +641,66 @@ func (a *App) reinitializeServices() {
 		}
 	}
 
+	// Initialize the offline outbox if database store is available
+	if a.dbStore != nil && a.outboxService == nil {
+		a.bindOutbox()
+	}
+
+	// Initialize the local archive if database store is available
+	if a.dbStore != nil && a.localArchiveService == nil {
+		a.bindLocalArchive()
+	}
+
+	// Initialize smart labels (saved queries auto-applied to new mail) if database store is available
+	if a.dbStore != nil && a.smartLabelService == nil {
+		a.bindSmartLabels()
+	}
+
+	// Initialize pinned conversation notes if database store is available
+	if a.dbStore != nil && a.threadNoteService == nil {
+		a.bindThreadNotes()
+	}
+
+	// Initialize the contacts index if database store is available
+	if a.dbStore != nil && a.contactService == nil {
+		a.bindContacts()
+	}
+
+	// Initialize per-sender display overrides if database store is available
+	if a.dbStore != nil && a.senderOverrideService == nil {
+		a.bindSenderOverrides()
+	}
+
+	// Initialize the usual actions learned from the action log if database store is available
+	if a.dbStore != nil && a.usualActionsService == nil {
+		a.bindUsualActions()
+	}
+
+	// Initialize extracted action items if database store is available
+	if a.dbStore != nil && a.todoService == nil {
+		a.bindTodos()
+	}
+
+	// Initialize mail merge progress if database store is available
+	if a.dbStore != nil && a.mailMergeService == nil {
+		a.bindMailMerge()
+	}
+
+	// Merge the team's shared prompts and saved queries if database store is available
+	if a.dbStore != nil && a.sharedPackService == nil {
+		a.bindSharedPack()
+	}
+
+	// Initialize restoring Trash/Spam to the original labels if database store is available
+	if a.dbStore != nil && a.trashRestoreService == nil {
+		a.bindTrashRestore()
+	}
+
+	// Initialize daily inbox snapshots if database store is available
+	if a.dbStore != nil && a.timeMachineService == nil {
+		a.bindTimeMachine()
+	}
+
 	// Initialize Obsidian service if database store is available
 	if a.dbStore != nil && a.obsidianService == nil {
 		obsidianStore := db.NewObsidianStore(a.dbStore)
@@ -570,12 +742,19 @@ func (a *App) initServices() {
 		a.logger.Printf("initServices: account service initialized: %v", a.accountService != nil)
 	}
 
+	if a.accountService != nil {
+		a.crossSearchService = services.NewCrossAccountSearchService(a.accountService)
+	}
+
 	// Initialize database manager for hot account switching
 	a.databaseManager = services.NewDatabaseManager(a.Config, a.logger)
 	if a.logger != nil {
 		a.logger.Printf("initServices: database manager initialized: %v", a.databaseManager != nil)
 	}
 
+	// Recipient groups live in the config file, so they need neither a client nor a database
+	a.recipientGroupService = services.NewRecipientGroupService(a.Config, a.saveConfigAsync)
+
 	// Only update account status if we have a valid multi-account configuration
 	// Don't assume success in fallback mode - the AccountService might contain failed accounts
 	if activeAccount, err := a.accountService.GetActiveAccount(a.ctx); err == nil {
@@ -623,10 +802,11 @@ func (a *App) initServices() {
 	}
 
 	// Initialize label service
-	a.labelService = services.NewLabelService(a.Client)
+	a.labelService = a.newLabelService()
 	if a.logger != nil {
 		a.logger.Printf("initServices: label service initialized: %v", a.labelService != nil)
 	}
+	a.bindUnreadCounters()
 
 	// Initialize cache service if store is available
 	if a.dbStore != nil {
@@ -662,9 +842,17 @@ func (a *App) initServices() {
 	if emailServiceImpl, ok := a.emailService.(*services.EmailServiceImpl); ok && a.logger != nil {
 		emailServiceImpl.SetLogger(a.logger)
 	}
+	// Bulk jobs change up to 1000 messages per Gmail call
+	if emailServiceImpl, ok := a.emailService.(*services.EmailServiceImpl); ok && a.Client != nil {
+		emailServiceImpl.SetBatchModifier(a.Client)
+	}
 
 	// Initialize composition service
-	a.compositionService = services.NewCompositionService(a.emailService, a.Client, a.repository)
+	compositionService := services.NewCompositionService(a.emailService, a.Client, a.repository)
+	compositionService.SetLabelService(a.labelService)
+	compositionService.SetSentLabelRules(a.Config.Compose.SentLabels, a.Config.Compose.DomainLabels)
+	compositionService.SetAIService(a.aiService)
+	a.compositionService = compositionService
 	if a.logger != nil {
 		a.logger.Printf("initServices: composition service initialized: %v", a.compositionService != nil)
 	}
@@ -674,7 +862,9 @@ func (a *App) initServices() {
 	}
 
 	// Initialize link service
-	a.linkService = services.NewLinkService(a.Client, a.emailRenderer)
+	linkService := services.NewLinkService(a.Client, a.emailRenderer)
+	linkService.SetPreviewConfig(a.Config.Links)
+	a.linkService = linkService
 	if a.logger != nil {
 		a.logger.Printf("initServices: link service initialized: %v", a.linkService != nil)
 	}
@@ -685,12 +875,23 @@ func (a *App) initServices() {
 		a.logger.Printf("initServices: attachment service initialized: %v", a.attachmentService != nil)
 	}
 
+	// Initialize HTML preview service (cid: images are fetched with the client)
+	if a.Client != nil {
+		a.htmlPreviewService = services.NewHTMLPreviewService(a.Client, a.Config.HTMLPreview)
+	}
+
 	// Initialize Gmail web service
 	a.gmailWebService = services.NewGmailWebService(a.linkService)
 	if a.logger != nil {
 		a.logger.Printf("initServices: gmail web service initialized: %v", a.gmailWebService != nil)
 	}
 
+	// Initialize email report and unread digest services (the AI parts are optional)
+	if a.Client != nil {
+		a.reportService = services.NewReportService(a.Client, a.aiService)
+		a.digestService = services.NewDigestService(a.Client, a.aiService)
+	}
+
 	// Initialize bulk prompt service if dependencies are available
 	if a.repository != nil && a.aiService != nil && a.cacheService != nil {
 		// For now, pass nil as promptService to avoid circular dependency
@@ -911,7 +1112,13 @@ func (a *App) initServices() {
 			APIQuotaReservePercent: a.Config.Performance.Preloading.Limits.APIQuotaReservePercent,
 		}
 
-		a.preloaderService = services.NewMessagePreloader(a.Client, preloadConfig, a.logger)
+		preloader := services.NewMessagePreloader(a.Client, preloadConfig, a.logger)
+		if a.accountService != nil {
+			if active, err := a.accountService.GetActiveAccount(a.ctx); err == nil {
+				_ = preloader.SwitchAccount(a.ctx, active.ID, a.Client) // tag the initial partition
+			}
+		}
+		a.preloaderService = preloader
 		if a.logger != nil {
 			a.logger.Printf("initServices: preloader service initialized: %v (enabled: %v)",
 				a.preloaderService != nil, preloadConfig.Enabled)
@@ -923,39 +1130,7 @@ func (a *App) initServices() {
 		}
 	}
 
-	// Initialize database for active account
-	if a.databaseManager != nil {
-		if activeAccount, err := a.accountService.GetActiveAccount(a.ctx); err == nil && activeAccount.Email != "" {
-			if a.logger != nil {
-				a.logger.Printf("initServices: initializing database for active account: %s", activeAccount.Email)
-			}
-			if err := a.databaseManager.SwitchToAccountDatabase(a.ctx, activeAccount.Email); err != nil {
-				if a.logger != nil {
-					a.logger.Printf("initServices: failed to initialize database for account %s: %v", activeAccount.Email, err)
-				}
-			} else {
-				if a.logger != nil {
-					a.logger.Printf("initServices: successfully initialized database for account %s", activeAccount.Email)
-				}
-
-				// Get the new database store and register it with the app
-				if newStore := a.databaseManager.GetCurrentStore(); newStore != nil {
-					if a.logger != nil {
-						a.logger.Printf("initServices: registering new database store for services")
-					}
-					a.RegisterDBStore(newStore)
-				} else {
-					if a.logger != nil {
-						a.logger.Printf("initServices: WARNING - database manager returned nil store after successful switch")
-					}
-				}
-			}
-		} else {
-			if a.logger != nil {
-				a.logger.Printf("initServices: no active account found for database initialization")
-			}
-		}
-	}
+	// The account database opens in the background once the UI runs (openAccountDatabase)
 
 	// Auto-refresh service (opt-in inbox polling)
 	a.autoRefreshService = services.NewAutoRefreshService(
@@ -965,6 +1140,12 @@ func (a *App) initServices() {
 		time.Minute,
 	)
 
+	// Quota planner for large bulk jobs (client-independent; survives account switches)
+	a.quotaPlanner = services.NewQuotaPlannerService(
+		a.Config.Performance.Quota.UnitsPerMinute,
+		a.Config.Performance.Quota.ReservePercent,
+	)
+
 	if a.logger != nil {
 		a.logger.Printf("initServices: service initialization completed")
 	}
@@ -976,6 +1157,7 @@ func (a *App) initServices() {
 	if a.autoRefreshService.IsEnabled() {
 		a.startAutoRefresh()
 	}
+	a.startUnreadCounters()
 
 	// Text-to-speech service (opt-in). The engine auto-selects by OS ("auto"/empty → macOS uses the
 	// built-in "say", no deps; other platforms use the cross-platform Piper binary), or can be
@@ -1030,10 +1212,11 @@ func (a *App) reinitializeClientDependentServices() {
 	}
 
 	// Reinitialize label service with new client
-	a.labelService = services.NewLabelService(a.Client)
+	a.labelService = a.newLabelService()
 	if a.logger != nil {
 		a.logger.Printf("reinitializeClientDependentServices: label service reinitialized: %v", a.labelService != nil)
 	}
+	a.bindUnreadCounters()
 
 	// Reinitialize email service with new client and repository
 	a.emailService = services.NewEmailService(a.repository, a.Client, a.emailRenderer)
@@ -1044,9 +1227,17 @@ func (a *App) reinitializeClientDependentServices() {
 	if emailServiceImpl, ok := a.emailService.(*services.EmailServiceImpl); ok && a.logger != nil {
 		emailServiceImpl.SetLogger(a.logger)
 	}
+	// Bulk jobs change up to 1000 messages per Gmail call
+	if emailServiceImpl, ok := a.emailService.(*services.EmailServiceImpl); ok && a.Client != nil {
+		emailServiceImpl.SetBatchModifier(a.Client)
+	}
 
 	// Reinitialize composition service with new client
-	a.compositionService = services.NewCompositionService(a.emailService, a.Client, a.repository)
+	compositionService := services.NewCompositionService(a.emailService, a.Client, a.repository)
+	compositionService.SetLabelService(a.labelService)
+	compositionService.SetSentLabelRules(a.Config.Compose.SentLabels, a.Config.Compose.DomainLabels)
+	compositionService.SetAIService(a.aiService)
+	a.compositionService = compositionService
 	if a.logger != nil {
 		a.logger.Printf("reinitializeClientDependentServices: composition service reinitialized: %v", a.compositionService != nil)
 	}
@@ -1055,8 +1246,12 @@ func (a *App) reinitializeClientDependentServices() {
 		compositionServiceImpl.SetLogger(a.logger)
 	}
 
-	// Reinitialize link service with new client
-	a.linkService = services.NewLinkService(a.Client, a.emailRenderer)
+	// Reinitialize link service with new client, keeping this session's preview toggle
+	previewEnabled := a.linkService != nil && a.linkService.IsPreviewEnabled()
+	linkService := services.NewLinkService(a.Client, a.emailRenderer)
+	linkService.SetPreviewConfig(a.Config.Links)
+	linkService.SetPreviewEnabled(previewEnabled)
+	a.linkService = linkService
 	if a.logger != nil {
 		a.logger.Printf("reinitializeClientDependentServices: link service reinitialized: %v", a.linkService != nil)
 	}
@@ -1067,6 +1262,11 @@ func (a *App) reinitializeClientDependentServices() {
 		a.logger.Printf("reinitializeClientDependentServices: attachment service reinitialized: %v", a.attachmentService != nil)
 	}
 
+	// Reinitialize HTML preview service with new client
+	if a.Client != nil {
+		a.htmlPreviewService = services.NewHTMLPreviewService(a.Client, a.Config.HTMLPreview)
+	}
+
 	// Reinitialize Gmail web service (depends on link service)
 	a.gmailWebService = services.NewGmailWebService(a.linkService)
 	if a.logger != nil {
@@ -1155,6 +1355,12 @@ func (a *App) reinitializeClientDependentServices() {
 		}
 	}
 
+	// Reinitialize email report and unread digest services (depend on Client; aiService is optional)
+	if a.Client != nil {
+		a.reportService = services.NewReportService(a.Client, a.aiService)
+		a.digestService = services.NewDigestService(a.Client, a.aiService)
+	}
+
 	// Reinitialize Slack service if enabled (depends on Client, Config, and aiService)
 	if a.Config.Slack.Enabled && a.aiService != nil {
 		a.slackService = services.NewSlackService(a.Client, a.Config, a.aiService)
@@ -1163,6 +1369,34 @@ func (a *App) reinitializeClientDependentServices() {
 		}
 	}
 
+	// Rebind saved queries and analyzer rules to the new account's database and email; they are
+	// only created once in reinitializeServices and would otherwise keep the previous store
+	if a.dbStore != nil {
+		email := a.getActiveAccountEmail()
+		queryService := services.NewQueryService(db.NewQueryStore(a.dbStore), a.Config)
+		queryService.SetAccountEmail(email)
+		a.queryService = queryService
+
+		rulesService := services.NewAnalyzerRulesService(db.NewAnalyzerRulesStore(a.dbStore))
+		rulesService.SetAccountEmail(email)
+		a.analyzerRulesService = rulesService
+		a.bindOutbox()
+		a.bindLocalArchive()
+		a.bindSmartLabels()
+		a.bindThreadNotes()
+		a.bindContacts()
+		a.bindSenderOverrides()
+		a.bindUsualActions()
+		a.bindTodos()
+		a.bindMailMerge()
+		a.bindSharedPack()
+		a.bindTrashRestore()
+		a.bindTimeMachine()
+		if a.logger != nil {
+			a.logger.Printf("reinitializeClientDependentServices: query, analyzer rules, outbox, local archive, smart label, thread note, contacts, sender override, usual actions, todos, mail merge, shared pack, trash restore and time machine services rebound to %s", email)
+		}
+	}
+
 	// Reinitialize Obsidian service (depends on dbStore but needs fresh database connection after account switch)
 	if a.dbStore != nil {
 		obsidianStore := db.NewObsidianStore(a.dbStore)
@@ -1215,6 +1449,13 @@ func (a *App) initErrorHandler() {
 
 	// Create error handler
 	a.errorHandler = NewErrorHandler(a.Application, a, statusView, flashView, a.logger)
+	if a.Config != nil {
+		verbosity, ok := ParseStatusVerbosity(a.Config.Display.StatusVerbosity)
+		if !ok && a.logger != nil {
+			a.logger.Printf("initErrorHandler: unknown display.status_verbosity %q, using normal", a.Config.Display.StatusVerbosity)
+		}
+		a.errorHandler.SetVerbosity(verbosity)
+	}
 }
 
 // Thread-safe state access methods
@@ -1378,15 +1619,34 @@ func (a *App) GetMessageFromCache(messageID string) (*gmail.Message, bool) {
 	return a.caches.messageGet(messageID)
 }
 
-// SetMessageInCache stores a message in cache thread-safely
+// SetMessageInCache stores a message in cache thread-safely, bounded by performance.caches
 func (a *App) SetMessageInCache(messageID string, message *gmail.Message) {
-	a.caches.messageSet(messageID, message)
+	a.caches.messageEvictOneIfFull(messageID, message, a.messageCacheLimit())
 }
 
 // renderCacheMaxEntries bounds the rendered-body cache to keep session memory
 // in check (each entry is a rendered message body per mode/width).
 const renderCacheMaxEntries = 256
 
+// messageCacheMaxEntries is the default bound of the opened-message cache
+const messageCacheMaxEntries = 500
+
+// messageCacheLimit returns the configured opened-message cache bound
+func (a *App) messageCacheLimit() int {
+	if a.Config != nil && a.Config.Performance.Caches.MessageEntries > 0 {
+		return a.Config.Performance.Caches.MessageEntries
+	}
+	return messageCacheMaxEntries
+}
+
+// renderCacheLimit returns the configured rendered-body cache bound
+func (a *App) renderCacheLimit() int {
+	if a.Config != nil && a.Config.Performance.Caches.RenderEntries > 0 {
+		return a.Config.Performance.Caches.RenderEntries
+	}
+	return renderCacheMaxEntries
+}
+
 // renderCacheKey composes a key for cached rendered body text.
 func renderCacheKey(messageID string, markdown bool, width int) string {
 	return fmt.Sprintf("%s|%t|%d", messageID, markdown, width)
@@ -1400,7 +1660,7 @@ func (a *App) getRenderCache(messageID string, markdown bool, width int) (string
 // setRenderCache stores rendered body text, bounding the cache to
 // renderCacheMaxEntries (evicting one entry when full and the key is new).
 func (a *App) setRenderCache(messageID string, markdown bool, width int, text string) {
-	a.caches.renderEvictOneIfFull(renderCacheKey(messageID, markdown, width), text, renderCacheMaxEntries)
+	a.caches.renderEvictOneIfFull(renderCacheKey(messageID, markdown, width), text, a.renderCacheLimit())
 }
 
 // GetScreenSize returns the current screen dimensions thread-safely
@@ -1453,6 +1713,11 @@ func (a *App) GetAccountService() services.AccountService {
 	return a.accountService
 }
 
+// GetCrossAccountSearchService returns the cross-account search service instance
+func (a *App) GetCrossAccountSearchService() services.CrossAccountSearchService {
+	return a.crossSearchService
+}
+
 // GetUndoService returns the undo service instance
 func (a *App) GetUndoService() services.UndoService {
 	return a.undoService
@@ -1680,7 +1945,7 @@ func (a *App) restoreMessagesToInboxList(messageIDs []string) {
 				table.SetCell(0, 0, cell)
 
 				// Update table title to reflect new count
-				table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+				table.SetTitle(a.messagesTitle())
 
 				// Logging removed for simplicity
 			}
@@ -1992,6 +2257,9 @@ func (a *App) saveConfigAsync() error {
 	if a.Config == nil {
 		return fmt.Errorf("config is nil")
 	}
+	if a.Config.DemoMode {
+		return nil // the demo config carries a fake account; keep the user's file untouched
+	}
 	configPath := config.DefaultConfigPath()
 	return a.Config.SaveConfig(configPath)
 }
@@ -2151,6 +2419,7 @@ func (a *App) generateHelpText() string {
 	fmt.Fprintf(&help, "    %-8s  🗑️   Move to trash\n", a.Keys.Trash)
 	fmt.Fprintf(&help, "    %-8s  👁️   Toggle read/unread\n", a.Keys.ToggleRead)
 	fmt.Fprintf(&help, "    %-8s  ↩️   Undo last action\n", a.Keys.Undo)
+	fmt.Fprintf(&help, "    %-8s  💡  Do the usual for this sender (when suggested)\n", a.Keys.UsualActions)
 	fmt.Fprintf(&help, "    %-8s  📦  Move message to folder\n", a.Keys.Move)
 	fmt.Fprintf(&help, "    %-8s  🔖  Manage labels\n", a.Keys.ManageLabels)
 	fmt.Fprintf(&help, "    %-8s  📝  View drafts\n\n", a.Keys.Drafts)
@@ -2208,6 +2477,7 @@ func (a *App) generateHelpText() string {
 		help.WriteString("🤖 AI FEATURES (✅ Available)\n\n")
 		fmt.Fprintf(&help, "    %-8s  📝  Summarize message\n", a.Keys.Summarize)
 		help.WriteString("    Y         🔄  Regenerate summary (force refresh)\n")
+		fmt.Fprintf(&help, "    %-8s  🎚️  Summary style (one line, bullets, actions, ELI5) — press on the open summary\n", a.Keys.Summarize)
 		fmt.Fprintf(&help, "    %-8s  🎯  Open Prompt Library\n", a.Keys.Prompt)
 		fmt.Fprintf(&help, "    %-8s  🤖  Generate reply draft\n", a.Keys.GenerateReply)
 		fmt.Fprintf(&help, "    %-8s  🔖  AI suggest label\n\n", a.Keys.SuggestLabel)
@@ -2275,10 +2545,13 @@ func (a *App) generateHelpText() string {
 	fmt.Fprintf(&help, "    %-8s  🔗  Link picker (view/open message links)\n", a.Keys.LinkPicker)
 	fmt.Fprintf(&help, "    %-8s  🔎  Advanced search form (in search box)\n", a.Keys.SearchAdvanced)
 	fmt.Fprintf(&help, "    %-8s  🔁  Toggle Gmail/local search (in search box)\n", a.Keys.SearchToggleMode)
+	fmt.Fprintf(&help, "    %-8s  📖  Search operators cheat-sheet; Enter inserts (in search box)\n", a.Keys.SearchCheatSheet)
 	fmt.Fprintf(&help, "    %-8s  👁️   Preview selected prompt (in prompt picker)\n", a.Keys.PromptPreview)
 	fmt.Fprintf(&help, "    %-8s  📋  Copy selected link (in link picker)\n", a.Keys.LinkCopy)
 	fmt.Fprintf(&help, "    %-8s  💾  Save selected attachment as… (in attachments)\n", a.Keys.AttachmentSave)
+	fmt.Fprintf(&help, "    %-8s  👁️  Preview a small text attachment in the content pane (in attachments)\n", a.Keys.AttachmentPreview)
 	fmt.Fprintf(&help, "    %-8s  📨  Send the message (in composition)\n", a.Keys.ComposeSend)
+	fmt.Fprintf(&help, "    %-8s  ↔️  Widen / narrow the open side panel (%s narrows; remembered per panel)\n", a.Keys.PanelWider, a.Keys.PanelNarrower)
 	fmt.Fprintf(&help, "    %-8s  🎨  Theme picker & preview\n", a.Keys.ThemePicker)
 	if a.Config.IsObsidianEnabled() {
 		fmt.Fprintf(&help, "    %-8s  📝  Send to Obsidian (individual files or repopack)\n", a.Keys.Obsidian)
@@ -2308,16 +2581,64 @@ func (a *App) generateHelpText() string {
 	fmt.Fprintf(&help, "    %-18s 📝  Same as :drafts (view drafts)\n", ":dr")
 	fmt.Fprintf(&help, "    %-18s ✏️   Same as :compose (compose new message)\n", ":new")
 	fmt.Fprintf(&help, "    %-18s 🔍  Search for 'term'\n", ":search term")
+	fmt.Fprintf(&help, "    %-18s 📄  Jump to result page N of the current search (skips pages by token only)\n", ":page N")
+	fmt.Fprintf(&help, "    %-18s 👥  Search every configured account; merged results get an Account column\n", ":search --all term")
+	fmt.Fprintf(&help, "    %-18s 📖  Open Gmail search with the operators cheat-sheet (alias :ops)\n", ":operators")
+	fmt.Fprintf(&help, "    %-18s 🏷️  Focus the search refinement chips (d removes a chip)\n", ":refine")
+	fmt.Fprintf(&help, "    %-18s ➕  Add a filter: advanced search prefilled with the current query\n", ":refine add")
 	fmt.Fprintf(&help, "    %-18s 💾  Save current search as bookmark\n", ":save-query")
 	fmt.Fprintf(&help, "    %-18s 📚  Browse saved query bookmarks\n", ":bookmarks")
-	fmt.Fprintf(&help, "    %-18s 🔍  Execute saved query by name\n", ":bookmark name")
+	fmt.Fprintf(&help, "    %-18s ▶️  Replay startup_actions (run after the first inbox load)\n", ":startup")
+	fmt.Fprintf(&help, "    %-18s 👥  Pull the shared prompt/query pack and merge it again (👥 items are read-only)\n", ":pack")
+	fmt.Fprintf(&help, "    %-18s 🔍  Execute saved query by name (templates like from:{sender} ask for values)\n", ":bookmark name")
 	if a.Config.IsObsidianEnabled() {
 		fmt.Fprintf(&help, "    %-18s 📦  Create repopack with selected messages\n", ":obsidian repack")
 		fmt.Fprintf(&help, "    %-18s 📦  Same as :obsidian repack (short alias)\n", ":obs repack")
 	}
+	fmt.Fprintf(&help, "    %-18s 🔗  Toggle link picker page previews (title/description; contacts the sites)\n", ":links preview")
 	fmt.Fprintf(&help, "    %-18s 🎨  Open theme picker\n", ":theme")
 	fmt.Fprintf(&help, "    %-18s 📄  Toggle header visibility\n", ":headers")
 	fmt.Fprintf(&help, "    %-18s 🔢  Toggle message numbers\n", ":numbers")
+	fmt.Fprintf(&help, "    %-18s 🔔  Collapse repeated alerts (alert_groups.rules) into one row each\n", ":alerts [expand|off]")
+	fmt.Fprintf(&help, "    %-18s 💬  Count or archive loaded Google Chat/Meet/Drive notifications\n", ":workspace [archive]")
+	fmt.Fprintf(&help, "    %-18s 📋  Render list rows from a template ({date:>6} {from:20} {subject:*}); off, reset\n", ":rowformat <tmpl>")
+	fmt.Fprintf(&help, "    %-18s 📊  Toggle the list footer: loaded/estimated total, unread, selected, query\n", ":footer [on|off]")
+	fmt.Fprintf(&help, "    %-18s 🗺️  Toggle the scroll indicator with search match marks beside long messages\n", ":minimap [on|off]")
+	fmt.Fprintf(&help, "    %-18s 🎚️  Summarize in a style: oneline, bullets, actions, eli5, explain (each cached)\n", ":summary <style>")
+	fmt.Fprintf(&help, "    %-18s 🌐  Explain the message in simple words in llm.language, with asks and deadlines\n", ":explain")
+	fmt.Fprintf(&help, "    %-18s 🔈  Status messages shown: errors only, normal, or verbose with cache hits\n", ":verbosity <level>")
+	fmt.Fprintf(&help, "    %-18s 📐  Compact (short chips, no snippets, brief headers) or comfortable density\n", ":density [mode]")
+	fmt.Fprintf(&help, "    %-18s ↔️  Widen, narrow or size (percent) the open side panel\n", ":panel <size>")
+	fmt.Fprintf(&help, "    %-18s 💡  Toggle the key hints bar (keys for the list, reader, pickers or composer)\n", ":hints [on|off]")
+	fmt.Fprintf(&help, "    %-18s 🔄  Unsynced read/label changes (⚠/↻ in the list): retry or discard\n", ":sync [retry|discard]")
+	fmt.Fprintf(&help, "    %-18s 🗄️  Save message(s) to a local mbox, then trash them in Gmail\n", ":localarchive")
+	fmt.Fprintf(&help, "    %-18s 🔍  Full-text search of locally archived messages\n", ":la search [terms]")
+	fmt.Fprintf(&help, "    %-18s 🏷️  Label new mail matching a saved query (regex, size+age…); no args lists them\n", ":smartlabel <q> = <label>")
+	fmt.Fprintf(&help, "    %-18s 👥  Recipient groups: typing the name in To/Cc expands it; no args manages them\n", ":groups <n> = <a,b>")
+	fmt.Fprintf(&help, "    %-18s 📌  Edit the note pinned to this conversation (shown when it opens)\n", ":note")
+	fmt.Fprintf(&help, "    %-18s 📌  Pin the AI thread summary (editable), regenerate it or remove the note\n", ":note pin|regen|rm")
+	fmt.Fprintf(&help, "    %-18s ✅  Action items panel: extract them from the message (or thread) with AI, mark done/dismiss, Enter opens the source\n", ":todos [extract]")
+	fmt.Fprintf(&help, "    %-18s 🗓️  Pre-meeting brief of an invite from its details, conversation and attachments\n", ":briefing")
+	fmt.Fprintf(&help, "    %-18s 🗓️  Save the brief as a note in the Obsidian vault\n", ":briefing obsidian")
+	fmt.Fprintf(&help, "    %-18s 👤  Search the contacts index; add saves the previewed vCard, remove drops one\n", ":contacts [add|rm]")
+	fmt.Fprintf(&help, "    %-18s 🕰  Every message exchanged with a contact in order (→ sent, ← received); Enter opens one\n", ":timeline [email]")
+	fmt.Fprintf(&help, "    %-18s 🏷️  Show this sender under a custom name; emoji/color tag it, remove; no args lists\n", ":sender = <name>")
+	fmt.Fprintf(&help, "    %-18s 📊  Weekly email report (labels, senders, reply times); ai adds a narrative\n", ":report [days] [ai]")
+	fmt.Fprintf(&help, "    %-18s 📊  Save the report to the saved folder, or email it to yourself\n", ":report save|email")
+	fmt.Fprintf(&help, "    %-18s 📨  Compose a digest of unread mail to yourself (top senders, AI one-liners)\n", ":digest [noai] [q]")
+	fmt.Fprintf(&help, "    %-18s 🌐  Open the HTML part in the browser; remote images blocked unless 'images'\n", ":html [images]")
+	fmt.Fprintf(&help, "    %-18s 🔒  Preview the message as the AI provider receives it after redaction\n", ":privacy")
+	fmt.Fprintf(&help, "    %-18s 🧾  Show the raw source of the message (useful when it is only partially rendered)\n", ":source")
+	fmt.Fprintf(&help, "    %-18s 🧩  Pick any MIME part (HTML body, inline image, invite, forwarded email) to save\n", ":savepart")
+	fmt.Fprintf(&help, "    %-18s ✉️  Read a message forwarded as an attachment; :nested back returns\n", ":nested [n|back]")
+	fmt.Fprintf(&help, "    %-18s 🧾  Labels before → after of the last bulk label/move job; save exports it\n", ":labeldiff [save]")
+	fmt.Fprintf(&help, "    %-18s ♻️  Move selected/current message(s) out of Trash/Spam to their original labels\n", ":restore")
+	fmt.Fprintf(&help, "    %-18s 🕰️  Inbox as it was on a past date (YYYY-MM-DD, yesterday, last fri, 10d); list, capture\n", ":timemachine <date>")
+	fmt.Fprintf(&help, "    %-18s 🌙  Toggle do-not-disturb (auto follows the configured quiet hours)\n", ":dnd [on|off|auto]")
+	fmt.Fprintf(&help, "    %-18s 🌙  Do not disturb until a time: tomorrow 9am, in 2h, next mon (previewed while typed)\n", ":dnd until <when>")
+	fmt.Fprintf(&help, "    %-18s 📤  Messages queued while offline: send now, discard or clear sent\n", ":outbox [send|clear]")
+	fmt.Fprintf(&help, "    %-18s 📨  Mail merge: preview a template filled per CSV row, send (s), stop (x)\n", ":merge <csv> <tmpl>")
+	fmt.Fprintf(&help, "    %-18s ⏱️  Benchmark list rendering, local filter and invite parsing\n", ":bench")
 	fmt.Fprintf(&help, "    %-18s 📋  Toggle Markdown rendering (alias :md)\n", ":markdown")
 	fmt.Fprintf(&help, "    %-18s 🧾  Toggle AI touch-up of rendered text\n", ":touch-up")
 	fmt.Fprintf(&help, "    %-18s 👤  Open account picker (alias :acc)\n", ":accounts")
@@ -2335,6 +2656,7 @@ func (a *App) generateHelpText() string {
 		fmt.Fprintf(&help, "    %-18s 📄  Switch to flat view\n", ":flatten")
 		fmt.Fprintf(&help, "    %-18s 📤  Expand all threads\n", ":expand-all")
 		fmt.Fprintf(&help, "    %-18s 📥  Same as %s (collapse all threads)\n", ":collapse-all", a.Keys.CollapseAllThreads)
+		fmt.Fprintf(&help, "    %-18s ✂️  Split the conversation where its subject changes topic (again to join)\n", ":thread-split")
 		if a.LLM != nil {
 			fmt.Fprintf(&help, "    %-18s 🧵  Same as %s (generate thread summary)\n", ":thread-summary", a.Keys.ThreadSummary)
 		}
@@ -2355,6 +2677,16 @@ func (a *App) generateHelpText() string {
 	fmt.Fprintf(&help, "    %-18s 📤  Export prompts\n", ":prompt export")
 	fmt.Fprintf(&help, "    %-18s ❓  Show this help\n\n", ":help")
 
+	// Custom commands (custom_commands in config)
+	if names := a.customCommandNames(); len(names) > 0 {
+		help.WriteString("🧩 CUSTOM COMMANDS\n\n")
+		for _, name := range names {
+			def, _ := a.customCommandDefinition(name)
+			fmt.Fprintf(&help, "    %-18s ▶️  %s\n", ":"+name, def)
+		}
+		help.WriteString("\n")
+	}
+
 	// Footer with tips
 	help.WriteString("💡 TIPS\n\n")
 	help.WriteString("    • All shortcuts are configurable in ~/.config/giztui/config.json\n")
@@ -2406,6 +2738,9 @@ func (a *App) Run() error {
 		go a.reloadMessages()
 	}
 
+	// Open the account database alongside the first load instead of before the first draw
+	go a.openAccountDatabase()
+
 	// Notify when the user's config is missing options this version knows about (in the run path
 	// only, so the event loop is live to drain the message — keeping it out of initServices, which
 	// tests exercise, avoids a leaked QueueUpdateDraw goroutine).
@@ -2413,6 +2748,15 @@ func (a *App) Run() error {
 		go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("ℹ %d new config option(s) available — run :config migrate to add them", len(missing)))
 	}
 
+	if reason := localOnlyBlock(a.Config); reason != "" {
+		go a.GetErrorHandler().ShowWarning(a.ctx, "🔒 AI features disabled: "+reason)
+	}
+
+	// Permissions asked for while the UI runs (access.mode) prompt in the status bar instead of
+	// printing over the screen
+	auth.SetConsentPrompt(a.consentPrompt)
+	defer auth.SetConsentPrompt(nil)
+
 	// Start the application
 	return a.Application.Run()
 }
@@ -2897,12 +3241,13 @@ func (a *App) performSearch(query string) {
 		}
 	})
 
+	// A regular search replaces any cross-account results
+	a.crossSearch.reset()
+	a.alerts.reset()
+
 	// Build effective query
 	originalQuery := strings.TrimSpace(query)
-	q := originalQuery
-	if !strings.Contains(q, "in:") && !strings.Contains(q, "label:") {
-		q = q + " -in:sent -in:draft -in:chat -in:spam -in:trash in:inbox"
-	}
+	q := effectiveSearchQuery(originalQuery)
 
 	// Stream search results progresivamente como en la carga inicial
 	messages, next, err := a.Client.SearchMessagesPage(q, 50, "")
@@ -2922,6 +3267,8 @@ func (a *App) performSearch(query string) {
 	a.nextPageToken = next
 	a.search.SetMode("remote")
 	a.search.SetQuery(q)
+	a.search.page = 0
+	a.refreshResultEstimate(q)
 
 	var spinnerStop chan struct{}
 	if _, ok := a.views["list"].(*tview.Table); ok {
@@ -2950,12 +3297,8 @@ func (a *App) performSearch(query string) {
 	}
 
 	// Prepare label map and show system labels in list for search results (mixed scopes)
-	if labels, err := a.Client.ListLabels(); err == nil {
-		m := make(map[string]string, len(labels))
-		for _, l := range labels {
-			m[l.Id] = l.Name
-		}
-		a.emailRenderer.SetLabelMap(m)
+	if labels, err := a.listLabels(); err == nil {
+		a.setRendererLabels(labels)
 	}
 	a.emailRenderer.SetShowSystemLabelsInList(true)
 
@@ -2998,7 +3341,8 @@ func (a *App) performSearch(query string) {
 	}
 	a.QueueUpdateDraw(func() {
 		if table, ok := a.views["list"].(*tview.Table); ok {
-			table.SetTitle(fmt.Sprintf(" 🔍 Search Results (%d) — %s ", len(a.ids), originalQuery))
+			a.showSearchChips(originalQuery)
+			table.SetTitle(a.searchResultsTitle(originalQuery))
 			if table.GetRowCount() > 1 {
 				// Only auto-select if composition panel is not active
 				if a.compositionPanel == nil || !a.compositionPanel.IsVisible() {
@@ -3028,6 +3372,15 @@ func (a *App) performSearch(query string) {
 	})
 }
 
+// effectiveSearchQuery scopes a query without in:/label: to the inbox, excluding sent/draft/chat/spam/trash
+func effectiveSearchQuery(query string) string {
+	q := strings.TrimSpace(query)
+	if !strings.Contains(q, "in:") && !strings.Contains(q, "label:") {
+		q = q + " -in:sent -in:draft -in:chat -in:spam -in:trash in:inbox"
+	}
+	return q
+}
+
 // (moved to status.go) showError/showInfo
 
 // Placeholder methods for functionality that will be implemented later
@@ -3143,6 +3496,7 @@ func (a *App) showCompositionWithStatusBar(compositionType services.CompositionT
 
 	// Add the combined layout as a page
 	a.Pages.AddPage("compose_with_status", compositionLayout, true, true)
+	a.renderKeyHints()
 
 	// Update the status bar now that the page is active
 	if status, ok := a.views["status"].(*tview.TextView); ok {
@@ -3160,6 +3514,7 @@ func (a *App) showCompositionWithDraft(composition *services.Composition) {
 
 	// Add the combined layout as a page
 	a.Pages.AddPage("compose_with_status", compositionLayout, true, true)
+	a.renderKeyHints()
 
 	// Switch to the composition page to make it immediately visible
 	a.Pages.SwitchToPage("compose_with_status")
@@ -3233,6 +3588,7 @@ func (a *App) SetFocus(primitive tview.Primitive) *tview.Application {
 		if primitive == a.compositionPanel.toField ||
 			primitive == a.compositionPanel.ccField ||
 			primitive == a.compositionPanel.bccField ||
+			primitive == a.compositionPanel.labelsField ||
 			primitive == a.compositionPanel.subjectField ||
 			primitive == a.compositionPanel.bodySection {
 			// This is internal composition navigation - allow it
@@ -3313,6 +3669,7 @@ func (a *App) setActivePicker(picker ActivePicker) {
 		a.logger.Printf("Picker state change: %s -> %s", a.currentActivePicker, picker)
 	}
 	a.currentActivePicker = picker
+	a.renderKeyHints()
 }
 
 // Shutdown gracefully shuts down the application services
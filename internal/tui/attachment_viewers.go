@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// renderStructuredPreview renders CSV, calendar and vCard attachments in their own viewers
+// (an aligned table, event details, contact cards); ok is false for other attachments, which
+// are shown as highlighted text
+func (a *App) renderStructuredPreview(p *services.AttachmentPreview) (string, bool) {
+	switch {
+	case p.Table != nil:
+		return a.renderCSVPreview(p.Table), true
+	case len(p.Events) > 0:
+		return a.renderEventsPreview(p.Events), true
+	case len(p.Contacts) > 0:
+		return a.renderContactsPreview(p.Contacts), true
+	}
+	return "", false
+}
+
+// renderCSVPreview lays the table out with aligned columns and a highlighted header
+func (a *App) renderCSVPreview(t *services.CSVTable) string {
+	var b strings.Builder
+	lines := t.Lines(0)
+	for i, line := range lines {
+		switch i {
+		case 0:
+			b.WriteString(a.GetColorTag("header") + tview.Escape(line) + a.GetEndTag())
+		case 1:
+			b.WriteString(a.GetColorTag("secondary") + line + a.GetEndTag())
+		default:
+			b.WriteString(tview.Escape(line))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n%s%d rows × %d columns%s\n", a.GetColorTag("secondary"), len(t.Rows), t.Columns(), a.GetEndTag())
+	return b.String()
+}
+
+// previewField writes a "Label: value" line when value is set
+func (a *App) previewField(b *strings.Builder, label, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s%-10s%s %s\n", a.GetColorTag("secondary"), label, a.GetEndTag(), tview.Escape(value))
+}
+
+// renderEventsPreview shows each event's details: time, place, people and description
+func (a *App) renderEventsPreview(events []services.ICSEvent) string {
+	var b strings.Builder
+	for i, e := range events {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		summary := e.Summary
+		if summary == "" {
+			summary = "(untitled event)"
+		}
+		fmt.Fprintf(&b, "%s📅 %s%s", a.GetColorTag("title"), tview.Escape(summary), a.GetEndTag())
+		if e.Status == "CANCELLED" || e.Method == "CANCEL" {
+			fmt.Fprintf(&b, " %s(cancelled)%s", a.GetColorTag("emphasis"), a.GetEndTag())
+		}
+		b.WriteString("\n")
+		a.previewField(&b, "When:", e.When())
+		a.previewField(&b, "Repeats:", e.Recurrence)
+		a.previewField(&b, "Where:", e.Location)
+		a.previewField(&b, "Organizer:", e.Organizer)
+		for j, at := range e.Attendees {
+			label := ""
+			if j == 0 {
+				label = "Attendees:"
+			}
+			who := at.Email
+			if at.Name != "" && at.Name != at.Email {
+				who = fmt.Sprintf("%s <%s>", at.Name, at.Email)
+			}
+			if at.Status != "" {
+				who += " — " + strings.ToLower(at.Status)
+			}
+			a.previewField(&b, label, who)
+		}
+		a.previewField(&b, "Link:", e.URL)
+		if d := strings.TrimSpace(e.Description); d != "" {
+			b.WriteString("\n" + tview.Escape(d) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// renderContactsPreview shows each vCard as a contact card, followed by how to add them to the
+// contacts index
+func (a *App) renderContactsPreview(cards []services.VCard) string {
+	var b strings.Builder
+	for i, c := range cards {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s👤 %s%s\n", a.GetColorTag("title"), tview.Escape(c.Name), a.GetEndTag())
+		title := c.Title
+		if c.Org != "" {
+			title = strings.TrimPrefix(title+", "+c.Org, ", ")
+		}
+		a.previewField(&b, "", title)
+		for _, e := range c.Emails {
+			a.previewField(&b, "Email:", withFieldType(e))
+		}
+		for _, p := range c.Phones {
+			a.previewField(&b, "Phone:", withFieldType(p))
+		}
+		a.previewField(&b, "Address:", c.Address)
+		a.previewField(&b, "Web:", c.URL)
+		a.previewField(&b, "Birthday:", c.Birthday)
+		if n := strings.TrimSpace(c.Note); n != "" {
+			b.WriteString(tview.Escape(n) + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "\n%s:contacts add — add to the contacts index%s\n", a.GetColorTag("secondary"), a.GetEndTag())
+	return b.String()
+}
+
+func withFieldType(f services.VCardField) string {
+	if f.Type == "" {
+		return f.Value
+	}
+	return fmt.Sprintf("%s (%s)", f.Value, f.Type)
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderStructuredPreview(t *testing.T) {
+	a := &App{}
+
+	_, ok := a.renderStructuredPreview(&services.AttachmentPreview{Filename: "a.txt", Text: "hi"})
+	assert.False(t, ok)
+
+	table, _ := services.ParseCSVTable("a,b\n1,[x]\n", ',')
+	out, ok := a.renderStructuredPreview(&services.AttachmentPreview{Table: table})
+	assert.True(t, ok)
+	assert.Contains(t, out, "1 rows × 2 columns")
+	assert.Contains(t, out, tview.Escape("[x]"))
+
+	out, _ = a.renderStructuredPreview(&services.AttachmentPreview{Events: []services.ICSEvent{{
+		Method:    "CANCEL",
+		Location:  "Room 4",
+		Attendees: []services.ICSAttendee{{Name: "Bob", Email: "bob@example.com", Status: "DECLINED"}},
+	}}})
+	assert.Contains(t, out, "(untitled event)")
+	assert.Contains(t, out, "(cancelled)")
+	assert.Contains(t, out, "Bob <bob@example.com> — declined")
+
+	out, _ = a.renderStructuredPreview(&services.AttachmentPreview{Contacts: []services.VCard{{
+		Name:   "Jane Doe",
+		Title:  "CTO",
+		Org:    "Acme",
+		Emails: []services.VCardField{{Value: "jane@acme.com", Type: "work"}},
+	}}})
+	assert.Contains(t, out, "👤 Jane Doe")
+	assert.Contains(t, out, "CTO, Acme")
+	assert.Contains(t, out, "jane@acme.com (work)")
+	assert.Contains(t, out, ":contacts add")
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 )
@@ -28,6 +30,11 @@ func (a *App) openAttachmentPicker() {
 		a.GetErrorHandler().ShowError(a.ctx, "No message selected")
 		return
 	}
+	// A forwarded message opened with :nested has its attachments inside the parent's source
+	if v := a.currentNestedView(); v != nil {
+		a.openNestedAttachments(v)
+		return
+	}
 
 	// Get attachment service
 	_, _, _, _, _, _, _, _, _, _, attachmentService, _ := a.GetServices()
@@ -187,6 +194,15 @@ func (a *App) openAttachmentPicker() {
 
 			// Allow navigation from input to list
 			input.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+				// Preview the first match inline (configurable; default "ctrl+p")
+				if a.matchesConfiguredKey(e, a.Keys.AttachmentPreview) {
+					if len(visible) > 0 {
+						item := visible[0]
+						a.closeAttachmentPicker()
+						go a.previewAttachment(messageID, services.AttachmentInfo{AttachmentID: item.attachmentID, Filename: item.filename, MimeType: item.mimeType, Size: item.size})
+					}
+					return nil
+				}
 				if e.Key() == tcell.KeyDown || e.Key() == tcell.KeyUp || e.Key() == tcell.KeyPgDn || e.Key() == tcell.KeyPgUp {
 					a.SetFocus(list)
 					return e
@@ -258,7 +274,7 @@ func (a *App) openAttachmentPicker() {
 
 			// Footer with instructions
 			footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
-			footer.SetText(" Enter/1-9 to download | Ctrl+S to save as | Esc to cancel ")
+			footer.SetText(" Enter/1-9 to download | Ctrl+P to preview text | Ctrl+S to save as | Esc to cancel ")
 			footer.SetTextColor(a.GetComponentColors("attachments").Text.Color()) // Standardized footer color
 			footer.SetBackgroundColor(bgColor)
 			container.AddItem(footer, 1, 0, false)
@@ -298,6 +314,16 @@ func (a *App) openAttachmentPicker() {
 					}
 					return nil
 				}
+				// Preview inline (configurable; default "ctrl+p")
+				if a.matchesConfiguredKey(e, a.Keys.AttachmentPreview) {
+					currentItem := list.GetCurrentItem()
+					if currentItem >= 0 && currentItem < len(visible) {
+						item := visible[currentItem]
+						a.closeAttachmentPicker()
+						go a.previewAttachment(messageID, services.AttachmentInfo{AttachmentID: item.attachmentID, Filename: item.filename, MimeType: item.mimeType, Size: item.size})
+					}
+					return nil
+				}
 				// Quick number access
 				if e.Rune() >= '1' && e.Rune() <= '9' {
 					num := int(e.Rune() - '0')
@@ -329,7 +355,7 @@ func (a *App) openAttachmentPicker() {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "attachments")
 			}
 			a.SetFocus(input)
 			a.focus.set("labels") // Reuse labels focus state for consistency
@@ -400,6 +426,55 @@ func (a *App) downloadAndOpenAttachment(messageID, attachmentID, filename, fileT
 	}
 }
 
+// previewAttachment shows a small text attachment in the content pane, highlighted by type, so
+// it can be read without downloading and opening it
+func (a *App) previewAttachment(messageID string, att services.AttachmentInfo) {
+	_, _, _, _, _, _, _, _, _, _, attachmentService, _ := a.GetServices()
+	if attachmentService == nil {
+		a.GetErrorHandler().ShowError(a.ctx, "Attachment service not available")
+		return
+	}
+	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Loading preview: %s", att.Filename))
+	preview, err := attachmentService.PreviewAttachment(a.ctx, messageID, att)
+	a.GetErrorHandler().ClearProgress()
+	if err != nil {
+		a.GetErrorHandler().ShowError(a.ctx, err.Error())
+		return
+	}
+
+	content := a.renderAttachmentPreview(preview)
+	a.QueueUpdateDraw(func() {
+		a.previewedContacts, a.previewedContactsSource = preview.Contacts, preview.Filename
+		if a.enhancedTextView != nil {
+			a.enhancedTextView.SetContent(content)
+			a.enhancedTextView.ScrollToBeginning()
+		}
+		if text, ok := a.views["text"].(*tview.TextView); ok {
+			a.SetFocus(text)
+			a.markFocus("text")
+		}
+	})
+	a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("📎 %s (%s) — reopen the message to return to it", preview.Filename, formatFileSize(int64(preview.Size))))
+}
+
+// renderAttachmentPreview shows CSV, calendar and vCard attachments in their structured viewers
+// and highlights other text through the Markdown renderer (a fenced code block), falling back to
+// the escaped plain text
+func (a *App) renderAttachmentPreview(p *services.AttachmentPreview) string {
+	title := fmt.Sprintf("%s📎 %s%s\n\n", a.GetColorTag("emphasis"), tview.Escape(p.Filename), a.GetEndTag())
+	if out, ok := a.renderStructuredPreview(p); ok {
+		return title + out
+	}
+	theme := ""
+	if a.Config != nil {
+		theme = a.Config.Rendering.GlamourTheme
+	}
+	if out, err := render.MarkdownToTerminal(p.Markdown(), theme, a.getListWidth()); err == nil {
+		return title + out
+	}
+	return title + tview.Escape(p.Text)
+}
+
 // saveAttachmentAs opens input panel for custom save location
 func (a *App) saveAttachmentAs(messageID, attachmentID, filename string) {
 	// Close current attachment picker
@@ -482,7 +557,7 @@ func (a *App) saveAttachmentAs(messageID, attachmentID, filename string) {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "attachments")
 	}
 
 	a.SetFocus(pathInput)
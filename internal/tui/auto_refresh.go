@@ -119,19 +119,53 @@ func (a *App) performAutoRefreshTick() {
 		return
 	}
 
+	// Failed flag changes may have been applied from another client meanwhile
+	if _, failed := a.syncState.counts(); failed > 0 {
+		go a.reconcileSyncChanges()
+	}
+
+	// Messages queued while offline go out once Gmail answers again
+	if a.outboxService != nil {
+		if pending, _ := a.outboxService.Counts(); pending > 0 {
+			go a.flushOutbox()
+		}
+	}
+
 	known := a.GetMessageIDs()
 	newIDs, err := a.autoRefreshService.CheckForNewMessages(a.ctx, known)
 	if err != nil {
 		if a.logger != nil {
 			a.logger.Printf("AUTO_REFRESH: detection error: %v", err)
 		}
+		if isOfflineError(err) && a.outboxService != nil && !a.outboxService.IsOffline() {
+			a.outboxService.SetOffline(true)
+			a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+		}
 		return
 	}
+	if a.outboxService != nil && a.outboxService.IsOffline() {
+		if pending, _ := a.outboxService.Counts(); pending == 0 {
+			a.outboxService.SetOffline(false)
+			a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+		}
+	}
+	if a.dnd.changed(a.dndActive()) {
+		a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+	}
 	if len(newIDs) == 0 {
 		return
 	}
 
+	// Workspace notifications set to "archive" never reach the list
+	if newIDs = a.archiveNewWorkspaceNotifications(newIDs); len(newIDs) == 0 {
+		return
+	}
+
+	// Smart labels first, so the rows loaded below already carry them
+	a.applySmartLabels(newIDs)
+
 	go a.notifyNewMailSlack(newIDs)
+	go a.routeNewMailSlack(newIDs)
 
 	if a.isAutoRefreshSafeState() {
 		a.prependNewMessages(newIDs)
@@ -240,12 +274,14 @@ func (a *App) prependNewMessages(newIDs []string) {
 		a.refreshStatusBar()
 	})
 
-	go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("📬 %d new message(s)", count))
+	if !a.dndActive() {
+		go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("📬 %d new message(s)", count))
+	}
 }
 
 // notifyNewMailSlack posts a Slack notification about newly-detected mail, when enabled.
 func (a *App) notifyNewMailSlack(newIDs []string) {
-	if !a.Config.AutoRefresh.NotifySlack || !a.Config.Slack.Enabled {
+	if !a.Config.AutoRefresh.NotifySlack || !a.Config.Slack.Enabled || a.dndActive() {
 		return
 	}
 	svc := a.GetSlackService()
@@ -265,3 +301,40 @@ func (a *App) notifyNewMailSlack(newIDs []string) {
 		a.GetErrorHandler().ShowWarning(a.ctx, "Slack notify failed: "+err.Error())
 	}
 }
+
+// routeNewMailSlack posts new mail matching slack.routes to the routes' channels. Routes feed
+// shared channels, so quiet hours do not hold them.
+func (a *App) routeNewMailSlack(newIDs []string) {
+	if !a.Config.Slack.Enabled || len(a.Config.Slack.Routes) == 0 || a.Client == nil {
+		return
+	}
+	svc := a.GetSlackService()
+	if svc == nil {
+		return
+	}
+	labels, err := a.listLabels()
+	if err != nil {
+		a.GetErrorHandler().ShowWarning(a.ctx, "Slack routes skipped: "+err.Error())
+		return
+	}
+	names := make(map[string]string, len(labels))
+	for _, l := range labels {
+		names[l.Id] = l.Name
+	}
+	opts := services.SlackRouteOptions{LabelNames: names}
+	if a.gmailWebService != nil {
+		opts.LinkFor = a.gmailWebService.GenerateGmailWebURL
+	}
+	results, err := svc.RouteNewMail(a.ctx, newIDs, opts)
+	for _, r := range results {
+		if a.logger != nil {
+			a.logger.Printf("slack route %q: posted %d, held back %d", r.Route, r.Posted, r.Suppressed)
+		}
+		if r.Posted > 0 {
+			a.GetErrorHandler().ShowDetail(a.ctx, fmt.Sprintf("📤 %s: %d message(s) sent to Slack", r.Route, r.Posted))
+		}
+	}
+	if err != nil {
+		a.GetErrorHandler().ShowWarning(a.ctx, "Slack route failed: "+err.Error())
+	}
+}
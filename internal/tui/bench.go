@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// benchPage is the Pages name of the :bench results overlay
+const benchPage = "bench"
+
+// benchResult is the outcome of one hot-path workload
+type benchResult struct {
+	name      string
+	perOp     time.Duration
+	threshold time.Duration
+	allocs    int64
+}
+
+func (r benchResult) regressed() bool { return r.perOp > r.threshold }
+
+// formatBenchResults renders the results table shown by :bench
+func formatBenchResults(results []benchResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-30s %12s %12s %10s\n", "Benchmark", "per op", "threshold", "allocs")
+	regressions := 0
+	for _, r := range results {
+		mark := "✅"
+		if r.regressed() {
+			mark = "⚠️"
+			regressions++
+		}
+		fmt.Fprintf(&sb, "%-30s %12s %12s %10d  %s\n", r.name, r.perOp.Round(time.Microsecond/10), r.threshold, r.allocs, mark)
+	}
+	sb.WriteString("\n")
+	if regressions == 0 {
+		sb.WriteString("All hot paths are within their thresholds.")
+	} else {
+		fmt.Fprintf(&sb, "%d benchmark(s) over threshold — see docs/TESTING.md.", regressions)
+	}
+	return sb.String()
+}
+
+// executeBenchCommand runs the hot-path benchmarks in the background and shows the results
+func (a *App) executeBenchCommand(args []string) {
+	if len(args) > 0 {
+		a.GetErrorHandler().ShowError(a.ctx, "Usage: :bench")
+		return
+	}
+	if !benchBuilt {
+		a.GetErrorHandler().ShowInfo(a.ctx, "⏱️ :bench needs a build with the bench tag (make build-bench)")
+		return
+	}
+	if a.Pages.HasPage(benchPage) {
+		return
+	}
+	cfg, theme := a.Config, a.currentTheme
+	go func() {
+		results := runHotPathBenchmarks(cfg, theme, func(done, total int, name string) {
+			a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("⏱️ Benchmarking %s (%d/%d)…", name, done+1, total))
+		})
+		a.GetErrorHandler().ClearProgress()
+		a.QueueUpdateDraw(func() { a.showBenchResults(results) })
+	}()
+}
+
+// showBenchResults opens the :bench results overlay; Esc or q closes it
+func (a *App) showBenchResults(results []benchResult) {
+	colors := a.GetComponentColors("general")
+	view := tview.NewTextView().SetDynamicColors(false).SetWrap(false)
+	view.SetText(formatBenchResults(results))
+	view.SetTextColor(colors.Text.Color())
+	view.SetBackgroundColor(colors.Background.Color())
+	view.SetBorder(true).
+		SetTitle(" ⏱️ Benchmarks — Esc to close ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+	view.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape || ev.Rune() == 'q' {
+			a.Pages.RemovePage(benchPage)
+			a.restoreFocusAfterModal()
+			return nil
+		}
+		return ev
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(view, len(results)+5, 0, true).
+			AddItem(nil, 0, 1, false), 76, 0, true).
+		AddItem(nil, 0, 1, false)
+	a.Pages.AddPage(benchPage, overlay, true, true)
+	a.SetFocus(view)
+}
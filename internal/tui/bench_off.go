@@ -0,0 +1,13 @@
+//go:build !bench
+
+package tui
+
+import "github.com/ajramos/giztui/internal/config"
+
+// benchBuilt reports whether :bench can run in this binary; the workloads need -tags bench
+const benchBuilt = false
+
+// runHotPathBenchmarks is a no-op without the bench tag
+func runHotPathBenchmarks(cfg *config.Config, theme *config.ColorsConfig, progress func(done, total int, name string)) []benchResult {
+	return nil
+}
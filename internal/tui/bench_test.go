@@ -0,0 +1,21 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatBenchResults_FlagsRegressions(t *testing.T) {
+	out := formatBenchResults([]benchResult{
+		{name: "fast", perOp: time.Microsecond, threshold: time.Millisecond},
+		{name: "slow", perOp: 2 * time.Millisecond, threshold: time.Millisecond},
+	})
+	if !strings.Contains(out, "1 benchmark(s) over threshold") {
+		t.Fatalf("summary missing regression count:\n%s", out)
+	}
+	lines := strings.Split(out, "\n")
+	if !strings.HasSuffix(lines[1], "✅") || !strings.HasSuffix(lines[2], "⚠️") {
+		t.Fatalf("unexpected marks:\n%s", out)
+	}
+}
@@ -0,0 +1,178 @@
+//go:build bench
+
+package tui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// The hot-path workloads and their fixtures. They link the testing package, so they are only
+// built with -tags bench (make build-bench, make bench); bench_off.go stands in otherwise.
+
+// benchBuilt reports whether :bench can run in this binary
+const benchBuilt = true
+
+// benchCase is one hot-path workload. threshold is the documented per-operation budget
+// (docs/TESTING.md); a result above it is reported as a regression.
+type benchCase struct {
+	name      string
+	threshold time.Duration
+	run       func(b *testing.B)
+}
+
+// benchCases returns the hot-path workloads. The same cases back the Go benchmarks in
+// bench_workloads_test.go and the in-app :bench command, so the thresholds are checked identically.
+func benchCases(cfg *config.Config, theme *config.ColorsConfig) []benchCase {
+	return []benchCase{
+		{name: "FormatEmailList", threshold: 100 * time.Microsecond, run: func(b *testing.B) { benchFormatEmailList(b, cfg) }},
+		{name: "refreshTableDisplay (1k rows)", threshold: 25 * time.Millisecond, run: func(b *testing.B) { benchRefreshTable(b, cfg, theme, 1000) }},
+		{name: "Local filter (10k cached)", threshold: 100 * time.Millisecond, run: func(b *testing.B) { benchLocalFilter(b, 10000) }},
+		{name: "ICS invite parsing", threshold: 100 * time.Microsecond, run: benchICSParse},
+	}
+}
+
+// benchMessages builds n synthetic list messages with realistic headers and labels
+func benchMessages(n int) ([]string, []*gmailapi.Message) {
+	senders := []string{"Ana García <ana@example.com>", "GitHub <notifications@github.com>", "Pepe <pepe@example.org>", "Billing <billing@vendor.example>"}
+	subjects := []string{"Quarterly planning notes", "[org/repo] CI failed: main", "Lunch on Friday?", "Your invoice is ready"}
+	labels := [][]string{{"INBOX", "UNREAD"}, {"INBOX", "CATEGORY_UPDATES"}, {"INBOX", "STARRED", "Label_1"}, {"INBOX", "IMPORTANT", "Label_2"}}
+	base := time.Now().Add(-time.Hour)
+	ids := make([]string, n)
+	meta := make([]*gmailapi.Message, n)
+	for i := 0; i < n; i++ {
+		k := i % len(senders)
+		ids[i] = fmt.Sprintf("m%05d", i)
+		meta[i] = &gmailapi.Message{
+			Id:           ids[i],
+			ThreadId:     fmt.Sprintf("t%05d", i/3),
+			LabelIds:     labels[k],
+			Snippet:      fmt.Sprintf("Message %d about %s, please take a look when you can", i, strings.ToLower(subjects[k])),
+			InternalDate: base.Add(-time.Duration(i) * time.Minute).UnixMilli(),
+			Payload: &gmailapi.MessagePart{
+				MimeType: "multipart/mixed",
+				Headers: []*gmailapi.MessagePartHeader{
+					{Name: "From", Value: senders[k]},
+					{Name: "To", Value: "me@example.com"},
+					{Name: "Subject", Value: fmt.Sprintf("%s #%d", subjects[k], i)},
+					{Name: "Date", Value: base.Add(-time.Duration(i) * time.Minute).Format(time.RFC1123Z)},
+				},
+			},
+		}
+	}
+	return ids, meta
+}
+
+func benchFormatEmailList(b *testing.B, cfg *config.Config) {
+	er := render.NewEmailRenderer(cfg)
+	_, meta := benchMessages(64)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		er.FormatEmailList(meta[i%len(meta)], 120)
+	}
+}
+
+// newBenchApp returns a detached App holding only what the list rendering path reads,
+// so :bench never touches the live table
+func newBenchApp(cfg *config.Config, theme *config.ColorsConfig, n int) *App {
+	if theme == nil {
+		theme = config.DefaultColors()
+	}
+	a := &App{
+		Config:        cfg,
+		views:         map[string]tview.Primitive{"list": tview.NewTable()},
+		emailRenderer: render.NewEmailRenderer(cfg),
+		currentTheme:  theme,
+		bulk:          newBulkState(),
+		layout:        layoutState{currentLayout: LayoutMedium, width: 160, height: 50},
+	}
+	a.emailRenderer.UpdateFromConfig(theme)
+	a.ids, a.messagesMeta = benchMessages(n)
+	return a
+}
+
+func benchRefreshTable(b *testing.B, cfg *config.Config, theme *config.ColorsConfig, n int) {
+	a := newBenchApp(cfg, theme, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.refreshTableDisplay()
+	}
+}
+
+func benchLocalFilter(b *testing.B, n int) {
+	_, meta := benchMessages(n)
+	idToName := map[string]string{"Label_1": "Projects/Atlas", "Label_2": "Finance"}
+	filter := parseLocalFilter("invoice label:finance")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, m := range meta {
+			filter.matches(m, idToName)
+		}
+	}
+}
+
+// benchInvite is a typical Google Calendar REQUEST with folded lines
+const benchInvite = "BEGIN:VCALENDAR\r\nPRODID:-//Google Inc//Google Calendar 70.9054//EN\r\nVERSION:2.0\r\nMETHOD:REQUEST\r\n" +
+	"BEGIN:VTIMEZONE\r\nTZID:Europe/Madrid\r\nEND:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\nDTSTART;TZID=Europe/Madrid:20250818T163000\r\nDTEND;TZID=Europe/Madrid:20250818T173000\r\n" +
+	"DTSTAMP:20250801T090000Z\r\nORGANIZER;CN=Ana García:mailto:ana@example.com\r\nUID:7kukuqrfedlm2f9t0vr42q8j1d@google.com\r\n" +
+	"ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE;CN=me@example.com;X-NUM-GUESTS=0:mailto:me@example.com\r\n" +
+	"DESCRIPTION:Walk through the design doc and agree on the rollout plan. Join with Google Meet: https://meet.google.com/abc-defg-hij\r\n" +
+	" \\n\\nLearn more about Meet at: https://support.google.com/a/users/answer/9282720\r\n" +
+	"LOCATION:Room 4.2\r\nSUMMARY:Design review\r\nSTATUS:CONFIRMED\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func benchICSParse(b *testing.B) {
+	a := &App{}
+	msg := &gmailapi.Message{Id: "ics", Payload: &gmailapi.MessagePart{
+		MimeType: "multipart/alternative",
+		Parts: []*gmailapi.MessagePart{
+			{MimeType: "text/plain", Body: &gmailapi.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("Invitation: Design review"))}},
+			{MimeType: "text/calendar; method=REQUEST", Body: &gmailapi.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte(benchInvite))}},
+		},
+	}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := a.detectCalendarInvite(msg); !ok {
+			b.Fatal("invite not detected")
+		}
+	}
+}
+
+// runHotPathBenchmarks runs the hot-path workloads for :bench
+func runHotPathBenchmarks(cfg *config.Config, theme *config.ColorsConfig, progress func(done, total int, name string)) []benchResult {
+	cases := benchCases(cfg, theme)
+	return runBenchCases(cases, func(done int, name string) {
+		if progress != nil {
+			progress(done, len(cases), name)
+		}
+	})
+}
+
+// runBenchCases runs every case with testing.Benchmark (about a second each)
+func runBenchCases(cases []benchCase, progress func(done int, name string)) []benchResult {
+	results := make([]benchResult, 0, len(cases))
+	for i, c := range cases {
+		if progress != nil {
+			progress(i, c.name)
+		}
+		r := testing.Benchmark(c.run)
+		perOp := time.Duration(0)
+		if r.N > 0 {
+			perOp = r.T / time.Duration(r.N)
+		}
+		results = append(results, benchResult{name: c.name, perOp: perOp, threshold: c.threshold, allocs: r.AllocsPerOp()})
+	}
+	return results
+}
@@ -0,0 +1,36 @@
+//go:build bench
+
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+)
+
+// Baseline thresholds live in benchCases and are documented in docs/TESTING.md.
+// Run with: go test -tags bench ./internal/tui -run '^$' -bench . -benchmem
+
+func BenchmarkFormatEmailList(b *testing.B) {
+	benchFormatEmailList(b, config.DefaultConfig())
+}
+
+func BenchmarkRefreshTableDisplay1k(b *testing.B) {
+	benchRefreshTable(b, config.DefaultConfig(), config.DefaultColors(), 1000)
+}
+
+func BenchmarkLocalFilter10k(b *testing.B) {
+	benchLocalFilter(b, 10000)
+}
+
+func BenchmarkICSParse(b *testing.B) {
+	benchICSParse(b)
+}
+
+func TestBenchRefreshTable_RendersEveryRow(t *testing.T) {
+	a := newBenchApp(config.DefaultConfig(), nil, 50)
+	a.refreshTableDisplay()
+	if got := a.views["list"].(interface{ GetRowCount() int }).GetRowCount(); got != 51 {
+		t.Fatalf("rows = %d, want 50 messages + header", got)
+	}
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"time"
+
+	calclient "github.com/ajramos/giztui/internal/calendar"
+	"github.com/ajramos/giztui/internal/llm"
+)
+
+// Startup runs in stages so the UI shell draws before the slow parts finish: main attaches the
+// Calendar client and the LLM provider when their initialization completes, and Run opens the
+// account database in the background while the inbox loads. Those goroutines finish in any
+// order, so each holds uiLifecycle.wiring while it wires services, as the account switch does.
+
+// markBoot records a finished startup step and logs the elapsed time; the first inbox load
+// starts the startup actions
+func (a *App) markBoot(step string) {
+	at, first := a.uiLifecycle.boot.mark(step, time.Now())
+	if first && step == "inbox" {
+		go a.runStartupActions()
+	}
+	if !first || a.logger == nil {
+		return
+	}
+	a.logger.Printf("startup: %s finished after %s", step, at.Round(time.Millisecond))
+	if step == "inbox" {
+		a.logger.Printf("startup: %s", a.uiLifecycle.boot.summary())
+	}
+}
+
+// AttachCalendar installs the Calendar client once it finishes initializing after startup. Like
+// the account switch it runs on a background goroutine.
+func (a *App) AttachCalendar(c *calclient.Client) {
+	if c == nil {
+		return
+	}
+	a.uiLifecycle.wiring.Lock()
+	if a.Calendar == nil {
+		a.Calendar = c
+	}
+	a.uiLifecycle.wiring.Unlock()
+	a.markBoot("calendar")
+}
+
+// AttachLLM installs the LLM provider once it finishes initializing after startup; the data
+// policies (local_only, privacy) apply as they do to a provider passed to NewApp
+func (a *App) AttachLLM(p llm.Provider) {
+	if p == nil {
+		return
+	}
+	a.uiLifecycle.wiring.Lock()
+	if a.LLM != nil {
+		a.uiLifecycle.wiring.Unlock()
+		return
+	}
+	if a.LLM = guardLLM(a.Config, p, a.logger); a.LLM != nil {
+		a.reinitializeServices()
+	}
+	a.uiLifecycle.wiring.Unlock()
+	a.markBoot("ai")
+}
+
+// openAccountDatabase opens the active account's database and wires the services that store
+// data locally
+func (a *App) openAccountDatabase() {
+	defer a.markBoot("database") // also on failure: nothing waits for a database that won't open
+	if !a.attachAccountDatabase() {
+		return
+	}
+	// The first inbox load may have finished before the database was ready
+	if a.search.Query() == "" {
+		a.captureInboxSnapshot(false)
+	}
+}
+
+// attachAccountDatabase opens and registers the active account's database unless an account
+// switch during startup already opened one; it reports whether it registered a store
+func (a *App) attachAccountDatabase() bool {
+	if a.databaseManager == nil || a.accountService == nil {
+		return false
+	}
+	a.uiLifecycle.wiring.Lock()
+	defer a.uiLifecycle.wiring.Unlock()
+	// An account switch during startup opens its own database; leave it in place
+	if a.dbStore != nil {
+		return false
+	}
+	activeAccount, err := a.accountService.GetActiveAccount(a.ctx)
+	if err != nil || activeAccount.Email == "" {
+		if a.logger != nil {
+			a.logger.Printf("openAccountDatabase: no active account found for database initialization")
+		}
+		return false
+	}
+	if err := a.databaseManager.SwitchToAccountDatabase(a.ctx, activeAccount.Email); err != nil {
+		if a.logger != nil {
+			a.logger.Printf("openAccountDatabase: failed to initialize database for account %s: %v", activeAccount.Email, err)
+		}
+		return false
+	}
+	newStore := a.databaseManager.GetCurrentStore()
+	if newStore == nil {
+		if a.logger != nil {
+			a.logger.Printf("openAccountDatabase: WARNING - database manager returned nil store after successful switch")
+		}
+		return false
+	}
+	a.RegisterDBStore(newStore)
+	return true
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+)
+
+type bootAccounts struct {
+	services.AccountService
+	email string
+}
+
+func (b *bootAccounts) GetActiveAccount(context.Context) (*services.Account, error) {
+	return &services.Account{ID: "work", Email: b.email}, nil
+}
+
+// bootDatabases opens one store per account, counting the switches; opened runs when the store
+// is handed out
+type bootDatabases struct {
+	services.DatabaseManager
+	dir      string
+	opened   func()
+	mu       sync.Mutex
+	store    *db.Store
+	switches int
+}
+
+func (b *bootDatabases) SwitchToAccountDatabase(ctx context.Context, email string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.switches++
+	store, err := db.Open(ctx, filepath.Join(b.dir, email+".db"))
+	if err != nil {
+		return err
+	}
+	b.store = store
+	return nil
+}
+
+func (b *bootDatabases) GetCurrentStore() *db.Store {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.opened != nil {
+		b.opened()
+	}
+	return b.store
+}
+
+// Run with -race: the LLM and the account database attach on their own goroutines. Opening the
+// database takes a while, so the LLM is attached just as the store is about to be registered.
+func TestStartupAttachment_LLMAndDatabaseConcurrently(t *testing.T) {
+	cfg := config.DefaultConfig()
+	dbs := &bootDatabases{dir: t.TempDir()}
+	a := &App{Config: cfg, ctx: context.Background(), accountService: &bootAccounts{email: "me@example.com"}, databaseManager: dbs}
+	a.errorHandler = NewErrorHandler(nil, nil, nil, nil, nil)
+	t.Cleanup(func() { _ = dbs.store.Close() })
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	dbs.opened = func() {
+		dbs.opened = nil
+		go func() { defer wg.Done(); a.AttachLLM(&echoProvider{}) }()
+	}
+	go func() { defer wg.Done(); a.openAccountDatabase() }()
+	wg.Wait()
+
+	if a.LLM == nil || a.dbStore == nil {
+		t.Fatalf("not attached: LLM=%v dbStore=%v", a.LLM, a.dbStore)
+	}
+	if a.cacheService == nil || a.aiService == nil || a.promptService == nil {
+		t.Fatalf("services not wired: cache=%v ai=%v prompt=%v", a.cacheService, a.aiService, a.promptService)
+	}
+
+	// A database already registered (by an account switch) is left in place
+	a.openAccountDatabase()
+	if dbs.switches != 1 {
+		t.Fatalf("database switched %d times, want 1", dbs.switches)
+	}
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// meetingBriefingService builds the briefing service over the current client and services; nil
+// when the client or the AI is not available
+func (a *App) meetingBriefingService() services.MeetingBriefingService {
+	if a.Client == nil || a.aiService == nil {
+		return nil
+	}
+	return services.NewMeetingBriefingService(a.Client, a.getThreadService(), a.attachmentService, a.aiService)
+}
+
+// executeBriefingCommand handles :briefing [obsidian|refresh] — show the pre-meeting brief of the
+// selected invite, or export it to Obsidian (generating it first when needed)
+func (a *App) executeBriefingCommand(args []string) {
+	action := ""
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+	switch action {
+	case "", "refresh":
+		a.generateBriefing(action == "refresh", false)
+	case "obsidian", "save", "export":
+		a.generateBriefing(false, true)
+	default:
+		a.showError("Usage: briefing [obsidian|refresh]")
+	}
+}
+
+// generateBriefing shows the brief of the selected invite, reusing the last one for the same
+// message unless refresh is set; export also saves it to Obsidian
+func (a *App) generateBriefing(refresh, export bool) {
+	id := a.getCurrentSelectedMessageID()
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	if export && a.obsidianService == nil {
+		a.showError("Obsidian not available (no local database)")
+		return
+	}
+	if b := a.lastBriefing; b != nil && b.MessageID == id && !refresh {
+		a.showBriefing(b)
+		if export {
+			go a.exportBriefing(b)
+		}
+		return
+	}
+	svc := a.meetingBriefingService()
+	if svc == nil {
+		a.showError(a.aiUnavailableText("prepare briefings"))
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowProgress(a.ctx, "🗓️ Preparing meeting briefing…")
+		b, err := svc.Generate(a.ctx, id)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error preparing briefing", err)
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			a.lastBriefing = b
+			a.showBriefing(b)
+		})
+		if export {
+			a.exportBriefing(b)
+			return
+		}
+		a.GetErrorHandler().ShowInfo(a.ctx, "🗓️ Briefing ready — :briefing obsidian exports it, reopen the message to return to it")
+	}()
+}
+
+// showBriefing renders a briefing in the content pane
+func (a *App) showBriefing(b *services.MeetingBriefing) {
+	theme := ""
+	if a.Config != nil {
+		theme = a.Config.Rendering.GlamourTheme
+	}
+	content, err := render.MarkdownToTerminal(b.Markdown(), theme, a.getListWidth())
+	if err != nil {
+		content = tview.Escape(b.Markdown())
+	}
+	if a.enhancedTextView != nil {
+		a.enhancedTextView.SetContent(content)
+		a.enhancedTextView.ScrollToBeginning()
+	}
+	if text, ok := a.views["text"].(*tview.TextView); ok {
+		a.SetFocus(text)
+		a.markFocus("text")
+	}
+}
+
+// exportBriefing saves a briefing as a note in the Obsidian vault
+func (a *App) exportBriefing(b *services.MeetingBriefing) {
+	path, err := a.obsidianService.SaveNote(a.ctx, b.Title(), b.Markdown())
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error exporting briefing to Obsidian", err)
+		return
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("📝 Briefing saved to %s", path))
+}
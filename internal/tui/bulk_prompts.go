@@ -294,7 +294,7 @@ func (a *App) openBulkPromptPicker() {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "prompts")
 	}
 	a.SetFocus(input)
 	a.markFocus("prompts")
@@ -406,7 +406,7 @@ func (a *App) applyBulkPrompt(promptID int, promptName string) {
 		// Show AI panel manually to avoid potential issues with toggleAISummary
 		if !a.aiPanel.visible.Load() {
 			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-				split.ResizeItem(a.aiSummaryView, 0, 1)
+				a.showSidePanel(split, a.aiSummaryView, "prompts")
 			}
 			a.aiPanel.visible.Store(true)
 		}
@@ -439,7 +439,7 @@ func (a *App) applyBulkPrompt(promptID int, promptName string) {
 
 	// Apply bulk prompt using the dedicated bulk prompt service
 	go func() {
-		var resultBuilder strings.Builder
+		stream := a.newAIStream()
 
 		ctx, cancel := context.WithCancel(a.ctx)
 		a.aiPanel.setStreamingCancel(cancel) // Store cancel function for Esc handler
@@ -470,20 +470,17 @@ func (a *App) applyBulkPrompt(promptID int, promptName string) {
 			default:
 			}
 
-			resultBuilder.WriteString(token)
-			currentText := resultBuilder.String()
+			// Format the streaming result
+			formattedResult := fmt.Sprintf("🤖 Bulk Prompt Result: %s\n\n", promptName)
+			formattedResult += fmt.Sprintf("📊 Messages Processed: %d\n", messageCount)
+			formattedResult += "⏰ Processing... 🔄\n"
+			formattedResult += "📝 Analysis (streaming):\n"
+			currentText := stream.write(token, formattedResult)
 
 			// CRITICAL: NEVER use QueueUpdateDraw in streaming callbacks
 			// Direct UI update to prevent deadlock with ESC handler
 			if ctx.Err() == nil && a.aiSummaryView != nil {
-				// Format the streaming result
-				formattedResult := fmt.Sprintf("🤖 Bulk Prompt Result: %s\n\n", promptName)
-				formattedResult += fmt.Sprintf("📊 Messages Processed: %d\n", messageCount)
-				formattedResult += "⏰ Processing... 🔄\n"
-				formattedResult += "📝 Analysis (streaming):\n"
-				formattedResult += currentText
-
-				a.aiSummaryView.SetText(formattedResult)
+				a.aiSummaryView.SetText(currentText)
 				a.aiSummaryView.ScrollToEnd()
 			}
 		})
@@ -509,6 +506,7 @@ func (a *App) applyBulkPrompt(promptID int, promptName string) {
 				metaHeader += fmt.Sprintf("💾 From Cache: %v\n\n", result.FromCache)
 				metaHeader += "📝 Analysis:\n"
 				formattedResult := metaHeader + a.renderPromptResult(result.Summary)
+				a.aiPanel.setSource(result.Summary)
 
 				a.aiSummaryView.SetText(formattedResult)
 				a.aiSummaryView.ScrollToBeginning()
@@ -31,6 +31,18 @@ func newAppCaches() *appCaches {
 	}
 }
 
+// reset drops every cached entry. All caches are keyed by message ID of the active account, so
+// this runs on account switch to keep one account's data out of the other's views.
+func (c *appCaches) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.message = make(map[string]*gmail.Message)
+	c.render = make(map[string]string)
+	c.invite = make(map[string]Invite)
+	c.aiInFlight = make(map[string]bool)
+	c.aiLabels = make(map[string][]string)
+}
+
 // --- AI label-suggestion cache -------------------------------------------
 
 func (c *appCaches) aiLabelsGet(id string) ([]string, bool) {
@@ -61,6 +73,26 @@ func (c *appCaches) messageSet(id string, msg *gmail.Message) {
 	c.message[id] = msg
 }
 
+// messageEvictOneIfFull stores id=msg, first evicting an arbitrary entry when the cache is at or
+// above max and id is new (same semantics as renderEvictOneIfFull). max <= 0 means unbounded.
+func (c *appCaches) messageEvictOneIfFull(id string, msg *gmail.Message, max int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.message[id]; !exists && max > 0 && len(c.message) >= max {
+		for k := range c.message {
+			delete(c.message, k)
+			break
+		}
+	}
+	c.message[id] = msg
+}
+
+func (c *appCaches) messageLen() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.message)
+}
+
 // --- render cache --------------------------------------------------------
 
 func (c *appCaches) renderGet(key string) (string, bool) {
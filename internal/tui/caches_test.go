@@ -131,3 +131,37 @@ func TestAppCachesAIInFlightCancelFirst(t *testing.T) {
 		t.Fatalf("expected in-flight 'a' to be inactive after cancel")
 	}
 }
+
+func TestAppCachesMessageBounded(t *testing.T) {
+	c := newAppCaches()
+	for i := 0; i < 10; i++ {
+		c.messageEvictOneIfFull(string(rune('a'+i)), &gmail.Message{}, 4)
+	}
+	if got := c.messageLen(); got != 4 {
+		t.Fatalf("message cache holds %d entries, want 4", got)
+	}
+	if _, ok := c.messageGet("j"); !ok {
+		t.Fatalf("most recent entry must be kept")
+	}
+}
+
+func TestAppCachesResetDropsAccountData(t *testing.T) {
+	c := newAppCaches()
+	c.messageSet("m", &gmail.Message{})
+	c.renderSet("r", "body")
+	c.inviteSet("i", Invite{UID: "u"})
+	c.aiInFlightSet("a")
+	c.aiLabelsSet("l", []string{"x"})
+
+	c.reset()
+
+	if _, ok := c.messageGet("m"); ok {
+		t.Error("message cache not cleared")
+	}
+	if c.renderLen() != 0 || c.inviteLen() != 0 || c.aiInFlightHas("a") {
+		t.Error("render/invite/in-flight caches not cleared")
+	}
+	if _, ok := c.aiLabelsGet("l"); ok {
+		t.Error("AI label cache not cleared")
+	}
+}
@@ -44,16 +44,8 @@ func (a *App) configureTableForMode(table *tview.Table, mode render.DisplayMode)
 	table.Clear()
 
 	// Reapply table theming (necessary after Clear())
+	a.styleListTable(table)
 	generalColors := a.GetComponentColors("general")
-	table.SetBackgroundColor(generalColors.Background.Color())
-	table.SetBorderColor(generalColors.Border.Color())
-	table.SetTitleColor(generalColors.Title.Color())
-
-	// Set table properties
-	table.SetBorders(false).
-		SetSeparator('│').
-		SetFixed(1, 0).            // Fix header row
-		SetSelectable(true, false) // Allow row selection only
 
 	// Create and populate header row
 	for col, columnConfig := range config {
@@ -74,6 +66,21 @@ func (a *App) configureTableForMode(table *tview.Table, mode render.DisplayMode)
 	}
 }
 
+// styleListTable applies the list's theming and table properties
+func (a *App) styleListTable(table *tview.Table) {
+	generalColors := a.GetComponentColors("general")
+	table.SetBackgroundColor(generalColors.Background.Color())
+	table.SetBorderColor(generalColors.Border.Color())
+	table.SetTitleColor(generalColors.Title.Color())
+
+	// Set table properties
+	table.SetBorders(false).
+		SetSeparator('│').
+		SetFixed(1, 0).            // Fix header row
+		SetSelectable(true, false) // Allow row selection only
+	a.applyListDensity(table)
+}
+
 // ResponsiveBreakpoint represents different screen size categories
 type ResponsiveBreakpoint int
 
@@ -122,6 +129,7 @@ func (a *App) getResponsiveFlatConfig(breakpoint ResponsiveBreakpoint, available
 	labelsMaxWidth := 16      // Maximum width for labels column
 	attachmentFixedWidth := 2 // Fixed width for attachment column (📎)
 	calendarFixedWidth := 2   // Fixed width for calendar column (📅)
+	accountFixedWidth := 12   // Fixed width for account column (cross-account search only)
 	dateMinWidth := 8
 	numbersWidth := 0
 
@@ -140,6 +148,14 @@ func (a *App) getResponsiveFlatConfig(breakpoint ResponsiveBreakpoint, available
 		config = append(config, numbersColumn)
 	}
 
+	// A row format template renders the whole row in one column
+	if a.rowFormat != nil {
+		config = append(config, render.ColumnConfig{
+			Header: a.rowFormat.Header(a.rowFormatWidth()), Alignment: tview.AlignLeft, Expansion: 1,
+		})
+		return config
+	}
+
 	// Always include flags column (highest priority) - fixed width
 	flagsColumn := render.ColumnConfig{
 		Header:    "",
@@ -154,6 +170,15 @@ func (a *App) getResponsiveFlatConfig(breakpoint ResponsiveBreakpoint, available
 	usedWidth := numbersWidth + flagsFixedWidth + 2 // +2 for separators
 	remainingWidth := availableWidth - usedWidth
 
+	// Cross-account search results get an Account column right after the flags
+	if a.crossSearch.isActive() && breakpoint != BreakpointVeryNarrow {
+		config = append(config, render.ColumnConfig{
+			Header: "Account", Alignment: tview.AlignLeft, Expansion: 0,
+			MaxWidth: accountFixedWidth, MinWidth: accountFixedWidth,
+		})
+		remainingWidth -= accountFixedWidth + 1 // +1 for separator
+	}
+
 	// Responsive column inclusion based on breakpoint and available space
 	switch breakpoint {
 	case BreakpointVeryNarrow:
@@ -514,6 +539,7 @@ func (a *App) mapEmailDataToResponsiveColumns(emailData render.EmailColumnData,
 		SRC_ATTACHMENT = 4 // Updated index
 		SRC_CALENDAR   = 5 // Updated index
 		SRC_DATE       = 6 // Updated index
+		SRC_ROW        = 7 // Row format template, appended by populateFlatRows
 	)
 
 	// Determine if numbers column is present in config (always first if present)
@@ -535,6 +561,18 @@ func (a *App) mapEmailDataToResponsiveColumns(emailData render.EmailColumnData,
 		configIndex++
 	}
 
+	// Row format template: one column holding the rendered row (loading rows show their subject)
+	if a.rowFormat != nil && configIndex < len(config) {
+		cell := render.ColumnCell{Alignment: tview.AlignLeft}
+		if len(emailData.Columns) > SRC_ROW {
+			cell = emailData.Columns[SRC_ROW]
+		} else if len(emailData.Columns) > SRC_SUBJECT {
+			cell.Content = emailData.Columns[SRC_SUBJECT].Content
+		}
+		mappedColumns[configIndex] = cell
+		return mappedColumns
+	}
+
 	// Track which empty-header columns we've seen (flags, then attachment, then calendar)
 	flagsColumnSeen := false
 	attachmentColumnSeen := false
@@ -581,6 +619,13 @@ func (a *App) mapEmailDataToResponsiveColumns(emailData render.EmailColumnData,
 			if len(emailData.Columns) > SRC_DATE {
 				mappedColumns[configIndex] = emailData.Columns[SRC_DATE]
 			}
+		case "Account":
+			if rowIndex >= 0 && rowIndex < len(a.ids) {
+				mappedColumns[configIndex] = render.ColumnCell{
+					Content:   a.crossSearch.accountLabel(a.ids[rowIndex]),
+					Alignment: tview.AlignLeft,
+				}
+			}
 		}
 
 		// Apply responsive column configuration overrides
@@ -789,19 +834,27 @@ func (a *App) refreshTableDisplay() {
 
 	mode := a.getCurrentDisplayMode()
 
-	// Configure table structure for current mode
-	a.configureTableForMode(table, mode)
+	// Render into a staging table and copy over only the cells that changed, so untouched rows
+	// keep their cells and the selection and scroll position stay where they are
+	staging := tview.NewTable()
+	staging.Select(table.GetSelection())
+	a.configureTableForMode(staging, mode)
 
 	// Populate rows based on mode
 	switch mode {
 	case render.ModeFlatList:
-		a.populateFlatRows(table)
+		a.populateFlatRows(staging)
 	case render.ModeThreaded:
-		a.populateThreadedRows(table)
+		a.populateThreadedRows(staging)
 	}
 
 	// Apply bulk mode styling if active
-	a.applyBulkModeStyle(table)
+	a.applyBulkModeStyle(staging)
+
+	a.styleListTable(table)
+	syncTableCells(table, staging)
+
+	a.renderListFooter()
 }
 
 // populateFlatRows populates the table with flat message list data
@@ -827,6 +880,7 @@ func (a *App) populateFlatRows(table *tview.Table) {
 		}
 
 		msg := a.messagesMeta[i]
+		a.syncState.overlay(msg) // keep unsynced local changes visible after a reload
 		columnData := a.emailRenderer.FormatFlatMessageColumns(msg)
 
 		// Enhance flags column with bulk mode, preserving original status flags
@@ -834,6 +888,16 @@ func (a *App) populateFlatRows(table *tview.Table) {
 		originalFlags := columnData.Columns[0].Content
 		flags := a.buildEnhancedFlags(msg, i, originalFlags)
 		columnData.Columns[0].Content = flags
+		columnData.Columns[2].Content = a.alertSubject(msg.Id, columnData.Columns[2].Content)
+		a.applyRowDensity(msg, &columnData)
+		if f := a.rowFormat; f != nil {
+			values := a.emailRenderer.RowFields(msg)
+			values["flags"] = flags
+			values["subject"] = a.alertSubject(msg.Id, values["subject"])
+			columnData.Columns = append(columnData.Columns, render.ColumnCell{
+				Content: f.Render(values, a.rowFormatWidth()), Alignment: tview.AlignLeft,
+			})
+		}
 
 		// Apply bulk mode styling if this message is selected
 		if a.bulk.isMode() && a.bulk.isSelected(a.ids[i]) {
@@ -869,6 +933,11 @@ func (a *App) populateFlatRows(table *tview.Table) {
 func (a *App) buildEnhancedFlags(msg *gmailapi.Message, index int, originalFlags string) string {
 	var flags strings.Builder
 
+	// Unsynced flag changes: ⚠ failed, ↻ waiting for Gmail
+	if msg != nil {
+		flags.WriteString(a.syncState.indicator(msg.Id))
+	}
+
 	// Add bulk mode checkbox, but preserve original status flags
 	if a.bulk.isMode() {
 		if a.bulk.isSelected(a.ids[index]) {
@@ -1133,17 +1202,10 @@ func (a *App) populateThreadedRows(table *tview.Table) {
 				a.populateTableRow(table, rowIndex, errorData)
 				rowIndex++
 			} else {
-				// Add individual message rows with tree structure
+				// Add individual message rows with tree structure, one branch per topic when split
+				prefixes := threadTreePrefixes(len(messages), a.threadSubjectDrift(messages), a.isThreadSplit(thread.ThreadID))
 				for msgIndex, message := range messages {
-					// Determine tree prefix - using more visible markers for testing
-					var treePrefix string
-					if msgIndex == len(messages)-1 {
-						treePrefix = " └> " // Last message - more visible
-					} else {
-						treePrefix = " ├> " // Intermediate message - more visible
-					}
-
-					messageData := a.FormatThreadMessageColumns(message, treePrefix)
+					messageData := a.FormatThreadMessageColumns(message, prefixes[msgIndex])
 					a.populateTableRow(table, rowIndex, messageData)
 					rowIndex++
 				}
@@ -3,6 +3,8 @@ package tui
 import (
 	"sort"
 	"strings"
+
+	"github.com/ajramos/giztui/internal/render"
 )
 
 // argCompleter returns full-replacement candidates for the argument text `rest` (everything the user
@@ -25,13 +27,16 @@ type commandSpec struct {
 // commands.go. Adding a command here is all that's needed for it to autocomplete.
 var commandRegistry = []commandSpec{
 	{name: "labels", aliases: []string{"l"}, completeArg: completeLabelsArg},
-	{name: "links", aliases: []string{"link"}},
+	{name: "links", aliases: []string{"link"}, completeArg: completeLinksArg},
 	{name: "attachments", aliases: []string{"attach"}},
 	{name: "gmail", aliases: []string{"web", "open-web", "o"}},
 	{name: "search", completeArg: completeSearchArg},
+	{name: "operators", aliases: []string{"ops"}},
+	{name: "refine", completeArg: completeRefineArg},
 	{name: "slack", aliases: []string{"sl"}},
 	{name: "s"},
-	{name: "summary"},
+	{name: "summary", completeArg: completeSummaryArg},
+	{name: "explain"},
 	{name: "rsvp"},
 	{name: "inbox", aliases: []string{"i"}},
 	{name: "compose", aliases: []string{"c"}},
@@ -41,8 +46,45 @@ var commandRegistry = []commandSpec{
 	{name: "thread-summary", aliases: []string{"th-sum"}},
 	{name: "expand-all", aliases: []string{"expand"}},
 	{name: "collapse-all", aliases: []string{"collapse"}},
+	{name: "thread-split", aliases: []string{"th-split"}},
 	{name: "help", aliases: []string{"h"}},
 	{name: "numbers", aliases: []string{"n"}},
+	{name: "footer", completeArg: completeFooterArg},
+	{name: "hints", completeArg: completeFooterArg},
+	{name: "minimap", completeArg: completeFooterArg},
+	{name: "verbosity", completeArg: completeVerbosityArg},
+	{name: "density", completeArg: completeDensityArg},
+	{name: "panel", completeArg: completePanelArg},
+	{name: "startup"},
+	{name: "pack"},
+	{name: "sync", completeArg: completeSyncArg},
+	{name: "bench"},
+	{name: "outbox", completeArg: completeOutboxArg},
+	{name: "merge", aliases: []string{"mailmerge"}, completeArg: completeMergeArg},
+	{name: "localarchive", aliases: []string{"la"}, completeArg: completeLocalArchiveArg},
+	{name: "smartlabel", aliases: []string{"sml"}, completeArg: completeSmartLabelArg},
+	{name: "groups", aliases: []string{"group"}, completeArg: completeGroupsArg},
+	{name: "note", completeArg: completeThreadNoteArg},
+	{name: "contacts", completeArg: completeContactsArg},
+	{name: "timeline", completeArg: completeTimelineArg},
+	{name: "sender", completeArg: completeSenderArg},
+	{name: "todos", aliases: []string{"todo"}, completeArg: completeTodosArg},
+	{name: "briefing", aliases: []string{"brief"}, completeArg: completeBriefingArg},
+	{name: "report", completeArg: completeReportArg},
+	{name: "digest", completeArg: completeDigestArg},
+	{name: "html", completeArg: completeHTMLArg},
+	{name: "privacy"},
+	{name: "source", aliases: []string{"raw"}},
+	{name: "savepart", aliases: []string{"parts"}},
+	{name: "nested", aliases: []string{"forwarded"}, completeArg: completeNestedArg},
+	{name: "labeldiff", aliases: []string{"bulkreport"}, completeArg: completeLabelDiffArg},
+	{name: "restore", aliases: []string{"untrash"}},
+	{name: "alerts", completeArg: completeAlertsArg},
+	{name: "workspace", aliases: []string{"gnotif"}, completeArg: completeWorkspaceArg},
+	{name: "rowformat", aliases: []string{"rf"}, completeArg: completeRowFormatArg},
+	{name: "timemachine", aliases: []string{"tm"}, completeArg: completeTimeMachineArg},
+	{name: "dnd", completeArg: completeDNDArg},
+	{name: "page"},
 	{name: "quit", aliases: []string{"q"}},
 	{name: "cache"},
 	{name: "preload", aliases: []string{"pl"}},
@@ -151,6 +193,12 @@ func (a *App) commandCandidates(text string) []string {
 			out = append(out, s.name)
 		}
 	}
+	for _, name := range a.customCommandNames() {
+		if strings.HasPrefix(name, lower) && !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
 	if len(out) == 0 {
 		return nil
 	}
@@ -221,6 +269,9 @@ func withHead(head string, matches []string) []string {
 // completeSearchArg completes the current token with a Gmail search operator, at any position.
 func completeSearchArg(a *App, rest string) []string {
 	head, prefix := splitLastToken(rest)
+	if head == "" && strings.HasPrefix(prefix, "-") {
+		return withHead(head, filterByPrefix([]string{"--all"}, prefix))
+	}
 	return withHead(head, filterByPrefix(gmailSearchOperators, prefix))
 }
 
@@ -248,15 +299,33 @@ func completePromptArg(a *App, rest string) []string {
 	return withHead("", filterByPrefix([]string{"create", "delete", "export", "list", "stats", "update"}, prefix))
 }
 
+// completeNestedArg: ':nested [n|back]'. First token → back
+func completeNestedArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head != "" {
+		return nil
+	}
+	return withHead("", filterByPrefix([]string{"back"}, prefix))
+}
+
+// completeLabelDiffArg: ':labeldiff [save [path]]'. First token → save
+func completeLabelDiffArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head != "" {
+		return nil
+	}
+	return withHead("", filterByPrefix([]string{"save"}, prefix))
+}
+
 // completeThemeArg: ':theme <subcommand> [name]'. First token → list/preview/set; after set/preview
 // → a theme name (from the pre-fetched a.cmd.themeNames).
 func completeThemeArg(a *App, rest string) []string {
 	head, prefix := splitLastToken(rest)
 	if head == "" {
-		return withHead("", filterByPrefix([]string{"list", "preview", "set"}, prefix))
+		return withHead("", filterByPrefix([]string{"compare", "list", "preview", "set"}, prefix))
 	}
 	switch firstToken(rest) {
-	case "set", "preview":
+	case "set", "preview", "compare":
 		return withHead(head, filterByPrefix(a.cmd.themeNames, prefix))
 	}
 	return nil
@@ -274,6 +343,299 @@ func completeAccountsArg(a *App, rest string) []string {
 	return nil
 }
 
+// completeLinksArg: ':links preview [on|off]'.
+func completeLinksArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"preview"}, prefix))
+	}
+	if firstToken(rest) == "preview" && strings.Count(head, " ") == 1 {
+		return withHead(head, filterByPrefix([]string{"off", "on"}, prefix))
+	}
+	return nil
+}
+
+// completeRefineArg: ':refine add'.
+func completeRefineArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"add"}, prefix))
+	}
+	return nil
+}
+
+// completeFooterArg: ':footer on|off' (also used by :hints).
+func completeFooterArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"off", "on"}, prefix))
+	}
+	return nil
+}
+
+// completeSyncArg: ':sync retry|discard'.
+func completeSyncArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"discard", "retry"}, prefix))
+	}
+	return nil
+}
+
+// completeOutboxArg: ':outbox send|clear'.
+func completeOutboxArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"clear", "send"}, prefix))
+	}
+	return nil
+}
+
+// completeMergeArg: ':merge stop' or ':merge <recipients.csv> <template>', with the composer
+// template names for the template.
+func completeMergeArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	switch len(strings.Fields(head)) {
+	case 0:
+		return withHead("", filterByPrefix([]string{"stop"}, prefix))
+	case 1:
+		if a.compositionService == nil || strings.EqualFold(firstToken(head), "stop") {
+			return nil
+		}
+		templates, err := a.compositionService.GetTemplates(a.ctx, "")
+		if err != nil {
+			return nil
+		}
+		var names []string
+		for _, t := range templates {
+			names = append(names, t.ID)
+		}
+		return withHead(head, filterByPrefix(names, prefix))
+	}
+	return nil
+}
+
+// completeLocalArchiveArg: ':localarchive search'.
+func completeLocalArchiveArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"search"}, prefix))
+	}
+	return nil
+}
+
+// completeSmartLabelArg: ':smartlabel remove|<saved-query name>'.
+func completeSmartLabelArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	switch strings.TrimSpace(head) {
+	case "":
+		return withHead("", filterByPrefix(append([]string{"remove"}, a.cmd.queryNames...), prefix))
+	case "remove":
+		return withHead(head, filterByPrefix(a.cmd.queryNames, prefix))
+	}
+	return nil
+}
+
+// completeGroupsArg: ':groups remove <name>'.
+func completeGroupsArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	switch strings.TrimSpace(head) {
+	case "":
+		return withHead("", filterByPrefix([]string{"remove"}, prefix))
+	case "remove":
+		if a.recipientGroupService == nil {
+			return nil
+		}
+		var names []string
+		for _, g := range a.recipientGroupService.List() {
+			names = append(names, g.Name)
+		}
+		return withHead(head, filterByPrefix(names, prefix))
+	}
+	return nil
+}
+
+// completeThreadNoteArg: ':note pin|regen|remove'.
+func completeThreadNoteArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"pin", "regen", "remove"}, prefix))
+	}
+	return nil
+}
+
+// completeContactsArg: ':contacts [add|remove <email>|query]'.
+func completeContactsArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"add", "remove"}, prefix))
+	}
+	return nil
+}
+
+// completeTimelineArg: ':timeline <email>' — addresses from the contacts index.
+func completeTimelineArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head != "" || prefix == "" || a.contactService == nil {
+		return nil
+	}
+	contacts, err := a.contactService.Search(a.ctx, prefix, 20)
+	if err != nil {
+		return nil
+	}
+	var emails []string
+	for _, c := range contacts {
+		emails = append(emails, c.Email)
+	}
+	return withHead("", filterByPrefix(emails, prefix))
+}
+
+// completeSenderArg: ':sender [email] emoji|color <color>|remove'.
+func completeSenderArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	fields := strings.Fields(head)
+	if len(fields) > 0 && strings.Contains(fields[0], "@") {
+		fields = fields[1:]
+	}
+	switch {
+	case len(fields) == 0:
+		return withHead(head, filterByPrefix([]string{"color", "emoji", "name", "remove"}, prefix))
+	case len(fields) == 1 && fields[0] == "color":
+		return withHead(head, filterByPrefix(append(render.SenderColors(), "none"), prefix))
+	}
+	return nil
+}
+
+// completeReportArg: ':report [days] [ai] [save|email]'; options may come in any order.
+func completeReportArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	return withHead(head, filterByPrefix([]string{"14d", "30d", "7d", "ai", "email", "save"}, prefix))
+}
+
+// completeDigestArg: ':digest [noai] [query]'.
+func completeDigestArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"noai"}, prefix))
+	}
+	return nil
+}
+
+// completeHTMLArg: ':html images|noimages'.
+func completeHTMLArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"images", "noimages"}, prefix))
+	}
+	return nil
+}
+
+// completeTimeMachineArg: ':timemachine list|capture|yesterday' (or a date).
+func completeTimeMachineArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"capture", "list", "yesterday"}, prefix))
+	}
+	return nil
+}
+
+// completeVerbosityArg: ':verbosity errors|normal|verbose'.
+func completeVerbosityArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"errors", "normal", "verbose"}, prefix))
+	}
+	return nil
+}
+
+// completeDensityArg: ':density compact|comfortable'.
+func completeDensityArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{densityCompact, densityComfortable}, prefix))
+	}
+	return nil
+}
+
+// completePanelArg: ':panel wider|narrower|<percent>'.
+func completePanelArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"30", "40", "50", "60", "70", "narrower", "wider"}, prefix))
+	}
+	return nil
+}
+
+// completeSummaryArg: ':summary refresh|default|<preset>'.
+func completeSummaryArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		opts := []string{"refresh", "default"}
+		for _, it := range summaryPresetItems[1:] {
+			opts = append(opts, it.preset)
+		}
+		return withHead("", filterByPrefix(opts, prefix))
+	}
+	return nil
+}
+
+// completeTodosArg: ':todos all|extract [thread]|clear'.
+func completeTodosArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"all", "clear", "extract"}, prefix))
+	}
+	if firstToken(rest) == "extract" && len(strings.Fields(rest)) <= 2 {
+		return withHead(head, filterByPrefix([]string{"thread"}, prefix))
+	}
+	return nil
+}
+
+// completeBriefingArg: ':briefing obsidian|refresh'.
+func completeBriefingArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"obsidian", "refresh"}, prefix))
+	}
+	return nil
+}
+
+// completeAlertsArg: ':alerts expand|off'.
+func completeAlertsArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"expand", "off"}, prefix))
+	}
+	return nil
+}
+
+// completeWorkspaceArg: ':workspace archive'.
+func completeWorkspaceArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"archive"}, prefix))
+	}
+	return nil
+}
+
+// completeRowFormatArg: ':rowformat off|reset' (or a template).
+func completeRowFormatArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"off", "reset"}, prefix))
+	}
+	return nil
+}
+
+// completeDNDArg: ':dnd on|off|auto'.
+func completeDNDArg(a *App, rest string) []string {
+	head, prefix := splitLastToken(rest)
+	if head == "" {
+		return withHead("", filterByPrefix([]string{"auto", "off", "on", "until"}, prefix))
+	}
+	return nil
+}
+
 // completeBookmarkArg: ':bookmark <saved-query name>'. The whole rest is the name (the command joins
 // args with spaces), so it is matched as a unit against the saved-query names.
 func completeBookmarkArg(a *App, rest string) []string {
@@ -90,7 +90,8 @@ func TestCommandCandidates_ArgGrammar(t *testing.T) {
 		{"labels ", []string{"labels add", "labels list", "labels remove"}},
 		{"labels add wor", []string{"labels add Work"}},
 		{"prompt li", []string{"prompt list"}},
-		{"theme ", []string{"theme list", "theme preview", "theme set"}},
+		{"theme ", []string{"theme compare", "theme list", "theme preview", "theme set"}},
+		{"theme compare gmail-dark g", []string{"theme compare gmail-dark gmail-dark", "theme compare gmail-dark gruvbox"}},
 		{"theme set gr", []string{"theme set gruvbox"}},
 		{"bookmark Unread V", []string{"bookmark Unread VIP"}},
 		{"search ha", []string{"search has:attachment"}},
@@ -132,10 +133,29 @@ func TestArgCompleters_Subcommands(t *testing.T) {
 	if got := completeAccountsArg(a, "switch w"); len(got) != 1 || got[0] != "switch work" {
 		t.Fatalf("accounts 'switch w' -> %v, want [switch work]", got)
 	}
+	// links preview [on|off]
+	if got := completeLinksArg(a, "pre"); len(got) != 1 || got[0] != "preview" {
+		t.Fatalf("links 'pre' -> %v, want [preview]", got)
+	}
+	if got := completeLinksArg(a, "preview o"); len(got) != 2 || got[0] != "preview off" {
+		t.Fatalf("links 'preview o' -> %v, want [preview off, preview on]", got)
+	}
+	// todos extract [thread]
+	if got := completeTodosArg(a, "extract t"); len(got) != 1 || got[0] != "extract thread" {
+		t.Fatalf("todos 'extract t' -> %v, want [extract thread]", got)
+	}
+	// briefing obsidian|refresh
+	if got := completeBriefingArg(a, "o"); len(got) != 1 || got[0] != "obsidian" {
+		t.Fatalf("briefing 'o' -> %v, want [obsidian]", got)
+	}
+	// summary refresh|default|<preset>
+	if got := completeSummaryArg(a, "b"); len(got) != 1 || got[0] != "bullets" {
+		t.Fatalf("summary 'b' -> %v, want [bullets]", got)
+	}
 }
 
 func TestArgCompleters_Wired(t *testing.T) {
-	for _, name := range []string{"search", "labels", "prompt", "theme", "bookmark", "accounts"} {
+	for _, name := range []string{"search", "labels", "links", "refine", "footer", "sync", "prompt", "theme", "bookmark", "accounts", "summary", "todos", "briefing"} {
 		if s := lookupCommand(name); s == nil || s.completeArg == nil {
 			t.Fatalf("command %q should have an arg completer", name)
 		}
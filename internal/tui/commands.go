@@ -137,7 +137,8 @@ func (a *App) showCommandBarWithPrefix(prefix string) {
 		if len(cands) > 0 && cands[0] != strings.TrimSpace(text) {
 			hint.SetText("[" + cands[0] + "]")
 		} else {
-			hint.SetText("")
+			// Commands taking a quick date preview the time it resolves to
+			hint.SetText(a.quickDatePreview(text))
 		}
 	})
 
@@ -283,7 +284,11 @@ func parseCommandArgs(cmd string) []string {
 // executeCommand executes the current command
 func (a *App) executeCommand(cmd string) {
 	a.cmd.addToHistory(cmd)
+	a.dispatchCommand(cmd)
+}
 
+// dispatchCommand runs a command line without recording it in the history
+func (a *App) dispatchCommand(cmd string) {
 	parts := parseCommandArgs(cmd)
 	if len(parts) == 0 {
 		return
@@ -315,6 +320,10 @@ func (a *App) executeCommand(cmd string) {
 		a.executeContentSearch(args)
 	case "search":
 		a.executeSearchCommand(args)
+	case "operators", "ops":
+		a.executeOperatorsCommand(args)
+	case "refine":
+		a.executeRefineCommand(args)
 	case "slack", "sl":
 		a.executeSlackCommand(args)
 	case "s":
@@ -326,6 +335,9 @@ func (a *App) executeCommand(cmd string) {
 		}
 	case "summary":
 		a.executeSummaryCommand(args)
+	case "explain":
+		// Shorthand for :summary explain
+		a.showSummaryPreset("explain")
 	case "rsvp":
 		a.executeRSVPCommand(args)
 	case "inbox", "i":
@@ -346,9 +358,83 @@ func (a *App) executeCommand(cmd string) {
 		a.executeExpandAllCommand(args)
 	case "collapse-all", "collapse":
 		a.executeCollapseAllCommand(args)
+	case "thread-split", "th-split":
+		a.executeThreadSplitCommand(args)
 
 	case "help", "h", "?":
 		a.executeHelpCommand(args)
+	case "page":
+		a.executePageCommand(args)
+	case "hints":
+		a.executeHintsCommand(args)
+	case "footer":
+		a.executeFooterCommand(args)
+	case "sync":
+		a.executeSyncCommand(args)
+	case "bench":
+		a.executeBenchCommand(args)
+	case "outbox":
+		a.executeOutboxCommand(args)
+	case "merge", "mailmerge":
+		a.executeMergeCommand(args)
+	case "localarchive", "la":
+		a.executeLocalArchiveCommand(args)
+	case "smartlabel", "sml":
+		a.executeSmartLabelCommand(args)
+	case "groups", "group":
+		a.executeGroupsCommand(args)
+	case "note":
+		a.executeThreadNoteCommand(args)
+	case "todos", "todo":
+		a.executeTodosCommand(args)
+	case "briefing", "brief":
+		a.executeBriefingCommand(args)
+	case "sender":
+		a.executeSenderCommand(args)
+	case "contacts":
+		a.executeContactsCommand(args)
+	case "timeline":
+		a.executeTimelineCommand(args)
+	case "report":
+		a.executeReportCommand(args)
+	case "digest":
+		a.executeDigestCommand(args)
+	case "privacy":
+		a.executePrivacyCommand(args)
+	case "labeldiff", "bulkreport":
+		a.executeLabelDiffCommand(args)
+	case "nested", "forwarded":
+		a.executeNestedCommand(args)
+	case "savepart", "parts":
+		a.executeSavePartCommand(args)
+	case "source", "raw":
+		a.executeSourceCommand(args)
+	case "html":
+		a.executeHTMLCommand(args)
+	case "restore", "untrash":
+		a.executeRestoreCommand(args)
+	case "timemachine", "tm":
+		a.executeTimeMachineCommand(args)
+	case "dnd":
+		a.executeDNDCommand(args)
+	case "minimap":
+		a.executeMinimapCommand(args)
+	case "verbosity":
+		a.executeVerbosityCommand(args)
+	case "density":
+		a.executeDensityCommand(args)
+	case "panel":
+		a.executePanelCommand(args)
+	case "startup":
+		a.executeStartupCommand(args)
+	case "pack":
+		a.executePackCommand(args)
+	case "alerts":
+		a.executeAlertsCommand(args)
+	case "workspace", "gnotif":
+		a.executeWorkspaceCommand(args)
+	case "rowformat", "rf":
+		a.executeRowFormatCommand(args)
 	case "numbers", "n":
 		a.executeNumbersCommand(args)
 	case "quit", "q":
@@ -429,6 +515,9 @@ func (a *App) executeCommand(cmd string) {
 	case "bookmark", "query":
 		a.executeBookmarkCommand(args)
 	default:
+		if a.runCustomCommand(command, args) {
+			return
+		}
 		// Check for numeric shortcuts like :1, :$
 		if matched := a.executeNumericShortcut(command); !matched {
 			if suggestion, ok := closestCommand(command); ok {
@@ -552,10 +641,41 @@ func (a *App) executeLabelsCommand(args []string) {
 
 // executeLinksCommand handles links commands
 func (a *App) executeLinksCommand(args []string) {
-	// Simple command - just open the link picker
+	if len(args) > 0 && strings.ToLower(args[0]) == "preview" {
+		a.executeLinksPreviewCommand(args[1:])
+		return
+	}
+	// No subcommand - just open the link picker
 	go a.openLinkPicker()
 }
 
+// executeLinksPreviewCommand handles :links preview [on|off], toggling when no argument is given
+func (a *App) executeLinksPreviewCommand(args []string) {
+	_, _, _, _, _, _, _, _, linkService, _, _, _ := a.GetServices()
+	if linkService == nil {
+		a.showError("Link service not available")
+		return
+	}
+	enabled := !linkService.IsPreviewEnabled()
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on", "enable":
+			enabled = true
+		case "off", "disable":
+			enabled = false
+		default:
+			go a.GetErrorHandler().ShowWarning(a.ctx, "Usage: :links preview [on|off]")
+			return
+		}
+	}
+	linkService.SetPreviewEnabled(enabled)
+	if enabled {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "🔗 Link previews ON (linked sites will be contacted)")
+	} else {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "🔗 Link previews OFF")
+	}
+}
+
 // executeAttachmentsCommand handles attachment commands
 func (a *App) executeAttachmentsCommand(args []string) {
 	// Simple command - just open the attachment picker
@@ -608,7 +728,7 @@ func (a *App) executeContentSearch(args []string) {
 // executeSearchCommand handles email search commands
 func (a *App) executeSearchCommand(args []string) {
 	if len(args) == 0 {
-		a.showError("Usage: search <query>")
+		a.showError("Usage: search [--all] <query>")
 		return
 	}
 	// Support contextual shorthands: from:current | to:current | subject:current | domain:current
@@ -642,21 +762,35 @@ func (a *App) executeSearchCommand(args []string) {
 			}
 		}
 	}
+	if args[0] == "--all" || args[0] == "-a" {
+		if len(args) == 1 {
+			a.showError("Usage: search --all <query>")
+			return
+		}
+		go a.performCrossAccountSearch(strings.Join(args[1:], " "))
+		return
+	}
 	query := strings.Join(args, " ")
 	go a.performSearch(query)
 }
 
 // executeSummaryCommand handles :summary commands
 func (a *App) executeSummaryCommand(args []string) {
+	usage := "Usage: summary refresh|default|oneline|bullets|actions|eli5|explain"
 	if len(args) == 0 {
-		a.showError("Usage: summary <refresh>")
+		a.showError(usage)
 		return
 	}
 	switch strings.ToLower(args[0]) {
 	case "refresh", "regenerate", "update":
 		go a.forceRegenerateSummary()
 	default:
-		a.showError("Usage: summary <refresh>")
+		preset, ok := parseSummaryPreset(args[0])
+		if !ok {
+			a.showError(usage)
+			return
+		}
+		a.showSummaryPreset(preset)
 	}
 }
 
@@ -1335,7 +1469,7 @@ func (a *App) executeObsidianCommand(args []string) {
 			}
 			// For single message, just open normal Obsidian panel
 			// (repack mode doesn't make sense for single message)
-			message, err := a.Client.GetMessageWithContent(messageID)
+			message, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 			if err != nil {
 				a.showError("Failed to load message content")
 				return
@@ -1810,9 +1944,17 @@ func (a *App) executeThemeCommand(args []string) {
 				a.GetErrorHandler().ShowError(a.ctx, "Usage: theme preview <theme-name>")
 			}()
 		}
+	case "compare", "c":
+		if len(subArgs) > 1 {
+			a.showThemeGallery(subArgs[1], subArgs[0], true)
+		} else {
+			go func() {
+				a.GetErrorHandler().ShowError(a.ctx, "Usage: theme compare <theme-a> <theme-b>")
+			}()
+		}
 	default:
 		go func() {
-			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Unknown theme command: %s. Use 'list', 'set', or 'preview'", subCommand))
+			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Unknown theme command: %s. Use 'list', 'set', 'preview' or 'compare'", subCommand))
 		}()
 	}
 }
@@ -1852,6 +1994,7 @@ func (a *App) executeThemeList() {
 		output += "\n💡 Commands:\n"
 		output += "   :theme set <name>     - Switch to theme\n"
 		output += "   :theme preview <name> - Preview theme\n"
+		output += "   :theme compare <a> <b> - Show two themes side by side\n"
 		output += "   :theme                - Open theme picker\n"
 		output += "   H                     - Open theme picker (shortcut)\n"
 
@@ -2029,8 +2172,13 @@ func (a *App) executeThreadsCommand(args []string) {
 		a.GetErrorHandler().ShowInfo(a.ctx, "📧 Switched to threaded view")
 	}()
 
-	// Refresh the view to show threads
-	go a.refreshThreadView()
+	// Refresh the view to show threads; loading until the conversations arrive so pagination
+	// and startup actions wait for them
+	a.SetMessagesLoading(true)
+	go func() {
+		defer a.SetMessagesLoading(false)
+		a.refreshThreadView()
+	}()
 }
 
 // executeFlattenCommand handles :flatten command
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -28,16 +29,19 @@ type CompositionPanel struct {
 	ccField      *tview.InputField
 	bccField     *tview.InputField
 	subjectField *tview.InputField
+	labelsField  *tview.InputField // labels applied to the sent message (shown with CC/BCC)
 
 	// Action buttons
-	sendButton  *tview.Button
-	draftButton *tview.Button
-	ccBccToggle *tview.Button
+	sendButton    *tview.Button
+	draftButton   *tview.Button
+	ccBccToggle   *tview.Button
+	forwardButton *tview.Button // forward options, only shown when forwarding
 
 	// Button section spacers (to apply theme colors)
 	spacer1 *tview.Box // Left spacer to center buttons
 	spacer2 *tview.Box // Between Send and Draft
 	spacer3 *tview.Box // Right side push spacer
+	spacer4 *tview.Box // Between Forward options and Send
 
 	// CC/BCC toggle container spacers
 	toggleTopSpacer    *tview.Box // Top spacer around CC/BCC toggle
@@ -54,6 +58,14 @@ type CompositionPanel struct {
 	ccBccVisible      bool
 	currentFocusIndex int
 	focusableItems    []tview.Primitive
+	newerAcknowledged int // newer thread messages already shown by the reply warning
+	// externalAcknowledged is the external recipient list already confirmed for sending
+	externalAcknowledged string
+
+	// Forward options: the original's attachments that can be carried over, and whether the
+	// quoted history was stripped
+	forwardAttachments []services.Attachment
+	forwardStripped    bool
 
 	// Auto-save functionality
 	autoSaveTimer   *time.Timer
@@ -129,6 +141,14 @@ func (c *CompositionPanel) createComponents() {
 	c.bccField.SetLabelColor(componentColors.Title.Color())
 	c.bccField.SetPlaceholderTextColor(c.app.getHintColor()) // Match Advanced Search placeholder color
 
+	c.labelsField = tview.NewInputField()
+	c.labelsField.SetLabel("Labels: ")
+	c.labelsField.SetPlaceholder("Clients/Acme, Projects")
+	c.labelsField.SetFieldBackgroundColor(componentColors.Background.Color())
+	c.labelsField.SetFieldTextColor(componentColors.Text.Color())
+	c.labelsField.SetLabelColor(componentColors.Title.Color())
+	c.labelsField.SetPlaceholderTextColor(c.app.getHintColor())
+
 	c.subjectField = tview.NewInputField()
 	c.subjectField.SetLabel("Subject: ")
 	c.subjectField.SetPlaceholder("Enter email subject")
@@ -166,6 +186,10 @@ func (c *CompositionPanel) createComponents() {
 	c.draftButton.SetBackgroundColor(componentColors.Border.Color())
 	c.draftButton.SetLabelColor(componentColors.Text.Color())
 
+	c.forwardButton = tview.NewButton("📎 Forward options")
+	c.forwardButton.SetBackgroundColor(componentColors.Border.Color())
+	c.forwardButton.SetLabelColor(componentColors.Text.Color())
+
 	// Cancel button removed - Esc key provides cancel functionality
 
 	// Create button section spacers with theme colors
@@ -178,6 +202,9 @@ func (c *CompositionPanel) createComponents() {
 	c.spacer3 = tview.NewBox()
 	c.spacer3.SetBackgroundColor(componentColors.Background.Color())
 
+	c.spacer4 = tview.NewBox()
+	c.spacer4.SetBackgroundColor(componentColors.Background.Color())
+
 	// Create header container to hold Form and CC/BCC toggle (no border to save space)
 	c.headerContainer = tview.NewFlex().SetDirection(tview.FlexColumn)
 	c.headerContainer.SetBackgroundColor(componentColors.Background.Color())
@@ -290,6 +317,11 @@ func (c *CompositionPanel) applyFieldStyling() {
 	c.bccField.SetFieldTextColor(componentColors.Text.Color())
 	c.bccField.SetLabelColor(componentColors.Title.Color())
 	c.bccField.SetPlaceholderTextColor(c.app.getHintColor())
+
+	c.labelsField.SetFieldBackgroundColor(componentColors.Background.Color())
+	c.labelsField.SetFieldTextColor(componentColors.Text.Color())
+	c.labelsField.SetLabelColor(componentColors.Title.Color())
+	c.labelsField.SetPlaceholderTextColor(c.app.getHintColor())
 }
 
 // setupButtonSection arranges action buttons horizontally with hint text
@@ -332,6 +364,7 @@ func (c *CompositionPanel) setupButtonSection() {
 	// Configure button actions
 	c.sendButton.SetSelectedFunc(func() { go c.sendComposition() })
 	c.draftButton.SetSelectedFunc(func() { go c.saveDraft() })
+	c.forwardButton.SetSelectedFunc(c.openForwardOptions)
 }
 
 // setupInputHandling implements comprehensive input capture to prevent global shortcuts
@@ -353,6 +386,9 @@ func (c *CompositionPanel) setupInputHandling() {
 			go c.sendComposition()
 			return nil
 		}
+		if c.handleForwardOptionsKey(event) {
+			return nil
+		}
 
 		// Check if EditableTextView has focus and handle character input
 		if c.bodySection != nil && c.bodySection.HasFocus() {
@@ -461,6 +497,7 @@ func (c *CompositionPanel) setupFieldInputCapture() {
 	c.toField.SetInputCapture(inputCapture)
 	c.ccField.SetInputCapture(inputCapture)
 	c.bccField.SetInputCapture(inputCapture)
+	c.labelsField.SetInputCapture(inputCapture)
 	c.subjectField.SetInputCapture(inputCapture)
 
 	// EditableTextView has its own input capture for editing - no need to override
@@ -512,22 +549,33 @@ func (c *CompositionPanel) ShowWithComposition(composition *services.Composition
 // loadComposition loads a composition into the form fields with improved data binding
 func (c *CompositionPanel) loadComposition(composition *services.Composition) {
 	c.composition = composition
+	c.newerAcknowledged = 0
+	c.externalAcknowledged = ""
 
 	// Load data into input fields
 	c.toField.SetText(strings.Join(c.formatRecipients(composition.To), ", "))
 	c.ccField.SetText(strings.Join(c.formatRecipients(composition.CC), ", "))
 	c.bccField.SetText(strings.Join(c.formatRecipients(composition.BCC), ", "))
+	c.labelsField.SetText(strings.Join(composition.Labels, ", "))
 	c.subjectField.SetText(composition.Subject)
 	c.bodySection.SetText(composition.Body)
 
 	// Show CC/BCC if they have content
-	if len(composition.CC) > 0 || len(composition.BCC) > 0 {
+	if len(composition.CC) > 0 || len(composition.BCC) > 0 || len(composition.Labels) > 0 {
 		c.ccBccVisible = true
 		c.updateCCBCCVisibility()
 	}
 
 	// Setup change handlers for real-time updates
 	c.setupChangeHandlers()
+	c.refreshSentLabelsTitle()
+
+	c.forwardAttachments = nil
+	c.forwardStripped = false
+	if composition.Type == services.CompositionTypeForward {
+		c.forwardAttachments = append([]services.Attachment(nil), composition.Attachments...)
+	}
+	c.refreshForwardControls()
 }
 
 // setupChangeHandlers configures real-time data binding for form fields
@@ -536,18 +584,28 @@ func (c *CompositionPanel) setupChangeHandlers() {
 	c.toField.SetChangedFunc(func(text string) {
 		if c.composition != nil {
 			c.composition.To = c.parseRecipients(text)
+			c.refreshSentLabelsTitle()
 		}
 	})
 
 	c.ccField.SetChangedFunc(func(text string) {
 		if c.composition != nil {
 			c.composition.CC = c.parseRecipients(text)
+			c.refreshSentLabelsTitle()
 		}
 	})
 
 	c.bccField.SetChangedFunc(func(text string) {
 		if c.composition != nil {
 			c.composition.BCC = c.parseRecipients(text)
+			c.refreshSentLabelsTitle()
+		}
+	})
+
+	c.labelsField.SetChangedFunc(func(text string) {
+		if c.composition != nil {
+			c.composition.Labels = parseLabelList(text)
+			c.refreshSentLabelsTitle()
 		}
 	})
 
@@ -587,6 +645,7 @@ func (c *CompositionPanel) updateCCBCCVisibility() {
 		c.ccBccToggle.SetLabel("-CC/BCC")
 		c.headerSection.AddFormItem(c.ccField)
 		c.headerSection.AddFormItem(c.bccField)
+		c.headerSection.AddFormItem(c.labelsField)
 	} else {
 		c.ccBccToggle.SetLabel("+CC/BCC")
 	}
@@ -627,10 +686,14 @@ func (c *CompositionPanel) updateFocusOrder() {
 	if c.ccBccVisible {
 		c.focusableItems = append(c.focusableItems, c.ccField)
 		c.focusableItems = append(c.focusableItems, c.bccField)
+		c.focusableItems = append(c.focusableItems, c.labelsField)
 	}
 
 	c.focusableItems = append(c.focusableItems, c.subjectField)
 	c.focusableItems = append(c.focusableItems, c.bodySection)
+	if c.isForwarding() {
+		c.focusableItems = append(c.focusableItems, c.forwardButton)
+	}
 	c.focusableItems = append(c.focusableItems, c.sendButton)
 	c.focusableItems = append(c.focusableItems, c.draftButton)
 
@@ -645,6 +708,7 @@ func (c *CompositionPanel) focusNext() {
 	if len(c.focusableItems) == 0 {
 		return
 	}
+	c.expandFocusedRecipientField()
 
 	c.currentFocusIndex = (c.currentFocusIndex + 1) % len(c.focusableItems)
 
@@ -656,6 +720,7 @@ func (c *CompositionPanel) focusPrevious() {
 	if len(c.focusableItems) == 0 {
 		return
 	}
+	c.expandFocusedRecipientField()
 
 	c.currentFocusIndex = (c.currentFocusIndex - 1 + len(c.focusableItems)) % len(c.focusableItems)
 	c.focusCurrent()
@@ -672,6 +737,32 @@ func (c *CompositionPanel) focusCurrent() {
 	c.app.SetFocus(focusTarget)
 }
 
+// refreshSentLabelsTitle shows the labels the message will get when sent (typed + configured
+// defaults) in the panel title
+func (c *CompositionPanel) refreshSentLabelsTitle() {
+	_, _, _, _, _, compositionService, _, _, _, _, _, _ := c.app.GetServices()
+	if compositionService == nil || c.composition == nil {
+		return
+	}
+	labels := compositionService.SentLabelsFor(c.composition)
+	if len(labels) == 0 {
+		c.SetTitle(" Compose Email ")
+		return
+	}
+	c.SetTitle(fmt.Sprintf(" Compose Email · 🏷 %s ", tview.Escape(strings.Join(labels, ", "))))
+}
+
+// parseLabelList splits a comma-separated list of label names
+func parseLabelList(text string) []string {
+	var labels []string
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			labels = append(labels, part)
+		}
+	}
+	return labels
+}
+
 // formatRecipients converts recipient structs to display strings
 func (c *CompositionPanel) formatRecipients(recipients []services.Recipient) []string {
 	result := make([]string, len(recipients))
@@ -766,6 +857,7 @@ func (c *CompositionPanel) sendComposition() {
 
 	// Update composition with current form values
 	c.updateCompositionFromForm()
+	c.expandRecipientGroups()
 
 	_, _, _, _, _, compositionService, _, _, _, _, _, _ := c.app.GetServices()
 
@@ -780,6 +872,22 @@ func (c *CompositionPanel) sendComposition() {
 		return
 	}
 
+	// Warn before replying into a thread that received newer messages
+	if !c.checkNewerThreadMessages() {
+		return
+	}
+
+	// Confirm recipients outside the account's internal domains
+	if !c.checkExternalRecipients() {
+		return
+	}
+
+	// While offline, queue behind the messages already waiting so they go out in order
+	if c.app.outboxService != nil && c.app.outboxService.IsOffline() {
+		c.queueForLater(nil)
+		return
+	}
+
 	// 1. Update button state to show sending
 	c.updateSendButtonState("sending")
 
@@ -796,6 +904,17 @@ func (c *CompositionPanel) sendComposition() {
 		err := compositionService.SendComposition(context.Background(), c.composition)
 		c.app.GetErrorHandler().ClearProgress()
 
+		// The message went out; only labeling it failed
+		var labelErr *services.SentLabelsError
+		if errors.As(err, &labelErr) {
+			err = nil
+		}
+
+		if isOfflineError(err) && c.app.outboxService != nil {
+			c.queueForLater(err)
+			return
+		}
+
 		if err != nil {
 			// Handle error case immediately
 			c.app.QueueUpdateDraw(func() {
@@ -813,7 +932,11 @@ func (c *CompositionPanel) sendComposition() {
 		// Show success message
 		recipientCount := len(c.composition.To) + len(c.composition.CC) + len(c.composition.BCC)
 		successMsg := fmt.Sprintf("Email sent to %d recipient(s)!", recipientCount)
-		c.app.GetErrorHandler().ShowSuccess(c.app.ctx, successMsg)
+		if labelErr != nil {
+			c.app.GetErrorHandler().ShowWarning(c.app.ctx, fmt.Sprintf("%s Labels not applied: %s", successMsg, strings.Join(labelErr.Labels, ", ")))
+		} else {
+			c.app.GetErrorHandler().ShowSuccess(c.app.ctx, successMsg)
+		}
 
 		// Auto-close after brief delay
 		time.Sleep(1500 * time.Millisecond)
@@ -823,6 +946,23 @@ func (c *CompositionPanel) sendComposition() {
 	}()
 }
 
+// queueForLater puts the composition in the offline outbox and closes the composer; cause is the
+// send error that revealed the network is down, if any
+func (c *CompositionPanel) queueForLater(cause error) {
+	if err := c.app.queueOffline(c.composition, cause); err != nil {
+		c.app.QueueUpdateDraw(func() {
+			c.updateSendButtonState("normal")
+		})
+		c.app.GetErrorHandler().ShowError(c.app.ctx, fmt.Sprintf("Offline and could not queue email: %v", err))
+		return
+	}
+	pending, _ := c.app.outboxService.Counts()
+	c.app.GetErrorHandler().ShowWarning(c.app.ctx, fmt.Sprintf("📴 Offline — email queued in the outbox (%d waiting); it is sent automatically when Gmail is reachable", pending))
+	c.app.QueueUpdateDraw(func() {
+		c.hide()
+	})
+}
+
 // saveDraft saves the current composition as a draft
 func (c *CompositionPanel) saveDraft() {
 	if c.composition == nil {
@@ -866,14 +1006,18 @@ func (c *CompositionPanel) hide() {
 	c.composition = nil
 	c.currentFocusIndex = 0
 	c.ccBccVisible = false
+	c.forwardAttachments = nil
+	c.forwardStripped = false
 	c.stopAutoSave() // Disable auto-save when hiding
 
 	// Clear form fields
 	c.toField.SetText("")
 	c.ccField.SetText("")
 	c.bccField.SetText("")
+	c.labelsField.SetText("")
 	c.subjectField.SetText("")
 	c.bodySection.SetText("")
+	c.SetTitle(" Compose Email ")
 
 	// Update CC/BCC visibility to default
 	c.updateCCBCCVisibility()
@@ -889,6 +1033,7 @@ func (c *CompositionPanel) hide() {
 	if list := c.app.views["list"]; list != nil {
 		c.app.SetFocus(list)
 		c.app.focus.set("list")
+		c.app.renderKeyHints()
 
 		// Check if we need to auto-select a message after closing composer
 		if table, ok := list.(*tview.Table); ok {
@@ -977,6 +1122,11 @@ func (c *CompositionPanel) UpdateTheme() {
 	c.bccField.SetLabelColor(componentColors.Title.Color())
 	c.bccField.SetPlaceholderTextColor(c.app.getHintColor())
 
+	c.labelsField.SetFieldBackgroundColor(componentColors.Background.Color())
+	c.labelsField.SetFieldTextColor(componentColors.Text.Color())
+	c.labelsField.SetLabelColor(componentColors.Title.Color())
+	c.labelsField.SetPlaceholderTextColor(c.app.getHintColor())
+
 	c.subjectField.SetFieldBackgroundColor(componentColors.Background.Color())
 	c.subjectField.SetFieldTextColor(componentColors.Text.Color())
 	c.subjectField.SetLabelColor(componentColors.Title.Color())
@@ -998,6 +1148,9 @@ func (c *CompositionPanel) UpdateTheme() {
 	c.draftButton.SetBackgroundColor(componentColors.Border.Color())
 	c.draftButton.SetLabelColor(componentColors.Text.Color())
 
+	c.forwardButton.SetBackgroundColor(componentColors.Border.Color())
+	c.forwardButton.SetLabelColor(componentColors.Text.Color())
+
 	c.ccBccToggle.SetBackgroundColor(componentColors.Border.Color())
 	c.ccBccToggle.SetLabelColor(componentColors.Text.Color())
 
@@ -1008,6 +1161,7 @@ func (c *CompositionPanel) UpdateTheme() {
 	c.spacer1.SetBackgroundColor(componentColors.Background.Color())
 	c.spacer2.SetBackgroundColor(componentColors.Background.Color())
 	c.spacer3.SetBackgroundColor(componentColors.Background.Color())
+	c.spacer4.SetBackgroundColor(componentColors.Background.Color())
 
 	// Update CC/BCC toggle spacers to match theme background
 	c.toggleTopSpacer.SetBackgroundColor(componentColors.Background.Color())
@@ -1174,6 +1328,9 @@ func (c *CompositionPanel) updateCompositionFromFields() {
 	if c.bccField != nil {
 		c.composition.BCC = c.parseRecipients(c.bccField.GetText())
 	}
+	if c.labelsField != nil {
+		c.composition.Labels = parseLabelList(c.labelsField.GetText())
+	}
 
 	// Update subject and body
 	if c.subjectField != nil {
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// bindContacts (re)creates the contacts index service for the active account
+func (a *App) bindContacts() {
+	if a.dbStore == nil {
+		return
+	}
+	svc := services.NewContactService(db.NewContactStore(a.dbStore))
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.contactService = svc
+}
+
+// executeContactsCommand handles :contacts [query], :contacts add (the vCard being previewed)
+// and :contacts remove <email>
+func (a *App) executeContactsCommand(args []string) {
+	if a.contactService == nil {
+		a.showError("Contacts index not available (no local database)")
+		return
+	}
+	action := ""
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+	switch action {
+	case "add":
+		cards, source := a.previewedContacts, a.previewedContactsSource
+		if len(cards) == 0 {
+			a.showError("❌ Preview a .vcf attachment first (Ctrl+P in the attachment picker)")
+			return
+		}
+		go func() {
+			n, err := a.contactService.AddVCards(a.ctx, cards, source)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error adding contacts", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("👤 Added %d address(es) from %s to the contacts index", n, source))
+		}()
+	case "remove", "rm":
+		if len(args) < 2 {
+			a.showError("Usage: contacts remove <email>")
+			return
+		}
+		email := args[1]
+		go func() {
+			if err := a.contactService.Remove(a.ctx, email); err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing contact", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Removed %s from the contacts index", email))
+		}()
+	default:
+		query := strings.Join(args, " ")
+		go func() {
+			contacts, err := a.contactService.Search(a.ctx, query, 0)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading contacts", err)
+				return
+			}
+			if len(contacts) == 0 {
+				a.GetErrorHandler().ShowInfo(a.ctx, "No contacts found")
+				return
+			}
+			content := a.formatContactsIndex(contacts, query)
+			a.QueueUpdateDraw(func() {
+				if a.enhancedTextView != nil {
+					a.enhancedTextView.SetContent(content)
+					a.enhancedTextView.ScrollToBeginning()
+				}
+			})
+		}()
+	}
+}
+
+// formatContactsIndex lists indexed contacts for the content pane
+func (a *App) formatContactsIndex(contacts []services.ContactInfo, query string) string {
+	var b strings.Builder
+	title := fmt.Sprintf("👤 Contacts (%d)", len(contacts))
+	if query != "" {
+		title = fmt.Sprintf("👤 Contacts matching %q (%d)", query, len(contacts))
+	}
+	fmt.Fprintf(&b, "%s%s%s\n\n", a.GetColorTag("title"), tview.Escape(title), a.GetEndTag())
+	for _, c := range contacts {
+		name := c.Name
+		if name == "" {
+			name = c.Email
+		}
+		fmt.Fprintf(&b, "%s%s%s <%s>", a.GetColorTag("emphasis"), tview.Escape(name), a.GetEndTag(), tview.Escape(c.Email))
+		if details := strings.TrimPrefix(strings.TrimSuffix(c.Org+" · "+c.Phone, " · "), " · "); details != "" {
+			fmt.Fprintf(&b, "  %s%s%s", a.GetColorTag("secondary"), tview.Escape(details), a.GetEndTag())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
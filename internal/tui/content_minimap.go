@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// Gutter cell kinds, in increasing priority
+const (
+	minimapTrack = iota
+	minimapThumb
+	minimapMatch
+	minimapCurrent
+)
+
+// contentMinimap is a one-column gutter beside the message content: a scroll thumb for the
+// visible part of the message and marks where content search matches are, so n/N through a long
+// digest shows where the remaining matches lie. It only draws when the content overflows.
+type contentMinimap struct {
+	*tview.Box
+	app  *App
+	view *EnhancedTextView
+
+	// Layout cache: recomputed when the content or the wrap width changes
+	layoutContent string
+	layoutWidth   int
+	layout        minimapLayout
+}
+
+func newContentMinimap(app *App, view *EnhancedTextView) *contentMinimap {
+	return &contentMinimap{Box: tview.NewBox(), app: app, view: view}
+}
+
+// Draw paints the gutter in the current theme's colors
+func (m *contentMinimap) Draw(screen tcell.Screen) {
+	general := m.app.GetComponentColors("general")
+	m.SetBackgroundColor(general.Background.Color())
+	m.Box.DrawForSubclass(screen, m)
+	x, y, _, height := m.GetInnerRect()
+	_, _, textWidth, textHeight := m.view.GetInnerRect()
+	if height <= 0 || textWidth <= 0 {
+		return
+	}
+	if m.layoutWidth != textWidth || m.layoutContent != m.view.content {
+		m.layout = newMinimapLayout(m.view.content, textWidth)
+		m.layoutContent, m.layoutWidth = m.view.content, textWidth
+	}
+	if m.layout.rows <= textHeight {
+		return
+	}
+
+	var matchRows []int
+	currentRow := -1
+	if r := m.view.currentSearchResult; r != nil {
+		for i, pos := range r.Matches {
+			row := m.layout.rowOf(pos)
+			matchRows = append(matchRows, row)
+			if i == m.view.currentMatchIndex {
+				currentRow = row
+			}
+		}
+	}
+	offset, _ := m.view.GetScrollOffset()
+
+	bg := general.Background.Color()
+	styles := map[int]struct {
+		r     rune
+		style tcell.Style
+	}{
+		minimapTrack:   {'│', tcell.StyleDefault.Background(bg).Foreground(general.Border.Color())},
+		minimapThumb:   {'┃', tcell.StyleDefault.Background(bg).Foreground(general.Accent.Color())},
+		minimapMatch:   {'━', tcell.StyleDefault.Background(bg).Foreground(m.app.GetComponentColors("search").Accent.Color())},
+		minimapCurrent: {'━', tcell.StyleDefault.Background(bg).Foreground(general.Title.Color()).Bold(true)},
+	}
+	for i, kind := range minimapCells(height, m.layout.rows, offset, textHeight, matchRows, currentRow) {
+		s := styles[kind]
+		screen.SetContent(x, y+i, s.r, nil, s.style)
+	}
+}
+
+// minimapLayout maps content lines to display rows at a wrap width
+type minimapLayout struct {
+	lineStarts []int // byte offset where each line starts
+	lineRows   []int // first display row of each line
+	rows       int   // total display rows
+}
+
+// newMinimapLayout estimates how the wrapped content is laid out; color tags take no room
+func newMinimapLayout(content string, width int) minimapLayout {
+	var l minimapLayout
+	if width <= 0 {
+		width = 1
+	}
+	start := 0
+	for _, line := range strings.Split(content, "\n") {
+		l.lineStarts = append(l.lineStarts, start)
+		l.lineRows = append(l.lineRows, l.rows)
+		rows := (tview.TaggedStringWidth(line) + width - 1) / width
+		if rows < 1 {
+			rows = 1
+		}
+		l.rows += rows
+		start += len(line) + 1
+	}
+	return l
+}
+
+// rowOf returns the display row of a byte offset in the content
+func (l minimapLayout) rowOf(pos int) int {
+	line := sort.Search(len(l.lineStarts), func(i int) bool { return l.lineStarts[i] > pos }) - 1
+	if line < 0 {
+		return 0
+	}
+	return l.lineRows[line]
+}
+
+// minimapCells scales the content (rows display rows, visible of them shown from offset) onto a
+// gutter of height cells and returns the kind of each cell
+func minimapCells(height, rows, offset, visible int, matchRows []int, currentRow int) []int {
+	cells := make([]int, height)
+	if height <= 0 || rows <= 0 {
+		return cells
+	}
+	cellOf := func(row int) int {
+		c := row * height / rows
+		if c >= height {
+			c = height - 1
+		}
+		if c < 0 {
+			c = 0
+		}
+		return c
+	}
+	thumbStart := cellOf(offset)
+	thumbSize := (visible*height + rows - 1) / rows
+	if thumbSize < 1 {
+		thumbSize = 1
+	}
+	for i := thumbStart; i < thumbStart+thumbSize && i < height; i++ {
+		cells[i] = minimapThumb
+	}
+	for _, row := range matchRows {
+		if c := cellOf(row); cells[c] < minimapMatch {
+			cells[c] = minimapMatch
+		}
+	}
+	if currentRow >= 0 {
+		cells[cellOf(currentRow)] = minimapCurrent
+	}
+	return cells
+}
+
+// setContentMinimapVisible shows or hides the gutter. Must run on the UI goroutine.
+func (a *App) setContentMinimapVisible(show bool) {
+	a.showContentMinimap = show
+	row, ok := a.views["textRow"].(*tview.Flex)
+	if !ok || a.contentMinimap == nil {
+		return
+	}
+	width := 0
+	if show {
+		width = 1
+	}
+	row.ResizeItem(a.contentMinimap, width, 0)
+}
+
+// executeMinimapCommand handles :minimap [on|off]
+func (a *App) executeMinimapCommand(args []string) {
+	show := !a.showContentMinimap
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on", "show":
+			show = true
+		case "off", "hide":
+			show = false
+		default:
+			a.showError("Usage: minimap [on|off]")
+			return
+		}
+	}
+	a.setContentMinimapVisible(show)
+	if show {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "Content minimap enabled")
+		return
+	}
+	go a.GetErrorHandler().ShowInfo(a.ctx, "Content minimap disabled")
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMinimapLayout(t *testing.T) {
+	// "[red]" takes no room; the 25-cell line wraps to 3 rows at width 10
+	content := "short\n[red]" + "0123456789012345678901234" + "\n\nend"
+	l := newMinimapLayout(content, 10)
+	if l.rows != 6 {
+		t.Fatalf("rows = %d, want 6", l.rows)
+	}
+	if want := []int{0, 1, 4, 5}; !reflect.DeepEqual(l.lineRows, want) {
+		t.Errorf("lineRows = %v, want %v", l.lineRows, want)
+	}
+	if got := l.rowOf(len(content) - 1); got != 5 {
+		t.Errorf("rowOf(last) = %d, want 5", got)
+	}
+	if got := l.rowOf(7); got != 1 {
+		t.Errorf("rowOf(7) = %d, want 1", got)
+	}
+}
+
+func TestMinimapCells(t *testing.T) {
+	// 100 rows on a 10-cell gutter, rows 30-49 visible, matches at rows 5, 35 and 95 (current)
+	got := minimapCells(10, 100, 30, 20, []int{5, 35, 95}, 95)
+	want := []int{minimapMatch, minimapTrack, minimapTrack, minimapMatch, minimapThumb,
+		minimapTrack, minimapTrack, minimapTrack, minimapTrack, minimapCurrent}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("minimapCells = %v, want %v", got, want)
+	}
+
+	// The thumb never disappears on huge messages
+	got = minimapCells(4, 100000, 100000, 10, nil, -1)
+	if got[3] != minimapThumb {
+		t.Errorf("thumb at the end missing: %v", got)
+	}
+}
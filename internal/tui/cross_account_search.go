@@ -0,0 +1,365 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// crossAccountPageSize is the number of results fetched per account and page
+const crossAccountPageSize = 50
+
+// crossAccountState tracks the results of a cross-account search. The account lookups are read by
+// message-loading goroutines, so everything is guarded by mu; use it via a.crossSearch.*.
+type crossAccountState struct {
+	mu              sync.RWMutex
+	active          bool
+	query           string            // effective Gmail query sent to every account
+	activeAccountID string            // account whose client is a.Client when the search ran
+	accountOf       map[string]string // message ID -> account ID
+	labels          map[string]string // account ID -> label shown in the Account column
+	tokens          map[string]string // account ID -> next page token
+}
+
+// begin starts a new cross-account result set, dropping the previous one
+func (s *crossAccountState) begin(query, activeAccountID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = true
+	s.query = query
+	s.activeAccountID = activeAccountID
+	s.accountOf = make(map[string]string)
+	s.labels = make(map[string]string)
+	s.tokens = make(map[string]string)
+}
+
+// reset leaves cross-account mode
+func (s *crossAccountState) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = false
+	s.query = ""
+	s.activeAccountID = ""
+	s.accountOf = nil
+	s.labels = nil
+	s.tokens = nil
+}
+
+func (s *crossAccountState) isActive() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+func (s *crossAccountState) Query() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.query
+}
+
+// add records a page of results and replaces the page tokens; it returns the messages not seen yet
+func (s *crossAccountState) add(res *services.CrossAccountSearchResult) []*gmailapi.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return nil
+	}
+	fresh := make([]*gmailapi.Message, 0, len(res.Messages))
+	for _, m := range res.Messages {
+		if _, seen := s.accountOf[m.Message.Id]; seen {
+			continue
+		}
+		s.accountOf[m.Message.Id] = m.AccountID
+		s.labels[m.AccountID] = m.AccountLabel
+		fresh = append(fresh, m.Message)
+	}
+	s.tokens = make(map[string]string, len(res.NextPageTokens))
+	for id, tok := range res.NextPageTokens {
+		s.tokens[id] = tok
+	}
+	return fresh
+}
+
+// pageTokens returns a copy of the per-account next page tokens
+func (s *crossAccountState) pageTokens() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]string, len(s.tokens))
+	for id, tok := range s.tokens {
+		out[id] = tok
+	}
+	return out
+}
+
+// accountLabel returns the Account column text for a message ("" outside cross-account mode)
+func (s *crossAccountState) accountLabel(messageID string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.active {
+		return ""
+	}
+	return s.labels[s.accountOf[messageID]]
+}
+
+// foreignAccount returns the owning account when a message belongs to an account other than the
+// one a.Client talks to
+func (s *crossAccountState) foreignAccount(messageID string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.active {
+		return "", false
+	}
+	accountID, ok := s.accountOf[messageID]
+	if !ok || accountID == s.activeAccountID {
+		return "", false
+	}
+	return accountID, true
+}
+
+// foreignOwner returns the label of the account that owns the first of ids not belonging to the
+// active account
+func (s *crossAccountState) foreignOwner(ids []string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.active {
+		return "", false
+	}
+	for _, id := range ids {
+		accountID, ok := s.accountOf[id]
+		if !ok || accountID == s.activeAccountID {
+			continue
+		}
+		if label := s.labels[accountID]; label != "" {
+			return label, true
+		}
+		return accountID, true
+	}
+	return "", false
+}
+
+// blockForeignMessages refuses an action that modifies messages when any of them belongs to another
+// account than the active one: archive, trash, labels, replies and bulk operations go through the
+// active account's services, which cannot see that message. Returns true when the action is blocked.
+func (a *App) blockForeignMessages(ids ...string) bool {
+	owner, foreign := a.crossSearch.foreignOwner(ids)
+	if !foreign {
+		return false
+	}
+	a.showError(fmt.Sprintf("⚠️ Switch to %s (:accounts) to modify this message", owner))
+	return true
+}
+
+// messageClient returns the Gmail client that owns a message: the active client, or the owning
+// account's client for results of a cross-account search
+func (a *App) messageClient(messageID string) *gmail.Client {
+	accountID, foreign := a.crossSearch.foreignAccount(messageID)
+	if !foreign || a.accountService == nil {
+		return a.Client
+	}
+	client, err := a.accountService.GetAccountClient(a.ctx, accountID)
+	if err != nil || client == nil {
+		if a.logger != nil {
+			a.logger.Printf("messageClient: no client for account %s: %v", accountID, err)
+		}
+		return a.Client
+	}
+	return client
+}
+
+// accountCount returns the number of configured accounts
+func (a *App) accountCount() int {
+	if a.accountService == nil {
+		return 0
+	}
+	accounts, err := a.accountService.ListAccounts(a.ctx)
+	if err != nil {
+		return 0
+	}
+	return len(accounts)
+}
+
+// performCrossAccountSearch runs a remote search on every configured account concurrently and
+// shows the merged results, newest first, with an Account column
+func (a *App) performCrossAccountSearch(query string) {
+	originalQuery := strings.TrimSpace(query)
+	if originalQuery == "" {
+		a.showError("Search query cannot be empty")
+		return
+	}
+	svc := a.GetCrossAccountSearchService()
+	if svc == nil || a.accountCount() < 2 {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "Only one account configured — searching it alone")
+		a.performSearch(originalQuery)
+		return
+	}
+
+	a.QueueUpdateDraw(func() {
+		if list, ok := a.views["list"].(*tview.Table); ok {
+			list.Clear()
+			list.SetTitle(fmt.Sprintf(" 🔍 Searching all accounts: %s ", originalQuery))
+		}
+	})
+
+	q := effectiveSearchQuery(originalQuery)
+	res, err := svc.Search(a.ctx, q, crossAccountPageSize)
+	if err != nil {
+		a.QueueUpdateDraw(func() {
+			a.showError(fmt.Sprintf("❌ Search error: %v", err))
+			if list, ok := a.views["list"].(*tview.Table); ok {
+				list.SetTitle(" ❌ Search failed ")
+			}
+		})
+		return
+	}
+
+	activeID := ""
+	if active, err := a.accountService.GetActiveAccount(a.ctx); err == nil {
+		activeID = active.ID
+	}
+	a.alerts.reset()
+	a.crossSearch.begin(q, activeID)
+	messages := a.crossSearch.add(res)
+
+	ids := make([]string, len(messages))
+	for i, m := range messages {
+		ids[i] = m.Id
+	}
+	a.SetMessageIDs(ids)
+	a.mu.Lock()
+	a.messagesMeta = messages
+	a.mu.Unlock()
+	a.nextPageToken = "" // pagination is per account, see loadMoreCrossAccountResults
+	a.search.SetMode("remote")
+	a.search.SetQuery(q)
+	a.footer.begin(q) // Gmail estimates are per account; the footer shows loaded counts only
+
+	a.emailRenderer.SetLabelMap(a.crossAccountLabelMap(res, activeID))
+	a.emailRenderer.SetShowSystemLabelsInList(true)
+
+	a.QueueUpdateDraw(func() {
+		a.refreshTableDisplay()
+		if table, ok := a.views["list"].(*tview.Table); ok {
+			table.SetTitle(fmt.Sprintf(" 🔍 All accounts (%d) — %s ", len(a.ids), originalQuery))
+			a.showSearchChips(originalQuery)
+			if table.GetRowCount() > 1 && (a.compositionPanel == nil || !a.compositionPanel.IsVisible()) {
+				table.Select(1, 0)
+				if len(a.ids) > 0 {
+					firstID := a.ids[0]
+					a.SetCurrentMessageID(firstID)
+					go a.showMessageWithoutFocus(firstID)
+				}
+			}
+		}
+		a.markFocus("list")
+		a.SetFocus(a.views["list"])
+	})
+	a.reportCrossAccountErrors(res, false)
+}
+
+// loadMoreCrossAccountResults fetches the next page of every account that still has results
+func (a *App) loadMoreCrossAccountResults() {
+	tokens := a.crossSearch.pageTokens()
+	if len(tokens) == 0 {
+		a.showStatusMessage("No more results")
+		return
+	}
+	svc := a.GetCrossAccountSearchService()
+	if svc == nil {
+		return
+	}
+	a.setStatusPersistent("Loading more results from all accounts…")
+	res, err := svc.NextPage(a.ctx, a.crossSearch.Query(), crossAccountPageSize, tokens)
+	go a.GetErrorHandler().ClearPersistentMessage()
+	if err != nil {
+		a.showErrorFor("Error loading more", err)
+		return
+	}
+	messages := a.crossSearch.add(res)
+	a.mu.Lock()
+	for _, m := range messages {
+		a.ids = append(a.ids, m.Id)
+		a.messagesMeta = append(a.messagesMeta, m)
+	}
+	a.mu.Unlock()
+
+	a.QueueUpdateDraw(func() {
+		a.refreshTableDisplay()
+		if table, ok := a.views["list"].(*tview.Table); ok {
+			table.SetTitle(fmt.Sprintf(" 🔍 All accounts (%d) — %s ", len(a.ids), a.crossSearch.Query()))
+		}
+		a.SetFocus(a.views["list"])
+		a.markFocus("list")
+	})
+	a.reportCrossAccountErrors(res, true)
+}
+
+// crossAccountLabelMap merges the label maps of the accounts in the results. Label IDs are per
+// account, so on a clash the active account's name wins.
+func (a *App) crossAccountLabelMap(res *services.CrossAccountSearchResult, activeID string) map[string]string {
+	accountIDs := make(map[string]struct{})
+	for _, m := range res.Messages {
+		accountIDs[m.AccountID] = struct{}{}
+	}
+	order := make([]string, 0, len(accountIDs)+1)
+	order = append(order, activeID)
+	for id := range accountIDs {
+		if id != activeID {
+			order = append(order, id)
+		}
+	}
+	sort.Strings(order[1:])
+
+	labels := make(map[string]string)
+	for _, id := range order {
+		client := a.Client
+		if id != activeID {
+			c, err := a.accountService.GetAccountClient(a.ctx, id)
+			if err != nil || c == nil {
+				continue
+			}
+			client = c
+		}
+		if client == nil {
+			continue
+		}
+		list, err := client.ListLabels()
+		if err != nil {
+			continue
+		}
+		for _, l := range list {
+			if _, exists := labels[l.Id]; !exists {
+				labels[l.Id] = l.Name
+			}
+		}
+	}
+	return labels
+}
+
+// reportCrossAccountErrors warns about accounts whose page could not be searched; more is set when
+// loading a next page, which those accounts keep for a retry
+func (a *App) reportCrossAccountErrors(res *services.CrossAccountSearchResult, more bool) {
+	if len(res.Errors) == 0 {
+		return
+	}
+	failed := make([]string, 0, len(res.Errors))
+	for id := range res.Errors {
+		failed = append(failed, id)
+	}
+	sort.Strings(failed)
+	if a.logger != nil {
+		for _, id := range failed {
+			a.logger.Printf("cross-account search: account %s failed: %v", id, res.Errors[id])
+		}
+	}
+	msg := fmt.Sprintf("Search skipped %d account(s): %s", len(failed), strings.Join(failed, ", "))
+	if more {
+		msg = fmt.Sprintf("Could not load more from %d account(s): %s — load more again to retry", len(failed), strings.Join(failed, ", "))
+	}
+	go a.GetErrorHandler().ShowWarning(a.ctx, msg)
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func crossPage(tokens map[string]string, hits ...[2]string) *services.CrossAccountSearchResult {
+	res := &services.CrossAccountSearchResult{NextPageTokens: tokens}
+	for _, h := range hits {
+		res.Messages = append(res.Messages, services.CrossAccountMessage{
+			AccountID:    h[0],
+			AccountLabel: "label-" + h[0],
+			Message:      &gmailapi.Message{Id: h[1]},
+		})
+	}
+	return res
+}
+
+func TestCrossAccountState_AddDedupesAndTracksOwners(t *testing.T) {
+	var s crossAccountState
+	if fresh := s.add(crossPage(nil, [2]string{"work", "m1"})); fresh != nil {
+		t.Fatalf("add outside cross-account mode must be ignored, got %v", fresh)
+	}
+
+	s.begin("from:bob", "work")
+	fresh := s.add(crossPage(map[string]string{"home": "t1"}, [2]string{"work", "m1"}, [2]string{"home", "m2"}))
+	if len(fresh) != 2 {
+		t.Fatalf("fresh = %d, want 2", len(fresh))
+	}
+	fresh = s.add(crossPage(nil, [2]string{"home", "m2"}, [2]string{"home", "m3"}))
+	if len(fresh) != 1 || fresh[0].Id != "m3" {
+		t.Fatalf("second page should only return m3, got %v", fresh)
+	}
+	if toks := s.pageTokens(); len(toks) != 0 {
+		t.Fatalf("tokens should be replaced by the last page, got %v", toks)
+	}
+	if got := s.accountLabel("m2"); got != "label-home" {
+		t.Fatalf("accountLabel(m2) = %q", got)
+	}
+	if _, foreign := s.foreignAccount("m1"); foreign {
+		t.Fatal("m1 belongs to the active account")
+	}
+	if id, foreign := s.foreignAccount("m3"); !foreign || id != "home" {
+		t.Fatalf("foreignAccount(m3) = %q, %v", id, foreign)
+	}
+
+	s.reset()
+	if s.isActive() || s.accountLabel("m2") != "" {
+		t.Fatal("reset should leave cross-account mode")
+	}
+	if _, foreign := s.foreignAccount("m3"); foreign {
+		t.Fatal("no message is foreign after reset")
+	}
+}
+
+func TestEffectiveSearchQuery(t *testing.T) {
+	if got := effectiveSearchQuery(" from:bob "); got != "from:bob -in:sent -in:draft -in:chat -in:spam -in:trash in:inbox" {
+		t.Errorf("got %q", got)
+	}
+	if got := effectiveSearchQuery("label:work"); got != "label:work" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestBlockForeignMessages(t *testing.T) {
+	a := &App{}
+	a.crossSearch.begin("from:bob", "work")
+	a.crossSearch.add(crossPage(nil, [2]string{"work", "m1"}, [2]string{"home", "m2"}))
+
+	if a.blockForeignMessages("m1") {
+		t.Fatal("m1 belongs to the active account and must not be blocked")
+	}
+	if owner, foreign := a.crossSearch.foreignOwner([]string{"m1", "m2"}); !foreign || owner != "label-home" {
+		t.Fatalf("foreignOwner = %q, %v", owner, foreign)
+	}
+	if !a.blockForeignMessages("m1", "m2") {
+		t.Fatal("a selection with a message of another account must be blocked")
+	}
+	// Blocked before touching the active client (nil here): trashing a foreign ID must not reach Gmail
+	a.trashSelectedByID("m2")
+
+	a.crossSearch.reset()
+	if a.blockForeignMessages("m2") {
+		t.Fatal("nothing is blocked outside cross-account mode")
+	}
+}
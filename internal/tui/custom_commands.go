@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Custom commands (custom_commands) are user-defined names for a sequence of command lines, e.g.
+// "work": "search label:work is:unread; threads". They complete in the command bar and are listed
+// in :help; a name that is also a built-in command or alias is ignored.
+
+// customCommandMaxDepth bounds custom commands that run other custom commands, so one that
+// (indirectly) runs itself stops with an error instead of looping
+const customCommandMaxDepth = 5
+
+// customArgPattern matches the argument placeholders of a definition: {{1}}…{{9}} and {{args}}
+var customArgPattern = regexp.MustCompile(`\{\{\s*(\d+|args)\s*\}\}`)
+
+// splitCommandLines splits a definition on ';' outside double quotes, dropping empty lines
+func splitCommandLines(def string) []string {
+	var lines []string
+	var current strings.Builder
+	inQuotes := false
+	flush := func() {
+		if line := normalizeStartupAction(current.String()); line != "" {
+			lines = append(lines, line)
+		}
+		current.Reset()
+	}
+	for _, r := range def {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	flush()
+	return lines
+}
+
+// quoteCommandArg quotes an argument holding spaces so it stays one argument once substituted
+func quoteCommandArg(arg string) string {
+	if strings.ContainsAny(arg, " \t") && !strings.Contains(arg, `"`) {
+		return `"` + arg + `"`
+	}
+	return arg
+}
+
+// expandCustomCommand returns the command lines a custom command definition runs for args:
+// {{n}} is the n-th argument ("" when missing) and {{args}} all of them. Arguments a definition
+// never refers to are appended to its last line, so "unread": "search is:unread" accepts
+// ":unread from:bob".
+func expandCustomCommand(def string, args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteCommandArg(arg)
+	}
+	used := make([]bool, len(args))
+	lines := splitCommandLines(def)
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(customArgPattern.ReplaceAllStringFunc(line, func(m string) string {
+			key := customArgPattern.FindStringSubmatch(m)[1]
+			if key == "args" {
+				for j := range used {
+					used[j] = true
+				}
+				return strings.Join(quoted, " ")
+			}
+			n, _ := strconv.Atoi(key)
+			if n < 1 || n > len(args) {
+				return ""
+			}
+			used[n-1] = true
+			return quoted[n-1]
+		}))
+	}
+	var rest []string
+	for i, u := range used {
+		if !u {
+			rest = append(rest, quoted[i])
+		}
+	}
+	if len(rest) > 0 && len(lines) > 0 {
+		lines[len(lines)-1] += " " + strings.Join(rest, " ")
+	}
+	return lines
+}
+
+// customCommandDefinition returns the definition of a custom command by name (case-insensitive).
+// Built-in commands win over custom ones of the same name.
+func (a *App) customCommandDefinition(name string) (string, bool) {
+	if a.Config == nil || len(a.Config.CustomCommands) == 0 || lookupCommand(name) != nil {
+		return "", false
+	}
+	for n, def := range a.Config.CustomCommands {
+		if strings.EqualFold(n, name) {
+			return def, strings.TrimSpace(def) != ""
+		}
+	}
+	return "", false
+}
+
+// customCommandNames returns the names of the usable custom commands, sorted
+func (a *App) customCommandNames() []string {
+	if a.Config == nil {
+		return nil
+	}
+	var names []string
+	for n, def := range a.Config.CustomCommands {
+		if strings.TrimSpace(def) != "" && lookupCommand(n) == nil {
+			names = append(names, strings.ToLower(n))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveCustomCommand expands a custom command into built-in command lines, expanding custom
+// commands it runs in turn
+func (a *App) resolveCustomCommand(name string, args []string, depth int) ([]string, error) {
+	def, ok := a.customCommandDefinition(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown custom command %q", name)
+	}
+	if depth >= customCommandMaxDepth {
+		return nil, fmt.Errorf("custom command :%s nests more than %d levels (does it run itself?)", name, customCommandMaxDepth)
+	}
+	var out []string
+	for _, line := range expandCustomCommand(def, args) {
+		parts := parseCommandArgs(line)
+		if len(parts) == 0 {
+			continue
+		}
+		if _, custom := a.customCommandDefinition(parts[0]); !custom {
+			out = append(out, line)
+			continue
+		}
+		nested, err := a.resolveCustomCommand(parts[0], parts[1:], depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, nested...)
+	}
+	return out, nil
+}
+
+// runCustomCommand runs a custom command if name is one, and reports whether it was. A single
+// line runs right away; several run in order, each waiting for the previous one to load.
+func (a *App) runCustomCommand(name string, args []string) bool {
+	if _, ok := a.customCommandDefinition(name); !ok {
+		return false
+	}
+	lines, err := a.resolveCustomCommand(name, args, 0)
+	if err != nil {
+		a.showError(err.Error())
+		return true
+	}
+	switch len(lines) {
+	case 0:
+		a.showError(fmt.Sprintf("Custom command :%s has no commands", name))
+	case 1:
+		a.dispatchCommand(lines[0])
+	default:
+		go a.commandSequence("custom command :"+name).run(a.ctx, lines)
+	}
+	return true
+}
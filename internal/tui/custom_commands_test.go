@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+)
+
+func TestExpandCustomCommand(t *testing.T) {
+	cases := []struct {
+		name string
+		def  string
+		args []string
+		want []string
+	}{
+		{"sequence", "search label:work is:unread; threads", nil, []string{"search label:work is:unread", "threads"}},
+		{"numbered args", ":search from:{{1}} subject:{{2}}", []string{"bob", "q3 budget"}, []string{`search from:bob subject:"q3 budget"`}},
+		{"all args", "search {{args}} is:unread", []string{"from:bob", "has:attachment"}, []string{"search from:bob has:attachment is:unread"}},
+		{"unused args go to the last line", "threads; search is:unread", []string{"from:bob"}, []string{"threads", "search is:unread from:bob"}},
+		{"missing arg is empty", "search from:{{1}}", nil, []string{"search from:"}},
+		{"quoted semicolon", `search "a;b"; threads`, nil, []string{`search "a;b"`, "threads"}},
+	}
+	for _, c := range cases {
+		if got := expandCustomCommand(c.def, c.args); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestResolveCustomCommand(t *testing.T) {
+	a := &App{Config: &config.Config{CustomCommands: map[string]string{
+		"work":      "todo-mail label:work; threads",
+		"Todo-Mail": "search is:unread {{args}}",
+		"loop":      "loop",
+		"search":    "archive", // a built-in name is never shadowed
+	}}}
+
+	got, err := a.resolveCustomCommand("work", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"search is:unread label:work", "threads"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("nested custom commands: got %q, want %q", got, want)
+	}
+
+	if _, err := a.resolveCustomCommand("loop", nil, 0); err == nil || !strings.Contains(err.Error(), "nests") {
+		t.Errorf("a command running itself must stop with an error, got %v", err)
+	}
+	if _, ok := a.customCommandDefinition("search"); ok {
+		t.Error("built-in commands must win over custom ones")
+	}
+	if want := []string{"loop", "todo-mail", "work"}; !reflect.DeepEqual(a.customCommandNames(), want) {
+		t.Errorf("names: got %v, want %v", a.customCommandNames(), want)
+	}
+	if got := a.commandCandidates("todo-"); len(got) != 1 || got[0] != "todo-mail" {
+		t.Errorf("custom commands should complete, got %v", got)
+	}
+}
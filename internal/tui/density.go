@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// Display densities (display.density)
+const (
+	densityComfortable = "comfortable"
+	densityCompact     = "compact"
+)
+
+// compactLabelsWidth caps the labels column in compact density: short chips plus a [+N] count
+const compactLabelsWidth = 8
+
+// parseDensity validates a display.density value; empty means comfortable
+func parseDensity(s string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", densityComfortable:
+		return densityComfortable, true
+	case densityCompact:
+		return densityCompact, true
+	}
+	return "", false
+}
+
+// density returns the configured display density
+func (a *App) density() string {
+	if a.Config == nil {
+		return densityComfortable
+	}
+	d, ok := parseDensity(a.Config.Display.Density)
+	if !ok {
+		return densityComfortable
+	}
+	return d
+}
+
+// isCompact reports whether the list and reader use the compact density
+func (a *App) isCompact() bool {
+	return a.density() == densityCompact
+}
+
+// applyListDensity pads the list sideways in comfortable density and drops the padding in compact
+func (a *App) applyListDensity(table *tview.Table) {
+	if a.isCompact() {
+		table.SetBorderPadding(0, 0, 0, 0)
+		return
+	}
+	table.SetBorderPadding(0, 0, 1, 1)
+}
+
+// applyRowDensity adjusts a flat list row: compact shortens the label chips, comfortable follows
+// the subject with the message snippet
+func (a *App) applyRowDensity(msg *gmailapi.Message, data *render.EmailColumnData) {
+	if msg == nil || len(data.Columns) < 4 {
+		return
+	}
+	if a.isCompact() {
+		data.Columns[3].Content = a.emailRenderer.FormatLabelsForColumn(msg, compactLabelsWidth)
+		data.Columns[3].MaxWidth = compactLabelsWidth
+		return
+	}
+	if snippet := rowSnippet(msg.Snippet); snippet != "" {
+		data.Columns[2].Content += " — " + snippet
+	}
+}
+
+// rowSnippet returns Gmail's snippet as plain text on one line
+func rowSnippet(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// formatMessageHeader renders the reader header for the density: compact keeps Subject, From
+// and Date; comfortable adds recipients and labels
+func (a *App) formatMessageHeader(m *gmail.Message, width int) string {
+	if a.isCompact() {
+		return a.emailRenderer.FormatHeaderCompactWithWidth(m.Subject, m.From, m.Date, width)
+	}
+	return a.emailRenderer.FormatHeaderPlainWithWidth(m.Subject, m.From, m.To, m.Cc, m.Date, m.Labels, width)
+}
+
+// executeDensityCommand handles :density [compact|comfortable] — switch (or toggle) the list and
+// reader density and save it to display.density
+func (a *App) executeDensityCommand(args []string) {
+	next := densityCompact
+	if a.isCompact() {
+		next = densityComfortable
+	}
+	if len(args) > 0 {
+		d, ok := parseDensity(args[0])
+		if !ok || args[0] == "" {
+			a.showError("Usage: density [compact|comfortable]")
+			return
+		}
+		next = d
+	}
+	if a.Config == nil {
+		return
+	}
+	a.Config.Display.Density = next
+	go func() {
+		if err := a.saveConfigAsync(); err != nil && a.logger != nil {
+			a.logger.Printf("Failed to save display density: %v", err)
+		}
+	}()
+
+	go func() {
+		a.QueueUpdateDraw(func() {
+			a.refreshTableDisplay()
+		})
+		if id := a.GetCurrentMessageID(); id != "" {
+			a.refreshMessageContent(id)
+		}
+		a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("📐 Density: %s", next))
+	}()
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/render"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestParseDensity(t *testing.T) {
+	for in, want := range map[string]string{"": "comfortable", "Compact": "compact", " comfortable ": "comfortable"} {
+		if got, ok := parseDensity(in); !ok || got != want {
+			t.Errorf("parseDensity(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := parseDensity("dense"); ok {
+		t.Error("parseDensity(dense) should be rejected")
+	}
+}
+
+func densityApp(density string) *App {
+	cfg := config.DefaultConfig()
+	cfg.Display.Density = density
+	er := render.NewEmailRenderer(cfg)
+	er.SetLabelMap(map[string]string{"L1": "Projects", "L2": "Finance", "L3": "Travel"})
+	return &App{Config: cfg, emailRenderer: er}
+}
+
+func densityRow(a *App, msg *gmailapi.Message) render.EmailColumnData {
+	data := a.emailRenderer.FormatFlatMessageColumns(msg)
+	a.applyRowDensity(msg, &data)
+	return data
+}
+
+func TestApplyRowDensity(t *testing.T) {
+	msg := &gmailapi.Message{
+		Id:       "m1",
+		Snippet:  "Let&#39;s meet\n tomorrow",
+		LabelIds: []string{"L1", "L2", "L3"},
+		Payload:  &gmailapi.MessagePart{Headers: []*gmailapi.MessagePartHeader{{Name: "Subject", Value: "Plan"}}},
+	}
+
+	comfortable := densityRow(densityApp("comfortable"), msg)
+	if got := comfortable.Columns[2].Content; got != "Plan — Let's meet tomorrow" {
+		t.Errorf("comfortable subject = %q, want the snippet after it", got)
+	}
+
+	compact := densityRow(densityApp("compact"), msg)
+	if got := compact.Columns[2].Content; got != "Plan" {
+		t.Errorf("compact subject = %q, want no snippet", got)
+	}
+	if got := compact.Columns[3].Content; len(got) > compactLabelsWidth || !strings.Contains(got, "+") {
+		t.Errorf("compact labels = %q, want short chips with a count", got)
+	}
+	if len(compact.Columns[3].Content) >= len(comfortable.Columns[3].Content) {
+		t.Errorf("compact labels %q should be shorter than %q", compact.Columns[3].Content, comfortable.Columns[3].Content)
+	}
+}
+
+func TestFormatMessageHeader_Density(t *testing.T) {
+	m := &gmail.Message{Subject: "Plan", From: "ana@example.com", To: "bo@example.com", Date: time.Now(), Labels: []string{"Work"}}
+
+	full := densityApp("comfortable").formatMessageHeader(m, 80)
+	if !strings.Contains(full, "bo@example.com") || !strings.Contains(full, "Work") {
+		t.Errorf("comfortable header lacks recipients or labels:\n%s", full)
+	}
+	brief := densityApp("compact").formatMessageHeader(m, 80)
+	if strings.Contains(brief, "bo@example.com") || strings.Contains(brief, "Labels") {
+		t.Errorf("compact header should keep only Subject, From and Date:\n%s", brief)
+	}
+	if !strings.Contains(brief, "Plan") || !strings.Contains(brief, "ana@example.com") {
+		t.Errorf("compact header lost subject or sender:\n%s", brief)
+	}
+}
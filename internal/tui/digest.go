@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+// parseDigestArgs parses ':digest [noai] [query]'. The query defaults to the unread inbox.
+func parseDigestArgs(args []string) services.DigestOptions {
+	var opts services.DigestOptions
+	var query []string
+	for _, arg := range args {
+		if strings.EqualFold(arg, "noai") {
+			opts.NoAI = true
+			continue
+		}
+		query = append(query, arg)
+	}
+	opts.Query = strings.Join(query, " ")
+	return opts
+}
+
+// executeDigestCommand handles :digest [noai] [query] — build a digest of the unread mail and
+// open it in the composer addressed to yourself; nothing is sent until you send it
+func (a *App) executeDigestCommand(args []string) {
+	if a.digestService == nil {
+		a.showError("Digest not available (no Gmail client)")
+		return
+	}
+	opts := parseDigestArgs(args)
+	opts.AccountEmail = a.getActiveAccountEmail()
+	if !opts.NoAI && a.aiService == nil {
+		opts.NoAI = true
+	}
+	go func() {
+		msg := "📨 Building unread digest…"
+		if !opts.NoAI {
+			msg = "📨 Building unread digest with AI one-liners…"
+		}
+		a.GetErrorHandler().ShowProgress(a.ctx, msg)
+		digest, err := a.digestService.Generate(a.ctx, opts)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error building digest", err)
+			return
+		}
+		if digest.OneLinerError != "" {
+			a.GetErrorHandler().ShowWarning(a.ctx, "Digest built without some AI one-liners: "+digest.OneLinerError)
+		}
+		a.composeDigest(digest)
+	}()
+}
+
+// composeDigest opens the digest in the composer, to the active account's own address
+func (a *App) composeDigest(digest *services.UnreadDigest) {
+	me := a.getActiveAccountEmail()
+	if me == "" || a.compositionPanel == nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Cannot compose the digest: account address unknown")
+		return
+	}
+	now := time.Now()
+	a.showCompositionWithDraft(&services.Composition{
+		Type:       services.CompositionTypeNew,
+		To:         []services.Recipient{{Email: me}},
+		Subject:    digest.Subject(),
+		Body:       digest.Body(),
+		CreatedAt:  now,
+		ModifiedAt: now,
+	})
+	a.GetErrorHandler().ShowInfo(a.ctx, "📨 Digest ready — review it and send when you are done")
+}
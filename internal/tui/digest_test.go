@@ -0,0 +1,14 @@
+package tui
+
+import "testing"
+
+func TestParseDigestArgs(t *testing.T) {
+	opts := parseDigestArgs(nil)
+	if opts.NoAI || opts.Query != "" {
+		t.Fatalf("defaults: got %+v", opts)
+	}
+	opts = parseDigestArgs([]string{"NoAI", "label:work", "is:unread"})
+	if !opts.NoAI || opts.Query != "label:work is:unread" {
+		t.Fatalf("got %+v", opts)
+	}
+}
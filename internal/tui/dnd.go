@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dndState is the manual do-not-disturb override. It lasts until the quiet-hours schedule next
+// changes state, so ":dnd off" during the night does not disable tomorrow's quiet hours.
+type dndState struct {
+	mu        sync.Mutex
+	override  *bool     // manual setting; nil follows the schedule
+	scheduled bool      // schedule state when the override was set
+	last      bool      // last reported state, to notice schedule boundaries
+	until     time.Time // ":dnd until": on until then, whatever the schedule says
+}
+
+// active returns the effective state given the schedule's current one
+func (d *dndState) active(scheduled bool) bool {
+	return d.activeAt(scheduled, time.Now())
+}
+
+// activeAt is active at a given time
+func (d *dndState) activeAt(scheduled bool, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.until.IsZero() {
+		if now.Before(d.until) {
+			return true
+		}
+		d.until = time.Time{}
+	}
+	if d.override != nil && d.scheduled != scheduled {
+		d.override = nil
+	}
+	if d.override != nil {
+		return *d.override
+	}
+	return scheduled
+}
+
+// set forces the state until the next schedule boundary
+func (d *dndState) set(on, scheduled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.until = time.Time{}
+	if on == scheduled {
+		d.override = nil
+		return
+	}
+	d.override = &on
+	d.scheduled = scheduled
+}
+
+// clear drops the override so the schedule applies
+func (d *dndState) clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.override = nil
+	d.until = time.Time{}
+}
+
+// setUntil keeps do-not-disturb on until t; the schedule applies again afterwards
+func (d *dndState) setUntil(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.override = nil
+	d.until = t
+}
+
+// changed records the current state and reports whether it differs from the last one recorded
+func (d *dndState) changed(now bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed := d.last != now
+	d.last = now
+	return changed
+}
+
+// dndScheduled reports whether the configured quiet hours are active now
+func (a *App) dndScheduled() bool {
+	return a.Config != nil && a.Config.DoNotDisturb.Active(time.Now())
+}
+
+// dndActive reports whether new-mail notifications are currently suppressed
+func (a *App) dndActive() bool {
+	if a == nil {
+		return false
+	}
+	return a.dnd.active(a.dndScheduled())
+}
+
+// dndIndicator is the status-bar segment shown during quiet hours
+func (a *App) dndIndicator() string {
+	if a.dndActive() {
+		return "🌙"
+	}
+	return ""
+}
+
+// executeDNDCommand handles :dnd [on|off|auto|until <when>] — without an argument it toggles
+// do-not-disturb until the quiet-hours schedule next changes; auto drops the manual setting;
+// until keeps it on until a quick date such as "tomorrow 9am" or "in 2h"
+func (a *App) executeDNDCommand(args []string) {
+	scheduled := a.dndScheduled()
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(args[0])
+	}
+	if arg == "until" {
+		until, err := a.parseQuickDate(strings.Join(args[1:], " "))
+		if err != nil {
+			a.showError(fmt.Sprintf("dnd until: %v", err))
+			return
+		}
+		a.dnd.setUntil(until)
+		a.dnd.changed(true)
+		msg := "🌙 Do not disturb ON until " + a.formatQuickDate(until) + " — new mail syncs silently"
+		go func() {
+			a.GetErrorHandler().ShowInfo(a.ctx, msg)
+			a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+		}()
+		return
+	}
+	switch arg {
+	case "":
+		a.dnd.set(!a.dnd.active(scheduled), scheduled)
+	case "on":
+		a.dnd.set(true, scheduled)
+	case "off":
+		a.dnd.set(false, scheduled)
+	case "auto":
+		a.dnd.clear()
+	default:
+		a.showError("Usage: dnd [on|off|auto|until <when>]")
+		return
+	}
+	on := a.dnd.active(scheduled)
+	a.dnd.changed(on)
+	msg := "🔔 Do not disturb OFF — new-mail notifications resume"
+	if on {
+		msg = "🌙 Do not disturb ON — new mail syncs silently"
+	}
+	if arg == "auto" {
+		msg += " (following quiet hours)"
+	}
+	go func() {
+		a.GetErrorHandler().ShowInfo(a.ctx, msg)
+		a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+	}()
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+)
+
+func TestDNDState_OverrideLastsUntilScheduleChanges(t *testing.T) {
+	var d dndState
+	if d.active(false) || !d.active(true) {
+		t.Fatal("without an override the schedule decides")
+	}
+
+	// Turned off during quiet hours: stays off for the rest of the window
+	d.set(false, true)
+	if d.active(true) {
+		t.Fatal("override off should win while the window lasts")
+	}
+	// Window ends, then the next one starts: the schedule applies again
+	if d.active(false) {
+		t.Fatal("outside the window dnd is off")
+	}
+	if !d.active(true) {
+		t.Fatal("the next window should be quiet again")
+	}
+
+	// Turned on outside quiet hours, then auto
+	d.set(true, false)
+	if !d.active(false) {
+		t.Fatal("override on should win")
+	}
+	d.clear()
+	if d.active(false) {
+		t.Fatal("auto should follow the schedule")
+	}
+
+	// Setting the schedule's own state is not an override
+	d.set(true, true)
+	if d.active(false) {
+		t.Fatal("matching the schedule should not leave an override behind")
+	}
+}
+
+func TestDNDState_Until(t *testing.T) {
+	var d dndState
+	now := time.Now()
+	d.setUntil(now.Add(time.Hour))
+	if !d.activeAt(false, now) {
+		t.Fatal("dnd until should be on before the time")
+	}
+	if !d.activeAt(true, now.Add(2*time.Hour)) || d.activeAt(false, now.Add(2*time.Hour)) {
+		t.Fatal("after the time the schedule decides again")
+	}
+	d.setUntil(now.Add(time.Hour))
+	d.set(false, false)
+	if d.activeAt(false, now) {
+		t.Fatal(":dnd off ends dnd until")
+	}
+}
+
+func TestQuickDatePreview(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.QuickDates.Locale = "es"
+	a := &App{Config: cfg}
+	if got := a.quickDatePreview("dnd until mañana 10:00"); !strings.HasPrefix(got, "→ ") || !strings.Contains(got, "10:00") {
+		t.Errorf("preview should show the resolved time, got %q", got)
+	}
+	if got := a.quickDatePreview("dnd until whenever"); !strings.HasPrefix(got, "✗ ") {
+		t.Errorf("preview should say why a date does not resolve, got %q", got)
+	}
+	if got := a.quickDatePreview("dnd on"); got != "" {
+		t.Errorf("other commands have no preview, got %q", got)
+	}
+	if got := a.quickDatePreview("timemachine ayer"); !strings.HasPrefix(got, "→ ") || !strings.Contains(got, "ago") {
+		t.Errorf(":timemachine previews a past day, got %q", got)
+	}
+	if got := a.quickDatePreview("tm mañana"); !strings.HasPrefix(got, "✗ ") {
+		t.Errorf(":timemachine rejects a future day, got %q", got)
+	}
+	if got := a.quickDatePreview("timemachine list"); got != "" {
+		t.Errorf("subcommands have no preview, got %q", got)
+	}
+}
@@ -2,12 +2,14 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 )
@@ -20,8 +22,55 @@ const (
 	LogLevelWarning
 	LogLevelError
 	LogLevelSuccess
+	LogLevelDetail // cache hits and similar diagnostics, shown only at verbose verbosity
 )
 
+// StatusVerbosity decides which status messages are shown (display.status_verbosity). Hidden
+// messages are still logged; progress messages are always shown.
+type StatusVerbosity int
+
+const (
+	StatusVerbosityNormal  StatusVerbosity = iota // everything but details
+	StatusVerbosityErrors                         // errors and warnings only
+	StatusVerbosityVerbose                        // everything, including details such as cache hits
+)
+
+// ParseStatusVerbosity parses "errors", "normal" or "verbose" ("" is normal)
+func ParseStatusVerbosity(s string) (StatusVerbosity, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "normal":
+		return StatusVerbosityNormal, true
+	case "errors", "quiet":
+		return StatusVerbosityErrors, true
+	case "verbose":
+		return StatusVerbosityVerbose, true
+	}
+	return StatusVerbosityNormal, false
+}
+
+func (v StatusVerbosity) String() string {
+	switch v {
+	case StatusVerbosityErrors:
+		return "errors"
+	case StatusVerbosityVerbose:
+		return "verbose"
+	default:
+		return "normal"
+	}
+}
+
+// Shows reports whether messages of a level are shown at this verbosity
+func (v StatusVerbosity) Shows(level LogLevel) bool {
+	switch v {
+	case StatusVerbosityErrors:
+		return level == LogLevelError || level == LogLevelWarning
+	case StatusVerbosityVerbose:
+		return true
+	default:
+		return level != LogLevelDetail
+	}
+}
+
 // ErrorHandler provides consistent error handling and user feedback
 type ErrorHandler struct {
 	mu         sync.RWMutex
@@ -35,6 +84,7 @@ type ErrorHandler struct {
 	currentStatus    string
 	persistentStatus string
 	statusTimer      *time.Timer
+	verbosity        StatusVerbosity
 }
 
 // NewErrorHandler creates a new error handler
@@ -67,6 +117,20 @@ func (eh *ErrorHandler) HandleError(ctx context.Context, err error, userMsg stri
 	eh.ShowMessage(ctx, userMsg, LogLevelError)
 }
 
+// SetVerbosity sets which status messages are shown
+func (eh *ErrorHandler) SetVerbosity(v StatusVerbosity) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	eh.verbosity = v
+}
+
+// Verbosity returns which status messages are shown
+func (eh *ErrorHandler) Verbosity() StatusVerbosity {
+	eh.mu.RLock()
+	defer eh.mu.RUnlock()
+	return eh.verbosity
+}
+
 // ShowMessage displays a message to the user
 func (eh *ErrorHandler) ShowMessage(ctx context.Context, msg string, level LogLevel) {
 	if strings.TrimSpace(msg) == "" {
@@ -82,6 +146,10 @@ func (eh *ErrorHandler) ShowMessage(ctx context.Context, msg string, level LogLe
 		eh.logger.Printf("%s: %s", levelStr, msg)
 	}
 
+	if !eh.Verbosity().Shows(level) {
+		return
+	}
+
 	// Update UI in the main thread
 	if eh.app != nil {
 		eh.app.QueueUpdateDraw(func() {
@@ -126,6 +194,9 @@ func (eh *ErrorHandler) ShowFlashMessage(ctx context.Context, msg string, level
 		return
 	}
 
+	if !eh.Verbosity().Shows(level) {
+		return
+	}
 	formattedMsg := eh.formatMessage(msg, level)
 
 	if eh.app != nil {
@@ -162,6 +233,8 @@ func (eh *ErrorHandler) formatMessage(msg string, level LogLevel) string {
 		icon = "❌"
 	case LogLevelSuccess:
 		icon = "✅"
+	case LogLevelDetail:
+		icon = "⚪"
 	default:
 		icon = "•"
 	}
@@ -180,6 +253,8 @@ func (eh *ErrorHandler) levelToString(level LogLevel) string {
 		return "ERROR"
 	case LogLevelSuccess:
 		return "SUCCESS"
+	case LogLevelDetail:
+		return "DETAIL"
 	default:
 		return "UNKNOWN"
 	}
@@ -307,6 +382,11 @@ func (eh *ErrorHandler) ShowInfo(ctx context.Context, msg string) {
 	eh.ShowMessage(ctx, msg, LogLevelInfo)
 }
 
+// ShowDetail shows a diagnostic message (cache hits and the like) at verbose verbosity only
+func (eh *ErrorHandler) ShowDetail(ctx context.Context, msg string) {
+	eh.ShowMessage(ctx, msg, LogLevelDetail)
+}
+
 // ShowWarning shows a warning message
 func (eh *ErrorHandler) ShowWarning(ctx context.Context, msg string) {
 	eh.ShowMessage(ctx, msg, LogLevelWarning)
@@ -322,6 +402,47 @@ func (eh *ErrorHandler) ShowSuccess(ctx context.Context, msg string) {
 	eh.ShowMessage(ctx, msg, LogLevelSuccess)
 }
 
+// describeError turns err into the status text for a failed action: what failed, why in plain
+// words and what to do next. Gmail errors are classified with services.ClassifyError, so raw
+// client errors get the same wording as typed errors returned by the services. Throttling and
+// network trouble are warnings since they usually clear on their own.
+func describeError(action string, err error) (string, LogLevel) {
+	err = services.ClassifyError("", err)
+	var (
+		authErr     *services.AuthError
+		quotaErr    *services.QuotaError
+		networkErr  *services.NetworkError
+		notFoundErr *services.NotFoundError
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return fmt.Sprintf("%s: Gmail access expired or was revoked — restart GizTUI to sign in again (or run giztui --setup)", action), LogLevelError
+	case errors.As(err, &quotaErr):
+		wait := "wait a moment and try again"
+		if quotaErr.RetryAfter > 0 {
+			wait = fmt.Sprintf("try again in %s", quotaErr.RetryAfter.Round(time.Second))
+		}
+		return fmt.Sprintf("%s: Gmail rate limit reached — %s", action, wait), LogLevelWarning
+	case errors.As(err, &networkErr):
+		return fmt.Sprintf("%s: can't reach Gmail — check your connection and try again", action), LogLevelWarning
+	case errors.As(err, &notFoundErr):
+		return fmt.Sprintf("%s: it no longer exists in Gmail (deleted elsewhere?) — refresh the list", action), LogLevelError
+	}
+	return fmt.Sprintf("%s: %s", action, shortErrorText(err, 160)), LogLevelError
+}
+
+// ShowErrorFor reports a failed action with a message and suggested fix chosen from the error type
+func (eh *ErrorHandler) ShowErrorFor(ctx context.Context, action string, err error) {
+	if err == nil {
+		return
+	}
+	if eh.logger != nil {
+		eh.logger.Printf("ERROR: %s: %v", action, err)
+	}
+	msg, level := describeError(action, err)
+	eh.ShowMessage(ctx, msg, level)
+}
+
 // ShowLLMError shows an LLM-specific error with context
 func (eh *ErrorHandler) ShowLLMError(ctx context.Context, operation string, err error) {
 	userMsg := fmt.Sprintf("AI %s failed", operation)
@@ -330,8 +451,7 @@ func (eh *ErrorHandler) ShowLLMError(ctx context.Context, operation string, err
 
 // ShowGmailError shows a Gmail API error with context
 func (eh *ErrorHandler) ShowGmailError(ctx context.Context, operation string, err error) {
-	userMsg := fmt.Sprintf("Gmail %s failed", operation)
-	eh.HandleError(ctx, err, userMsg)
+	eh.ShowErrorFor(ctx, fmt.Sprintf("Gmail %s failed", operation), err)
 }
 
 // ShowProgress shows a progress message
@@ -7,7 +7,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tview"
 	"github.com/stretchr/testify/assert"
 )
@@ -423,3 +425,53 @@ func TestErrorHandler_StatusAutoClearing(t *testing.T) {
 
 	assert.True(t, hasTimer, "Info messages should auto-clear when no persistent status")
 }
+
+func TestDescribeError_SuggestsActionByType(t *testing.T) {
+	cases := []struct {
+		err   error
+		want  string
+		level LogLevel
+	}{
+		{&services.AuthError{Err: errors.New("401")}, "restart GizTUI to sign in again", LogLevelError},
+		{&services.QuotaError{Err: errors.New("429"), RetryAfter: 30 * time.Second}, "rate limit reached — try again in 30s", LogLevelWarning},
+		{&services.NetworkError{Err: errors.New("dial tcp")}, "can't reach Gmail", LogLevelWarning},
+		{&services.NotFoundError{Err: errors.New("404")}, "no longer exists in Gmail", LogLevelError},
+		{context.DeadlineExceeded, "can't reach Gmail", LogLevelWarning}, // raw client errors are classified too
+		{errors.New("invalid query"), "Error loading messages: invalid query", LogLevelError},
+	}
+	for _, c := range cases {
+		msg, level := describeError("Error loading messages", c.err)
+		assert.Contains(t, msg, c.want)
+		assert.True(t, strings.HasPrefix(msg, "Error loading messages: "), msg)
+		assert.Equal(t, c.level, level, msg)
+	}
+}
+
+func TestStatusVerbosity(t *testing.T) {
+	for in, want := range map[string]StatusVerbosity{"": StatusVerbosityNormal, "Errors": StatusVerbosityErrors, "quiet": StatusVerbosityErrors, "verbose": StatusVerbosityVerbose} {
+		v, ok := ParseStatusVerbosity(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, v, in)
+	}
+	_, ok := ParseStatusVerbosity("loud")
+	assert.False(t, ok)
+
+	assert.True(t, StatusVerbosityErrors.Shows(LogLevelWarning))
+	assert.False(t, StatusVerbosityErrors.Shows(LogLevelSuccess))
+	assert.True(t, StatusVerbosityNormal.Shows(LogLevelInfo))
+	assert.False(t, StatusVerbosityNormal.Shows(LogLevelDetail))
+	assert.True(t, StatusVerbosityVerbose.Shows(LogLevelDetail))
+}
+
+func TestErrorHandler_SetVerbosity(t *testing.T) {
+	eh := NewErrorHandler(nil, nil, nil, nil, nil)
+	assert.Equal(t, StatusVerbosityNormal, eh.Verbosity())
+	eh.SetVerbosity(StatusVerbosityErrors)
+	assert.Equal(t, "errors", eh.Verbosity().String())
+
+	// Filtered messages are dropped before touching the UI
+	assert.NotPanics(t, func() {
+		eh.ShowInfo(context.Background(), "hidden")
+		eh.ShowDetail(context.Background(), "hidden")
+	})
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// externalRecipientsPage is the overlay listing the external recipients of a message about to be sent
+const externalRecipientsPage = "externalRecipients"
+
+// activeInternalDomains are the internal domains of the active account; none means no check
+func (a *App) activeInternalDomains() []string {
+	accountService := a.GetAccountService()
+	if accountService == nil {
+		return nil
+	}
+	account, err := accountService.GetActiveAccount(a.ctx)
+	if err != nil || account == nil {
+		return nil
+	}
+	return account.InternalDomains
+}
+
+// formatExternalRecipientsTitle is the warning shown before sending outside the organization
+func formatExternalRecipientsTitle(n int) string {
+	if n == 1 {
+		return "1 recipient is outside your organization"
+	}
+	return fmt.Sprintf("%d recipients are outside your organization", n)
+}
+
+// checkExternalRecipients returns true when sending may proceed. When the composition goes to
+// addresses outside the active account's internal domains that weren't confirmed yet, it opens
+// the confirmation overlay instead. Editing the recipients asks again.
+func (c *CompositionPanel) checkExternalRecipients() bool {
+	external := services.ExternalRecipients(c.composition, c.app.activeInternalDomains())
+	if len(external) == 0 {
+		return true
+	}
+	key := strings.ToLower(strings.Join(external, ","))
+	if key == c.externalAcknowledged {
+		return true
+	}
+	c.app.QueueUpdateDraw(func() {
+		c.showExternalRecipientsWarning(external, key)
+	})
+	return false
+}
+
+// showExternalRecipientsWarning lists the external addresses; Enter sends anyway, Esc returns to
+// editing. Must run on the UI goroutine.
+func (c *CompositionPanel) showExternalRecipientsWarning(external []string, key string) {
+	colors := c.app.GetComponentColors("compose")
+	bgColor := colors.Background.Color()
+	warnColor := c.app.GetStatusColor("warning")
+
+	var b strings.Builder
+	b.WriteString("Check that these people may receive this message:\n\n")
+	for _, addr := range external {
+		fmt.Fprintf(&b, "  • %s\n", addr)
+	}
+	body := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+	body.SetText(b.String())
+	body.SetTextColor(warnColor)
+	body.SetBackgroundColor(bgColor)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to send anyway  |  Esc to keep editing ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+
+	box := tview.NewFlex().SetDirection(tview.FlexRow)
+	box.SetBorder(true).
+		SetTitle(" ⚠️ " + formatExternalRecipientsTitle(len(external)) + " ").
+		SetTitleColor(warnColor).
+		SetBorderColor(warnColor).
+		SetBackgroundColor(bgColor)
+	box.AddItem(body, 0, 1, true)
+	box.AddItem(footer, 1, 0, false)
+
+	closeWarning := func() {
+		c.app.Pages.RemovePage(externalRecipientsPage)
+		c.updateSendButtonState("normal")
+		c.focusCurrent()
+	}
+	box.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEscape:
+			closeWarning()
+			return nil
+		case tcell.KeyEnter:
+			closeWarning()
+			c.externalAcknowledged = key
+			go c.sendComposition()
+			return nil
+		}
+		return ev
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(box, len(external)+6, 0, true).
+			AddItem(nil, 0, 1, false), 72, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	c.app.Pages.AddPage(externalRecipientsPage, overlay, true, true)
+	c.app.SetFocus(body)
+}
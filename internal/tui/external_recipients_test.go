@@ -0,0 +1,12 @@
+package tui
+
+import "testing"
+
+func TestFormatExternalRecipientsTitle(t *testing.T) {
+	if got := formatExternalRecipientsTitle(1); got != "1 recipient is outside your organization" {
+		t.Errorf("singular title = %q", got)
+	}
+	if got := formatExternalRecipientsTitle(3); got != "3 recipients are outside your organization" {
+		t.Errorf("plural title = %q", got)
+	}
+}
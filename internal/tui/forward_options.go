@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// forwardOptionsPage is the Pages name of the forward options form
+const forwardOptionsPage = "forwardOptions"
+
+// isForwarding reports whether the panel is composing a forward of an existing message
+func (c *CompositionPanel) isForwarding() bool {
+	return c.composition != nil && c.composition.Type == services.CompositionTypeForward && c.composition.OriginalID != ""
+}
+
+// refreshForwardControls shows the forward options button and the attachment count only while
+// forwarding. Runs on the UI goroutine.
+func (c *CompositionPanel) refreshForwardControls() {
+	c.buttonRow.Clear()
+	c.buttonRow.AddItem(c.spacer1, 0, 4, false)
+	if c.isForwarding() {
+		c.buttonRow.AddItem(c.forwardButton, 0, 1, false)
+		c.buttonRow.AddItem(c.spacer4, 0, 1, false)
+	}
+	c.buttonRow.AddItem(c.sendButton, 0, 1, false)
+	c.buttonRow.AddItem(c.spacer2, 0, 1, false)
+	c.buttonRow.AddItem(c.draftButton, 0, 1, false)
+	c.buttonRow.AddItem(c.spacer3, 0, 1, false)
+
+	hint := "Ctrl+Enter to Send | Esc to cancel"
+	if c.isForwarding() {
+		hint = fmt.Sprintf("📎 %d attachment(s) | Ctrl+O forward options | %s", len(c.composition.Attachments), hint)
+	}
+	c.hintTextView.SetText(hint)
+	c.updateFocusOrder()
+}
+
+// openForwardOptions shows the forward options over the composer: keep or strip the quoted history,
+// choose the attachments to carry over and optionally add an AI note on why it is forwarded
+func (c *CompositionPanel) openForwardOptions() {
+	if !c.isForwarding() {
+		return
+	}
+	a := c.app
+	colors := a.GetComponentColors("compose")
+
+	form := tview.NewForm()
+	form.SetBackgroundColor(colors.Background.Color())
+	form.SetButtonBackgroundColor(colors.Accent.Color())
+	form.SetButtonTextColor(colors.Background.Color())
+	form.SetLabelColor(colors.Title.Color())
+	form.SetFieldBackgroundColor(colors.Border.Color())
+	form.SetFieldTextColor(colors.Text.Color())
+	form.SetBorder(true).
+		SetTitle(" ↪️ Forward options ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+
+	includeQuoted := !c.forwardStripped
+	form.AddCheckbox("Include quoted history", includeQuoted, func(_ string, checked bool) { includeQuoted = checked })
+
+	selected := make(map[string]bool, len(c.composition.Attachments))
+	for _, att := range c.composition.Attachments {
+		selected[att.ID] = true
+	}
+	for _, att := range c.forwardAttachments {
+		id := att.ID
+		label := fmt.Sprintf("📎 %s (%s)", att.Filename, formatFileSize(att.Size))
+		form.AddCheckbox(label, selected[id], func(_ string, checked bool) { selected[id] = checked })
+	}
+
+	addNote := false
+	if a.aiService != nil {
+		form.AddCheckbox("Add AI note on why it's forwarded", false, func(_ string, checked bool) { addNote = checked })
+	}
+
+	closeForm := func() {
+		a.Pages.RemovePage(forwardOptionsPage)
+		c.focusCurrent()
+	}
+	form.AddButton("Apply", func() {
+		var atts []services.Attachment
+		for _, att := range c.forwardAttachments {
+			if selected[att.ID] {
+				atts = append(atts, att)
+			}
+		}
+		closeForm()
+		go c.applyForwardOptions(includeQuoted, atts, addNote)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	height := 7 + 2*(len(c.forwardAttachments)+1)
+	if a.aiService != nil {
+		height += 2
+	}
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, height, 0, true).
+			AddItem(nil, 0, 1, false), 70, 0, true).
+		AddItem(nil, 0, 1, false)
+	a.Pages.AddPage(forwardOptionsPage, overlay, true, true)
+	a.SetFocus(form)
+}
+
+// applyForwardOptions rebuilds the forwarded section of the body (keeping what the user wrote
+// above it), sets the attachments and prepends the AI note
+func (c *CompositionPanel) applyForwardOptions(includeQuoted bool, atts []services.Attachment, addNote bool) {
+	a := c.app
+	if c.composition == nil {
+		return
+	}
+	originalID := c.composition.OriginalID
+	_, _, _, _, _, compositionService, _, _, _, _, _, _ := a.GetServices()
+
+	forwarded, err := compositionService.ForwardBody(a.ctx, originalID, includeQuoted)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error rebuilding forwarded message", err)
+		return
+	}
+	note := ""
+	if addNote {
+		a.GetErrorHandler().ShowProgress(a.ctx, "🧠 Writing forwarding note…")
+		note, err = compositionService.ForwardNote(a.ctx, originalID)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error writing forwarding note", err)
+		}
+	}
+
+	a.QueueUpdateDraw(func() {
+		if c.composition == nil || c.composition.OriginalID != originalID {
+			return // composer closed or reused meanwhile
+		}
+		body := services.ReplaceForwardedSection(c.bodySection.GetText(), forwarded)
+		if note != "" {
+			body = note + "\n\n" + strings.TrimLeft(body, "\n")
+		}
+		c.bodySection.SetText(body)
+		c.composition.Body = body
+		c.composition.Attachments = atts
+		c.forwardStripped = !includeQuoted
+		c.refreshForwardControls()
+	})
+	a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("↪️ Forward updated: %d attachment(s)", len(atts)))
+}
+
+// handleForwardOptionsKey opens the forward options on Ctrl+O while forwarding
+func (c *CompositionPanel) handleForwardOptionsKey(event *tcell.EventKey) bool {
+	if event.Key() == tcell.KeyCtrlO && c.isForwarding() {
+		c.openForwardOptions()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/gmail"
+)
+
+// parseHTMLPreviewArgs reads :html [images|noimages]; without an argument the configured image
+// blocking applies
+func parseHTMLPreviewArgs(args []string, blockByDefault bool) (bool, error) {
+	if len(args) == 0 {
+		return blockByDefault, nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "images", "img":
+		return false, nil
+	case "noimages", "noimg", "block":
+		return true, nil
+	}
+	return false, fmt.Errorf("usage: html [images|noimages]")
+}
+
+// executeHTMLCommand handles :html [images|noimages] — write the current message's HTML part to
+// a temp file and open it in the browser, with remote images blocked unless asked for
+func (a *App) executeHTMLCommand(args []string) {
+	if a.htmlPreviewService == nil {
+		a.showError("HTML preview not available (no Gmail client)")
+		return
+	}
+	blockImages, err := parseHTMLPreviewArgs(args, a.htmlPreviewService.BlockImagesByDefault())
+	if err != nil {
+		a.showError(err.Error())
+		return
+	}
+	id := a.getCurrentMessageID()
+	if id == "" {
+		id = a.currentMessageID
+	}
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	go a.openHTMLPreview(id, blockImages)
+}
+
+// openHTMLPreview writes the preview and hands it to the browser
+func (a *App) openHTMLPreview(messageID string, blockImages bool) {
+	var m *gmail.Message
+	if cached, ok := a.caches.messageGet(messageID); ok {
+		m = cached
+	} else {
+		fetched, err := a.messageClient(messageID).GetMessageWithContent(messageID)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading message", err)
+			return
+		}
+		m = fetched
+	}
+
+	preview, err := a.htmlPreviewService.WritePreview(a.ctx, m, blockImages)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error writing HTML preview", err)
+		return
+	}
+	if err := a.htmlPreviewService.OpenInBrowser(a.ctx, preview.Path); err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error opening browser", err)
+		return
+	}
+
+	msg := "🌐 Opened HTML in browser: " + preview.Path
+	if blockImages && preview.BlockedImages > 0 {
+		msg = fmt.Sprintf("🌐 Opened HTML in browser (%d remote image(s) blocked — :html images to load them)", preview.BlockedImages)
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, msg)
+}
@@ -0,0 +1,20 @@
+package tui
+
+import "testing"
+
+func TestParseHTMLPreviewArgs(t *testing.T) {
+	for _, def := range []bool{true, false} {
+		if block, err := parseHTMLPreviewArgs(nil, def); err != nil || block != def {
+			t.Errorf("default %v: got %v %v", def, block, err)
+		}
+	}
+	if block, err := parseHTMLPreviewArgs([]string{"Images"}, true); err != nil || block {
+		t.Errorf("images: got %v %v", block, err)
+	}
+	if block, err := parseHTMLPreviewArgs([]string{"noimages"}, false); err != nil || !block {
+		t.Errorf("noimages: got %v %v", block, err)
+	}
+	if _, err := parseHTMLPreviewArgs([]string{"pdf"}, true); err == nil {
+		t.Error("pdf: want error")
+	}
+}
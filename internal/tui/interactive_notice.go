@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/derailed/tview"
+)
+
+// formatInteractiveNotice explains what of the message can't work in the terminal and how to open
+// it in Gmail web instead; "" when there is nothing to explain. markup escapes it for tview.
+func formatInteractiveNotice(c render.InteractiveContent, openKey string, markup bool) string {
+	if !c.Any() {
+		return ""
+	}
+	var lines []string
+	switch {
+	case c.AMPOnly:
+		lines = append(lines, "⚡ This email is interactive (AMP for Email) and has no plain version the terminal can show.")
+	case c.AMP:
+		lines = append(lines, "⚡ This email has an interactive AMP version; below is its static version, which may be out of date.")
+	}
+	if c.Forms > 0 {
+		fields := ""
+		if c.FormFields > 0 {
+			fields = fmt.Sprintf(" (%d field(s))", c.FormFields)
+		}
+		lines = append(lines, fmt.Sprintf("📝 It contains %d form(s)%s that can't be filled in or submitted from the terminal.", c.Forms, fields))
+	}
+	lines = append(lines, fmt.Sprintf("🌐 Press %s to open it in Gmail web.", openKey))
+
+	text := strings.Join(lines, "\n")
+	if markup {
+		text = tview.Escape(text)
+	}
+	return text + "\n" + strings.Repeat("─", 40) + "\n\n"
+}
+
+// withInteractiveNotice puts the AMP/form notice above the rendered body. An AMP-only message
+// would render as empty or broken markup, so the notice replaces it.
+func (a *App) withInteractiveNotice(m *gmail.Message, rendered string, markup bool) string {
+	c := render.DetectInteractiveContent(m)
+	notice := formatInteractiveNotice(c, a.Keys.OpenGmail, markup)
+	if notice == "" {
+		return rendered
+	}
+	if c.AMPOnly {
+		return notice
+	}
+	return notice + rendered
+}
@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/render"
+)
+
+func TestFormatInteractiveNotice(t *testing.T) {
+	if got := formatInteractiveNotice(render.InteractiveContent{}, "O", true); got != "" {
+		t.Errorf("no interactive content should have no notice, got %q", got)
+	}
+
+	got := formatInteractiveNotice(render.InteractiveContent{AMP: true, Forms: 1, FormFields: 3}, "O", true)
+	for _, want := range []string{"AMP", "static version", "1 form(s) (3 field(s))", "Press O to open it in Gmail web"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("notice lacks %q:\n%s", want, got)
+		}
+	}
+
+	only := formatInteractiveNotice(render.InteractiveContent{AMP: true, AMPOnly: true}, "O", true)
+	if !strings.Contains(only, "no plain version") || strings.Contains(only, "form") {
+		t.Errorf("AMP-only notice = %q", only)
+	}
+}
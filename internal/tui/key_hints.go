@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/derailed/tview"
+)
+
+// Key hint contexts, derived from focus and the open panels
+const (
+	hintContextList     = "list"
+	hintContextContent  = "content"
+	hintContextPicker   = "picker"
+	hintContextComposer = "composer"
+)
+
+// maxKeyHints caps the bar so it stays a single, readable line
+const maxKeyHints = 8
+
+// keyHint is one entry of the hints registry: a description and the binding it shows. key reads
+// the binding from the user's config so remapped keys show up as remapped; fixed keys ignore it.
+type keyHint struct {
+	desc string
+	key  func(k config.KeyBindings) string
+}
+
+func fixedKey(key string) func(config.KeyBindings) string {
+	return func(config.KeyBindings) string { return key }
+}
+
+// keyHintRegistry lists the most relevant actions per context, most useful first
+var keyHintRegistry = map[string][]keyHint{
+	hintContextList: {
+		{"open", fixedKey("enter")},
+		{"reply", func(k config.KeyBindings) string { return k.Reply }},
+		{"archive", func(k config.KeyBindings) string { return k.Archive }},
+		{"trash", func(k config.KeyBindings) string { return k.Trash }},
+		{"read/unread", func(k config.KeyBindings) string { return k.ToggleRead }},
+		{"search", func(k config.KeyBindings) string { return k.Search }},
+		{"compose", func(k config.KeyBindings) string { return k.Compose }},
+		{"summary", func(k config.KeyBindings) string { return k.Summarize }},
+		{"command", func(k config.KeyBindings) string { return k.CommandMode }},
+		{"help", func(k config.KeyBindings) string { return k.Help }},
+	},
+	hintContextContent: {
+		{"find", func(k config.KeyBindings) string { return k.ContentSearch }},
+		{"next match", func(k config.KeyBindings) string { return k.SearchNext }},
+		{"top", func(k config.KeyBindings) string { return k.GotoTop }},
+		{"bottom", func(k config.KeyBindings) string { return k.GotoBottom }},
+		{"links", func(k config.KeyBindings) string { return k.LinkPicker }},
+		{"reply", func(k config.KeyBindings) string { return k.Reply }},
+		{"headers", func(k config.KeyBindings) string { return k.ToggleHeaders }},
+		{"back to list", fixedKey("tab")},
+	},
+	hintContextPicker: {
+		{"select", fixedKey("enter")},
+		{"move", fixedKey("↑/↓")},
+		{"filter", fixedKey("type")},
+		{"close", fixedKey("esc")},
+	},
+	hintContextComposer: {
+		{"send", fixedKey("ctrl+enter")},
+		{"next field", fixedKey("tab")},
+		{"previous field", fixedKey("shift+tab")},
+		{"new line", fixedKey("enter")},
+		{"cancel", fixedKey("esc")},
+	},
+}
+
+// keyHintsFor returns "key desc" pairs for a context, skipping unbound actions
+func keyHintsFor(context string, keys config.KeyBindings) [][2]string {
+	var out [][2]string
+	for _, h := range keyHintRegistry[context] {
+		key := strings.TrimSpace(h.key(keys))
+		if key == "" {
+			continue
+		}
+		out = append(out, [2]string{key, h.desc})
+		if len(out) == maxKeyHints {
+			break
+		}
+	}
+	return out
+}
+
+// keyHintContext picks the hints context from what currently has the user's attention
+func (a *App) keyHintContext() string {
+	switch {
+	case a.compositionPanel != nil && a.compositionPanel.IsVisible():
+		return hintContextComposer
+	case a.currentActivePicker != PickerNone:
+		return hintContextPicker
+	case a.focus.is("text") || a.focus.is("summary"):
+		return hintContextContent
+	}
+	return hintContextList
+}
+
+// renderKeyHints refreshes the hints bar for the current context; a no-op while it is hidden.
+// Must run on the UI goroutine.
+func (a *App) renderKeyHints() {
+	if !a.showKeyHints {
+		return
+	}
+	bar, ok := a.views["keyHints"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	keyTag, descTag := a.GetColorTag("emphasis"), a.GetColorTag("secondary")
+	var b strings.Builder
+	for _, h := range keyHintsFor(a.keyHintContext(), a.Keys) {
+		fmt.Fprintf(&b, " %s%s%s %s%s%s ", keyTag, tview.Escape(h[0]), a.GetEndTag(), descTag, h[1], a.GetEndTag())
+	}
+	bar.SetText(b.String())
+}
+
+// setKeyHintsVisible shows or hides the hints bar in the main and compose layouts. Must run on
+// the UI goroutine.
+func (a *App) setKeyHintsVisible(show bool) {
+	a.showKeyHints = show
+	bar, ok := a.views["keyHints"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	height := 0
+	if show {
+		height = 1
+	} else {
+		bar.Clear()
+	}
+	if mainFlex, ok := a.views["mainFlex"].(*tview.Flex); ok {
+		mainFlex.ResizeItem(bar, height, 0)
+	}
+	a.renderKeyHints()
+}
+
+// executeHintsCommand handles :hints [on|off]
+func (a *App) executeHintsCommand(args []string) {
+	show := !a.showKeyHints
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on", "show":
+			show = true
+		case "off", "hide":
+			show = false
+		default:
+			a.showError("Usage: hints [on|off]")
+			return
+		}
+	}
+	a.setKeyHintsVisible(show)
+	if show {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "Key hints enabled")
+		return
+	}
+	go a.GetErrorHandler().ShowInfo(a.ctx, "Key hints disabled")
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+)
+
+func TestKeyHintsFor(t *testing.T) {
+	keys := config.DefaultKeyBindings()
+	keys.Reply = "R"  // remapped
+	keys.Archive = "" // unbound
+
+	hints := keyHintsFor(hintContextList, keys)
+	if len(hints) == 0 || len(hints) > maxKeyHints {
+		t.Fatalf("expected 1..%d hints, got %d", maxKeyHints, len(hints))
+	}
+	byDesc := map[string]string{}
+	for _, h := range hints {
+		byDesc[h[1]] = h[0]
+	}
+	if byDesc["reply"] != "R" {
+		t.Errorf("remapped reply key should be shown, got %q", byDesc["reply"])
+	}
+	if _, ok := byDesc["archive"]; ok {
+		t.Error("unbound actions should be skipped")
+	}
+
+	for _, ctx := range []string{hintContextContent, hintContextPicker, hintContextComposer} {
+		if len(keyHintsFor(ctx, keys)) == 0 {
+			t.Errorf("context %q has no hints", ctx)
+		}
+	}
+	if keyHintsFor("unknown", keys) != nil {
+		t.Error("unknown context should have no hints")
+	}
+}
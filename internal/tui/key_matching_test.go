@@ -58,3 +58,37 @@ func TestMatchesConfiguredKey_Empty(t *testing.T) {
 		t.Error("an empty binding must never match")
 	}
 }
+
+func TestMatchesConfiguredKey_FunctionKeys(t *testing.T) {
+	a := &App{}
+
+	if !a.matchesConfiguredKey(tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone), "f1") {
+		t.Error(`"f1" should match F1`)
+	}
+	if !a.matchesConfiguredKey(tcell.NewEventKey(tcell.KeyF12, 0, tcell.ModNone), "F12") {
+		t.Error(`"F12" should match F12`)
+	}
+	if a.matchesConfiguredKey(tcell.NewEventKey(tcell.KeyF2, 0, tcell.ModNone), "f1") {
+		t.Error(`"f1" must not match F2`)
+	}
+	if a.matchesConfiguredKey(runeEvent('f', tcell.ModNone), "f1") {
+		t.Error(`"f1" must not match a plain f`)
+	}
+	if a.matchesConfiguredKey(tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone), "f13") {
+		t.Error(`out-of-range "f13" must not match`)
+	}
+}
+
+func TestMatchesKeyCombo_CtrlArrow(t *testing.T) {
+	a := &App{}
+
+	if !a.matchesKeyCombo(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModCtrl), "ctrl+left") {
+		t.Error("ctrl+left should match Ctrl+Left")
+	}
+	if a.matchesKeyCombo(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), "ctrl+left") {
+		t.Error("ctrl+left must NOT match a plain Left")
+	}
+	if a.matchesKeyCombo(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModCtrl), "ctrl+left") {
+		t.Error("ctrl+left must NOT match Ctrl+Right")
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -92,6 +93,11 @@ func (a *App) handleConfigurableKey(event *tcell.EventKey) bool {
 		if a.logger != nil {
 			a.logger.Printf("Configurable shortcut: '%s' -> summarize", key)
 		}
+		// On the open summary, the key offers the summary styles (pressing it twice still closes)
+		if a.aiPanel.visible.Load() && a.focus.is("summary") {
+			a.openSummaryPresetMenu()
+			return true
+		}
 		a.toggleAISummary()
 		return true
 	}
@@ -487,6 +493,12 @@ func (a *App) handleConfigurableKey(event *tcell.EventKey) bool {
 		}
 		a.performUndoFromShortcut()
 		return true
+	case a.Keys.UsualActions:
+		if a.logger != nil {
+			a.logger.Printf("Configurable shortcut: '%s' -> usual_actions", key)
+		}
+		go a.applyUsualActions()
+		return true
 	}
 
 	return false
@@ -534,7 +546,8 @@ func (a *App) isKeyConfigured(key rune) bool {
 		keyStr == a.Keys.ToggleHeaders ||
 		keyStr == a.Keys.SaveQuery ||
 		keyStr == a.Keys.QueryBookmarks ||
-		keyStr == a.Keys.ActionPlan
+		keyStr == a.Keys.ActionPlan ||
+		keyStr == a.Keys.UsualActions
 }
 
 // bindKeys sets up keyboard shortcuts and routes actions to feature modules
@@ -588,10 +601,16 @@ func (a *App) bindKeys() {
 		if a.focus.is("prompt_preview") || a.focus.is("action_plan_move") ||
 			a.focus.is("analyzer_rules") || a.focus.is("analyzer_rules_add") ||
 			a.focus.is("action_plan_rule") || a.focus.is("action_plan_prompt") ||
-			a.focus.is("action_plan_summary") {
+			a.focus.is("action_plan_summary") || a.focus.is("thread_note") {
 			return event
 		}
 
+		// The search chips bar takes the keys it uses; the rest (':' and the other global
+		// shortcuts) go on to normal handling
+		if a.focus.is("search_chips") && a.handleSearchChipsKey(event) == nil {
+			return nil
+		}
+
 		// Action Plan panel key routing. The panel stays mounted (active) even when the
 		// user Tabs to the inbox to read mail while analysis runs in the background, so
 		// behavior is gated on FOCUS, not just on the panel being active.
@@ -619,6 +638,11 @@ func (a *App) bindKeys() {
 			// to normal inbox handling (read/navigate freely while analysis runs).
 		}
 
+		// Side panel resizing works whatever the panel's focused widget is
+		if a.handlePanelResizeKey(event) {
+			return nil
+		}
+
 		// If focus is on form widgets (advanced/simple search), don't intercept
 		switch focused := a.GetFocus().(type) {
 		case *tview.InputField:
@@ -1469,6 +1493,7 @@ func (a *App) bindKeys() {
 					// Don't change focus, just hide the panel
 				}
 				a.SetCurrentMessageID(id)
+				go a.suggestUsualActions(id)
 				// Re-render list items so bulk selection backgrounds update when focus moves
 				a.refreshTableDisplay()
 
@@ -1584,7 +1609,7 @@ type focusRingEntry struct {
 // for Tab / Shift+Tab cycling.
 func (a *App) buildFocusRing() []focusRingEntry {
 	ring := make([]focusRingEntry, 0, 6)
-	// 1) Search (only when the search container is on-screen).
+	// 1) Search (only when the search container is on-screen), then its refinement chips.
 	if sc, ok := a.views["searchContainer"].(*tview.Flex); ok {
 		if _, _, w, h := sc.GetRect(); w > 0 && h > 0 {
 			if inp, ok2 := a.views["searchInput"].(*tview.InputField); ok2 {
@@ -1592,6 +1617,9 @@ func (a *App) buildFocusRing() []focusRingEntry {
 			}
 		}
 	}
+	if a.searchChipsVisible() {
+		ring = append(ring, focusRingEntry{"search_chips", a.views["searchChips"]})
+	}
 	// 2) List and 3) the message reader are always present.
 	ring = append(ring, focusRingEntry{"list", a.views["list"]})
 	ring = append(ring, focusRingEntry{"text", a.views["text"]})
@@ -2166,6 +2194,9 @@ func (a *App) archiveRange(startIndex, count int) {
 	for i := 0; i < count && startIndex+i < len(a.ids); i++ {
 		messageIDs = append(messageIDs, a.ids[startIndex+i])
 	}
+	if a.blockForeignMessages(messageIDs...) {
+		return
+	}
 
 	actualCount := len(messageIDs)
 
@@ -2207,6 +2238,9 @@ func (a *App) trashRange(startIndex, count int) {
 	for i := 0; i < count && startIndex+i < len(a.ids); i++ {
 		messageIDs = append(messageIDs, a.ids[startIndex+i])
 	}
+	if a.blockForeignMessages(messageIDs...) {
+		return
+	}
 
 	actualCount := len(messageIDs)
 
@@ -2252,6 +2286,9 @@ func (a *App) toggleReadRange(startIndex, count int) {
 	for i := 0; i < count && startIndex+i < len(a.ids); i++ {
 		messageIDs = append(messageIDs, a.ids[startIndex+i])
 	}
+	if a.blockForeignMessages(messageIDs...) {
+		return
+	}
 
 	actualCount := len(messageIDs)
 
@@ -2691,6 +2728,9 @@ func (a *App) matchesKeyCombo(event *tcell.EventKey, keyCombo string) bool {
 	if strings.HasPrefix(keyCombo, "ctrl+") {
 		// Extract the letter (e.g., "ctrl+a" -> "a")
 		letter := keyCombo[5:]
+		if key, ok := arrowKeys[letter]; ok {
+			return event.Key() == key && (event.Modifiers()&tcell.ModCtrl) != 0
+		}
 		if len(letter) == 1 {
 			letterRune := rune(letter[0])
 			// Check both KeyCtrlX and Modifier+rune patterns
@@ -2733,6 +2773,11 @@ func (a *App) matchesKeyCombo(event *tcell.EventKey, keyCombo string) bool {
 		return false
 	}
 
+	// Handle function keys ("f1".."f12")
+	if n, ok := functionKeyNumber(keyCombo); ok {
+		return event.Key() == tcell.KeyF1+tcell.Key(n-1)
+	}
+
 	// Handle simple character keys
 	if len(keyCombo) == 1 {
 		return event.Rune() == rune(keyCombo[0])
@@ -2740,3 +2785,23 @@ func (a *App) matchesKeyCombo(event *tcell.EventKey, keyCombo string) bool {
 
 	return false
 }
+
+// arrowKeys maps arrow key names, as in "ctrl+left", to their keys
+var arrowKeys = map[string]tcell.Key{
+	"left":  tcell.KeyLeft,
+	"right": tcell.KeyRight,
+	"up":    tcell.KeyUp,
+	"down":  tcell.KeyDown,
+}
+
+// functionKeyNumber parses a lowercase "f1".."f12" binding into its number.
+func functionKeyNumber(keyCombo string) (int, bool) {
+	if len(keyCombo) < 2 || keyCombo[0] != 'f' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(keyCombo[1:])
+	if err != nil || n < 1 || n > 12 {
+		return 0, false
+	}
+	return n, true
+}
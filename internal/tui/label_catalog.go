@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/ajramos/giztui/internal/services"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// newLabelService creates the label service of the active client with a fresh label catalog. The
+// client names labels through the catalog and invalidates it when it creates, renames or deletes one;
+// bulk label jobs go through batchModify.
+func (a *App) newLabelService() services.LabelService {
+	svc := services.NewLabelService(a.Client)
+	if a.Client != nil {
+		catalog := services.NewLabelCatalog(a.Client, 0)
+		a.Client.SetLabelNameSource(catalog)
+		svc.SetLabelCatalog(catalog)
+		svc.SetBatchModifier(a.Client)
+		a.labelCatalog = catalog
+	}
+	return svc
+}
+
+// listLabels returns the active account's labels from the catalog, listing them from Gmail only
+// when the cache is stale
+func (a *App) listLabels() ([]*gmailapi.Label, error) {
+	if a.labelCatalog != nil {
+		return a.labelCatalog.Labels(a.ctx)
+	}
+	if a.Client == nil {
+		return nil, fmt.Errorf("gmail client not initialized")
+	}
+	return a.Client.ListLabels()
+}
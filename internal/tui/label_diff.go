@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// labelStatesBefore snapshots the labels of ids as the list shows them; messages that are not
+// loaded are read from Gmail
+func (a *App) labelStatesBefore(ids []string) map[string]services.LabelState {
+	states := make(map[string]services.LabelState, len(ids))
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	a.mu.RLock()
+	for _, m := range a.messagesMeta {
+		if m != nil && want[m.Id] {
+			states[m.Id] = services.LabelState{
+				Subject:  extractHeaderValue(m, "Subject"),
+				LabelIDs: append([]string(nil), m.LabelIds...),
+			}
+		}
+	}
+	a.mu.RUnlock()
+	var missing []string
+	for _, id := range ids {
+		if _, ok := states[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	for id, st := range a.labelStatesFromGmail(missing) {
+		states[id] = st
+	}
+	return states
+}
+
+// labelStatesFromGmail reads the current labels of ids; messages that can't be read are left out
+func (a *App) labelStatesFromGmail(ids []string) map[string]services.LabelState {
+	states := make(map[string]services.LabelState, len(ids))
+	if len(ids) == 0 || a.Client == nil {
+		return states
+	}
+	metas, _ := a.Client.GetMessagesMetadataParallel(ids, 10)
+	for _, m := range metas {
+		if m != nil {
+			states[m.Id] = services.LabelState{Subject: extractHeaderValue(m, "Subject"), LabelIDs: m.LabelIds}
+		}
+	}
+	return states
+}
+
+// reportLabelDiff reads the labels of a finished bulk job back from Gmail and shows what changed
+// for each message. Single-message jobs are not reported.
+func (a *App) reportLabelDiff(job services.LabelDiffJob, ids []string, before map[string]services.LabelState) {
+	if len(ids) < 2 {
+		return
+	}
+	after := a.labelStatesFromGmail(ids)
+	names := map[string]string{}
+	if a.Client != nil {
+		if labels, err := a.listLabels(); err == nil {
+			for _, l := range labels {
+				names[l.Id] = l.Name
+			}
+		}
+	}
+	d := job.Diff(ids, before, after, names)
+	a.lastLabelDiff.Store(d)
+	a.QueueUpdateDraw(func() { a.showLabelDiff(d) })
+}
+
+// showLabelDiff shows a label diff report in the content pane
+func (a *App) showLabelDiff(d *services.LabelDiff) {
+	if a.enhancedTextView == nil {
+		return
+	}
+	a.setMessageContentTitle(" 🧾 Bulk label report ")
+	a.enhancedTextView.SetContent(a.formatLabelDiff(d))
+	a.enhancedTextView.ScrollToBeginning()
+}
+
+// formatLabelDiff colors the plain report for the content pane
+func (a *App) formatLabelDiff(d *services.LabelDiff) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(d.Format(), "\n"), "\n") {
+		tag := ""
+		switch {
+		case strings.HasPrefix(line, "Bulk label report"):
+			tag = a.GetColorTag("title")
+		case strings.HasPrefix(line, "  ✗ "):
+			tag = a.GetColorTag("emphasis")
+		case strings.HasPrefix(line, "  - "):
+			tag = a.GetColorTag("secondary")
+		case strings.HasPrefix(line, "  + "):
+			tag = a.GetColorTag("header")
+		}
+		if tag != "" {
+			fmt.Fprintf(&b, "%s%s%s\n", tag, tview.Escape(line), a.GetEndTag())
+		} else {
+			b.WriteString(tview.Escape(line) + "\n")
+		}
+	}
+	b.WriteString("\n(:labeldiff save [path] exports this report)\n")
+	return b.String()
+}
+
+// executeLabelDiffCommand handles :labeldiff [save [path]] — show or export the report of the
+// last bulk label or move job
+func (a *App) executeLabelDiffCommand(args []string) {
+	d := a.lastLabelDiff.Load()
+	if d == nil {
+		a.showError("No bulk label or move job has run yet")
+		return
+	}
+	if len(args) == 0 {
+		a.showLabelDiff(d)
+		return
+	}
+	if !strings.EqualFold(args[0], "save") {
+		a.showError("Usage: labeldiff [save [path]]")
+		return
+	}
+	path := strings.TrimSpace(strings.Join(args[1:], " "))
+	if path == "" {
+		path = filepath.Join(config.DefaultSavedDir(), d.Finished.Format("20060102-150405")+"-label-report.txt")
+	}
+	go func() {
+		if err := writeLabelDiff(d, path); err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving the report", err)
+			return
+		}
+		a.GetErrorHandler().ShowSuccess(a.ctx, "🧾 Report saved: "+path)
+	}()
+}
+
+// writeLabelDiff writes the plain report to path, creating its folder
+func writeLabelDiff(d *services.LabelDiff, path string) error {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(d.Format()), 0o600)
+}
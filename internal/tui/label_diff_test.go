@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestWriteLabelDiff(t *testing.T) {
+	job := services.LabelDiffJob{Operation: "Apply Finance", Add: []string{"Label_9"}}
+	before := map[string]services.LabelState{"m1": {Subject: "Invoice"}, "m2": {Subject: "Receipt"}}
+	after := map[string]services.LabelState{"m1": {LabelIDs: []string{"Label_9"}}, "m2": {LabelIDs: []string{"Label_9"}}}
+	d := job.Diff([]string{"m1", "m2"}, before, after, map[string]string{"Label_9": "Finance"})
+
+	path := filepath.Join(t.TempDir(), "reports", "label-report.txt")
+	if err := writeLabelDiff(d, path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "2 messages: 2 changed, 0 unchanged, 0 failed") || !strings.Contains(string(data), "Invoice  [m1]\n  + Finance") {
+		t.Fatalf("report = %s", data)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 	"github.com/mattn/go-runewidth"
@@ -44,6 +45,23 @@ func (a *App) getSystemFolders(messageLabels []string) []systemFolder {
 
 	folders := []systemFolder{}
 
+	// Offer restoring to the original labels for messages in Trash or Spam
+	if (labelSet[GMAIL_TRASH] || labelSet[GMAIL_SPAM]) && a.trashRestoreService != nil {
+		folders = append(folders, systemFolder{
+			id:   restoreFolderID,
+			name: "♻️ Restore (original labels)",
+			icon: "♻️",
+			condition: func(labels []string) bool {
+				for _, l := range labels {
+					if l == GMAIL_TRASH || l == GMAIL_SPAM {
+						return true
+					}
+				}
+				return false
+			},
+		})
+	}
+
 	// Show Inbox if message is not in inbox (archived, spam, trash, etc.)
 	if !labelSet[GMAIL_INBOX] {
 		folders = append(folders, systemFolder{
@@ -139,7 +157,7 @@ func (a *App) buildMoveOptions(messageID string) ([]labelItem, error) {
 	}
 
 	// Get all available labels
-	labels, err := a.Client.ListLabels()
+	labels, err := a.listLabels()
 	if err != nil {
 		if a.logger != nil {
 			a.logger.Printf("buildMoveOptions: failed to get labels: %v", err)
@@ -204,13 +222,16 @@ func (a *App) executeLabelAdd(args []string) {
 		a.showError("No message selected")
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	go func() {
 		label, err := a.Client.CreateLabel(labelName)
 		if err != nil {
-			labels, err := a.Client.ListLabels()
+			labels, err := a.listLabels()
 			if err != nil {
-				a.showError(fmt.Sprintf("❌ Error creating/finding label: %v", err))
+				a.showErrorFor("Error creating/finding label", err)
 				return
 			}
 			for _, l := range labels {
@@ -220,14 +241,14 @@ func (a *App) executeLabelAdd(args []string) {
 				}
 			}
 			if label == nil {
-				a.showError(fmt.Sprintf("❌ Error creating label: %v", err))
+				a.showErrorFor("Error creating label", err)
 				return
 			}
 		}
 		// Use LabelService for undo support
 		_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
 		if err := labelService.ApplyLabel(a.ctx, messageID, label.Id); err != nil {
-			a.showError(fmt.Sprintf("❌ Error applying label: %v", err))
+			a.showErrorFor("Error applying label", err)
 			return
 		}
 
@@ -259,10 +280,13 @@ func (a *App) executeLabelRemove(args []string) {
 		a.showError("No message selected")
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 	go func() {
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil {
-			a.showError(fmt.Sprintf("❌ Error loading labels: %v", err))
+			a.showErrorFor("Error loading labels", err)
 			return
 		}
 		var labelID string
@@ -279,7 +303,7 @@ func (a *App) executeLabelRemove(args []string) {
 		// Use LabelService for undo support
 		_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
 		if err := labelService.RemoveLabel(a.ctx, messageID, labelID); err != nil {
-			a.showError(fmt.Sprintf("❌ Error removing label: %v", err))
+			a.showErrorFor("Error removing label", err)
 			return
 		}
 		a.showStatusMessage(fmt.Sprintf("🔖  Removed label: %s", labelName))
@@ -310,13 +334,16 @@ func (a *App) manageLabels() {
 		}()
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	// Ensure message content is shown without stealing focus
 	a.showMessageWithoutFocus(messageID)
 
 	// Show panel and load quick view
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "labels")
 	}
 	a.setActivePicker(PickerLabels)
 	a.labelsExpanded = false
@@ -348,7 +375,7 @@ func (a *App) populateLabelsQuickView(messageID string) {
 		if a.logger != nil {
 			a.logger.Printf("populateLabelsQuickView: fetching labels list")
 		}
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil {
 			if a.logger != nil {
 				a.logger.Printf("populateLabelsQuickView: FAILED to get labels: %v", err)
@@ -662,6 +689,17 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 
 					failed := 0
 					var operationName string
+					// Per-message failures and the expected change, for the bulk report
+					failures := map[string]string{}
+					fail := func(mid string, err error) {
+						failed++
+						failures[mid] = err.Error()
+					}
+					job := services.LabelDiffJob{Failures: failures}
+					var before map[string]services.LabelState
+					if len(idsToMove) > 1 {
+						before = a.labelStatesBefore(idsToMove)
+					}
 
 					// Get services for undo support - use proper move function
 					emailService, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
@@ -675,6 +713,7 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 					case GMAIL_INBOX:
 						// Move to Inbox: Use undo-aware system folder move
 						operationName = "Inbox"
+						job.Add = []string{GMAIL_INBOX}
 						if a.logger != nil {
 							a.logger.Printf("🔥 INBOX MOVE OPERATION STARTED - User triggered move to inbox with %d messages", len(idsToMove))
 						}
@@ -690,7 +729,7 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 								}()
 							}
 							if err := emailService.MoveToSystemFolder(a.ctx, mid, GMAIL_INBOX, "Inbox"); err != nil {
-								failed++
+								fail(mid, err)
 								if a.logger != nil {
 									a.logger.Printf("[UI ERROR] Failed to move message %s to Inbox: %v", mid, err)
 								}
@@ -704,9 +743,10 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 					case GMAIL_TRASH:
 						// Move to Trash: Use undo-aware system folder move
 						operationName = "Trash"
+						job.Add = []string{GMAIL_TRASH}
 						for _, mid := range idsToMove {
 							if err := emailService.MoveToSystemFolder(a.ctx, mid, GMAIL_TRASH, "Trash"); err != nil {
-								failed++
+								fail(mid, err)
 								if a.logger != nil {
 									a.logger.Printf("Failed to move message %s to Trash: %v", mid, err)
 								}
@@ -716,22 +756,38 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 					case GMAIL_SPAM:
 						// Move to Spam: Use undo-aware system folder move
 						operationName = "Spam"
+						job.Add = []string{GMAIL_SPAM}
 						for _, mid := range idsToMove {
 							if err := emailService.MoveToSystemFolder(a.ctx, mid, GMAIL_SPAM, "Spam"); err != nil {
-								failed++
+								fail(mid, err)
 								if a.logger != nil {
 									a.logger.Printf("Failed to move message %s to Spam: %v", mid, err)
 								}
 							}
 						}
 
+					case restoreFolderID:
+						// Restore: back to the labels recorded when trashed (or inferred)
+						operationName = "original labels"
+						res, err := a.trashRestoreService.Restore(a.ctx, idsToMove, nil)
+						if err != nil {
+							failed = len(idsToMove)
+							job.Err = err
+						} else {
+							failed = len(res.Failed)
+							for _, mid := range res.Failed {
+								failures[mid] = "could not restore"
+							}
+						}
+
 					case "REMOVE_INBOX":
 						// Archive: Remove INBOX label (ArchiveMessage handles both label removal and undo)
 						operationName = "Archive"
+						job.Remove = []string{GMAIL_INBOX}
 						for _, mid := range idsToMove {
 							// Use ArchiveMessage which removes INBOX label and records undo action
 							if err := emailService.ArchiveMessage(a.ctx, mid); err != nil {
-								failed++
+								fail(mid, err)
 								if a.logger != nil {
 									a.logger.Printf("Failed to archive message %s: %v", mid, err)
 								}
@@ -741,13 +797,14 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 					default:
 						// Regular label: Apply label and archive (original behavior)
 						operationName = name
+						job.Add, job.Remove = []string{id}, []string{GMAIL_INBOX}
 						for _, mid := range idsToMove {
 							if err := labelService.ApplyLabel(a.ctx, mid, id); err != nil {
-								failed++
+								fail(mid, err)
 							}
 							// Use ArchiveMessageAsMove to record proper move undo action
 							if err := emailService.ArchiveMessageAsMove(a.ctx, mid, id, name); err != nil {
-								failed++
+								fail(mid, err)
 							}
 						}
 					}
@@ -811,9 +868,17 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 							a.showStatusMessage(fmt.Sprintf("📦 Moved %d/%d message(s) to %s", successCount, len(idsToMove), operationName))
 						}
 					}
+					job.Operation = "Move to " + operationName
+					a.reportLabelDiff(job, idsToMove, before)
 				}()
 			})
 		}
+		// Nothing matches: offer to create the typed label (nested paths included) and apply it
+		if !moveMode && filter != "" && len(visible) == 0 {
+			list.AddItem(fmt.Sprintf("➕ Create label '%s' and apply", tview.Escape(filter)), "Enter: create and apply", 0, func() {
+				a.createLabelAndApply(messageID, filter)
+			})
+		}
 	}
 
 	go func() {
@@ -821,7 +886,7 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 			// Use the new system folders + labels builder for move mode
 			options, err := a.buildMoveOptions(messageID)
 			if err != nil {
-				a.showError(fmt.Sprintf("❌ Error loading move options: %v", err))
+				a.showErrorFor("Error loading move options", err)
 				return
 			}
 			all = options
@@ -832,7 +897,7 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 				a.showError("❌ Error loading message")
 				return
 			}
-			labels, err := a.Client.ListLabels()
+			labels, err := a.listLabels()
 			if err != nil {
 				a.showError("❌ Error loading labels")
 				return
@@ -900,6 +965,11 @@ func (a *App) expandLabelsBrowseWithMode(messageID string, moveMode bool) {
 					return
 				}
 				if key == tcell.KeyEnter {
+					// No match for the typed name: create it and apply in one step
+					if name := strings.TrimSpace(input.GetText()); !moveMode && name != "" && len(visible) == 0 {
+						a.createLabelAndApply(messageID, name)
+						return
+					}
 					// UX shortcut: if there is at least one visible result, apply/move the first one
 					if len(visible) >= 1 {
 						v := visible[0]
@@ -1236,7 +1306,7 @@ func (a *App) expandLabelsBrowseGeneric(messageID, title string, onPick func(id,
 	}
 
 	go func() {
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil {
 			a.showError("❌ Error loading labels")
 			return
@@ -1292,7 +1362,7 @@ func (a *App) expandLabelsBrowseGeneric(messageID, title string, onPick func(id,
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "labels")
 			}
 			// Capturar flechas en la lista: si estamos en la primera y pulsamos Arriba, volver al buscador
 			list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
@@ -1376,7 +1446,7 @@ func (a *App) editLabelInline(labelID, name string) {
 			}
 			a.labelsView = container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1)
+			a.showSidePanel(split, a.labelsView, "labels")
 		}
 		a.SetFocus(input)
 		a.markFocus("labels")
@@ -1437,7 +1507,7 @@ func (a *App) confirmDeleteLabel(labelID, name string) {
 			}
 			a.labelsView = container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1)
+			a.showSidePanel(split, a.labelsView, "labels")
 		}
 		a.SetFocus(container)
 		a.markFocus("labels")
@@ -1451,9 +1521,12 @@ func (a *App) openMovePanel() {
 		a.showError("❌ No message selected")
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 	// Ensure panel is visible
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "labels")
 	}
 	a.setActivePicker(PickerLabels)
 	a.markFocus("labels")
@@ -1468,9 +1541,12 @@ func (a *App) openMovePanelBulk() {
 		a.openMovePanel()
 		return
 	}
+	if a.blockForeignMessages(a.bulk.ids()...) {
+		return
+	}
 	// Ensure panel visible
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "labels")
 	}
 	a.setActivePicker(PickerLabels)
 	a.markFocus("labels")
@@ -1493,10 +1569,13 @@ func (a *App) manageLabelsBulk() {
 		a.manageLabels()
 		return
 	}
+	if a.blockForeignMessages(a.bulk.ids()...) {
+		return
+	}
 
 	// Ensure panel visible
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "labels")
 	}
 	a.setActivePicker(PickerLabels)
 	a.markFocus("labels")
@@ -1575,7 +1654,7 @@ func (a *App) addCustomLabelInline(messageID string) {
 				if a.logger != nil {
 					a.logger.Printf("addCustomLabelInline: ListLabels start")
 				}
-				labels, err := a.Client.ListLabels()
+				labels, err := a.listLabels()
 				if err != nil {
 					if a.logger != nil {
 						a.logger.Printf("addCustomLabelInline: ListLabels error: %v", err)
@@ -1667,7 +1746,7 @@ func (a *App) addCustomLabelInline(messageID string) {
 			}
 			a.labelsView = container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1)
+			a.showSidePanel(split, a.labelsView, "labels")
 		}
 		a.setActivePicker(PickerLabels)
 		a.markFocus("labels")
@@ -1675,29 +1754,95 @@ func (a *App) addCustomLabelInline(messageID string) {
 	})
 }
 
-// toggleLabelForMessage toggles a label asynchronously and invokes onDone when finished
-func (a *App) toggleLabelForMessage(messageID, labelID, labelName string, isCurrentlyApplied bool, onDone func(newApplied bool, err error)) {
+// createLabelAndApply creates a label (and any missing parents of a "Parent/Child" path) and applies
+// it to the message or to the bulk selection, then reopens the picker with the new label listed
+func (a *App) createLabelAndApply(messageID, name string) {
 	go func() {
-		// Use LabelService for undo support
 		_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
+		if labelService == nil {
+			a.GetErrorHandler().ShowError(a.ctx, "Label service not available")
+			return
+		}
+		a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Creating label %s…", name))
+		label, err := labelService.EnsureLabelPath(a.ctx, name)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Error creating label %s: %v", name, err))
+			return
+		}
+		a.refreshRendererLabelMap()
 
+		if a.bulk.isMode() && a.bulk.count() > 0 {
+			a.applyLabelToBulkSelection(label.Id, label.Name, false)
+			return
+		}
+		if err := labelService.ApplyLabel(a.ctx, messageID, label.Id); err != nil {
+			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Label %s created but not applied: %v", label.Name, err))
+			return
+		}
+		a.updateCachedMessageLabels(messageID, label.Id, true)
+		a.updateMessageCacheLabels(messageID, label.Name, true)
+		a.refreshMessageContent(messageID)
+		a.QueueUpdateDraw(func() {
+			a.reformatListItems()
+		})
+		go a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🔖 Created and applied label: %s", label.Name))
+		a.expandLabelsBrowseWithMode(messageID, false)
+	}()
+}
+
+// refreshRendererLabelMap reloads the label ID -> name map used for list chips (e.g. after a
+// label was created)
+func (a *App) refreshRendererLabelMap() {
+	if a.Client == nil || a.crossSearch.isActive() {
+		return
+	}
+	labels, err := a.listLabels()
+	if err != nil {
+		return
+	}
+	a.setRendererLabels(labels)
+}
+
+// setRendererLabels gives the list renderer the label names and the labels label_visibility
+// hides from the list
+func (a *App) setRendererLabels(labels []*gmailapi.Label) {
+	m := make(map[string]string, len(labels))
+	for _, l := range labels {
+		m[l.Id] = l.Name
+	}
+	a.emailRenderer.SetLabelMap(m)
+	a.emailRenderer.SetHiddenLabels(a.labelVisibility.HiddenInMessageList(labels))
+}
+
+// toggleLabelForMessage toggles a label asynchronously and invokes onDone when finished
+func (a *App) toggleLabelForMessage(messageID, labelID, labelName string, isCurrentlyApplied bool, onDone func(newApplied bool, err error)) {
+	go func() {
+		change := syncChange{MessageID: messageID, Kind: syncLabelAdd, LabelID: labelID, LabelName: labelName}
 		if isCurrentlyApplied {
-			if err := labelService.RemoveLabel(a.ctx, messageID, labelID); err != nil {
-				a.showError(fmt.Sprintf("❌ Error removing label %s: %v", labelName, err))
-				onDone(isCurrentlyApplied, err)
-				return
+			change.Kind = syncLabelRemove
+		}
+		// Sent through LabelService for undo support. A server failure keeps the change locally,
+		// flagged with ⚠, until it is retried or discarded in the :sync panel.
+		key := a.syncState.begin(change)
+		if err := a.sendSyncChange(change); err != nil {
+			a.syncState.fail(key, err, isMessageGoneError(err))
+			if a.logger != nil {
+				a.logger.Printf("toggleLabelForMessage: %s on %s failed: %v", change.describe(), messageID, err)
 			}
+			go a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Couldn't %s in Gmail — kept locally (:sync to retry or discard)", change.describe()))
+			onDone(!isCurrentlyApplied, nil)
+			return
+		}
+		a.syncState.succeed(key)
+
+		if isCurrentlyApplied {
 			go func() {
 				a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🔖 Removed label: %s", labelName))
 			}()
 			onDone(false, nil)
 			return
 		}
-		if err := labelService.ApplyLabel(a.ctx, messageID, labelID); err != nil {
-			a.showError(fmt.Sprintf("❌ Error applying label %s: %v", labelName, err))
-			onDone(isCurrentlyApplied, err)
-			return
-		}
 		go func() {
 			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🔖 Applied label: %s", labelName))
 		}()
@@ -1819,10 +1964,20 @@ func (a *App) removeLabelNameFromMessageCache(messageID, name string) {
 // showMoveLabelsView lets user choose a label to apply and then archives the message (move semantics)
 // OBLITERATED: showMoveLabelsView function eliminated! 💥
 
-// filterAndSortLabels filters out system labels and returns a name-sorted slice
+// filterAndSortLabels filters out system labels and labels hidden by label_visibility and
+// returns a name-sorted slice
 func (a *App) filterAndSortLabels(labels []*gmailapi.Label) []*gmailapi.Label {
+	return a.filterAndSortLabelsKeeping(labels, nil)
+}
+
+// filterAndSortLabelsKeeping is filterAndSortLabels, but labels in keep (e.g. applied to the
+// message) are listed even when label_visibility hides them
+func (a *App) filterAndSortLabelsKeeping(labels []*gmailapi.Label, keep map[string]bool) []*gmailapi.Label {
 	filtered := make([]*gmailapi.Label, 0, len(labels))
 	for _, l := range labels {
+		if !keep[l.Id] && !a.labelVisibility.InPickers(l) {
+			continue
+		}
 		if strings.HasPrefix(l.Id, "CATEGORY_") || l.Id == "INBOX" || l.Id == "SENT" || l.Id == "DRAFT" ||
 			l.Id == "SPAM" || l.Id == "TRASH" || l.Id == "CHAT" || (strings.HasSuffix(l.Id, "_STARRED") && l.Id != "STARRED") {
 			continue
@@ -1837,7 +1992,7 @@ func (a *App) filterAndSortLabels(labels []*gmailapi.Label) []*gmailapi.Label {
 
 // partitionAndSortLabels returns two sorted slices: labels applied to current and the rest
 func (a *App) partitionAndSortLabels(labels []*gmailapi.Label, current map[string]bool) ([]*gmailapi.Label, []*gmailapi.Label) {
-	filtered := a.filterAndSortLabels(labels)
+	filtered := a.filterAndSortLabelsKeeping(labels, current)
 	applied := make([]*gmailapi.Label, 0)
 	notApplied := make([]*gmailapi.Label, 0)
 	for _, l := range filtered {
@@ -1853,7 +2008,7 @@ func (a *App) partitionAndSortLabels(labels []*gmailapi.Label, current map[strin
 
 // showAllLabelsPicker shows a list of all actionable labels to apply one to the message
 func (a *App) showAllLabelsPicker(messageID string) {
-	labels, err := a.Client.ListLabels()
+	labels, err := a.listLabels()
 	if err != nil {
 		a.showError("❌ Error loading labels")
 		return
@@ -1987,64 +2142,78 @@ func (a *App) applyLabelToBulkSelection(labelID, labelName string, currentlyAppl
 		a.markFocus("list")
 	})
 
-	// Do the actual labeling work in a separate goroutine (like move operations)
-	go func() {
-		failed := 0
-		total := len(messageIDs)
+	// Large selections go through the quota planner first (schedule across windows or shrink)
+	op, verb := services.QuotaOpApplyLabel, "Applying label"
+	if action == "remove" {
+		op, verb = services.QuotaOpRemove, "Removing label"
+	}
+	a.withQuotaPlan(op, messageIDs, verb, func(messageIDs []string, plan services.QuotaPlan) {
+		// Do the actual labeling work in a separate goroutine (like move operations)
+		go func() {
+			failed := 0
+			total := len(messageIDs)
+			before := a.labelStatesBefore(messageIDs)
 
-		// Use bulk label service methods for proper undo recording
-		_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
-		var err error
-		if action == "add" {
-			if a.logger != nil {
-				a.logger.Printf("applyLabelToBulkSelection: calling BulkApplyLabel for %d messages", len(messageIDs))
-			}
-			err = labelService.BulkApplyLabel(a.ctx, messageIDs, labelID, a.bulkProgress(a.ctx, "Applying label"))
+			// Use bulk label service methods for proper undo recording
+			_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
+			err := a.runQuotaPlanned(plan, messageIDs, verb, func(batch []string, progress func(done, total int)) error {
+				if action == "add" {
+					if a.logger != nil {
+						a.logger.Printf("applyLabelToBulkSelection: calling BulkApplyLabel for %d messages", len(batch))
+					}
+					return labelService.BulkApplyLabel(a.ctx, batch, labelID, progress)
+				}
+				if a.logger != nil {
+					a.logger.Printf("applyLabelToBulkSelection: calling BulkRemoveLabel for %d messages", len(batch))
+				}
+				return labelService.BulkRemoveLabel(a.ctx, batch, labelID)
+			})
 			a.GetErrorHandler().ClearPersistentMessage()
-		} else {
-			if a.logger != nil {
-				a.logger.Printf("applyLabelToBulkSelection: calling BulkRemoveLabel for %d messages", len(messageIDs))
-			}
-			err = labelService.BulkRemoveLabel(a.ctx, messageIDs, labelID)
-		}
 
-		if err != nil {
-			if a.logger != nil {
-				a.logger.Printf("applyLabelToBulkSelection: bulk operation FAILED: %v", err)
-			}
-			failed = len(messageIDs) // If bulk operation fails, consider all as failed
-		} else {
-			if a.logger != nil {
-				a.logger.Printf("applyLabelToBulkSelection: bulk operation SUCCESS for all %d messages", len(messageIDs))
-			}
-			// Update local cache for all messages
-			for _, messageID := range messageIDs {
-				a.updateCachedMessageLabels(messageID, labelID, action == "add")
+			if err != nil {
+				if a.logger != nil {
+					a.logger.Printf("applyLabelToBulkSelection: bulk operation FAILED: %v", err)
+				}
+				failed = len(messageIDs) // If bulk operation fails, consider all as failed
+			} else {
+				if a.logger != nil {
+					a.logger.Printf("applyLabelToBulkSelection: bulk operation SUCCESS for all %d messages", len(messageIDs))
+				}
+				// Update local cache for all messages
+				for _, messageID := range messageIDs {
+					a.updateCachedMessageLabels(messageID, labelID, action == "add")
+				}
 			}
-		}
 
-		// Update UI after all operations complete
-		a.QueueUpdateDraw(func() {
-			// Update the visual list to reflect label changes
-			a.refreshTableDisplay()
-		})
+			// Update UI after all operations complete
+			a.QueueUpdateDraw(func() {
+				// Update the visual list to reflect label changes
+				a.refreshTableDisplay()
+			})
 
-		// Show completion status using ErrorHandler (async to avoid deadlock)
-		successful := total - failed
-		go func() {
-			if failed == 0 {
-				if action == "add" {
-					a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Applied '%s' to %d messages", labelName, total))
-				} else {
-					a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Removed '%s' from %d messages", labelName, total))
-				}
-			} else {
-				if action == "add" {
-					a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Applied '%s' to %d/%d messages (%d failed)", labelName, successful, total, failed))
+			// Show completion status using ErrorHandler (async to avoid deadlock)
+			successful := total - failed
+			go func() {
+				if failed == 0 {
+					if action == "add" {
+						a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Applied '%s' to %d messages", labelName, total))
+					} else {
+						a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Removed '%s' from %d messages", labelName, total))
+					}
 				} else {
-					a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Removed '%s' from %d/%d messages (%d failed)", labelName, successful, total, failed))
+					if action == "add" {
+						a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Applied '%s' to %d/%d messages (%d failed)", labelName, successful, total, failed))
+					} else {
+						a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Removed '%s' from %d/%d messages (%d failed)", labelName, successful, total, failed))
+					}
 				}
+			}()
+
+			job := services.LabelDiffJob{Operation: "Apply " + labelName, Add: []string{labelID}, Err: err}
+			if action == "remove" {
+				job = services.LabelDiffJob{Operation: "Remove " + labelName, Remove: []string{labelID}, Err: err}
 			}
+			a.reportLabelDiff(job, messageIDs, before)
 		}()
-	}()
+	})
 }
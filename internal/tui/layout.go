@@ -92,7 +92,19 @@ func (a *App) initComponents() {
 
 	// Fixed height for header (room for Subject, From, To, Cc, Date, Labels)
 	textContainer.AddItem(header, 6, 0, false)
-	textContainer.AddItem(text, 0, 1, false)
+	// Message text with the minimap gutter on its right edge (content_minimap.go)
+	minimap := newContentMinimap(a, enhancedText)
+	a.contentMinimap = minimap
+	minimapWidth := 0
+	if a.showContentMinimap {
+		minimapWidth = 1
+	}
+	textRow := tview.NewFlex().SetDirection(tview.FlexColumn)
+	textRow.SetBackgroundColor(a.GetComponentColors("general").Background.Color())
+	textRow.AddItem(text, 0, 1, false)
+	textRow.AddItem(minimap, minimapWidth, 0, false)
+	a.views["textRow"] = textRow
+	textContainer.AddItem(textRow, 0, 1, false)
 
 	// Create AI Summary view (hidden by default)
 	ai := tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetScrollable(true)
@@ -108,6 +120,16 @@ func (a *App) initComponents() {
 	a.views["list"] = list
 	a.views["searchPanel"] = searchPanel
 	a.views["listContainer"] = listContainer
+
+	// Aggregate stats row under the list (optional, see list_footer.go)
+	listFooter := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	listFooter.SetBackgroundColor(a.GetComponentColors("general").Background.Color())
+	a.views["listFooter"] = listFooter
+
+	// Context-sensitive key hints above the status bar (optional, see key_hints.go)
+	keyHints := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	keyHints.SetBackgroundColor(a.GetComponentColors("general").Background.Color())
+	a.views["keyHints"] = keyHints
 	a.views["text"] = text
 	a.views["header"] = header
 	a.views["textContainer"] = textContainer
@@ -194,6 +216,11 @@ func (a *App) initComponents() {
 	// Reapply title styling since the helper can't preserve it
 	advancedSearchContainer.SetTitleColor(advancedSearchColors.Title.Color()).SetTitleAlign(tview.AlignCenter)
 	a.views["advancedSearchContainer"] = advancedSearchContainer
+
+	// Refinement chips bar for remote search results (hidden by default)
+	searchChips := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	searchChips.SetBackgroundColor(searchColors.Background.Color())
+	a.views["searchChips"] = searchChips
 }
 
 // initViews initializes the main views
@@ -241,9 +268,23 @@ func (a *App) createMainLayout() tview.Primitive {
 		mainFlex.AddItem(asc, 0, 0, false)
 	}
 
+	// Refinement chips bar mounted hidden (height 0); shown after a Gmail search.
+	if chips, ok := a.views["searchChips"]; ok {
+		mainFlex.AddItem(chips, 0, 0, false)
+	}
+
 	// Add list+search container (takes 40% of available height)
 	mainFlex.AddItem(a.views["listContainer"], 0, 40, true)
 
+	// List stats footer: one row when enabled, hidden (height 0) otherwise
+	if footer, ok := a.views["listFooter"]; ok {
+		height := 0
+		if a.showListFooter {
+			height = 1
+		}
+		mainFlex.AddItem(footer, height, 0, false)
+	}
+
 	// Message content row: split into content | AI summary (hidden initially)
 	contentSplit := tview.NewFlex().SetDirection(tview.FlexColumn)
 	contentSplit.SetBackgroundColor(a.GetComponentColors("general").Background.Color())
@@ -260,6 +301,15 @@ func (a *App) createMainLayout() tview.Primitive {
 		mainFlex.AddItem(cp, 0, 0, false)
 	}
 
+	// Key hints bar: one row when enabled, hidden (height 0) otherwise
+	if hints, ok := a.views["keyHints"]; ok {
+		height := 0
+		if a.showKeyHints {
+			height = 1
+		}
+		mainFlex.AddItem(hints, height, 0, false)
+	}
+
 	// Add status bar at the bottom
 	statusBar := a.createStatusBar()
 	a.views["status"] = statusBar // Store status bar as a view
@@ -267,6 +317,7 @@ func (a *App) createMainLayout() tview.Primitive {
 
 	// Store reference for dynamic resize (e.g., advanced search)
 	a.views["mainFlex"] = mainFlex
+	a.renderKeyHints()
 	return mainFlex
 }
 
@@ -281,6 +332,11 @@ func (a *App) createCompositionLayoutWithStatus() tview.Primitive {
 		compositionLayout.AddItem(a.compositionPanel, 0, 1, true) // flexible, focusable
 	}
 
+	// Key hints bar, when enabled
+	if hints, exists := a.views["keyHints"]; exists && a.showKeyHints {
+		compositionLayout.AddItem(hints, 1, 0, false)
+	}
+
 	// Add status bar (fixed height at bottom)
 	if statusBar, exists := a.views["status"]; exists {
 		compositionLayout.AddItem(statusBar, 1, 0, false) // fixed height, not focusable
@@ -305,6 +361,7 @@ func (a *App) focusList() {
 func (a *App) markFocus(name string) {
 	a.focus.set(name)
 	a.updateFocusIndicators(name)
+	a.renderKeyHints()
 }
 
 // updateFocusIndicators updates the visual indicators for the focused view
@@ -1,11 +1,15 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 )
@@ -44,7 +48,8 @@ func (a *App) openLinkPicker() {
 		SetLabelColor(a.GetComponentColors("links").Title.Color()).
 		SetFieldBackgroundColor(a.GetComponentColors("links").Background.Color()).
 		SetFieldTextColor(a.GetComponentColors("links").Text.Color())
-	list := tview.NewList().ShowSecondaryText(false)
+	previewsEnabled := linkService.IsPreviewEnabled()
+	list := tview.NewList().ShowSecondaryText(previewsEnabled)
 	list.SetBorder(false)
 
 	// Apply component-specific selection colors
@@ -62,6 +67,8 @@ func (a *App) openLinkPicker() {
 
 	var all []linkItem
 	var visible []linkItem
+	// Fetched previews by URL; only touched on the UI goroutine
+	previews := make(map[string]*services.LinkPreview)
 
 	// Reload function for filtering
 	reload := func(filter string) {
@@ -70,8 +77,13 @@ func (a *App) openLinkPicker() {
 		for _, item := range all {
 			if filter != "" {
 				filterLower := strings.ToLower(filter)
+				previewTitle := ""
+				if p := previews[item.url]; p != nil {
+					previewTitle = strings.ToLower(p.Title)
+				}
 				if !strings.Contains(strings.ToLower(item.url), filterLower) &&
-					!strings.Contains(strings.ToLower(item.text), filterLower) {
+					!strings.Contains(strings.ToLower(item.text), filterLower) &&
+					!strings.Contains(previewTitle, filterLower) {
 					// Check for special filters
 					if strings.HasPrefix(filterLower, "domain:") {
 						domain := strings.TrimPrefix(filterLower, "domain:")
@@ -101,6 +113,8 @@ func (a *App) openLinkPicker() {
 				icon = "🌐"
 			case "html":
 				icon = "🔗"
+			case "ai":
+				icon = "🧠"
 			default:
 				icon = "🔗"
 			}
@@ -120,6 +134,9 @@ func (a *App) openLinkPicker() {
 					secondary = item.url
 				}
 			}
+			if p := previews[item.url]; p != nil {
+				secondary = formatLinkPreview(p)
+			}
 
 			// Capture variables for closure
 			linkURL := item.url
@@ -151,12 +168,20 @@ func (a *App) openLinkPicker() {
 	}
 
 	// Load links in background
+	nested := a.currentNestedView()
 	go func() {
-		links, err := linkService.GetMessageLinks(a.ctx, messageID)
+		var links []services.LinkInfo
+		var err error
+		if nested != nil {
+			links = linkService.LinksFromMessage(nested.msg)
+		} else {
+			links, err = linkService.GetMessageLinks(a.ctx, messageID)
+		}
 		if err != nil {
 			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Failed to load links: %v", err))
 			return
 		}
+		links = append(links, a.aiPaneLinks(linkService, links)...)
 
 		if len(links) == 0 {
 			a.GetErrorHandler().ShowInfo(a.ctx, "No links found in this message")
@@ -268,7 +293,11 @@ func (a *App) openLinkPicker() {
 
 			// Footer with instructions
 			footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
-			footer.SetText(" Enter/1-9 to open | Ctrl+Y to copy | Esc to cancel ")
+			if previewsEnabled {
+				footer.SetText(" Enter/1-9 to open | Ctrl+Y to copy | previews on | Esc to cancel ")
+			} else {
+				footer.SetText(" Enter/1-9 to open | Ctrl+Y to copy | Esc to cancel ")
+			}
 			footer.SetTextColor(a.GetComponentColors("links").Text.Color()) // Standardized footer color
 			footer.SetBackgroundColor(bgColor)
 			container.AddItem(footer, 1, 0, false)
@@ -335,6 +364,22 @@ func (a *App) openLinkPicker() {
 				return e
 			})
 
+			if previewsEnabled {
+				urls := make([]string, 0, len(all))
+				for _, item := range all {
+					urls = append(urls, item.url)
+				}
+				a.startLinkPreviews(linkService, urls, func(url string, preview *services.LinkPreview) {
+					previews[url] = preview
+					for i, item := range visible {
+						if item.url == url && i < list.GetItemCount() {
+							main, _ := list.GetItemText(i)
+							list.SetItemText(i, main, formatLinkPreview(preview))
+						}
+					}
+				})
+			}
+
 			// Add to content split like other pickers
 			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
 				if a.labelsView != nil {
@@ -342,7 +387,7 @@ func (a *App) openLinkPicker() {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "links")
 			}
 			a.SetFocus(input)
 			a.focus.set("labels") // Reuse labels focus state for consistency
@@ -366,8 +411,87 @@ func (a *App) openLinkPicker() {
 	}()
 }
 
+// startLinkPreviews fetches previews for urls in the background, bounded by the configured
+// concurrency. onPreview runs on the UI goroutine for each successful fetch while the link
+// picker is still open. Fetches are cancelled when the picker closes.
+func (a *App) startLinkPreviews(linkService services.LinkService, urls []string, onPreview func(url string, preview *services.LinkPreview)) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.mu.Lock()
+	if a.linkPreviewCancel != nil {
+		a.linkPreviewCancel()
+	}
+	a.linkPreviewCancel = cancel
+	a.mu.Unlock()
+
+	workers := a.Config.Links.PreviewMaxConcurrent
+	if workers <= 0 {
+		workers = config.DefaultLinksConfig().PreviewMaxConcurrent
+	}
+
+	go func() {
+		sem := make(chan struct{}, workers)
+		var wg sync.WaitGroup
+		for _, url := range urls {
+			if !strings.HasPrefix(strings.ToLower(url), "http") {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+			wg.Add(1)
+			go func(url string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				preview, err := linkService.FetchLinkPreview(ctx, url)
+				if err != nil || preview == nil || ctx.Err() != nil {
+					if err != nil && a.logger != nil {
+						a.logger.Printf("link preview: %s: %v", url, err)
+					}
+					return
+				}
+				a.QueueUpdateDraw(func() {
+					if ctx.Err() == nil && a.currentActivePicker == PickerLinks {
+						onPreview(url, preview)
+					}
+				})
+			}(url)
+		}
+		wg.Wait()
+	}()
+}
+
+// formatLinkPreview renders a preview as the picker's secondary line
+func formatLinkPreview(p *services.LinkPreview) string {
+	text := p.Title
+	if p.Description != "" {
+		if text != "" {
+			text += " — "
+		}
+		text += p.Description
+	}
+	if text == "" && p.FinalURL != "" && p.FinalURL != p.URL {
+		text = "→ " + p.FinalURL
+	}
+	if text == "" {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) > 80 {
+		text = string(runes[:77]) + "..."
+	}
+	return "   " + text
+}
+
 // closeLinkPicker closes the link picker and restores focus
 func (a *App) closeLinkPicker() {
+	a.mu.Lock()
+	if a.linkPreviewCancel != nil {
+		a.linkPreviewCancel()
+		a.linkPreviewCancel = nil
+	}
+	a.mu.Unlock()
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
 		split.ResizeItem(a.labelsView, 0, 0)
 	}
@@ -448,3 +572,29 @@ func (a *App) copyToClipboard(text string) {
 		}
 	}
 }
+
+// aiPaneLinks returns the links of the AI answer shown in the AI pane that the message does not
+// already have, numbered after the message's own
+func (a *App) aiPaneLinks(linkService services.LinkService, messageLinks []services.LinkInfo) []services.LinkInfo {
+	if !a.aiPanel.visible.Load() {
+		return nil
+	}
+	source := a.aiPanel.paneSource()
+	if source == "" {
+		return nil
+	}
+	seen := make(map[string]bool, len(messageLinks))
+	for _, link := range messageLinks {
+		seen[link.URL] = true
+	}
+	var extra []services.LinkInfo
+	for _, link := range linkService.LinksFromMarkdown(source) {
+		if seen[link.URL] {
+			continue
+		}
+		seen[link.URL] = true
+		link.Index = len(messageLinks) + len(extra) + 1
+		extra = append(extra, link)
+	}
+	return extra
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// listFooterState holds Gmail's estimated total for the current view. It is written by the
+// estimate goroutine and read while rendering, so it is guarded by mu; use it via a.footer.*.
+type listFooterState struct {
+	mu       sync.RWMutex
+	query    string // scope the estimate belongs to ("" = inbox)
+	estimate int64
+	known    bool
+}
+
+// begin marks a new scope whose estimate is not known yet
+func (s *listFooterState) begin(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.query = query
+	s.estimate = 0
+	s.known = false
+}
+
+// set stores the estimate if it still belongs to the current scope
+func (s *listFooterState) set(query string, estimate int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.query != query {
+		return false
+	}
+	s.estimate = estimate
+	s.known = true
+	return true
+}
+
+// get returns the estimate of the current scope
+func (s *listFooterState) get() (int64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.estimate, s.known
+}
+
+// listFooterStats is the aggregate info shown in the footer
+type listFooterStats struct {
+	loaded    int
+	estimate  int64
+	estimated bool
+	unread    int
+	selected  int
+	bulk      bool
+	query     string
+}
+
+// computeListFooterStats aggregates the loaded messages of the current view
+func computeListFooterStats(meta []*gmailapi.Message, loaded int) listFooterStats {
+	st := listFooterStats{loaded: loaded}
+	for _, m := range meta {
+		if m == nil {
+			continue
+		}
+		for _, l := range m.LabelIds {
+			if l == "UNREAD" {
+				st.unread++
+				break
+			}
+		}
+	}
+	return st
+}
+
+// formatListFooter renders the stats as a single line (without color tags)
+func formatListFooter(st listFooterStats) string {
+	parts := make([]string, 0, 4)
+	if st.estimated && st.estimate >= int64(st.loaded) {
+		parts = append(parts, fmt.Sprintf("%d of ~%d loaded", st.loaded, st.estimate))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d loaded", st.loaded))
+	}
+	parts = append(parts, fmt.Sprintf("%d unread", st.unread))
+	if st.bulk {
+		parts = append(parts, fmt.Sprintf("%d selected", st.selected))
+	}
+	if st.query != "" {
+		parts = append(parts, st.query)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// listFooterQuery describes the scope of the current view for the footer
+func (a *App) listFooterQuery() string {
+	switch a.search.Mode() {
+	case "remote":
+		if a.crossSearch.isActive() {
+			return "all accounts: " + a.crossSearch.Query()
+		}
+		return a.search.Query()
+	case "local":
+		return "filter: " + a.search.localFilter
+	}
+	return "inbox"
+}
+
+// renderListFooter refreshes the footer text; a no-op while the footer is hidden
+func (a *App) renderListFooter() {
+	if !a.showListFooter {
+		return
+	}
+	footer, ok := a.views["listFooter"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	st := computeListFooterStats(a.messagesMeta, len(a.ids))
+	if a.search.Mode() != "local" { // a local filter narrows the loaded rows only
+		st.estimate, st.estimated = a.footer.get()
+	}
+	st.bulk = a.bulk.isMode()
+	st.selected = a.bulk.count()
+	st.query = a.listFooterQuery()
+	footer.SetText(fmt.Sprintf(" %s📊 %s%s", a.GetColorTag("secondary"), tview.Escape(formatListFooter(st)), a.GetEndTag()))
+}
+
+// refreshResultEstimate fetches Gmail's estimated total for a view scope in the background.
+// query is the effective Gmail query ("" = inbox). Searches always fetch it for their title; the
+// inbox only when the footer is shown.
+func (a *App) refreshResultEstimate(query string) {
+	a.footer.begin(query)
+	if (query == "" && !a.showListFooter) || a.Client == nil {
+		return
+	}
+	client := a.Client
+	go func() {
+		est, err := client.EstimateResultSize(query)
+		if err != nil {
+			if a.logger != nil {
+				a.logger.Printf("refreshResultEstimate: %v", err)
+			}
+			return
+		}
+		if a.footer.set(query, est) {
+			a.QueueUpdateDraw(func() {
+				a.renderListFooter()
+				a.refreshSearchResultsTitle()
+			})
+		}
+	}()
+}
+
+// setListFooterVisible shows or hides the footer row. Must run on the UI goroutine.
+func (a *App) setListFooterVisible(show bool) {
+	a.showListFooter = show
+	footer, ok := a.views["listFooter"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	height := 0
+	if show {
+		height = 1
+	} else {
+		footer.Clear()
+	}
+	if mainFlex, ok := a.views["mainFlex"].(*tview.Flex); ok {
+		mainFlex.ResizeItem(footer, height, 0)
+	}
+}
+
+// executeFooterCommand handles :footer [on|off]
+func (a *App) executeFooterCommand(args []string) {
+	show := !a.showListFooter
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on", "show":
+			show = true
+		case "off", "hide":
+			show = false
+		default:
+			a.showError("Usage: footer [on|off]")
+			return
+		}
+	}
+	a.setListFooterVisible(show)
+	if show {
+		query := ""
+		if a.search.Mode() == "remote" && !a.crossSearch.isActive() {
+			query = a.search.Query()
+		}
+		a.refreshResultEstimate(query)
+		a.renderListFooter()
+		go a.GetErrorHandler().ShowInfo(a.ctx, "List footer enabled")
+		return
+	}
+	go a.GetErrorHandler().ShowInfo(a.ctx, "List footer disabled")
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"testing"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestComputeListFooterStats_CountsUnread(t *testing.T) {
+	meta := []*gmailapi.Message{
+		{Id: "a", LabelIds: []string{"INBOX", "UNREAD"}},
+		{Id: "b", LabelIds: []string{"INBOX"}},
+		nil,
+		{Id: "c", LabelIds: []string{"UNREAD"}},
+	}
+	st := computeListFooterStats(meta, 4)
+	if st.loaded != 4 || st.unread != 2 {
+		t.Fatalf("stats = %+v, want loaded=4 unread=2", st)
+	}
+}
+
+func TestFormatListFooter(t *testing.T) {
+	cases := []struct {
+		st   listFooterStats
+		want string
+	}{
+		{listFooterStats{loaded: 50, unread: 3, query: "inbox"}, "50 loaded · 3 unread · inbox"},
+		{listFooterStats{loaded: 50, estimate: 1200, estimated: true, unread: 3, query: "from:ana"}, "50 of ~1200 loaded · 3 unread · from:ana"},
+		// Gmail can underestimate; never show "50 of ~20"
+		{listFooterStats{loaded: 50, estimate: 20, estimated: true}, "50 loaded · 0 unread"},
+		{listFooterStats{loaded: 10, bulk: true, selected: 4}, "10 loaded · 0 unread · 4 selected"},
+	}
+	for _, c := range cases {
+		if got := formatListFooter(c.st); got != c.want {
+			t.Errorf("formatListFooter(%+v) = %q, want %q", c.st, got, c.want)
+		}
+	}
+}
+
+func TestListFooterState_DropsStaleEstimates(t *testing.T) {
+	var s listFooterState
+	s.begin("from:ana")
+	s.begin("")
+	if s.set("from:ana", 99) {
+		t.Fatal("estimate of a previous scope must be dropped")
+	}
+	if _, ok := s.get(); ok {
+		t.Fatal("estimate should still be unknown")
+	}
+	if !s.set("", 1234) {
+		t.Fatal("estimate of the current scope must be stored")
+	}
+	if est, ok := s.get(); !ok || est != 1234 {
+		t.Fatalf("get() = %d, %v", est, ok)
+	}
+}
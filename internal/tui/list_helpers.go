@@ -1,8 +1,6 @@
 package tui
 
 import (
-	"fmt"
-
 	"github.com/derailed/tview"
 )
 
@@ -107,7 +105,7 @@ func (a *App) safeRemoveCurrentSelection(removedMessageID string) {
 	}
 
 	// Update title
-	table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+	table.SetTitle(a.messagesTitle())
 
 	// Content update is handled automatically by SetSelectionChangedFunc when table.Select() is called above
 	// No need to manually call showMessageWithoutFocus here as it creates race conditions
@@ -173,7 +171,7 @@ func (a *App) removeIDsFromCurrentList(ids []string) {
 		}
 		i++
 	}
-	table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+	table.SetTitle(a.messagesTitle())
 
 	// Adjust selection and content
 	cur, _ := table.GetSelection()
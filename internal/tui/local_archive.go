@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// localArchivePage is the Pages name of the archived-message viewer
+const localArchivePage = "localArchiveMessage"
+
+// bindLocalArchive (re)creates the local archive for the active account and client
+func (a *App) bindLocalArchive() {
+	if a.dbStore == nil || a.Client == nil || a.emailService == nil {
+		return
+	}
+	svc := services.NewLocalArchiveService(db.NewLocalArchiveStore(a.dbStore), a.Client, a.emailService.TrashMessage, a.Config.LocalArchive.ResolvedDir())
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.localArchiveService = svc
+}
+
+// executeLocalArchiveCommand handles :localarchive [search [terms]] — move the current message (or
+// the bulk selection) to the local archive, or search what was archived
+func (a *App) executeLocalArchiveCommand(args []string) {
+	if a.localArchiveService == nil {
+		a.showError("Local archive not available (no local database)")
+		return
+	}
+	if len(args) > 0 {
+		if strings.EqualFold(args[0], "search") {
+			a.openLocalArchivePanel(strings.Join(args[1:], " "))
+			return
+		}
+		a.showError("Usage: localarchive [search [terms]]")
+		return
+	}
+	if a.bulk.isMode() && a.bulk.count() > 0 {
+		ids := make([]string, 0, a.bulk.count())
+		ids = append(ids, a.bulk.ids()...)
+		if a.blockForeignMessages(ids...) {
+			return
+		}
+		a.withQuotaPlan(services.QuotaOpLocal, ids, "Archiving locally", a.runLocalArchiveBulk)
+		return
+	}
+	messageID := a.getCurrentSelectedMessageID()
+	if messageID == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowProgress(a.ctx, "🗄️ Saving message to the local archive…")
+		err := a.localArchiveService.ArchiveLocally(a.ctx, messageID)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error archiving locally", err)
+			return
+		}
+		a.GetErrorHandler().ShowSuccess(a.ctx, "🗄️ Archived locally and moved to Gmail trash")
+		a.QueueUpdateDraw(func() { a.safeRemoveCurrentSelection(messageID) })
+	}()
+}
+
+// runLocalArchiveBulk archives ids locally following the quota plan. Messages that fail stay in
+// the list and selected so the job can be rerun.
+func (a *App) runLocalArchiveBulk(ids []string, plan services.QuotaPlan) {
+	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Archiving %d message(s) locally…", len(ids)))
+	go func() {
+		var done []string
+		var lastErr error
+		_ = a.runQuotaPlanned(plan, ids, "Archiving locally", func(batch []string, progress func(done, total int)) error {
+			for i, id := range batch {
+				if err := a.localArchiveService.ArchiveLocally(a.ctx, id); err != nil {
+					lastErr = err
+				} else {
+					done = append(done, id)
+				}
+				progress(i+1, len(batch))
+			}
+			return nil
+		})
+		a.GetErrorHandler().ClearPersistentMessage()
+
+		a.QueueUpdateDraw(func() {
+			a.removeIDsFromCurrentList(done)
+			a.finishBulkJob(done)
+			a.refreshTableDisplay()
+			if list, ok := a.views["list"].(*tview.Table); ok {
+				list.SetSelectedStyle(a.getSelectionStyle())
+			}
+			a.focusList()
+		})
+		a.GetErrorHandler().ClearProgress()
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			if failed := len(ids) - len(done); failed > 0 {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, fmt.Sprintf("Archived %d locally, %d failed", len(done), failed), lastErr)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🗄️ Archived %d message(s) locally", len(done)))
+		}()
+	}()
+}
+
+// formatLocalArchiveItem renders a search result row: subject, then sender and dates
+func formatLocalArchiveItem(info *services.LocalArchiveInfo) (string, string) {
+	subject := strings.TrimSpace(info.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	secondary := info.From
+	if !info.Date.IsZero() {
+		secondary += " · " + info.Date.Format("Jan 2 2006")
+	}
+	secondary += " · archived " + info.ArchivedAt.Format("Jan 2 15:04")
+	return "🗄️ " + subject, secondary
+}
+
+// openLocalArchivePanel shows the local archive search in the side panel: type to search the
+// full-text index, Enter opens the archived copy, s saves it as .eml
+func (a *App) openLocalArchivePanel(terms string) {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	input := tview.NewInputField().
+		SetLabel("🔍 Search: ").
+		SetText(terms).
+		SetLabelColor(colors.Title.Color()).
+		SetFieldBackgroundColor(bgColor).
+		SetFieldTextColor(colors.Text.Color())
+	input.SetBackgroundColor(bgColor)
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+	var results []*services.LocalArchiveInfo
+	search := func(q string) {
+		go func() {
+			found, err := a.localArchiveService.Search(a.ctx, q, 200)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error searching local archive", err)
+				return
+			}
+			a.QueueUpdateDraw(func() {
+				results = found
+				list.Clear()
+				if len(found) == 0 {
+					list.AddItem("No archived messages match", "", 0, nil)
+					return
+				}
+				for _, info := range found {
+					primary, secondary := formatLocalArchiveItem(info)
+					list.AddItem(tview.Escape(primary), tview.Escape(secondary), 0, nil)
+				}
+			})
+		}()
+	}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i >= 0 && i < len(results) {
+			go a.showLocalArchiveMessage(results[i])
+		}
+	})
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			a.closeLocalArchivePanel()
+		case tcell.KeyEnter:
+			search(strings.TrimSpace(input.GetText()))
+			a.SetFocus(list)
+		}
+	})
+	input.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyDown {
+			a.SetFocus(list)
+			return nil
+		}
+		return e
+	})
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyEscape {
+			a.closeLocalArchivePanel()
+			return nil
+		}
+		if e.Key() == tcell.KeyUp && list.GetCurrentItem() == 0 || e.Rune() == '/' {
+			a.SetFocus(input)
+			return nil
+		}
+		if e.Rune() == 's' {
+			if i := list.GetCurrentItem(); i >= 0 && i < len(results) {
+				go a.saveLocalArchiveEML(results[i])
+			}
+			return nil
+		}
+		return e
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(" 🗄️ Local archive ")
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(input, 3, 0, true)
+	container.AddItem(list, 0, 1, false)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to open | s to save .eml | / to search | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "localarchive")
+	}
+	a.markFocus("labels")
+	a.setActivePicker(PickerLocalArchive)
+	if terms != "" {
+		a.SetFocus(list)
+	} else {
+		a.SetFocus(input)
+	}
+	search(strings.TrimSpace(terms))
+}
+
+// closeLocalArchivePanel closes the local archive panel and restores focus
+func (a *App) closeLocalArchivePanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
+
+// showLocalArchiveMessage opens an archived message from the index in a read-only viewer
+func (a *App) showLocalArchiveMessage(info *services.LocalArchiveInfo) {
+	body, err := a.localArchiveService.Body(a.ctx, info.ID)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error opening archived message", err)
+		return
+	}
+	header := a.emailRenderer.FormatHeaderPlain(info.Subject, info.From, info.To, "", info.Date, info.Labels)
+	content := header + "\n\n" + body
+
+	a.QueueUpdateDraw(func() {
+		colors := a.GetComponentColors("general")
+		view := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+		view.SetText(content)
+		view.SetTextColor(colors.Text.Color())
+		view.SetBackgroundColor(colors.Background.Color())
+		view.SetBorder(true).
+			SetTitle(" 🗄️ Archived message — s to save .eml | Esc to close ").
+			SetTitleColor(colors.Title.Color()).
+			SetBorderColor(colors.Border.Color())
+		view.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+			switch {
+			case ev.Key() == tcell.KeyEscape || ev.Rune() == 'q':
+				a.Pages.RemovePage(localArchivePage)
+				a.restoreFocusAfterModal()
+				return nil
+			case ev.Rune() == 's':
+				go a.saveLocalArchiveEML(info)
+				return nil
+			}
+			return ev
+		})
+		a.Pages.AddPage(localArchivePage, tview.NewFlex().
+			AddItem(nil, 2, 0, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 1, 0, false).
+				AddItem(view, 0, 1, true).
+				AddItem(nil, 1, 0, false), 0, 1, true).
+			AddItem(nil, 2, 0, false), true, true)
+		a.SetFocus(view)
+	})
+}
+
+// saveLocalArchiveEML writes the archived raw message to the saved folder as an .eml file
+func (a *App) saveLocalArchiveEML(info *services.LocalArchiveInfo) {
+	raw, err := a.localArchiveService.Raw(a.ctx, info)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error reading local archive", err)
+		return
+	}
+	base := config.DefaultSavedDir()
+	if err := os.MkdirAll(base, 0o750); err != nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Could not create saved folder")
+		return
+	}
+	file := filepath.Join(base, time.Now().Format("20060102-150405")+"-"+sanitizeFilename(info.Subject)+".eml")
+	if err := os.WriteFile(file, raw, 0o600); err != nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Could not write file")
+		return
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, "💾 Saved raw: "+file)
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestFormatLocalArchiveItem(t *testing.T) {
+	info := &services.LocalArchiveInfo{
+		Subject:    "Your invoice for March",
+		From:       "Billing <billing@vendor.example>",
+		Date:       time.Date(2024, 3, 1, 8, 0, 0, 0, time.Local),
+		ArchivedAt: time.Date(2025, 3, 14, 9, 30, 0, 0, time.Local),
+	}
+	primary, secondary := formatLocalArchiveItem(info)
+	if primary != "🗄️ Your invoice for March" {
+		t.Fatalf("primary = %q", primary)
+	}
+	if secondary != "Billing <billing@vendor.example> · Mar 1 2024 · archived Mar 14 09:30" {
+		t.Fatalf("secondary = %q", secondary)
+	}
+
+	info.Subject, info.Date = "  ", time.Time{}
+	primary, secondary = formatLocalArchiveItem(info)
+	if primary != "🗄️ (no subject)" || secondary != "Billing <billing@vendor.example> · archived Mar 14 09:30" {
+		t.Fatalf("fallbacks = %q / %q", primary, secondary)
+	}
+}
+
+type recordingLocalArchive struct {
+	services.LocalArchiveService
+	archived []string
+}
+
+func (r *recordingLocalArchive) ArchiveLocally(_ context.Context, messageID string) error {
+	r.archived = append(r.archived, messageID)
+	return nil
+}
+
+func TestExecuteLocalArchiveCommand_BlocksForeignMessages(t *testing.T) {
+	archive := &recordingLocalArchive{}
+	a := &App{localArchiveService: archive, bulk: newBulkState()}
+	a.crossSearch.begin("from:bob", "work")
+	a.crossSearch.add(crossPage(nil, [2]string{"work", "m1"}, [2]string{"home", "m2"}))
+	a.bulk.setMode(true)
+	a.bulk.add("m1")
+	a.bulk.add("m2")
+
+	a.executeLocalArchiveCommand(nil)
+	if len(archive.archived) != 0 {
+		t.Fatalf("messages of another account must not be archived, got %v", archive.archived)
+	}
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// localFilterMessages returns two messages of each of four kinds (plain, update, starred project,
+// finance invoice)
+func localFilterMessages() []*gmailapi.Message {
+	kinds := []struct {
+		from, subject string
+		labels        []string
+	}{
+		{"Ana García <ana@example.com>", "Quarterly planning notes", []string{"INBOX", "UNREAD"}},
+		{"GitHub <notifications@github.com>", "[org/repo] CI failed: main", []string{"INBOX", "CATEGORY_UPDATES"}},
+		{"Pepe <pepe@example.org>", "Lunch on Friday?", []string{"INBOX", "STARRED", "Label_1"}},
+		{"Billing <billing@vendor.example>", "Your invoice is ready", []string{"INBOX", "IMPORTANT", "Label_2"}},
+	}
+	var meta []*gmailapi.Message
+	for i := 0; i < 8; i++ {
+		k := kinds[i%len(kinds)]
+		meta = append(meta, &gmailapi.Message{
+			Id:       string(rune('a' + i)),
+			LabelIds: k.labels,
+			Payload: &gmailapi.MessagePart{Headers: []*gmailapi.MessagePartHeader{
+				{Name: "From", Value: k.from},
+				{Name: "Subject", Value: k.subject},
+			}},
+		})
+	}
+	return meta
+}
+
+func TestLocalFilter_Matches(t *testing.T) {
+	meta := localFilterMessages()
+	idToName := map[string]string{"Label_2": "Finance"}
+	cases := []struct {
+		expr string
+		want int
+	}{
+		{"invoice", 2},
+		{"invoice label:finance", 2},
+		{"label:updates", 2}, // CATEGORY_ prefix is stripped
+		{"lunch label:finance", 0},
+		{"", 8},
+	}
+	for _, c := range cases {
+		f := parseLocalFilter(c.expr)
+		n := 0
+		for _, m := range meta {
+			if f.matches(m, idToName) {
+				n++
+			}
+		}
+		if n != c.want {
+			t.Errorf("filter %q matched %d, want %d", c.expr, n, c.want)
+		}
+	}
+	if parseLocalFilter("x").matches(nil, nil) {
+		t.Fatal("nil message must not match")
+	}
+}
@@ -0,0 +1,373 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// mailMergePage is the Pages name of the mail merge preview
+const mailMergePage = "mailMerge"
+
+// mailMergeState is the loaded merge as the UI shows it. The statuses are copies updated through
+// QueueUpdateDraw, so the panel never reads items the sending goroutine writes (UI goroutine only).
+type mailMergeState struct {
+	job     *services.MailMergeJob
+	status  []services.MergeStatus
+	errs    []string
+	cancel  context.CancelFunc // set while sending
+	refresh func()             // redraws the open panel
+}
+
+// bindMailMerge (re)creates the mail merge service for the active account
+func (a *App) bindMailMerge() {
+	svc := services.NewMailMergeService(db.NewMailMergeStore(a.dbStore), a.compositionService, a.Config.MailMerge.ResolvedInterval())
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.mailMergeService = svc
+}
+
+// executeMergeCommand handles ':merge <recipients.csv> <template>' (load and preview a merge),
+// ':merge' (reopen the loaded one) and ':merge stop'
+func (a *App) executeMergeCommand(args []string) {
+	if a.mailMergeService == nil {
+		a.showError("Mail merge not available (no local database)")
+		return
+	}
+	switch {
+	case len(args) == 0:
+		if a.mailMerge == nil {
+			a.showError("Usage: merge <recipients.csv> <template file or name> | stop")
+			return
+		}
+		a.openMailMergePanel()
+	case len(args) == 1 && strings.EqualFold(args[0], "stop"):
+		a.stopMailMerge()
+	case len(args) == 2:
+		if a.mailMerge != nil && a.mailMerge.cancel != nil {
+			a.showError("A mail merge is sending — :merge stop first")
+			return
+		}
+		go a.loadMailMerge(args[0], args[1])
+	default:
+		a.showError("Usage: merge <recipients.csv> <template file or name> | stop")
+	}
+}
+
+// loadMailMerge reads the recipient list and the template, fills it for every row and opens the
+// preview with what earlier runs of the same merge already sent
+func (a *App) loadMailMerge(csvPath, templateArg string) {
+	f, err := os.Open(expandMergePath(csvPath))
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error opening recipient list", err)
+		return
+	}
+	recipients, err := services.ParseMergeCSV(f)
+	_ = f.Close()
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error reading recipient list", err)
+		return
+	}
+	tmpl, err := a.loadMergeTemplate(templateArg)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading template", err)
+		return
+	}
+	job := services.NewMailMergeJob(tmpl, recipients)
+	if err := a.mailMergeService.LoadStatus(a.ctx, job); err != nil && a.logger != nil {
+		a.logger.Printf("mail merge: loading earlier progress failed: %v", err)
+	}
+	state := &mailMergeState{job: job}
+	for _, it := range job.Items {
+		state.status = append(state.status, it.Status)
+		state.errs = append(state.errs, mergeItemError(it))
+	}
+	a.QueueUpdateDraw(func() {
+		a.mailMerge = state
+		a.openMailMergePanel()
+	})
+}
+
+// loadMergeTemplate reads a template file ("Subject: ..." line, blank line, body) or, when no such
+// file exists, picks the composer template with that ID
+func (a *App) loadMergeTemplate(arg string) (services.MergeTemplate, error) {
+	path := expandMergePath(arg)
+	if data, err := os.ReadFile(path); err == nil {
+		return services.ParseMergeTemplate(filepath.Base(path), string(data)), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return services.MergeTemplate{}, err
+	}
+	if a.compositionService != nil {
+		templates, err := a.compositionService.GetTemplates(a.ctx, "")
+		if err != nil {
+			return services.MergeTemplate{}, err
+		}
+		for _, t := range templates {
+			if strings.EqualFold(t.ID, arg) {
+				return services.MergeTemplateFromEmailTemplate(t), nil
+			}
+		}
+	}
+	return services.MergeTemplate{}, fmt.Errorf("no template file or template named %q", arg)
+}
+
+// expandMergePath expands a leading ~/ to the home directory
+func expandMergePath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// mergeItemError is the reason shown for an item that can't be sent or failed
+func mergeItemError(it *services.MergeItem) string {
+	if it.RenderError != nil {
+		return it.RenderError.Error()
+	}
+	return it.LastError
+}
+
+// formatMergeRow renders a recipient row of the merge panel
+func formatMergeRow(it *services.MergeItem, status services.MergeStatus, errText string) string {
+	marker := "⏳"
+	switch {
+	case status == services.MergeSent:
+		marker = "✅"
+	case status == services.MergeFailed, it.RenderError != nil:
+		marker = "⚠"
+	}
+	row := fmt.Sprintf("%s %s", marker, it.Recipient.Email)
+	if errText != "" {
+		row += " — " + errText
+	}
+	return row
+}
+
+// formatMergePreview renders the message generated for a row; external marks a recipient outside
+// the account's internal domains
+func formatMergePreview(it *services.MergeItem, external bool) string {
+	if it.Composition == nil {
+		return "⚠ This message can't be generated:\n\n" + mergeItemError(it)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "To:      %s", it.Recipient.Email)
+	if external {
+		b.WriteString("  ⚠ outside your organization")
+	}
+	fmt.Fprintf(&b, "\nSubject: %s\n\n%s", it.Composition.Subject, it.Composition.Body)
+	return b.String()
+}
+
+// title is the panel title with the counts of the loaded merge
+func (s *mailMergeState) title() string {
+	var sent, failed int
+	for _, st := range s.status {
+		switch st {
+		case services.MergeSent:
+			sent++
+		case services.MergeFailed:
+			failed++
+		}
+	}
+	title := fmt.Sprintf(" 📨 Mail merge · %s · %d recipient(s) · %d sent", s.job.Template.Name, len(s.status), sent)
+	if failed > 0 {
+		title += fmt.Sprintf(" · %d failed", failed)
+	}
+	if s.cancel != nil {
+		title += " · sending…"
+	}
+	return title + " "
+}
+
+// openMailMergePanel shows the loaded merge: the recipients with their status on the left and the
+// selected message on the right. s sends everything not sent yet, x stops, Esc closes (sending
+// goes on in the background).
+func (a *App) openMailMergePanel() {
+	state := a.mailMerge
+	if state == nil {
+		return
+	}
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+	domains := a.activeInternalDomains()
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+	list.SetBorder(true).SetBorderColor(colors.Border.Color())
+
+	preview := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+	preview.SetTextColor(colors.Text.Color())
+	preview.SetBackgroundColor(bgColor)
+	preview.SetBorder(true).SetBorderColor(colors.Border.Color())
+
+	showPreview := func(i int) {
+		if i < 0 || i >= len(state.job.Items) {
+			return
+		}
+		it := state.job.Items[i]
+		external := len(domains) > 0 && !services.IsInternalAddress(it.Recipient.Email, domains)
+		preview.SetText(formatMergePreview(it, external))
+		preview.SetTitle(fmt.Sprintf(" Message %d/%d ", i+1, len(state.job.Items)))
+		preview.ScrollToBeginning()
+	}
+	for i, it := range state.job.Items {
+		list.AddItem(tview.Escape(formatMergeRow(it, state.status[i], state.errs[i])), "", 0, nil)
+	}
+	list.SetChangedFunc(func(i int, _, _ string, _ rune) { showPreview(i) })
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" s to send all not yet sent | x to stop | Tab to scroll preview | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+
+	body := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(preview, 0, 3, false)
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(body, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true).
+		SetTitle(state.title()).
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+
+	state.refresh = func() {
+		for i, it := range state.job.Items {
+			list.SetItemText(i, tview.Escape(formatMergeRow(it, state.status[i], state.errs[i])), "")
+		}
+		container.SetTitle(state.title())
+	}
+	closePanel := func() {
+		state.refresh = nil
+		a.Pages.RemovePage(mailMergePage)
+		a.restoreFocusAfterModal()
+	}
+	keys := func(ev *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case ev.Key() == tcell.KeyEscape:
+			closePanel()
+			return nil
+		case ev.Key() == tcell.KeyTab:
+			if a.GetFocus() == list {
+				a.SetFocus(preview)
+			} else {
+				a.SetFocus(list)
+			}
+			return nil
+		case ev.Rune() == 's':
+			a.startMailMerge()
+			return nil
+		case ev.Rune() == 'x':
+			a.stopMailMerge()
+			return nil
+		}
+		return ev
+	}
+	list.SetInputCapture(keys)
+	preview.SetInputCapture(keys)
+
+	a.Pages.AddPage(mailMergePage, tview.NewFlex().
+		AddItem(nil, 2, 0, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(container, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 1, true).
+		AddItem(nil, 2, 0, false), true, true)
+	a.SetFocus(list)
+	showPreview(0)
+}
+
+// startMailMerge sends the loaded merge in the background, one message every
+// mail_merge.interval, with the progress in the status bar and the panel. UI goroutine only.
+func (a *App) startMailMerge() {
+	state := a.mailMerge
+	if state == nil || state.cancel != nil {
+		return
+	}
+	pending := 0
+	for _, st := range state.status {
+		if st != services.MergeSent {
+			pending++
+		}
+	}
+	if pending == 0 {
+		a.showInfo("📨 Every message of this merge was already sent")
+		return
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	state.cancel = cancel
+	if state.refresh != nil {
+		state.refresh()
+	}
+
+	go func() {
+		done := 0
+		a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("📨 Mail merge 0/%d…", pending))
+		res, err := a.mailMergeService.Run(ctx, state.job, func(i int, it *services.MergeItem) {
+			done++
+			status, errText := it.Status, mergeItemError(it)
+			a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("📨 Mail merge %d/%d…", done, pending))
+			a.QueueUpdateDraw(func() {
+				state.status[i], state.errs[i] = status, errText
+				if state.refresh != nil {
+					state.refresh()
+				}
+			})
+		})
+		cancel()
+		a.GetErrorHandler().ClearProgress()
+		a.QueueUpdateDraw(func() {
+			state.cancel = nil
+			if state.refresh != nil {
+				state.refresh()
+			}
+		})
+		switch {
+		case err != nil:
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Mail merge failed", err)
+		case res.StoppedBy != nil:
+			a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("📨 Mail merge stopped (%v): %s — run the same :merge again to resume", res.StoppedBy, formatMergeResult(res)))
+		case res.Failed > 0:
+			a.GetErrorHandler().ShowWarning(a.ctx, "📨 Mail merge done: "+formatMergeResult(res))
+		default:
+			a.GetErrorHandler().ShowSuccess(a.ctx, "📨 Mail merge done: "+formatMergeResult(res))
+		}
+	}()
+}
+
+// stopMailMerge cancels a running merge after the message being sent. UI goroutine only.
+func (a *App) stopMailMerge() {
+	if a.mailMerge == nil || a.mailMerge.cancel == nil {
+		a.showInfo("📨 No mail merge is sending")
+		return
+	}
+	a.mailMerge.cancel()
+}
+
+// formatMergeResult summarizes a run, e.g. "12 sent, 1 failed, 30 left"
+func formatMergeResult(res services.MergeRunResult) string {
+	parts := []string{fmt.Sprintf("%d sent", res.Sent)}
+	if res.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", res.Failed))
+	}
+	if res.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d already sent before", res.Skipped))
+	}
+	if res.Remaining > 0 {
+		parts = append(parts, fmt.Sprintf("%d left", res.Remaining))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestFormatMergeRowAndPreview(t *testing.T) {
+	r := services.MergeRecipient{Row: 2, Email: "ana@partner.io", Vars: map[string]string{"name": "Ana"}}
+	ok := &services.MergeItem{Recipient: r, Composition: &services.Composition{Subject: "Hi Ana", Body: "Hello"}}
+	if got := formatMergeRow(ok, services.MergeSent, ""); got != "✅ ana@partner.io" {
+		t.Errorf("sent row = %q", got)
+	}
+	if got := formatMergeRow(ok, services.MergeFailed, "invalid recipient"); got != "⚠ ana@partner.io — invalid recipient" {
+		t.Errorf("failed row = %q", got)
+	}
+	if got := formatMergePreview(ok, true); !strings.Contains(got, "⚠ outside your organization") || !strings.HasSuffix(got, "Subject: Hi Ana\n\nHello") {
+		t.Errorf("preview = %q", got)
+	}
+
+	broken := &services.MergeItem{Recipient: r, RenderError: errors.New("row 2: no column for title")}
+	if got := formatMergeRow(broken, services.MergePending, mergeItemError(broken)); !strings.HasPrefix(got, "⚠ ") {
+		t.Errorf("unrenderable row = %q", got)
+	}
+}
+
+func TestFormatMergeResult(t *testing.T) {
+	got := formatMergeResult(services.MergeRunResult{Sent: 12, Failed: 1, Skipped: 3, Remaining: 30})
+	if got != "12 sent, 1 failed, 3 already sent before, 30 left" {
+		t.Errorf("result = %q", got)
+	}
+	if got := formatMergeResult(services.MergeRunResult{}); got != "0 sent" {
+		t.Errorf("empty result = %q", got)
+	}
+}
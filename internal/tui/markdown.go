@@ -105,16 +105,62 @@ func paragraphsOnlyDeleted(in, out string) bool {
 // pipeline as the email reader (tables, headings, width-fit). <br> is normalized first
 // because some models emit it inside table cells. Falls back to the raw text on error.
 func (a *App) renderPromptResult(text string) string {
-	clean := strings.ReplaceAll(text, "<br>", "\n")
-	clean = strings.ReplaceAll(clean, "<br/>", "\n")
-	clean = strings.ReplaceAll(clean, "<br />", "\n")
-	out, err := render.MarkdownToTerminal(clean, a.Config.Rendering.GlamourTheme, a.getListWidth())
+	out, err := render.MarkdownToTerminal(normalizeBreaks(text), a.Config.Rendering.GlamourTheme, a.aiPaneWidth())
 	if err != nil {
-		return text
+		return tview.Escape(text)
 	}
 	return out
 }
 
+// normalizeBreaks turns the <br> tags some models emit into newlines
+func normalizeBreaks(text string) string {
+	return strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n").Replace(text)
+}
+
+// aiPaneWidth is the width AI answers wrap at: the AI pane's, or the list's while it is hidden
+func (a *App) aiPaneWidth() int {
+	if a.aiSummaryView != nil {
+		if _, _, w, _ := a.aiSummaryView.GetInnerRect(); w >= 20 {
+			return w - 2
+		}
+	}
+	return a.getListWidth()
+}
+
+// aiStream renders an AI answer into the pane as it streams: finished Markdown blocks formatted,
+// the block being written as plain text
+type aiStream struct {
+	app *App
+	md  *render.MarkdownStream
+	buf strings.Builder
+}
+
+// newAIStream starts rendering a streamed answer into the AI pane
+func (a *App) newAIStream() *aiStream {
+	a.aiPanel.setSource("")
+	return &aiStream{app: a}
+}
+
+// write adds a streamed token and returns the pane text, prefix (plain text) first. The wrap
+// width is taken on the first token, once the pane has been laid out.
+func (s *aiStream) write(token, prefix string) string {
+	if s.md == nil {
+		s.md = render.NewMarkdownStream(s.app.Config.Rendering.GlamourTheme, s.app.aiPaneWidth())
+	}
+	s.buf.WriteString(token)
+	source := normalizeBreaks(s.buf.String())
+	s.app.aiPanel.setSource(source)
+	return tview.Escape(prefix) + s.md.Render(source)
+}
+
+// showAIResult shows a finished AI answer in the pane rendered as Markdown, and keeps it for the
+// link picker. Must run on the UI goroutine.
+func (a *App) showAIResult(text string) {
+	a.aiPanel.setSource(text)
+	a.aiSummaryView.SetText(a.renderPromptResult(text))
+	a.aiSummaryView.ScrollToBeginning()
+}
+
 // renderMessageContent builds body via deterministic formatter and optional LLM touch-up
 func (a *App) renderMessageContent(m *gmail.Message) (string, bool) {
 	// Update header TextView separately (tview markup)
@@ -125,7 +171,7 @@ func (a *App) renderMessageContent(m *gmail.Message) (string, bool) {
 		_, _, _, _, _, _, _, _, _, _, _, displayService := a.GetServices()
 		if displayService != nil && displayService.IsHeaderVisible() {
 			headerWidth := a.getHeaderWidth()
-			headerContent := a.emailRenderer.FormatHeaderPlainWithWidth(m.Subject, m.From, m.To, m.Cc, m.Date, m.Labels, headerWidth)
+			headerContent := a.formatMessageHeader(m, headerWidth)
 			hv.SetText(headerContent)
 
 			// Dynamically adjust header height based on content
@@ -226,7 +272,7 @@ func (a *App) renderMessageContent(m *gmail.Message) (string, bool) {
 // toggles below.
 func (a *App) rerenderCurrentMessage(mid string, status func()) {
 	apply := func(msg *gmail.Message) {
-		rendered, _ := a.renderMessageContent(msg)
+		rendered, _ := a.renderMessageForView(msg)
 		a.QueueUpdateDraw(func() {
 			if text, ok := a.views["text"].(*tview.TextView); ok {
 				text.SetDynamicColors(true)
@@ -245,7 +291,7 @@ func (a *App) rerenderCurrentMessage(mid string, status func()) {
 		return
 	}
 	go func(id string) {
-		fetched, err := a.Client.GetMessageWithContent(id)
+		fetched, err := a.messageClient(id).GetMessageWithContent(id)
 		if err != nil {
 			a.GetErrorHandler().ShowError(a.ctx, "❌ Could not load message content")
 			return
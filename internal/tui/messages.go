@@ -133,7 +133,7 @@ func (a *App) restoreLocalBaseSnapshot() {
 				}
 				table.Select(selectIdx, 0)
 			}
-			table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+			table.SetTitle(a.messagesTitle())
 		}
 		a.refreshTableDisplay()
 
@@ -191,6 +191,19 @@ func (a *App) exitSearch() {
 
 // reloadMessages loads messages from the inbox, respecting current threading mode
 func (a *App) reloadMessages() {
+	a.crossSearch.reset()
+	a.alerts.reset()
+	// Sessions that run past midnight still get one inbox snapshot per day
+	if a.search.Query() == "" {
+		go a.captureInboxSnapshot(false)
+	}
+	a.refreshResultEstimate("")
+	// Leaving the search results: drop their refinement chips
+	a.QueueUpdateDraw(func() {
+		if len(a.search.chips) > 0 {
+			a.hideSearchChips()
+		}
+	})
 
 	// Check if we're in threading mode and should reload threads instead
 	if a.IsThreadingEnabled() && a.GetCurrentThreadViewMode() == ThreadViewThread {
@@ -265,6 +278,7 @@ func (a *App) reloadThreadsWithSpinner() {
 
 		// Mark loading as complete
 		a.SetMessagesLoading(false)
+		a.markBoot("inbox")
 	}()
 }
 
@@ -320,7 +334,7 @@ func (a *App) reloadMessagesFlat() {
 		if a.logger != nil {
 			a.logger.Printf("RELOAD_MSG: Error loading messages: %v", err)
 		}
-		a.showError(fmt.Sprintf("❌ Error loading messages: %v", err))
+		a.showErrorFor("Error loading messages", err)
 		return
 	}
 	a.nextPageToken = next
@@ -340,12 +354,8 @@ func (a *App) reloadMessagesFlat() {
 	screenWidth := a.getFormatWidth()
 
 	// Preload labels once for renderer context (avoid per-row API calls)
-	if labels, err := a.Client.ListLabels(); err == nil {
-		m := make(map[string]string, len(labels))
-		for _, l := range labels {
-			m[l.Id] = l.Name
-		}
-		a.emailRenderer.SetLabelMap(m)
+	if labels, err := a.listLabels(); err == nil {
+		a.setRendererLabels(labels)
 		a.emailRenderer.SetShowSystemLabelsInList(a.search.Mode() == "remote")
 	}
 
@@ -390,7 +400,7 @@ func (a *App) reloadMessagesFlat() {
 	// Fetch message metadata in parallel (optimized for list display - uses format=metadata)
 	detailedMessages, err := a.Client.GetMessagesMetadataParallel(messageIDs, 10)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error loading messages: %v", err))
+		a.showErrorFor("Error loading messages", err)
 		return
 	}
 
@@ -457,7 +467,7 @@ func (a *App) reloadMessagesFlat() {
 			a.hideCommandBar()
 		}
 		if table, ok := a.views["list"].(*tview.Table); ok {
-			table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+			table.SetTitle(a.messagesTitle())
 
 			// Always ensure the first message is selected when loading messages
 			if table.GetRowCount() > 1 && len(a.ids) > 0 {
@@ -523,6 +533,7 @@ func (a *App) reloadMessagesFlat() {
 
 	// Mark loading as complete
 	a.SetMessagesLoading(false)
+	a.markBoot("inbox")
 }
 
 // loadMoreMessages fetches the next page of inbox and appends to list, respecting current threading mode
@@ -546,6 +557,10 @@ func (a *App) loadMoreMessages() {
 	}
 	// If in remote search mode, paginate that query
 	if a.search.Mode() == "remote" {
+		if a.crossSearch.isActive() {
+			a.loadMoreCrossAccountResults()
+			return
+		}
 		if a.nextPageToken == "" {
 			a.showStatusMessage("No more results")
 			return
@@ -618,7 +633,7 @@ func (a *App) loadMoreMessages() {
 			a.logger.Printf("🌐 API FETCH (SEARCH): Loaded %d messages from API in %v", len(messages), apiLoadTime)
 		}
 		if err != nil {
-			a.showError(fmt.Sprintf("❌ Error loading more: %v", err))
+			a.showErrorFor("Error loading more", err)
 			return
 		}
 		// De-duplicate message IDs before appending to avoid duplicates on rapid key presses
@@ -661,12 +676,8 @@ func (a *App) loadMoreMessages() {
 			screenWidth := a.getFormatWidth()
 
 			// Preload labels once for this page
-			if labels, err := a.Client.ListLabels(); err == nil {
-				m := make(map[string]string, len(labels))
-				for _, l := range labels {
-					m[l.Id] = l.Name
-				}
-				a.emailRenderer.SetLabelMap(m)
+			if labels, err := a.listLabels(); err == nil {
+				a.setRendererLabels(labels)
 				a.emailRenderer.SetShowSystemLabelsInList(a.search.Mode() == "remote")
 			}
 
@@ -698,7 +709,7 @@ func (a *App) loadMoreMessages() {
 
 			a.QueueUpdateDraw(func() {
 				if table, ok := a.views["list"].(*tview.Table); ok {
-					table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+					table.SetTitle(a.messagesTitle())
 				}
 				a.refreshTableDisplay()
 				// FOCUS FIX: Restore focus to message list after loading cached messages
@@ -720,7 +731,7 @@ func (a *App) loadMoreMessages() {
 		a.logger.Printf("🌐 API FETCH (INBOX): Loaded %d messages from API in %v", len(messages), apiLoadTime)
 	}
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error loading more: %v", err))
+		a.showErrorFor("Error loading more", err)
 		return
 	}
 	// Append with lightweight progress in title
@@ -752,12 +763,8 @@ func (a *App) loadMoreMessages() {
 	}
 	screenWidth := a.getFormatWidth()
 	// Preload labels once for this page
-	if labels, err := a.Client.ListLabels(); err == nil {
-		m := make(map[string]string, len(labels))
-		for _, l := range labels {
-			m[l.Id] = l.Name
-		}
-		a.emailRenderer.SetLabelMap(m)
+	if labels, err := a.listLabels(); err == nil {
+		a.setRendererLabels(labels)
 		a.emailRenderer.SetShowSystemLabelsInList(a.search.Mode() == "remote")
 	}
 	// Collect message IDs for parallel fetching
@@ -772,7 +779,7 @@ func (a *App) loadMoreMessages() {
 	// Fetch message metadata in parallel (optimized for list display)
 	detailedMessages, err := a.Client.GetMessagesMetadataParallel(messageIDs, 10)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error loading more messages: %v", err))
+		a.showErrorFor("Error loading more messages", err)
 		return
 	}
 
@@ -809,7 +816,7 @@ func (a *App) loadMoreMessages() {
 
 	a.QueueUpdateDraw(func() {
 		if table, ok := a.views["list"].(*tview.Table); ok {
-			table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+			table.SetTitle(a.messagesTitle())
 		}
 		a.refreshTableDisplay()
 		if spinnerStop != nil {
@@ -834,7 +841,7 @@ func (a *App) appendMessages(messages []*gmailapi.Message) {
 	// Fetch message metadata in parallel (optimized for list display)
 	detailedMessages, err := a.Client.GetMessagesMetadataParallel(messageIDs, 10)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error loading message details: %v", err))
+		a.showErrorFor("Error loading message details", err)
 		return
 	}
 
@@ -859,7 +866,7 @@ func (a *App) appendMessages(messages []*gmailapi.Message) {
 	}
 	a.QueueUpdateDraw(func() {
 		if table, ok := a.views["list"].(*tview.Table); ok {
-			table.SetTitle(fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids)))
+			table.SetTitle(a.messagesTitle())
 
 			// Ensure there's always a valid selection when appending messages
 			if table.GetRowCount() > 0 && len(a.ids) > 0 {
@@ -948,7 +955,7 @@ func (a *App) openSearchOverlay(mode string) {
 	help := tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter)
 	help.SetTextColor(searchColors.Text.Color()).SetBackgroundColor(searchColors.Background.Color())
 	if mode == "remote" {
-		help.SetText("Press Ctrl+F for advanced search | Enter=search, Ctrl-T=switch, ESC to back")
+		help.SetText("Press Ctrl+F for advanced search | F1=operators | Enter=search, Ctrl-T=switch, ESC to back")
 	} else {
 		help.SetText("Type space-separated terms; all must match | Enter=apply, Ctrl-T=switch, ESC to back")
 	}
@@ -1064,7 +1071,7 @@ func (a *App) openSearchOverlay(mode string) {
 			} else {
 				curMode = "remote"
 				searchContainer.SetTitle("🔍 Gmail Search")
-				help.SetText("Press Ctrl+F for advanced search | Enter=search, Ctrl-T=switch, ESC to back")
+				help.SetText("Press Ctrl+F for advanced search | F1=operators | Enter=search, Ctrl-T=switch, ESC to back")
 				input.SetPlaceholder("e.g., from:user@domain.com subject:\"report\" is:unread label:work")
 				if sp, ok := a.views["searchPanel"].(*tview.Flex); ok {
 					sp.SetTitle("🔍 Gmail Search")
@@ -1077,6 +1084,11 @@ func (a *App) openSearchOverlay(mode string) {
 			a.openAdvancedSearchForm()
 			return nil
 		}
+		// Search operators cheat-sheet (configurable; default "f1")
+		if curMode == "remote" && a.matchesConfiguredKey(ev, a.Keys.SearchCheatSheet) {
+			a.toggleSearchCheatSheet()
+			return nil
+		}
 		if ev.Key() == tcell.KeyTab {
 			// move focus back to list while keeping search open
 			a.markFocus("list")
@@ -1124,6 +1136,13 @@ func (a *App) hideSearchContainer() {
 
 // openAdvancedSearchForm shows a guided form to compose a Gmail query, splitting the list area
 func (a *App) openAdvancedSearchForm() {
+	a.openAdvancedSearchFormWithQuery("")
+}
+
+// openAdvancedSearchFormWithQuery opens the advanced search form prefilled from an existing query
+// (used by the "add filter" refinement chip)
+func (a *App) openAdvancedSearchFormWithQuery(prefillQuery string) {
+	prefill := parseAdvancedSearchPrefill(prefillQuery)
 	// Hide simple search container if it's visible (mutual exclusion)
 	a.hideSearchContainer()
 
@@ -1193,10 +1212,17 @@ func (a *App) openAdvancedSearchForm() {
 		SetFieldWidth(50)
 	a.ConfigureInputFieldTheme(dateWithinField, "advanced")
 	form.AddFormItem(dateWithinField)
+	fromField.SetText(prefill.from)
+	toField.SetText(prefill.to)
+	subjectField.SetText(prefill.subject)
+	hasField.SetText(prefill.words)
 	// Scope
 	baseScopes := []string{"All Mail", "Inbox", "Archive", "Sent", "Drafts", "Spam", "Trash", "Starred", "Important"}
 	scopes := append([]string{}, baseScopes...)
 	scopeVal := "All Mail"
+	if prefill.scope != "" {
+		scopeVal = prefill.scope
+	}
 	if a.logger != nil {
 		a.logger.Println("advsearch: building form")
 	}
@@ -1272,15 +1298,15 @@ func (a *App) openAdvancedSearchForm() {
 	setNav(sizeExprField, 5)
 	setNav(dateWithinField, 6)
 	// Attachment
-	var hasAttachment bool
-	form.AddCheckbox("📎 Has attachment", false, func(label string, checked bool) { hasAttachment = checked })
+	hasAttachment := prefill.hasAttachment
+	form.AddCheckbox("📎 Has attachment", hasAttachment, func(label string, checked bool) { hasAttachment = checked })
 
 	// Load labels asynchronously to build picker options
 	go func() {
 		if a.logger != nil {
 			a.logger.Println("advsearch: loading labels...")
 		}
-		labels, err := a.Client.ListLabels()
+		labels, err := a.listLabels()
 		if err != nil || labels == nil {
 			if a.logger != nil {
 				a.logger.Printf("advsearch: ListLabels error=%v", err)
@@ -1290,7 +1316,7 @@ func (a *App) openAdvancedSearchForm() {
 		names := make([]string, 0, len(labels))
 		for _, l := range labels {
 			// Hide system categories we already map
-			if l.Type == "system" {
+			if l.Type == "system" || !a.labelVisibility.InPickers(l) {
 				continue
 			}
 			names = append(names, l.Name)
@@ -1960,22 +1986,88 @@ func (a *App) openAdvancedSearchForm() {
 	a.SetFocus(form)
 }
 
-// applyLocalFilter filters current in-memory messages based on a simple expression
-func (a *App) applyLocalFilter(expr string) {
-	// Compute matches off the UI thread
-	tokens := strings.Fields(strings.ToLower(expr))
-	labelTokens := make([]string, 0)
-	textTokens := make([]string, 0)
-	for _, t := range tokens {
+// localFilter is a parsed local filter expression: free-text tokens plus label: tokens
+type localFilter struct {
+	text   []string
+	labels []string
+}
+
+// parseLocalFilter splits a filter expression into lowercase text and label tokens
+func parseLocalFilter(expr string) localFilter {
+	var f localFilter
+	for _, t := range strings.Fields(strings.ToLower(expr)) {
 		if strings.HasPrefix(t, "label:") {
 			v := strings.TrimSpace(strings.TrimPrefix(t, "label:"))
 			if v != "" {
-				labelTokens = append(labelTokens, v)
+				f.labels = append(f.labels, v)
 			}
 		} else {
-			textTokens = append(textTokens, t)
+			f.text = append(f.text, t)
 		}
 	}
+	return f
+}
+
+// matches reports whether a cached message satisfies the filter; idToName maps label IDs to display names
+func (f localFilter) matches(m *gmailapi.Message, idToName map[string]string) bool {
+	if m == nil {
+		return false
+	}
+	// Build a rich searchable string: Subject, From, To, Snippet
+	var subject, from, to string
+	if m.Payload != nil {
+		for _, h := range m.Payload.Headers {
+			switch strings.ToLower(h.Name) {
+			case "subject":
+				subject = h.Value
+			case "from":
+				from = h.Value
+			case "to":
+				to = h.Value
+			}
+		}
+	}
+	// Collect label display names (normalize CATEGORY_* → friendly name)
+	labelNames := make([]string, 0, len(m.LabelIds))
+	for _, lid := range m.LabelIds {
+		name := idToName[lid]
+		if name == "" {
+			name = lid
+		}
+		up := strings.ToUpper(name)
+		if strings.HasPrefix(up, "CATEGORY_") {
+			name = strings.TrimPrefix(name, "CATEGORY_")
+		}
+		labelNames = append(labelNames, strings.ToLower(name))
+	}
+	labelsJoined := strings.Join(labelNames, " ")
+	content := strings.ToLower(subject + " " + from + " " + to + " " + m.Snippet + " " + labelsJoined)
+	// General text tokens
+	for _, t := range f.text {
+		if !strings.Contains(content, t) {
+			return false
+		}
+	}
+	// label: tokens (each must match at least one label name)
+	for _, lt := range f.labels {
+		found := false
+		for _, ln := range labelNames {
+			if strings.Contains(ln, lt) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// applyLocalFilter filters current in-memory messages based on a simple expression
+func (a *App) applyLocalFilter(expr string) {
+	// Compute matches off the UI thread
+	filter := parseLocalFilter(expr)
 	filteredIDs := make([]string, 0, len(a.ids))
 	filteredMeta := make([]*gmailapi.Message, 0, len(a.messagesMeta))
 	rows := make([]string, 0, len(a.messagesMeta))
@@ -1983,7 +2075,7 @@ func (a *App) applyLocalFilter(expr string) {
 	// Build label ID -> name map once (best-effort)
 	idToName := map[string]string{}
 	if a.Client != nil {
-		if labels, err := a.Client.ListLabels(); err == nil {
+		if labels, err := a.listLabels(); err == nil {
 			for _, l := range labels {
 				idToName[l.Id] = l.Name
 			}
@@ -1991,63 +2083,7 @@ func (a *App) applyLocalFilter(expr string) {
 	}
 
 	for i, m := range a.messagesMeta {
-		if m == nil {
-			continue
-		}
-		// Build a rich searchable string: Subject, From, To, Snippet
-		var subject, from, to string
-		if m.Payload != nil {
-			for _, h := range m.Payload.Headers {
-				switch strings.ToLower(h.Name) {
-				case "subject":
-					subject = h.Value
-				case "from":
-					from = h.Value
-				case "to":
-					to = h.Value
-				}
-			}
-		}
-		// Collect label display names (normalize CATEGORY_* → friendly name)
-		labelNames := make([]string, 0, len(m.LabelIds))
-		for _, lid := range m.LabelIds {
-			name := idToName[lid]
-			if name == "" {
-				name = lid
-			}
-			up := strings.ToUpper(name)
-			if strings.HasPrefix(up, "CATEGORY_") {
-				name = strings.TrimPrefix(name, "CATEGORY_")
-			}
-			labelNames = append(labelNames, strings.ToLower(name))
-		}
-		labelsJoined := strings.Join(labelNames, " ")
-		content := strings.ToLower(subject + " " + from + " " + to + " " + m.Snippet + " " + labelsJoined)
-		match := true
-		// General text tokens
-		for _, t := range textTokens {
-			if !strings.Contains(content, t) {
-				match = false
-				break
-			}
-		}
-		// label: tokens (each must match at least one label name)
-		if match && len(labelTokens) > 0 {
-			for _, lt := range labelTokens {
-				found := false
-				for _, ln := range labelNames {
-					if strings.Contains(ln, lt) {
-						found = true
-						break
-					}
-				}
-				if !found {
-					match = false
-					break
-				}
-			}
-		}
-		if !match {
+		if !filter.matches(m, idToName) {
 			continue
 		}
 		filteredIDs = append(filteredIDs, a.ids[i])
@@ -2144,6 +2180,7 @@ func (a *App) showMessage(id string) {
 	a.SetFocus(a.views["text"])
 	a.markFocus("text")
 	a.SetCurrentMessageID(id)
+	a.resetNestedViews()
 
 	a.Draw()
 
@@ -2160,11 +2197,12 @@ func (a *App) showMessage(id string) {
 			if a.debug {
 				a.logger.Printf("showMessage: cache hit id=%s", id)
 			}
+			a.GetErrorHandler().ShowDetail(a.ctx, "Message loaded from cache")
 			message = cached
 		} else {
-			m, err := a.Client.GetMessageWithContent(id)
+			m, err := a.messageClient(id).GetMessageWithContent(id)
 			if err != nil {
-				a.showError(fmt.Sprintf("❌ Error loading message: %v", err))
+				a.showErrorFor("Error loading message", err)
 				return
 			}
 			if a.debug {
@@ -2174,7 +2212,7 @@ func (a *App) showMessage(id string) {
 			message = m
 		}
 
-		rendered, isANSI := a.renderMessageContent(message)
+		rendered, isANSI := a.renderMessageForView(message)
 		// Detect calendar invite parts (best-effort)
 		if inv, ok := a.detectCalendarInvite(message.Message); ok {
 			a.caches.inviteSet(id, inv)
@@ -2237,7 +2275,7 @@ func (a *App) saveCurrentMessageToFile() {
 		if cached, ok := a.caches.messageGet(mid); ok {
 			m = cached
 		} else {
-			fetched, err := a.Client.GetMessageWithContent(mid)
+			fetched, err := a.messageClient(mid).GetMessageWithContent(mid)
 			if err != nil {
 				a.QueueUpdateDraw(func() { a.showError("❌ Could not load message") })
 				return
@@ -2291,16 +2329,9 @@ func (a *App) saveCurrentMessageRawEML() {
 			a.QueueUpdateDraw(func() { a.showError("❌ Gmail client not initialized") })
 			return
 		}
-		user := "me"
-		msg, err := a.Client.Service.Users.Messages.Get(user, mid).Format("raw").Do()
-		if err != nil || msg == nil || msg.Raw == "" {
-			a.QueueUpdateDraw(func() { a.showError("❌ Could not fetch raw message") })
-			return
-		}
-		// Decode base64url raw -> bytes
-		data, err := base64.URLEncoding.DecodeString(msg.Raw)
+		data, err := a.Client.GetMessageRaw(mid)
 		if err != nil {
-			a.QueueUpdateDraw(func() { a.showError("❌ Could not decode raw message") })
+			a.QueueUpdateDraw(func() { a.showError("❌ Could not fetch raw message") })
 			return
 		}
 		// Build filename
@@ -2365,6 +2396,7 @@ func (a *App) showMessageWithoutFocus(id string) {
 		a.enhancedTextView.SetContent("Loading message...")
 		text.ScrollToBeginning()
 	}
+	a.resetNestedViews()
 	// Do NOT set currentMessageID here; selection changes (and Enter) manage it.
 	// Setting it here could race with selection updates after deletions and cause stale content.
 
@@ -2391,12 +2423,13 @@ func (a *App) showMessageWithoutFocus(id string) {
 				if hasContent {
 					// Convert gmail_v1.Message to gmail.Message without additional API calls
 					message = a.Client.CreateMessageFromRaw(cachedMessage)
+					a.GetErrorHandler().ShowDetail(a.ctx, "Message served from the preload cache")
 				} else {
 					if a.debug {
 						a.logger.Printf("showMessageWithoutFocus: Preloader cache has metadata only, need full content")
 					}
 					// Preloader cache only has metadata, fetch full content
-					fullMessage, err := a.Client.GetMessageWithContent(id)
+					fullMessage, err := a.messageClient(id).GetMessageWithContent(id)
 					if err == nil {
 						message = fullMessage
 						// Store in regular cache for future use
@@ -2412,11 +2445,12 @@ func (a *App) showMessageWithoutFocus(id string) {
 				if a.debug {
 					a.logger.Printf("showMessageWithoutFocus: regular cache hit id=%s", id)
 				}
+				a.GetErrorHandler().ShowDetail(a.ctx, "Message loaded from cache")
 				message = cached
 			} else {
-				m, err := a.Client.GetMessageWithContent(id)
+				m, err := a.messageClient(id).GetMessageWithContent(id)
 				if err != nil {
-					a.showError(fmt.Sprintf("❌ Error loading message: %v", err))
+					a.showErrorFor("Error loading message", err)
 					return
 				}
 				if a.debug {
@@ -2430,7 +2464,7 @@ func (a *App) showMessageWithoutFocus(id string) {
 		// In preview (selection change), do not run LLM touch-up to avoid many calls
 		prev := a.llmTouchUpEnabled.Load()
 		a.llmTouchUpEnabled.Store(false)
-		rendered, isANSI := a.renderMessageContent(message)
+		rendered, isANSI := a.renderMessageForView(message)
 		a.llmTouchUpEnabled.Store(prev)
 
 		// Detect calendar invite (same as showMessage) and cache result
@@ -2473,6 +2507,11 @@ func (a *App) refreshMessageContent(id string) {
 		if a.debug {
 			a.logger.Printf("refreshMessageContent: id=%s", id)
 		}
+		// Keep showing the forwarded message opened with :nested
+		if v := a.currentNestedView(); v != nil && id == a.GetCurrentMessageID() {
+			a.showNestedView()
+			return
+		}
 		// Prefer cached message to avoid re-fetching on toggles
 		var m *gmail.Message
 		if cached, ok := a.GetMessageFromCache(id); ok {
@@ -2481,7 +2520,7 @@ func (a *App) refreshMessageContent(id string) {
 			}
 			m = cached
 		} else {
-			fetched, err := a.Client.GetMessageWithContent(id)
+			fetched, err := a.messageClient(id).GetMessageWithContent(id)
 			if err != nil {
 				return
 			}
@@ -2491,7 +2530,7 @@ func (a *App) refreshMessageContent(id string) {
 			a.SetMessageInCache(id, fetched)
 			m = fetched
 		}
-		rendered, isANSI := a.renderMessageContent(m)
+		rendered, isANSI := a.renderMessageForView(m)
 		a.QueueUpdateDraw(func() {
 			// Don't update content when in help mode to preserve help content
 			if !a.showHelp {
@@ -3098,7 +3137,7 @@ func (a *App) openRSVPModal() {
 
 	// Footer with instructions
 	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
-	footer.SetText(" Enter to respond | Esc to close ")
+	footer.SetText(" Enter to respond | b briefing | Esc to close ")
 	footer.SetTextColor(a.GetComponentColors("rsvp").Text.Color())
 	footer.SetBackgroundColor(a.GetComponentColors("rsvp").Background.Color())
 
@@ -3157,6 +3196,15 @@ func (a *App) openRSVPModal() {
 			a.restoreFocusAfterModal()
 			return nil
 		}
+		if e.Rune() == 'b' {
+			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+				split.ResizeItem(a.labelsView, 0, 0) // Hide RSVP panel
+			}
+			a.setActivePicker(PickerNone)
+			a.restoreFocusAfterModal()
+			a.generateBriefing(false, false)
+			return nil
+		}
 		return e
 	})
 
@@ -3166,7 +3214,7 @@ func (a *App) openRSVPModal() {
 			split.RemoveItem(a.labelsView)
 			a.labelsView = container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1) // Show RSVP panel
+			a.showSidePanel(split, a.labelsView, "rsvp") // Show RSVP panel
 		}
 		a.setActivePicker(PickerRSVP)
 		a.markFocus("labels")
@@ -3282,7 +3330,7 @@ func (a *App) reauthorizeCalendar() error {
 	if strings.TrimSpace(a.Config.Token) != "" {
 		tok = a.Config.Token
 	}
-	svc, err := auth.NewCalendarService(a.ctx, cred, tok, "https://www.googleapis.com/auth/calendar.events")
+	svc, err := auth.NewCalendarService(a.ctx, cred, tok, auth.ScopeCalendarEvents)
 	if err != nil {
 		return err
 	}
@@ -3536,7 +3584,7 @@ func (a *App) archiveSelected() {
 
 	message, err := a.Client.GetMessage(messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error getting message: %v", err))
+		a.showErrorFor("Error getting message", err)
 		return
 	}
 	subject := "Unknown subject"
@@ -3550,7 +3598,7 @@ func (a *App) archiveSelected() {
 	}
 
 	if err := a.Client.ArchiveMessage(messageID); err != nil {
-		a.showError(fmt.Sprintf("❌ Error archiving message: %v", err))
+		a.showErrorFor("Error archiving message", err)
 		return
 	}
 	go func() {
@@ -3664,6 +3712,9 @@ func (a *App) replySelected() {
 		}()
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	a.showCompositionWithStatusBar(services.CompositionTypeReply, messageID)
 }
@@ -3677,6 +3728,9 @@ func (a *App) replyAllSelected() {
 		}()
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	a.showCompositionWithStatusBar(services.CompositionTypeReplyAll, messageID)
 }
@@ -3690,6 +3744,9 @@ func (a *App) forwardSelected() {
 		}()
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	a.showCompositionWithStatusBar(services.CompositionTypeForward, messageID)
 }
@@ -3713,6 +3770,9 @@ func (a *App) toggleMarkReadUnread() {
 		a.showError("❌ Invalid message ID")
 		return
 	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 
 	// Determine unread state from cache if possible to avoid extra roundtrip
 	isUnread := false
@@ -3736,31 +3796,18 @@ func (a *App) toggleMarkReadUnread() {
 		}
 	}
 	go func(markUnread bool) {
-		// Get EmailService to ensure undo actions are recorded
-		emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
-
+		// Applied locally first; a server failure keeps the local state flagged with ⚠ (see :sync)
+		change := syncChange{MessageID: messageID, Kind: syncMarkRead}
+		verb := "read"
 		if markUnread {
-			if err := emailService.MarkAsUnread(a.ctx, messageID); err != nil {
-				a.showError(fmt.Sprintf("❌ Error marking as unread: %v", err))
-				return
-			}
-			a.showStatusMessage("✅ Message marked as unread")
-			// Update caches/UI on main thread
-			a.QueueUpdateDraw(func() {
-				a.updateCachedMessageLabels(messageID, "UNREAD", true)
-				a.refreshTableDisplay()
-			})
-		} else {
-			if err := emailService.MarkAsRead(a.ctx, messageID); err != nil {
-				a.showError(fmt.Sprintf("❌ Error marking as read: %v", err))
-				return
-			}
-			a.showStatusMessage("✅ Message marked as read")
-			a.QueueUpdateDraw(func() {
-				a.updateCachedMessageLabels(messageID, "UNREAD", false)
-				a.refreshTableDisplay()
-			})
+			change.Kind = syncMarkUnread
+			verb = "unread"
+		}
+		if err := a.runSyncChange(change); err != nil {
+			a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Couldn't mark as %s in Gmail — kept locally (:sync to retry or discard)", verb))
+			return
 		}
+		a.showStatusMessage(fmt.Sprintf("✅ Message marked as %s", verb))
 	}(!isUnread)
 }
 
@@ -4132,7 +4179,7 @@ func (a *App) showDraftsPicker(container *tview.Flex) {
 		// Replace a.labelsView with our enhanced container (same pattern as other pickers)
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true) // true = focusable for tab navigation
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "drafts")
 
 		// Update state
 		a.setActivePicker(PickerDrafts) // Set specific drafts picker state
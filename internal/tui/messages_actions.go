@@ -24,9 +24,12 @@ func (a *App) archiveSelected() {
 		return
 	}
 
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 	message, err := a.Client.GetMessage(messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error getting message: %v", err))
+		a.showErrorFor("Error getting message", err)
 		return
 	}
 	subject := "Unknown subject"
@@ -62,9 +65,12 @@ func (a *App) trashSelectedByID(messageID string) {
 	}
 
 	// Get the current message to show confirmation
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 	message, err := a.Client.GetMessage(messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error getting message: %v", err))
+		a.showErrorFor("Error getting message", err)
 		return
 	}
 
@@ -83,7 +89,7 @@ func (a *App) trashSelectedByID(messageID string) {
 	emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
 	err = emailService.TrashMessage(a.ctx, messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error moving to trash: %v", err))
+		a.showErrorFor("Error moving to trash", err)
 		return
 	}
 
@@ -112,9 +118,12 @@ func (a *App) trashSelected() {
 	}
 
 	// Get the current message to show confirmation
+	if a.blockForeignMessages(messageID) {
+		return
+	}
 	message, err := a.Client.GetMessage(messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error getting message: %v", err))
+		a.showErrorFor("Error getting message", err)
 		return
 	}
 
@@ -133,7 +142,7 @@ func (a *App) trashSelected() {
 	emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
 	err = emailService.TrashMessage(a.ctx, messageID)
 	if err != nil {
-		a.showError(fmt.Sprintf("❌ Error moving to trash: %v", err))
+		a.showErrorFor("Error moving to trash", err)
 		return
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tview"
 )
 
@@ -15,11 +16,21 @@ func (a *App) archiveSelectedBulk() {
 	// Snapshot selection
 	ids := make([]string, 0, a.bulk.count())
 	ids = append(ids, a.bulk.ids()...)
+	if a.blockForeignMessages(ids...) {
+		return
+	}
+	a.withQuotaPlan(services.QuotaOpArchive, ids, "Archiving", a.runArchiveBulk)
+}
+
+// runArchiveBulk archives ids following the quota plan, then updates the list and selection
+func (a *App) runArchiveBulk(ids []string, plan services.QuotaPlan) {
 	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Archiving %d message(s)…", len(ids)))
 	go func() {
 		// Use bulk service method for proper undo recording
 		emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
-		err := emailService.BulkArchive(a.ctx, ids, a.bulkProgress(a.ctx, "Archiving"))
+		err := a.runQuotaPlanned(plan, ids, "Archiving", func(batch []string, progress func(done, total int)) error {
+			return emailService.BulkArchive(a.ctx, batch, progress)
+		})
 		a.GetErrorHandler().ClearPersistentMessage()
 
 		failed := 0
@@ -29,9 +40,8 @@ func (a *App) archiveSelectedBulk() {
 		}
 		a.QueueUpdateDraw(func() {
 			a.removeIDsFromCurrentList(ids)
-			// Exit bulk mode and restore normal rendering/styles
-			a.bulk.clear()
-			a.bulk.setMode(false)
+			// Exit bulk mode (unless a shrunk job left messages selected) and restore rendering/styles
+			a.finishBulkJob(ids)
 			a.refreshTableDisplay()
 			if list, ok := a.views["list"].(*tview.Table); ok {
 				list.SetSelectedStyle(a.getSelectionStyle())
@@ -63,11 +73,21 @@ func (a *App) trashSelectedBulk() {
 	}
 	ids := make([]string, 0, a.bulk.count())
 	ids = append(ids, a.bulk.ids()...)
+	if a.blockForeignMessages(ids...) {
+		return
+	}
+	a.withQuotaPlan(services.QuotaOpTrash, ids, "Trashing", a.runTrashBulk)
+}
+
+// runTrashBulk trashes ids following the quota plan, then updates the list and selection
+func (a *App) runTrashBulk(ids []string, plan services.QuotaPlan) {
 	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Trashing %d message(s)…", len(ids)))
 	go func() {
 		// Use bulk service method for proper undo recording
 		emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
-		err := emailService.BulkTrash(a.ctx, ids, a.bulkProgress(a.ctx, "Trashing"))
+		err := a.runQuotaPlanned(plan, ids, "Trashing", func(batch []string, progress func(done, total int)) error {
+			return emailService.BulkTrash(a.ctx, batch, progress)
+		})
 		a.GetErrorHandler().ClearPersistentMessage()
 
 		failed := 0
@@ -77,9 +97,8 @@ func (a *App) trashSelectedBulk() {
 		}
 		a.QueueUpdateDraw(func() {
 			a.removeIDsFromCurrentList(ids)
-			// Exit bulk mode and restore normal rendering/styles
-			a.bulk.clear()
-			a.bulk.setMode(false)
+			// Exit bulk mode (unless a shrunk job left messages selected) and restore rendering/styles
+			a.finishBulkJob(ids)
 			a.refreshTableDisplay()
 			if list, ok := a.views["list"].(*tview.Table); ok {
 				list.SetSelectedStyle(a.getSelectionStyle())
@@ -113,6 +132,9 @@ func (a *App) toggleMarkReadUnreadBulk() {
 	// Snapshot selection
 	ids := make([]string, 0, a.bulk.count())
 	ids = append(ids, a.bulk.ids()...)
+	if a.blockForeignMessages(ids...) {
+		return
+	}
 
 	// Determine the action by checking the majority state of selected messages
 	// If majority are unread, mark all as read. If majority are read, mark all as unread.
@@ -135,23 +157,35 @@ func (a *App) toggleMarkReadUnreadBulk() {
 
 	// Decide action: if majority are unread, mark all as read; otherwise mark all as unread
 	markAsUnread := unreadCount <= len(ids)/2
+	verb := "Marking read"
+	if markAsUnread {
+		verb = "Marking unread"
+	}
+	a.withQuotaPlan(services.QuotaOpMarkRead, ids, verb, func(ids []string, plan services.QuotaPlan) {
+		a.runMarkReadUnreadBulk(ids, plan, markAsUnread)
+	})
+}
+
+// runMarkReadUnreadBulk marks ids read or unread following the quota plan
+func (a *App) runMarkReadUnreadBulk(ids []string, plan services.QuotaPlan, markAsUnread bool) {
 	action := "read"
+	verb := "Marking read"
 	if markAsUnread {
 		action = "unread"
+		verb = "Marking unread"
 	}
-
 	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Marking %d message(s) as %s…", len(ids), action))
 
 	go func() {
 		// Get EmailService to ensure undo actions are recorded
 		emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
 
-		var err error
-		if markAsUnread {
-			err = emailService.BulkMarkAsUnread(a.ctx, ids, a.bulkProgress(a.ctx, "Marking unread"))
-		} else {
-			err = emailService.BulkMarkAsRead(a.ctx, ids, a.bulkProgress(a.ctx, "Marking read"))
-		}
+		err := a.runQuotaPlanned(plan, ids, verb, func(batch []string, progress func(done, total int)) error {
+			if markAsUnread {
+				return emailService.BulkMarkAsUnread(a.ctx, batch, progress)
+			}
+			return emailService.BulkMarkAsRead(a.ctx, batch, progress)
+		})
 		a.GetErrorHandler().ClearPersistentMessage()
 
 		failed := 0
@@ -164,9 +198,8 @@ func (a *App) toggleMarkReadUnreadBulk() {
 			// NOTE: Removed manual cache updates - let undo system handle cache updates to avoid conflicts
 			// The bulk service methods record proper undo actions, and undo will handle cache updates
 
-			// Exit bulk mode and restore normal rendering/styles
-			a.bulk.clear()
-			a.bulk.setMode(false)
+			// Exit bulk mode (unless a shrunk job left messages selected) and restore rendering/styles
+			a.finishBulkJob(ids)
 			a.refreshTableDisplay()
 			if list, ok := a.views["list"].(*tview.Table); ok {
 				list.SetSelectedStyle(a.getSelectionStyle())
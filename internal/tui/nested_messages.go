@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/derailed/tview"
+)
+
+// nestedView is an embedded message (a message/rfc822 part) shown in the content pane
+type nestedView struct {
+	part *gmail.MIMEPart
+	msg  *gmail.Message
+}
+
+// nestedStack is the chain of embedded messages opened from a message, outermost first. It
+// belongs to parentID: selecting another message drops it.
+type nestedStack struct {
+	mu            sync.Mutex
+	parentID      string
+	parentSubject string
+	root          *gmail.MIMEPart // parsed raw source of the parent message
+	views         []nestedView
+}
+
+// currentNestedView returns the embedded message shown for the current message, if any
+func (a *App) currentNestedView() *nestedView {
+	a.nested.mu.Lock()
+	defer a.nested.mu.Unlock()
+	if len(a.nested.views) == 0 || a.nested.parentID != a.GetCurrentMessageID() {
+		return nil
+	}
+	v := a.nested.views[len(a.nested.views)-1]
+	return &v
+}
+
+// resetNestedViews forgets the opened embedded messages, e.g. when another message is shown
+func (a *App) resetNestedViews() {
+	a.nested.mu.Lock()
+	a.nested.parentID, a.nested.parentSubject, a.nested.root, a.nested.views = "", "", nil, nil
+	a.nested.mu.Unlock()
+}
+
+// executeNestedCommand handles :nested [n|back] — open the n-th message forwarded as an attachment
+// in the message shown (the first by default), or go back to the message that contains it
+func (a *App) executeNestedCommand(args []string) {
+	id := a.GetCurrentMessageID()
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	if len(args) > 0 && (strings.EqualFold(args[0], "back") || strings.EqualFold(args[0], "up")) {
+		a.closeNestedView()
+		return
+	}
+	index := 0
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || len(args) > 1 {
+			a.showError("Usage: nested [n|back]")
+			return
+		}
+		index = n - 1
+	}
+	go func() {
+		container, err := a.nestedContainer(id)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error reading the message structure", err)
+			return
+		}
+		embedded := container.EmbeddedMessages()
+		switch {
+		case len(embedded) == 0:
+			a.GetErrorHandler().ShowInfo(a.ctx, "No forwarded messages attached to this one")
+			return
+		case index >= len(embedded):
+			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Only %d forwarded message(s) attached", len(embedded)))
+			return
+		case len(args) == 0 && len(embedded) > 1:
+			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("%d forwarded messages attached (opening the first): %s — :nested <n> opens another", len(embedded), listEmbeddedSubjects(embedded)))
+		}
+		part := embedded[index]
+		msg, err := part.EmbeddedMessage(id + "#" + part.Path)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error reading the forwarded message", err)
+			return
+		}
+		a.nested.mu.Lock()
+		if a.nested.parentID != id {
+			a.nested.mu.Unlock()
+			return
+		}
+		a.nested.views = append(a.nested.views, nestedView{part: part, msg: msg})
+		a.nested.mu.Unlock()
+		a.showNestedView()
+	}()
+}
+
+// nestedContainer returns the part whose embedded messages :nested opens: the one shown, or the
+// parent message, whose raw source is fetched and parsed the first time
+func (a *App) nestedContainer(id string) (*gmail.MIMEPart, error) {
+	if v := a.currentNestedView(); v != nil {
+		return v.part.Parts[0], nil
+	}
+	a.nested.mu.Lock()
+	if a.nested.parentID == id && a.nested.root != nil {
+		root := a.nested.root
+		a.nested.mu.Unlock()
+		return root, nil
+	}
+	a.nested.mu.Unlock()
+
+	raw, err := a.messageClient(id).GetMessageRaw(id)
+	if err != nil {
+		return nil, err
+	}
+	root, err := gmail.ParseMIMETree(raw)
+	if err != nil {
+		return nil, err
+	}
+	subject := decodedHeader(root, "Subject")
+	if m, ok := a.GetMessageFromCache(id); ok {
+		subject = m.Subject
+	}
+	a.nested.mu.Lock()
+	a.nested.parentID, a.nested.parentSubject, a.nested.root, a.nested.views = id, subject, root, nil
+	a.nested.mu.Unlock()
+	return root, nil
+}
+
+// closeNestedView goes back one level: to the enclosing embedded message or to the parent message
+func (a *App) closeNestedView() {
+	a.nested.mu.Lock()
+	if len(a.nested.views) == 0 || a.nested.parentID != a.GetCurrentMessageID() {
+		a.nested.mu.Unlock()
+		a.showError("Not viewing a forwarded message")
+		return
+	}
+	a.nested.views = a.nested.views[:len(a.nested.views)-1]
+	parentID, back := a.nested.parentID, len(a.nested.views) == 0
+	a.nested.mu.Unlock()
+	if back {
+		a.setMessageContentTitle(" 📄 Message Content ")
+		a.refreshMessageContent(parentID)
+		return
+	}
+	go a.showNestedView()
+}
+
+// showNestedView renders the innermost opened embedded message below its breadcrumbs
+func (a *App) showNestedView() {
+	v := a.currentNestedView()
+	if v == nil {
+		return
+	}
+	rendered, isANSI := a.renderMessageForView(v.msg)
+	crumbs := a.nestedBreadcrumbs()
+	a.QueueUpdateDraw(func() {
+		a.setMessageContentTitle(" ✉️ Forwarded message ")
+		text, ok := a.views["text"].(*tview.TextView)
+		if !ok || a.showHelp {
+			return
+		}
+		text.SetDynamicColors(true)
+		text.Clear()
+		if isANSI {
+			_, _ = fmt.Fprint(tview.ANSIWriter(text, "", ""), crumbs+rendered)
+		} else {
+			a.enhancedTextView.SetContent(tview.Escape(crumbs) + rendered)
+		}
+		text.ScrollToBeginning()
+	})
+}
+
+// nestedBreadcrumbs is the line above an embedded message showing where it sits
+func (a *App) nestedBreadcrumbs() string {
+	a.nested.mu.Lock()
+	defer a.nested.mu.Unlock()
+	titles := []string{a.nested.parentSubject}
+	for _, v := range a.nested.views {
+		titles = append(titles, v.msg.Subject)
+	}
+	return formatNestedBreadcrumbs(titles)
+}
+
+// formatNestedBreadcrumbs joins the subjects from the parent message to the one shown
+func formatNestedBreadcrumbs(subjects []string) string {
+	crumbs := make([]string, len(subjects))
+	for i, s := range subjects {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			s = "(no subject)"
+		}
+		if len([]rune(s)) > 40 {
+			s = string([]rune(s)[:39]) + "…"
+		}
+		icon := "✉️"
+		if i == 0 {
+			icon = "📨"
+		}
+		crumbs[i] = icon + " " + s
+	}
+	return strings.Join(crumbs, " › ") + "\n(:nested back returns to the enclosing message)\n" + strings.Repeat("─", 40) + "\n\n"
+}
+
+// listEmbeddedSubjects numbers the subjects of embedded messages for a status line
+func listEmbeddedSubjects(parts []*gmail.MIMEPart) string {
+	names := make([]string, len(parts))
+	for i, p := range parts {
+		subject := ""
+		if len(p.Parts) > 0 {
+			subject = decodedHeader(p.Parts[0], "Subject")
+		}
+		if subject == "" {
+			subject = p.SuggestedFilename()
+		}
+		names[i] = fmt.Sprintf("%d %s", i+1, subject)
+	}
+	return strings.Join(names, ", ")
+}
+
+// decodedHeader returns a header of a part with its MIME encoded-words decoded
+func decodedHeader(p *gmail.MIMEPart, name string) string {
+	v := p.Header.Get(name)
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(v); err == nil {
+		return decoded
+	}
+	return v
+}
+
+// openNestedAttachments lists the attachments of the embedded message shown, to save them
+func (a *App) openNestedAttachments(v *nestedView) {
+	var parts []*gmail.MIMEPart
+	for _, p := range v.part.Parts[0].Flatten() {
+		if p.IsAttachment() {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		a.GetErrorHandler().ShowInfo(a.ctx, "No attachments found in this message")
+		return
+	}
+	a.openMIMEPartPicker(" 📎 Forwarded message attachments ", parts)
+}
+
+// setMessageContentTitle sets the title of the content pane (unless help is shown)
+func (a *App) setMessageContentTitle(title string) {
+	if a.showHelp {
+		return
+	}
+	if textContainer, ok := a.views["textContainer"].(*tview.Flex); ok {
+		textContainer.SetTitle(title)
+		textContainer.SetTitleColor(a.GetComponentColors("general").Title.Color())
+	}
+}
@@ -0,0 +1,18 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatNestedBreadcrumbs(t *testing.T) {
+	got := formatNestedBreadcrumbs([]string{"Fwd: quarterly numbers", "", strings.Repeat("x", 50)})
+	first := strings.SplitN(got, "\n", 2)[0]
+	want := "📨 Fwd: quarterly numbers › ✉️ (no subject) › ✉️ " + strings.Repeat("x", 39) + "…"
+	if first != want {
+		t.Fatalf("breadcrumbs = %q, want %q", first, want)
+	}
+	if !strings.Contains(got, ":nested back") {
+		t.Fatalf("breadcrumbs should say how to go back: %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/ajramos/giztui/internal/gmail"
 	"github.com/ajramos/giztui/internal/obsidian"
@@ -50,7 +51,7 @@ func (a *App) sendEmailToObsidian() {
 
 	// Load message content in background
 	go func() {
-		message, err := a.Client.GetMessageWithContent(messageID)
+		message, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 		if err != nil {
 			a.GetErrorHandler().ShowError(a.ctx, "Failed to load message content")
 			return
@@ -131,7 +132,7 @@ func (a *App) openObsidianIngestPanel(message *gmail.Message) {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "obsidian")
 
 		if a.logger != nil {
 			a.logger.Printf("Obsidian panel added to contentSplit successfully")
@@ -310,7 +311,7 @@ func (a *App) sendSelectedBulkToObsidianWithComment(comment string) {
 		// Process each message individually with progress updates (following bulk pattern)
 		for i, id := range ids {
 			// Load message content
-			message, err := a.Client.GetMessageWithContent(id)
+			message, err := a.messageClient(id).GetMessageWithContent(id)
 			if err != nil {
 				failed++
 				continue
@@ -516,7 +517,7 @@ func (a *App) openBulkObsidianPanel() {
 		if a.logger != nil {
 			a.logger.Printf("Resizing container in split...")
 		}
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "obsidian")
 
 		if a.logger != nil {
 			a.logger.Printf("Bulk Obsidian panel added to contentSplit successfully")
@@ -733,7 +734,7 @@ func (a *App) sendSelectedBulkToObsidianAsRepopack(accountEmail, comment string)
 		failedCount := 0
 
 		for _, id := range ids {
-			message, err := a.Client.GetMessageWithContent(id)
+			message, err := a.messageClient(id).GetMessageWithContent(id)
 			if err != nil {
 				failedCount++
 				continue
@@ -801,6 +802,9 @@ func (a *App) sendSelectedBulkToObsidianAsRepopack(accountEmail, comment string)
 		// Clear progress and show success
 		a.GetErrorHandler().ClearProgress()
 		successMsg := fmt.Sprintf("📦 Repopack created with %d messages!", actualCount)
+		if parts, ok := result.Metadata["chunk_count"].(int); ok && parts > 1 {
+			successMsg = fmt.Sprintf("📦 Repopack created with %d messages in %d parts (see %s)", actualCount, parts, filepath.Base(result.FilePath))
+		}
 		if comment != "" {
 			successMsg += " (with your comment)"
 		}
@@ -913,7 +917,7 @@ func (a *App) openBulkObsidianPanelWithRepack() {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "obsidian")
 
 		if a.logger != nil {
 			a.logger.Printf("Repack Obsidian panel added to contentSplit successfully")
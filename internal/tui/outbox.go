@@ -0,0 +1,353 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// outboxRetryInterval is how often queued messages are retried while offline
+const outboxRetryInterval = 30 * time.Second
+
+// isOfflineError reports whether a send failed because Gmail could not be reached
+func isOfflineError(err error) bool {
+	return err != nil && errors.Is(services.ClassifyError("", err), services.ErrNetworkUnavailable)
+}
+
+// queueOffline stores a composition in the outbox and starts retrying it in the background
+func (a *App) queueOffline(c *services.Composition, cause error) error {
+	if a.outboxService == nil {
+		return fmt.Errorf("outbox not available (no local database)")
+	}
+	if _, err := a.outboxService.Enqueue(a.ctx, c, cause); err != nil {
+		return err
+	}
+	a.outboxService.SetOffline(true)
+	a.startOutboxWatcher()
+	a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+	return nil
+}
+
+// outboxIndicator is the status-bar segment for the outbox: an offline banner with the queue size,
+// or the number of queued/failed messages once back online
+func (a *App) outboxIndicator() string {
+	if a == nil || a.outboxService == nil {
+		return ""
+	}
+	pending, failed := a.outboxService.Counts()
+	var parts []string
+	if a.outboxService.IsOffline() {
+		parts = append(parts, "📴 Offline")
+	}
+	if pending > 0 {
+		parts = append(parts, fmt.Sprintf("📤%d queued", pending))
+	}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("⚠%d unsent", failed))
+	}
+	return strings.Join(parts, " ")
+}
+
+// startOutboxWatcher retries the outbox every outboxRetryInterval until it is empty. Idempotent.
+func (a *App) startOutboxWatcher() {
+	if a.outboxService == nil || !a.outboxWatching.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer a.outboxWatching.Store(false)
+		ticker := time.NewTicker(outboxRetryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+				a.flushOutbox()
+				if pending, _ := a.outboxService.Counts(); pending == 0 {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// flushOutbox sends the queued messages and reports the outcome. The first successful send after
+// an offline period also lets the flag-change sync catch up.
+func (a *App) flushOutbox() {
+	if a.outboxService == nil {
+		return
+	}
+	wasOffline := a.outboxService.IsOffline()
+	res, err := a.outboxService.Flush(a.ctx)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("outbox: flush failed: %v", err)
+		}
+		return
+	}
+	if a.logger != nil && (res.Sent > 0 || res.Failed > 0) {
+		a.logger.Printf("outbox: sent=%d failed=%d remaining=%d offline=%v", res.Sent, res.Failed, res.Remaining, res.Offline)
+	}
+	switch {
+	case res.Failed > 0:
+		a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("📤 Sent %d queued message(s); %d rejected by Gmail — see :outbox", res.Sent, res.Failed))
+	case res.Sent > 0:
+		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("📤 Back online — sent %d queued message(s)", res.Sent))
+	}
+	if wasOffline && !a.outboxService.IsOffline() {
+		go a.resyncAfterReconnect()
+	}
+	a.QueueUpdateDraw(func() {
+		a.refreshStatusBar()
+		if a.outboxReload != nil {
+			a.outboxReload()
+		}
+	})
+}
+
+// resyncAfterReconnect reconciles and resends the flag changes that failed while offline
+func (a *App) resyncAfterReconnect() {
+	a.reconcileSyncChanges()
+	for _, c := range a.syncState.list() {
+		if c.Status == syncFailed && !c.Conflict {
+			_ = a.retrySyncChange(c)
+		}
+	}
+}
+
+// formatOutboxItem renders an outbox panel row: status marker, subject and recipient; the
+// secondary line carries the reconciliation status
+func formatOutboxItem(it *services.OutboxItem) (string, string) {
+	c := it.Composition
+	subject := strings.TrimSpace(c.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	to := ""
+	if len(c.To) > 0 {
+		to = c.To[0].Email
+		if len(c.To) > 1 {
+			to += fmt.Sprintf(" +%d", len(c.To)-1)
+		}
+	}
+	marker := "📤"
+	var secondary string
+	switch it.Status {
+	case services.OutboxSending:
+		marker = "↻"
+		secondary = "sending…"
+	case services.OutboxSent:
+		marker = "✅"
+		secondary = "sent " + it.UpdatedAt.Format("Jan 2 15:04")
+	case services.OutboxFailed:
+		marker = "⚠"
+		secondary = fmt.Sprintf("failed (%d attempt(s)): %s", it.Attempts, it.LastError)
+	default:
+		secondary = "queued " + it.QueuedAt.Format("Jan 2 15:04") + " · waiting for connectivity"
+		if it.Attempts > 0 {
+			secondary += fmt.Sprintf(" · %d attempt(s)", it.Attempts)
+		}
+	}
+	primary := fmt.Sprintf("%s %s", marker, subject)
+	if to != "" {
+		primary += " → " + to
+	}
+	return primary, secondary
+}
+
+// executeOutboxCommand handles :outbox [send|clear] — open the outbox panel, send everything
+// queued now, or clear the delivered messages
+func (a *App) executeOutboxCommand(args []string) {
+	if a.outboxService == nil {
+		a.showError("Outbox not available (no local database)")
+		return
+	}
+	if len(args) == 0 {
+		a.openOutboxPanel()
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "send":
+		go func() {
+			if pending, _ := a.outboxService.Counts(); pending == 0 {
+				a.GetErrorHandler().ShowInfo(a.ctx, "Outbox is empty")
+				return
+			}
+			a.GetErrorHandler().ShowProgress(a.ctx, "📤 Sending queued messages…")
+			a.flushOutbox()
+			a.GetErrorHandler().ClearProgress()
+			if a.outboxService.IsOffline() {
+				a.GetErrorHandler().ShowWarning(a.ctx, "📴 Still offline — messages stay queued")
+			}
+		}()
+	case "clear":
+		go func() {
+			n, err := a.outboxService.ClearSent(a.ctx)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error clearing outbox", err)
+				return
+			}
+			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Cleared %d sent message(s)", n))
+		}()
+	default:
+		a.showError("Usage: outbox [send|clear]")
+	}
+}
+
+// openOutboxPanel shows the outbox in the side panel: Enter/r sends now, d discards, c clears sent
+func (a *App) openOutboxPanel() {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+	var items []*services.OutboxItem
+	render := func(loaded []*services.OutboxItem) {
+		items = loaded
+		cur := list.GetCurrentItem()
+		list.Clear()
+		if len(items) == 0 {
+			list.AddItem("✅ Outbox is empty", "", 0, nil)
+			return
+		}
+		for _, it := range items {
+			primary, secondary := formatOutboxItem(it)
+			list.AddItem(tview.Escape(primary), tview.Escape(secondary), 0, nil)
+		}
+		if cur >= 0 && cur < list.GetItemCount() {
+			list.SetCurrentItem(cur)
+		}
+	}
+	reload := func() {
+		go func() {
+			loaded, err := a.outboxService.List(a.ctx)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading outbox", err)
+				return
+			}
+			a.QueueUpdateDraw(func() { render(loaded) })
+		}()
+	}
+	sendNow := func(it *services.OutboxItem) {
+		go func() {
+			a.GetErrorHandler().ShowProgress(a.ctx, "📤 Sending…")
+			err := a.outboxService.SendNow(a.ctx, it.ID)
+			a.GetErrorHandler().ClearProgress()
+			switch {
+			case isOfflineError(err):
+				a.GetErrorHandler().ShowWarning(a.ctx, "📴 Still offline — message stays queued")
+				a.startOutboxWatcher()
+			case err != nil:
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error sending queued message", err)
+			default:
+				a.GetErrorHandler().ShowSuccess(a.ctx, "Queued message sent")
+			}
+			a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+			reload()
+		}()
+	}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i >= 0 && i < len(items) {
+			sendNow(items[i])
+		}
+	})
+
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyEscape {
+			a.closeOutboxPanel()
+			return nil
+		}
+		switch e.Rune() {
+		case 'c':
+			go func() {
+				if _, err := a.outboxService.ClearSent(a.ctx); err != nil {
+					a.GetErrorHandler().ShowErrorFor(a.ctx, "Error clearing outbox", err)
+				}
+				reload()
+			}()
+			return nil
+		}
+		idx := list.GetCurrentItem()
+		if idx < 0 || idx >= len(items) {
+			return e
+		}
+		switch e.Rune() {
+		case 'r':
+			sendNow(items[idx])
+			return nil
+		case 'd':
+			it := items[idx]
+			go func() {
+				if err := a.outboxService.Discard(a.ctx, it.ID); err != nil {
+					a.GetErrorHandler().ShowErrorFor(a.ctx, "Error discarding message", err)
+				}
+				a.QueueUpdateDraw(func() { a.refreshStatusBar() })
+				reload()
+			}()
+			return nil
+		}
+		return e
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(" 📤 Outbox ")
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(list, 0, 1, true)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter/r to send now | d to discard | c to clear sent | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "outbox")
+	}
+	a.outboxReload = reload
+	a.markFocus("labels")
+	a.setActivePicker(PickerOutbox)
+	a.SetFocus(list)
+	reload()
+}
+
+// closeOutboxPanel closes the outbox panel and restores focus
+func (a *App) closeOutboxPanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.outboxReload = nil
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
+
+// bindOutbox (re)creates the outbox for the active account and resumes retrying anything left
+// queued from a previous session
+func (a *App) bindOutbox() {
+	svc := services.NewOutboxService(db.NewOutboxStore(a.dbStore), a.compositionService)
+	if email := a.getActiveAccountEmail(); email != "" {
+		svc.SetAccountEmail(email)
+	}
+	a.outboxService = svc
+	if pending, _ := svc.Counts(); pending > 0 {
+		a.startOutboxWatcher()
+	}
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestFormatOutboxItem_ShowsReconciliationStatus(t *testing.T) {
+	queued := time.Date(2025, 3, 14, 9, 30, 0, 0, time.Local)
+	it := &services.OutboxItem{
+		Composition: &services.Composition{Subject: "Quarterly plan", To: []services.Recipient{{Email: "ana@example.com"}, {Email: "pepe@example.org"}}},
+		Status:      services.OutboxQueued,
+		Attempts:    2,
+		QueuedAt:    queued,
+		UpdatedAt:   queued,
+	}
+	primary, secondary := formatOutboxItem(it)
+	if primary != "📤 Quarterly plan → ana@example.com +1" {
+		t.Fatalf("primary = %q", primary)
+	}
+	if secondary != "queued Mar 14 09:30 · waiting for connectivity · 2 attempt(s)" {
+		t.Fatalf("secondary = %q", secondary)
+	}
+
+	it.Status, it.LastError = services.OutboxFailed, "invalid To header"
+	primary, secondary = formatOutboxItem(it)
+	if !strings.HasPrefix(primary, "⚠ ") || secondary != "failed (2 attempt(s)): invalid To header" {
+		t.Fatalf("failed row = %q / %q", primary, secondary)
+	}
+
+	it.Status = services.OutboxSent
+	if primary, _ = formatOutboxItem(it); !strings.HasPrefix(primary, "✅ ") {
+		t.Fatalf("sent row = %q", primary)
+	}
+}
+
+func TestIsOfflineError(t *testing.T) {
+	if !isOfflineError(&services.NetworkError{Op: "send message", Err: errors.New("connection reset")}) {
+		t.Fatal("network errors should queue the message")
+	}
+	if isOfflineError(errors.New("invalid To header")) || isOfflineError(nil) {
+		t.Fatal("only network errors mean offline")
+	}
+}
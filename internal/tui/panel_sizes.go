@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// Side panel sizes, in percent of the content area (layout.panel_sizes)
+const (
+	defaultPanelPercent = 50
+	minPanelPercent     = 20
+	maxPanelPercent     = 80
+	panelResizeStep     = 5
+)
+
+// clampPanelPercent keeps a panel size within the bounds that leave both panes usable
+func clampPanelPercent(p int) int {
+	return max(minPanelPercent, min(maxPanelPercent, p))
+}
+
+// panelPercent returns the remembered size of a side panel
+func (a *App) panelPercent(kind string) int {
+	if a.Config == nil {
+		return defaultPanelPercent
+	}
+	p, ok := a.Config.Layout.PanelSizes[kind]
+	if !ok || p <= 0 {
+		return defaultPanelPercent
+	}
+	return clampPanelPercent(p)
+}
+
+// showSidePanel opens view beside the message content at the size remembered for its kind of
+// panel. Must run on the UI goroutine.
+func (a *App) showSidePanel(split *tview.Flex, view tview.Primitive, kind string) {
+	if a.sidePanelKinds == nil {
+		a.sidePanelKinds = make(map[tview.Primitive]string)
+	}
+	a.sidePanelKinds[view] = kind
+	a.applySidePanelSize(split, view, a.panelPercent(kind))
+}
+
+// applySidePanelSize gives view p percent of the split and the message content the rest
+func (a *App) applySidePanelSize(split *tview.Flex, view tview.Primitive, p int) {
+	if tc, ok := a.views["textContainer"]; ok && tc != nil {
+		split.ResizeItem(tc, 0, 100-p)
+	}
+	split.ResizeItem(view, 0, p)
+}
+
+// visibleSidePanel returns the open side panel and its kind: the focused one when several are
+// open. Hidden panels have no width since the last draw.
+func (a *App) visibleSidePanel() (tview.Primitive, string) {
+	var open tview.Primitive
+	for _, view := range []tview.Primitive{a.labelsView, a.aiSummaryView, a.slackView} {
+		if view == nil || a.sidePanelKinds[view] == "" {
+			continue
+		}
+		if _, _, w, _ := view.GetRect(); w == 0 {
+			continue
+		}
+		if view.HasFocus() {
+			return view, a.sidePanelKinds[view]
+		}
+		if open == nil {
+			open = view
+		}
+	}
+	if open == nil {
+		return nil, ""
+	}
+	return open, a.sidePanelKinds[open]
+}
+
+// handlePanelResizeKey widens or narrows the open side panel on the panel_wider/panel_narrower
+// keys
+func (a *App) handlePanelResizeKey(event *tcell.EventKey) bool {
+	step := 0
+	switch {
+	case a.Keys.PanelWider != "" && a.matchesKeyCombo(event, a.Keys.PanelWider):
+		step = panelResizeStep
+	case a.Keys.PanelNarrower != "" && a.matchesKeyCombo(event, a.Keys.PanelNarrower):
+		step = -panelResizeStep
+	default:
+		return false
+	}
+	return a.resizeSidePanel(func(p int) int { return p + step })
+}
+
+// resizeSidePanel sets the open side panel to size(current percent) and remembers it for that
+// kind of panel in layout.panel_sizes; false when no side panel is open. Must run on the UI
+// goroutine.
+func (a *App) resizeSidePanel(size func(p int) int) bool {
+	split, ok := a.views["contentSplit"].(*tview.Flex)
+	if !ok {
+		return false
+	}
+	view, kind := a.visibleSidePanel()
+	if view == nil {
+		return false
+	}
+	p := clampPanelPercent(size(a.panelPercent(kind)))
+	a.applySidePanelSize(split, view, p)
+	if a.Config == nil {
+		return true
+	}
+	if a.Config.Layout.PanelSizes == nil {
+		a.Config.Layout.PanelSizes = make(map[string]int)
+	}
+	a.Config.Layout.PanelSizes[kind] = p
+	go func() {
+		if err := a.saveConfigAsync(); err != nil && a.logger != nil {
+			a.logger.Printf("Failed to save panel size: %v", err)
+		}
+	}()
+	go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("↔ %s panel: %d%%", kind, p))
+	return true
+}
+
+// parsePanelSize reads the argument of :panel: wider, narrower or a percent ("60" or "60%")
+func parsePanelSize(arg string) (func(p int) int, bool) {
+	switch strings.ToLower(arg) {
+	case "wider", "+":
+		return func(p int) int { return p + panelResizeStep }, true
+	case "narrower", "-":
+		return func(p int) int { return p - panelResizeStep }, true
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(arg, "%"))
+	if err != nil || n <= 0 || n >= 100 {
+		return nil, false
+	}
+	return func(int) int { return n }, true
+}
+
+// executePanelCommand handles :panel wider|narrower|<percent>, the command form of the
+// panel_wider/panel_narrower keys
+func (a *App) executePanelCommand(args []string) {
+	if len(args) != 1 {
+		a.showError("Usage: panel wider|narrower|<percent>")
+		return
+	}
+	size, ok := parsePanelSize(args[0])
+	if !ok {
+		a.showError(fmt.Sprintf("Usage: panel wider|narrower|<percent> (%d–%d)", minPanelPercent, maxPanelPercent))
+		return
+	}
+	if !a.resizeSidePanel(size) {
+		a.showError("No side panel open")
+	}
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanelPercent(t *testing.T) {
+	a := &App{Config: config.DefaultConfig()}
+	assert.Equal(t, defaultPanelPercent, a.panelPercent("labels"))
+
+	a.Config.Layout.PanelSizes = map[string]int{"ai": 65, "labels": 5, "slack": 95}
+	assert.Equal(t, 65, a.panelPercent("ai"))
+	assert.Equal(t, minPanelPercent, a.panelPercent("labels"))
+	assert.Equal(t, maxPanelPercent, a.panelPercent("slack"))
+	assert.Equal(t, defaultPanelPercent, (&App{}).panelPercent("ai"))
+}
+
+func TestShowSidePanelUsesRememberedSize(t *testing.T) {
+	a := &App{Config: config.DefaultConfig(), views: map[string]tview.Primitive{}}
+	a.Config.Layout.PanelSizes = map[string]int{"ai": 75}
+	text, panel := tview.NewBox(), tview.NewBox()
+	a.views["textContainer"] = text
+	split := tview.NewFlex().AddItem(text, 0, 1, false).AddItem(panel, 0, 0, false)
+
+	a.showSidePanel(split, panel, "ai")
+	screen := tcell.NewSimulationScreen("")
+	require.NoError(t, screen.Init())
+	defer screen.Fini()
+	split.SetRect(0, 0, 100, 10)
+	split.Draw(screen)
+	_, _, tw, _ := text.GetRect()
+	_, _, pw, _ := panel.GetRect()
+	assert.Equal(t, [2]int{25, 75}, [2]int{tw, pw})
+	assert.Equal(t, "ai", a.sidePanelKinds[panel])
+}
+
+func TestParsePanelSize(t *testing.T) {
+	for arg, want := range map[string]int{"wider": 55, "narrower": 45, "60": 60, "35%": 35} {
+		size, ok := parsePanelSize(arg)
+		require.True(t, ok, arg)
+		assert.Equal(t, want, size(50), arg)
+	}
+	for _, arg := range []string{"", "0", "100", "big", "-5"} {
+		_, ok := parsePanelSize(arg)
+		assert.False(t, ok, arg)
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/derailed/tview"
+)
+
+// maxRawSourceBytes caps the raw source shown by :source; large attachments make it unreadable anyway
+const maxRawSourceBytes = 200 * 1024
+
+// formatPartialRenderNotice lists the parts of a corrupt message that could not be decoded and
+// points at the raw source; "" when everything decoded. markup escapes it for tview.
+func formatPartialRenderNotice(problems []string, markup bool) string {
+	if len(problems) == 0 {
+		return ""
+	}
+	lines := []string{"⚠️ Partially rendered — some parts could not be decoded:"}
+	for _, p := range problems {
+		lines = append(lines, "  • "+p)
+	}
+	lines = append(lines, "🧾 :source shows the raw message")
+
+	text := strings.Join(lines, "\n")
+	if markup {
+		text = tview.Escape(text)
+	}
+	return text + "\n" + strings.Repeat("─", 40) + "\n\n"
+}
+
+// withPartialRenderNotice puts the partial-render notice above the rendered body
+func withPartialRenderNotice(m *gmail.Message, rendered string, markup bool) string {
+	if m == nil {
+		return rendered
+	}
+	return formatPartialRenderNotice(m.PartErrors, markup) + rendered
+}
+
+// executeSourceCommand handles :source — show the raw RFC 5322 source of the current message
+func (a *App) executeSourceCommand(args []string) {
+	if len(args) > 0 {
+		a.showError("Usage: source")
+		return
+	}
+	id := a.getCurrentMessageID()
+	if id == "" {
+		id = a.currentMessageID
+	}
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowProgress(a.ctx, "Loading the raw message...")
+		raw, err := a.messageClient(id).GetMessageRaw(id)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading the raw message", err)
+			return
+		}
+		content := formatRawSource(raw)
+		a.QueueUpdateDraw(func() {
+			if a.enhancedTextView != nil {
+				a.enhancedTextView.SetContent(content)
+				a.enhancedTextView.ScrollToBeginning()
+			}
+			if text, ok := a.views["text"].(*tview.TextView); ok {
+				a.SetFocus(text)
+				a.markFocus("text")
+			}
+		})
+	}()
+}
+
+// formatRawSource prepares a raw message for the content pane: truncated, terminal-safe, escaped
+func formatRawSource(raw []byte) string {
+	truncated := ""
+	if len(raw) > maxRawSourceBytes {
+		truncated = fmt.Sprintf("\n\n… truncated: showing %d KB of %d KB", maxRawSourceBytes/1024, len(raw)/1024)
+		raw = raw[:maxRawSourceBytes]
+	}
+	body := strings.ReplaceAll(string(raw), "\r\n", "\n")
+	return "[::b]🧾 Raw source[::-] (reopen the message to return)\n\n" + tview.Escape(sanitizeForTerminal(body)) + truncated
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPartialRenderNotice(t *testing.T) {
+	if got := formatPartialRenderNotice(nil, true); got != "" {
+		t.Fatalf("clean message got a notice: %q", got)
+	}
+	got := formatPartialRenderNotice([]string{"text/html: damaged base64 (kept 10 of ~40 bytes)", `unknown charset "x-foo"`}, false)
+	for _, want := range []string{"Partially rendered", "  • text/html: damaged base64", `  • unknown charset "x-foo"`, ":source"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("notice %q lacks %q", got, want)
+		}
+	}
+}
+
+func TestFormatRawSource(t *testing.T) {
+	got := formatRawSource([]byte("Subject: [urgent]\r\n\r\nbody"))
+	if !strings.Contains(got, "Subject: [urgent[]\n\nbody") {
+		t.Fatalf("raw source not escaped or normalized: %q", got)
+	}
+	big := formatRawSource([]byte(strings.Repeat("x", maxRawSourceBytes+10)))
+	if !strings.Contains(big, "truncated") {
+		t.Fatal("oversized source not truncated")
+	}
+}
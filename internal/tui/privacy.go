@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/llm"
+	"github.com/derailed/tview"
+)
+
+// privacyRedactor compiles the llm.privacy redaction rules
+func privacyRedactor(cfg *config.Config) (*llm.Redactor, error) {
+	p := cfg.LLM.Privacy
+	return llm.NewRedactor(llm.RedactOptions{
+		Emails:   p.RedactEmails,
+		Phones:   p.RedactPhones,
+		Cards:    p.RedactCards,
+		Patterns: p.Patterns,
+	})
+}
+
+// providerTrusted reports whether the configured provider receives prompts unredacted
+func providerTrusted(cfg *config.Config) bool {
+	return llm.ProviderTrusted(cfg.LLM.Provider, cfg.LLM.Privacy.TrustedProviders)
+}
+
+// localOnlyBlock returns why llm.local_only refuses the configured provider ("" when allowed)
+func localOnlyBlock(cfg *config.Config) string {
+	if cfg == nil || !cfg.LLM.LocalOnly || !cfg.LLM.Enabled {
+		return ""
+	}
+	if llm.IsLocalProvider(cfg.LLM.Provider, cfg.LLM.Endpoint) {
+		return ""
+	}
+	provider := cfg.LLM.Provider
+	if provider == "" {
+		provider = "ollama"
+	}
+	return fmt.Sprintf("llm.local_only is on and %s is not a local provider", provider)
+}
+
+// aiUnavailableText explains why an AI feature cannot run, e.g. action "prepare briefings":
+// refused by llm.local_only, or no provider configured
+func (a *App) aiUnavailableText(action string) string {
+	if reason := localOnlyBlock(a.Config); reason != "" {
+		return fmt.Sprintf("🔒 Cannot %s: AI is disabled because %s", action, reason)
+	}
+	return fmt.Sprintf("⚠️ Cannot %s: AI is not available — configure an LLM provider", action)
+}
+
+// guardLLM applies the data policies to the provider: llm.local_only drops a remote provider,
+// and the privacy redaction wraps an untrusted one when llm.privacy is enabled. An invalid
+// pattern disables AI rather than sending unredacted text.
+func guardLLM(cfg *config.Config, p llm.Provider, logger *log.Logger) llm.Provider {
+	if reason := localOnlyBlock(cfg); reason != "" {
+		if logger != nil && p != nil {
+			logger.Printf("LLM provider refused: %s — AI disabled", reason)
+		}
+		return nil
+	}
+	if p == nil || cfg == nil || !cfg.LLM.Privacy.Enabled || providerTrusted(cfg) {
+		return p
+	}
+	r, err := privacyRedactor(cfg)
+	if err != nil {
+		if logger != nil {
+			logger.Printf("LLM privacy guard: %v — AI disabled", err)
+		}
+		return nil
+	}
+	return llm.NewGuardedProvider(p, r)
+}
+
+// executePrivacyCommand handles :privacy — preview the selected message as the AI provider
+// receives it, with the values the guard masks
+func (a *App) executePrivacyCommand(args []string) {
+	if len(args) > 0 {
+		a.showError("Usage: privacy")
+		return
+	}
+	id := a.getCurrentSelectedMessageID()
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	r, err := privacyRedactor(a.Config)
+	if err != nil {
+		a.showError("❌ " + err.Error())
+		return
+	}
+	go func() {
+		m, ok := a.caches.messageGet(id)
+		if !ok {
+			fetched, err := a.messageClient(id).GetMessageWithContent(id)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading message", err)
+				return
+			}
+			m = fetched
+		}
+		preview := privacyPreview(a.Config, r, m)
+		a.QueueUpdateDraw(func() {
+			if a.enhancedTextView != nil {
+				a.enhancedTextView.SetContent(preview)
+				a.enhancedTextView.ScrollToBeginning()
+			}
+			if text, ok := a.views["text"].(*tview.TextView); ok {
+				a.SetFocus(text)
+				a.markFocus("text")
+			}
+		})
+	}()
+}
+
+// privacyPreview renders the redacted message with a header saying whether the guard applies
+// and what it masked
+func privacyPreview(cfg *config.Config, r *llm.Redactor, m *gmail.Message) string {
+	provider := cfg.LLM.Provider
+	if provider == "" {
+		provider = "ollama"
+	}
+	text := fmt.Sprintf("Subject: %s\nFrom: %s\n\n%s", m.Subject, m.From, m.PlainText)
+	redacted, report := r.Redact(text)
+
+	var sb strings.Builder
+	sb.WriteString("[::b]🔒 Privacy preview[::-]\n")
+	switch {
+	case !cfg.LLM.Privacy.Enabled:
+		fmt.Fprintf(&sb, "Guard off (llm.privacy.enabled): %s receives the original text. With the guard on it would receive:\n", provider)
+	case providerTrusted(cfg):
+		fmt.Fprintf(&sb, "%s is trusted: it receives the original text. An untrusted provider would receive:\n", provider)
+	default:
+		fmt.Fprintf(&sb, "%s receives this redacted text:\n", provider)
+	}
+	if report.Total() == 0 {
+		sb.WriteString("Nothing to mask.\n")
+	} else {
+		kinds := make([]string, 0, len(report))
+		for k := range report {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		parts := make([]string, 0, len(kinds))
+		for _, k := range kinds {
+			parts = append(parts, fmt.Sprintf("%d %s", report[k], k))
+		}
+		fmt.Fprintf(&sb, "Masked: %s\n", strings.Join(parts, ", "))
+	}
+	sb.WriteString("Reopen the message to return to it.\n\n")
+	sb.WriteString(tview.Escape(redacted))
+	return sb.String()
+}
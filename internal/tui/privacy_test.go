@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/llm"
+)
+
+type echoProvider struct{ last string }
+
+func (p *echoProvider) Name() string { return "echo" }
+func (p *echoProvider) Generate(prompt string) (string, error) {
+	p.last = prompt
+	return prompt, nil
+}
+
+func TestGuardLLM(t *testing.T) {
+	cfg := config.DefaultConfig()
+	inner := &echoProvider{}
+	if guardLLM(cfg, inner, nil) != llm.Provider(inner) {
+		t.Fatal("guard off: provider must be left as is")
+	}
+	cfg.LLM.Privacy.Enabled = true
+	if guardLLM(cfg, inner, nil) != llm.Provider(inner) {
+		t.Fatal("ollama is trusted by default")
+	}
+	cfg.LLM.Provider = "bedrock"
+	guarded := guardLLM(cfg, inner, nil)
+	if _, err := guarded.Generate("mail ana@example.com"); err != nil || inner.last != "mail [EMAIL]" {
+		t.Fatalf("untrusted provider got %q (%v)", inner.last, err)
+	}
+	cfg.LLM.Privacy.Patterns = []string{"("}
+	if guardLLM(cfg, inner, nil) != nil {
+		t.Fatal("an invalid pattern must disable AI instead of sending unredacted text")
+	}
+}
+
+func TestPrivacyPreview(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LLM.Privacy.Enabled = true
+	cfg.LLM.Provider = "bedrock"
+	r, err := privacyRedactor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := privacyPreview(cfg, r, &gmail.Message{Subject: "Hi", From: "Bob <bob@example.com>", PlainText: "Call me at +34 612 345 678"})
+	for _, want := range []string{"bedrock receives this redacted text", "Masked: 1 email, 1 phone", "From: Bob <[EMAIL[]>", "Call me at [PHONE[]"} { // tview-escaped
+		if !strings.Contains(out, want) {
+			t.Fatalf("preview missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGuardLLM_LocalOnly(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LLM.LocalOnly = true
+	inner := &echoProvider{}
+	a := &App{Config: cfg}
+	if guardLLM(cfg, inner, nil) == nil || localOnlyBlock(cfg) != "" {
+		t.Fatal("the default Ollama on localhost is local")
+	}
+	cfg.LLM.Provider = "bedrock"
+	if guardLLM(cfg, inner, nil) != nil {
+		t.Fatal("a remote provider must be refused under local_only")
+	}
+	if got := a.aiUnavailableText("prepare briefings"); got != "🔒 Cannot prepare briefings: AI is disabled because llm.local_only is on and bedrock is not a local provider" {
+		t.Fatalf("unavailable text = %q", got)
+	}
+	if !strings.Contains(a.statusBaseline(), "🔒 AI off") {
+		t.Fatalf("status baseline should show AI off: %q", a.statusBaseline())
+	}
+}
+
+func TestAttachLLM_AppliesGuardAndWiresAI(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LLM.Provider = "bedrock"
+	cfg.LLM.LocalOnly = true
+	a := &App{Config: cfg}
+	a.AttachLLM(&echoProvider{})
+	if a.LLM != nil || a.aiService != nil {
+		t.Fatal("a provider attached after startup must still honour local_only")
+	}
+
+	cfg.LLM.LocalOnly = false
+	a.AttachLLM(&echoProvider{})
+	if a.LLM == nil || a.aiService == nil {
+		t.Fatalf("attached provider not wired: LLM=%v aiService=%v", a.LLM, a.aiService)
+	}
+}
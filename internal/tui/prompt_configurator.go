@@ -198,7 +198,7 @@ func (a *App) openPromptConfigurator(pctx promptConfiguratorContext) {
 		}
 		a.labelsView = state.container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "prompts")
 	}
 
 	a.SetFocus(state.intentInput)
@@ -484,7 +484,7 @@ func (a *App) applyEphemeralPromptToMessage(messageID string, promptText string,
 	a.QueueUpdateDraw(func() {
 		if !a.aiPanel.visible.Load() {
 			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-				split.ResizeItem(a.aiSummaryView, 0, 1)
+				a.showSidePanel(split, a.aiSummaryView, "prompts")
 			}
 			a.aiPanel.visible.Store(true)
 		}
@@ -505,16 +505,16 @@ func (a *App) applyEphemeralPromptToMessage(messageID string, promptText string,
 		a.aiPanel.clearStreamingCancel()
 	}()
 
-	var b strings.Builder
+	stream := a.newAIStream()
 	result, err := aiService.ApplyCustomPromptStream(ctx, finalPrompt, nil, func(token string) {
 		select {
 		case <-ctx.Done():
 			return
 		default:
 		}
-		b.WriteString(token)
+		text := stream.write(token, "")
 		if ctx.Err() == nil && a.aiSummaryView != nil {
-			a.aiSummaryView.SetText(b.String())
+			a.aiSummaryView.SetText(text)
 			a.aiSummaryView.ScrollToEnd()
 		}
 	})
@@ -539,8 +539,7 @@ func (a *App) applyEphemeralPromptToMessage(messageID string, promptText string,
 
 	a.QueueUpdateDraw(func() {
 		if a.aiSummaryView != nil {
-			a.aiSummaryView.SetText(result)
-			a.aiSummaryView.ScrollToBeginning()
+			a.showAIResult(result)
 		}
 	})
 	a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Applied: %s", name))
@@ -564,7 +563,7 @@ func (a *App) applyEphemeralPromptToBulk(messageIDs []string, promptText string,
 	a.QueueUpdateDraw(func() {
 		if !a.aiPanel.visible.Load() {
 			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-				split.ResizeItem(a.aiSummaryView, 0, 1)
+				a.showSidePanel(split, a.aiSummaryView, "prompts")
 			}
 			a.aiPanel.visible.Store(true)
 		}
@@ -584,7 +583,7 @@ func (a *App) applyEphemeralPromptToBulk(messageIDs []string, promptText string,
 		a.aiPanel.clearStreamingCancel()
 	}()
 
-	var b strings.Builder
+	stream := a.newAIStream()
 	// Route through BulkPromptService so ephemeral applies use the same content
 	// extraction + cleaning + combination as saved-prompt bulk applies (issue #44).
 	result, err := bulkSvc.ApplyEphemeralBulkPromptStream(ctx, messageIDs, promptText, nil, func(token string) {
@@ -593,9 +592,9 @@ func (a *App) applyEphemeralPromptToBulk(messageIDs []string, promptText string,
 			return
 		default:
 		}
-		b.WriteString(token)
+		text := stream.write(token, "")
 		if ctx.Err() == nil && a.aiSummaryView != nil {
-			a.aiSummaryView.SetText(b.String())
+			a.aiSummaryView.SetText(text)
 			a.aiSummaryView.ScrollToEnd()
 		}
 	})
@@ -620,8 +619,7 @@ func (a *App) applyEphemeralPromptToBulk(messageIDs []string, promptText string,
 
 	a.QueueUpdateDraw(func() {
 		if a.aiSummaryView != nil {
-			a.aiSummaryView.SetText(result)
-			a.aiSummaryView.ScrollToBeginning()
+			a.showAIResult(result)
 		}
 	})
 	a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Applied: %s (%d msgs)", name, len(messageIDs)))
@@ -709,7 +707,7 @@ func (a *App) savePromptFromConfigurator() {
 			}
 			a.labelsView = state.container
 			split.AddItem(a.labelsView, 0, 1, true)
-			split.ResizeItem(a.labelsView, 0, 1)
+			a.showSidePanel(split, a.labelsView, "prompts")
 		}
 		a.SetFocus(state.promptArea)
 	}
@@ -777,7 +775,7 @@ func (a *App) savePromptFromConfigurator() {
 		}
 		a.labelsView = form
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "prompts")
 	}
 	a.SetFocus(nameInput)
 }
@@ -36,7 +36,7 @@ func (a *App) openPromptPicker() {
 	}
 
 	// Get message content for prompt processing
-	message, err := a.Client.GetMessageWithContent(messageID)
+	message, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 	if err != nil {
 		a.GetErrorHandler().ShowError(a.ctx, "Failed to load message content")
 		return
@@ -68,6 +68,7 @@ func (a *App) openPromptPicker() {
 		description string
 		promptText  string
 		category    string
+		shared      bool
 	}
 
 	var all []promptItem
@@ -110,6 +111,9 @@ func (a *App) openPromptPicker() {
 			}
 
 			display := fmt.Sprintf("%s %s", icon, item.name)
+			if item.shared {
+				display += " " + sharedPackMarker
+			}
 
 			// Capture variables for closure
 			promptID := item.id
@@ -159,6 +163,7 @@ func (a *App) openPromptPicker() {
 				description: p.Description,
 				promptText:  p.PromptText,
 				category:    p.Category,
+				shared:      p.Source != "",
 			})
 		}
 
@@ -280,7 +285,7 @@ func (a *App) openPromptPicker() {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "prompts")
 			}
 			a.SetFocus(input)
 			a.markFocus("prompts")
@@ -370,7 +375,7 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 		// Show AI panel manually to avoid potential issues with toggleAISummary
 		if !a.aiPanel.visible.Load() {
 			if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-				split.ResizeItem(a.aiSummaryView, 0, 1)
+				a.showSidePanel(split, a.aiSummaryView, "prompts")
 			}
 			a.aiPanel.visible.Store(true)
 		}
@@ -383,6 +388,7 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 			a.aiSummaryView.SetTitle(fmt.Sprintf(" 🤖 %s ", promptName))
 			a.aiSummaryView.SetTitleColor(a.GetComponentColors("ai").Title.Color())
 			// Show loading message
+			a.aiPanel.setSource("")
 			a.aiSummaryView.SetText("🤖 Applying prompt...")
 			a.aiSummaryView.ScrollToBeginning()
 
@@ -418,14 +424,13 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 		a.QueueUpdateDraw(func() {
 			if a.aiSummaryView != nil {
 				// Set the cached result text (rendered through Markdown pipeline)
-				a.aiSummaryView.SetText(a.renderPromptResult(cachedResult.ResultText))
-				a.aiSummaryView.ScrollToBeginning()
+				a.showAIResult(cachedResult.ResultText)
 			}
 		})
 
 		// Clear progress and show success
 		a.GetErrorHandler().ClearProgress()
-		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("%s (cached)", promptName))
+		a.GetErrorHandler().ShowDetail(a.ctx, fmt.Sprintf("%s (cached)", promptName))
 		return
 	}
 
@@ -492,7 +497,8 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 
 		// Throttling for visible streaming effect
 		var lastUpdate time.Time
-		var b strings.Builder
+		stream := a.newAIStream()
+		var currentText string
 		chunkDelayMs := a.Config.LLM.StreamChunkMs
 		if chunkDelayMs <= 0 {
 			chunkDelayMs = 150 // Default 150ms for smooth streaming
@@ -518,13 +524,12 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 			default:
 			}
 
-			b.WriteString(token)
+			currentText = stream.write(token, "")
 
 			// Throttle UI updates for visible streaming effect
 			now := time.Now()
 			if now.Sub(lastUpdate) >= chunkDelay || lastUpdate.IsZero() {
 				lastUpdate = now
-				currentText := sanitizeForTerminal(b.String())
 
 				// CRITICAL: NEVER use QueueUpdateDraw in streaming callbacks
 				// Direct UI update to prevent deadlock with ESC handler
@@ -555,8 +560,7 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 			// Final UI update to ensure result is shown (rendered through Markdown pipeline)
 			a.QueueUpdateDraw(func() {
 				if a.aiSummaryView != nil {
-					a.aiSummaryView.SetText(a.renderPromptResult(result.ResultText))
-					a.aiSummaryView.ScrollToBeginning()
+					a.showAIResult(result.ResultText)
 				}
 			})
 
@@ -591,8 +595,7 @@ func (a *App) applyPromptToMessage(messageID string, promptID int, promptName st
 	// Update AI panel with result (rendered through Markdown pipeline)
 	a.QueueUpdateDraw(func() {
 		if a.aiSummaryView != nil {
-			a.aiSummaryView.SetText(a.renderPromptResult(result.ResultText))
-			a.aiSummaryView.ScrollToBeginning()
+			a.showAIResult(result.ResultText)
 		}
 	})
 
@@ -640,6 +643,7 @@ func (a *App) openPromptPickerForManagement() {
 		description string
 		category    string
 		usageCount  int
+		shared      bool
 	}
 
 	var all []promptItem
@@ -672,6 +676,10 @@ func (a *App) openPromptPickerForManagement() {
 
 			display := fmt.Sprintf("%s %s", icon, item.name)
 			secondary := fmt.Sprintf("Category: %s | Used: %d times", item.category, item.usageCount)
+			if item.shared {
+				display += " " + sharedPackMarker
+				secondary += " | Shared (read-only)"
+			}
 
 			// Capture variables for closure
 			promptID := item.id
@@ -702,6 +710,7 @@ func (a *App) openPromptPickerForManagement() {
 					description: p.Description,
 					category:    p.Category,
 					usageCount:  p.UsageCount,
+					shared:      p.Source != "",
 				})
 			}
 
@@ -808,7 +817,7 @@ func (a *App) openPromptPickerForManagement() {
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "prompts")
 	}
 	a.SetFocus(input)
 	a.markFocus("prompts")
@@ -869,6 +878,9 @@ func (a *App) showPromptDetails(promptID int, promptName string) {
 		if prompt.Description != "" {
 			details += fmt.Sprintf("📄 Description: %s\n", prompt.Description)
 		}
+		if prompt.Source != "" {
+			details += fmt.Sprintf("%s Shared pack (read-only)\n", sharedPackMarker)
+		}
 		details += fmt.Sprintf("🆔 ID: %d\n", prompt.ID)
 		details += "\nTemplate:\n\n"
 		details += prompt.PromptText
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+// quickDateCommand is a command-line prefix followed by a quick date
+type quickDateCommand struct {
+	prefix []string
+	// past reads the date backwards, as a day to look up
+	past bool
+	// keywords are the arguments after prefix that are not dates
+	keywords []string
+}
+
+// quickDateCommands are the commands taking a quick date; the command bar previews the time the
+// date resolves to while it is typed
+var quickDateCommands = []quickDateCommand{
+	{prefix: []string{"dnd", "until"}},
+	{prefix: []string{"timemachine"}, past: true, keywords: []string{"list", "days", "capture", "snapshot"}},
+	{prefix: []string{"tm"}, past: true, keywords: []string{"list", "days", "capture", "snapshot"}},
+}
+
+// quickDateOptions returns the quick_dates settings
+func (a *App) quickDateOptions() services.QuickDateOptions {
+	opts := services.QuickDateOptions{DefaultHour: 9}
+	if a.Config != nil {
+		opts.Locale = a.Config.QuickDates.Locale
+		opts.DefaultHour, opts.DefaultMinute = a.Config.QuickDates.ResolvedDefaultTime()
+	}
+	return opts
+}
+
+// parseQuickDate reads a date typed the short way ("tomorrow 9am", "next mon", "in 2h")
+func (a *App) parseQuickDate(input string) (time.Time, error) {
+	return services.ParseQuickDate(input, time.Now(), a.quickDateOptions())
+}
+
+// parsePastQuickDate reads a date to look up ("yesterday", "last fri", "3 days ago", "10d")
+func (a *App) parsePastQuickDate(input string) (time.Time, error) {
+	opts := a.quickDateOptions()
+	opts.Past = true
+	return services.ParseQuickDate(input, time.Now(), opts)
+}
+
+// formatQuickDate shows a resolved quick date in the configured language
+func (a *App) formatQuickDate(t time.Time) string {
+	return services.FormatQuickDate(t, time.Now(), a.quickDateOptions().Locale)
+}
+
+// quickDatePreview returns the command bar hint for a command taking a quick date: the absolute
+// time it resolves to, or why it does not resolve. "" when text is not such a command.
+func (a *App) quickDatePreview(text string) string {
+	fields := strings.Fields(text)
+	for _, cmd := range quickDateCommands {
+		if len(fields) <= len(cmd.prefix) {
+			continue
+		}
+		matches := true
+		for i, word := range cmd.prefix {
+			if !strings.EqualFold(fields[i], word) {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		arg := strings.Join(fields[len(cmd.prefix):], " ")
+		for _, keyword := range cmd.keywords {
+			if strings.EqualFold(arg, keyword) {
+				return ""
+			}
+		}
+		parse := a.parseQuickDate
+		if cmd.past {
+			parse = a.parsePastQuickDate
+		}
+		t, err := parse(arg)
+		if err != nil {
+			return "✗ " + err.Error()
+		}
+		return "→ " + a.formatQuickDate(t)
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// quotaPlanPage is the Pages name of the bulk quota prompt
+const quotaPlanPage = "quotaPlan"
+
+// withQuotaPlan gates a bulk job on the quota planner. A job that fits the current quota window
+// starts right away; a larger one opens a prompt to schedule it across windows, shrink it to
+// what fits now, or cancel. start receives the ids to process and the plan to run them with.
+func (a *App) withQuotaPlan(op services.QuotaOperation, ids []string, verb string, start func(ids []string, plan services.QuotaPlan)) {
+	if a.quotaPlanner == nil || len(ids) == 0 {
+		start(ids, services.QuotaPlan{Operation: op, Messages: len(ids)})
+		return
+	}
+	plan := a.quotaPlanner.Plan(op, len(ids))
+	if plan.Fits() {
+		start(ids, plan)
+		return
+	}
+	// Callers may be on the UI goroutine or not; queue the prompt either way
+	a.QueueUpdateDraw(func() {
+		a.showQuotaPlanPrompt(plan, verb, func(choice rune) {
+			switch choice {
+			case 's':
+				start(ids, plan)
+			case 'b':
+				n := plan.FitsNow()
+				start(ids[:n], a.quotaPlanner.Plan(op, n))
+			}
+		})
+	})
+}
+
+// runQuotaPlanned runs fn over ids in the plan's batches, reporting overall progress as
+// "<verb> done/total…" and a status line while waiting for the next quota window
+func (a *App) runQuotaPlanned(plan services.QuotaPlan, ids []string, verb string, fn func(batch []string, progress func(done, total int)) error) error {
+	progress := a.bulkProgress(a.ctx, verb)
+	if a.quotaPlanner == nil || plan.Fits() {
+		return fn(ids, progress)
+	}
+	return a.quotaPlanner.RunScheduled(a.ctx, plan, ids,
+		func(batch []string, offset int) error {
+			return fn(batch, func(done, _ int) { progress(offset+done, len(ids)) })
+		},
+		func(next, total int, resume time.Time) {
+			a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("⏳ %s: batch %d/%d resumes at %s to stay within Gmail quota…", verb, next, total, resume.Format("15:04:05")))
+		})
+}
+
+// finishBulkJob drops processed messages from the selection and leaves bulk mode once nothing
+// is left; a shrunk job keeps the remaining messages selected for another run
+func (a *App) finishBulkJob(processed []string) {
+	for _, id := range processed {
+		a.bulk.remove(id)
+	}
+	if a.bulk.count() == 0 {
+		a.bulk.clear()
+		a.bulk.setMode(false)
+	}
+}
+
+// formatQuotaPlanPrompt explains why a bulk job needs a decision and lists the choices
+func formatQuotaPlanPrompt(plan services.QuotaPlan, verb string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %d messages needs about %d Gmail quota units.\n", verb, plan.Messages, plan.Units)
+	fmt.Fprintf(&sb, "Bulk jobs may use %d units per minute (the rest is kept for everything else); %d are left this minute.\n\n", plan.Budget, plan.Available)
+	minutes := int(plan.Duration().Round(time.Minute) / time.Minute)
+	fmt.Fprintf(&sb, "  s    Schedule in %d batches over ~%d min\n", len(plan.Batches), minutes)
+	if n := plan.FitsNow(); n > 0 {
+		fmt.Fprintf(&sb, "  b    Shrink to the first %d messages (the rest stay selected)\n", n)
+	}
+	sb.WriteString("  Esc  Cancel")
+	return sb.String()
+}
+
+// showQuotaPlanPrompt asks how to run a job that exceeds the quota budget; onChoice gets 's'
+// (schedule) or 'b' (shrink) and is not called on cancel
+func (a *App) showQuotaPlanPrompt(plan services.QuotaPlan, verb string, onChoice func(choice rune)) {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	body := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+	body.SetText(formatQuotaPlanPrompt(plan, verb))
+	body.SetTextColor(colors.Text.Color())
+	body.SetBackgroundColor(bgColor)
+	body.SetBorder(true).
+		SetTitle(" ⚖️ Large bulk job ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+
+	closePrompt := func() {
+		a.Pages.RemovePage(quotaPlanPage)
+		a.restoreFocusAfterModal()
+	}
+	body.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape {
+			closePrompt()
+			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("%s cancelled", verb))
+			return nil
+		}
+		switch ev.Rune() {
+		case 's':
+			closePrompt()
+			onChoice('s')
+			return nil
+		case 'b':
+			if plan.FitsNow() > 0 {
+				closePrompt()
+				onChoice('b')
+			}
+			return nil
+		}
+		return ev
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(body, 10, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+	a.Pages.AddPage(quotaPlanPage, overlay, true, true)
+	a.SetFocus(body)
+}
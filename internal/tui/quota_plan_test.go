@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestFormatQuotaPlanPrompt(t *testing.T) {
+	p := services.NewQuotaPlannerService(1000, 20)
+	p.Record(300)
+	out := formatQuotaPlanPrompt(p.Plan(services.QuotaOpArchive, 250), "Archiving")
+	for _, want := range []string{
+		"Archiving 250 messages needs about 1300 Gmail quota units.",
+		"800 units per minute",
+		"500 are left this minute",
+		"Schedule in 3 batches over ~2 min",
+		"Shrink to the first 90 messages",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("prompt missing %q:\n%s", want, out)
+		}
+	}
+
+	// Nothing fits right now: no shrink option
+	p.Record(500)
+	if out := formatQuotaPlanPrompt(p.Plan(services.QuotaOpArchive, 250), "Archiving"); strings.Contains(out, "Shrink") {
+		t.Errorf("shrink offered with no budget left:\n%s", out)
+	}
+}
+
+func TestFinishBulkJob_KeepsUnprocessedSelected(t *testing.T) {
+	a := &App{bulk: newBulkState()}
+	a.bulk.setMode(true)
+	for _, id := range []string{"a", "b", "c"} {
+		a.bulk.add(id)
+	}
+	a.finishBulkJob([]string{"a", "b"})
+	if !a.bulk.isMode() || a.bulk.count() != 1 || !a.bulk.isSelected("c") {
+		t.Fatalf("selection after shrunk job = %v (mode %v)", a.bulk.ids(), a.bulk.isMode())
+	}
+	a.finishBulkJob([]string{"c"})
+	if a.bulk.isMode() || a.bulk.count() != 0 {
+		t.Fatal("bulk mode should end once every selected message is processed")
+	}
+}
@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// expandFocusedRecipientField expands group names in the To/Cc/Bcc field being left, so the
+// members are visible before sending. Runs on the UI goroutine.
+func (c *CompositionPanel) expandFocusedRecipientField() {
+	if c.app.recipientGroupService == nil || c.currentFocusIndex >= len(c.focusableItems) {
+		return
+	}
+	field, ok := c.focusableItems[c.currentFocusIndex].(*tview.InputField)
+	if !ok || (field != c.toField && field != c.ccField && field != c.bccField) {
+		return
+	}
+	if expanded, changed := c.app.recipientGroupService.Expand(c.parseRecipients(field.GetText())); changed {
+		field.SetText(strings.Join(c.formatRecipients(expanded), ", "))
+	}
+}
+
+// expandRecipientGroups expands group names left in the composition's recipients before sending
+func (c *CompositionPanel) expandRecipientGroups() {
+	if c.app.recipientGroupService == nil || c.composition == nil {
+		return
+	}
+	c.composition.To, _ = c.app.recipientGroupService.Expand(c.composition.To)
+	c.composition.CC, _ = c.app.recipientGroupService.Expand(c.composition.CC)
+	c.composition.BCC, _ = c.app.recipientGroupService.Expand(c.composition.BCC)
+}
+
+// parseRecipientGroupArgs splits '<name> = <addresses>'
+func parseRecipientGroupArgs(text string) (string, []string, bool) {
+	i := strings.Index(text, "=")
+	if i < 0 {
+		return "", nil, false
+	}
+	name := strings.TrimSpace(text[:i])
+	members := services.ParseRecipientGroupMembers(text[i+1:])
+	return name, members, name != "" && len(members) > 0
+}
+
+// executeGroupsCommand handles :groups [<name> = <addresses> | remove <name>] — define or delete a
+// recipient group, or manage them in the side panel
+func (a *App) executeGroupsCommand(args []string) {
+	if a.recipientGroupService == nil {
+		a.showError("Recipient groups not available")
+		return
+	}
+	if len(args) == 0 {
+		a.openRecipientGroupsPanel()
+		return
+	}
+	if strings.EqualFold(args[0], "remove") {
+		name := strings.TrimSpace(strings.Join(args[1:], " "))
+		if name == "" {
+			a.showError("Usage: groups remove <name>")
+			return
+		}
+		if err := a.recipientGroupService.Delete(name); err != nil {
+			go a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing recipient group", err)
+			return
+		}
+		go a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("👥 Recipient group %q removed", name))
+		return
+	}
+	name, members, ok := parseRecipientGroupArgs(strings.Join(args, " "))
+	if !ok {
+		a.showError("Usage: groups <name> = <address>, <address>… | remove <name>")
+		return
+	}
+	group, err := a.recipientGroupService.Set(name, members)
+	if err != nil {
+		go a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving recipient group", err)
+		return
+	}
+	go a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("👥 Group %q saved with %d address(es)", group.Name, len(group.Members)))
+}
+
+// openRecipientGroupsPanel manages recipient groups in the side panel: type 'name = addresses' and
+// press Enter to save, Enter on a group loads it for editing, d deletes it
+func (a *App) openRecipientGroupsPanel() {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	input := tview.NewInputField().
+		SetLabel("👥 ").
+		SetPlaceholder("team = a@example.com, b@example.com").
+		SetLabelColor(colors.Title.Color()).
+		SetFieldBackgroundColor(bgColor).
+		SetFieldTextColor(colors.Text.Color())
+	input.SetBackgroundColor(bgColor)
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+	var groups []services.RecipientGroup
+	reload := func() {
+		groups = a.recipientGroupService.List()
+		list.Clear()
+		if len(groups) == 0 {
+			list.AddItem("No recipient groups yet", "", 0, nil)
+			return
+		}
+		for _, g := range groups {
+			list.AddItem(tview.Escape(fmt.Sprintf("👥 %s (%d)", g.Name, len(g.Members))), tview.Escape(strings.Join(g.Members, ", ")), 0, nil)
+		}
+	}
+
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i >= 0 && i < len(groups) {
+			input.SetText(groups[i].Name + " = " + strings.Join(groups[i].Members, ", "))
+			a.SetFocus(input)
+		}
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			a.closeRecipientGroupsPanel()
+		case tcell.KeyEnter:
+			name, members, ok := parseRecipientGroupArgs(input.GetText())
+			if !ok {
+				go a.GetErrorHandler().ShowWarning(a.ctx, "Type a group as: name = address, address…")
+				return
+			}
+			if _, err := a.recipientGroupService.Set(name, members); err != nil {
+				go a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving recipient group", err)
+				return
+			}
+			input.SetText("")
+			reload()
+			a.SetFocus(list)
+			go a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("👥 Group %q saved", name))
+		}
+	})
+	input.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyDown {
+			a.SetFocus(list)
+			return nil
+		}
+		return e
+	})
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case e.Key() == tcell.KeyEscape:
+			a.closeRecipientGroupsPanel()
+			return nil
+		case e.Key() == tcell.KeyUp && list.GetCurrentItem() == 0, e.Rune() == 'n':
+			a.SetFocus(input)
+			return nil
+		case e.Rune() == 'd':
+			if i := list.GetCurrentItem(); i >= 0 && i < len(groups) {
+				if err := a.recipientGroupService.Delete(groups[i].Name); err != nil {
+					go a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing recipient group", err)
+				}
+				reload()
+			}
+			return nil
+		}
+		return e
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(" 👥 Recipient groups ")
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(input, 3, 0, false)
+	container.AddItem(list, 0, 1, true)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to edit | n for new | d to delete | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "groups")
+	}
+	a.markFocus("labels")
+	a.setActivePicker(PickerRecipientGroups)
+	reload()
+	if len(groups) == 0 {
+		a.SetFocus(input)
+	} else {
+		a.SetFocus(list)
+	}
+}
+
+// closeRecipientGroupsPanel closes the recipient groups panel and restores focus
+func (a *App) closeRecipientGroupsPanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
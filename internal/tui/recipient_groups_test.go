@@ -0,0 +1,18 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRecipientGroupArgs(t *testing.T) {
+	name, members, ok := parseRecipientGroupArgs("team = a@x.com, b@x.com")
+	if !ok || name != "team" || !reflect.DeepEqual(members, []string{"a@x.com", "b@x.com"}) {
+		t.Fatalf("got %q %v %v", name, members, ok)
+	}
+	for _, bad := range []string{"team", "= a@x.com", "team = ", ""} {
+		if _, _, ok := parseRecipientGroupArgs(bad); ok {
+			t.Errorf("%q should be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// replyNewerWarningPage is the overlay listing thread messages newer than the one being replied to
+const replyNewerWarningPage = "replyNewerWarning"
+
+// formatNewerMessagesTitle is the warning shown before sending a reply into a thread that moved on
+func formatNewerMessagesTitle(n int) string {
+	if n == 1 {
+		return "Thread has 1 newer message — view before sending?"
+	}
+	return fmt.Sprintf("Thread has %d newer messages — view before sending?", n)
+}
+
+// formatNewerMessages renders the newer messages (sender, date, snippet), oldest first
+func formatNewerMessages(newer []*gmailapi.Message) string {
+	var b strings.Builder
+	for i, m := range newer {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		from := extractHeaderValue(m, "From")
+		if from == "" {
+			from = "(unknown sender)"
+		}
+		fmt.Fprintf(&b, "• %s — %s\n", from, time.UnixMilli(m.InternalDate).Format("Jan 2 15:04"))
+		if snippet := strings.TrimSpace(m.Snippet); snippet != "" {
+			fmt.Fprintf(&b, "  %s\n", snippet)
+		}
+	}
+	return b.String()
+}
+
+// isReply reports whether the composition answers an existing message
+func (c *CompositionPanel) isReply() bool {
+	if c.composition == nil || c.composition.OriginalID == "" {
+		return false
+	}
+	return c.composition.Type == services.CompositionTypeReply || c.composition.Type == services.CompositionTypeReplyAll
+}
+
+// checkNewerThreadMessages returns true when sending may proceed. When the thread received messages
+// after the one being replied to (beyond those already shown), it opens the warning overlay instead.
+// Lookup failures never block sending.
+func (c *CompositionPanel) checkNewerThreadMessages() bool {
+	if !c.isReply() {
+		return true
+	}
+	_, _, _, _, _, compositionService, _, _, _, _, _, _ := c.app.GetServices()
+	newer, err := compositionService.NewerThreadMessages(c.app.ctx, c.composition.OriginalID)
+	if err != nil {
+		if c.app.logger != nil {
+			c.app.logger.Printf("checkNewerThreadMessages: %v", err)
+		}
+		return true
+	}
+	if len(newer) <= c.newerAcknowledged {
+		return true
+	}
+	c.app.QueueUpdateDraw(func() {
+		c.showNewerMessagesWarning(newer)
+	})
+	return false
+}
+
+// showNewerMessagesWarning lists the newer thread messages; Enter sends anyway, Esc returns to editing.
+// Must run on the UI goroutine.
+func (c *CompositionPanel) showNewerMessagesWarning(newer []*gmailapi.Message) {
+	colors := c.app.GetComponentColors("compose")
+	bgColor := colors.Background.Color()
+
+	body := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+	body.SetText(formatNewerMessages(newer))
+	body.SetTextColor(colors.Text.Color())
+	body.SetBackgroundColor(bgColor)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to send anyway  |  Esc to keep editing ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+
+	box := tview.NewFlex().SetDirection(tview.FlexRow)
+	box.SetBorder(true).
+		SetTitle(" ⚠️ " + formatNewerMessagesTitle(len(newer)) + " ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color()).
+		SetBackgroundColor(bgColor)
+	box.AddItem(body, 0, 1, true)
+	box.AddItem(footer, 1, 0, false)
+
+	closeWarning := func() {
+		c.app.Pages.RemovePage(replyNewerWarningPage)
+		c.newerAcknowledged = len(newer)
+		c.updateSendButtonState("normal")
+		c.focusCurrent()
+	}
+	box.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		switch ev.Key() {
+		case tcell.KeyEscape:
+			closeWarning()
+			return nil
+		case tcell.KeyEnter:
+			closeWarning()
+			go c.sendComposition()
+			return nil
+		}
+		return ev
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(box, 0, 2, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	c.app.Pages.AddPage(replyNewerWarningPage, overlay, true, true)
+	c.app.SetFocus(body)
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestFormatNewerMessages(t *testing.T) {
+	if got := formatNewerMessagesTitle(2); got != "Thread has 2 newer messages — view before sending?" {
+		t.Errorf("title = %q", got)
+	}
+	if got := formatNewerMessagesTitle(1); !strings.Contains(got, "1 newer message —") {
+		t.Errorf("singular title = %q", got)
+	}
+
+	msgs := []*gmailapi.Message{
+		{Snippet: "Already handled, thanks", Payload: &gmailapi.MessagePart{Headers: []*gmailapi.MessagePartHeader{{Name: "From", Value: "Ana <ana@x.com>"}}}},
+		{},
+	}
+	out := formatNewerMessages(msgs)
+	if !strings.Contains(out, "• Ana <ana@x.com> — ") || !strings.Contains(out, "  Already handled, thanks") {
+		t.Errorf("missing sender or snippet:\n%s", out)
+	}
+	if !strings.Contains(out, "(unknown sender)") {
+		t.Errorf("missing placeholder sender:\n%s", out)
+	}
+}
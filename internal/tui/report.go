@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// reportPage is the Pages name of the email report viewer
+const reportPage = "emailReport"
+
+// parseReportArgs parses ':report [days] [ai] [save|email]'. days accepts "14" or "14d".
+func parseReportArgs(args []string) (services.ReportOptions, string, error) {
+	opts := services.ReportOptions{Days: 7}
+	action := "show"
+	for _, arg := range args {
+		switch a := strings.ToLower(arg); a {
+		case "ai":
+			opts.Narrative = true
+		case "save", "email", "show":
+			action = a
+		default:
+			n, err := strconv.Atoi(strings.TrimSuffix(a, "d"))
+			if err != nil || n <= 0 || n > 90 {
+				return opts, "", fmt.Errorf("usage: report [days] [ai] [save|email]")
+			}
+			opts.Days = n
+		}
+	}
+	return opts, action, nil
+}
+
+// executeReportCommand handles :report [days] [ai] [save|email] — build the email report for the
+// last days (7 by default) and show it, save it to the saved folder, or email it to yourself
+func (a *App) executeReportCommand(args []string) {
+	if a.reportService == nil {
+		a.showError("Report not available (no Gmail client)")
+		return
+	}
+	opts, action, err := parseReportArgs(args)
+	if err != nil {
+		a.showError(err.Error())
+		return
+	}
+	go func() {
+		msg := fmt.Sprintf("📊 Building %d-day email report…", opts.Days)
+		if opts.Narrative {
+			msg = fmt.Sprintf("📊 Building %d-day email report with AI narrative…", opts.Days)
+		}
+		a.GetErrorHandler().ShowProgress(a.ctx, msg)
+		report, err := a.reportService.Generate(a.ctx, opts)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error building report", err)
+			return
+		}
+		if report.NarrativeError != "" {
+			a.GetErrorHandler().ShowWarning(a.ctx, "Report built without AI narrative: "+report.NarrativeError)
+		}
+		switch action {
+		case "save":
+			a.saveReport(report)
+		case "email":
+			a.emailReport(report)
+		default:
+			a.showReport(report)
+		}
+	}()
+}
+
+// showReport opens the report in a read-only viewer: s saves it, m emails it to yourself
+func (a *App) showReport(report *services.EmailReport) {
+	content := report.Markdown()
+	a.QueueUpdateDraw(func() {
+		colors := a.GetComponentColors("general")
+		view := tview.NewTextView().SetDynamicColors(false).SetWrap(true).SetWordWrap(true)
+		view.SetText(content)
+		view.SetTextColor(colors.Text.Color())
+		view.SetBackgroundColor(colors.Background.Color())
+		view.SetBorder(true).
+			SetTitle(" 📊 " + report.Title() + " — s to save | m to email me | Esc to close ").
+			SetTitleColor(colors.Title.Color()).
+			SetBorderColor(colors.Border.Color())
+		view.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+			switch {
+			case ev.Key() == tcell.KeyEscape || ev.Rune() == 'q':
+				a.Pages.RemovePage(reportPage)
+				a.restoreFocusAfterModal()
+				return nil
+			case ev.Rune() == 's':
+				go a.saveReport(report)
+				return nil
+			case ev.Rune() == 'm':
+				go a.emailReport(report)
+				return nil
+			}
+			return ev
+		})
+		a.Pages.AddPage(reportPage, tview.NewFlex().
+			AddItem(nil, 2, 0, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 1, 0, false).
+				AddItem(view, 0, 1, true).
+				AddItem(nil, 1, 0, false), 0, 1, true).
+			AddItem(nil, 2, 0, false), true, true)
+		a.SetFocus(view)
+	})
+}
+
+// saveReport writes the report as Markdown to the saved folder
+func (a *App) saveReport(report *services.EmailReport) {
+	base := config.DefaultSavedDir()
+	if err := os.MkdirAll(base, 0o750); err != nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Could not create saved folder")
+		return
+	}
+	file := filepath.Join(base, "report-"+report.Until.Format("20060102-150405")+".md")
+	if err := os.WriteFile(file, []byte(report.Markdown()), 0o600); err != nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Could not write file")
+		return
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, "💾 Report saved: "+file)
+}
+
+// emailReport sends the report to the active account's own address
+func (a *App) emailReport(report *services.EmailReport) {
+	me := a.getActiveAccountEmail()
+	if me == "" || a.emailService == nil {
+		a.GetErrorHandler().ShowError(a.ctx, "❌ Cannot email the report: account address unknown")
+		return
+	}
+	a.GetErrorHandler().ShowProgress(a.ctx, "📧 Emailing report…")
+	err := a.emailService.SendMessage(a.ctx, me, me, report.Title(), report.Markdown(), nil, nil)
+	a.GetErrorHandler().ClearProgress()
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error emailing report", err)
+		return
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, "📧 Report emailed to "+me)
+}
@@ -0,0 +1,22 @@
+package tui
+
+import "testing"
+
+func TestParseReportArgs(t *testing.T) {
+	opts, action, err := parseReportArgs(nil)
+	if err != nil || opts.Days != 7 || opts.Narrative || action != "show" {
+		t.Fatalf("defaults: got %+v %q %v", opts, action, err)
+	}
+	opts, action, err = parseReportArgs([]string{"14d", "AI", "email"})
+	if err != nil || opts.Days != 14 || !opts.Narrative || action != "email" {
+		t.Fatalf("got %+v %q %v", opts, action, err)
+	}
+	if _, action, _ = parseReportArgs([]string{"save", "30"}); action != "save" {
+		t.Fatalf("want save, got %q", action)
+	}
+	for _, bad := range [][]string{{"week"}, {"0"}, {"365"}} {
+		if _, _, err := parseReportArgs(bad); err == nil {
+			t.Errorf("%v: want error", bad)
+		}
+	}
+}
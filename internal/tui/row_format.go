@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/render"
+)
+
+// rowFormatWidth is the width a row format template can fill: the list minus the numbers column
+func (a *App) rowFormatWidth() int {
+	width := a.getListWidth()
+	if a.showMessageNumbers {
+		width -= len(fmt.Sprintf("%d", len(a.ids))) + 2 // numbers column plus separator
+	}
+	return width
+}
+
+// executeRowFormatCommand handles :rowformat — show the active template, try one for this
+// session (quote it to keep spacing), go back to the columns with off, or to the configured
+// template with reset
+func (a *App) executeRowFormatCommand(args []string) {
+	if len(args) == 0 {
+		if a.rowFormat == nil {
+			a.showInfo("📋 List shows columns; set display.row_format or :rowformat \"{date:>6} {from:20} {subject:*}\"")
+		} else {
+			a.showInfo("📋 Row format: " + a.rowFormatText)
+		}
+		return
+	}
+
+	tmpl := strings.Join(args, " ")
+	switch strings.ToLower(tmpl) {
+	case "off", "columns":
+		a.rowFormat = nil
+		a.rowFormatText = ""
+		a.showInfo("📋 List shows columns")
+	case "reset":
+		tmpl = a.Config.Display.RowFormat
+		if strings.TrimSpace(tmpl) == "" {
+			a.rowFormat = nil
+			a.rowFormatText = ""
+			a.showInfo("📋 No display.row_format configured; list shows columns")
+			break
+		}
+		fallthrough
+	default:
+		f, err := render.ParseRowFormat(tmpl)
+		if err != nil {
+			a.showError("❌ Row format: " + err.Error())
+			return
+		}
+		a.rowFormat = f
+		a.rowFormatText = tmpl
+		a.showInfo("📋 Row format: " + tmpl)
+	}
+	go a.QueueUpdateDraw(a.refreshTableDisplay)
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/render"
+)
+
+func TestMapEmailDataToResponsiveColumns_RowFormat(t *testing.T) {
+	f, err := render.ParseRowFormat("{date:>4} {subject:*}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &App{rowFormat: f}
+	config := []render.ColumnConfig{{Header: "Row", Expansion: 1}}
+
+	data := render.EmailColumnData{Columns: make([]render.ColumnCell, 8)}
+	data.Columns[2].Content = "Subject"
+	data.Columns[7].Content = "  3h Subject"
+	if got := a.mapEmailDataToResponsiveColumns(data, config, 0); got[0].Content != "  3h Subject" {
+		t.Errorf("row cell = %q, want the rendered row", got[0].Content)
+	}
+
+	// Rows without a rendered template (loading placeholders) fall back to the subject
+	data.Columns = data.Columns[:7]
+	if got := a.mapEmailDataToResponsiveColumns(data, config, 0); got[0].Content != "Subject" {
+		t.Errorf("row cell = %q, want the subject", got[0].Content)
+	}
+}
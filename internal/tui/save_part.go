@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// executeSavePartCommand handles :savepart — pick any MIME part of the current message to save
+func (a *App) executeSavePartCommand(args []string) {
+	if len(args) > 0 {
+		a.showError("Usage: savepart")
+		return
+	}
+	id := a.getCurrentMessageID()
+	if id == "" {
+		id = a.currentMessageID
+	}
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowProgress(a.ctx, "Loading the message structure...")
+		raw, err := a.messageClient(id).GetMessageRaw(id)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading the raw message", err)
+			return
+		}
+		root, err := gmail.ParseMIMETree(raw)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error reading the message structure", err)
+			return
+		}
+		a.QueueUpdateDraw(func() { a.openMIMEPartPicker(" 🧩 Message parts ", root.Flatten()) })
+	}()
+}
+
+// mimePartIcon picks the icon of a part in the MIME tree
+func mimePartIcon(p *gmail.MIMEPart) string {
+	switch {
+	case p.IsContainer():
+		return "🗂️"
+	case p.MimeType == "message/rfc822":
+		return "✉️"
+	case p.MimeType == "text/calendar":
+		return "📅"
+	case p.MimeType == "text/html", p.MimeType == gmail.AMPMimeType:
+		return "🌐"
+	case strings.HasPrefix(p.MimeType, "text/"):
+		return "📄"
+	case strings.HasPrefix(p.MimeType, "image/"):
+		return "🖼️"
+	}
+	return "📎"
+}
+
+// formatMIMEPartRow is the picker row of a part: indented by depth, type and filename, then
+// size, disposition and Content-ID
+func formatMIMEPartRow(p *gmail.MIMEPart) (string, string) {
+	main := strings.Repeat("  ", p.Depth) + mimePartIcon(p) + " " + p.MimeType
+	if p.Filename != "" {
+		main += "  " + p.Filename
+	}
+	if p.IsContainer() {
+		return main, fmt.Sprintf("%s%d part(s)", strings.Repeat("  ", p.Depth), len(p.Parts))
+	}
+	details := []string{formatFileSize(int64(len(p.Body)))}
+	if p.Disposition != "" {
+		details = append(details, p.Disposition)
+	}
+	if p.ContentID != "" {
+		details = append(details, "cid:"+p.ContentID)
+	}
+	return main, strings.Repeat("  ", p.Depth) + strings.Join(details, " · ")
+}
+
+// openMIMEPartPicker lists the MIME tree in the side panel: Enter saves the part to the download
+// folder, the attachment save key asks where
+func (a *App) openMIMEPartPicker(title string, parts []*gmail.MIMEPart) {
+	colors := a.GetComponentColors("attachments")
+	bgColor := colors.Background.Color()
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSecondaryTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+	for _, p := range parts {
+		main, secondary := formatMIMEPartRow(p)
+		list.AddItem(tview.Escape(main), tview.Escape(secondary), 0, nil)
+	}
+
+	selected := func() *gmail.MIMEPart {
+		i := list.GetCurrentItem()
+		if i < 0 || i >= len(parts) {
+			return nil
+		}
+		if parts[i].IsContainer() {
+			a.GetErrorHandler().ShowWarning(a.ctx, "That groups other parts — pick one of the parts under it")
+			return nil
+		}
+		return parts[i]
+	}
+	list.SetSelectedFunc(func(int, string, string, rune) {
+		if p := selected(); p != nil {
+			a.closeMIMEPartPicker()
+			go a.saveMIMEPart(p, filepath.Join(a.partDownloadDir(), p.SuggestedFilename()), false)
+		}
+	})
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyEscape {
+			a.closeMIMEPartPicker()
+			return nil
+		}
+		if a.matchesConfiguredKey(e, a.Keys.AttachmentSave) {
+			if p := selected(); p != nil {
+				a.promptSaveMIMEPart(p)
+			}
+			return nil
+		}
+		return e
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(title)
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(list, 0, 1, true)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(fmt.Sprintf(" Enter to save | %s to save as | Esc to close ", a.Keys.AttachmentSave))
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "attachments")
+	}
+	a.markFocus("labels")
+	a.setActivePicker(PickerMIMEParts)
+	a.SetFocus(list)
+}
+
+// closeMIMEPartPicker closes the parts panel and restores focus
+func (a *App) closeMIMEPartPicker() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
+
+// promptSaveMIMEPart replaces the parts panel with a path input for the part
+func (a *App) promptSaveMIMEPart(p *gmail.MIMEPart) {
+	colors := a.GetComponentColors("attachments")
+	pathInput := tview.NewInputField().
+		SetLabel("Save to: ").
+		SetText(filepath.Join(a.partDownloadDir(), p.SuggestedFilename())).
+		SetFieldWidth(0).
+		SetLabelColor(colors.Text.Color()).
+		SetFieldBackgroundColor(colors.Background.Color()).
+		SetFieldTextColor(colors.Text.Color())
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			a.closeSaveAsPanel()
+		case tcell.KeyEnter:
+			path := strings.TrimSpace(pathInput.GetText())
+			a.closeSaveAsPanel()
+			if path != "" {
+				go a.saveMIMEPart(p, path, true)
+			}
+		}
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(colors.Background.Color())
+	container.SetBorder(true)
+	container.SetTitle(fmt.Sprintf(" 💾 Save %s ", p.MimeType))
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(pathInput, 3, 0, true)
+	instructions := tview.NewTextView().
+		SetText("Press Enter to save | Esc to cancel").
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(colors.Accent.Color())
+	container.AddItem(instructions, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "attachments")
+	}
+	a.SetFocus(pathInput)
+	a.markFocus("labels")
+	a.setActivePicker(PickerMIMEParts)
+}
+
+// partDownloadDir is where parts are saved by default: the attachment download folder
+func (a *App) partDownloadDir() string {
+	_, _, _, _, _, _, _, _, _, _, attachmentService, _ := a.GetServices()
+	if attachmentService != nil {
+		if dir := attachmentService.GetDefaultDownloadPath(); dir != "" {
+			return dir
+		}
+	}
+	return config.DefaultSavedDir()
+}
+
+// saveMIMEPart writes a part's decoded content to path; overwrite is for an explicitly chosen
+// path, otherwise an existing file gets a numbered name beside it
+func (a *App) saveMIMEPart(p *gmail.MIMEPart, path string, overwrite bool) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !overwrite {
+		path = uniqueFilePath(path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error creating the folder", err)
+		return
+	}
+	if err := os.WriteFile(path, p.Body, 0o600); err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving the part", err)
+		return
+	}
+	a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("💾 Saved %s (%s) to %s", p.MimeType, formatFileSize(int64(len(p.Body))), path))
+}
+
+// uniqueFilePath returns path, or "name (2).ext", "name (3).ext"... when it already exists
+func uniqueFilePath(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", stem, i, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
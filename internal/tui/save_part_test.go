@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/gmail"
+)
+
+func TestUniqueFilePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invite.ics")
+	if got := uniqueFilePath(path); got != path {
+		t.Fatalf("free path changed: %q", got)
+	}
+	for _, name := range []string{"invite.ics", "invite (2).ics"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := uniqueFilePath(path), filepath.Join(dir, "invite (3).ics"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatMIMEPartRow(t *testing.T) {
+	p := &gmail.MIMEPart{Depth: 2, MimeType: "image/png", Filename: "logo.png", Disposition: "inline", ContentID: "logo", Body: make([]byte, 2048)}
+	main, secondary := formatMIMEPartRow(p)
+	if !strings.HasPrefix(main, "    🖼️ image/png") || !strings.Contains(main, "logo.png") {
+		t.Fatalf("main = %q", main)
+	}
+	if !strings.Contains(secondary, "inline") || !strings.Contains(secondary, "cid:logo") {
+		t.Fatalf("secondary = %q", secondary)
+	}
+	container := &gmail.MIMEPart{MimeType: "multipart/mixed", Parts: []*gmail.MIMEPart{p}}
+	if _, secondary := formatMIMEPartRow(container); secondary != "1 part(s)" {
+		t.Fatalf("container secondary = %q", secondary)
+	}
+}
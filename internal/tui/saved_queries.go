@@ -17,6 +17,7 @@ type queryItem struct {
 	category    string
 	query       string
 	useCount    int
+	shared      bool // from the shared pack (read-only)
 }
 
 // showSavedQueriesPicker displays the saved queries picker interface using prompts-style picker
@@ -83,6 +84,9 @@ func (a *App) showSavedQueriesPicker() {
 			if item.useCount > 0 {
 				display += fmt.Sprintf(" (used %d times)", item.useCount)
 			}
+			if item.shared {
+				display += " " + sharedPackMarker
+			}
 
 			// Capture variables for closure
 			queryID := item.id // int64
@@ -140,6 +144,7 @@ func (a *App) showSavedQueriesPicker() {
 				category:    q.Category,
 				query:       q.Query,
 				useCount:    q.UseCount,
+				shared:      q.Source != "",
 			})
 		}
 
@@ -240,7 +245,7 @@ func (a *App) showSavedQueriesPicker() {
 				a.labelsView = container
 				split.SetBackgroundColor(bgColor)
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "queries")
 			}
 
 			// Set focus and state (use "labels" for proper border highlighting)
@@ -288,6 +293,12 @@ func (a *App) executeQueryItem(item queryItem, queryService services.QueryServic
 		}
 	}()
 
+	// Templates prompt for their parameters first
+	if params := services.QueryParams(item.query); len(params) > 0 {
+		go a.promptQueryParams(item.name, item.query, params, queryService)
+		return
+	}
+
 	// Execute the query
 	go a.performSearch(item.query)
 
@@ -388,7 +399,10 @@ This search query will be saved:
 
 "%s"
 
-You can execute it later using the bookmarks picker (Q key) or the :bookmark command.`, query)
+You can execute it later using the bookmarks picker (Q key) or the :bookmark command.
+
+Turn it into a template by replacing values with {parameters}, e.g.
+from:{sender} newer_than:{days}d — running it will ask for each value.`, query)
 	queryView := tview.NewTextView()
 	queryView.SetText(queryPreview).
 		SetScrollable(true).
@@ -419,9 +433,23 @@ You can execute it later using the bookmarks picker (Q key) or the :bookmark com
 		nameInput.SetText(defaultName)
 	}
 
+	// Editable query (lets the user turn values into {parameters})
+	queryLabel := tview.NewTextView().SetText("🔍 Query:")
+	queryLabel.SetTextColor(a.GetComponentColors("saved_queries").Title.Color())
+	queryLabel.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
+
+	queryInput := tview.NewInputField()
+	queryInput.SetLabel("")
+	queryInput.SetText(query)
+	queryInput.SetFieldWidth(50)
+	queryInput.SetBorder(false)
+	queryInput.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
+	queryInput.SetFieldBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
+	queryInput.SetFieldTextColor(a.GetComponentColors("saved_queries").Text.Color())
+
 	// Instructions
 	instructions := tview.NewTextView().SetTextAlign(tview.AlignRight)
-	instructions.SetText("Enter to save | Esc to cancel")
+	instructions.SetText("Enter to save | Tab name/query | Esc to cancel")
 	instructions.SetTextColor(a.GetComponentColors("general").Text.Color())
 	instructions.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
 
@@ -436,9 +464,18 @@ You can execute it later using the bookmarks picker (Q key) or the :bookmark com
 	spacer.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
 	nameRow.AddItem(spacer, 0, 1, false) // Spacer takes remaining space
 
+	queryRow := tview.NewFlex().SetDirection(tview.FlexColumn)
+	queryRow.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
+	queryRow.AddItem(queryLabel, 17, 0, false)
+	queryRow.AddItem(queryInput, 50, 0, false)
+	querySpacer := tview.NewBox()
+	querySpacer.SetBackgroundColor(a.GetComponentColors("saved_queries").Background.Color())
+	queryRow.AddItem(querySpacer, 0, 1, false)
+
 	// Add items to container with proper proportions
 	container.AddItem(queryView, 0, 1, false)    // Query preview takes most space
 	container.AddItem(nameRow, 2, 0, false)      // Name label and input in same row
+	container.AddItem(queryRow, 2, 0, false)     // Editable query below the name
 	container.AddItem(instructions, 1, 0, false) // Instructions take minimal space
 
 	// Add to content split like Obsidian
@@ -448,19 +485,27 @@ You can execute it later using the bookmarks picker (Q key) or the :bookmark com
 		}
 		a.labelsView = container
 		split.AddItem(a.labelsView, 0, 1, true)
-		split.ResizeItem(a.labelsView, 0, 1)
+		a.showSidePanel(split, a.labelsView, "queries")
 	}
 
 	// Set focus and state (use "labels" for proper border highlighting)
 	a.markFocus("labels")
 	a.setActivePicker(PickerSavedQueries)
 
-	// Configure input handling
-	nameInput.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+	// Configure input handling (shared by the name and query fields)
+	saveInputCapture := func(e *tcell.EventKey) *tcell.EventKey {
 		if e.Key() == tcell.KeyEscape {
 			a.closeSaveQueryPanel()
 			return nil
 		}
+		if e.Key() == tcell.KeyTab || e.Key() == tcell.KeyBacktab {
+			if a.GetFocus() == nameInput {
+				a.SetFocus(queryInput)
+			} else {
+				a.SetFocus(nameInput)
+			}
+			return nil
+		}
 		if e.Key() == tcell.KeyEnter {
 			// Get name and save
 			name := strings.TrimSpace(nameInput.GetText())
@@ -470,12 +515,21 @@ You can execute it later using the bookmarks picker (Q key) or the :bookmark com
 				}()
 				return nil
 			}
+			savedQuery := strings.TrimSpace(queryInput.GetText())
+			if savedQuery == "" {
+				go func() {
+					a.GetErrorHandler().ShowWarning(a.ctx, "Query cannot be empty")
+				}()
+				return nil
+			}
 			// Perform save with default values
-			go a.performQuerySave(name, query, "", "general", queryService)
+			go a.performQuerySave(name, savedQuery, "", "general", queryService)
 			return nil
 		}
 		return e
-	})
+	}
+	nameInput.SetInputCapture(saveInputCapture)
+	queryInput.SetInputCapture(saveInputCapture)
 
 	// Container-level input capture for Escape
 	container.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
@@ -603,6 +657,12 @@ func (a *App) executeQueryByName(name string) {
 		// Record usage
 		_ = queryService.RecordQueryUsage(a.ctx, query.ID)
 
+		// Templates prompt for their parameters first
+		if params := services.QueryParams(query.Query); len(params) > 0 {
+			a.promptQueryParams(query.Name, query.Query, params, queryService)
+			return
+		}
+
 		// Execute query
 		a.performSearch(query.Query)
 
@@ -612,3 +672,148 @@ func (a *App) executeQueryByName(name string) {
 		}()
 	}()
 }
+
+// promptQueryParams loads the recent values of each template parameter and then shows the
+// parameter panel. Call from a goroutine.
+func (a *App) promptQueryParams(name, template string, params []string, queryService services.QueryService) {
+	recent := make(map[string][]string, len(params))
+	for _, p := range params {
+		values, err := queryService.GetRecentParamValues(a.ctx, p, 0)
+		if err != nil {
+			if a.logger != nil {
+				a.logger.Printf("saved query params: recent values for %q: %v", p, err)
+			}
+			continue
+		}
+		recent[p] = values
+	}
+	a.QueueUpdateDraw(func() {
+		a.showQueryParamsPanel(name, template, params, recent, queryService)
+	})
+}
+
+// showQueryParamsPanel asks for the values of a saved query template's parameters in the side
+// panel (same placement as the save query panel). Each field starts with the most recent value;
+// Up/Down cycle through older ones.
+func (a *App) showQueryParamsPanel(name, template string, params []string, recent map[string][]string, queryService services.QueryService) {
+	colors := a.GetComponentColors("saved_queries")
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(colors.Background.Color())
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(fmt.Sprintf(" 🧩 %s ", name))
+	container.SetTitleColor(colors.Title.Color())
+
+	preview := tview.NewTextView().SetWordWrap(true).SetScrollable(true)
+	preview.SetTextColor(colors.Text.Color())
+	preview.SetBackgroundColor(colors.Background.Color())
+
+	fields := make([]*tview.InputField, len(params))
+	recentIdx := make([]int, len(params))
+	values := func() map[string]string {
+		v := make(map[string]string, len(params))
+		for i, p := range params {
+			v[p] = fields[i].GetText()
+		}
+		return v
+	}
+	updatePreview := func() {
+		query := template
+		if expanded, err := services.ExpandQueryParams(template, values()); err == nil {
+			query = expanded
+		}
+		preview.SetText(fmt.Sprintf("🔍 QUERY TEMPLATE\n\n%s\n\nWill search:\n\n%s", template, query))
+	}
+
+	run := func() {
+		vals := values()
+		query, err := services.ExpandQueryParams(template, vals)
+		if err != nil {
+			for i, f := range fields {
+				if strings.TrimSpace(f.GetText()) == "" {
+					a.SetFocus(fields[i])
+					break
+				}
+			}
+			go a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Fill in all parameters: %v", err))
+			return
+		}
+		a.closeSavedQueriesPicker()
+		go func() {
+			if err := queryService.RecordParamValues(a.ctx, vals); err != nil && a.logger != nil {
+				a.logger.Printf("saved query params: record values: %v", err)
+			}
+		}()
+		go a.performSearch(query)
+		go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("🔍 Executing: %s", name))
+	}
+
+	container.AddItem(preview, 0, 1, false)
+	for i, p := range params {
+		i := i
+		field := tview.NewInputField().SetLabel(fmt.Sprintf("{%s} ", p)).SetFieldWidth(0)
+		field.SetBackgroundColor(colors.Background.Color())
+		field.SetFieldBackgroundColor(colors.Background.Color())
+		field.SetFieldTextColor(colors.Text.Color())
+		field.SetLabelColor(colors.Title.Color())
+		field.SetPlaceholderTextColor(a.getHintColor())
+		if vals := recent[p]; len(vals) > 0 {
+			field.SetText(vals[0])
+			field.SetPlaceholder("recent: " + strings.Join(vals, ", "))
+		} else {
+			field.SetPlaceholder(fmt.Sprintf("value for {%s}", p))
+		}
+		field.SetChangedFunc(func(string) { updatePreview() })
+		field.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+			switch e.Key() {
+			case tcell.KeyEscape:
+				a.closeSavedQueriesPicker()
+				return nil
+			case tcell.KeyEnter:
+				run()
+				return nil
+			case tcell.KeyTab:
+				a.SetFocus(fields[(i+1)%len(fields)])
+				return nil
+			case tcell.KeyBacktab:
+				a.SetFocus(fields[(i-1+len(fields))%len(fields)])
+				return nil
+			case tcell.KeyUp, tcell.KeyDown:
+				vals := recent[params[i]]
+				if len(vals) == 0 {
+					return nil
+				}
+				if e.Key() == tcell.KeyDown {
+					recentIdx[i] = (recentIdx[i] + 1) % len(vals)
+				} else {
+					recentIdx[i] = (recentIdx[i] - 1 + len(vals)) % len(vals)
+				}
+				fields[i].SetText(vals[recentIdx[i]])
+				return nil
+			}
+			return e
+		})
+		fields[i] = field
+		container.AddItem(field, 1, 0, i == 0)
+	}
+	updatePreview()
+
+	instructions := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	instructions.SetText("Enter run | Tab next | ↑/↓ recent values | Esc cancel")
+	instructions.SetTextColor(a.GetComponentColors("general").Text.Color())
+	instructions.SetBackgroundColor(colors.Background.Color())
+	container.AddItem(instructions, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "queries")
+	}
+	a.markFocus("labels")
+	a.setActivePicker(PickerSavedQueries)
+	a.SetFocus(fields[0])
+}
@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// searchCheatSheetPage is the Pages name of the operators overlay
+const searchCheatSheetPage = "searchCheatSheet"
+
+// searchCheatEntry is one row of the search operators cheat-sheet. insert is the text added
+// to the query when the row is selected; operators ending in ':' leave the cursor after the
+// colon so the value can be typed next.
+type searchCheatEntry struct {
+	insert      string
+	description string
+	example     string
+}
+
+// searchCheatSheetOperators mirrors docs/GMAIL_SEARCH_REFERENCE.md.
+var searchCheatSheetOperators = []searchCheatEntry{
+	{"from:", "Sender", "from:ana@example.com"},
+	{"to:", "Recipient", "to:team@example.com"},
+	{"cc:", "Carbon copy recipient", "cc:boss@example.com"},
+	{"bcc:", "Blind carbon copy recipient", "bcc:me@example.com"},
+	{"subject:", "Words in the subject", "subject:invoice"},
+	{"label:", "Has a label", "label:work"},
+	{"category:", "Inbox category", "category:social"},
+	{"has:attachment", "Has any attachment", "has:attachment"},
+	{"filename:", "Attachment name or type", "filename:pdf"},
+	{"has:document", "Google Docs attachment", "has:document"},
+	{"has:calendar", "Calendar invitation", "has:calendar"},
+	{"has:youtube", "Contains YouTube links", "has:youtube"},
+	{"is:unread", "Unread messages", "is:unread"},
+	{"is:read", "Read messages", "is:read"},
+	{"is:starred", "Starred messages", "is:starred"},
+	{"is:important", "Marked important", "is:important"},
+	{"in:inbox", "In the inbox", "in:inbox"},
+	{"in:sent", "Sent mail", "in:sent"},
+	{"in:archive", "Archived mail", "in:archive"},
+	{"in:spam", "Spam folder", "in:spam"},
+	{"in:trash", "Trash folder", "in:trash"},
+	{"in:anywhere", "Including spam and trash", "in:anywhere"},
+	{"after:", "On or after a date (YYYY/MM/DD)", "after:2025/05/01"},
+	{"before:", "Before a date (YYYY/MM/DD)", "before:2025/01/01"},
+	{"newer_than:", "Newer than (d, m, y)", "newer_than:7d"},
+	{"older_than:", "Older than (d, m, y)", "older_than:1y"},
+	{"larger:", "Larger than a size", "larger:10M"},
+	{"smaller:", "Smaller than a size", "smaller:5M"},
+	{"list:", "Mailing list", "list:news@example.com"},
+	{"OR", "Either criterion (uppercase)", "from:ana OR from:pepe"},
+	{"-", "Exclude a word or operator", "-subject:ads"},
+	{"\"\"", "Exact phrase", "\"march invoice\""},
+	{"()", "Group terms", "(invoice receipt)"},
+}
+
+// searchLabelToken returns the label: operator for a label name, quoting names with spaces
+// (same form the advanced search form produces).
+func searchLabelToken(name string) string {
+	if strings.ContainsAny(name, " \t") {
+		return "label:\"" + name + "\""
+	}
+	return "label:" + name
+}
+
+// insertSearchToken appends token to query, separated by a single space. Operators that
+// expect a value (ending in ':') are inserted without a trailing space so the value can be
+// typed right after; complete terms get a trailing space.
+func insertSearchToken(query, token string) string {
+	out := query
+	if strings.TrimSpace(out) != "" && !strings.HasSuffix(out, " ") && !strings.HasSuffix(out, "(") {
+		out += " "
+	}
+	out += token
+	if !strings.HasSuffix(token, ":") && token != "-" {
+		out += " "
+	}
+	return out
+}
+
+// toggleSearchCheatSheet shows (or hides, when already visible) the search operators overlay
+// on top of the search input. Selecting an entry inserts it into the query.
+func (a *App) toggleSearchCheatSheet() {
+	if a.Pages.HasPage(searchCheatSheetPage) {
+		a.closeSearchCheatSheet()
+		return
+	}
+	input, ok := a.views["searchInput"].(*tview.InputField)
+	if !ok {
+		return
+	}
+
+	colors := a.GetComponentColors("search")
+	bgColor := colors.Background.Color()
+
+	filter := tview.NewInputField().
+		SetLabel("🔎 ").
+		SetPlaceholder("filter operators and labels…").
+		SetFieldWidth(0)
+	a.ConfigureInputFieldTheme(filter, "overlay")
+	filter.SetBackgroundColor(bgColor)
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSecondaryTextColor(a.getHintColor())
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+	list.SetSelectedTextColor(colors.Background.Color())
+
+	entries := append([]searchCheatEntry{}, searchCheatSheetOperators...)
+	var visible []searchCheatEntry
+
+	insert := func(entry searchCheatEntry) {
+		text := insertSearchToken(input.GetText(), entry.insert)
+		a.closeSearchCheatSheet()
+		input.SetText(text)
+	}
+
+	reload := func(q string) {
+		q = strings.ToLower(strings.TrimSpace(q))
+		list.Clear()
+		visible = visible[:0]
+		for _, e := range entries {
+			if q != "" && !strings.Contains(strings.ToLower(e.insert+" "+e.description+" "+e.example), q) {
+				continue
+			}
+			visible = append(visible, e)
+			entry := e
+			list.AddItem(fmt.Sprintf("%-16s %s", entry.insert, entry.description), "   e.g. "+entry.example, 0, func() {
+				insert(entry)
+			})
+		}
+	}
+
+	filter.SetChangedFunc(func(text string) { reload(text) })
+	filter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter && len(visible) > 0 {
+			insert(visible[list.GetCurrentItem()])
+		}
+	})
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter insert | type to filter | F1/Esc close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+
+	box := tview.NewFlex().SetDirection(tview.FlexRow)
+	box.SetBorder(true).
+		SetTitle(" 📖 Search operators ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color()).
+		SetBackgroundColor(bgColor)
+	box.AddItem(filter, 1, 0, true)
+	box.AddItem(list, 0, 1, false)
+	box.AddItem(footer, 1, 0, false)
+
+	box.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape || a.matchesConfiguredKey(ev, a.Keys.SearchCheatSheet) {
+			a.closeSearchCheatSheet()
+			return nil
+		}
+		switch ev.Key() {
+		case tcell.KeyDown, tcell.KeyUp, tcell.KeyPgDn, tcell.KeyPgUp:
+			if a.GetFocus() == filter {
+				a.SetFocus(list)
+			}
+		case tcell.KeyTab:
+			if a.GetFocus() == filter {
+				a.SetFocus(list)
+			} else {
+				a.SetFocus(filter)
+			}
+			return nil
+		}
+		return ev
+	})
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyUp && list.GetCurrentItem() == 0 {
+			a.SetFocus(filter)
+			return nil
+		}
+		return ev
+	})
+
+	// Center the overlay over the main layout
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(box, 0, 4, true).
+			AddItem(nil, 0, 1, false), 72, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	reload("")
+	a.Pages.AddPage(searchCheatSheetPage, overlay, true, true)
+	a.SetFocus(filter)
+
+	// Append the account's user labels in the background
+	_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
+	if labelService == nil {
+		return
+	}
+	go func() {
+		labels, err := labelService.ListLabels(a.ctx)
+		if err != nil {
+			if a.logger != nil {
+				a.logger.Printf("search cheat-sheet: ListLabels error=%v", err)
+			}
+			return
+		}
+		names := make([]string, 0, len(labels))
+		for _, l := range labels {
+			if l.Type == "system" || !a.labelVisibility.InPickers(l) {
+				continue
+			}
+			names = append(names, l.Name)
+		}
+		sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+		a.QueueUpdateDraw(func() {
+			if !a.Pages.HasPage(searchCheatSheetPage) {
+				return
+			}
+			for _, name := range names {
+				entries = append(entries, searchCheatEntry{insert: searchLabelToken(name), description: "🔖 Label", example: searchLabelToken(name)})
+			}
+			reload(filter.GetText())
+		})
+	}()
+}
+
+// closeSearchCheatSheet removes the operators overlay and returns focus to the search input
+func (a *App) closeSearchCheatSheet() {
+	if a.Pages.HasPage(searchCheatSheetPage) {
+		a.Pages.RemovePage(searchCheatSheetPage)
+	}
+	if input, ok := a.views["searchInput"].(*tview.InputField); ok {
+		a.SetFocus(input)
+	}
+}
+
+// executeOperatorsCommand handles :operators, opening Gmail search with the cheat-sheet shown
+func (a *App) executeOperatorsCommand(args []string) {
+	if _, ok := a.views["searchInput"].(*tview.InputField); !ok {
+		a.openSearchOverlay("remote")
+	}
+	a.toggleSearchCheatSheet()
+}
@@ -0,0 +1,30 @@
+package tui
+
+import "testing"
+
+func TestInsertSearchToken(t *testing.T) {
+	cases := []struct {
+		query, token, want string
+	}{
+		{"", "from:", "from:"},
+		{"invoice", "from:", "invoice from:"},
+		{"invoice ", "is:unread", "invoice is:unread "},
+		{"(", "from:", "(from:"},
+		{"report", "-", "report -"},
+		{"  ", "OR", "  OR "},
+	}
+	for _, c := range cases {
+		if got := insertSearchToken(c.query, c.token); got != c.want {
+			t.Errorf("insertSearchToken(%q, %q) = %q, want %q", c.query, c.token, got, c.want)
+		}
+	}
+}
+
+func TestSearchLabelToken(t *testing.T) {
+	if got := searchLabelToken("work"); got != "label:work" {
+		t.Errorf("got %q", got)
+	}
+	if got := searchLabelToken("Team Updates"); got != `label:"Team Updates"` {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// searchChipsAddLabel is the trailing pseudo-chip that reopens the advanced search form
+const searchChipsAddLabel = "＋ add filter"
+
+// splitSearchChips splits a Gmail query into refinement chips. Quoted phrases and parenthesized
+// groups stay in one chip, and OR joins its neighbours into a single chip so removing a chip
+// never leaves a dangling operator behind.
+func splitSearchChips(query string) []string {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	depth := 0
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case !inQuote && r == '(':
+			depth++
+		case !inQuote && r == ')' && depth > 0:
+			depth--
+		case !inQuote && depth == 0 && unicode.IsSpace(r):
+			flush()
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	flush()
+
+	chips := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] == "OR" && len(chips) > 0 && i+1 < len(tokens) {
+			chips[len(chips)-1] += " OR " + tokens[i+1]
+			i++
+			continue
+		}
+		chips = append(chips, tokens[i])
+	}
+	return chips
+}
+
+// queryWithoutChip rebuilds the query from chips, leaving out the chip at index skip
+func queryWithoutChip(chips []string, skip int) string {
+	kept := make([]string, 0, len(chips))
+	for i, c := range chips {
+		if i != skip {
+			kept = append(kept, c)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// showSearchChips renders the chips of a finished remote search above the message list.
+// Must run on the UI goroutine.
+func (a *App) showSearchChips(query string) {
+	bar, ok := a.views["searchChips"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	a.search.chips = splitSearchChips(query)
+	a.search.chipSel = 0
+	a.renderSearchChips()
+	if mainFlex, ok := a.views["mainFlex"].(*tview.Flex); ok {
+		mainFlex.ResizeItem(bar, 1, 0)
+	}
+}
+
+// hideSearchChips collapses the chips bar. Must run on the UI goroutine.
+func (a *App) hideSearchChips() {
+	a.search.chips = nil
+	a.search.chipSel = 0
+	bar, ok := a.views["searchChips"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	bar.Clear()
+	if mainFlex, ok := a.views["mainFlex"].(*tview.Flex); ok {
+		mainFlex.ResizeItem(bar, 0, 0)
+	}
+	if a.focus.is("search_chips") {
+		a.focusList()
+	}
+}
+
+// searchChipsVisible reports whether the chips bar is currently shown
+func (a *App) searchChipsVisible() bool {
+	bar, ok := a.views["searchChips"].(*tview.TextView)
+	if !ok {
+		return false
+	}
+	_, _, w, h := bar.GetRect()
+	return w > 0 && h > 0 && len(a.search.chips) > 0
+}
+
+// renderSearchChips redraws the chips; the selected chip is highlighted while the bar has focus
+func (a *App) renderSearchChips() {
+	bar, ok := a.views["searchChips"].(*tview.TextView)
+	if !ok {
+		return
+	}
+	colors := a.GetComponentColors("search")
+	focused := a.focus.is("search_chips")
+	var b strings.Builder
+	b.WriteString(" 🔍 ")
+	items := append(append([]string{}, a.search.chips...), searchChipsAddLabel)
+	for i, chip := range items {
+		label := tview.Escape(chip)
+		if i < len(a.search.chips) {
+			label += " ✕"
+		}
+		if focused && i == a.search.chipSel {
+			fmt.Fprintf(&b, "[%s:%s:b] %s [-:-:-] ", colors.Background.String(), colors.Accent.String(), label)
+		} else {
+			fmt.Fprintf(&b, "[%s:%s] %s [-:-] ", colors.Text.String(), colors.Border.String(), label)
+		}
+	}
+	if focused {
+		fmt.Fprintf(&b, " %s←/→ select · d/Enter remove · a add · Esc back%s", a.GetColorTag("secondary"), a.GetEndTag())
+	}
+	bar.SetText(b.String())
+}
+
+// focusSearchChips moves focus to the chips bar of the active remote search
+func (a *App) focusSearchChips() {
+	bar, ok := a.views["searchChips"].(*tview.TextView)
+	if !ok || !a.searchChipsVisible() {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "No Gmail search results to refine")
+		return
+	}
+	a.SetFocus(bar)
+	a.markFocus("search_chips")
+	a.renderSearchChips()
+}
+
+// removeSearchChip drops one chip and reruns the narrowed query; removing the last chip leaves search
+func (a *App) removeSearchChip(idx int) {
+	if idx < 0 || idx >= len(a.search.chips) {
+		return
+	}
+	query := queryWithoutChip(a.search.chips, idx)
+	if strings.TrimSpace(query) == "" {
+		a.hideSearchChips()
+		go a.exitSearch()
+		return
+	}
+	a.focusList()
+	if a.crossSearch.isActive() {
+		go a.performCrossAccountSearch(query)
+		return
+	}
+	go a.performSearch(query)
+}
+
+// openSearchChipsAdvanced reopens the advanced search form prefilled with the current query
+func (a *App) openSearchChipsAdvanced() {
+	query := strings.Join(a.search.chips, " ")
+	a.openAdvancedSearchFormWithQuery(query)
+}
+
+// handleSearchChipsKey handles the keys of the focused chips bar, called from the global input
+// capture; keys it does not use are returned for normal handling
+func (a *App) handleSearchChipsKey(ev *tcell.EventKey) *tcell.EventKey {
+	last := len(a.search.chips) // index of the "add filter" pseudo-chip
+	switch {
+	case ev.Key() == tcell.KeyEscape:
+		a.focusList()
+		a.renderSearchChips()
+		return nil
+	case ev.Key() == tcell.KeyTab || ev.Key() == tcell.KeyBacktab:
+		a.cycleFocus(ev.Key() == tcell.KeyTab)
+		a.renderSearchChips()
+		return nil
+	case ev.Key() == tcell.KeyLeft || ev.Rune() == 'h':
+		if a.search.chipSel > 0 {
+			a.search.chipSel--
+		}
+		a.renderSearchChips()
+		return nil
+	case ev.Key() == tcell.KeyRight || ev.Rune() == 'l':
+		if a.search.chipSel < last {
+			a.search.chipSel++
+		}
+		a.renderSearchChips()
+		return nil
+	case ev.Rune() == 'a':
+		a.openSearchChipsAdvanced()
+		return nil
+	case ev.Key() == tcell.KeyEnter:
+		if a.search.chipSel == last {
+			a.openSearchChipsAdvanced()
+			return nil
+		}
+		a.removeSearchChip(a.search.chipSel)
+		return nil
+	case ev.Rune() == 'd' || ev.Key() == tcell.KeyBackspace || ev.Key() == tcell.KeyBackspace2 || ev.Key() == tcell.KeyDelete:
+		a.removeSearchChip(a.search.chipSel)
+		return nil
+	}
+	return ev
+}
+
+// executeRefineCommand handles :refine [add]
+func (a *App) executeRefineCommand(args []string) {
+	if len(args) > 0 && strings.ToLower(args[0]) == "add" {
+		if !a.searchChipsVisible() {
+			a.openAdvancedSearchForm()
+			return
+		}
+		a.openSearchChipsAdvanced()
+		return
+	}
+	a.focusSearchChips()
+	if a.focus.is("search_chips") {
+		a.cmd.focusOverride = "keep"
+	}
+}
+
+// advancedSearchPrefill is a query split back into the advanced search form fields. Anything the
+// form cannot represent one-to-one is kept verbatim in words ("Has the words" is passed through).
+type advancedSearchPrefill struct {
+	from, to, subject, scope string
+	words                    string
+	hasAttachment            bool
+}
+
+// parseAdvancedSearchPrefill maps a query onto the advanced search form fields
+func parseAdvancedSearchPrefill(query string) advancedSearchPrefill {
+	var p advancedSearchPrefill
+	var rest []string
+	for _, tok := range splitSearchChips(query) {
+		lower := strings.ToLower(tok)
+		simple := !strings.Contains(tok, " OR ")
+		switch {
+		case simple && p.from == "" && strings.HasPrefix(lower, "from:"):
+			p.from = tok[len("from:"):]
+		case simple && p.to == "" && strings.HasPrefix(lower, "to:"):
+			p.to = tok[len("to:"):]
+		case simple && p.subject == "" && strings.HasPrefix(lower, "subject:") && !strings.HasPrefix(tok[len("subject:"):], "("):
+			p.subject = strings.Trim(tok[len("subject:"):], "\"")
+		case lower == "has:attachment":
+			p.hasAttachment = true
+		case simple && p.scope == "" && (strings.HasPrefix(lower, "in:") || strings.HasPrefix(lower, "is:") ||
+			strings.HasPrefix(lower, "category:") || strings.HasPrefix(lower, "label:")):
+			p.scope = tok
+		default:
+			rest = append(rest, tok)
+		}
+	}
+	p.words = strings.Join(rest, " ")
+	return p
+}
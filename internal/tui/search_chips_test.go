@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/derailed/tcell/v2"
+)
+
+func TestSplitSearchChips(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"from:ana newer_than:7d", []string{"from:ana", "newer_than:7d"}},
+		{`subject:"march invoice" is:unread`, []string{`subject:"march invoice"`, "is:unread"}},
+		{"(invoice receipt) -in:spam", []string{"(invoice receipt)", "-in:spam"}},
+		{"from:ana OR from:pepe has:attachment", []string{"from:ana OR from:pepe", "has:attachment"}},
+		{"  report  ", []string{"report"}},
+		{"", []string{}},
+	}
+	for _, c := range cases {
+		if got := splitSearchChips(c.query); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splitSearchChips(%q) = %q, want %q", c.query, got, c.want)
+		}
+	}
+}
+
+func TestQueryWithoutChip(t *testing.T) {
+	chips := []string{"from:ana", `subject:"a b"`, "newer_than:7d"}
+	if got := queryWithoutChip(chips, 1); got != "from:ana newer_than:7d" {
+		t.Errorf("got %q", got)
+	}
+	if got := queryWithoutChip([]string{"from:ana"}, 0); got != "" {
+		t.Errorf("removing the only chip should leave an empty query, got %q", got)
+	}
+}
+
+func TestParseAdvancedSearchPrefill(t *testing.T) {
+	p := parseAdvancedSearchPrefill(`from:ana to:bob subject:"q3 report" label:work has:attachment newer_than:7d -in:spam`)
+	want := advancedSearchPrefill{
+		from:          "ana",
+		to:            "bob",
+		subject:       "q3 report",
+		scope:         "label:work",
+		words:         "newer_than:7d -in:spam",
+		hasAttachment: true,
+	}
+	if p != want {
+		t.Fatalf("got %+v, want %+v", p, want)
+	}
+
+	// OR chains and repeated operators are not representable by single fields: keep them verbatim
+	p = parseAdvancedSearchPrefill("from:ana OR from:pepe from:x")
+	if p.from != "x" || p.words != "from:ana OR from:pepe" {
+		t.Fatalf("got %+v", p)
+	}
+}
+
+func TestHandleSearchChipsKey_PassesUnusedKeys(t *testing.T) {
+	a := &App{}
+	a.search.chips = []string{"from:alice", "is:unread"}
+
+	if got := a.handleSearchChipsKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone)); got != nil || a.search.chipSel != 1 {
+		t.Fatalf("right: got %v, chipSel %d", got, a.search.chipSel)
+	}
+	for _, r := range []rune{':', '/', 'q'} {
+		ev := tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+		if got := a.handleSearchChipsKey(ev); got != ev {
+			t.Fatalf("%q must pass through, got %v", r, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tview"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// searchPageSize is the number of results per remote search page (matches performSearch)
+const searchPageSize = 50
+
+// formatApproxCount renders an estimate with thousands separators: 1240 -> "1,240"
+func formatApproxCount(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	if n < 0 {
+		return s
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatSearchResultsTitle builds the list title of a remote search
+func formatSearchResultsTitle(display string, loaded int, estimate int64, estimated bool, page int) string {
+	var b strings.Builder
+	if estimated && estimate >= int64(loaded) {
+		fmt.Fprintf(&b, " 🔍 ~%s results (%d loaded)", formatApproxCount(estimate), loaded)
+	} else {
+		fmt.Fprintf(&b, " 🔍 Search Results (%d)", loaded)
+	}
+	if page > 1 {
+		fmt.Fprintf(&b, " · from page %d", page)
+	}
+	fmt.Fprintf(&b, " — %s ", display)
+	return b.String()
+}
+
+// searchResultsTitle returns the title for the current remote search results
+func (a *App) searchResultsTitle(display string) string {
+	est, ok := a.footer.get()
+	return formatSearchResultsTitle(display, len(a.ids), est, ok, a.search.page)
+}
+
+// refreshSearchResultsTitle re-titles the list of a finished remote search (e.g. when the estimate
+// arrives). Must run on the UI goroutine.
+func (a *App) refreshSearchResultsTitle() {
+	if a.search.Mode() != "remote" || a.crossSearch.isActive() || len(a.search.chips) == 0 {
+		return
+	}
+	if table, ok := a.views["list"].(*tview.Table); ok {
+		table.SetTitle(a.searchResultsTitle(strings.Join(a.search.chips, " ")))
+	}
+}
+
+// executePageCommand handles :page N — jump to result page N of the current Gmail search
+func (a *App) executePageCommand(args []string) {
+	if len(args) != 1 {
+		a.showError("Usage: page <number>")
+		return
+	}
+	page, err := strconv.Atoi(args[0])
+	if err != nil || page < 1 {
+		a.showError("Usage: page <number> (positive number)")
+		return
+	}
+	if a.search.Mode() != "remote" || a.crossSearch.isActive() {
+		a.showError("Jump to page works on Gmail search results")
+		return
+	}
+	if a.IsThreadingEnabled() && a.GetCurrentThreadViewMode() == ThreadViewThread {
+		a.showError("Jump to page not available in threading mode")
+		return
+	}
+	go a.jumpToSearchPage(page)
+}
+
+// jumpToSearchPage replaces the list with page N of the current search. Pages before it are skipped
+// by token only (no metadata), so deep pages of large result sets load quickly.
+func (a *App) jumpToSearchPage(page int) {
+	if a.IsMessagesLoading() {
+		go a.GetErrorHandler().ShowInfo(a.ctx, "Already loading…")
+		return
+	}
+	a.SetMessagesLoading(true)
+	defer a.SetMessagesLoading(false)
+
+	query := a.search.Query()
+	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("Jumping to page %d…", page))
+	token := ""
+	if page > 1 {
+		t, err := a.Client.PageTokenAfter(query, int64(page-1)*searchPageSize)
+		if err != nil {
+			a.GetErrorHandler().ClearProgress()
+			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Cannot jump to page %d: %v", page, err))
+			return
+		}
+		token = t
+	}
+	messages, next, err := a.Client.SearchMessagesPage(query, searchPageSize, token)
+	if err != nil {
+		a.GetErrorHandler().ClearProgress()
+		a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Search error: %v", err))
+		return
+	}
+	ids := make([]string, len(messages))
+	for i, m := range messages {
+		ids[i] = m.Id
+	}
+	detailed, err := a.Client.GetMessagesMetadataParallel(ids, 10)
+	if err != nil {
+		a.GetErrorHandler().ClearProgress()
+		a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Error loading search results: %v", err))
+		return
+	}
+	meta := make([]*gmailapi.Message, 0, len(detailed))
+	ids = ids[:0]
+	for _, m := range detailed {
+		if m != nil {
+			meta = append(meta, m)
+			ids = append(ids, m.Id)
+		}
+	}
+	a.GetErrorHandler().ClearProgress()
+
+	a.QueueUpdateDraw(func() {
+		// The user may have left the search while the pages were skipped
+		if a.search.Mode() != "remote" || a.search.Query() != query {
+			return
+		}
+		a.SetMessageIDs(ids)
+		a.messagesMeta = meta
+		a.nextPageToken = next
+		a.search.page = page
+		a.refreshTableDisplay()
+		a.refreshSearchResultsTitle()
+		if table, ok := a.views["list"].(*tview.Table); ok && len(ids) > 0 {
+			table.Select(1, 0)
+			a.SetCurrentMessageID(ids[0])
+			go a.showMessageWithoutFocus(ids[0])
+		}
+		a.SetFocus(a.views["list"])
+		a.markFocus("list")
+	})
+}
@@ -0,0 +1,32 @@
+package tui
+
+import "testing"
+
+func TestFormatApproxCount(t *testing.T) {
+	cases := map[int64]string{0: "0", 999: "999", 1240: "1,240", 1000000: "1,000,000", 12345: "12,345"}
+	for n, want := range cases {
+		if got := formatApproxCount(n); got != want {
+			t.Errorf("formatApproxCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestFormatSearchResultsTitle(t *testing.T) {
+	cases := []struct {
+		loaded    int
+		estimate  int64
+		estimated bool
+		page      int
+		want      string
+	}{
+		{50, 0, false, 0, " 🔍 Search Results (50) — from:ana "},
+		{50, 1240, true, 0, " 🔍 ~1,240 results (50 loaded) — from:ana "},
+		{50, 1240, true, 7, " 🔍 ~1,240 results (50 loaded) · from page 7 — from:ana "},
+		{50, 10, true, 0, " 🔍 Search Results (50) — from:ana "},
+	}
+	for _, c := range cases {
+		if got := formatSearchResultsTitle("from:ana", c.loaded, c.estimate, c.estimated, c.page); got != c.want {
+			t.Errorf("got %q, want %q", got, c.want)
+		}
+	}
+}
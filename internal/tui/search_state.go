@@ -17,6 +17,13 @@ type searchState struct {
 	query       string // current query
 	localFilter string // event-loop only
 
+	// Refinement chips of the last remote search and the highlighted chip (event-loop only).
+	chips   []string
+	chipSel int
+
+	// 1-based result page the remote search list starts at (:page N); 0 = first page.
+	page int
+
 	// Local-filter base snapshot (event-loop only).
 	baseIDs           []string
 	baseMessagesMeta  []*gmailapi.Message
@@ -57,6 +64,7 @@ func (s *searchState) clear() {
 	s.mode = ""
 	s.query = ""
 	s.localFilter = ""
+	s.page = 0
 }
 
 // captureSnapshot stores an independent copy of the current inbox view as the local-filter base.
@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// bindSenderOverrides (re)creates the sender display override service for the active account and
+// loads its overrides into the list renderer
+func (a *App) bindSenderOverrides() {
+	if a.dbStore == nil {
+		return
+	}
+	svc := services.NewSenderOverrideService(db.NewSenderOverrideStore(a.dbStore))
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.senderOverrideService = svc
+	go a.reloadSenderDisplays(true)
+}
+
+// reloadSenderDisplays hands the overrides to the renderer and redraws the list and the open
+// message with them
+func (a *App) reloadSenderDisplays(redraw bool) {
+	if a.senderOverrideService == nil || a.emailRenderer == nil {
+		return
+	}
+	displays, err := a.senderOverrideService.Displays(a.ctx)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("sender overrides: %v", err)
+		}
+		return
+	}
+	a.emailRenderer.SetSenderDisplays(displays)
+	if !redraw {
+		return
+	}
+	a.QueueUpdateDraw(func() {
+		a.refreshTableDisplay()
+	})
+	if id := a.GetCurrentMessageID(); id != "" {
+		a.refreshMessageContent(id)
+	}
+}
+
+// senderCommand is a parsed :sender invocation
+type senderCommand struct {
+	addr   string // "" = the sender of the current message
+	action string // list, name, emoji, color or remove
+	value  string
+}
+
+// parseSenderCommand reads ':sender [<email>] = <name> | emoji <e> | color <c> | remove'; no
+// arguments lists the overrides
+func parseSenderCommand(args []string) (senderCommand, error) {
+	var cmd senderCommand
+	if len(args) == 0 {
+		cmd.action = "list"
+		return cmd, nil
+	}
+	if strings.Contains(args[0], "@") && !strings.HasPrefix(args[0], "=") {
+		cmd.addr, args = args[0], args[1:]
+	}
+	usage := fmt.Errorf("usage: sender [email] = <name> | emoji <emoji> | color <%s> | remove", strings.Join(render.SenderColors(), "|"))
+	if len(args) == 0 {
+		return cmd, usage
+	}
+	rest := strings.TrimSpace(strings.Join(args[1:], " "))
+	switch strings.ToLower(args[0]) {
+	case "name":
+		cmd.action, cmd.value = "name", rest
+	case "emoji", "color", "colour":
+		if rest == "" {
+			return cmd, usage
+		}
+		cmd.action, cmd.value = strings.Replace(strings.ToLower(args[0]), "colour", "color", 1), rest
+		if strings.EqualFold(rest, "none") || rest == "-" {
+			cmd.value = ""
+		}
+	case "remove", "rm":
+		cmd.action = "remove"
+	default:
+		if !strings.HasPrefix(args[0], "=") {
+			return cmd, usage
+		}
+		cmd.action = "name"
+		cmd.value = strings.TrimSpace(strings.TrimPrefix(strings.Join(args, " "), "="))
+	}
+	return cmd, nil
+}
+
+// executeSenderCommand handles :sender — set how a sender is shown in the list and the header
+func (a *App) executeSenderCommand(args []string) {
+	if a.senderOverrideService == nil {
+		a.showError("Sender overrides not available (no local database)")
+		return
+	}
+	cmd, err := parseSenderCommand(args)
+	if err != nil {
+		a.showError(strings.ToUpper(err.Error()[:1]) + err.Error()[1:])
+		return
+	}
+	if cmd.action == "list" {
+		go a.showSenderOverrides()
+		return
+	}
+	id := a.getCurrentMessageID()
+	if cmd.addr == "" && id == "" {
+		a.showError("❌ No message selected (or give the sender's address)")
+		return
+	}
+	go func() {
+		addr := cmd.addr
+		if addr == "" {
+			addr = a.currentSenderAddress(id)
+			if addr == "" {
+				a.GetErrorHandler().ShowError(a.ctx, "❌ Could not determine sender")
+				return
+			}
+		}
+		if cmd.action == "remove" {
+			if err := a.senderOverrideService.Remove(a.ctx, addr); err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing the sender override", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("%s is shown as in its emails again", addr))
+			a.reloadSenderDisplays(true)
+			return
+		}
+		o, err := a.senderOverrideService.Edit(a.ctx, addr, func(o *services.SenderOverrideInfo) {
+			switch cmd.action {
+			case "name":
+				o.Name = cmd.value
+			case "emoji":
+				o.Emoji = cmd.value
+			case "color":
+				o.Color = cmd.value
+			}
+		})
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving the sender override", err)
+			return
+		}
+		if o == nil {
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("%s is shown as in its emails again", addr))
+		} else {
+			label := render.SenderDisplay{Name: o.Name, Emoji: o.Emoji, Color: o.Color}.Label(o.Email)
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🏷️ %s is shown as %s", o.Email, label))
+		}
+		a.reloadSenderDisplays(true)
+	}()
+}
+
+// currentSenderAddress returns the address in the From header of a message
+func (a *App) currentSenderAddress(id string) string {
+	if m, ok := a.caches.messageGet(id); ok && m.From != "" {
+		return render.SenderAddress(m.From)
+	}
+	meta, err := a.messageClient(id).GetMessage(id)
+	if err != nil {
+		return ""
+	}
+	return render.SenderAddress(extractHeaderValue(meta, "From"))
+}
+
+// showSenderOverrides lists the overrides in the content pane
+func (a *App) showSenderOverrides() {
+	overrides, err := a.senderOverrideService.List(a.ctx)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading sender overrides", err)
+		return
+	}
+	if len(overrides) == 0 {
+		a.GetErrorHandler().ShowInfo(a.ctx, "No sender overrides (:sender = <name> on a message sets one)")
+		return
+	}
+	content := a.formatSenderOverrides(overrides)
+	a.QueueUpdateDraw(func() {
+		if a.enhancedTextView != nil {
+			a.enhancedTextView.SetContent(content)
+			a.enhancedTextView.ScrollToBeginning()
+		}
+	})
+}
+
+// formatSenderOverrides lists the overrides with how each sender is shown
+func (a *App) formatSenderOverrides(overrides []services.SenderOverrideInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s\n\n", a.GetColorTag("title"), tview.Escape(fmt.Sprintf("🏷️ Sender overrides (%d)", len(overrides))), a.GetEndTag())
+	for _, o := range overrides {
+		label := render.SenderDisplay{Name: o.Name, Emoji: o.Emoji, Color: o.Color}.Label("(name from the email)")
+		fmt.Fprintf(&b, "%s%s%s  %s\n", a.GetColorTag("emphasis"), tview.Escape(label), a.GetEndTag(), tview.Escape(o.Email))
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package tui
+
+import "testing"
+
+func TestParseSenderCommand(t *testing.T) {
+	cases := []struct {
+		args []string
+		want senderCommand
+	}{
+		{nil, senderCommand{action: "list"}},
+		{[]string{"=", "🚨", "PagerDuty"}, senderCommand{action: "name", value: "🚨 PagerDuty"}},
+		{[]string{"boss@example.com", "=Boss"}, senderCommand{addr: "boss@example.com", action: "name", value: "Boss"}},
+		{[]string{"="}, senderCommand{action: "name"}},
+		{[]string{"emoji", "👩‍💼"}, senderCommand{action: "emoji", value: "👩‍💼"}},
+		{[]string{"x@example.com", "colour", "none"}, senderCommand{addr: "x@example.com", action: "color"}},
+		{[]string{"rm"}, senderCommand{action: "remove"}},
+	}
+	for _, c := range cases {
+		got, err := parseSenderCommand(c.args)
+		if err != nil || got != c.want {
+			t.Errorf("parseSenderCommand(%q) = %+v, %v; want %+v", c.args, got, err, c.want)
+		}
+	}
+	for _, bad := range [][]string{{"color"}, {"x@example.com"}, {"PagerDuty"}} {
+		if _, err := parseSenderCommand(bad); err == nil {
+			t.Errorf("parseSenderCommand(%q) accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+)
+
+// sharedPackMarker tags prompts and saved queries merged from the shared pack in pickers
+const sharedPackMarker = "👥"
+
+// bindSharedPack (re)creates the shared pack service for the active account and merges the pack
+// in the background; the repository is pulled on the first sync only
+func (a *App) bindSharedPack() {
+	if a.dbStore == nil {
+		return
+	}
+	svc := services.NewSharedPackService(a.Config, db.NewPromptStore(a.dbStore), db.NewQueryStore(a.dbStore))
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.sharedPackService = svc
+	go a.syncSharedPack(!a.sharedPackPulled.Swap(true), false)
+}
+
+// syncSharedPack merges the shared pack, pulling the repository first when pull is set. Details
+// go to the log; verbose (:pack) also shows the outcome outside verbose verbosity.
+func (a *App) syncSharedPack(pull, verbose bool) {
+	svc := a.sharedPackService
+	if svc == nil {
+		return
+	}
+	report, err := svc.Sync(a.ctx, pull)
+	if verbose {
+		a.GetErrorHandler().ClearProgress()
+	}
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("shared pack: %v", err)
+		}
+		a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Shared pack not merged: %v", err))
+		return
+	}
+	if a.logger != nil {
+		if report.PullErr != nil {
+			a.logger.Printf("shared pack: pull failed, using the existing checkout: %v", report.PullErr)
+		}
+		for _, p := range report.Problems {
+			a.logger.Printf("shared pack: skipped %s", p)
+		}
+		a.logger.Printf("shared pack: %s", formatSharedPackReport(report))
+	}
+	switch {
+	case report.PullErr != nil:
+		a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("Shared pack pull failed (using the last copy): %v", report.PullErr))
+	case verbose:
+		a.GetErrorHandler().ShowInfo(a.ctx, sharedPackMarker+" Shared pack: "+formatSharedPackReport(report))
+	default:
+		a.GetErrorHandler().ShowDetail(a.ctx, sharedPackMarker+" Shared pack: "+formatSharedPackReport(report))
+	}
+}
+
+// formatSharedPackReport summarizes a sync for the status bar
+func formatSharedPackReport(r *services.SharedPackReport) string {
+	if r.Dir == "" {
+		return "none configured"
+	}
+	counts := func(what string, s db.SharedSyncResult) string {
+		out := fmt.Sprintf("%s +%d ~%d -%d", what, s.Added, s.Updated, s.Removed)
+		if len(s.Shadowed) > 0 {
+			out += fmt.Sprintf(" (%d kept local: %s)", len(s.Shadowed), strings.Join(s.Shadowed, ", "))
+		}
+		return out
+	}
+	parts := []string{counts("prompts", r.Prompts), counts("queries", r.Queries)}
+	if len(r.Problems) > 0 {
+		parts = append(parts, fmt.Sprintf("%d invalid skipped", len(r.Problems)))
+	}
+	if r.Pulled {
+		parts = append(parts, "pulled")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// executePackCommand handles :pack — pull the shared pack and merge it again
+func (a *App) executePackCommand(args []string) {
+	if len(args) > 0 {
+		a.showError("Usage: pack")
+		return
+	}
+	if a.sharedPackService == nil {
+		a.showError("Shared pack needs the local database")
+		return
+	}
+	if !a.Config.SharedPack.Enabled() {
+		a.showError("No shared_pack configured (set shared_pack.repo or shared_pack.dir)")
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowProgress(a.ctx, "Syncing the shared pack...")
+		a.syncSharedPack(true, true)
+	}()
+}
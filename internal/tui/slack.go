@@ -56,7 +56,7 @@ func (a *App) showSlackForwardDialog() {
 
 	// Show panel and load quick view
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.slackView, 0, 1)
+		a.showSidePanel(split, a.slackView, "slack")
 	}
 	a.slackVisible = true
 	a.markFocus("slack")
@@ -84,7 +84,7 @@ func (a *App) showSlackBulkForwardDialog() {
 
 	// Show panel and load bulk view
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.slackView, 0, 1)
+		a.showSidePanel(split, a.slackView, "slack")
 	}
 	a.slackVisible = true
 	a.markFocus("slack")
@@ -618,7 +618,7 @@ func (a *App) forwardEmailToSlack(messageID string, options services.SlackForwar
 			options.ProcessedContent = cleanContent
 		} else {
 			// If not cached, load the message
-			message, err := a.Client.GetMessageWithContent(messageID)
+			message, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 			if err == nil {
 				a.caches.messageSet(messageID, message)
 				rendered, _ := a.renderMessageContent(message)
@@ -690,7 +690,7 @@ func (a *App) forwardBulkEmailsToSlack(options services.SlackForwardOptions) {
 					messageOptions.ProcessedContent = cleanContent
 				} else {
 					// If not cached, load the message
-					message, err := a.Client.GetMessageWithContent(messageID)
+					message, err := a.messageClient(messageID).GetMessageWithContent(messageID)
 					if err == nil {
 						a.caches.messageSet(messageID, message)
 						rendered, _ := a.renderMessageContent(message)
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// bindSmartLabels (re)creates the smart label service for the active account and client
+func (a *App) bindSmartLabels() {
+	if a.dbStore == nil || a.Client == nil || a.labelService == nil {
+		return
+	}
+	svc := services.NewSmartLabelService(db.NewQueryStore(a.dbStore), a.labelService, a.Client.ApplyLabel)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.smartLabelService = svc
+}
+
+// applySmartLabels labels the new messages found by a background refresh that match a smart
+// label. It runs before the new messages are loaded so the list shows the labels right away.
+func (a *App) applySmartLabels(newIDs []string) {
+	if a.smartLabelService == nil || a.Client == nil || len(newIDs) == 0 {
+		return
+	}
+	links, err := a.smartLabelService.List(a.ctx)
+	if err != nil || len(links) == 0 {
+		return
+	}
+	metas, err := a.Client.GetMessagesMetadataParallel(newIDs, 10)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("smart labels: metadata fetch failed: %v", err)
+		}
+		return
+	}
+	matches, err := a.smartLabelService.Apply(a.ctx, metas)
+	if err != nil && a.logger != nil {
+		a.logger.Printf("smart labels: %v", err)
+	}
+	if len(matches) == 0 {
+		if err != nil {
+			a.GetErrorHandler().ShowWarning(a.ctx, "Smart labels not applied: "+err.Error())
+		}
+		return
+	}
+	messages := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		messages[m.MessageID] = true
+	}
+	if a.dndActive() {
+		return
+	}
+	a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("🏷️ Smart labels applied to %d new message(s)", len(messages)))
+}
+
+// parseSmartLabelArgs splits ':smartlabel <query name> = <label>'. Without '=' exactly two words
+// are accepted (query, then label).
+func parseSmartLabelArgs(args []string) (string, string, bool) {
+	joined := strings.Join(args, " ")
+	if i := strings.Index(joined, "="); i >= 0 {
+		query, label := strings.TrimSpace(joined[:i]), strings.TrimSpace(joined[i+1:])
+		return query, label, query != "" && label != ""
+	}
+	if len(args) == 2 {
+		return args[0], args[1], true
+	}
+	return "", "", false
+}
+
+// executeSmartLabelCommand handles :smartlabel [<query> = <label> | remove <query>] — link a saved
+// query to a label, unlink it, or list the smart labels
+func (a *App) executeSmartLabelCommand(args []string) {
+	if a.smartLabelService == nil {
+		a.showError("Smart labels not available (no local database)")
+		return
+	}
+	if len(args) == 0 {
+		a.openSmartLabelsPanel()
+		return
+	}
+	if strings.EqualFold(args[0], "remove") {
+		name := strings.TrimSpace(strings.Join(args[1:], " "))
+		if name == "" {
+			a.showError("Usage: smartlabel remove <saved query>")
+			return
+		}
+		go func() {
+			if err := a.smartLabelService.Unlink(a.ctx, name); err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing smart label", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🏷️ Saved query %q no longer labels new mail", name))
+		}()
+		return
+	}
+	query, label, ok := parseSmartLabelArgs(args)
+	if !ok {
+		a.showError("Usage: smartlabel <saved query> = <label> | remove <saved query>")
+		return
+	}
+	go func() {
+		info, err := a.smartLabelService.Link(a.ctx, query, label)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error creating smart label", err)
+			return
+		}
+		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🏷️ New mail matching %q will be labeled %s", info.QueryName, info.LabelName))
+	}()
+}
+
+// openSmartLabelsPanel lists the smart labels in the side panel: Enter runs the query, d unlinks it
+func (a *App) openSmartLabelsPanel() {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+	var links []*services.SmartLabelInfo
+	reload := func() {
+		go func() {
+			loaded, err := a.smartLabelService.List(a.ctx)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading smart labels", err)
+				return
+			}
+			a.QueueUpdateDraw(func() {
+				links = loaded
+				list.Clear()
+				if len(links) == 0 {
+					list.AddItem("No smart labels — :smartlabel <saved query> = <label>", "", 0, nil)
+					return
+				}
+				for _, l := range links {
+					list.AddItem(tview.Escape(fmt.Sprintf("🏷️ %s → %s", l.QueryName, l.LabelName)), tview.Escape(l.Query), 0, nil)
+				}
+			})
+		}()
+	}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i >= 0 && i < len(links) {
+			query := links[i].Query
+			a.closeSmartLabelsPanel()
+			go a.performSearch(query)
+		}
+	})
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyEscape {
+			a.closeSmartLabelsPanel()
+			return nil
+		}
+		if e.Rune() == 'd' {
+			if i := list.GetCurrentItem(); i >= 0 && i < len(links) {
+				name := links[i].QueryName
+				go func() {
+					if err := a.smartLabelService.Unlink(a.ctx, name); err != nil {
+						a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing smart label", err)
+					}
+					reload()
+				}()
+			}
+			return nil
+		}
+		return e
+	})
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitle(" 🏷️ Smart labels ")
+	container.SetTitleColor(colors.Title.Color())
+	container.AddItem(list, 0, 1, true)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to run query | d to remove | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "smartlabels")
+	}
+	a.markFocus("labels")
+	a.setActivePicker(PickerSmartLabels)
+	a.SetFocus(list)
+	reload()
+}
+
+// closeSmartLabelsPanel closes the smart labels panel and restores focus
+func (a *App) closeSmartLabelsPanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
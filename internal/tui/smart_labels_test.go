@@ -0,0 +1,23 @@
+package tui
+
+import "testing"
+
+func TestParseSmartLabelArgs(t *testing.T) {
+	cases := []struct {
+		args         []string
+		query, label string
+		ok           bool
+	}{
+		{[]string{"ci", "Builds"}, "ci", "Builds", true},
+		{[]string{"big", "old", "=", "Cleanup/Large"}, "big old", "Cleanup/Large", true},
+		{[]string{"big=Cleanup"}, "big", "Cleanup", true},
+		{[]string{"ci", "="}, "", "", false},
+		{[]string{"one", "two", "three"}, "", "", false},
+	}
+	for _, tc := range cases {
+		q, l, ok := parseSmartLabelArgs(tc.args)
+		if ok != tc.ok || (ok && (q != tc.query || l != tc.label)) {
+			t.Errorf("%v: got (%q, %q, %v), want (%q, %q, %v)", tc.args, q, l, ok, tc.query, tc.label, tc.ok)
+		}
+	}
+}
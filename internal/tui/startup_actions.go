@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Startup actions (startup_actions) are command lines replayed after the first inbox load, so
+// the app opens into the user's triage setup: a saved query, threading, expanded threads.
+const (
+	// startupActionSettle gives an action time to start loading before the next one waits on it
+	startupActionSettle = 400 * time.Millisecond
+	// startupActionTimeout bounds the wait for a load (or the database) so one stuck action
+	// cannot hold the rest forever
+	startupActionTimeout = 30 * time.Second
+)
+
+// actionSequence replays command lines one at a time, waiting until the app is idle between
+// them because most commands load in the background
+type actionSequence struct {
+	exec    func(cmd string)
+	idle    func() bool
+	settle  time.Duration
+	timeout time.Duration
+}
+
+// normalizeStartupAction trims an action and its optional leading ':'; "" means skip it
+func normalizeStartupAction(action string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(action), ":"))
+}
+
+// run executes the actions in order and returns how many ran
+func (s actionSequence) run(ctx context.Context, actions []string) int {
+	ran := 0
+	for _, action := range actions {
+		cmd := normalizeStartupAction(action)
+		if cmd == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		s.exec(cmd)
+		ran++
+		select {
+		case <-ctx.Done():
+			return ran
+		case <-time.After(s.settle):
+		}
+		waitUntil(ctx, s.idle, s.timeout)
+	}
+	return ran
+}
+
+// waitUntil polls cond until it holds, the timeout passes or ctx ends; it reports whether cond held
+func waitUntil(ctx context.Context, cond func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	return true
+}
+
+// runStartupActions replays startup_actions on the event loop. Saved queries live in the account
+// database, which opens alongside the first load, so the sequence waits for it first.
+func (a *App) runStartupActions() {
+	if a.Config == nil || len(a.Config.StartupActions) == 0 {
+		return
+	}
+	waitUntil(a.ctx, func() bool { return a.uiLifecycle.boot.done("database") }, startupActionTimeout)
+	a.commandSequence("startup action").run(a.ctx, a.Config.StartupActions)
+}
+
+// commandSequence returns a sequence that dispatches each command line on the event loop and
+// waits for the message list to finish loading before the next; kind labels them in the log
+func (a *App) commandSequence(kind string) actionSequence {
+	return actionSequence{
+		exec: func(cmd string) {
+			if a.logger != nil {
+				a.logger.Printf("%s: :%s", kind, cmd)
+			}
+			done := make(chan struct{})
+			a.QueueUpdateDraw(func() {
+				defer close(done)
+				a.dispatchCommand(cmd)
+			})
+			select {
+			case <-done:
+			case <-a.ctx.Done():
+			}
+		},
+		idle:    func() bool { return !a.IsMessagesLoading() },
+		settle:  startupActionSettle,
+		timeout: startupActionTimeout,
+	}
+}
+
+// executeStartupCommand handles :startup — replay the startup actions now
+func (a *App) executeStartupCommand(args []string) {
+	if len(args) > 0 {
+		a.showError("Usage: startup")
+		return
+	}
+	if a.Config == nil || len(a.Config.StartupActions) == 0 {
+		a.showError("No startup_actions configured")
+		return
+	}
+	go func() {
+		a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("▶️ Running %d startup action(s)", len(a.Config.StartupActions)))
+		a.runStartupActions()
+	}()
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActionSequence_RunsInOrderWaitingForIdle(t *testing.T) {
+	var ran []string
+	loading := 0
+	seq := actionSequence{
+		exec: func(cmd string) {
+			ran = append(ran, cmd)
+			loading = 2 // busy for two polls after each action
+		},
+		idle: func() bool {
+			if loading > 0 {
+				loading--
+				return false
+			}
+			return true
+		},
+		timeout: time.Second,
+	}
+
+	n := seq.run(context.Background(), []string{":query today", "  ", "threads", ":expand-all"})
+	if n != 3 {
+		t.Fatalf("ran %d actions, want 3", n)
+	}
+	if want := []string{"query today", "threads", "expand-all"}; !reflect.DeepEqual(ran, want) {
+		t.Fatalf("ran %v, want %v", ran, want)
+	}
+	if loading != 0 {
+		t.Fatal("the last action should have been waited on")
+	}
+}
+
+func TestActionSequence_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran []string
+	seq := actionSequence{
+		exec: func(cmd string) {
+			ran = append(ran, cmd)
+			cancel()
+		},
+		idle:    func() bool { return false },
+		timeout: time.Minute,
+	}
+	if n := seq.run(ctx, []string{"threads", "expand-all"}); n != 1 || len(ran) != 1 {
+		t.Fatalf("ran %v after cancel, want only the first action", ran)
+	}
+}
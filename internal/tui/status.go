@@ -35,16 +35,40 @@ func (a *App) showError(msg string) {
 	a.showStatusMessage(msg)
 }
 
+// showErrorFor reports a failed action with a message and suggested fix chosen from the error type
+func (a *App) showErrorFor(action string, err error) {
+	if eh := a.GetErrorHandler(); eh != nil {
+		eh.ShowErrorFor(a.ctx, action, err)
+		return
+	}
+	msg, _ := describeError(action, err)
+	a.showError("❌ " + msg)
+}
+
 // showInfo shows an info message via status helpers
 func (a *App) showInfo(msg string) {
+	if !a.statusShows(LogLevelInfo) {
+		return
+	}
 	a.showStatusMessage(fmt.Sprintf("💡 %s", msg))
 }
 
 // showSuccess shows a success message via status helpers
 func (a *App) showSuccess(msg string) {
+	if !a.statusShows(LogLevelSuccess) {
+		return
+	}
 	a.showStatusMessage(fmt.Sprintf("✅ %s", msg))
 }
 
+// statusShows reports whether the status verbosity lets messages of a level through
+func (a *App) statusShows(level LogLevel) bool {
+	if a.errorHandler == nil {
+		return true
+	}
+	return a.errorHandler.Verbosity().Shows(level)
+}
+
 // showLLMError logs the full error and shows a concise message in the status bar
 func (a *App) showLLMError(operation string, err error) {
 	if err == nil {
@@ -60,6 +84,11 @@ func (a *App) showLLMError(operation string, err error) {
 
 // shortError returns a single-line, length-limited error string
 func (a *App) shortError(err error, max int) string {
+	return shortErrorText(err, max)
+}
+
+// shortErrorText is shortError without an App, for helpers that only have the error
+func shortErrorText(err error, max int) string {
 	if err == nil {
 		return ""
 	}
@@ -98,6 +127,18 @@ func (a *App) statusBaseline() string {
 		}
 	}
 
+	if a != nil && localOnlyBlock(a.Config) != "" {
+		base += " | 🔒 AI off"
+	}
+
+	if dnd := a.dndIndicator(); dnd != "" {
+		base += " | " + dnd
+	}
+
+	if outbox := a.outboxIndicator(); outbox != "" {
+		base += " | " + outbox
+	}
+
 	// Check if composition panel is active and show context-appropriate message
 	if a != nil && a.compositionPanel != nil {
 		// Check if we're on the composition page
@@ -113,3 +154,23 @@ func (a *App) statusBaseline() string {
 	// Default baseline message
 	return base + " | Press ? for help"
 }
+
+// executeVerbosityCommand handles :verbosity [errors|normal|verbose]; no argument shows the level.
+// The reply bypasses the filter so it shows at any level.
+func (a *App) executeVerbosityCommand(args []string) {
+	eh := a.GetErrorHandler()
+	if eh == nil {
+		return
+	}
+	if len(args) == 0 {
+		a.showStatusMessage(fmt.Sprintf("🔈 Status verbosity: %s (errors|normal|verbose)", eh.Verbosity()))
+		return
+	}
+	v, ok := ParseStatusVerbosity(args[0])
+	if !ok {
+		a.showError("Usage: verbosity errors|normal|verbose")
+		return
+	}
+	eh.SetVerbosity(v)
+	a.showStatusMessage(fmt.Sprintf("🔈 Status verbosity: %s", v))
+}
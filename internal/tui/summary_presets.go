@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// summaryPresetsPage is the Pages name of the summary preset menu
+const summaryPresetsPage = "summaryPresets"
+
+// summaryPresetItem is one entry of the summary preset menu
+type summaryPresetItem struct {
+	preset   string
+	label    string
+	shortcut rune
+}
+
+// summaryPresetItems lists the default summary and the presets of config.SummaryPresets, in menu order
+var summaryPresetItems = []summaryPresetItem{
+	{preset: "", label: "Default summary", shortcut: 'd'},
+	{preset: "oneline", label: "One line", shortcut: '1'},
+	{preset: "bullets", label: "Bullet points", shortcut: 'b'},
+	{preset: "actions", label: "Action items only", shortcut: 'a'},
+	{preset: "eli5", label: "Explain simply (ELI5)", shortcut: 'e'},
+	{preset: "explain", label: "Explain in my language", shortcut: 'x'},
+}
+
+// summaryPresetLabel returns the menu label of a preset
+func summaryPresetLabel(preset string) string {
+	for _, it := range summaryPresetItems {
+		if it.preset == preset {
+			return it.label
+		}
+	}
+	return preset
+}
+
+// summaryPaneTitle is the AI pane title for a preset
+func summaryPaneTitle(preset string) string {
+	if preset == "" {
+		return " 🧠 AI Summary "
+	}
+	return fmt.Sprintf(" 🧠 AI Summary · %s ", summaryPresetLabel(preset))
+}
+
+// parseSummaryPreset maps a command argument to a preset, accepting a few spellings
+func parseSummaryPreset(arg string) (string, bool) {
+	switch p := strings.ToLower(strings.TrimSpace(arg)); p {
+	case "default", "normal":
+		return "", true
+	case "one-line", "1":
+		return "oneline", true
+	case "bullet", "bullet-points":
+		return "bullets", true
+	case "action", "action-items", "todo":
+		return "actions", true
+	case "explanation", "simple":
+		return "explain", true
+	default:
+		if p != "" && config.IsSummaryPreset(p) {
+			return p, true
+		}
+		return "", false
+	}
+}
+
+// openSummaryPresetMenu shows the preset menu over the AI summary pane. Pressing the summarize
+// key again closes the pane, so a double tap keeps its old toggle behavior.
+func (a *App) openSummaryPresetMenu() {
+	colors := a.GetComponentColors("ai")
+	current := a.aiPanel.summaryPreset()
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBackgroundColor(colors.Background.Color())
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetShortcutColor(colors.Accent.Color())
+	list.SetBorder(true).
+		SetTitle(" 🧠 Summary style ").
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+
+	closeMenu := func() {
+		a.Pages.RemovePage(summaryPresetsPage)
+		a.SetFocus(a.aiSummaryView)
+		a.focus.set("summary")
+	}
+	for i, it := range summaryPresetItems {
+		preset := it.preset
+		label := it.label
+		if preset == current && !a.aiPanel.inPromptMode {
+			label += "  ✓"
+			list.SetCurrentItem(i)
+		}
+		list.AddItem(label, "", it.shortcut, func() {
+			closeMenu()
+			a.showSummaryPreset(preset)
+		})
+	}
+	var closeKey rune
+	if len([]rune(a.Keys.Summarize)) == 1 {
+		closeKey = []rune(a.Keys.Summarize)[0]
+	}
+	list.AddItem("Close summary", "", closeKey, func() {
+		closeMenu()
+		a.closeAISummary()
+	})
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape {
+			closeMenu()
+			return nil
+		}
+		return ev
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(list, len(summaryPresetItems)+3, 0, true).
+			AddItem(nil, 0, 1, false), 34, 0, true).
+		AddItem(nil, 0, 1, false)
+	a.Pages.AddPage(summaryPresetsPage, overlay, true, true)
+	a.SetFocus(list)
+}
+
+// showSummaryPreset switches the AI summary pane to a preset, opening the pane if needed. Each
+// preset is cached separately, so switching back and forth is instant once generated.
+func (a *App) showSummaryPreset(preset string) {
+	a.aiPanel.setSummaryPreset(preset)
+	if !a.aiPanel.visible.Load() {
+		a.toggleAISummary()
+		return
+	}
+	mid := a.GetCurrentMessageID()
+	if mid == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	a.aiPanel.inPromptMode = false
+	a.aiSummaryView.SetTitle(summaryPaneTitle(preset))
+	go a.generateOrShowSummary(mid)
+}
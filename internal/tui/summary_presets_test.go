@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryPresetItems_MatchConfig(t *testing.T) {
+	var presets []string
+	for _, it := range summaryPresetItems[1:] {
+		presets = append(presets, it.preset)
+	}
+	assert.Equal(t, "", summaryPresetItems[0].preset)
+	assert.Equal(t, config.SummaryPresets, presets)
+}
+
+func TestParseSummaryPreset(t *testing.T) {
+	cases := map[string]string{"default": "", "one-line": "oneline", "Bullets": "bullets", "todo": "actions", "eli5": "eli5"}
+	for arg, want := range cases {
+		got, ok := parseSummaryPreset(arg)
+		assert.True(t, ok, arg)
+		assert.Equal(t, want, got, arg)
+	}
+	_, ok := parseSummaryPreset("haiku")
+	assert.False(t, ok)
+	_, ok = parseSummaryPreset("")
+	assert.False(t, ok)
+}
+
+func TestSummaryPaneTitle(t *testing.T) {
+	assert.Equal(t, " 🧠 AI Summary ", summaryPaneTitle(""))
+	assert.Equal(t, " 🧠 AI Summary · Bullet points ", summaryPaneTitle("bullets"))
+}
@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// applyLocalSyncChange mirrors a change (or its reversal) into the cached list and message metadata
+func (a *App) applyLocalSyncChange(c syncChange, undo bool) {
+	label, applied := c.target()
+	if undo {
+		applied = !applied
+	}
+	a.updateCachedMessageLabels(c.MessageID, label, applied)
+	if c.LabelName != "" {
+		a.updateMessageCacheLabels(c.MessageID, c.LabelName, applied)
+	}
+}
+
+// sendSyncChange sends a tracked change to Gmail through the services (so undo is recorded)
+func (a *App) sendSyncChange(c syncChange) error {
+	emailService, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
+	switch c.Kind {
+	case syncMarkRead:
+		return emailService.MarkAsRead(a.ctx, c.MessageID)
+	case syncMarkUnread:
+		return emailService.MarkAsUnread(a.ctx, c.MessageID)
+	case syncLabelAdd:
+		return labelService.ApplyLabel(a.ctx, c.MessageID, c.LabelID)
+	}
+	return labelService.RemoveLabel(a.ctx, c.MessageID, c.LabelID)
+}
+
+// isMessageGoneError reports whether Gmail no longer has the message (deleted elsewhere)
+func isMessageGoneError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(services.ClassifyError("", err), services.ErrNotFound) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "404") || strings.Contains(msg, "not found")
+}
+
+// runSyncChange applies a change locally right away, then sends it. A failure keeps the local state
+// and marks the message with ⚠ until the change is retried or discarded in the :sync panel.
+func (a *App) runSyncChange(c syncChange) error {
+	key := a.syncState.begin(c)
+	a.QueueUpdateDraw(func() {
+		a.applyLocalSyncChange(c, false)
+		a.refreshTableDisplay()
+	})
+	err := a.sendSyncChange(c)
+	if err != nil {
+		a.syncState.fail(key, err, isMessageGoneError(err))
+		if a.logger != nil {
+			a.logger.Printf("sync: %s on %s failed: %v", c.describe(), c.MessageID, err)
+		}
+	} else {
+		a.syncState.succeed(key)
+	}
+	a.QueueUpdateDraw(func() {
+		a.refreshTableDisplay()
+	})
+	return err
+}
+
+// retrySyncChange resends a failed change; it keeps its original position in the panel
+func (a *App) retrySyncChange(c syncChange) error {
+	return a.runSyncChange(c)
+}
+
+// discardSyncChange forgets a change and reverts the local view to the server state
+func (a *App) discardSyncChange(c syncChange) {
+	if _, ok := a.syncState.remove(c.key()); !ok {
+		return
+	}
+	a.QueueUpdateDraw(func() {
+		a.applyLocalSyncChange(c, true)
+		a.refreshTableDisplay()
+		a.refreshMessageContent(c.MessageID)
+	})
+}
+
+// reconcileSyncChanges checks the failed changes against Gmail: changes the server already reflects
+// (e.g. done from another client) are dropped, and messages that disappeared are flagged as conflicts
+func (a *App) reconcileSyncChanges() {
+	seen := make(map[string]bool)
+	resolved := 0
+	for _, c := range a.syncState.list() {
+		if c.Status != syncFailed || seen[c.MessageID] {
+			continue
+		}
+		seen[c.MessageID] = true
+		server, err := a.messageClient(c.MessageID).GetMessageMetadata(c.MessageID)
+		if err != nil {
+			if isMessageGoneError(err) {
+				a.syncState.markGone(c.MessageID, err)
+			}
+			continue
+		}
+		resolved += a.syncState.reconcile(server)
+	}
+	if resolved > 0 {
+		a.QueueUpdateDraw(func() {
+			a.refreshTableDisplay()
+		})
+		go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Sync: %d change(s) already applied in Gmail", resolved))
+	}
+}
+
+// syncMessageSubject returns the subject of a loaded message for the panel
+func (a *App) syncMessageSubject(messageID string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for i, id := range a.ids {
+		if id == messageID && i < len(a.messagesMeta) {
+			if s := extractHeaderValue(a.messagesMeta[i], "Subject"); s != "" {
+				return s
+			}
+			break
+		}
+	}
+	return "(message " + messageID + ")"
+}
+
+// formatSyncChange renders a panel row: status marker, action and subject; the secondary line
+// carries the error
+func formatSyncChange(c syncChange, subject string) (string, string) {
+	marker := "↻"
+	if c.Status == syncFailed {
+		marker = "⚠"
+	}
+	primary := fmt.Sprintf("%s %s — %s", marker, c.describe(), subject)
+	var secondary string
+	switch {
+	case c.Conflict:
+		secondary = "conflict: message changed or removed in Gmail · " + c.Err
+	case c.Status == syncFailed:
+		secondary = fmt.Sprintf("failed (%d attempt(s)): %s", c.Attempts, c.Err)
+	default:
+		secondary = "sending…"
+	}
+	return primary, secondary
+}
+
+// executeSyncCommand handles :sync [retry|discard] — open the resolution panel, or retry/discard
+// every failed change
+func (a *App) executeSyncCommand(args []string) {
+	if len(args) == 0 {
+		go a.openSyncPanel()
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "retry":
+		go a.retryAllSyncChanges()
+	case "discard":
+		go a.discardAllSyncChanges()
+	default:
+		a.showError("Usage: sync [retry|discard]")
+	}
+}
+
+// retryAllSyncChanges resends every failed change
+func (a *App) retryAllSyncChanges() {
+	failed := 0
+	total := 0
+	for _, c := range a.syncState.list() {
+		if c.Status != syncFailed {
+			continue
+		}
+		total++
+		if err := a.retrySyncChange(c); err != nil {
+			failed++
+		}
+	}
+	switch {
+	case total == 0:
+		a.GetErrorHandler().ShowInfo(a.ctx, "No failed changes to retry")
+	case failed == 0:
+		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("Synced %d change(s)", total))
+	default:
+		a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("%d of %d change(s) still failing", failed, total))
+	}
+}
+
+// discardAllSyncChanges reverts every failed change locally
+func (a *App) discardAllSyncChanges() {
+	n := 0
+	for _, c := range a.syncState.list() {
+		if c.Status == syncFailed {
+			a.discardSyncChange(c)
+			n++
+		}
+	}
+	a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Discarded %d change(s)", n))
+}
+
+// openSyncPanel shows the pending/failed changes in the side panel: Enter/r retries, d discards
+func (a *App) openSyncPanel() {
+	a.reconcileSyncChanges()
+
+	a.QueueUpdateDraw(func() {
+		colors := a.GetComponentColors("general")
+		bgColor := colors.Background.Color()
+
+		list := tview.NewList().ShowSecondaryText(true)
+		list.SetBorder(false)
+		list.SetBackgroundColor(bgColor)
+		list.SetMainTextColor(colors.Text.Color())
+		list.SetSelectedTextColor(bgColor)
+		list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+		var changes []syncChange
+		var reload func()
+		reload = func() {
+			changes = a.syncState.list()
+			cur := list.GetCurrentItem()
+			list.Clear()
+			if len(changes) == 0 {
+				list.AddItem("✅ Everything is in sync with Gmail", "", 0, nil)
+				return
+			}
+			for _, c := range changes {
+				primary, secondary := formatSyncChange(c, a.syncMessageSubject(c.MessageID))
+				change := c
+				list.AddItem(tview.Escape(primary), tview.Escape(secondary), 0, func() {
+					go func() {
+						_ = a.retrySyncChange(change)
+						a.QueueUpdateDraw(reload)
+					}()
+				})
+			}
+			if cur >= 0 && cur < list.GetItemCount() {
+				list.SetCurrentItem(cur)
+			}
+		}
+
+		list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+			if e.Key() == tcell.KeyEscape {
+				a.closeSyncPanel()
+				return nil
+			}
+			idx := list.GetCurrentItem()
+			if idx < 0 || idx >= len(changes) {
+				return e
+			}
+			switch e.Rune() {
+			case 'r':
+				change := changes[idx]
+				go func() {
+					_ = a.retrySyncChange(change)
+					a.QueueUpdateDraw(reload)
+				}()
+				return nil
+			case 'd':
+				change := changes[idx]
+				go func() {
+					a.discardSyncChange(change)
+					a.QueueUpdateDraw(reload)
+				}()
+				return nil
+			}
+			return e
+		})
+
+		container := tview.NewFlex().SetDirection(tview.FlexRow)
+		container.SetBackgroundColor(bgColor)
+		container.SetBorder(true)
+		container.SetBorderColor(colors.Border.Color())
+		container.SetTitle(" 🔄 Sync status ")
+		container.SetTitleColor(colors.Title.Color())
+		container.AddItem(list, 0, 1, true)
+
+		footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+		footer.SetText(" Enter/r to retry | d to discard | Esc to close ")
+		footer.SetTextColor(colors.Text.Color())
+		footer.SetBackgroundColor(bgColor)
+		container.AddItem(footer, 1, 0, false)
+
+		reload()
+
+		if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+			if a.labelsView != nil {
+				split.RemoveItem(a.labelsView)
+			}
+			a.labelsView = container
+			split.AddItem(a.labelsView, 0, 1, true)
+			a.showSidePanel(split, a.labelsView, "sync")
+		}
+		a.markFocus("labels")
+		a.setActivePicker(PickerSync)
+		a.SetFocus(list)
+	})
+}
+
+// closeSyncPanel closes the sync panel and restores focus
+func (a *App) closeSyncPanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
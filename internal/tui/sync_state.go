@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// syncChangeKind is a local flag change that has to reach Gmail
+type syncChangeKind string
+
+const (
+	syncMarkRead    syncChangeKind = "read"
+	syncMarkUnread  syncChangeKind = "unread"
+	syncLabelAdd    syncChangeKind = "label_add"
+	syncLabelRemove syncChangeKind = "label_remove"
+)
+
+// syncStatus is the state of a tracked change
+type syncStatus int
+
+const (
+	syncPending syncStatus = iota // sent, waiting for Gmail
+	syncFailed                    // Gmail rejected it; kept locally until retried or discarded
+)
+
+// syncChange is a message flag change applied to the local view before Gmail confirmed it
+type syncChange struct {
+	MessageID string
+	Kind      syncChangeKind
+	LabelID   string
+	LabelName string
+	Status    syncStatus
+	Err       string
+	Conflict  bool // the message changed on the server in a way the change cannot apply to
+	Attempts  int
+	Since     time.Time
+}
+
+// key identifies the flag a change targets, so opposite changes of the same flag replace each other
+func (c syncChange) key() string {
+	label, _ := c.target()
+	return c.MessageID + "|" + label
+}
+
+// target returns the Gmail label the change toggles and whether it should end up applied
+func (c syncChange) target() (string, bool) {
+	switch c.Kind {
+	case syncMarkRead:
+		return "UNREAD", false
+	case syncMarkUnread:
+		return "UNREAD", true
+	case syncLabelAdd:
+		return c.LabelID, true
+	}
+	return c.LabelID, false
+}
+
+// describe renders the change for the resolution panel
+func (c syncChange) describe() string {
+	switch c.Kind {
+	case syncMarkRead:
+		return "mark read"
+	case syncMarkUnread:
+		return "mark unread"
+	case syncLabelAdd:
+		return "add label " + c.LabelName
+	}
+	return "remove label " + c.LabelName
+}
+
+// syncTracker holds the changes not confirmed by Gmail yet. Changes are recorded by action
+// goroutines and read while rendering, so everything is guarded by mu; use it via a.syncState.*.
+type syncTracker struct {
+	mu      sync.RWMutex
+	changes map[string]*syncChange
+}
+
+// begin records a change as pending and returns its key. A retry keeps the attempt count.
+func (t *syncTracker) begin(c syncChange) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.changes == nil {
+		t.changes = make(map[string]*syncChange)
+	}
+	key := c.key()
+	if prev, ok := t.changes[key]; ok && prev.Kind == c.Kind {
+		c.Attempts = prev.Attempts
+	}
+	c.Attempts++
+	c.Status = syncPending
+	c.Err = ""
+	c.Conflict = false
+	if c.Since.IsZero() {
+		c.Since = time.Now()
+	}
+	t.changes[key] = &c
+	return key
+}
+
+// succeed forgets a change Gmail confirmed
+func (t *syncTracker) succeed(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.changes, key)
+}
+
+// fail keeps a change as failed so it can be retried or discarded
+func (t *syncTracker) fail(key string, err error, conflict bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c, ok := t.changes[key]; ok {
+		c.Status = syncFailed
+		c.Conflict = conflict
+		if err != nil {
+			c.Err = err.Error()
+		}
+	}
+}
+
+// remove drops a change and returns it
+func (t *syncTracker) remove(key string) (syncChange, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.changes[key]
+	if !ok {
+		return syncChange{}, false
+	}
+	delete(t.changes, key)
+	return *c, true
+}
+
+// list returns the tracked changes, oldest first
+func (t *syncTracker) list() []syncChange {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]syncChange, 0, len(t.changes))
+	for _, c := range t.changes {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Since.Equal(out[j].Since) {
+			return out[i].key() < out[j].key()
+		}
+		return out[i].Since.Before(out[j].Since)
+	})
+	return out
+}
+
+// counts returns the number of pending and failed changes
+func (t *syncTracker) counts() (pending, failed int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, c := range t.changes {
+		if c.Status == syncFailed {
+			failed++
+		} else {
+			pending++
+		}
+	}
+	return pending, failed
+}
+
+// indicator returns the list marker of a message: ⚠ when a change failed, ↻ while one is pending
+func (t *syncTracker) indicator(messageID string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	mark := ""
+	for _, c := range t.changes {
+		if c.MessageID != messageID {
+			continue
+		}
+		if c.Status == syncFailed {
+			return "⚠"
+		}
+		mark = "↻"
+	}
+	return mark
+}
+
+// overlay re-applies the tracked changes of a message onto its metadata, so the local intent stays
+// visible when the list is reloaded from Gmail
+func (t *syncTracker) overlay(msg *gmailapi.Message) {
+	if msg == nil {
+		return
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, c := range t.changes {
+		if c.MessageID != msg.Id {
+			continue
+		}
+		label, applied := c.target()
+		msg.LabelIds = setLabel(msg.LabelIds, label, applied)
+	}
+}
+
+// reconcile compares the failed changes of a message with its server state: changes Gmail already
+// reflects are dropped. It returns how many were resolved.
+func (t *syncTracker) reconcile(server *gmailapi.Message) int {
+	if server == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	resolved := 0
+	for key, c := range t.changes {
+		if c.MessageID != server.Id || c.Status != syncFailed {
+			continue
+		}
+		label, applied := c.target()
+		if hasLabel(server.LabelIds, label) == applied {
+			delete(t.changes, key)
+			resolved++
+		}
+	}
+	return resolved
+}
+
+// markGone flags the failed changes of a message that no longer exists on the server
+func (t *syncTracker) markGone(messageID string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range t.changes {
+		if c.MessageID == messageID && c.Status == syncFailed {
+			c.Conflict = true
+			if err != nil {
+				c.Err = err.Error()
+			}
+		}
+	}
+}
+
+// reset forgets every change (e.g. on account switch)
+func (t *syncTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.changes = nil
+}
+
+func hasLabel(labels []string, id string) bool {
+	for _, l := range labels {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
+// setLabel adds or removes a label ID, returning the updated slice
+func setLabel(labels []string, id string, applied bool) []string {
+	if applied {
+		if hasLabel(labels, id) {
+			return labels
+		}
+		return append(labels, id)
+	}
+	out := labels[:0]
+	for _, l := range labels {
+		if l != id {
+			out = append(out, l)
+		}
+	}
+	return out
+}
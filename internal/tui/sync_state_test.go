@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+func TestSyncTracker_BeginFailSucceed(t *testing.T) {
+	var tr syncTracker
+	key := tr.begin(syncChange{MessageID: "m1", Kind: syncMarkRead})
+	if got := tr.indicator("m1"); got != "↻" {
+		t.Fatalf("pending indicator = %q, want ↻", got)
+	}
+	tr.fail(key, errors.New("boom"), false)
+	if got := tr.indicator("m1"); got != "⚠" {
+		t.Fatalf("failed indicator = %q, want ⚠", got)
+	}
+	if pending, failed := tr.counts(); pending != 0 || failed != 1 {
+		t.Fatalf("counts = %d/%d, want 0/1", pending, failed)
+	}
+
+	// A retry keeps counting attempts
+	key = tr.begin(syncChange{MessageID: "m1", Kind: syncMarkRead})
+	if c := tr.list()[0]; c.Attempts != 2 || c.Status != syncPending || c.Err != "" {
+		t.Fatalf("retry = %+v, want 2 attempts, pending, no error", c)
+	}
+	tr.succeed(key)
+	if got := tr.indicator("m1"); got != "" || len(tr.list()) != 0 {
+		t.Fatalf("after success indicator = %q, %d changes", got, len(tr.list()))
+	}
+}
+
+func TestSyncTracker_OppositeChangeReplaces(t *testing.T) {
+	var tr syncTracker
+	tr.begin(syncChange{MessageID: "m1", Kind: syncLabelAdd, LabelID: "L1", LabelName: "Work"})
+	tr.begin(syncChange{MessageID: "m1", Kind: syncLabelRemove, LabelID: "L1", LabelName: "Work"})
+	tr.begin(syncChange{MessageID: "m1", Kind: syncMarkUnread})
+	changes := tr.list()
+	if len(changes) != 2 {
+		t.Fatalf("len = %d, want 2 (one per flag)", len(changes))
+	}
+	for _, c := range changes {
+		if c.LabelID == "L1" && (c.Kind != syncLabelRemove || c.Attempts != 1) {
+			t.Fatalf("label change = %+v, want latest remove with a fresh attempt count", c)
+		}
+	}
+}
+
+func TestSyncTracker_OverlayKeepsLocalIntent(t *testing.T) {
+	var tr syncTracker
+	tr.begin(syncChange{MessageID: "m1", Kind: syncMarkRead})
+	tr.begin(syncChange{MessageID: "m1", Kind: syncLabelAdd, LabelID: "L1"})
+	msg := &gmailapi.Message{Id: "m1", LabelIds: []string{"INBOX", "UNREAD"}}
+	tr.overlay(msg)
+	if hasLabel(msg.LabelIds, "UNREAD") || !hasLabel(msg.LabelIds, "L1") || !hasLabel(msg.LabelIds, "INBOX") {
+		t.Fatalf("labels = %v, want INBOX and L1 without UNREAD", msg.LabelIds)
+	}
+	other := &gmailapi.Message{Id: "m2", LabelIds: []string{"UNREAD"}}
+	tr.overlay(other)
+	if !hasLabel(other.LabelIds, "UNREAD") {
+		t.Fatalf("overlay touched another message: %v", other.LabelIds)
+	}
+}
+
+func TestSyncTracker_ReconcileAndGone(t *testing.T) {
+	var tr syncTracker
+	k1 := tr.begin(syncChange{MessageID: "m1", Kind: syncMarkRead})
+	k2 := tr.begin(syncChange{MessageID: "m1", Kind: syncLabelAdd, LabelID: "L1"})
+	tr.fail(k1, errors.New("rate limited"), false)
+	tr.fail(k2, errors.New("rate limited"), false)
+
+	// Read elsewhere, label still missing: only the read change is resolved
+	if n := tr.reconcile(&gmailapi.Message{Id: "m1", LabelIds: []string{"INBOX"}}); n != 1 {
+		t.Fatalf("resolved = %d, want 1", n)
+	}
+	changes := tr.list()
+	if len(changes) != 1 || changes[0].Kind != syncLabelAdd {
+		t.Fatalf("remaining = %+v, want the label change", changes)
+	}
+
+	tr.markGone("m1", errors.New("404 not found"))
+	if c := tr.list()[0]; !c.Conflict || c.Err != "404 not found" {
+		t.Fatalf("gone = %+v, want a conflict with the error", c)
+	}
+	tr.reset()
+	if len(tr.list()) != 0 {
+		t.Fatal("reset kept changes")
+	}
+}
+
+func TestFormatSyncChange(t *testing.T) {
+	c := syncChange{Kind: syncLabelRemove, LabelName: "Work", Status: syncFailed, Err: "403", Attempts: 2}
+	primary, secondary := formatSyncChange(c, "Hello")
+	if primary != "⚠ remove label Work — Hello" || secondary != "failed (2 attempt(s)): 403" {
+		t.Fatalf("got %q / %q", primary, secondary)
+	}
+}
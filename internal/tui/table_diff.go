@@ -0,0 +1,47 @@
+package tui
+
+import "github.com/derailed/tview"
+
+// syncTableCells makes dst show what src shows, replacing only the cells that differ and dropping
+// the rows src doesn't have. Selection and offset of dst are left alone. It returns the number of
+// rows that changed. A different column count (another layout) rebuilds dst from scratch.
+func syncTableCells(dst, src *tview.Table) int {
+	if dst.GetColumnCount() != src.GetColumnCount() {
+		dst.Clear()
+	}
+	rows, cols := src.GetRowCount(), src.GetColumnCount()
+	changed := 0
+	for row := 0; row < rows; row++ {
+		isNew := row >= dst.GetRowCount()
+		rowChanged := isNew
+		for col := 0; col < cols; col++ {
+			cell := src.GetCell(row, col)
+			if !isNew && sameTableCell(dst.GetCell(row, col), cell) {
+				continue
+			}
+			dst.SetCell(row, col, cell)
+			rowChanged = true
+		}
+		if rowChanged {
+			changed++
+		}
+	}
+	for dst.GetRowCount() > rows {
+		dst.RemoveRow(dst.GetRowCount() - 1)
+		changed++
+	}
+	return changed
+}
+
+// sameTableCell reports whether two cells draw the same
+func sameTableCell(a, b *tview.TableCell) bool {
+	return a.Text == b.Text &&
+		a.Align == b.Align &&
+		a.MaxWidth == b.MaxWidth &&
+		a.Expansion == b.Expansion &&
+		a.Color == b.Color &&
+		a.BackgroundColor == b.BackgroundColor &&
+		a.Transparent == b.Transparent &&
+		a.Attributes == b.Attributes &&
+		a.NotSelectable == b.NotSelectable
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+func tableOf(rows ...[]string) *tview.Table {
+	t := tview.NewTable()
+	for r, cells := range rows {
+		for c, text := range cells {
+			t.SetCell(r, c, tview.NewTableCell(text))
+		}
+	}
+	return t
+}
+
+func TestSyncTableCells(t *testing.T) {
+	dst := tableOf([]string{"From", "Subject"}, []string{"ann", "hello"}, []string{"bob", "lunch"}, []string{"cat", "old"})
+	dst.Select(2, 0)
+	dst.SetOffset(1, 0)
+	kept := dst.GetCell(1, 0)
+
+	src := tableOf([]string{"From", "Subject"}, []string{"ann", "hello"}, []string{"bob", "lunch?"})
+	src.GetCell(1, 1).SetTextColor(tcell.ColorRed)
+
+	if changed := syncTableCells(dst, src); changed != 3 {
+		t.Fatalf("changed rows = %d, want 3 (recolored, edited, removed)", changed)
+	}
+	if dst.GetRowCount() != 3 || dst.GetCell(2, 1).Text != "lunch?" || dst.GetCell(1, 1).Color != tcell.ColorRed {
+		t.Fatalf("dst not in sync: rows=%d %q", dst.GetRowCount(), dst.GetCell(2, 1).Text)
+	}
+	if dst.GetCell(1, 0) != kept {
+		t.Fatal("an unchanged cell was replaced")
+	}
+	if row, _ := dst.GetSelection(); row != 2 {
+		t.Fatalf("selection moved to %d", row)
+	}
+	if row, _ := dst.GetOffset(); row != 1 {
+		t.Fatalf("offset moved to %d", row)
+	}
+	if changed := syncTableCells(dst, src); changed != 0 {
+		t.Fatalf("second sync changed %d rows", changed)
+	}
+
+	wider := tableOf([]string{"#", "From", "Subject"}, []string{"1", "ann", "hello"})
+	syncTableCells(dst, wider)
+	if dst.GetColumnCount() != 3 || dst.GetRowCount() != 2 || dst.GetCell(1, 2).Text != "hello" {
+		t.Fatalf("layout change not rebuilt: %d cols %d rows", dst.GetColumnCount(), dst.GetRowCount())
+	}
+}
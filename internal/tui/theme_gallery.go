@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+	"github.com/mattn/go-runewidth"
+)
+
+// Theme mock sizes: the preferred width of one mock, and the narrowest it is drawn
+const (
+	themeMockWidth    = 44
+	themeMockMinWidth = 26
+	themeCompareGap   = 3
+)
+
+// mockSeg is a run of mock text in one color pair
+type mockSeg struct {
+	text   string
+	fg, bg string
+	bold   bool
+}
+
+func (s mockSeg) tagged() string {
+	attrs := "-"
+	if s.bold {
+		attrs = "b"
+	}
+	return fmt.Sprintf("[%s:%s:%s]%s", s.fg, s.bg, attrs, tview.Escape(s.text))
+}
+
+// mockRow draws one framed row of width columns: the border, the segments cut or padded to the
+// inner width on the theme background, and the border again
+func mockRow(t *services.ThemeConfig, width int, segs ...mockSeg) string {
+	ui := t.UIColors
+	inner := width - 2
+	var b strings.Builder
+	b.WriteString(mockSeg{text: "│", fg: ui.BorderColor, bg: ui.BgColor}.tagged())
+	used := 0
+	for _, s := range segs {
+		if used >= inner {
+			break
+		}
+		s.text = runewidth.Truncate(s.text, inner-used, "")
+		used += runewidth.StringWidth(s.text)
+		b.WriteString(s.tagged())
+	}
+	if used < inner {
+		// A selected row keeps its highlight to the edge
+		bg := ui.BgColor
+		if len(segs) > 0 && segs[len(segs)-1].bg == ui.SelectionBgColor {
+			bg = ui.SelectionBgColor
+		}
+		b.WriteString(mockSeg{text: strings.Repeat(" ", inner-used), fg: ui.FgColor, bg: bg}.tagged())
+	}
+	b.WriteString(mockSeg{text: "│", fg: ui.BorderColor, bg: ui.BgColor}.tagged())
+	b.WriteString("[-:-:-]")
+	return b.String()
+}
+
+// mockRule draws a frame line (top, divider or bottom) with an optional pane title
+func mockRule(t *services.ThemeConfig, width int, left, right, title string) string {
+	ui := t.UIColors
+	fill := width - 2
+	var b strings.Builder
+	b.WriteString(mockSeg{text: left, fg: ui.BorderColor, bg: ui.BgColor}.tagged())
+	if title != "" && fill > runewidth.StringWidth(title)+3 {
+		label := " " + title + " "
+		b.WriteString(mockSeg{text: "─", fg: ui.BorderColor, bg: ui.BgColor}.tagged())
+		b.WriteString(mockSeg{text: label, fg: ui.TitleColor, bg: ui.BgColor, bold: true}.tagged())
+		fill -= runewidth.StringWidth(label) + 1
+	}
+	b.WriteString(mockSeg{text: strings.Repeat("─", fill) + right, fg: ui.BorderColor, bg: ui.BgColor}.tagged())
+	b.WriteString("[-:-:-]")
+	return b.String()
+}
+
+// themeMockLines renders a miniature of the main screen in a theme's colors: the message list
+// (unread, selected, draft, important and read rows), the message content and the status bar
+// with a search input. Every line is width columns wide.
+func themeMockLines(t *services.ThemeConfig, width int) []string {
+	if width < themeMockMinWidth {
+		width = themeMockMinWidth
+	}
+	ui, email := t.UIColors, t.EmailColors
+	bg := ui.BgColor
+	row := func(segs ...mockSeg) string { return mockRow(t, width, segs...) }
+	sender := func(name string) string { return fmt.Sprintf("%-13s", name) }
+
+	return []string{
+		mockRule(t, width, "┌", "┐", "Messages"),
+		row(mockSeg{text: " ● " + sender("Alice Martin") + "Quarterly numbers", fg: email.UnreadColor, bg: bg, bold: true}),
+		row(mockSeg{text: "▌", fg: ui.FocusColor, bg: ui.SelectionBgColor},
+			mockSeg{text: "  " + sender("Bob Chen") + "Re: Lunch on Friday?", fg: ui.SelectionFgColor, bg: ui.SelectionBgColor}),
+		row(mockSeg{text: "   " + sender("Carol Diaz") + "Draft: Q4 proposal", fg: email.DraftColor, bg: bg}),
+		row(mockSeg{text: " ! " + sender("Ops Alerts") + "Disk usage at 91%", fg: email.ImportantColor, bg: bg}),
+		row(mockSeg{text: "   " + sender("Dana Lee") + "Meeting notes", fg: email.ReadColor, bg: bg}),
+		row(mockSeg{text: " ➤ " + sender("me") + "Re: Travel plans", fg: email.SentColor, bg: bg}),
+		mockRule(t, width, "├", "┤", "Message"),
+		row(mockSeg{text: " From: ", fg: ui.LabelColor, bg: bg}, mockSeg{text: "Alice Martin", fg: ui.FgColor, bg: bg}),
+		row(mockSeg{text: " Numbers are in, see the report:", fg: ui.FgColor, bg: bg}),
+		row(mockSeg{text: " https://example.com/q3", fg: ui.HintColor, bg: bg}),
+		mockRule(t, width, "├", "┤", ""),
+		row(mockSeg{text: " ✓ Archived 1 message", fg: ui.SuccessColor, bg: bg}),
+		row(mockSeg{text: " ⚠ Quota at 80%", fg: ui.WarningColor, bg: bg}),
+		row(mockSeg{text: " ✗ Send failed: offline", fg: ui.ErrorColor, bg: bg}),
+		row(mockSeg{text: " Search: ", fg: ui.LabelColor, bg: bg},
+			mockSeg{text: "from:bob" + strings.Repeat(" ", width), fg: ui.InputFgColor, bg: ui.InputBgColor}),
+		mockRule(t, width, "└", "┘", ""),
+	}
+}
+
+// themeMockWidthFor picks the mock width for a content pane: one mock, or two side by side when
+// comparing
+func themeMockWidthFor(paneWidth int, compare bool) int {
+	width := paneWidth
+	if compare {
+		width = (paneWidth - themeCompareGap) / 2
+	}
+	if width <= 0 || width > themeMockWidth {
+		width = themeMockWidth
+	}
+	if width < themeMockMinWidth {
+		width = themeMockMinWidth
+	}
+	return width
+}
+
+// renderThemeGallery shows the mock of a theme, or of two themes side by side when other is set,
+// each under its name
+func renderThemeGallery(t, other *services.ThemeConfig, paneWidth int) string {
+	width := themeMockWidthFor(paneWidth, other != nil)
+	caption := func(c *services.ThemeConfig) string {
+		return fmt.Sprintf("%-*s", width, runewidth.Truncate(c.Name, width, "…"))
+	}
+	var b strings.Builder
+	if other == nil {
+		b.WriteString(tview.Escape(caption(t)) + "\n")
+		for _, line := range themeMockLines(t, width) {
+			b.WriteString(line + "\n")
+		}
+		return b.String()
+	}
+	gap := strings.Repeat(" ", themeCompareGap)
+	b.WriteString(tview.Escape(caption(other)) + gap + tview.Escape(caption(t)) + "\n")
+	left, right := themeMockLines(other, width), themeMockLines(t, width)
+	for i := range left {
+		b.WriteString(left[i] + gap + right[i] + "\n")
+	}
+	return b.String()
+}
+
+// formatThemeColorDetails lists a theme's colors by role
+func (a *App) formatThemeColorDetails(t *services.ThemeConfig) string {
+	details := "📧 Email Colors:\n"
+	details += a.formatColorSampleString("Unread", t.EmailColors.UnreadColor)
+	details += a.formatColorSampleString("Read", t.EmailColors.ReadColor)
+	details += a.formatColorSampleString("Important", t.EmailColors.ImportantColor)
+	details += a.formatColorSampleString("Sent", t.EmailColors.SentColor)
+	details += a.formatColorSampleString("Draft", t.EmailColors.DraftColor)
+
+	details += "\n🎨 UI Colors:\n"
+	details += a.formatColorSampleString("Background", t.UIColors.BgColor)
+	details += a.formatColorSampleString("Text", t.UIColors.FgColor)
+	details += a.formatColorSampleString("Borders", t.UIColors.BorderColor)
+	details += a.formatColorSampleString("Focus", t.UIColors.FocusColor)
+
+	details += "\n🔖 Status Colors:\n"
+	details += a.formatColorSampleString("Error", t.UIColors.ErrorColor)
+	details += a.formatColorSampleString("Success", t.UIColors.SuccessColor)
+	details += a.formatColorSampleString("Warning", t.UIColors.WarningColor)
+	return details
+}
+
+// contentPaneWidth is the width of the message content view, 0 before the first draw
+func (a *App) contentPaneWidth() int {
+	if textView, ok := a.views["text"].(*tview.TextView); ok {
+		_, _, w, _ := textView.GetInnerRect()
+		return w
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func testThemeConfig(name string) *services.ThemeConfig {
+	t := &services.ThemeConfig{Name: name}
+	t.UIColors.BgColor = "#282a36"
+	t.UIColors.FgColor = "#f8f8f2"
+	t.UIColors.BorderColor = "#44475a"
+	t.UIColors.SelectionBgColor = "#6272a4"
+	t.EmailColors.UnreadColor = "#8be9fd"
+	return t
+}
+
+func TestThemeMockLines_FixedWidth(t *testing.T) {
+	for _, width := range []int{themeMockMinWidth, 30, themeMockWidth} {
+		lines := themeMockLines(testThemeConfig("dracula"), width)
+		for i, line := range lines {
+			assert.Equal(t, width, tview.TaggedStringWidth(line), "width %d line %d: %q", width, i, line)
+		}
+	}
+	lines := themeMockLines(testThemeConfig("dracula"), themeMockWidth)
+	assert.Contains(t, lines[1], "[#8be9fd:#282a36:b]") // unread row
+	assert.Contains(t, lines[2], ":#6272a4:-]")         // selected row keeps the selection background
+}
+
+func TestThemeMockWidthFor(t *testing.T) {
+	assert.Equal(t, themeMockWidth, themeMockWidthFor(0, false))
+	assert.Equal(t, themeMockWidth, themeMockWidthFor(120, true))
+	assert.Equal(t, 35, themeMockWidthFor(73, true))
+	assert.Equal(t, themeMockMinWidth, themeMockWidthFor(40, true))
+}
+
+func TestRenderThemeGallery_Compare(t *testing.T) {
+	out := renderThemeGallery(testThemeConfig("right"), testThemeConfig("left"), 93)
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "left "))
+	assert.Contains(t, lines[0], "   right")
+	for _, line := range lines[1:] {
+		assert.Equal(t, 2*themeMockWidth+themeCompareGap, tview.TaggedStringWidth(line))
+	}
+}
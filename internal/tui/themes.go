@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ajramos/giztui/internal/services"
 	"github.com/derailed/tcell/v2"
 	"github.com/derailed/tview"
 )
@@ -52,6 +53,7 @@ func (a *App) openThemePicker() {
 
 	var all []themeItem
 	var visible []themeItem
+	compareWith := "" // theme marked with 'c', shown beside the highlighted one
 
 	// Enhanced reload function with better formatting
 	reload := func(filter string) {
@@ -149,6 +151,12 @@ func (a *App) openThemePicker() {
 				all = append(all, item)
 			}
 
+			// The preview follows the highlight, so themes can be browsed before applying one
+			list.SetChangedFunc(func(index int, _ string, _ string, _ rune) {
+				if index >= 0 && index < len(visible) && visible[index].valid {
+					a.showThemeGallery(visible[index].name, compareWith, false)
+				}
+			})
 			reload("")
 
 			// Set up input field
@@ -194,7 +202,7 @@ func (a *App) openThemePicker() {
 
 			// Footer
 			footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
-			footer.SetText(" Enter: preview | Space: apply | Esc: cancel ")
+			footer.SetText(" Enter: preview | c: compare | Space: apply | Esc: cancel ")
 			footer.SetTextColor(a.GetComponentColors("themes").Text.Color())
 			footer.SetBackgroundColor(bgColor)
 			container.AddItem(footer, 1, 0, false)
@@ -209,6 +217,22 @@ func (a *App) openThemePicker() {
 					a.closeThemePicker()
 					return nil
 				}
+				if e.Key() == tcell.KeyRune && e.Rune() == 'c' {
+					// Mark the highlighted theme to compare against (again on it to stop)
+					currentIdx := list.GetCurrentItem()
+					if currentIdx >= 0 && currentIdx < len(visible) {
+						themeName := visible[currentIdx].name
+						if compareWith == themeName {
+							compareWith = ""
+							go a.GetErrorHandler().ShowInfo(a.ctx, "Compare mode off")
+						} else {
+							compareWith = themeName
+							go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Comparing with %s — move to another theme to see both", themeName))
+						}
+						a.showThemeGallery(themeName, compareWith, false)
+					}
+					return nil
+				}
 				if e.Key() == tcell.KeyRune && e.Rune() == ' ' {
 					// Space key applies theme directly
 					currentIdx := list.GetCurrentItem()
@@ -228,7 +252,7 @@ func (a *App) openThemePicker() {
 				}
 				a.labelsView = container
 				split.AddItem(a.labelsView, 0, 1, true)
-				split.ResizeItem(a.labelsView, 0, 1)
+				a.showSidePanel(split, a.labelsView, "themes")
 			}
 			a.SetFocus(input)
 			a.focus.set("prompts") // Use same focus identifier as prompts for consistency
@@ -237,7 +261,7 @@ func (a *App) openThemePicker() {
 
 			// Show info message
 			go func() {
-				a.GetErrorHandler().ShowInfo(a.ctx, "Theme picker opened | Enter: preview | Space: apply")
+				a.GetErrorHandler().ShowInfo(a.ctx, "Theme picker opened | Enter: preview | c: compare | Space: apply")
 			}()
 		})
 	}()
@@ -245,12 +269,20 @@ func (a *App) openThemePicker() {
 
 // showThemePreview shows theme details in the text view (similar to showPromptDetails)
 func (a *App) showThemePreview(themeName string) {
+	a.showThemeGallery(themeName, "", true)
+}
+
+// showThemeGallery shows a miniature of the main screen in the theme's colors above its color
+// details, or side by side with compareName's miniature when set. focus moves focus to the text
+// view for scrolling; the picker keeps it while the preview follows its highlight.
+func (a *App) showThemeGallery(themeName, compareName string, focus bool) {
 	// Get theme service
 	themeService := a.GetThemeService()
 	if themeService == nil {
 		a.GetErrorHandler().ShowError(a.ctx, "Theme service not available")
 		return
 	}
+	paneWidth := a.contentPaneWidth()
 
 	go func() {
 		themeConfig, err := themeService.GetThemeConfig(a.ctx, themeName)
@@ -258,32 +290,26 @@ func (a *App) showThemePreview(themeName string) {
 			a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Failed to get theme details: %v", err))
 			return
 		}
+		var compareConfig *services.ThemeConfig
+		if compareName != "" && compareName != themeName {
+			if compareConfig, err = themeService.GetThemeConfig(a.ctx, compareName); err != nil {
+				a.GetErrorHandler().ShowError(a.ctx, fmt.Sprintf("Failed to get theme details: %v", err))
+				return
+			}
+		}
 
-		// Enhanced preview with actual color samples
-		details := fmt.Sprintf("🎨 Theme: %s\n", themeConfig.Name)
-		details += fmt.Sprintf("📄 Description: %s\n", themeConfig.Description)
-		details += "🔖 Version: 1.0\n\n" // OBLITERATED: unnecessary fmt.Sprintf eliminated! 💥
-
-		// Color samples using actual theme colors from service ThemeConfig
-		details += "📧 Email Colors:\n"
-		details += a.formatColorSampleString("Unread", themeConfig.EmailColors.UnreadColor)
-		details += a.formatColorSampleString("Read", themeConfig.EmailColors.ReadColor)
-		details += a.formatColorSampleString("Important", themeConfig.EmailColors.ImportantColor)
-		details += a.formatColorSampleString("Sent", themeConfig.EmailColors.SentColor)
-		details += a.formatColorSampleString("Draft", themeConfig.EmailColors.DraftColor)
-
-		details += "\n🎨 UI Colors:\n"
-		details += a.formatColorSampleString("Background", themeConfig.UIColors.BgColor)
-		details += a.formatColorSampleString("Text", themeConfig.UIColors.FgColor)
-		details += a.formatColorSampleString("Borders", themeConfig.UIColors.BorderColor)
-		details += a.formatColorSampleString("Focus", themeConfig.UIColors.FocusColor)
-
-		details += "\n🔖 Status Colors:\n"
-		details += a.formatColorSampleString("Error", themeConfig.UIColors.ErrorColor)
-		details += a.formatColorSampleString("Success", themeConfig.UIColors.SuccessColor)
-		details += a.formatColorSampleString("Warning", themeConfig.UIColors.WarningColor)
-
-		details += "\n💡 Press Space to apply this theme | Tab to return to picker"
+		var details string
+		if compareConfig != nil {
+			details = fmt.Sprintf("🎨 Comparing: %s ↔ %s\n\n", compareConfig.Name, themeConfig.Name)
+			details += renderThemeGallery(themeConfig, compareConfig, paneWidth)
+			details += "\n💡 Space: apply the highlighted theme | c: stop comparing | Tab: back to picker"
+		} else {
+			details = fmt.Sprintf("🎨 Theme: %s\n", themeConfig.Name)
+			details += fmt.Sprintf("📄 Description: %s\n\n", themeConfig.Description)
+			details += renderThemeGallery(themeConfig, nil, paneWidth) + "\n"
+			details += a.formatThemeColorDetails(themeConfig)
+			details += "\n💡 Press Space to apply this theme | c: compare with another | Tab to return to picker"
+		}
 
 		// Show in text view with improved UX (same pattern as showPromptDetails)
 		a.QueueUpdateDraw(func() {
@@ -293,8 +319,9 @@ func (a *App) showThemePreview(themeName string) {
 				// Use standard yellow for consistency with other titles
 				textContainer.SetTitleColor(a.GetComponentColors("general").Title.Color())
 
-				// Store the current header height before hiding it
-				if header, ok := a.views["header"].(*tview.TextView); ok {
+				// Store the current header height before hiding it (once: it is already hidden
+				// when the preview follows the picker's highlight)
+				if header, ok := a.views["header"].(*tview.TextView); ok && a.originalHeaderHeight == 0 {
 					// Calculate current header height based on its content
 					headerContent := header.GetText(false)
 					a.originalHeaderHeight = a.calculateHeaderHeight(headerContent)
@@ -302,21 +329,21 @@ func (a *App) showThemePreview(themeName string) {
 					// Hide message headers by resizing header to 0 height
 					textContainer.ResizeItem(header, 0, 0)
 				}
-
-				// Debug: Log that we're setting the title
 			}
 
 			if textView, ok := a.views["text"].(*tview.TextView); ok {
 				textView.SetText(details)
 				textView.ScrollToBeginning()
 
-				// Move focus to text view for scrolling (use EnhancedTextView if available)
-				if a.enhancedTextView != nil {
-					a.SetFocus(a.enhancedTextView)
-				} else {
-					a.SetFocus(textView)
+				if focus {
+					// Move focus to text view for scrolling (use EnhancedTextView if available)
+					if a.enhancedTextView != nil {
+						a.SetFocus(a.enhancedTextView)
+					} else {
+						a.SetFocus(textView)
+					}
+					a.markFocus("text")
 				}
-				a.markFocus("text")
 			}
 			// Also update enhanced text view if available
 			if a.enhancedTextView != nil {
@@ -324,9 +351,11 @@ func (a *App) showThemePreview(themeName string) {
 			}
 		})
 
-		go func() {
-			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Previewing: %s | Space: apply | Tab: back to picker", themeName))
-		}()
+		if focus {
+			go func() {
+				a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Previewing: %s | Space: apply | Tab: back to picker", themeName))
+			}()
+		}
 	}()
 }
 
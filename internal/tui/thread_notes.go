@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// threadNoteEditorPage is the Pages name of the pinned-note editor
+const threadNoteEditorPage = "threadNoteEditor"
+
+// bindThreadNotes (re)creates the pinned-note service for the active account
+func (a *App) bindThreadNotes() {
+	if a.dbStore == nil {
+		return
+	}
+	svc := services.NewThreadNoteService(db.NewThreadNoteStore(a.dbStore), a.summarizeThreadForNote)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.threadNoteService = svc
+}
+
+// summarizeThreadForNote returns the AI summary of a thread without touching the AI panel; force
+// bypasses the summary cache
+func (a *App) summarizeThreadForNote(ctx context.Context, threadID string, force bool) (string, error) {
+	threadService := a.getThreadService()
+	if threadService == nil {
+		return "", fmt.Errorf("thread service not available")
+	}
+	res, err := threadService.GenerateThreadSummary(ctx, threadID, services.ThreadSummaryOptions{
+		MaxLength:       500,
+		Language:        "en",
+		UseCache:        !force,
+		ForceRegenerate: force,
+		AccountEmail:    a.getActiveAccountEmail(),
+		SummaryType:     "conversation",
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Summary, nil
+}
+
+// currentThreadID resolves the conversation of the selected row: the cached or listed message's
+// thread, or the ID itself in thread mode where rows are threads
+func (a *App) currentThreadID() string {
+	id := a.getCurrentSelectedMessageID()
+	if id == "" {
+		return ""
+	}
+	if m, ok := a.GetMessageFromCache(id); ok && m.ThreadId != "" {
+		return m.ThreadId
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, meta := range a.messagesMeta {
+		if meta != nil && meta.Id == id && meta.ThreadId != "" {
+			return meta.ThreadId
+		}
+	}
+	return id
+}
+
+// renderMessageForView renders a message for the reader pane, with the conversation's pinned
+// note (if any) and the notice about AMP or form content (if any) on top
+func (a *App) renderMessageForView(m *gmail.Message) (string, bool) {
+	rendered, isANSI := a.renderMessageContent(m)
+	rendered = a.withInteractiveNotice(m, rendered, !isANSI)
+	rendered = withPartialRenderNotice(m, rendered, !isANSI)
+	if a.threadNoteService == nil || m == nil || m.ThreadId == "" {
+		return rendered, isANSI
+	}
+	note, err := a.threadNoteService.Get(a.ctx, m.ThreadId)
+	if err != nil || note == nil {
+		return rendered, isANSI
+	}
+	return formatPinnedNote(note, !isANSI) + rendered, isANSI
+}
+
+// formatPinnedNote renders the note block shown above the message body. markup escapes the note
+// for tview's dynamic colors; ANSI content is written through an ANSI writer and must stay plain.
+func formatPinnedNote(note *services.ThreadNoteInfo, markup bool) string {
+	kind := "note"
+	if note.Source == services.ThreadNoteAI {
+		kind = "AI summary"
+	}
+	text := strings.TrimSpace(note.Note)
+	if markup {
+		text = tview.Escape(text)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "📌 Pinned %s (updated %s) — :note to edit\n", kind, note.UpdatedAt.Format("Jan 2 2006"))
+	b.WriteString(text)
+	b.WriteString("\n" + strings.Repeat("─", 40) + "\n\n")
+	return b.String()
+}
+
+// executeThreadNoteCommand handles :note [pin|regen|remove] for the selected conversation
+func (a *App) executeThreadNoteCommand(args []string) {
+	if a.threadNoteService == nil {
+		a.showError("Pinned notes not available (no local database)")
+		return
+	}
+	threadID := a.currentThreadID()
+	if threadID == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	action := ""
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+	switch action {
+	case "", "edit":
+		go func() {
+			note, err := a.threadNoteService.Get(a.ctx, threadID)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading pinned note", err)
+				return
+			}
+			text, source := "", services.ThreadNoteManual
+			if note != nil {
+				text, source = note.Note, note.Source
+			}
+			a.QueueUpdateDraw(func() { a.openThreadNoteEditor(threadID, text, source) })
+		}()
+	case "pin":
+		go func() {
+			a.GetErrorHandler().ShowProgress(a.ctx, "🧠 Summarizing conversation…")
+			summary, err := a.threadNoteService.Summary(a.ctx, threadID, false)
+			a.GetErrorHandler().ClearProgress()
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error summarizing conversation", err)
+				return
+			}
+			a.QueueUpdateDraw(func() { a.openThreadNoteEditor(threadID, summary, services.ThreadNoteAI) })
+		}()
+	case "regen", "regenerate":
+		go func() {
+			a.GetErrorHandler().ShowProgress(a.ctx, "🧠 Regenerating pinned summary…")
+			_, err := a.threadNoteService.Regenerate(a.ctx, threadID)
+			a.GetErrorHandler().ClearProgress()
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error regenerating pinned summary", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, "📌 Pinned summary regenerated")
+			a.refreshPinnedNoteView()
+		}()
+	case "remove", "rm", "unpin":
+		go func() {
+			if err := a.threadNoteService.Unpin(a.ctx, threadID); err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error removing pinned note", err)
+				return
+			}
+			a.GetErrorHandler().ShowSuccess(a.ctx, "📌 Pinned note removed")
+			a.refreshPinnedNoteView()
+		}()
+	default:
+		a.showError("Usage: note [pin|regen|remove]")
+	}
+}
+
+// openThreadNoteEditor edits a conversation's note: Ctrl+S pins it (an empty note unpins), Esc
+// cancels. source records whether the text started as an AI summary.
+func (a *App) openThreadNoteEditor(threadID, text, source string) {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	title := " 📌 Pinned note — Ctrl+S to save | Esc to cancel "
+	if source == services.ThreadNoteAI {
+		title = " 📌 Pin AI summary (edit freely) — Ctrl+S to save | Esc to cancel "
+	}
+	editor := NewEditableTextView(a).
+		SetPlaceholder("Write a note for this conversation. Leave it empty to unpin.").
+		SetPlaceholderTextColor(colors.Border.Color()).
+		SetBackgroundColor(bgColor).
+		SetTextColor(colors.Text.Color()).
+		SetBorder(true).
+		SetTitle(title).
+		SetTitleColor(colors.Title.Color())
+	editor.SetText(text)
+
+	closeEditor := func() {
+		a.Pages.RemovePage(threadNoteEditorPage)
+		a.restoreFocusAfterModal()
+	}
+	editor.SetKeyHandler(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlS {
+			note := editor.GetText()
+			closeEditor()
+			if strings.TrimSpace(note) != "" || strings.TrimSpace(text) != "" {
+				go a.saveThreadNote(threadID, note, source)
+			}
+			return nil
+		}
+		return event
+	})
+
+	overlay := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(editor, 14, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+	// The editor lets Esc bubble up, so the overlay closes it
+	overlay.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeEditor()
+			return nil
+		}
+		return event
+	})
+	a.Pages.AddPage(threadNoteEditorPage, overlay, true, true)
+	a.markFocus("thread_note")
+	a.SetFocus(editor)
+}
+
+// saveThreadNote pins note to the conversation and refreshes the reader pane
+func (a *App) saveThreadNote(threadID, note, source string) {
+	info, err := a.threadNoteService.Pin(a.ctx, threadID, note, source)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error saving pinned note", err)
+		return
+	}
+	if info == nil {
+		a.GetErrorHandler().ShowSuccess(a.ctx, "📌 Pinned note removed")
+	} else {
+		a.GetErrorHandler().ShowSuccess(a.ctx, "📌 Note pinned to this conversation")
+	}
+	a.refreshPinnedNoteView()
+}
+
+// refreshPinnedNoteView re-renders the open message so a changed note shows immediately
+func (a *App) refreshPinnedNoteView() {
+	if mid := a.GetCurrentMessageID(); mid != "" {
+		a.refreshMessageContent(mid)
+	}
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestFormatPinnedNote(t *testing.T) {
+	note := &services.ThreadNoteInfo{
+		Note:      "  Budget agreed at [50k]; waiting on legal.  ",
+		Source:    services.ThreadNoteAI,
+		UpdatedAt: time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local),
+	}
+
+	got := formatPinnedNote(note, true)
+	if !strings.HasPrefix(got, "📌 Pinned AI summary (updated Mar 4 2026)") {
+		t.Errorf("unexpected heading: %q", got)
+	}
+	if !strings.Contains(got, "Budget agreed at [50k[]; waiting on legal.\n") {
+		t.Errorf("note should be trimmed and escaped for tview markup: %q", got)
+	}
+	if !strings.HasSuffix(got, "\n\n") {
+		t.Errorf("note block should be separated from the body: %q", got)
+	}
+
+	note.Source = services.ThreadNoteManual
+	plain := formatPinnedNote(note, false)
+	if !strings.HasPrefix(plain, "📌 Pinned note ") || !strings.Contains(plain, "[50k];") {
+		t.Errorf("plain block should keep the note unescaped: %q", plain)
+	}
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/ajramos/giztui/internal/services"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// threadSubjectDrift returns where the subject of a thread's messages changes topic
+func (a *App) threadSubjectDrift(messages []*gmailapi.Message) []int {
+	subjects := make([]string, len(messages))
+	for i, m := range messages {
+		subjects[i] = a.emailRenderer.GetHeader(m, "Subject")
+	}
+	return services.SubjectDriftPoints(subjects)
+}
+
+// isThreadSplit reports whether :thread-split split the conversation at its subject changes
+func (a *App) isThreadSplit(threadID string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.splitThreads[threadID]
+}
+
+// threadTreePrefixes returns the tree prefix of each of n expanded messages. Split at the drift
+// points, each topic becomes its own branch: the message that changes the subject starts it
+// with ✂ and the message before it closes the previous one.
+func threadTreePrefixes(n int, drift []int, split bool) []string {
+	starts := make(map[int]bool, len(drift))
+	if split {
+		for _, i := range drift {
+			starts[i] = true
+		}
+	}
+	prefixes := make([]string, n)
+	for i := range prefixes {
+		switch {
+		case starts[i]:
+			prefixes[i] = " ✂> "
+		case i == n-1 || starts[i+1]:
+			prefixes[i] = " └> "
+		default:
+			prefixes[i] = " ├> "
+		}
+	}
+	return prefixes
+}
+
+// offerThreadSplit points out a conversation whose subject changes, once it is expanded
+func (a *App) offerThreadSplit(threadID string, messages []*gmailapi.Message) {
+	drift := a.threadSubjectDrift(messages)
+	if len(drift) == 0 || a.isThreadSplit(threadID) {
+		return
+	}
+	first := messages[drift[0]]
+	subject := services.NormalizeThreadSubject(a.emailRenderer.GetHeader(first, "Subject"))
+	go a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("✂ Topic changes at message %d of %d (%q) — :thread-split separates it", drift[0]+1, len(messages), subject))
+}
+
+// executeThreadSplitCommand handles ':thread-split' — split the selected conversation where its
+// subject changes topic in the threaded view, or join it back
+func (a *App) executeThreadSplitCommand(args []string) {
+	if !a.IsThreadingEnabled() {
+		go a.GetErrorHandler().ShowError(a.ctx, "Threading is disabled in configuration")
+		return
+	}
+	if a.GetCurrentThreadViewMode() != ThreadViewThread {
+		go a.GetErrorHandler().ShowWarning(a.ctx, "Thread split only available in threaded view")
+		return
+	}
+	threadID := a.currentThreadID()
+	if threadID == "" {
+		go a.GetErrorHandler().ShowError(a.ctx, "No conversation selected")
+		return
+	}
+	if a.isThreadSplit(threadID) {
+		a.mu.Lock()
+		delete(a.splitThreads, threadID)
+		a.mu.Unlock()
+		a.refreshTableDisplay()
+		go a.GetErrorHandler().ShowInfo(a.ctx, "✂ Conversation joined back")
+		return
+	}
+	go func() {
+		messages, err := a.fetchThreadMessages(a.ctx, threadID)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading conversation", err)
+			return
+		}
+		drift := a.threadSubjectDrift(messages)
+		if len(drift) == 0 {
+			a.GetErrorHandler().ShowInfo(a.ctx, "The subject does not change in this conversation")
+			return
+		}
+		a.mu.Lock()
+		if a.splitThreads == nil {
+			a.splitThreads = make(map[string]bool)
+		}
+		a.splitThreads[threadID] = true
+		a.mu.Unlock()
+		a.QueueUpdateDraw(func() { a.refreshTableDisplay() })
+		msg := fmt.Sprintf("✂ Conversation split into %d topics", len(drift)+1)
+		if !a.isThreadExpanded(threadID) {
+			msg += " — expand it to see them"
+		}
+		a.GetErrorHandler().ShowSuccess(a.ctx, msg)
+	}()
+}
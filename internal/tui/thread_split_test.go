@@ -0,0 +1,14 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreadTreePrefixes(t *testing.T) {
+	assert.Equal(t, []string{" ├> ", " ├> ", " ├> ", " └> "}, threadTreePrefixes(4, []int{2}, false))
+	assert.Equal(t, []string{" ├> ", " └> ", " ✂> ", " └> "}, threadTreePrefixes(4, []int{2}, true))
+	assert.Equal(t, []string{" └> ", " ✂> ", " ✂> "}, threadTreePrefixes(3, []int{1, 2}, true))
+	assert.Empty(t, threadTreePrefixes(0, nil, true))
+}
@@ -650,6 +650,7 @@ func (a *App) expandThreadAsync(threadID string, isExpanded bool) {
 				a.replaceLoadingWithMessages(table, threadRowIndex+1, threadID, messages)
 				// CRITICAL FIX: Force complete table refresh after expansion
 				a.refreshTableDisplay()
+				a.offerThreadSplit(threadID, messages)
 			})
 
 			// Clear progress status
@@ -1187,7 +1188,7 @@ func (a *App) GenerateThreadSummary() error {
 
 	if summaryOptions.StreamEnabled {
 		// Use streaming summary generation
-		var threadStreamBuilder strings.Builder
+		stream := a.newAIStream()
 		summaryResult, err = threadService.GenerateThreadSummaryStream(a.ctx, threadID, summaryOptions, func(token string) {
 			// CRITICAL: NEVER use QueueUpdateDraw in streaming callbacks — it can
 			// deadlock with the ESC handler. Update the view directly, guarded by ctx.
@@ -1196,9 +1197,9 @@ func (a *App) GenerateThreadSummary() error {
 				return // Exit early if cancelled (e.g. ESC pressed mid-stream)
 			default:
 			}
-			threadStreamBuilder.WriteString(token)
+			text := stream.write(token, "")
 			if a.ctx.Err() == nil && a.aiSummaryView != nil {
-				a.aiSummaryView.SetText(threadStreamBuilder.String())
+				a.aiSummaryView.SetText(text)
 				a.aiSummaryView.ScrollToEnd()
 				a.ForceDraw()
 			}
@@ -1218,7 +1219,7 @@ func (a *App) GenerateThreadSummary() error {
 		}
 
 		if summaryResult.FromCache {
-			a.GetErrorHandler().ShowInfo(a.ctx, "🧠 Thread summary loaded from cache")
+			a.GetErrorHandler().ShowDetail(a.ctx, "🧠 Thread summary loaded from cache")
 		} else {
 			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🧠 Thread summary generated (%d messages)", summaryResult.MessageCount))
 		}
@@ -1228,10 +1229,8 @@ func (a *App) GenerateThreadSummary() error {
 	if err == nil {
 		a.QueueUpdateDraw(func() {
 			if a.aiSummaryView != nil {
-				if !summaryOptions.StreamEnabled {
-					a.aiSummaryView.SetText(summaryResult.Summary)
-				}
 				a.showAIPanel()
+				a.showAIResult(summaryResult.Summary)
 			}
 		})
 	}
@@ -1251,7 +1250,7 @@ func (a *App) showAIPanel() {
 
 	// Update layout to show the AI panel
 	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
-		split.ResizeItem(a.aiSummaryView, 0, 1)
+		a.showSidePanel(split, a.aiSummaryView, "ai")
 	}
 }
 
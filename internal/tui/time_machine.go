@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// timeMachineListedDays is how many snapshot days :timemachine list shows
+const timeMachineListedDays = 10
+
+// bindTimeMachine (re)creates the inbox snapshot service for the current account and takes
+// today's snapshot if it is missing
+func (a *App) bindTimeMachine() {
+	if a.dbStore == nil || a.Client == nil {
+		return
+	}
+	svc := services.NewTimeMachineService(db.NewInboxSnapshotStore(a.dbStore), a.Client, a.Config.TimeMachine)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.timeMachineService = svc
+	go a.captureInboxSnapshot(false)
+}
+
+// captureInboxSnapshot takes today's snapshot (once a day unless forced); only a forced capture
+// reports back in the status bar
+func (a *App) captureInboxSnapshot(force bool) {
+	svc := a.timeMachineService
+	if svc == nil {
+		return
+	}
+	if force {
+		a.GetErrorHandler().ShowProgress(a.ctx, "🕰️ Taking inbox snapshot…")
+	}
+	snap, err := svc.Capture(a.ctx, force)
+	if force {
+		a.GetErrorHandler().ClearProgress()
+	}
+	if err != nil {
+		if force {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error taking inbox snapshot", err)
+		} else if a.logger != nil {
+			a.logger.Printf("captureInboxSnapshot: %v", err)
+		}
+		return
+	}
+	if snap == nil {
+		return
+	}
+	if force {
+		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("🕰️ Snapshot %s: %d message(s) in the inbox, %d unread", snap.Day, snap.Messages, snap.Unread))
+	} else if a.logger != nil {
+		a.logger.Printf("captureInboxSnapshot: %s, %d messages, %d unread", snap.Day, snap.Messages, snap.Unread)
+	}
+}
+
+// executeTimeMachineCommand handles :timemachine <date>|list|capture
+func (a *App) executeTimeMachineCommand(args []string) {
+	if a.timeMachineService == nil {
+		a.showError("Time machine not available (no local database)")
+		return
+	}
+	if len(args) == 0 {
+		a.showError("Usage: timemachine <date: YYYY-MM-DD, yesterday, last fri, 10d>|list|capture")
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "list", "days":
+		go a.listTimeMachineDays()
+		return
+	case "capture", "snapshot":
+		go a.captureInboxSnapshot(true)
+		return
+	}
+	date, err := a.parsePastQuickDate(strings.Join(args, " "))
+	if err != nil {
+		a.showError(err.Error())
+		return
+	}
+	go a.showTimeMachine(date)
+}
+
+// listTimeMachineDays shows the most recent snapshot days
+func (a *App) listTimeMachineDays() {
+	days, err := a.timeMachineService.Days(a.ctx)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error listing snapshots", err)
+		return
+	}
+	if len(days) == 0 {
+		a.GetErrorHandler().ShowInfo(a.ctx, "🕰️ No inbox snapshots yet")
+		return
+	}
+	a.GetErrorHandler().ShowInfo(a.ctx, formatTimeMachineDays(days))
+}
+
+// formatTimeMachineDays summarizes the snapshot days, newest first
+func formatTimeMachineDays(days []string) string {
+	shown := days
+	if len(shown) > timeMachineListedDays {
+		shown = shown[:timeMachineListedDays]
+	}
+	msg := fmt.Sprintf("🕰️ %d snapshot(s): %s", len(days), strings.Join(shown, ", "))
+	if len(days) > len(shown) {
+		msg += fmt.Sprintf(" … oldest %s", days[len(days)-1])
+	}
+	return msg
+}
+
+// showTimeMachine lists the inbox as recorded by the snapshot closest to date. The view behaves
+// like search results: Esc returns to the live inbox.
+func (a *App) showTimeMachine(date time.Time) {
+	view, err := a.timeMachineService.At(a.ctx, date)
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Time machine", err)
+		return
+	}
+
+	a.crossSearch.reset()
+	a.alerts.reset()
+	ids := make([]string, len(view.Messages))
+	for i, m := range view.Messages {
+		ids[i] = m.Id
+	}
+	a.SetMessageIDs(ids)
+	a.mu.Lock()
+	a.messagesMeta = view.Messages
+	a.mu.Unlock()
+	a.nextPageToken = ""
+	a.search.SetMode("remote")
+	a.search.SetQuery("")
+	a.emailRenderer.SetShowSystemLabelsInList(false)
+
+	title := timeMachineTitle(view)
+	a.QueueUpdateDraw(func() {
+		a.refreshTableDisplay()
+		if table, ok := a.views["list"].(*tview.Table); ok {
+			table.SetTitle(title)
+			if table.GetRowCount() > 1 && (a.compositionPanel == nil || !a.compositionPanel.IsVisible()) {
+				table.Select(1, 0)
+				if len(a.ids) > 0 {
+					firstID := a.ids[0]
+					a.SetCurrentMessageID(firstID)
+					go a.showMessageWithoutFocus(firstID)
+				}
+			}
+		}
+		a.markFocus("list")
+		a.SetFocus(a.views["list"])
+	})
+	a.GetErrorHandler().ShowInfo(a.ctx, "🕰️ Time machine view — messages deleted since cannot be opened; Esc returns to the inbox")
+}
+
+// timeMachineTitle names the snapshot shown, noting when it predates the requested day
+func timeMachineTitle(view *services.TimeMachineView) string {
+	requested := view.Requested.Format("2006-01-02")
+	when := requested
+	if view.SnapshotDay != requested {
+		when = fmt.Sprintf("%s (snapshot of %s)", requested, view.SnapshotDay)
+	}
+	return fmt.Sprintf(" 🕰️ Inbox as of %s — %d message(s), %d unread ", when, len(view.Messages), view.Unread)
+}
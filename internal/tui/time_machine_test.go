@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestTimeMachineTitle(t *testing.T) {
+	view := &services.TimeMachineView{
+		Requested:   time.Date(2026, 7, 3, 0, 0, 0, 0, time.Local),
+		SnapshotDay: "2026-07-01",
+		Unread:      1,
+	}
+	want := " 🕰️ Inbox as of 2026-07-03 (snapshot of 2026-07-01) — 0 message(s), 1 unread "
+	if got := timeMachineTitle(view); got != want {
+		t.Errorf("timeMachineTitle = %q, want %q", got, want)
+	}
+	view.SnapshotDay = "2026-07-03"
+	want = " 🕰️ Inbox as of 2026-07-03 — 0 message(s), 1 unread "
+	if got := timeMachineTitle(view); got != want {
+		t.Errorf("timeMachineTitle = %q, want %q", got, want)
+	}
+}
+
+func TestFormatTimeMachineDays(t *testing.T) {
+	days := []string{"2026-07-12", "2026-07-11", "2026-07-10", "2026-07-09", "2026-07-08", "2026-07-07",
+		"2026-07-06", "2026-07-05", "2026-07-04", "2026-07-03", "2026-07-02", "2026-07-01"}
+	got := formatTimeMachineDays(days)
+	want := "🕰️ 12 snapshot(s): 2026-07-12, 2026-07-11, 2026-07-10, 2026-07-09, 2026-07-08, 2026-07-07, 2026-07-06, 2026-07-05, 2026-07-04, 2026-07-03 … oldest 2026-07-01"
+	if got != want {
+		t.Errorf("formatTimeMachineDays = %q, want %q", got, want)
+	}
+}
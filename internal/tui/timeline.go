@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// timelinePage is the Pages name of the contact timeline
+const timelinePage = "contactTimeline"
+
+// executeTimelineCommand handles ':timeline [email]' — every message exchanged with a contact, in
+// order; without an address, the other party of the current message
+func (a *App) executeTimelineCommand(args []string) {
+	if a.Client == nil {
+		a.showError("❌ Gmail client not available")
+		return
+	}
+	contact := ""
+	if len(args) > 0 {
+		contact = strings.Join(args, " ")
+	}
+	id := a.getCurrentMessageID()
+	if contact == "" && id == "" {
+		a.showError("Usage: timeline <email> (or select a message)")
+		return
+	}
+	go func() {
+		if contact == "" {
+			if contact = a.currentCounterpart(id); contact == "" {
+				a.GetErrorHandler().ShowError(a.ctx, "Could not read the sender of the current message")
+				return
+			}
+		}
+		a.GetErrorHandler().ShowProgress(a.ctx, "🕰 Building the timeline with "+contact+"…")
+		svc := services.NewTimelineService(a.Client, a.getActiveAccountEmail())
+		if a.localArchiveService != nil {
+			svc.SetLocalArchive(a.localArchiveService)
+		}
+		t, err := svc.Build(a.ctx, contact)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error building timeline", err)
+			return
+		}
+		if len(t.Entries) == 0 {
+			a.GetErrorHandler().ShowInfo(a.ctx, "🕰 No messages exchanged with "+t.Contact)
+			return
+		}
+		a.QueueUpdateDraw(func() { a.openTimeline(t) })
+	}()
+}
+
+// currentCounterpart is the other party of a message: its sender, or its first recipient when
+// the account sent it
+func (a *App) currentCounterpart(id string) string {
+	sender := a.currentSenderAddress(id)
+	self := strings.ToLower(a.getActiveAccountEmail())
+	if sender == "" || strings.ToLower(sender) != self {
+		return sender
+	}
+	meta, err := a.messageClient(id).GetMessage(id)
+	if err != nil {
+		return ""
+	}
+	if to, err := mail.ParseAddressList(extractHeaderValue(meta, "To")); err == nil && len(to) > 0 {
+		return to[0].Address
+	}
+	return ""
+}
+
+// formatTimelineTitle is the timeline title: contact, counts and time span
+func formatTimelineTitle(t *services.ContactTimeline) string {
+	sent, received := t.Counts()
+	first, last := t.Entries[0].Date, t.Entries[len(t.Entries)-1].Date
+	span := first.Format("Jan 2006")
+	if end := last.Format("Jan 2006"); end != span {
+		span += " – " + end
+	}
+	more := ""
+	if t.Truncated {
+		more = "+"
+	}
+	return fmt.Sprintf(" 🕰 %s · %d%s messages (%d sent, %d received) · %s ", t.Contact, len(t.Entries), more, sent, received, span)
+}
+
+// formatTimelineEntry renders a timeline row: date, direction (→ sent, ← received), subject; the
+// secondary line is the snippet
+func formatTimelineEntry(e services.TimelineEntry) (string, string) {
+	dir := "←"
+	if e.Outgoing {
+		dir = "→"
+	}
+	subject := strings.TrimSpace(e.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	primary := fmt.Sprintf("%s  %s %s", e.Date.Format("2006-01-02 15:04"), dir, subject)
+	secondary := strings.TrimSpace(e.Snippet)
+	if e.Archived {
+		primary += "  📦"
+		secondary = "in the local archive (:la search)"
+	}
+	return primary, strings.Repeat(" ", len("2006-01-02 15:04  ")) + secondary
+}
+
+// openTimeline shows a contact timeline, newest message selected; Enter opens a message in the list
+// or the reader. Must run on the UI goroutine.
+func (a *App) openTimeline(t *services.ContactTimeline) {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSecondaryTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+	for _, e := range t.Entries {
+		primary, secondary := formatTimelineEntry(e)
+		list.AddItem(tview.Escape(primary), tview.Escape(secondary), 0, nil)
+	}
+	list.SetCurrentItem(len(t.Entries) - 1)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" → sent  ← received  📦 archived locally  |  Enter to open  |  Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true).
+		SetTitle(formatTimelineTitle(t)).
+		SetTitleColor(colors.Title.Color()).
+		SetBorderColor(colors.Border.Color())
+
+	closeTimeline := func() {
+		a.Pages.RemovePage(timelinePage)
+		a.restoreFocusAfterModal()
+	}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i < 0 || i >= len(t.Entries) {
+			return
+		}
+		e := t.Entries[i]
+		if e.Archived {
+			a.showInfo("📦 Archived locally — find it with :la search " + t.Contact)
+			return
+		}
+		closeTimeline()
+		a.jumpToMessage(e.MessageID, "Message is not in the current list — showing it in the reader")
+	})
+	list.SetInputCapture(func(ev *tcell.EventKey) *tcell.EventKey {
+		if ev.Key() == tcell.KeyEscape || ev.Rune() == 'q' {
+			closeTimeline()
+			return nil
+		}
+		return ev
+	})
+
+	a.Pages.AddPage(timelinePage, tview.NewFlex().
+		AddItem(nil, 2, 0, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(container, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 1, true).
+		AddItem(nil, 2, 0, false), true, true)
+	a.SetFocus(list)
+}
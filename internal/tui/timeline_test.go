@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTimelineEntry(t *testing.T) {
+	date := time.Date(2025, 4, 3, 9, 30, 0, 0, time.UTC)
+	primary, secondary := formatTimelineEntry(services.TimelineEntry{Subject: "Quote", Snippet: " Here it is ", Date: date, Outgoing: true})
+	assert.Equal(t, "2025-04-03 09:30  → Quote", primary)
+	assert.Equal(t, "                  Here it is", secondary)
+
+	primary, secondary = formatTimelineEntry(services.TimelineEntry{Date: date, Archived: true})
+	assert.Equal(t, "2025-04-03 09:30  ← (no subject)  📦", primary)
+	assert.Equal(t, "                  in the local archive (:la search)", secondary)
+}
+
+func TestFormatTimelineTitle(t *testing.T) {
+	tl := &services.ContactTimeline{Contact: "ana@example.com", Entries: []services.TimelineEntry{
+		{Date: time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC), Outgoing: true},
+	}}
+	assert.Equal(t, " 🕰 ana@example.com · 2 messages (1 sent, 1 received) · Nov 2024 – Apr 2025 ", formatTimelineTitle(tl))
+
+	tl.Entries, tl.Truncated = tl.Entries[1:], true
+	assert.Equal(t, " 🕰 ana@example.com · 1+ messages (1 sent, 0 received) · Apr 2025 ", formatTimelineTitle(tl))
+}
@@ -0,0 +1,320 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/gmail"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tcell/v2"
+	"github.com/derailed/tview"
+)
+
+// bindTodos (re)creates the action items service for the active account
+func (a *App) bindTodos() {
+	if a.dbStore == nil {
+		return
+	}
+	svc := services.NewTodoService(db.NewTodoStore(a.dbStore), a.aiService)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.todoService = svc
+}
+
+// formatTodoItem renders a todos panel row: status box, task, owner and due date; the secondary
+// line names the source message
+func formatTodoItem(it services.TodoItem) (string, string) {
+	marker := "☐"
+	switch it.Status {
+	case services.TodoDone:
+		marker = "☑"
+	case services.TodoDismissed:
+		marker = "✗"
+	}
+	primary := marker + " " + it.Task
+	var meta []string
+	if it.Owner != "" {
+		meta = append(meta, "👤 "+it.Owner)
+	}
+	if it.Due != "" {
+		meta = append(meta, "📅 "+it.Due)
+	}
+	if len(meta) > 0 {
+		primary += "  " + strings.Join(meta, " · ")
+	}
+	subject := strings.TrimSpace(it.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	return primary, fmt.Sprintf("   ✉ %s · %s", subject, it.CreatedAt.Format("Jan 2"))
+}
+
+// executeTodosCommand handles :todos [all|extract [thread]|clear] — open the action items panel,
+// extract the items of the selected message or its whole conversation, or delete the closed ones
+func (a *App) executeTodosCommand(args []string) {
+	if a.todoService == nil {
+		a.showError("Action items not available (no local database)")
+		return
+	}
+	action := ""
+	if len(args) > 0 {
+		action = strings.ToLower(args[0])
+	}
+	switch action {
+	case "":
+		a.openTodosPanel(false)
+	case "all":
+		a.openTodosPanel(true)
+	case "extract", "x":
+		thread := len(args) > 1 && strings.EqualFold(args[1], "thread")
+		a.extractTodos(thread)
+	case "clear":
+		go func() {
+			n, err := a.todoService.ClearClosed(a.ctx)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error clearing action items", err)
+				return
+			}
+			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("Cleared %d done or dismissed action item(s)", n))
+		}()
+	default:
+		a.showError("Usage: todos [all|extract [thread]|clear]")
+	}
+}
+
+// extractTodos asks the AI for the action items of the selected message, or of its whole
+// conversation, and stores them for the todos panel
+func (a *App) extractTodos(thread bool) {
+	if a.aiService == nil {
+		a.showError(a.aiUnavailableText("extract action items"))
+		return
+	}
+	id := a.getCurrentSelectedMessageID()
+	if id == "" {
+		a.showError("❌ No message selected")
+		return
+	}
+	threadID := ""
+	if thread {
+		threadID = a.currentThreadID()
+	}
+	go func() {
+		src, err := a.todoSource(id, threadID)
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading message", err)
+			return
+		}
+		a.GetErrorHandler().ShowProgress(a.ctx, "🧠 Extracting action items…")
+		found, added, err := a.todoService.Extract(a.ctx, src)
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error extracting action items", err)
+			return
+		}
+		switch {
+		case found == 0:
+			a.GetErrorHandler().ShowInfo(a.ctx, "No action items found")
+		case added == 0:
+			a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("✅ %d action item(s), all already in :todos", found))
+		default:
+			a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("✅ Added %d action item(s) to :todos", added))
+		}
+		a.QueueUpdateDraw(func() {
+			if a.todosReload != nil {
+				a.todosReload()
+			}
+		})
+	}()
+}
+
+// todoSource loads the text to extract action items from: the message, or every message of the
+// conversation when threadID is set. The items link back to messageID either way.
+func (a *App) todoSource(messageID, threadID string) (services.TodoSource, error) {
+	m, err := a.messageClient(messageID).GetMessageWithContent(messageID)
+	if err != nil {
+		return services.TodoSource{}, err
+	}
+	src := services.TodoSource{MessageID: messageID, ThreadID: m.ThreadId, Subject: m.Subject, Content: m.PlainText}
+	if threadID == "" {
+		return src, nil
+	}
+	threadService := a.getThreadService()
+	if threadService == nil {
+		return src, fmt.Errorf("thread service not available")
+	}
+	messages, err := threadService.GetThreadMessages(a.ctx, threadID, services.MessageQueryOptions{Format: "full", SortOrder: "asc"})
+	if err != nil {
+		return src, err
+	}
+	var b strings.Builder
+	for i, msg := range messages {
+		fmt.Fprintf(&b, "---MESSAGE %d (from %s)---\n", i+1, extractHeaderValue(msg, "From"))
+		b.WriteString(gmail.ExtractPlainText(msg))
+		b.WriteString("\n")
+	}
+	src.ThreadID = threadID
+	src.Content = b.String()
+	return src, nil
+}
+
+// openTodosPanel shows the action items in the side panel: Enter jumps to the source message,
+// x toggles done, d dismisses, a shows or hides the closed items
+func (a *App) openTodosPanel(all bool) {
+	colors := a.GetComponentColors("general")
+	bgColor := colors.Background.Color()
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(false)
+	list.SetBackgroundColor(bgColor)
+	list.SetMainTextColor(colors.Text.Color())
+	list.SetSecondaryTextColor(colors.Text.Color())
+	list.SetSelectedTextColor(bgColor)
+	list.SetSelectedBackgroundColor(colors.Accent.Color())
+
+	container := tview.NewFlex().SetDirection(tview.FlexRow)
+	setTitle := func(n int) {
+		scope := "open"
+		if all {
+			scope = "all"
+		}
+		container.SetTitle(fmt.Sprintf(" ✅ Action items (%d %s) ", n, scope))
+	}
+
+	var items []services.TodoItem
+	render := func(loaded []services.TodoItem) {
+		items = loaded
+		cur := list.GetCurrentItem()
+		list.Clear()
+		setTitle(len(items))
+		if len(items) == 0 {
+			list.AddItem("No action items — :todos extract on a message adds them", "", 0, nil)
+			return
+		}
+		for _, it := range items {
+			primary, secondary := formatTodoItem(it)
+			list.AddItem(tview.Escape(primary), tview.Escape(secondary), 0, nil)
+		}
+		if cur >= 0 && cur < list.GetItemCount() {
+			list.SetCurrentItem(cur)
+		}
+	}
+	reload := func() {
+		go func() {
+			loaded, err := a.todoService.List(a.ctx, all)
+			if err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error loading action items", err)
+				return
+			}
+			a.QueueUpdateDraw(func() { render(loaded) })
+		}()
+	}
+	setStatus := func(it services.TodoItem, status string) {
+		go func() {
+			if err := a.todoService.SetStatus(a.ctx, it.ID, status); err != nil {
+				a.GetErrorHandler().ShowErrorFor(a.ctx, "Error updating action item", err)
+			}
+			reload()
+		}()
+	}
+	list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		if i >= 0 && i < len(items) {
+			a.closeTodosPanel()
+			a.jumpToTodoSource(items[i])
+		}
+	})
+
+	list.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if e.Key() == tcell.KeyEscape {
+			a.closeTodosPanel()
+			return nil
+		}
+		if e.Rune() == 'a' {
+			all = !all
+			reload()
+			return nil
+		}
+		idx := list.GetCurrentItem()
+		if idx < 0 || idx >= len(items) {
+			return e
+		}
+		switch e.Rune() {
+		case 'x', ' ':
+			status := services.TodoDone
+			if items[idx].Status == services.TodoDone {
+				status = services.TodoOpen
+			}
+			setStatus(items[idx], status)
+			return nil
+		case 'd':
+			status := services.TodoDismissed
+			if items[idx].Status == services.TodoDismissed {
+				status = services.TodoOpen
+			}
+			setStatus(items[idx], status)
+			return nil
+		}
+		return e
+	})
+
+	container.SetBackgroundColor(bgColor)
+	container.SetBorder(true)
+	container.SetBorderColor(colors.Border.Color())
+	container.SetTitleColor(colors.Title.Color())
+	setTitle(0)
+	container.AddItem(list, 0, 1, true)
+
+	footer := tview.NewTextView().SetTextAlign(tview.AlignRight)
+	footer.SetText(" Enter to open message | x done | d dismiss | a show all | Esc to close ")
+	footer.SetTextColor(colors.Text.Color())
+	footer.SetBackgroundColor(bgColor)
+	container.AddItem(footer, 1, 0, false)
+
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		if a.labelsView != nil {
+			split.RemoveItem(a.labelsView)
+		}
+		a.labelsView = container
+		split.AddItem(a.labelsView, 0, 1, true)
+		a.showSidePanel(split, a.labelsView, "todos")
+	}
+	a.todosReload = reload
+	a.markFocus("labels")
+	a.setActivePicker(PickerTodos)
+	a.SetFocus(list)
+	reload()
+}
+
+// closeTodosPanel closes the action items panel and restores focus
+func (a *App) closeTodosPanel() {
+	if split, ok := a.views["contentSplit"].(*tview.Flex); ok {
+		split.ResizeItem(a.labelsView, 0, 0)
+	}
+	a.todosReload = nil
+	a.setActivePicker(PickerNone)
+	a.restoreFocusAfterModal()
+}
+
+// jumpToTodoSource selects the item's source message in the list and opens it
+func (a *App) jumpToTodoSource(it services.TodoItem) {
+	a.jumpToMessage(it.MessageID, "Source message is not in the current list — showing it in the reader")
+}
+
+// jumpToMessage selects a message in the list and opens it; a message that is not in the current
+// list is opened in the reader alone, with notInList shown
+func (a *App) jumpToMessage(id, notInList string) {
+	row := -1
+	a.mu.RLock()
+	for i, mid := range a.ids {
+		if mid == id {
+			row = i
+			break
+		}
+	}
+	a.mu.RUnlock()
+	if table, ok := a.views["list"].(*tview.Table); ok && row >= 0 {
+		table.Select(row, 0)
+	} else {
+		go a.GetErrorHandler().ShowInfo(a.ctx, notInList)
+	}
+	a.showMessage(id)
+}
@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTodoItem(t *testing.T) {
+	created := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	primary, secondary := formatTodoItem(services.TodoItem{Task: "Send the slides", Owner: "me", Due: "Friday", Subject: "Offsite", Status: services.TodoOpen, CreatedAt: created})
+	assert.Equal(t, "☐ Send the slides  👤 me · 📅 Friday", primary)
+	assert.Equal(t, "   ✉ Offsite · Mar 4", secondary)
+
+	primary, secondary = formatTodoItem(services.TodoItem{Task: "Book the room", Status: services.TodoDone, CreatedAt: created})
+	assert.Equal(t, "☑ Book the room", primary)
+	assert.Equal(t, "   ✉ (no subject) · Mar 4", secondary)
+
+	primary, _ = formatTodoItem(services.TodoItem{Task: "Reply", Status: services.TodoDismissed, CreatedAt: created})
+	assert.Equal(t, "✗ Reply", primary)
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// restoreFolderID is the move panel entry that restores Trash/Spam messages to their original labels
+const restoreFolderID = "RESTORE_ORIGIN"
+
+// bindTrashRestore (re)creates the restore service for the current account and database and has
+// the email service record where messages were before they go to Trash or Spam
+func (a *App) bindTrashRestore() {
+	if a.dbStore == nil || a.Client == nil {
+		return
+	}
+	svc := services.NewTrashRestoreService(db.NewTrashOriginStore(a.dbStore), a.Client, a.repository)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	a.trashRestoreService = svc
+	if emailServiceImpl, ok := a.emailService.(*services.EmailServiceImpl); ok {
+		emailServiceImpl.SetTrashOriginRecorder(svc)
+	}
+	go func() {
+		if err := svc.Prune(a.ctx); err != nil && a.logger != nil {
+			a.logger.Printf("bindTrashRestore: prune failed: %v", err)
+		}
+	}()
+}
+
+// executeRestoreCommand handles :restore — move the selected messages (or the current one) out
+// of Trash/Spam back to the labels they had when trashed, or the inbox
+func (a *App) executeRestoreCommand(args []string) {
+	if a.trashRestoreService == nil {
+		a.showError("Restore not available (no local database)")
+		return
+	}
+	if len(args) > 0 {
+		a.showError("Usage: restore")
+		return
+	}
+	var ids []string
+	if a.bulk.isMode() && a.bulk.count() > 0 {
+		ids = append(ids, a.bulk.ids()...)
+	} else if id := a.getCurrentSelectedMessageID(); id != "" {
+		ids = []string{id}
+	}
+	if len(ids) == 0 {
+		a.showError("❌ No message selected")
+		return
+	}
+	go a.restoreMessages(ids)
+}
+
+// restoreMessages runs the restore with progress in the status bar, then drops the restored
+// messages from a Trash/Spam listing
+func (a *App) restoreMessages(ids []string) {
+	a.GetErrorHandler().ShowProgress(a.ctx, fmt.Sprintf("♻️ Restoring %d message(s)…", len(ids)))
+	res, err := a.trashRestoreService.Restore(a.ctx, ids, a.bulkProgress(a.ctx, "♻️ Restoring"))
+	a.GetErrorHandler().ClearProgress()
+	if err != nil {
+		a.GetErrorHandler().ShowErrorFor(a.ctx, "Error restoring messages", err)
+		return
+	}
+
+	failed := make(map[string]bool, len(res.Failed))
+	for _, id := range res.Failed {
+		failed[id] = true
+	}
+	done := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !failed[id] {
+			done = append(done, id)
+		}
+	}
+	viewingBin := isTrashOrSpamQuery(a.GetCurrentQuery())
+	a.QueueUpdateDraw(func() {
+		if viewingBin {
+			a.removeIDsFromCurrentList(done)
+		}
+		if a.bulk.isMode() {
+			a.finishBulkJob(done)
+			a.refreshTableDisplay()
+			if list, ok := a.views["list"].(*tview.Table); ok {
+				list.SetSelectedStyle(a.getSelectionStyle())
+			}
+		}
+	})
+
+	switch {
+	case len(res.Failed) > 0:
+		a.GetErrorHandler().ShowWarning(a.ctx, fmt.Sprintf("♻️ Restored %d, %d failed: %s", res.Restored, len(res.Failed), res.Errors[0]))
+	case res.Restored == 0:
+		a.GetErrorHandler().ShowInfo(a.ctx, "Nothing to restore: the message(s) are not in Trash or Spam")
+	default:
+		a.GetErrorHandler().ShowSuccess(a.ctx, formatRestoreResult(res))
+	}
+}
+
+// formatRestoreResult summarizes where restored messages went
+func formatRestoreResult(res *services.RestoreResult) string {
+	var parts []string
+	if res.FromRecord > 0 {
+		parts = append(parts, fmt.Sprintf("%d to their original labels", res.FromRecord))
+	}
+	if res.Inferred > 0 {
+		parts = append(parts, fmt.Sprintf("%d inferred (inbox, or Sent for your own mail)", res.Inferred))
+	}
+	return fmt.Sprintf("♻️ Restored %d message(s): %s", res.Restored, strings.Join(parts, ", "))
+}
+
+// isTrashOrSpamQuery reports whether a search lists Trash or Spam (negated terms such as the
+// inbox's -in:trash do not count)
+func isTrashOrSpamQuery(query string) bool {
+	for _, tok := range strings.Fields(strings.ToLower(query)) {
+		switch strings.Trim(tok, "()") {
+		case "in:trash", "in:spam", "label:trash", "label:spam":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestIsTrashOrSpamQuery(t *testing.T) {
+	tests := map[string]bool{
+		"in:trash":                             true,
+		"IN:SPAM":                              true,
+		"(label:trash)":                        true,
+		"from:bob in:trash":                    true,
+		"-in:trash in:inbox -in:spam -in:chat": false,
+		"subject:in:trashcan":                  false,
+		"":                                     false,
+	}
+	for q, want := range tests {
+		if got := isTrashOrSpamQuery(q); got != want {
+			t.Errorf("isTrashOrSpamQuery(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestFormatRestoreResult(t *testing.T) {
+	got := formatRestoreResult(&services.RestoreResult{Restored: 3, FromRecord: 2, Inferred: 1})
+	want := "♻️ Restored 3 message(s): 2 to their original labels, 1 inferred (inbox, or Sent for your own mail)"
+	if got != want {
+		t.Errorf("formatRestoreResult = %q, want %q", got, want)
+	}
+}
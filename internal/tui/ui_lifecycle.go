@@ -1,6 +1,12 @@
 package tui
 
-import "sync/atomic"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // uiLifecycle holds startup/welcome lifecycle flags extracted from the App god object. Both are
 // touched from the welcome-screen goroutine and the event loop, so they are atomic.Bool (previously
@@ -8,4 +14,70 @@ import "sync/atomic"
 type uiLifecycle struct {
 	ready            atomic.Bool
 	welcomeAnimating atomic.Bool
+	boot             bootTimeline
+	// wiring serializes what (re)wires the services off the event loop: AttachLLM,
+	// AttachCalendar, openAccountDatabase and the account switch
+	wiring sync.Mutex
+}
+
+// bootTimeline records when each startup step finished, measured from NewApp, so the log shows
+// where time-to-inbox goes. Steps finish on different goroutines.
+type bootTimeline struct {
+	mu    sync.Mutex
+	start time.Time
+	steps []bootStep
+}
+
+type bootStep struct {
+	name string
+	at   time.Duration
+}
+
+// begin starts the clock
+func (b *bootTimeline) begin(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.start = now
+	b.steps = nil
+}
+
+// mark records the first time a step finishes; it reports false when the step was already
+// recorded or the clock never started
+func (b *bootTimeline) mark(name string, now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.start.IsZero() {
+		return 0, false
+	}
+	for _, s := range b.steps {
+		if s.name == name {
+			return s.at, false
+		}
+	}
+	at := now.Sub(b.start)
+	b.steps = append(b.steps, bootStep{name: name, at: at})
+	return at, true
+}
+
+// done reports whether a step has finished
+func (b *bootTimeline) done(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.steps {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// summary lists the recorded steps in the order they finished, e.g. "ui 35ms, inbox 820ms"
+func (b *bootTimeline) summary() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	parts := make([]string, 0, len(b.steps))
+	for _, s := range b.steps {
+		parts = append(parts, fmt.Sprintf("%s %s", s.name, s.at.Round(time.Millisecond)))
+	}
+	return strings.Join(parts, ", ")
 }
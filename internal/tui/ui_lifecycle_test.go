@@ -1,6 +1,9 @@
 package tui
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestUILifecycle_Defaults(t *testing.T) {
 	var lc uiLifecycle
@@ -17,3 +20,23 @@ func TestUILifecycle_Defaults(t *testing.T) {
 		t.Errorf("expected ready to be true after Store(true), got false")
 	}
 }
+
+func TestBootTimeline_MarksEachStepOnce(t *testing.T) {
+	var b bootTimeline
+	t0 := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	if _, ok := b.mark("ui", t0); ok {
+		t.Fatal("steps before begin should not be recorded")
+	}
+
+	b.begin(t0)
+	if at, ok := b.mark("ui", t0.Add(40*time.Millisecond)); !ok || at != 40*time.Millisecond {
+		t.Fatalf("mark(ui) = %v, %v", at, ok)
+	}
+	b.mark("inbox", t0.Add(900*time.Millisecond))
+	if at, ok := b.mark("ui", t0.Add(2*time.Second)); ok || at != 40*time.Millisecond {
+		t.Fatalf("a repeated step should keep its first time, got %v, %v", at, ok)
+	}
+	if got := b.summary(); got != "ui 40ms, inbox 900ms" {
+		t.Fatalf("summary = %q", got)
+	}
+}
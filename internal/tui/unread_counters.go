@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajramos/giztui/internal/services"
+	"github.com/derailed/tview"
+)
+
+// counterFolderNames are the display names of the system labels a list title can count
+var counterFolderNames = map[string]string{
+	"INBOX":     "Inbox",
+	"SENT":      "Sent",
+	"DRAFT":     "Drafts",
+	"SPAM":      "Spam",
+	"TRASH":     "Trash",
+	"STARRED":   "Starred",
+	"IMPORTANT": "Important",
+}
+
+// counterQueryLabels maps single-term folder queries to their system label
+var counterQueryLabels = map[string]string{
+	"in:inbox":     "INBOX",
+	"in:sent":      "SENT",
+	"in:draft":     "DRAFT",
+	"in:drafts":    "DRAFT",
+	"in:spam":      "SPAM",
+	"in:trash":     "TRASH",
+	"is:starred":   "STARRED",
+	"is:important": "IMPORTANT",
+}
+
+// bindUnreadCounters (re)creates the unread counters of the current account: INBOX plus the
+// configured labels
+func (a *App) bindUnreadCounters() {
+	if a.Client == nil || !a.Config.UnreadCounters.Enabled {
+		a.unreadCounters = nil
+		return
+	}
+	svc := services.NewUnreadCounterService(a.Client, "INBOX")
+	a.unreadCounters = svc
+	go func() {
+		// Resolving names may list the labels, so it stays off the caller's goroutine
+		for _, name := range a.Config.UnreadCounters.Labels {
+			svc.Track(a.counterLabelID(name))
+		}
+		a.refreshUnreadCounters()
+	}()
+}
+
+// startUnreadCounters checks for count changes every configured interval. Idempotent; the loop
+// follows the counters across account switches.
+func (a *App) startUnreadCounters() {
+	if !a.Config.UnreadCounters.Enabled || !a.unreadCountersStarted.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.Config.UnreadCounters.ResolvedInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+				a.refreshUnreadCounters()
+			}
+		}
+	}()
+}
+
+// refreshUnreadCounters rereads the counts that changed and re-titles the list when one moved
+func (a *App) refreshUnreadCounters() {
+	counters := a.unreadCounters
+	if counters == nil {
+		return
+	}
+	changed, err := counters.Refresh(a.ctx)
+	if err != nil && a.logger != nil {
+		a.logger.Printf("UNREAD_COUNTERS: refresh failed: %v", err)
+	}
+	if changed {
+		a.QueueUpdateDraw(a.refreshMessagesTitle)
+	}
+}
+
+// refreshMessagesTitle re-titles the plain message list, leaving loading, search, filter and
+// thread titles alone. Must run on the UI goroutine.
+func (a *App) refreshMessagesTitle() {
+	if a.IsMessagesLoading() || a.search.Mode() != "" {
+		return
+	}
+	if a.IsThreadingEnabled() && a.GetCurrentThreadViewMode() == ThreadViewThread {
+		return
+	}
+	if table, ok := a.views["list"].(*tview.Table); ok {
+		table.SetTitle(a.messagesTitle())
+	}
+}
+
+// messagesTitle is the message list title: the folder with its unread and total counts when
+// they are known ("📧 Inbox (12 unread / 243)"), otherwise the number of loaded messages
+func (a *App) messagesTitle() string {
+	if counters := a.unreadCounters; counters != nil {
+		if id := a.viewedCounterLabel(); id != "" {
+			if c, ok := counters.Count(id); ok {
+				return formatCounterTitle(a.counterLabelName(id), c)
+			}
+			// First time this folder is viewed: count it and re-title once the count is in
+			counters.Track(id)
+			go a.refreshUnreadCounters()
+		}
+	}
+	return fmt.Sprintf(" 📧 Messages (%d) ", len(a.ids))
+}
+
+// formatCounterTitle renders a list title from a label's counts
+func formatCounterTitle(name string, c services.UnreadCount) string {
+	return fmt.Sprintf(" 📧 %s (%d unread / %d) ", name, c.Unread, c.Total)
+}
+
+// viewedCounterLabel is the label the list shows: INBOX for the plain list, or the folder of a
+// single-term query such as in:sent or label:Projects; "" for any other view
+func (a *App) viewedCounterLabel() string {
+	if a.search.Mode() == "local" {
+		return ""
+	}
+	q := strings.TrimSpace(a.search.Query())
+	if q == "" {
+		return "INBOX"
+	}
+	if id, ok := counterQueryLabels[strings.ToLower(q)]; ok {
+		return id
+	}
+	if name, ok := strings.CutPrefix(q, "label:"); ok {
+		// Unquoted names can't hold spaces, or the query has more than one term
+		quoted := len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"'
+		if quoted {
+			name = name[1 : len(name)-1]
+		}
+		if !strings.Contains(name, `"`) && (quoted || !strings.Contains(name, " ")) {
+			return a.counterLabelID(name)
+		}
+	}
+	return ""
+}
+
+// counterLabelID resolves a label name (or ID) to its ID from the label catalog; Gmail queries
+// write spaces in names as dashes, so those match too
+func (a *App) counterLabelID(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	if _, ok := counterFolderNames[strings.ToUpper(name)]; ok {
+		return strings.ToUpper(name)
+	}
+	if a.labelCatalog == nil {
+		return ""
+	}
+	for id, n := range a.labelCatalog.NameMap() {
+		if id == name || strings.EqualFold(n, name) || strings.EqualFold(strings.ReplaceAll(n, " ", "-"), name) {
+			return id
+		}
+	}
+	return ""
+}
+
+// counterLabelName is the display name of a counted label
+func (a *App) counterLabelName(id string) string {
+	if name, ok := counterFolderNames[id]; ok {
+		return name
+	}
+	if a.labelCatalog != nil {
+		if name := a.labelCatalog.Name(id); name != "" {
+			return name
+		}
+	}
+	return id
+}
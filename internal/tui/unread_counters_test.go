@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ajramos/giztui/internal/services"
+)
+
+func TestViewedCounterLabel(t *testing.T) {
+	a := &App{}
+	cases := map[string]string{
+		"":                     "INBOX",
+		"in:sent":              "SENT",
+		"IS:starred":           "STARRED",
+		"label:inbox":          "INBOX",
+		"from:bob in:inbox":    "",
+		`label:"two words" x`:  "",
+		"label:projects later": "",
+	}
+	for q, want := range cases {
+		a.search.SetQuery(q)
+		if got := a.viewedCounterLabel(); got != want {
+			t.Errorf("viewedCounterLabel(%q) = %q, want %q", q, got, want)
+		}
+	}
+
+	a.search.SetQuery("")
+	a.search.SetMode("local")
+	if got := a.viewedCounterLabel(); got != "" {
+		t.Errorf("a local filter has no folder count, got %q", got)
+	}
+}
+
+func TestFormatCounterTitle(t *testing.T) {
+	got := formatCounterTitle("Inbox", services.UnreadCount{Unread: 12, Total: 243})
+	if got != " 📧 Inbox (12 unread / 243) " {
+		t.Fatalf("title = %q", got)
+	}
+}
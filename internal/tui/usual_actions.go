@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/ajramos/giztui/internal/db"
+	"github.com/ajramos/giztui/internal/render"
+	"github.com/ajramos/giztui/internal/services"
+)
+
+// usualSuggestion is the usual actions suggested for the selected message
+type usualSuggestion struct {
+	messageID string
+	actions   *services.UsualActions
+}
+
+// bindUsualActions (re)creates the usual actions service for the active account and has the undo
+// service log every message action to it
+func (a *App) bindUsualActions() {
+	a.usualSuggestion.Store(nil)
+	if a.dbStore == nil || a.Config == nil || !a.Config.UsualActions.Enabled {
+		a.usualActionsService = nil
+		return
+	}
+	svc := services.NewUsualActionsService(db.NewActionLogStore(a.dbStore), a.Config.UsualActions.MinRepeats)
+	svc.SetAccountEmail(a.getActiveAccountEmail())
+	svc.SetSenderResolver(a.cachedSenderAddress)
+	svc.SetLogger(a.logger)
+	a.usualActionsService = svc
+	if undo, ok := a.undoService.(*services.UndoServiceImpl); ok {
+		undo.SetActionRecorder(svc)
+	}
+}
+
+// cachedSenderAddress returns the sender address of a message already loaded, without fetching it
+func (a *App) cachedSenderAddress(id string) string {
+	if m, ok := a.caches.messageGet(id); ok && m.From != "" {
+		return render.SenderAddress(m.From)
+	}
+	return ""
+}
+
+// suggestUsualActions looks up the habit for the sender of the selected message and suggests it
+// in the status bar. Runs off the UI goroutine.
+func (a *App) suggestUsualActions(messageID string) {
+	if a.usualActionsService == nil || a.Keys.UsualActions == "" {
+		return
+	}
+	a.usualSuggestion.Store(nil)
+	sender := a.currentSenderAddress(messageID)
+	if sender == "" {
+		return
+	}
+	usual, err := a.usualActionsService.Suggest(a.ctx, sender)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("usual actions: %v", err)
+		}
+		return
+	}
+	if usual == nil || a.GetCurrentMessageID() != messageID {
+		return
+	}
+	a.usualSuggestion.Store(&usualSuggestion{messageID: messageID, actions: usual})
+	a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("💡 Usual for %s (%d of last %d): %s — press %s",
+		sender, usual.Times, usual.Of, usual.Describe(a.counterLabelName), a.Keys.UsualActions))
+}
+
+// applyUsualActions applies the suggested usual actions to the selected message: its labels
+// first, then archive or trash
+func (a *App) applyUsualActions() {
+	messageID := a.getCurrentSelectedMessageID()
+	s := a.usualSuggestion.Load()
+	if s == nil || s.messageID != messageID {
+		a.GetErrorHandler().ShowInfo(a.ctx, "💡 Nothing usual to do for this message yet")
+		return
+	}
+	if a.blockForeignMessages(messageID) {
+		return
+	}
+	a.usualSuggestion.Store(nil)
+	usual := s.actions
+
+	_, _, labelService, _, _, _, _, _, _, _, _, _ := a.GetServices()
+	if labelService == nil {
+		a.GetErrorHandler().ShowError(a.ctx, "Label service not available")
+		return
+	}
+	for _, id := range usual.AddLabels {
+		if err := labelService.ApplyLabel(a.ctx, messageID, id); err != nil {
+			a.showErrorFor("Applying the usual labels", err)
+			return
+		}
+		a.updateCachedMessageLabels(messageID, id, true)
+		a.updateMessageCacheLabels(messageID, a.counterLabelName(id), true)
+	}
+	for _, id := range usual.RemoveLabels {
+		if err := labelService.RemoveLabel(a.ctx, messageID, id); err != nil {
+			a.showErrorFor("Removing the usual labels", err)
+			return
+		}
+		a.updateCachedMessageLabels(messageID, id, false)
+		a.updateMessageCacheLabels(messageID, a.counterLabelName(id), false)
+	}
+
+	switch {
+	case usual.Trash:
+		a.trashSelected()
+	case usual.Archive:
+		a.archiveSelected()
+	default:
+		a.QueueUpdateDraw(func() {
+			a.refreshMessageContent(messageID)
+			a.reformatListItems()
+		})
+		a.GetErrorHandler().ShowSuccess(a.ctx, "💡 Done: "+usual.Describe(a.counterLabelName))
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajramos/giztui/internal/config"
+	"github.com/ajramos/giztui/internal/services"
+	gmailapi "google.golang.org/api/gmail/v1"
+)
+
+// archivesWorkspaceNotifications reports whether any kind of Workspace notification is set to be
+// archived on arrival
+func (a *App) archivesWorkspaceNotifications() bool {
+	for _, kind := range services.WorkspaceKinds {
+		if a.Config.WorkspaceNotifications.Action(kind) == config.WorkspaceActionArchive {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveNewWorkspaceNotifications archives the new messages found by a background refresh that
+// are Chat, Meet or Drive comment notifications set to "archive", and returns the other IDs
+func (a *App) archiveNewWorkspaceNotifications(newIDs []string) []string {
+	if a.Client == nil || len(newIDs) == 0 || !a.archivesWorkspaceNotifications() {
+		return newIDs
+	}
+	metas, err := a.Client.GetMessagesMetadataParallel(newIDs, 10)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Printf("workspace notifications: metadata fetch failed: %v", err)
+		}
+		return newIDs
+	}
+	archive := services.WorkspaceToArchive(a.Config.WorkspaceNotifications, metas)
+	if len(archive) == 0 {
+		return newIDs
+	}
+	emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
+	if err := emailService.BulkArchive(a.ctx, archive); err != nil {
+		if a.logger != nil {
+			a.logger.Printf("workspace notifications: archive failed: %v", err)
+		}
+		return newIDs
+	}
+	skip := make(map[string]bool, len(archive))
+	for _, id := range archive {
+		skip[id] = true
+	}
+	rest := make([]string, 0, len(newIDs)-len(archive))
+	for _, id := range newIDs {
+		if !skip[id] {
+			rest = append(rest, id)
+		}
+	}
+	if !a.dndActive() {
+		a.GetErrorHandler().ShowInfo(a.ctx, fmt.Sprintf("💬 Archived %d Workspace notification(s) on arrival", len(archive)))
+	}
+	return rest
+}
+
+// executeWorkspaceCommand handles :workspace [archive] — count the Google Chat, Meet and Drive
+// comment notifications in the loaded list, or archive them all
+func (a *App) executeWorkspaceCommand(args []string) {
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	if sub != "" && sub != "archive" {
+		a.showError("Usage: workspace [archive]")
+		return
+	}
+	a.mu.RLock()
+	meta := append([]*gmailapi.Message(nil), a.messagesMeta...)
+	a.mu.RUnlock()
+	byKind := services.WorkspaceNotificationIDs(meta)
+	if len(byKind) == 0 {
+		a.showInfo("💬 No Google Chat, Meet or Drive comment notifications in the loaded messages")
+		return
+	}
+	if sub == "" {
+		a.showInfo("💬 " + formatWorkspaceCounts(byKind) + " — :workspace archive archives them, :alerts groups them")
+		return
+	}
+
+	var ids []string
+	for _, kind := range services.WorkspaceKinds {
+		ids = append(ids, byKind[kind]...)
+	}
+	if a.blockForeignMessages(ids...) {
+		return
+	}
+	go func() {
+		emailService, _, _, _, _, _, _, _, _, _, _, _ := a.GetServices()
+		err := emailService.BulkArchive(a.ctx, ids, a.bulkProgress(a.ctx, "Archiving"))
+		a.GetErrorHandler().ClearProgress()
+		if err != nil {
+			a.GetErrorHandler().ShowErrorFor(a.ctx, "Error archiving notifications", err)
+			return
+		}
+		a.QueueUpdateDraw(func() { a.removeIDsFromCurrentList(ids) })
+		a.GetErrorHandler().ShowSuccess(a.ctx, fmt.Sprintf("💬 Archived %s", formatWorkspaceCounts(byKind)))
+	}()
+}
+
+// formatWorkspaceCounts summarizes notification IDs by kind, e.g. "12 Google Chat, 3 Drive comments"
+func formatWorkspaceCounts(byKind map[string][]string) string {
+	var parts []string
+	for _, kind := range services.WorkspaceKinds {
+		if n := len(byKind[kind]); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, services.WorkspaceKindName(kind)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
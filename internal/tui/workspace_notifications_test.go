@@ -0,0 +1,10 @@
+package tui
+
+import "testing"
+
+func TestFormatWorkspaceCounts(t *testing.T) {
+	got := formatWorkspaceCounts(map[string][]string{"drive": {"d1"}, "chat": {"c1", "c2"}})
+	if got != "2 Google Chat, 1 Drive comments" {
+		t.Fatalf("counts = %q", got)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -23,6 +25,16 @@ type OAuth2Config struct {
 	TokenPath       string
 	Scopes          []string
 	AccountName     string // Optional account name for better user messaging
+
+	// granted holds the scopes the saved token was granted; nil when unknown (tokens saved
+	// before they were recorded)
+	granted []string
+}
+
+// storedToken is the token file: the OAuth token and the scopes it was granted
+type storedToken struct {
+	*oauth2.Token
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // NewOAuth2Config creates a new OAuth2 configuration
@@ -67,9 +79,10 @@ func (c *OAuth2Config) LoadToken(config *oauth2.Config) (*oauth2.Token, error) {
 		}
 	}()
 
-	token := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(token)
-	return token, err
+	stored := storedToken{Token: &oauth2.Token{}}
+	err = json.NewDecoder(f).Decode(&stored)
+	c.granted = stored.Scopes
+	return stored.Token, err
 }
 
 // SaveToken saves token to file
@@ -92,7 +105,7 @@ func (c *OAuth2Config) SaveToken(token *oauth2.Token) error {
 	}()
 
 	// #nosec G117 -- persisting the OAuth token to disk is the intended behaviour; the file is created with 0600 perms above to keep it private to the user.
-	return json.NewEncoder(f).Encode(token)
+	return json.NewEncoder(f).Encode(storedToken{Token: token, Scopes: c.granted})
 }
 
 // GetToken retrieves a token, refreshing if necessary
@@ -110,6 +123,12 @@ func (c *OAuth2Config) GetToken(ctx context.Context) (*oauth2.Token, error) {
 		if err != nil {
 			return nil, err
 		}
+	} else if missing := c.missingScopes(config.Scopes); len(missing) > 0 {
+		// Sign-in asks for more than the saved token was granted (access mode widened)
+		token, err = c.authenticate(ctx, c.withScopes(config, missing))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Refresh token if needed
@@ -122,7 +141,7 @@ func (c *OAuth2Config) GetToken(ctx context.Context) (*oauth2.Token, error) {
 				// Refresh token is invalid, need to re-authenticate
 				fmt.Println("\n⚠️  Your Gmail access token has expired or been revoked.")
 				fmt.Println("🔐 Re-authentication is required to continue using Gmail TUI.")
-				token, err = c.authenticate(ctx, config)
+				token, err = c.authenticate(ctx, c.withScopes(config, nil))
 				if err != nil {
 					return nil, fmt.Errorf("re-authentication failed: %w", err)
 				}
@@ -141,6 +160,44 @@ func (c *OAuth2Config) GetToken(ctx context.Context) (*oauth2.Token, error) {
 	return token, nil
 }
 
+// missingScopes returns the scopes among wanted the saved token was not granted; none when the
+// granted scopes are unknown
+func (c *OAuth2Config) missingScopes(wanted []string) []string {
+	if c.granted == nil {
+		return nil
+	}
+	var missing []string
+	for _, s := range wanted {
+		if !slices.Contains(c.granted, s) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// withScopes returns config asking for the scopes already granted plus extra, so a new consent
+// never drops a permission the app had
+func (c *OAuth2Config) withScopes(config *oauth2.Config, extra []string) *oauth2.Config {
+	scopes := slices.Clone(config.Scopes)
+	for _, s := range append(slices.Clone(c.granted), extra...) {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	widened := *config
+	widened.Scopes = scopes
+	return &widened
+}
+
+// grantedScopes returns the scopes of a token from the authorization server, falling back to the
+// requested ones when the response does not list them
+func grantedScopes(token *oauth2.Token, requested []string) []string {
+	if s, ok := token.Extra("scope").(string); ok && strings.TrimSpace(s) != "" {
+		return strings.Fields(s)
+	}
+	return slices.Clone(requested)
+}
+
 // authenticate performs OAuth2 authentication with local server
 func (c *OAuth2Config) authenticate(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
 	// Create a local server to capture the authorization code
@@ -197,7 +254,14 @@ func (c *OAuth2Config) authenticate(ctx context.Context, config *oauth2.Config)
 		Endpoint:     config.Endpoint,
 	}
 
-	authURL := localConfig.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	missing := c.missingScopes(config.Scopes)
+	authURL := authCodeURL(localConfig, missing)
+	if prompt := currentConsentPrompt(); prompt != nil {
+		done := prompt(c.AccountName, missing, authURL)
+		token, err := c.exchange(ctx, server, localConfig, codeChan, errorChan)
+		done(err)
+		return token, err
+	}
 	if c.AccountName != "" {
 		fmt.Printf("\n🔐 Authorization required for account: %s\n", c.AccountName)
 	} else {
@@ -212,6 +276,29 @@ func (c *OAuth2Config) authenticate(ctx context.Context, config *oauth2.Config)
 		fmt.Printf("\nWaiting for authorization...\n")
 	}
 
+	token, err := c.exchange(ctx, server, localConfig, codeChan, errorChan)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("✅ Authorization successful!\n")
+	return token, nil
+}
+
+// authCodeURL returns the consent page URL. The full consent screen is forced only when missing
+// holds scopes the account has not granted yet, so a feature's first use shows what it adds while
+// a routine re-authorization goes straight through.
+func authCodeURL(config *oauth2.Config, missing []string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("include_granted_scopes", "true")}
+	if len(missing) > 0 {
+		opts = append(opts, oauth2.ApprovalForce)
+	}
+	return config.AuthCodeURL("state-token", opts...)
+}
+
+// exchange waits for the authorization code on the local server and exchanges it for a token,
+// recording the scopes it was granted
+func (c *OAuth2Config) exchange(ctx context.Context, server *http.Server, localConfig *oauth2.Config, codeChan <-chan string, errorChan <-chan error) (*oauth2.Token, error) {
 	// Wait for authorization code
 	var authCode string
 	select {
@@ -233,8 +320,7 @@ func (c *OAuth2Config) authenticate(ctx context.Context, config *oauth2.Config)
 	if err != nil {
 		return nil, fmt.Errorf("could not exchange authorization code for token: %w", err)
 	}
-
-	fmt.Printf("✅ Authorization successful!\n")
+	c.granted = grantedScopes(token, localConfig.Scopes)
 	return token, nil
 }
 
@@ -271,7 +357,7 @@ func NewGmailServiceWithAccount(ctx context.Context, credentialsPath, tokenPath
 		return nil, err
 	}
 
-	httpClient := config.Client(ctx, token)
+	httpClient := newScopedClient(ctx, oauthConfig, config, token, GmailRequestFeature)
 
 	service, err := gmail.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
@@ -295,7 +381,7 @@ func NewCalendarService(ctx context.Context, credentialsPath, tokenPath string,
 		return nil, err
 	}
 
-	httpClient := config.Client(ctx, token)
+	httpClient := newScopedClient(ctx, oauthConfig, config, token, func(*http.Request) string { return FeatureCalendar })
 
 	service, err := calapi.NewService(ctx, option.WithHTTPClient(httpClient))
 	if err != nil {
@@ -304,3 +390,73 @@ func NewCalendarService(ctx context.Context, credentialsPath, tokenPath string,
 
 	return service, nil
 }
+
+// scopedTransport authorizes API requests with the account's token. When a request belongs to a
+// feature the token was not granted a scope for, it first asks for that scope (incremental
+// consent) and sends the request with the new token.
+type scopedTransport struct {
+	ctx     context.Context
+	auth    *OAuth2Config
+	config  *oauth2.Config
+	feature func(*http.Request) string
+
+	mu      sync.Mutex // guards source and auth.granted
+	source  oauth2.TokenSource
+	grantMu sync.Mutex // one consent at a time
+}
+
+// newScopedClient returns an HTTP client for API calls authorized with token
+func newScopedClient(ctx context.Context, auth *OAuth2Config, config *oauth2.Config, token *oauth2.Token, feature func(*http.Request) string) *http.Client {
+	return &http.Client{Transport: &scopedTransport{
+		ctx:     ctx,
+		auth:    auth,
+		config:  config,
+		feature: feature,
+		source:  config.TokenSource(ctx, token),
+	}}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *scopedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	source, err := t.sourceFor(req.Context(), t.feature(req))
+	if err != nil {
+		return nil, err
+	}
+	return (&oauth2.Transport{Source: source}).RoundTrip(req)
+}
+
+// sourceFor returns the token source for a feature, asking for its scope first when missing
+func (t *scopedTransport) sourceFor(ctx context.Context, feature string) (oauth2.TokenSource, error) {
+	if source, ok := t.allowed(feature); ok {
+		return source, nil
+	}
+	t.grantMu.Lock()
+	defer t.grantMu.Unlock()
+	if source, ok := t.allowed(feature); ok {
+		return source, nil // granted by a request that was waiting before this one
+	}
+
+	t.mu.Lock()
+	consent := *t.auth
+	t.mu.Unlock()
+	token, err := consent.authenticate(ctx, consent.withScopes(t.config, ScopesFor(feature)))
+	if err != nil {
+		return nil, fmt.Errorf("%s permission (%s) not granted: %w", feature, ScopeName(ScopesFor(feature)[0]), err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.auth.granted = consent.granted
+	t.source = t.config.TokenSource(t.ctx, token)
+	if err := t.auth.SaveToken(token); err != nil {
+		return nil, err
+	}
+	return t.source, nil
+}
+
+// allowed returns the token source when the token's scopes allow feature
+func (t *scopedTransport) allowed(feature string) (oauth2.TokenSource, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.source, Allows(t.auth.granted, feature)
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Google OAuth scopes the app uses
+const (
+	ScopeGmailReadonly  = "https://www.googleapis.com/auth/gmail.readonly"
+	ScopeGmailModify    = "https://www.googleapis.com/auth/gmail.modify"
+	ScopeGmailSend      = "https://www.googleapis.com/auth/gmail.send"
+	ScopeGmailCompose   = "https://www.googleapis.com/auth/gmail.compose"
+	ScopeCalendarEvents = "https://www.googleapis.com/auth/calendar.events"
+)
+
+// Features are the groups of API calls that need a permission of their own
+const (
+	FeatureRead     = "read"     // list, search and open messages
+	FeatureModify   = "modify"   // labels, archive, trash, mark read
+	FeatureSend     = "send"     // send, reply, forward
+	FeatureDrafts   = "drafts"   // save and edit drafts
+	FeatureCalendar = "calendar" // RSVP to invitations
+)
+
+// featureScopes lists, per feature, the scope requested for it followed by broader scopes that
+// also allow it
+var featureScopes = map[string][]string{
+	FeatureRead:     {ScopeGmailReadonly, ScopeGmailModify},
+	FeatureModify:   {ScopeGmailModify},
+	FeatureSend:     {ScopeGmailSend, ScopeGmailCompose, ScopeGmailModify},
+	FeatureDrafts:   {ScopeGmailCompose, ScopeGmailModify},
+	FeatureCalendar: {ScopeCalendarEvents},
+}
+
+// Features returns every feature, in the order their scopes are requested
+func Features() []string {
+	return []string{FeatureRead, FeatureModify, FeatureSend, FeatureDrafts, FeatureCalendar}
+}
+
+// ScopesFor returns the scopes to request for features, without duplicates. Unknown features
+// are ignored.
+func ScopesFor(features ...string) []string {
+	var scopes []string
+	for _, f := range features {
+		if s, ok := featureScopes[strings.ToLower(strings.TrimSpace(f))]; ok && !slices.Contains(scopes, s[0]) {
+			scopes = append(scopes, s[0])
+		}
+	}
+	return scopes
+}
+
+// Allows reports whether granted scopes permit a feature. Nil granted means the scopes are not
+// known (a token saved before they were recorded) and allows everything.
+func Allows(granted []string, feature string) bool {
+	if granted == nil {
+		return true
+	}
+	for _, s := range featureScopes[feature] {
+		if slices.Contains(granted, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeName returns the short name of a scope, e.g. "gmail.modify"
+func ScopeName(scope string) string {
+	return scope[strings.LastIndex(scope, "/")+1:]
+}
+
+// GmailRequestFeature returns the feature a Gmail API request belongs to
+func GmailRequestFeature(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/send"):
+		return FeatureSend
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return FeatureRead
+	case strings.Contains(path, "/drafts"):
+		return FeatureDrafts
+	default:
+		return FeatureModify
+	}
+}
+
+// ConsentPrompt shows the authorization page of a consent asked for while the app runs, for the
+// scopes it adds; the returned func is called with the outcome. Without one, the link is printed
+// to the terminal.
+type ConsentPrompt func(account string, scopes []string, authURL string) (done func(err error))
+
+var (
+	consentMu     sync.RWMutex
+	consentPrompt ConsentPrompt
+)
+
+// SetConsentPrompt sets how authorization links are shown; nil prints them to the terminal.
+// The TUI sets one while it owns the screen.
+func SetConsentPrompt(p ConsentPrompt) {
+	consentMu.Lock()
+	defer consentMu.Unlock()
+	consentPrompt = p
+}
+
+// currentConsentPrompt returns the prompt set by SetConsentPrompt
+func currentConsentPrompt() ConsentPrompt {
+	consentMu.RLock()
+	defer consentMu.RUnlock()
+	return consentPrompt
+}
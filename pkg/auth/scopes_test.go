@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestScopesFor(t *testing.T) {
+	assert.Equal(t, []string{ScopeGmailReadonly}, ScopesFor(FeatureRead))
+	assert.Equal(t, []string{ScopeGmailReadonly, ScopeGmailModify, ScopeCalendarEvents},
+		ScopesFor("read", " Modify ", "unknown", "calendar", "read"))
+	assert.Len(t, ScopesFor(Features()...), 5)
+}
+
+func TestAllows(t *testing.T) {
+	assert.True(t, Allows(nil, FeatureCalendar), "unknown scopes allow everything")
+
+	readonly := []string{ScopeGmailReadonly}
+	assert.True(t, Allows(readonly, FeatureRead))
+	assert.False(t, Allows(readonly, FeatureModify))
+	assert.False(t, Allows(readonly, FeatureSend))
+
+	modify := []string{ScopeGmailModify}
+	assert.True(t, Allows(modify, FeatureRead))
+	assert.True(t, Allows(modify, FeatureSend))
+	assert.True(t, Allows(modify, FeatureDrafts))
+	assert.False(t, Allows(modify, FeatureCalendar))
+}
+
+func TestGmailRequestFeature(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/gmail/v1/users/me/messages", FeatureRead},
+		{"GET", "/gmail/v1/users/me/drafts/d1", FeatureRead},
+		{"POST", "/gmail/v1/users/me/messages/m1/modify", FeatureModify},
+		{"POST", "/gmail/v1/users/me/messages/batchModify", FeatureModify},
+		{"POST", "/gmail/v1/users/me/messages/m1/trash", FeatureModify},
+		{"POST", "/upload/gmail/v1/users/me/messages/send", FeatureSend},
+		{"POST", "/gmail/v1/users/me/drafts/send", FeatureSend},
+		{"PUT", "/gmail/v1/users/me/drafts/d1", FeatureDrafts},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, "https://gmail.googleapis.com"+tt.path, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, GmailRequestFeature(req), "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestOAuth2Config_GrantedScopesRoundTrip(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token.json")
+	saved := &OAuth2Config{TokenPath: tokenPath, granted: []string{ScopeGmailReadonly}}
+	assert.NoError(t, saved.SaveToken(&oauth2.Token{AccessToken: "a", RefreshToken: "r"}))
+
+	loaded := &OAuth2Config{TokenPath: tokenPath}
+	token, err := loaded.LoadToken(&oauth2.Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, "r", token.RefreshToken)
+	assert.Equal(t, []string{ScopeGmailReadonly}, loaded.granted)
+	assert.Equal(t, []string{ScopeGmailModify}, loaded.missingScopes([]string{ScopeGmailReadonly, ScopeGmailModify}))
+
+	widened := loaded.withScopes(&oauth2.Config{Scopes: []string{ScopeCalendarEvents}}, []string{ScopeGmailModify})
+	assert.Equal(t, []string{ScopeCalendarEvents, ScopeGmailReadonly, ScopeGmailModify}, widened.Scopes)
+}
+
+func TestOAuth2Config_LegacyTokenScopesUnknown(t *testing.T) {
+	c := &OAuth2Config{}
+	assert.Nil(t, c.missingScopes([]string{ScopeGmailModify}), "tokens without recorded scopes are not re-consented")
+}
+
+func TestAuthCodeURL_ForcesConsentOnlyForNewScopes(t *testing.T) {
+	config := &oauth2.Config{ClientID: "id", Endpoint: oauth2.Endpoint{AuthURL: "https://accounts.example/auth"}}
+
+	reauth := authCodeURL(config, nil)
+	assert.NotContains(t, reauth, "prompt=consent", "routine re-authorization skips the full consent screen")
+	assert.Contains(t, reauth, "access_type=offline")
+	assert.Contains(t, reauth, "include_granted_scopes=true")
+
+	assert.Contains(t, authCodeURL(config, []string{ScopeCalendarEvents}), "prompt=consent")
+}